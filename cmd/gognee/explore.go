@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dan-solli/gognee/pkg/gognee"
+	"github.com/dan-solli/gognee/pkg/search"
+)
+
+const exploreHelp = `Commands:
+  search <query>   Search the graph (hybrid)
+  node <id>        Show a node with its edges, memories and source documents
+  memory <id>      Show a memory with the nodes and edges derived from it
+  <n>              Open item n of the last listing
+  back             Return to the previous node or memory
+  help             Show this help
+  quit             Leave the explorer
+`
+
+// exploreTarget is a node or memory the explorer can open.
+type exploreTarget struct {
+	kind string // "node" or "memory"
+	id   string
+}
+
+// explorer is an interactive line-based browser of the graph. Every listing numbers
+// the nodes and memories it shows, so they can be opened by number.
+type explorer struct {
+	g       *gognee.Gognee
+	out     io.Writer
+	items   []exploreTarget // Numbered items of the last listing
+	history []exploreTarget // Opened nodes and memories, for back
+}
+
+func (c *command) explore(ctx context.Context, args []string) error {
+	if _, err := parseFlags(flag.NewFlagSet("explore", flag.ContinueOnError), args); err != nil {
+		return err
+	}
+	e := &explorer{g: c.g, out: c.stdout}
+	return e.run(ctx, c.stdin)
+}
+
+// run reads commands from in until quit or end of input.
+func (e *explorer) run(ctx context.Context, in io.Reader) error {
+	fmt.Fprint(e.out, "gognee explorer; type help for commands\n")
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(e.out, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(e.out)
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "quit" || line == "exit" || line == "q" {
+			return nil
+		}
+		if err := e.handle(ctx, line); err != nil {
+			fmt.Fprintf(e.out, "error: %v\n", err)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// handle executes one explorer command.
+func (e *explorer) handle(ctx context.Context, line string) error {
+	cmd, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	if n, err := strconv.Atoi(cmd); err == nil {
+		if n < 1 || n > len(e.items) {
+			return fmt.Errorf("no item %d in the last listing", n)
+		}
+		return e.open(ctx, e.items[n-1])
+	}
+
+	switch cmd {
+	case "":
+		return nil
+	case "help", "?":
+		fmt.Fprint(e.out, exploreHelp)
+		return nil
+	case "search", "s":
+		if arg == "" {
+			return fmt.Errorf("search needs a query")
+		}
+		return e.search(ctx, arg)
+	case "node", "n":
+		if arg == "" {
+			return fmt.Errorf("node needs a node ID")
+		}
+		return e.open(ctx, exploreTarget{"node", arg})
+	case "memory", "m":
+		if arg == "" {
+			return fmt.Errorf("memory needs a memory ID")
+		}
+		return e.open(ctx, exploreTarget{"memory", arg})
+	case "back", "b":
+		if len(e.history) < 2 {
+			return fmt.Errorf("nothing to go back to")
+		}
+		previous := e.history[len(e.history)-2]
+		e.history = e.history[:len(e.history)-2]
+		return e.open(ctx, previous)
+	default:
+		return fmt.Errorf("unknown command %q; type help for commands", cmd)
+	}
+}
+
+// open shows a node or memory and records it in the history.
+func (e *explorer) open(ctx context.Context, target exploreTarget) error {
+	var err error
+	if target.kind == "node" {
+		err = e.showNode(ctx, target.id)
+	} else {
+		err = e.showMemory(ctx, target.id)
+	}
+	if err != nil {
+		return err
+	}
+	e.history = append(e.history, target)
+	return nil
+}
+
+// item numbers target in the current listing and returns its label
+func (e *explorer) item(target exploreTarget) string {
+	e.items = append(e.items, target)
+	return fmt.Sprintf("%3d.", len(e.items))
+}
+
+func (e *explorer) search(ctx context.Context, query string) error {
+	response, err := e.g.Search(ctx, query, search.SearchOptions{Type: search.SearchTypeHybrid, TopK: 20, GraphDepth: 1})
+	if err != nil {
+		return err
+	}
+	e.items = nil
+	if len(response.Results) == 0 {
+		fmt.Fprintln(e.out, "No results")
+		return nil
+	}
+	for _, result := range response.Results {
+		if result.Node == nil {
+			continue
+		}
+		fmt.Fprintf(e.out, "%s %s (%s)  score %.3f via %s\n", e.item(exploreTarget{"node", result.NodeID}),
+			result.Node.Name, result.Node.Type, result.Score, result.Source)
+	}
+	return nil
+}
+
+func (e *explorer) showNode(ctx context.Context, id string) error {
+	details, err := e.g.NodeDetails(ctx, id)
+	if err != nil {
+		return err
+	}
+	e.items = nil
+	node := details.Node
+	fmt.Fprintf(e.out, "\nNode %s (%s)\n  id: %s\n", node.Name, node.Type, node.ID)
+	if node.Description != "" {
+		fmt.Fprintf(e.out, "  %s\n", node.Description)
+	}
+	fmt.Fprintf(e.out, "  created %s, mentioned in %d chunk(s)", node.CreatedAt.Format("2006-01-02 15:04"), node.MentionCount)
+	if node.Pinned {
+		fmt.Fprintf(e.out, ", pinned (%s)", node.PinReason)
+	}
+	fmt.Fprintln(e.out)
+
+	fmt.Fprintf(e.out, "Edges (%d):\n", len(details.Edges))
+	for _, edge := range details.Edges {
+		neighbor := "(deleted node)"
+		label := "    "
+		if edge.Neighbor != nil {
+			neighbor = fmt.Sprintf("%s (%s)", edge.Neighbor.Name, edge.Neighbor.Type)
+			label = e.item(exploreTarget{"node", edge.Neighbor.ID})
+		}
+		if edge.Outgoing {
+			fmt.Fprintf(e.out, "%s -%s-> %s\n", label, edge.Edge.Relation, neighbor)
+		} else {
+			fmt.Fprintf(e.out, "%s <-%s- %s\n", label, edge.Edge.Relation, neighbor)
+		}
+		if edge.Edge.Evidence != "" {
+			fmt.Fprintf(e.out, "       evidence: %q\n", edge.Edge.Evidence)
+		}
+	}
+
+	fmt.Fprintf(e.out, "Memories (%d):\n", len(details.Memories))
+	for _, memory := range details.Memories {
+		fmt.Fprintf(e.out, "%s %s [%s]\n", e.item(exploreTarget{"memory", memory.ID}), memory.Topic, memory.Status)
+	}
+	fmt.Fprintf(e.out, "Documents (%d):\n", len(details.Documents))
+	for _, doc := range details.Documents {
+		fmt.Fprintf(e.out, "     %.12s  %s  added %s\n", doc.Hash, doc.Source, doc.AddedAt.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func (e *explorer) showMemory(ctx context.Context, id string) error {
+	details, err := e.g.MemoryDetails(ctx, id)
+	if err != nil {
+		return err
+	}
+	e.items = nil
+	memory := details.Memory
+	fmt.Fprintf(e.out, "\nMemory %s\n  id: %s\n  status %s, version %d, retention %s, score %.3f\n",
+		memory.Topic, memory.ID, memory.Status, memory.Version, memory.RetentionPolicy, memory.CurrentScore)
+	fmt.Fprintf(e.out, "  %s\n", memory.Context)
+	for _, decision := range memory.Decisions {
+		fmt.Fprintf(e.out, "  decision: %s\n", decision)
+	}
+	for _, rationale := range memory.Rationale {
+		fmt.Fprintf(e.out, "  rationale: %s\n", rationale)
+	}
+
+	names := make(map[string]string, len(details.Nodes))
+	fmt.Fprintf(e.out, "Nodes (%d):\n", len(details.Nodes))
+	for _, node := range details.Nodes {
+		names[node.ID] = node.Name
+		fmt.Fprintf(e.out, "%s %s (%s)\n", e.item(exploreTarget{"node", node.ID}), node.Name, node.Type)
+	}
+	fmt.Fprintf(e.out, "Edges (%d):\n", len(details.Edges))
+	for _, edge := range details.Edges {
+		fmt.Fprintf(e.out, "     %s -%s-> %s\n", nameOr(names, edge.SourceID), edge.Relation, nameOr(names, edge.TargetID))
+	}
+	if len(details.Supersessions) > 0 {
+		fmt.Fprintln(e.out, "Supersessions:")
+		for _, record := range details.Supersessions {
+			superseded := e.item(exploreTarget{"memory", record.SupersededID})
+			superseding := e.item(exploreTarget{"memory", record.SupersedingID})
+			fmt.Fprintf(e.out, "%s %s superseded by\n%s %s", superseded, record.SupersededID, superseding, record.SupersedingID)
+			if record.Reason != "" {
+				fmt.Fprintf(e.out, " (%s)", record.Reason)
+			}
+			fmt.Fprintln(e.out)
+		}
+	}
+	return nil
+}
+
+// nameOr returns the name recorded for id, or id itself
+func nameOr(names map[string]string, id string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return id
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCLI_Explore(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "cli.db")
+	code, out, errOut := runCLI(t, dbPath, "memory", "add", "-topic", "Library", "-context", "Gognee stores knowledge.")
+	if code != 0 {
+		t.Fatalf("memory add failed (%d): %s", code, errOut)
+	}
+	memoryID := strings.TrimSpace(out)
+
+	// Search, open the first hit, then its memory, then go back to the node
+	script := "help\nsearch Gognee\n1\n1\nback\nnode missing\n7\nquit\n"
+	code, out, errOut = runCLIWithInput(t, dbPath, script, "explore")
+	if code != 0 {
+		t.Fatalf("explore failed (%d): %s", code, errOut)
+	}
+	for _, want := range []string{
+		"Commands:",
+		"1. Gognee (Technology)",
+		"Node Gognee (Technology)",
+		"Memories (1):",
+		"Memory Library",
+		"id: " + memoryID,
+		"error: node not found: missing",
+		"error: no item 7 in the last listing",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in the explorer output:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "Node Gognee (Technology)") != 2 {
+		t.Errorf("Expected back to reopen the node:\n%s", out)
+	}
+}
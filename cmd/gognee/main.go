@@ -0,0 +1,451 @@
+// Command gognee runs basic operations against a gognee database file: buffering and
+// cognifying text, searching, managing memories, reporting stats, pruning, exporting,
+// backing up and exploring the graph interactively.
+//
+// Usage:
+//
+//	gognee [global flags] <command> [flags] [args]
+//
+// The database is -db, or $GOGNEE_DB, or gognee.db. The OpenAI key is read from
+// $OPENAI_API_KEY, and a token for the -embedding-url server from
+// $GOGNEE_EMBEDDING_API_KEY; commands that call no model (stats, memory list, export,
+// ...) work without them. Data is printed as JSON, confirmations as text.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/dan-solli/gognee/pkg/gognee"
+	"github.com/dan-solli/gognee/pkg/search"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+const usage = `Usage: gognee [global flags] <command> [flags] [args]
+
+Commands:
+  add [-source s] [text...]     Add text (or stdin) for cognify
+  cognify                       Extract entities and relations from the added text
+  search [-type t] [-top-k n] [-depth n] <query>
+  memory add -topic t -context c [-decision d]... [-rationale r]... [-tag t]... [-retention p] [-no-cognify]
+  memory list [-limit n] [-offset n] [-status s] [-order-by f]
+  memory get <id>
+  memory supersede [-reason r] -topic t -context c [...] <old-id>...
+  memory pin [-reason r] <id>
+  stats                         Print graph and memory statistics
+  prune [-dry-run=false] [-max-age-days n] [-min-score f] [-superseded=false] [-superseded-age-days n]
+  export [-format graphml|dot] [-o file]
+  backup <path>
+  explore                       Browse nodes, edges, memories and provenance interactively
+
+Global flags:
+`
+
+// errUsage reports invalid command-line arguments; run exits with status 2 for it.
+var errUsage = errors.New("invalid usage")
+
+// open creates the Gognee instance for a command; tests replace it to inject clients.
+var open = gognee.New
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the process exit status.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("gognee", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	dbPath := os.Getenv("GOGNEE_DB")
+	if dbPath == "" {
+		dbPath = "gognee.db"
+	}
+	cfg := gognee.Config{
+		OpenAIKey:       os.Getenv("OPENAI_API_KEY"),
+		EmbeddingAPIKey: os.Getenv("GOGNEE_EMBEDDING_API_KEY"),
+	}
+	fs.StringVar(&cfg.DBPath, "db", dbPath, "database file")
+	fs.StringVar(&cfg.Namespace, "namespace", "", "namespace to operate in")
+	fs.StringVar(&cfg.LLMModel, "llm-model", "", "LLM model (default: gpt-4o-mini)")
+	fs.StringVar(&cfg.EmbeddingModel, "embedding-model", "", "embedding model (default: text-embedding-3-small)")
+	fs.StringVar(&cfg.EmbeddingBaseURL, "embedding-url", "", "base URL of a local OpenAI-compatible embedding server")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	cmd := &command{stdin: stdin, stdout: stdout}
+	handler, name, cmdArgs := cmd.lookup(fs.Args())
+	if handler == nil {
+		fmt.Fprintf(stderr, "gognee: unknown command %q\n\n", name)
+		fs.Usage()
+		return 2
+	}
+
+	g, err := open(cfg)
+	if err != nil {
+		fmt.Fprintf(stderr, "gognee: %v\n", err)
+		return 1
+	}
+	defer g.Close()
+	cmd.g = g
+
+	if err := handler(ctx, cmdArgs); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintf(stderr, "gognee %s: %v\n", name, err)
+		if errors.Is(err, errUsage) {
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
+
+// command holds what the subcommands share.
+type command struct {
+	g      *gognee.Gognee
+	stdin  io.Reader
+	stdout io.Writer
+}
+
+// lookup resolves the subcommand named by args, returning its handler (nil if unknown),
+// its full name and its arguments.
+func (c *command) lookup(args []string) (func(context.Context, []string) error, string, []string) {
+	handlers := map[string]func(context.Context, []string) error{
+		"add":              c.add,
+		"cognify":          c.cognify,
+		"search":           c.search,
+		"memory add":       c.memoryAdd,
+		"memory list":      c.memoryList,
+		"memory get":       c.memoryGet,
+		"memory supersede": c.memorySupersede,
+		"memory pin":       c.memoryPin,
+		"stats":            c.stats,
+		"prune":            c.prune,
+		"export":           c.export,
+		"backup":           c.backup,
+		"explore":          c.explore,
+	}
+	name, rest := args[0], args[1:]
+	if name == "memory" && len(rest) > 0 {
+		name, rest = name+" "+rest[0], rest[1:]
+	}
+	return handlers[name], name, rest
+}
+
+// parseFlags parses args with fs, allowing flags after positional arguments, and
+// returns the positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (l *stringList) String() string     { return strings.Join(*l, ",") }
+func (l *stringList) Set(v string) error { *l = append(*l, v); return nil }
+
+func (c *command) add(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	source := fs.String("source", "", "source label of the text")
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	text := strings.Join(rest, " ")
+	if len(rest) == 0 || text == "-" {
+		data, err := io.ReadAll(c.stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+		text = string(data)
+	}
+	if err := c.g.Add(ctx, text, gognee.AddOptions{Source: *source}); err != nil {
+		return err
+	}
+	fmt.Fprintln(c.stdout, "Added; run cognify to process it")
+	return nil
+}
+
+func (c *command) cognify(ctx context.Context, args []string) error {
+	if _, err := parseFlags(flag.NewFlagSet("cognify", flag.ContinueOnError), args); err != nil {
+		return err
+	}
+
+	// Documents added by earlier invocations wait in the ingestion queue
+	result, err := c.g.ResumeCognify(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stdout, "Processed %d document(s) (%d skipped, %d deferred): %d chunk(s), %d node(s), %d edge(s) created\n",
+		result.DocumentsProcessed, result.DocumentsSkipped, result.DocumentsDeferred,
+		result.ChunksProcessed, result.NodesCreated, result.EdgesCreated)
+	for _, err := range result.Errors {
+		fmt.Fprintf(c.stdout, "  error: %v\n", err)
+	}
+	return nil
+}
+
+// searchHit is the JSON form of a search result, without the node's embedding.
+type searchHit struct {
+	NodeID      string   `json:"node_id"`
+	Name        string   `json:"name,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Score       float64  `json:"score"`
+	Source      string   `json:"source"`
+	GraphDepth  int      `json:"graph_depth"`
+	MemoryIDs   []string `json:"memory_ids,omitempty"`
+}
+
+func (c *command) search(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	searchType := fs.String("type", string(search.SearchTypeHybrid), "vector, keyword, graph or hybrid")
+	topK := fs.Int("top-k", 10, "maximum number of results")
+	depth := fs.Int("depth", 1, "graph expansion depth")
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		return fmt.Errorf("%w: search needs a query", errUsage)
+	}
+
+	response, err := c.g.Search(ctx, strings.Join(rest, " "), search.SearchOptions{
+		Type:       search.SearchType(*searchType),
+		TopK:       *topK,
+		GraphDepth: *depth,
+	})
+	if err != nil {
+		return err
+	}
+	hits := make([]searchHit, 0, len(response.Results))
+	for _, result := range response.Results {
+		hit := searchHit{NodeID: result.NodeID, Score: result.Score, Source: result.Source,
+			GraphDepth: result.GraphDepth, MemoryIDs: result.MemoryIDs}
+		if result.Node != nil {
+			hit.Name, hit.Type, hit.Description = result.Node.Name, result.Node.Type, result.Node.Description
+		}
+		hits = append(hits, hit)
+	}
+	return c.writeJSON(hits)
+}
+
+// memoryFlags registers the flags describing a new memory on fs.
+func memoryFlags(fs *flag.FlagSet) (*gognee.MemoryInput, *bool) {
+	input := &gognee.MemoryInput{}
+	fs.StringVar(&input.Topic, "topic", "", "memory topic (required)")
+	fs.StringVar(&input.Context, "context", "", "memory context (required)")
+	fs.Var((*stringList)(&input.Decisions), "decision", "decision (repeatable)")
+	fs.Var((*stringList)(&input.Rationale), "rationale", "rationale (repeatable)")
+	fs.Var((*stringList)(&input.Tags), "tag", "tag (repeatable)")
+	fs.StringVar(&input.RetentionPolicy, "retention", "", "retention policy (default: standard)")
+	fs.StringVar(&input.Source, "source", "", "source label")
+	noCognify := fs.Bool("no-cognify", false, "store the memory without extracting entities")
+	return input, noCognify
+}
+
+// addMemory adds input and prints its ID.
+func (c *command) addMemory(ctx context.Context, input *gognee.MemoryInput, noCognify bool) error {
+	if noCognify {
+		input.AutoCognify = new(bool)
+	}
+	result, err := c.g.AddMemory(ctx, *input)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stdout, "%s\n", result.MemoryID)
+	return nil
+}
+
+func (c *command) memoryAdd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("memory add", flag.ContinueOnError)
+	input, noCognify := memoryFlags(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	return c.addMemory(ctx, input, *noCognify)
+}
+
+func (c *command) memorySupersede(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("memory supersede", flag.ContinueOnError)
+	input, noCognify := memoryFlags(fs)
+	fs.StringVar(&input.SupersessionReason, "reason", "", "why the old memories are superseded")
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		return fmt.Errorf("%w: memory supersede needs the IDs of the memories to supersede", errUsage)
+	}
+	input.Supersedes = rest
+	return c.addMemory(ctx, input, *noCognify)
+}
+
+func (c *command) memoryList(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("memory list", flag.ContinueOnError)
+	var opts store.ListMemoriesOptions
+	fs.IntVar(&opts.Limit, "limit", 50, "maximum number of memories (max 100)")
+	fs.IntVar(&opts.Offset, "offset", 0, "number of memories to skip")
+	fs.StringVar(&opts.OrderBy, "order-by", "", "created_at, updated_at, access_count, last_accessed_at or score")
+	status := fs.String("status", "", "only list memories with this status")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *status != "" {
+		opts.Status = status
+	}
+	opts.OrderDesc = true
+
+	memories, err := c.g.ListMemories(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if memories == nil {
+		memories = []store.MemorySummary{}
+	}
+	return c.writeJSON(memories)
+}
+
+func (c *command) memoryGet(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("memory get", flag.ContinueOnError)
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return fmt.Errorf("%w: memory get needs one memory ID", errUsage)
+	}
+	memory, err := c.g.GetMemory(ctx, rest[0])
+	if err != nil {
+		return err
+	}
+	return c.writeJSON(memory)
+}
+
+func (c *command) memoryPin(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("memory pin", flag.ContinueOnError)
+	reason := fs.String("reason", "", "why the memory is pinned")
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return fmt.Errorf("%w: memory pin needs one memory ID", errUsage)
+	}
+	if err := c.g.PinMemory(ctx, rest[0], *reason); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stdout, "Pinned %s\n", rest[0])
+	return nil
+}
+
+func (c *command) stats(ctx context.Context, args []string) error {
+	if _, err := parseFlags(flag.NewFlagSet("stats", flag.ContinueOnError), args); err != nil {
+		return err
+	}
+	stats, err := c.g.Stats()
+	if err != nil {
+		return err
+	}
+	return c.writeJSON(stats)
+}
+
+func (c *command) prune(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("prune", flag.ContinueOnError)
+	var opts gognee.PruneOptions
+	fs.BoolVar(&opts.DryRun, "dry-run", true, "report what would be pruned without deleting; -dry-run=false deletes")
+	fs.IntVar(&opts.MaxAgeDays, "max-age-days", 0, "prune nodes older than this many days")
+	fs.Float64Var(&opts.MinDecayScore, "min-score", 0, "prune nodes with a decay score below this")
+	superseded := fs.Bool("superseded", true, "prune superseded memories")
+	fs.IntVar(&opts.SupersededAgeDays, "superseded-age-days", 30, "only prune superseded memories older than this")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	opts.PruneSuperseded = superseded
+
+	result, err := c.g.Prune(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return c.writeJSON(result)
+}
+
+func (c *command) export(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", string(gognee.ExportFormatGraphML), "graphml or dot")
+	output := fs.String("o", "", "output file (default: stdout)")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return c.g.ExportGraph(ctx, c.stdout, gognee.ExportFormat(*format))
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := c.g.ExportGraph(ctx, f, gognee.ExportFormat(*format)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (c *command) backup(ctx context.Context, args []string) error {
+	rest, err := parseFlags(flag.NewFlagSet("backup", flag.ContinueOnError), args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return fmt.Errorf("%w: backup needs one destination path", errUsage)
+	}
+	if err := c.g.Backup(ctx, rest[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stdout, "Backed up to %s\n", rest[0])
+	return nil
+}
+
+// writeJSON prints v as indented JSON.
+func (c *command) writeJSON(v interface{}) error {
+	enc := json.NewEncoder(c.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/gognee"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// fakeEmbeddings returns one fixed embedding of the dimension of the SQLite vector index.
+type fakeEmbeddings struct{}
+
+func (fakeEmbeddings) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	result := make([][]float32, len(texts))
+	for i, text := range texts {
+		result[i], _ = fakeEmbeddings{}.EmbedOne(ctx, text)
+	}
+	return result, nil
+}
+
+func (fakeEmbeddings) EmbedOne(ctx context.Context, text string) ([]float32, error) {
+	embedding := make([]float32, 1536)
+	embedding[0] = 1
+	return embedding, nil
+}
+
+// fakeLLM extracts one "Gognee" entity and no relations.
+type fakeLLM struct{}
+
+func (fakeLLM) Complete(ctx context.Context, prompt string) (string, error) { return "", nil }
+
+func (fakeLLM) CompleteWithSchema(ctx context.Context, prompt string, schema any) error {
+	switch s := schema.(type) {
+	case *[]extraction.Entity:
+		*s = []extraction.Entity{{Name: "Gognee", Type: "Technology", Description: "A knowledge graph library"}}
+	case *[]extraction.Triplet:
+		*s = []extraction.Triplet{}
+	}
+	return nil
+}
+
+// runCLI runs the command line against dbPath and returns its exit status and output.
+func runCLI(t *testing.T, dbPath string, args ...string) (int, string, string) {
+	t.Helper()
+	return runCLIWithInput(t, dbPath, "", args...)
+}
+
+// runCLIWithInput is runCLI with the given standard input.
+func runCLIWithInput(t *testing.T, dbPath, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	open = func(cfg gognee.Config) (*gognee.Gognee, error) {
+		return gognee.NewWithClients(cfg, fakeEmbeddings{}, fakeLLM{})
+	}
+	t.Cleanup(func() { open = gognee.New })
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), append([]string{"-db", dbPath}, args...), strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCLI_Memories(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "cli.db")
+
+	code, out, errOut := runCLI(t, dbPath, "memory", "add", "-topic", "Storage", "-context", "We store data in SQLite.",
+		"-decision", "Use SQLite", "-tag", "infra", "-no-cognify")
+	if code != 0 {
+		t.Fatalf("memory add failed (%d): %s", code, errOut)
+	}
+	oldID := strings.TrimSpace(out)
+
+	// Flags may follow the positional arguments
+	code, out, errOut = runCLI(t, dbPath, "memory", "supersede", oldID, "-topic", "Storage",
+		"-context", "We store data in Postgres.", "-reason", "Scale", "-no-cognify")
+	if code != 0 {
+		t.Fatalf("memory supersede failed (%d): %s", code, errOut)
+	}
+	newID := strings.TrimSpace(out)
+
+	if code, _, errOut = runCLI(t, dbPath, "memory", "pin", newID, "-reason", "Current plan"); code != 0 {
+		t.Fatalf("memory pin failed (%d): %s", code, errOut)
+	}
+
+	code, out, errOut = runCLI(t, dbPath, "memory", "get", oldID)
+	if code != 0 {
+		t.Fatalf("memory get failed (%d): %s", code, errOut)
+	}
+	var old store.MemoryRecord
+	if err := json.Unmarshal([]byte(out), &old); err != nil {
+		t.Fatalf("memory get printed invalid JSON: %v\n%s", err, out)
+	}
+	if old.Status != "Superseded" || old.SupersededBy == nil || *old.SupersededBy != newID {
+		t.Errorf("Expected the old memory superseded by %s, got status %q by %v", newID, old.Status, old.SupersededBy)
+	}
+
+	code, out, errOut = runCLI(t, dbPath, "memory", "list", "-status", "Pinned")
+	if code != 0 {
+		t.Fatalf("memory list failed (%d): %s", code, errOut)
+	}
+	var pinned []store.MemorySummary
+	if err := json.Unmarshal([]byte(out), &pinned); err != nil {
+		t.Fatalf("memory list printed invalid JSON: %v\n%s", err, out)
+	}
+	if len(pinned) != 1 || pinned[0].ID != newID {
+		t.Errorf("Expected only the pinned memory, got %+v", pinned)
+	}
+
+	code, out, errOut = runCLI(t, dbPath, "stats")
+	if code != 0 {
+		t.Fatalf("stats failed (%d): %s", code, errOut)
+	}
+	var stats gognee.Stats
+	if err := json.Unmarshal([]byte(out), &stats); err != nil {
+		t.Fatalf("stats printed invalid JSON: %v\n%s", err, out)
+	}
+	if stats.MemoryCount != 2 || stats.PinnedMemories != 1 {
+		t.Errorf("Expected 2 memories with 1 pinned, got %d with %d pinned", stats.MemoryCount, stats.PinnedMemories)
+	}
+
+	// Age the superseded memory past the grace period
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	_, err = db.Exec("UPDATE memories SET updated_at = ? WHERE id = ?", time.Now().Add(-60*24*time.Hour), oldID)
+	db.Close()
+	if err != nil {
+		t.Fatalf("failed to age memory: %v", err)
+	}
+
+	// prune is a dry run unless told otherwise, and -superseded=false keeps the memory
+	for _, args := range [][]string{
+		{"prune"},
+		{"prune", "-dry-run=false", "-superseded=false"},
+	} {
+		if code, _, errOut = runCLI(t, dbPath, args...); code != 0 {
+			t.Fatalf("%v failed (%d): %s", args, code, errOut)
+		}
+		if code, _, _ = runCLI(t, dbPath, "memory", "get", oldID); code != 0 {
+			t.Errorf("%v deleted the superseded memory", args)
+		}
+	}
+	if code, _, errOut = runCLI(t, dbPath, "prune", "-dry-run=false"); code != 0 {
+		t.Fatalf("prune failed (%d): %s", code, errOut)
+	}
+	if code, _, _ = runCLI(t, dbPath, "memory", "get", oldID); code == 0 {
+		t.Errorf("Expected prune to delete the superseded memory")
+	}
+}
+
+func TestCLI_Graph(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "cli.db")
+
+	// The buffer is persisted, so add and cognify can run as separate processes
+	if code, _, errOut := runCLI(t, dbPath, "add", "-source", "notes", "Gognee", "stores", "knowledge."); code != 0 {
+		t.Fatalf("add failed (%d): %s", code, errOut)
+	}
+	code, out, errOut := runCLI(t, dbPath, "cognify")
+	if code != 0 || !strings.Contains(out, "Processed 1 document(s)") {
+		t.Fatalf("cognify failed (%d): %s%s", code, out, errOut)
+	}
+
+	code, out, errOut = runCLI(t, dbPath, "search", "Gognee", "-type", "keyword")
+	if code != 0 {
+		t.Fatalf("search failed (%d): %s", code, errOut)
+	}
+	var hits []searchHit
+	if err := json.Unmarshal([]byte(out), &hits); err != nil {
+		t.Fatalf("search printed invalid JSON: %v\n%s", err, out)
+	}
+	if len(hits) == 0 || hits[0].Name != "Gognee" {
+		t.Errorf("Expected the Gognee node, got %+v", hits)
+	}
+
+	code, out, errOut = runCLI(t, dbPath, "prune", "--dry-run", "-max-age-days", "1")
+	if code != 0 || !strings.Contains(out, "NodesEvaluated") {
+		t.Fatalf("prune failed (%d): %s%s", code, out, errOut)
+	}
+
+	code, out, errOut = runCLI(t, dbPath, "export", "-format", "dot")
+	if code != 0 || !strings.Contains(out, "Gognee") {
+		t.Fatalf("export failed (%d): %s%s", code, out, errOut)
+	}
+
+	backupPath := filepath.Join(dir, "backup.db")
+	if code, _, errOut = runCLI(t, dbPath, "backup", backupPath); code != 0 {
+		t.Fatalf("backup failed (%d): %s", code, errOut)
+	}
+	if _, err := os.Stat(backupPath); err != nil {
+		t.Errorf("Expected a backup file: %v", err)
+	}
+}
+
+func TestCLI_Usage(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "cli.db")
+	for _, args := range [][]string{
+		{},
+		{"frobnicate"},
+		{"memory", "get"},
+		{"search"},
+		{"stats", "-bogus"},
+	} {
+		if code, _, _ := runCLI(t, dbPath, args...); code != 2 {
+			t.Errorf("Expected exit status 2 for %q, got %d", args, code)
+		}
+	}
+	if code, _, errOut := runCLI(t, dbPath, "memory", "get", "missing"); code != 1 || errOut == "" {
+		t.Errorf("Expected exit status 1 with an error for a missing memory, got %d (%q)", code, errOut)
+	}
+}
@@ -0,0 +1,148 @@
+// Package breaker provides a circuit breaker for calls to external providers
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/provider"
+)
+
+// ErrOpen is returned instead of calling a provider while the circuit is open.
+var ErrOpen = errors.New("circuit breaker open")
+
+// State is the state of a Breaker.
+type State string
+
+const (
+	// StateClosed passes calls through
+	StateClosed State = "closed"
+	// StateOpen rejects calls with ErrOpen until the cooldown has passed
+	StateOpen State = "open"
+	// StateHalfOpen lets one trial call through; its outcome closes or reopens the circuit
+	StateHalfOpen State = "half-open"
+)
+
+// Defaults for Config fields left zero
+const (
+	DefaultFailureThreshold = 5
+	DefaultCooldown         = 30 * time.Second
+)
+
+// Config configures a Breaker.
+type Config struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit (default: 5)
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before a trial call (default: 30s)
+	Cooldown time.Duration
+}
+
+// Breaker stops calls to a provider after repeated failures, so callers fail fast
+// instead of waiting on a dead endpoint for every request. It is safe for concurrent use.
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool // A half-open trial call is in flight
+}
+
+// New creates a closed Breaker. name identifies the provider in ErrOpen errors.
+func New(name string, cfg Config) *Breaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultFailureThreshold
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = DefaultCooldown
+	}
+	return &Breaker{
+		name:      name,
+		threshold: cfg.FailureThreshold,
+		cooldown:  cfg.Cooldown,
+		now:       time.Now,
+		state:     StateClosed,
+	}
+}
+
+// State returns the current state, reporting an open circuit past its cooldown as half-open.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// Do runs call unless the circuit is open, and records its outcome. Only errors showing
+// the provider is down count as failures (see outage); others, and cancellation of ctx,
+// leave the failure count unchanged.
+func (b *Breaker) Do(ctx context.Context, call func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := call()
+	b.record(err == nil, err != nil && (ctx.Err() != nil || !outage(err)))
+	return err
+}
+
+// outage reports whether err shows the provider is unavailable or throttling requests,
+// or could not be reached at all. Rejected requests and unparseable responses do not.
+func outage(err error) bool {
+	var urlErr *url.Error
+	return errors.Is(err, provider.ErrUnavailable) || errors.Is(err, provider.ErrRateLimited) || errors.As(err, &urlErr)
+}
+
+// allow reports whether a call may proceed, moving an open circuit past its cooldown to half-open
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return fmt.Errorf("%s: %w", b.name, ErrOpen)
+		}
+		b.state = StateHalfOpen
+		b.trial = true
+		return nil
+	case StateHalfOpen:
+		if b.trial {
+			return fmt.Errorf("%s: %w", b.name, ErrOpen)
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// record updates the state with a call's outcome
+func (b *Breaker) record(success, ignored bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen {
+		b.trial = false
+	}
+	switch {
+	case success:
+		b.state = StateClosed
+		b.failures = 0
+	case ignored:
+		// Neither evidence of an outage nor of recovery
+	default:
+		b.failures++
+		if b.state == StateHalfOpen || b.failures >= b.threshold {
+			b.state = StateOpen
+			b.openedAt = b.now()
+		}
+	}
+}
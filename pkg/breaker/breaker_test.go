@@ -0,0 +1,103 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/provider"
+)
+
+var errDown = provider.NewStatusError(http.StatusServiceUnavailable, errors.New("provider down"))
+
+func TestBreaker_OpensAndRecovers(t *testing.T) {
+	ctx := context.Background()
+	clock := time.Unix(0, 0)
+	b := New("llm", Config{FailureThreshold: 3, Cooldown: time.Minute})
+	b.now = func() time.Time { return clock }
+
+	calls := 0
+	fail := func() error { calls++; return errDown }
+	succeed := func() error { calls++; return nil }
+
+	for i := 0; i < 3; i++ {
+		if err := b.Do(ctx, fail); !errors.Is(err, errDown) {
+			t.Fatalf("expected the provider error, got %v", err)
+		}
+	}
+	if b.State() != StateOpen {
+		t.Fatalf("expected open after 3 failures, got %s", b.State())
+	}
+
+	// Open: calls are rejected without reaching the provider
+	if err := b.Do(ctx, succeed); !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected ErrOpen, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected no call while open, got %d calls", calls)
+	}
+
+	// After the cooldown a failed trial reopens the circuit
+	clock = clock.Add(time.Minute)
+	if b.State() != StateHalfOpen {
+		t.Fatalf("expected half-open after the cooldown, got %s", b.State())
+	}
+	if err := b.Do(ctx, fail); !errors.Is(err, errDown) {
+		t.Fatalf("expected the trial to reach the provider, got %v", err)
+	}
+	if err := b.Do(ctx, succeed); !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected ErrOpen after a failed trial, got %v", err)
+	}
+
+	// A successful trial closes it
+	clock = clock.Add(time.Minute)
+	if err := b.Do(ctx, succeed); err != nil {
+		t.Fatalf("expected the trial to succeed, got %v", err)
+	}
+	if b.State() != StateClosed {
+		t.Errorf("expected closed after a successful trial, got %s", b.State())
+	}
+}
+
+func TestBreaker_SuccessResetsAndCancellationIgnored(t *testing.T) {
+	b := New("embeddings", Config{FailureThreshold: 2})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_ = b.Do(ctx, func() error { return errDown })
+	_ = b.Do(ctx, func() error { return nil })
+	_ = b.Do(ctx, func() error { return errDown })
+	if b.State() != StateClosed {
+		t.Fatalf("expected a success to reset the failure count, got %s", b.State())
+	}
+
+	cancel()
+	_ = b.Do(ctx, func() error { return ctx.Err() })
+	if b.State() != StateClosed {
+		t.Errorf("expected cancellation not to count as a failure, got %s", b.State())
+	}
+}
+
+func TestBreaker_CountsOnlyOutages(t *testing.T) {
+	ctx := context.Background()
+	b := New("llm", Config{FailureThreshold: 2})
+
+	// Rejected requests and bad responses say nothing about the provider being down
+	rejected := provider.NewStatusError(http.StatusBadRequest, errors.New("invalid request"))
+	for _, err := range []error{rejected, errors.New("invalid JSON"), rejected} {
+		_ = b.Do(ctx, func() error { return err })
+	}
+	if b.State() != StateClosed {
+		t.Fatalf("expected non-outage errors not to open the circuit, got %s", b.State())
+	}
+
+	throttled := provider.NewStatusError(http.StatusTooManyRequests, errors.New("slow down"))
+	unreachable := &url.Error{Op: "Post", URL: "http://localhost:1", Err: errors.New("connection refused")}
+	_ = b.Do(ctx, func() error { return throttled })
+	_ = b.Do(ctx, func() error { return unreachable })
+	if b.State() != StateOpen {
+		t.Errorf("expected rate limiting and unreachable endpoints to open the circuit, got %s", b.State())
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // Chunk represents a single chunk of text with metadata
@@ -14,6 +15,30 @@ type Chunk struct {
 	Text       string
 	Index      int
 	TokenCount int
+	// StartOffset and EndOffset are byte offsets of the chunk within the source text
+	// (end exclusive), for citing and highlighting the original passage. Text joins the
+	// chunk's sentences with single spaces, so it may differ from the source span in whitespace.
+	StartOffset int
+	EndOffset   int
+}
+
+// Chunk strategies selectable by name (see gognee.Config.ChunkStrategy)
+const (
+	StrategyToken    = "token"    // Chunker: token windows with overlap, split at sentence ends
+	StrategySemantic = "semantic" // SemanticChunker: paragraph and sentence boundaries
+	StrategyMarkdown = "markdown" // MarkdownChunker: headings, lists and code fences
+)
+
+// TextChunker splits text into chunks. Chunker, SemanticChunker and MarkdownChunker implement it.
+type TextChunker interface {
+	Chunk(text string) []Chunk
+}
+
+// sentence is a sentence of the source text with its byte offsets (end exclusive)
+type sentence struct {
+	text  string
+	start int
+	end   int
 }
 
 // Chunker splits text into overlapping chunks with sentence boundary awareness
@@ -45,84 +70,97 @@ func (c *Chunker) Chunk(text string) []Chunk {
 	}
 
 	var chunks []Chunk
-	var currentChunk []string
+	var currentChunk []sentence
 	var currentTokenCount int
 
-	for _, sentence := range sentences {
-		sentenceTokens := countTokens(sentence)
+	for _, sent := range sentences {
+		sentenceTokens := countTokens(sent.text)
 
 		// If adding this sentence would exceed max tokens, finalize current chunk
 		if currentTokenCount+sentenceTokens > maxTokens && len(currentChunk) > 0 {
-			chunkText := strings.Join(currentChunk, " ")
-			chunks = append(chunks, Chunk{
-				ID:         generateChunkID(chunkText, len(chunks)),
-				Text:       chunkText,
-				Index:      len(chunks),
-				TokenCount: currentTokenCount,
-			})
+			chunks = append(chunks, newChunk(currentChunk, len(chunks), currentTokenCount))
 
 			// Keep overlap tokens for next chunk
 			currentChunk = getOverlapSentences(currentChunk, overlap)
 			currentTokenCount = countTokensForSentences(currentChunk)
 		}
 
-		currentChunk = append(currentChunk, sentence)
+		currentChunk = append(currentChunk, sent)
 		currentTokenCount += sentenceTokens
 	}
 
 	// Add final chunk if there's remaining content
 	if len(currentChunk) > 0 {
-		chunkText := strings.Join(currentChunk, " ")
-		chunks = append(chunks, Chunk{
-			ID:         generateChunkID(chunkText, len(chunks)),
-			Text:       chunkText,
-			Index:      len(chunks),
-			TokenCount: currentTokenCount,
-		})
+		chunks = append(chunks, newChunk(currentChunk, len(chunks), currentTokenCount))
 	}
 
 	return chunks
 }
 
-// splitSentences splits text into sentences based on common terminators
-func splitSentences(text string) []string {
-	// Simple sentence splitting on ., !, ? followed by space or end
-	var sentences []string
-	var current strings.Builder
+// newChunk builds a chunk from consecutive sentences
+func newChunk(sentences []sentence, index, tokenCount int) Chunk {
+	texts := make([]string, len(sentences))
+	for i, sent := range sentences {
+		texts[i] = sent.text
+	}
+	chunkText := strings.Join(texts, " ")
+
+	return Chunk{
+		ID:          generateChunkID(chunkText, index),
+		Text:        chunkText,
+		Index:       index,
+		TokenCount:  tokenCount,
+		StartOffset: sentences[0].start,
+		EndOffset:   sentences[len(sentences)-1].end,
+	}
+}
 
-	runes := []rune(text)
-	for i := 0; i < len(runes); i++ {
-		current.WriteRune(runes[i])
+// splitSentences splits text into sentences based on common terminators,
+// recording each sentence's byte offsets in text
+func splitSentences(text string) []sentence {
+	// Simple sentence splitting on ., !, ? followed by space or end
+	var sentences []sentence
+	start := 0
+
+	emit := func(end int) {
+		raw := text[start:end]
+		trimmed := strings.TrimSpace(raw)
+		if trimmed != "" {
+			offset := start + len(raw) - len(strings.TrimLeftFunc(raw, unicode.IsSpace))
+			sentences = append(sentences, sentence{text: trimmed, start: offset, end: offset + len(trimmed)})
+		}
+		start = end
+	}
 
+	for i, r := range text {
 		// Check for sentence terminators
-		if runes[i] == '.' || runes[i] == '!' || runes[i] == '?' {
+		if r == '.' || r == '!' || r == '?' {
 			// Check if followed by space/end
-			if i+1 >= len(runes) || unicode.IsSpace(runes[i+1]) {
-				sentence := strings.TrimSpace(current.String())
-				if sentence != "" {
-					sentences = append(sentences, sentence)
-				}
-				current.Reset()
+			next := i + utf8.RuneLen(r)
+			if next >= len(text) {
+				emit(next)
+				continue
+			}
+			if nextRune, _ := utf8.DecodeRuneInString(text[next:]); unicode.IsSpace(nextRune) {
+				emit(next)
 			}
 		}
 	}
 
 	// Add any remaining text
-	if current.Len() > 0 {
-		sentence := strings.TrimSpace(current.String())
-		if sentence != "" {
-			sentences = append(sentences, sentence)
-		}
-	}
-
-	// Fallback: if no sentences detected, treat whole text as one sentence
-	if len(sentences) == 0 && strings.TrimSpace(text) != "" {
-		sentences = append(sentences, strings.TrimSpace(text))
+	if start < len(text) {
+		emit(len(text))
 	}
 
 	return sentences
 }
 
+// EstimateTokens estimates the token count of text with the same word-based heuristic
+// used to size chunks.
+func EstimateTokens(text string) int {
+	return countTokens(text)
+}
+
 // countTokens estimates token count using word-based heuristic
 // Note: This is an approximation. For accurate token counting, use a proper tokenizer.
 func countTokens(text string) int {
@@ -131,18 +169,18 @@ func countTokens(text string) int {
 }
 
 // countTokensForSentences counts total tokens for a slice of sentences
-func countTokensForSentences(sentences []string) int {
+func countTokensForSentences(sentences []sentence) int {
 	total := 0
 	for _, s := range sentences {
-		total += countTokens(s)
+		total += countTokens(s.text)
 	}
 	return total
 }
 
 // getOverlapSentences returns the last N tokens worth of sentences for overlap
-func getOverlapSentences(sentences []string, overlapTokens int) []string {
+func getOverlapSentences(sentences []sentence, overlapTokens int) []sentence {
 	if overlapTokens == 0 || len(sentences) == 0 {
-		return []string{}
+		return []sentence{}
 	}
 
 	// Count backwards from end to get ~overlapTokens
@@ -150,7 +188,7 @@ func getOverlapSentences(sentences []string, overlapTokens int) []string {
 	startIdx := len(sentences)
 
 	for i := len(sentences) - 1; i >= 0; i-- {
-		tokens := countTokens(sentences[i])
+		tokens := countTokens(sentences[i].text)
 		if totalTokens+tokens > overlapTokens && startIdx != len(sentences) {
 			break
 		}
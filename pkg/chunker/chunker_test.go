@@ -143,3 +143,35 @@ func TestChunkerSentenceBoundaries(t *testing.T) {
 		}
 	}
 }
+
+func TestChunkerOffsets(t *testing.T) {
+	c := Chunker{
+		MaxTokens: 6,
+		Overlap:   1,
+	}
+
+	text := "  Héllo wörld. Second sentence here!\nThird one follows. Last."
+	chunks := c.Chunk(text)
+
+	if len(chunks) < 2 {
+		t.Fatalf("Expected multiple chunks, got %d", len(chunks))
+	}
+
+	for i, chunk := range chunks {
+		if chunk.StartOffset < 0 || chunk.EndOffset > len(text) || chunk.StartOffset >= chunk.EndOffset {
+			t.Fatalf("Chunk %d has invalid offsets [%d, %d)", i, chunk.StartOffset, chunk.EndOffset)
+		}
+		// The source span holds the same words as the chunk text
+		span := text[chunk.StartOffset:chunk.EndOffset]
+		if strings.Join(strings.Fields(span), " ") != chunk.Text {
+			t.Errorf("Chunk %d: source span %q does not match text %q", i, span, chunk.Text)
+		}
+	}
+
+	if chunks[0].StartOffset != 2 {
+		t.Errorf("Expected first chunk to start after leading whitespace, got %d", chunks[0].StartOffset)
+	}
+	if last := chunks[len(chunks)-1]; last.EndOffset != len(text) {
+		t.Errorf("Expected last chunk to end at %d, got %d", len(text), last.EndOffset)
+	}
+}
@@ -0,0 +1,263 @@
+package chunker
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MarkdownChunker splits markdown along its structure. Fenced code blocks are never
+// split across chunks, lists are split only between items and paragraphs only between
+// sentences. Each chunk starts with the headings it sits under, so text deep in a
+// document keeps its context; StartOffset/EndOffset cover the source span without them.
+// A section that fits in the remaining space joins the current chunk; otherwise its
+// heading starts a new one. Blocks larger than MaxTokens become their own chunk.
+type MarkdownChunker struct {
+	MaxTokens int // Maximum tokens per chunk (default: 512)
+}
+
+// markdownBlockKind classifies a block of markdown
+type markdownBlockKind int
+
+const (
+	blockParagraph markdownBlockKind = iota
+	blockHeading
+	blockCode
+	blockList
+)
+
+// markdownBlock is a heading, fenced code block, list or paragraph with its source span
+type markdownBlock struct {
+	kind  markdownBlockKind
+	span  span
+	level int    // Heading level (1-6)
+	title string // Heading line
+	items []span // List items
+}
+
+var (
+	headingPattern  = regexp.MustCompile(`^ {0,3}(#{1,6})(\s|$)`)
+	fencePattern    = regexp.MustCompile("^ {0,3}(```+|~~~+)")
+	listItemPattern = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+`)
+)
+
+// Chunk splits the input text into chunks
+func (c *MarkdownChunker) Chunk(text string) []Chunk {
+	maxTokens := c.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 512
+	}
+
+	blocks := parseMarkdownBlocks(text)
+	if len(blocks) == 0 {
+		return []Chunk{}
+	}
+
+	var chunks []Chunk
+	var headings []markdownBlock // Enclosing headings, outermost first
+	var current []span
+	var prefix string
+	currentTokens := 0
+
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+		chunkText := prefix + text[current[0].start:current[len(current)-1].end]
+		chunks = append(chunks, Chunk{
+			ID:          generateChunkID(chunkText, len(chunks)),
+			Text:        chunkText,
+			Index:       len(chunks),
+			TokenCount:  currentTokens,
+			StartOffset: current[0].start,
+			EndOffset:   current[len(current)-1].end,
+		})
+		current = nil
+		currentTokens = 0
+	}
+
+	for i, block := range blocks {
+		if block.kind == blockHeading {
+			// Start a new chunk unless the whole section fits in this one
+			if currentTokens+sectionTokens(text, blocks[i:]) > maxTokens {
+				flush()
+			}
+			for len(headings) > 0 && headings[len(headings)-1].level >= block.level {
+				headings = headings[:len(headings)-1]
+			}
+		}
+
+		for _, part := range block.parts(text, maxTokens) {
+			tokens := countTokens(text[part.start:part.end])
+			if len(current) > 0 && currentTokens+tokens > maxTokens {
+				flush()
+			}
+			if len(current) == 0 {
+				prefix = headingPrefix(headings)
+				currentTokens = countTokens(prefix)
+			}
+			current = append(current, part)
+			currentTokens += tokens
+		}
+
+		if block.kind == blockHeading {
+			headings = append(headings, block)
+		}
+	}
+	flush()
+
+	return chunks
+}
+
+// parts returns the pieces a block may be split into: the whole block when it fits,
+// otherwise sentences of a paragraph or items of a list. Code and headings stay whole.
+func (b markdownBlock) parts(text string, maxTokens int) []span {
+	if countTokens(text[b.span.start:b.span.end]) <= maxTokens {
+		return []span{b.span}
+	}
+
+	switch b.kind {
+	case blockList:
+		return b.items
+	case blockParagraph:
+		sentences := splitSentences(text[b.span.start:b.span.end])
+		parts := make([]span, len(sentences))
+		for i, sent := range sentences {
+			parts[i] = span{start: b.span.start + sent.start, end: b.span.start + sent.end}
+		}
+		return parts
+	default:
+		return []span{b.span}
+	}
+}
+
+// sectionTokens counts the tokens of the heading at blocks[0] and the blocks up to the next heading
+func sectionTokens(text string, blocks []markdownBlock) int {
+	total := countTokens(text[blocks[0].span.start:blocks[0].span.end])
+	for _, block := range blocks[1:] {
+		if block.kind == blockHeading {
+			break
+		}
+		total += countTokens(text[block.span.start:block.span.end])
+	}
+	return total
+}
+
+// headingPrefix renders the enclosing headings to prepend to a chunk
+func headingPrefix(headings []markdownBlock) string {
+	if len(headings) == 0 {
+		return ""
+	}
+	titles := make([]string, len(headings))
+	for i, heading := range headings {
+		titles[i] = heading.title
+	}
+	return strings.Join(titles, "\n\n") + "\n\n"
+}
+
+// parseMarkdownBlocks splits markdown into headings, fenced code blocks, lists and paragraphs
+func parseMarkdownBlocks(text string) []markdownBlock {
+	type line struct {
+		text  string // Without the line terminator
+		start int
+		end   int
+	}
+	var lines []line
+	offset := 0
+	for _, raw := range strings.SplitAfter(text, "\n") {
+		if raw == "" {
+			continue
+		}
+		trimmed := strings.TrimRight(raw, "\r\n")
+		lines = append(lines, line{text: trimmed, start: offset, end: offset + len(trimmed)})
+		offset += len(raw)
+	}
+	blank := func(i int) bool { return strings.TrimSpace(lines[i].text) == "" }
+
+	var blocks []markdownBlock
+	for i := 0; i < len(lines); {
+		if blank(i) {
+			i++
+			continue
+		}
+		l := lines[i]
+
+		if m := headingPattern.FindStringSubmatch(l.text); m != nil {
+			blocks = append(blocks, markdownBlock{
+				kind:  blockHeading,
+				span:  span{start: l.start, end: l.end},
+				level: len(m[1]),
+				title: strings.TrimSpace(l.text),
+			})
+			i++
+			continue
+		}
+
+		if m := fencePattern.FindStringSubmatch(l.text); m != nil {
+			fence := m[1]
+			j := i + 1
+			for j < len(lines) {
+				closing := strings.TrimSpace(lines[j].text)
+				if strings.HasPrefix(closing, fence) && strings.Trim(closing, fence[:1]) == "" {
+					break
+				}
+				j++
+			}
+			if j == len(lines) {
+				j-- // Unclosed fence runs to the end of the text
+			}
+			blocks = append(blocks, markdownBlock{kind: blockCode, span: span{start: l.start, end: lines[j].end}})
+			i = j + 1
+			continue
+		}
+
+		if m := listItemPattern.FindStringSubmatch(l.text); m != nil {
+			indent := len(m[1])
+			block := markdownBlock{kind: blockList}
+			itemStart := l.start
+			j := i
+			end := i
+			for j < len(lines) {
+				if blank(j) {
+					// A blank line continues the list only if more items or indented text follow
+					k := j
+					for k < len(lines) && blank(k) {
+						k++
+					}
+					if k == len(lines) || !(listItemPattern.MatchString(lines[k].text) || strings.HasPrefix(lines[k].text, " ") || strings.HasPrefix(lines[k].text, "\t")) {
+						break
+					}
+					j = k
+					continue
+				}
+				if j > i {
+					if headingPattern.MatchString(lines[j].text) || fencePattern.MatchString(lines[j].text) {
+						break
+					}
+					if m := listItemPattern.FindStringSubmatch(lines[j].text); m != nil && len(m[1]) <= indent {
+						block.items = append(block.items, span{start: itemStart, end: lines[end].end})
+						itemStart = lines[j].start
+					}
+				}
+				end = j
+				j++
+			}
+			block.items = append(block.items, span{start: itemStart, end: lines[end].end})
+			block.span = span{start: l.start, end: lines[end].end}
+			blocks = append(blocks, block)
+			i = end + 1
+			continue
+		}
+
+		j := i
+		for j+1 < len(lines) && !blank(j+1) &&
+			!headingPattern.MatchString(lines[j+1].text) &&
+			!fencePattern.MatchString(lines[j+1].text) &&
+			!listItemPattern.MatchString(lines[j+1].text) {
+			j++
+		}
+		blocks = append(blocks, markdownBlock{kind: blockParagraph, span: span{start: l.start, end: lines[j].end}})
+		i = j + 1
+	}
+
+	return blocks
+}
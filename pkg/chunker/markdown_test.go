@@ -0,0 +1,102 @@
+package chunker
+
+import (
+	"strings"
+	"testing"
+)
+
+const markdownDoc = `# Service Guide
+
+Intro paragraph about the service.
+
+## Setup
+
+Install the binary first.
+
+` + "```go" + `
+func main() {
+	fmt.Println("one two three four five six")
+}
+` + "```" + `
+
+## Usage
+
+- Run the server.
+- Call the API.
+`
+
+func TestMarkdownChunker_PrependsHeadings(t *testing.T) {
+	c := &MarkdownChunker{MaxTokens: 25}
+	chunks := c.Chunk(markdownDoc)
+	if len(chunks) != 3 {
+		t.Fatalf("Expected 3 chunks, got %d: %+v", len(chunks), chunks)
+	}
+
+	if !strings.HasPrefix(chunks[1].Text, "# Service Guide\n\n## Setup") {
+		t.Errorf("Expected setup chunk to start with its heading path, got %q", chunks[1].Text)
+	}
+	if !strings.HasPrefix(chunks[2].Text, "# Service Guide\n\n## Usage\n\n- Run the server.") {
+		t.Errorf("Expected usage chunk to start with its heading path, got %q", chunks[2].Text)
+	}
+
+	for i, chunk := range chunks {
+		if chunk.Index != i || chunk.ID == "" {
+			t.Errorf("Chunk %d has bad Index/ID: %+v", i, chunk)
+		}
+		if !strings.HasSuffix(chunk.Text, markdownDoc[chunk.StartOffset:chunk.EndOffset]) {
+			t.Errorf("Chunk %d offsets do not match its text", i)
+		}
+	}
+}
+
+func TestMarkdownChunker_NeverSplitsCodeFences(t *testing.T) {
+	c := &MarkdownChunker{MaxTokens: 4}
+	chunks := c.Chunk(markdownDoc)
+
+	fences := 0
+	for _, chunk := range chunks {
+		if n := strings.Count(chunk.Text, "```"); n%2 != 0 {
+			t.Errorf("Chunk splits a code fence: %q", chunk.Text)
+		} else {
+			fences += n
+		}
+	}
+	if fences != 2 {
+		t.Errorf("Expected the code block in exactly one chunk, saw %d fence markers", fences)
+	}
+}
+
+func TestMarkdownChunker_SplitsListsBetweenItems(t *testing.T) {
+	text := "# Todo\n\n- first item here\n  continued line\n- second item here\n- third item here\n"
+	c := &MarkdownChunker{MaxTokens: 10}
+	chunks := c.Chunk(text)
+
+	for _, chunk := range chunks {
+		if strings.Contains(chunk.Text, "continued line") && !strings.Contains(chunk.Text, "- first item here") {
+			t.Errorf("List item split from its continuation: %q", chunk.Text)
+		}
+	}
+	last := chunks[len(chunks)-1]
+	if !strings.HasPrefix(last.Text, "# Todo\n\n- ") {
+		t.Errorf("Expected list continuation chunk to keep its heading, got %q", last.Text)
+	}
+}
+
+func TestMarkdownChunker_PlainText(t *testing.T) {
+	c := &MarkdownChunker{}
+	chunks := c.Chunk("Just a sentence. And another.")
+	if len(chunks) != 1 || chunks[0].Text != "Just a sentence. And another." {
+		t.Errorf("Unexpected chunks for plain text: %+v", chunks)
+	}
+	if chunks := c.Chunk("\n\n"); len(chunks) != 0 {
+		t.Errorf("Expected no chunks for blank text, got %d", len(chunks))
+	}
+}
+
+func TestMarkdownChunker_UnclosedFence(t *testing.T) {
+	text := "Intro.\n\n```\ncode without end\nmore code"
+	chunks := (&MarkdownChunker{MaxTokens: 2}).Chunk(text)
+	if len(chunks) != 2 || !strings.HasSuffix(chunks[1].Text, "more code") {
+		t.Errorf("Expected unclosed fence to run to the end, got %+v", chunks)
+	}
+}
@@ -0,0 +1,197 @@
+package chunker
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"github.com/dan-solli/gognee/pkg/embeddings"
+)
+
+// SemanticChunker splits text on paragraph and sentence boundaries instead of token windows.
+// Whole paragraphs are packed into a chunk while they fit; a paragraph that does not fit
+// starts a new chunk, and one larger than MaxTokens is split between sentences.
+// Sentences are never split, so a single sentence longer than MaxTokens becomes its own chunk.
+// Chunks do not overlap.
+type SemanticChunker struct {
+	MaxTokens int // Maximum tokens per chunk (default: 512)
+
+	// Embeddings and BreakpointThreshold enable topic breakpoints: a new chunk also starts
+	// wherever the cosine similarity of consecutive sentence embeddings drops below the
+	// threshold. Disabled unless both are set. If embedding fails, only structural
+	// boundaries are used.
+	Embeddings          embeddings.EmbeddingClient
+	BreakpointThreshold float64
+}
+
+// span is a byte range of the source text (end exclusive)
+type span struct {
+	start int
+	end   int
+}
+
+// semanticUnit is a sentence tagged with the paragraph it belongs to
+type semanticUnit struct {
+	sentence
+	paragraph int
+}
+
+// Chunk splits the input text into chunks
+func (c *SemanticChunker) Chunk(text string) []Chunk {
+	maxTokens := c.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 512
+	}
+
+	paragraphs := splitParagraphs(text)
+	if len(paragraphs) == 0 {
+		return []Chunk{}
+	}
+
+	var units []semanticUnit
+	for i, para := range paragraphs {
+		for _, sent := range splitSentences(text[para.start:para.end]) {
+			sent.start += para.start
+			sent.end += para.start
+			units = append(units, semanticUnit{sentence: sent, paragraph: i})
+		}
+	}
+	breakpoints := c.breakpoints(units)
+
+	var chunks []Chunk
+	var current []semanticUnit
+	currentTokens := 0
+	flush := func() {
+		if len(current) > 0 {
+			chunks = append(chunks, newSemanticChunk(current, len(chunks), currentTokens))
+			current = nil
+			currentTokens = 0
+		}
+	}
+
+	for i, unit := range units {
+		tokens := countTokens(unit.text)
+
+		// Keep paragraphs whole when they fit in a chunk of their own
+		if i == 0 || unit.paragraph != units[i-1].paragraph {
+			if currentTokens+paragraphTokens(units[i:]) > maxTokens {
+				flush()
+			}
+		}
+		if breakpoints[i] || currentTokens+tokens > maxTokens {
+			flush()
+		}
+
+		current = append(current, unit)
+		currentTokens += tokens
+	}
+	flush()
+
+	return chunks
+}
+
+// breakpoints marks the units that start a new topic according to sentence embeddings
+func (c *SemanticChunker) breakpoints(units []semanticUnit) []bool {
+	result := make([]bool, len(units))
+	if c.Embeddings == nil || c.BreakpointThreshold <= 0 || len(units) < 2 {
+		return result
+	}
+
+	texts := make([]string, len(units))
+	for i, unit := range units {
+		texts[i] = unit.text
+	}
+	vectors, err := c.Embeddings.Embed(context.Background(), texts)
+	if err != nil || len(vectors) != len(units) {
+		return result
+	}
+
+	for i := 1; i < len(units); i++ {
+		if cosineSimilarity(vectors[i-1], vectors[i]) < c.BreakpointThreshold {
+			result[i] = true
+		}
+	}
+	return result
+}
+
+// paragraphTokens counts the tokens of the paragraph starting at units[0]
+func paragraphTokens(units []semanticUnit) int {
+	total := 0
+	for _, unit := range units {
+		if unit.paragraph != units[0].paragraph {
+			break
+		}
+		total += countTokens(unit.text)
+	}
+	return total
+}
+
+// newSemanticChunk builds a chunk from consecutive units, separating paragraphs with a blank line
+func newSemanticChunk(units []semanticUnit, index, tokenCount int) Chunk {
+	var b strings.Builder
+	for i, unit := range units {
+		if i > 0 {
+			if unit.paragraph != units[i-1].paragraph {
+				b.WriteString("\n\n")
+			} else {
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString(unit.text)
+	}
+	chunkText := b.String()
+
+	return Chunk{
+		ID:          generateChunkID(chunkText, index),
+		Text:        chunkText,
+		Index:       index,
+		TokenCount:  tokenCount,
+		StartOffset: units[0].start,
+		EndOffset:   units[len(units)-1].end,
+	}
+}
+
+// splitParagraphs returns the byte spans of blank-line separated paragraphs in text
+func splitParagraphs(text string) []span {
+	var paragraphs []span
+	start := -1
+	end := 0
+
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if start >= 0 {
+				paragraphs = append(paragraphs, span{start: start, end: end})
+				start = -1
+			}
+		} else {
+			if start < 0 {
+				start = offset
+			}
+			end = offset + len(strings.TrimRight(line, "\r\n"))
+		}
+		offset += len(line)
+	}
+	if start >= 0 {
+		paragraphs = append(paragraphs, span{start: start, end: end})
+	}
+
+	return paragraphs
+}
+
+// cosineSimilarity returns the cosine similarity of two vectors, or 0 if they are incomparable
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,104 @@
+package chunker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// topicEmbeddings embeds sentences mentioning "database" and everything else on orthogonal axes
+type topicEmbeddings struct {
+	err error
+}
+
+func (e *topicEmbeddings) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	result := make([][]float32, len(texts))
+	for i, text := range texts {
+		if strings.Contains(strings.ToLower(text), "database") {
+			result[i] = []float32{1, 0}
+		} else {
+			result[i] = []float32{0, 1}
+		}
+	}
+	return result, nil
+}
+
+func (e *topicEmbeddings) EmbedOne(ctx context.Context, text string) ([]float32, error) {
+	vectors, err := e.Embed(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return vectors[0], nil
+}
+
+func TestSemanticChunker_PacksParagraphs(t *testing.T) {
+	c := &SemanticChunker{MaxTokens: 12}
+	text := "Alpha beta gamma. Delta epsilon.\n\nZeta eta theta iota.\n\nKappa lambda mu nu xi omicron pi rho."
+
+	chunks := c.Chunk(text)
+	if len(chunks) != 2 {
+		t.Fatalf("Expected 2 chunks, got %d: %+v", len(chunks), chunks)
+	}
+	if chunks[0].Text != "Alpha beta gamma. Delta epsilon.\n\nZeta eta theta iota." {
+		t.Errorf("Unexpected first chunk: %q", chunks[0].Text)
+	}
+	if chunks[1].Text != "Kappa lambda mu nu xi omicron pi rho." {
+		t.Errorf("Expected the paragraph that does not fit to start a new chunk, got %q", chunks[1].Text)
+	}
+
+	for i, chunk := range chunks {
+		if chunk.Index != i || chunk.ID == "" {
+			t.Errorf("Chunk %d has bad Index/ID: %+v", i, chunk)
+		}
+		if !strings.HasPrefix(text[chunk.StartOffset:chunk.EndOffset], strings.Fields(chunk.Text)[0]) {
+			t.Errorf("Chunk %d offsets do not point at its text: %q", i, text[chunk.StartOffset:chunk.EndOffset])
+		}
+	}
+}
+
+func TestSemanticChunker_NeverSplitsSentences(t *testing.T) {
+	c := &SemanticChunker{MaxTokens: 5}
+	text := "One two three. This sentence is much longer than the limit allows. Four five."
+
+	chunks := c.Chunk(text)
+	if len(chunks) != 3 {
+		t.Fatalf("Expected 3 chunks, got %d: %+v", len(chunks), chunks)
+	}
+	if chunks[1].Text != "This sentence is much longer than the limit allows." {
+		t.Errorf("Expected the long sentence kept whole, got %q", chunks[1].Text)
+	}
+}
+
+func TestSemanticChunker_EmbeddingBreakpoints(t *testing.T) {
+	text := "The database stores rows. The database has indexes. Lunch is at noon. Lunch is pizza."
+
+	chunks := (&SemanticChunker{MaxTokens: 100}).Chunk(text)
+	if len(chunks) != 1 {
+		t.Fatalf("Expected 1 chunk without breakpoints, got %d", len(chunks))
+	}
+
+	c := &SemanticChunker{MaxTokens: 100, Embeddings: &topicEmbeddings{}, BreakpointThreshold: 0.5}
+	chunks = c.Chunk(text)
+	if len(chunks) != 2 {
+		t.Fatalf("Expected 2 chunks split at the topic change, got %d: %+v", len(chunks), chunks)
+	}
+	if chunks[1].Text != "Lunch is at noon. Lunch is pizza." {
+		t.Errorf("Unexpected second chunk: %q", chunks[1].Text)
+	}
+
+	c.Embeddings = &topicEmbeddings{err: errors.New("unavailable")}
+	if chunks := c.Chunk(text); len(chunks) != 1 {
+		t.Errorf("Expected structural chunking when embedding fails, got %d chunks", len(chunks))
+	}
+}
+
+func TestSemanticChunker_Empty(t *testing.T) {
+	c := &SemanticChunker{}
+	if chunks := c.Chunk("  \n\n  "); len(chunks) != 0 {
+		t.Errorf("Expected no chunks for blank text, got %d", len(chunks))
+	}
+}
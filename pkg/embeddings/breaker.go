@@ -0,0 +1,40 @@
+package embeddings
+
+import (
+	"context"
+
+	"github.com/dan-solli/gognee/pkg/breaker"
+)
+
+// breakerClient guards an EmbeddingClient with a circuit breaker
+type breakerClient struct {
+	client  EmbeddingClient
+	breaker *breaker.Breaker
+}
+
+// NewBreakerClient wraps client so calls fail fast with breaker.ErrOpen while b is open.
+func NewBreakerClient(client EmbeddingClient, b *breaker.Breaker) EmbeddingClient {
+	return &breakerClient{client: client, breaker: b}
+}
+
+// Embed forwards to the wrapped client unless the circuit is open
+func (c *breakerClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	var embeddings [][]float32
+	err := c.breaker.Do(ctx, func() error {
+		var err error
+		embeddings, err = c.client.Embed(ctx, texts)
+		return err
+	})
+	return embeddings, err
+}
+
+// EmbedOne forwards to the wrapped client unless the circuit is open
+func (c *breakerClient) EmbedOne(ctx context.Context, text string) ([]float32, error) {
+	var embedding []float32
+	err := c.breaker.Do(ctx, func() error {
+		var err error
+		embedding, err = c.client.EmbedOne(ctx, text)
+		return err
+	})
+	return embedding, err
+}
@@ -1,6 +1,13 @@
 package embeddings
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrMalformedResponse is matched by errors for provider responses that do not hold one
+// embedding per input text.
+var ErrMalformedResponse = errors.New("malformed embedding response")
 
 // EmbeddingClient defines the interface for generating text embeddings
 type EmbeddingClient interface {
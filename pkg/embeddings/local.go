@@ -0,0 +1,152 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/provider"
+)
+
+// defaultLocalBatchSize caps the texts per request; local servers often reject large batches
+const defaultLocalBatchSize = 32
+
+// LocalClient implements EmbeddingClient against a local server exposing the
+// OpenAI-compatible /v1/embeddings endpoint (llama.cpp server, LM Studio, vLLM,
+// text-embeddings-inference), so gognee runs fully offline.
+//
+// Loading ONNX models in-process is not supported: it needs a cgo ONNX runtime the
+// module does not depend on. Serve the model with one of the servers above instead.
+type LocalClient struct {
+	BaseURL    string // Server URL, e.g. "http://localhost:8080" or "http://localhost:1234/v1"
+	Model      string // Model name; ignored by single-model servers such as llama.cpp
+	APIKey     string // Optional bearer token
+	BatchSize  int    // Texts per request (default: 32)
+	HTTPClient *http.Client
+	// Timeout bounds each batch request; zero leaves only the HTTP client's limit.
+	// WithTimeout overrides it per context.
+	Timeout time.Duration
+}
+
+// NewLocalClient creates a client for the OpenAI-compatible server at baseURL.
+// baseURL may include the "/v1" prefix or the full "/v1/embeddings" path.
+func NewLocalClient(baseURL, model string) *LocalClient {
+	return &LocalClient{
+		BaseURL:   baseURL,
+		Model:     model,
+		BatchSize: defaultLocalBatchSize,
+		HTTPClient: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+// endpoint resolves BaseURL to the embeddings endpoint
+func (c *LocalClient) endpoint() string {
+	url := strings.TrimRight(c.BaseURL, "/")
+	switch {
+	case strings.HasSuffix(url, "/embeddings"):
+		return url
+	case strings.HasSuffix(url, "/v1"):
+		return url + "/embeddings"
+	default:
+		return url + "/v1/embeddings"
+	}
+}
+
+// Embed generates embeddings for multiple texts, in batches of BatchSize
+func (c *LocalClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	batchSize := c.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultLocalBatchSize
+	}
+
+	embeddings := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += batchSize {
+		batch, err := c.embedBatch(ctx, texts[start:min(start+batchSize, len(texts))])
+		if err != nil {
+			return nil, err
+		}
+		embeddings = append(embeddings, batch...)
+	}
+	return embeddings, nil
+}
+
+func (c *LocalClient) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	ctx, cancel := RequestContext(ctx, c.Timeout)
+	defer cancel()
+
+	bodyBytes, err := json.Marshal(openAIRequest{Input: texts, Model: c.Model})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint(), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("local embedding request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var apiResp openAIResponse
+	if resp.StatusCode != http.StatusOK {
+		if err := json.Unmarshal(bodyBytes, &apiResp); err == nil && apiResp.Error != nil {
+			return nil, provider.NewStatusError(resp.StatusCode, fmt.Errorf("local embedding server error (%d): %s", resp.StatusCode, apiResp.Error.Message))
+		}
+		return nil, provider.NewStatusError(resp.StatusCode, fmt.Errorf("local embedding server error (%d): %s", resp.StatusCode, string(bodyBytes)))
+	}
+	if err := json.Unmarshal(bodyBytes, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if apiResp.Error != nil {
+		return nil, fmt.Errorf("local embedding server error: %s", apiResp.Error.Message)
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for _, data := range apiResp.Data {
+		if data.Index < 0 || data.Index >= len(embeddings) {
+			return nil, fmt.Errorf("%w: invalid embedding index %d", ErrMalformedResponse, data.Index)
+		}
+		embeddings[data.Index] = data.Embedding
+	}
+	for i, embedding := range embeddings {
+		if embedding == nil {
+			return nil, fmt.Errorf("%w: no embedding returned for text %d", ErrMalformedResponse, i)
+		}
+	}
+	return embeddings, nil
+}
+
+// EmbedOne generates an embedding for a single text
+func (c *LocalClient) EmbedOne(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := c.Embed(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	if len(embeddings) == 0 {
+		return nil, fmt.Errorf("%w: no embeddings returned", ErrMalformedResponse)
+	}
+	return embeddings[0], nil
+}
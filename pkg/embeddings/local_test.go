@@ -0,0 +1,87 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocalClientEmbed(t *testing.T) {
+	var requests []openAIRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			t.Errorf("Expected /v1/embeddings, got %s", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Expected no Authorization header, got %s", auth)
+		}
+
+		var req openAIRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+
+		// Answer out of order, as the index field allows
+		var resp openAIResponse
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			resp.Data = append(resp.Data, struct {
+				Embedding []float32 `json:"embedding"`
+				Index     int       `json:"index"`
+			}{Embedding: []float32{float32(len(req.Input[i])), 1}, Index: i})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewLocalClient(server.URL, "nomic-embed-text")
+	client.BatchSize = 2
+
+	texts := []string{"a", "bb", "ccc", "dddd", "eeeee"}
+	embeddings, err := client.Embed(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if len(embeddings) != len(texts) {
+		t.Fatalf("Expected %d embeddings, got %d", len(texts), len(embeddings))
+	}
+	for i, text := range texts {
+		if embeddings[i][0] != float32(len(text)) {
+			t.Errorf("Embedding %d out of order: %v", i, embeddings[i])
+		}
+	}
+	if len(requests) != 3 || requests[0].Model != "nomic-embed-text" {
+		t.Errorf("Expected 3 batched requests for the model, got %+v", requests)
+	}
+}
+
+func TestLocalClientEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"http://localhost:8080":                "http://localhost:8080/v1/embeddings",
+		"http://localhost:1234/v1/":            "http://localhost:1234/v1/embeddings",
+		"http://localhost:8000/v1/embeddings":  "http://localhost:8000/v1/embeddings",
+		"http://gpu-box:8080/proxy/embeddings": "http://gpu-box:8080/proxy/embeddings",
+	}
+	for baseURL, want := range tests {
+		if got := NewLocalClient(baseURL, "").endpoint(); got != want {
+			t.Errorf("endpoint(%q) = %q, want %q", baseURL, got, want)
+		}
+	}
+}
+
+func TestLocalClientServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("Expected the API key as bearer token")
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": {"message": "input is too large to process"}}`))
+	}))
+	defer server.Close()
+
+	client := NewLocalClient(server.URL+"/v1", "")
+	client.APIKey = "secret"
+	if _, err := client.EmbedOne(context.Background(), "text"); err == nil {
+		t.Error("Expected an error for a rejected request")
+	}
+}
@@ -9,10 +9,15 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/dan-solli/gognee/pkg/provider"
 )
 
 // OllamaClient implements EmbeddingClient using local Ollama API
 type OllamaClient struct {
+	// Timeout bounds each request; zero leaves only the HTTP client's 60s limit.
+	// WithTimeout overrides it per context.
+	Timeout time.Duration
 	baseURL string
 	model   string
 	client  *http.Client
@@ -42,6 +47,9 @@ type ollamaEmbedResponse struct {
 
 // EmbedOne generates an embedding for a single text
 func (c *OllamaClient) EmbedOne(ctx context.Context, text string) ([]float32, error) {
+	ctx, cancel := RequestContext(ctx, c.Timeout)
+	defer cancel()
+
 	reqBody := ollamaEmbedRequest{
 		Model:  c.model,
 		Prompt: text,
@@ -66,7 +74,7 @@ func (c *OllamaClient) EmbedOne(ctx context.Context, text string) ([]float32, er
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("ollama returned %d: %s", resp.StatusCode, string(body))
+		return nil, provider.NewStatusError(resp.StatusCode, fmt.Errorf("ollama returned %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result ollamaEmbedResponse
@@ -7,20 +7,34 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/provider"
 )
 
 const (
 	defaultOpenAIURL  = "https://api.openai.com/v1/embeddings"
 	defaultModel      = "text-embedding-3-small"
 	defaultMaxRetries = 3
+
+	// DefaultAzureAPIVersion is the Azure OpenAI api-version used when none is given
+	DefaultAzureAPIVersion = "2024-10-21"
 )
 
 // OpenAIClient implements EmbeddingClient using OpenAI's API
 type OpenAIClient struct {
-	APIKey     string
-	Model      string
-	BaseURL    string
+	APIKey  string
+	Model   string
+	BaseURL string
+	// APIVersion selects Azure OpenAI when set: BaseURL is the resource endpoint,
+	// Model the deployment name, and APIKey is sent in the api-key header.
+	APIVersion string
 	HTTPClient *http.Client
+	// Timeout bounds each request; zero leaves it bounded by ctx only. WithTimeout
+	// overrides it per context.
+	Timeout time.Duration
 }
 
 // NewOpenAIClient creates a new OpenAI embedding client
@@ -33,6 +47,31 @@ func NewOpenAIClient(apiKey string) *OpenAIClient {
 	}
 }
 
+// NewAzureOpenAIClient creates a client for an Azure OpenAI embedding deployment.
+// endpoint is the resource URL, e.g. "https://my-resource.openai.azure.com";
+// apiVersion defaults to DefaultAzureAPIVersion.
+func NewAzureOpenAIClient(endpoint, deployment, apiVersion, apiKey string) *OpenAIClient {
+	if apiVersion == "" {
+		apiVersion = DefaultAzureAPIVersion
+	}
+	return &OpenAIClient{
+		APIKey:     apiKey,
+		Model:      deployment,
+		BaseURL:    strings.TrimRight(endpoint, "/"),
+		APIVersion: apiVersion,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// endpoint returns the embeddings URL for OpenAI or, with APIVersion, Azure OpenAI
+func (c *OpenAIClient) endpoint() string {
+	if c.APIVersion != "" {
+		return fmt.Sprintf("%s/openai/deployments/%s/embeddings?api-version=%s",
+			c.BaseURL, url.PathEscape(c.Model), url.QueryEscape(c.APIVersion))
+	}
+	return c.BaseURL
+}
+
 type openAIRequest struct {
 	Input []string `json:"input"`
 	Model string   `json:"model"`
@@ -57,6 +96,8 @@ func (c *OpenAIClient) Embed(ctx context.Context, texts []string) ([][]float32,
 	if len(texts) == 0 {
 		return [][]float32{}, nil
 	}
+	ctx, cancel := RequestContext(ctx, c.Timeout)
+	defer cancel()
 
 	reqBody := openAIRequest{
 		Input: texts,
@@ -68,13 +109,17 @@ func (c *OpenAIClient) Embed(ctx context.Context, texts []string) ([][]float32,
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL, bytes.NewReader(bodyBytes))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint(), bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	if c.APIVersion != "" {
+		req.Header.Set("api-key", c.APIKey)
+	} else {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
@@ -90,9 +135,9 @@ func (c *OpenAIClient) Embed(ctx context.Context, texts []string) ([][]float32,
 	if resp.StatusCode != http.StatusOK {
 		var apiResp openAIResponse
 		if err := json.Unmarshal(bodyBytes, &apiResp); err == nil && apiResp.Error != nil {
-			return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, apiResp.Error.Message)
+			return nil, provider.NewStatusError(resp.StatusCode, fmt.Errorf("API error (%d): %s", resp.StatusCode, apiResp.Error.Message))
 		}
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(bodyBytes))
+		return nil, provider.NewStatusError(resp.StatusCode, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(bodyBytes)))
 	}
 
 	var apiResp openAIResponse
@@ -108,7 +153,7 @@ func (c *OpenAIClient) Embed(ctx context.Context, texts []string) ([][]float32,
 	embeddings := make([][]float32, len(texts))
 	for _, data := range apiResp.Data {
 		if data.Index >= len(embeddings) {
-			return nil, fmt.Errorf("invalid embedding index: %d", data.Index)
+			return nil, fmt.Errorf("%w: invalid embedding index %d", ErrMalformedResponse, data.Index)
 		}
 		embeddings[data.Index] = data.Embedding
 	}
@@ -124,7 +169,7 @@ func (c *OpenAIClient) EmbedOne(ctx context.Context, text string) ([]float32, er
 	}
 
 	if len(embeddings) == 0 {
-		return nil, fmt.Errorf("no embeddings returned")
+		return nil, fmt.Errorf("%w: no embeddings returned", ErrMalformedResponse)
 	}
 
 	return embeddings[0], nil
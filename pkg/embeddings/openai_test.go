@@ -192,3 +192,30 @@ func TestOpenAIClientContextCancellation(t *testing.T) {
 		t.Fatal("Expected error for cancelled context")
 	}
 }
+
+func TestAzureOpenAIClientEmbed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openai/deployments/embed-prod/embeddings" {
+			t.Errorf("Expected the deployment path, got %s", r.URL.Path)
+		}
+		if v := r.URL.Query().Get("api-version"); v != DefaultAzureAPIVersion {
+			t.Errorf("Expected api-version %s, got %s", DefaultAzureAPIVersion, v)
+		}
+		if r.Header.Get("api-key") != "azure-key" || r.Header.Get("Authorization") != "" {
+			t.Errorf("Expected the key in the api-key header only")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"embedding": [0.5, 0.25], "index": 0}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAzureOpenAIClient(server.URL+"/", "embed-prod", "", "azure-key")
+	embedding, err := client.EmbedOne(context.Background(), "test text")
+	if err != nil {
+		t.Fatalf("EmbedOne failed: %v", err)
+	}
+	if len(embedding) != 2 || embedding[0] != 0.5 {
+		t.Errorf("Unexpected embedding %v", embedding)
+	}
+}
@@ -0,0 +1,41 @@
+package embeddings
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOpenAIClient_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewOpenAIClient("test-key")
+	client.BaseURL = server.URL
+	client.Timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	if _, err := client.EmbedOne(context.Background(), "slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the request cut at the timeout, took %v", elapsed)
+	}
+
+	// A per-context timeout overrides the client's
+	client.Timeout = time.Hour
+	ctx := WithTimeout(context.Background(), 50*time.Millisecond)
+	if _, err := client.EmbedOne(ctx, "slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the context's timeout to apply, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package extraction
+
+// clampConfidence bounds a model-reported confidence to [0, 1].
+func clampConfidence(c float64) float64 {
+	if c < 0 {
+		return 0
+	}
+	if c > 1 {
+		return 1
+	}
+	return c
+}
+
+// FilterEntitiesByConfidence drops entities whose confidence is below min.
+// Entities without a reported confidence (zero) are kept, as are all entities when min <= 0.
+// Returns the kept entities and the number dropped.
+func FilterEntitiesByConfidence(entities []Entity, min float64) ([]Entity, int) {
+	if min <= 0 {
+		return entities, 0
+	}
+
+	kept := make([]Entity, 0, len(entities))
+	for _, entity := range entities {
+		if entity.Confidence > 0 && entity.Confidence < min {
+			continue
+		}
+		kept = append(kept, entity)
+	}
+	return kept, len(entities) - len(kept)
+}
+
+// FilterTripletsByConfidence drops triplets whose confidence is below min.
+// Triplets without a reported confidence (zero) are kept, as are all triplets when min <= 0.
+// Returns the kept triplets and the number dropped.
+func FilterTripletsByConfidence(triplets []Triplet, min float64) ([]Triplet, int) {
+	if min <= 0 {
+		return triplets, 0
+	}
+
+	kept := make([]Triplet, 0, len(triplets))
+	for _, triplet := range triplets {
+		if triplet.Confidence > 0 && triplet.Confidence < min {
+			continue
+		}
+		kept = append(kept, triplet)
+	}
+	return kept, len(triplets) - len(kept)
+}
@@ -0,0 +1,71 @@
+package extraction
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFilterEntitiesByConfidence(t *testing.T) {
+	entities := []Entity{
+		{Name: "Go", Type: "Technology", Description: "A language", Confidence: 0.95},
+		{Name: "It", Type: "Concept", Description: "A pronoun", Confidence: 0.2},
+		{Name: "SQLite", Type: "Technology", Description: "A database"}, // unscored
+	}
+
+	kept, dropped := FilterEntitiesByConfidence(entities, 0.5)
+	if dropped != 1 {
+		t.Errorf("Expected 1 dropped entity, got %d", dropped)
+	}
+	if len(kept) != 2 || kept[0].Name != "Go" || kept[1].Name != "SQLite" {
+		t.Errorf("Unexpected kept entities: %+v", kept)
+	}
+
+	kept, dropped = FilterEntitiesByConfidence(entities, 0)
+	if dropped != 0 || len(kept) != 3 {
+		t.Errorf("Expected no filtering with zero threshold, got %d kept, %d dropped", len(kept), dropped)
+	}
+}
+
+func TestFilterTripletsByConfidence(t *testing.T) {
+	triplets := []Triplet{
+		{Subject: "A", Relation: "USES", Object: "B", Confidence: 0.9},
+		{Subject: "A", Relation: "RELATES_TO", Object: "C", Confidence: 0.3},
+		{Subject: "B", Relation: "DEPENDS_ON", Object: "C"},
+	}
+
+	kept, dropped := FilterTripletsByConfidence(triplets, 0.5)
+	if dropped != 1 {
+		t.Errorf("Expected 1 dropped triplet, got %d", dropped)
+	}
+	if len(kept) != 2 || kept[0].Relation != "USES" || kept[1].Relation != "DEPENDS_ON" {
+		t.Errorf("Unexpected kept triplets: %+v", kept)
+	}
+}
+
+func TestExtract_ConfidenceParsedAndClamped(t *testing.T) {
+	fakeLLM := &fakeLLMClient{response: `[
+		{"name": "Go", "type": "Technology", "description": "A language", "confidence": 0.8},
+		{"name": "Rust", "type": "Technology", "description": "A language", "confidence": 1.7},
+		{"name": "C", "type": "Technology", "description": "A language"}
+	]`}
+
+	entities, err := NewEntityExtractor(fakeLLM).Extract(context.Background(), "Go, Rust and C")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	want := []float64{0.8, 1, 0}
+	for i, entity := range entities {
+		if entity.Confidence != want[i] {
+			t.Errorf("Entity %s: expected confidence %v, got %v", entity.Name, want[i], entity.Confidence)
+		}
+	}
+
+	fakeLLM.response = `[{"subject": "Go", "relation": "RELATES_TO", "object": "Rust", "confidence": -0.5}]`
+	triplets, err := NewRelationExtractor(fakeLLM).Extract(context.Background(), "Go, Rust and C", entities)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(triplets) != 1 || triplets[0].Confidence != 0 {
+		t.Errorf("Expected one triplet with confidence clamped to 0, got %+v", triplets)
+	}
+}
@@ -0,0 +1,118 @@
+package extraction
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dan-solli/gognee/pkg/llm"
+)
+
+// DescriptionMerger combines an entity's stored description with the description a later
+// mention gives it, so what is known about the entity accumulates instead of the newest
+// mention overwriting it.
+type DescriptionMerger interface {
+	MergeDescriptions(ctx context.Context, name, existing, incoming string) (string, error)
+}
+
+// defaultMaxDescriptionLength caps descriptions grown by AppendDescriptions
+const defaultMaxDescriptionLength = 1000
+
+// AppendDescriptions merges by appending the sentences of the new description that the
+// stored one lacks, compared case-insensitively. No LLM call.
+type AppendDescriptions struct {
+	// MaxLength stops appending sentences that would make the description longer than
+	// this many bytes (default: 1000). The stored description is never cut.
+	MaxLength int
+}
+
+// MergeDescriptions appends the new sentences of incoming to existing.
+func (a AppendDescriptions) MergeDescriptions(ctx context.Context, name, existing, incoming string) (string, error) {
+	maxLength := a.MaxLength
+	if maxLength <= 0 {
+		maxLength = defaultMaxDescriptionLength
+	}
+	merged := strings.TrimSpace(existing)
+	if merged == "" {
+		return strings.TrimSpace(incoming), nil
+	}
+
+	known := make(map[string]bool)
+	for _, sentence := range splitSentences(merged) {
+		known[sentenceKey(sentence)] = true
+	}
+	for _, sentence := range splitSentences(incoming) {
+		key := sentenceKey(sentence)
+		if key == "" || known[key] {
+			continue
+		}
+		if !strings.ContainsAny(merged[len(merged)-1:], ".!?;") {
+			merged += "."
+		}
+		if len(merged)+1+len(sentence) > maxLength {
+			break
+		}
+		known[key] = true
+		merged += " " + sentence
+	}
+	return merged, nil
+}
+
+// splitSentences splits text after sentence-ending punctuation followed by a space
+func splitSentences(text string) []string {
+	var sentences []string
+	start := 0
+	for i := 0; i < len(text); i++ {
+		if strings.IndexByte(".!?;", text[i]) >= 0 && (i+1 == len(text) || text[i+1] == ' ' || text[i+1] == '\n') {
+			if sentence := strings.TrimSpace(text[start : i+1]); sentence != "" {
+				sentences = append(sentences, sentence)
+			}
+			start = i + 1
+		}
+	}
+	if sentence := strings.TrimSpace(text[start:]); sentence != "" {
+		sentences = append(sentences, sentence)
+	}
+	return sentences
+}
+
+// sentenceKey normalizes a sentence for duplicate detection
+func sentenceKey(sentence string) string {
+	return strings.ToLower(strings.TrimRight(strings.TrimSpace(sentence), ".!?; "))
+}
+
+// llmDescriptionMergePrompt asks the LLM to merge two descriptions of an entity
+const llmDescriptionMergePrompt = `You maintain a knowledge base of entities.
+
+Merge the two descriptions of %q below into one concise description of at most three
+sentences. Keep every distinct fact from both; when they contradict, prefer the new one.
+
+Stored description: %s
+New description: %s
+
+Return ONLY valid JSON:
+{"description": "..."}`
+
+// LLMDescriptionMerger has an LLM rewrite both descriptions into one, keeping distinct
+// facts and preferring the new one on conflicts. One LLM call per merge; an empty answer
+// falls back to AppendDescriptions.
+type LLMDescriptionMerger struct {
+	LLM llm.LLMClient
+}
+
+// MergeDescriptions merges existing and incoming with one LLM call.
+func (m *LLMDescriptionMerger) MergeDescriptions(ctx context.Context, name, existing, incoming string) (string, error) {
+	if strings.TrimSpace(existing) == "" || strings.TrimSpace(incoming) == "" {
+		return AppendDescriptions{}.MergeDescriptions(ctx, name, existing, incoming)
+	}
+	var response struct {
+		Description string `json:"description"`
+	}
+	if err := m.LLM.CompleteWithSchema(ctx, fmt.Sprintf(llmDescriptionMergePrompt, name, existing, incoming), &response); err != nil {
+		return "", fmt.Errorf("description merge failed: %w", err)
+	}
+	if merged := strings.TrimSpace(response.Description); merged != "" {
+		return merged, nil
+	}
+	return AppendDescriptions{}.MergeDescriptions(ctx, name, existing, incoming)
+}
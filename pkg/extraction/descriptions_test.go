@@ -0,0 +1,66 @@
+package extraction
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAppendDescriptions(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		merger   AppendDescriptions
+		existing string
+		incoming string
+		want     string
+	}{
+		{"new sentence appended", AppendDescriptions{}, "A programming language.", "Created at Google.", "A programming language. Created at Google."},
+		{"duplicate dropped", AppendDescriptions{}, "A programming language. Created at Google.", "created at google", "A programming language. Created at Google."},
+		{"period added", AppendDescriptions{}, "A language", "Compiled.", "A language. Compiled."},
+		{"empty existing", AppendDescriptions{}, "", "Compiled.", "Compiled."},
+		{"capped", AppendDescriptions{MaxLength: 20}, "A language.", "Compiled to machine code.", "A language."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.merger.MergeDescriptions(ctx, "Go", tt.existing, tt.incoming)
+			if err != nil {
+				t.Fatalf("MergeDescriptions failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLLMDescriptionMerger(t *testing.T) {
+	ctx := context.Background()
+	var prompt string
+	merger := &LLMDescriptionMerger{LLM: &fakeLLMClient{
+		response:      `{"description": "A compiled language created at Google."}`,
+		capturePrompt: func(p string) { prompt = p },
+	}}
+	got, err := merger.MergeDescriptions(ctx, "Go", "A compiled language.", "Created at Google.")
+	if err != nil {
+		t.Fatalf("MergeDescriptions failed: %v", err)
+	}
+	if got != "A compiled language created at Google." {
+		t.Errorf("unexpected merge %q", got)
+	}
+	if !strings.Contains(prompt, "A compiled language.") || !strings.Contains(prompt, "Created at Google.") {
+		t.Errorf("expected both descriptions in the prompt, got %q", prompt)
+	}
+
+	// An empty answer falls back to appending
+	merger.LLM = &fakeLLMClient{response: `{"description": ""}`}
+	if got, _ := merger.MergeDescriptions(ctx, "Go", "A language.", "Compiled."); got != "A language. Compiled." {
+		t.Errorf("expected the appended fallback, got %q", got)
+	}
+
+	merger.LLM = &fakeLLMClient{err: errors.New("unavailable")}
+	if _, err := merger.MergeDescriptions(ctx, "Go", "A language.", "Compiled."); err == nil {
+		t.Error("expected the LLM error")
+	}
+}
@@ -4,7 +4,8 @@ package extraction
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"strings"
 
 	"github.com/dan-solli/gognee/pkg/llm"
 )
@@ -14,6 +15,9 @@ type Entity struct {
 	Name        string `json:"name"`
 	Type        string `json:"type"`
 	Description string `json:"description"`
+	// Confidence is the model's certainty in the extraction, from 0 to 1.
+	// Zero means the model did not report a confidence.
+	Confidence float64 `json:"confidence,omitempty"`
 }
 
 // Valid entity types from the roadmap
@@ -38,6 +42,12 @@ var validEntityTypes = map[string]bool{
 	"Task":         true,
 }
 
+// builtinEntityTypes lists validEntityTypes in prompt order, for ontologies without entity types
+var builtinEntityTypes = []string{
+	"Person", "Concept", "System", "Decision", "Event", "Technology", "Pattern", "Problem", "Goal",
+	"Location", "Organization", "Document", "Process", "Requirement", "Feature", "Task",
+}
+
 // entityExtractionPrompt is the prompt template for entity extraction
 const entityExtractionPrompt = `You are a knowledge graph construction assistant.
 
@@ -45,6 +55,27 @@ Extract all meaningful entities from this text. For each entity, provide:
 - name: The entity name
 - type: One of [Person, Concept, System, Decision, Event, Technology, Pattern, Problem, Goal, Location, Organization, Document, Process, Requirement, Feature, Task]
 - description: Brief description (1 sentence)
+- confidence: How certain you are that this is a real, meaningful entity (0.0 to 1.0)
+
+Text:
+---
+%s
+---
+
+Return ONLY valid JSON array:
+[{"name": "...", "type": "...", "description": "...", "confidence": 0.9}, ...]`
+
+// ontologyEntityPrompt is entityExtractionPrompt with the types of an Ontology
+const ontologyEntityPrompt = `You are a knowledge graph construction assistant.
+
+Extract all meaningful entities from this text. For each entity, provide:
+- name: The entity name
+- type: One of [%s]
+- description: Brief description (1 sentence)
+- confidence: How certain you are that this is a real, meaningful entity (0.0 to 1.0)
+
+Entity types:
+%s
 
 Text:
 ---
@@ -52,11 +83,17 @@ Text:
 ---
 
 Return ONLY valid JSON array:
-[{"name": "...", "type": "...", "description": "..."}, ...]`
+[{"name": "...", "type": "...", "description": "...", "confidence": 0.9}, ...]`
 
 // EntityExtractor extracts entities from text using an LLM
 type EntityExtractor struct {
 	LLM llm.LLMClient
+
+	// Ontology, when set, replaces the built-in types in the prompt and decides
+	// what happens to entities of other types.
+	Ontology *Ontology
+
+	logger *slog.Logger // Warns about entity types outside the ontology (see SetLogger)
 }
 
 // NewEntityExtractor creates a new entity extractor
@@ -66,6 +103,24 @@ func NewEntityExtractor(llmClient llm.LLMClient) *EntityExtractor {
 	}
 }
 
+// SetLogger sets the structured logger that warns about rejected, coerced and normalized
+// entity types. When nil, logging is disabled.
+func (e *EntityExtractor) SetLogger(logger *slog.Logger) {
+	if logger != nil {
+		logger = logger.With(slog.String("component", "extraction.entities"))
+	}
+	e.logger = logger
+}
+
+// warnType logs a warning about an entity's type. Only the type is logged, never the
+// entity's name (user content, M10 security fix).
+func (e *EntityExtractor) warnType(ctx context.Context, msg, entityType string, attrs ...slog.Attr) {
+	if e.logger == nil {
+		return
+	}
+	e.logger.LogAttrs(ctx, slog.LevelWarn, msg, append([]slog.Attr{slog.String("type", entityType)}, attrs...)...)
+}
+
 // Extract extracts entities from the given text
 func (e *EntityExtractor) Extract(ctx context.Context, text string) ([]Entity, error) {
 	if text == "" {
@@ -73,6 +128,10 @@ func (e *EntityExtractor) Extract(ctx context.Context, text string) ([]Entity, e
 	}
 
 	prompt := fmt.Sprintf(entityExtractionPrompt, text)
+	if e.Ontology != nil {
+		types := e.Ontology.entityTypes()
+		prompt = fmt.Sprintf(ontologyEntityPrompt, typeNames(types), describeTypes(types), text)
+	}
 
 	var entities []Entity
 	if err := e.LLM.CompleteWithSchema(ctx, prompt, &entities); err != nil {
@@ -80,6 +139,7 @@ func (e *EntityExtractor) Extract(ctx context.Context, text string) ([]Entity, e
 	}
 
 	// Validate entities
+	kept := entities[:0]
 	for i, entity := range entities {
 		// Check required fields
 		if entity.Name == "" {
@@ -92,14 +152,26 @@ func (e *EntityExtractor) Extract(ctx context.Context, text string) ([]Entity, e
 			return nil, fmt.Errorf("entity at index %d (%s) has empty description", i, entity.Name)
 		}
 
-		// Normalize unknown types to Concept with warning
-		if !validEntityTypes[entity.Type] {
-			// M10: Security fix - don't log entity.Name (user content)
-			// Log type only (safe per security review)
-			log.Printf("gognee: entity with unrecognized type %q, normalizing to Concept", entity.Type)
-			entities[i].Type = "Concept"
+		entity.Confidence = clampConfidence(entity.Confidence)
+
+		if e.Ontology != nil {
+			t, ok := e.Ontology.EntityType(entity.Type)
+			if !ok {
+				e.warnType(ctx, "entity type outside the ontology, rejecting", entity.Type)
+				continue
+			}
+			if t != entity.Type && !strings.EqualFold(t, entity.Type) {
+				e.warnType(ctx, "entity type outside the ontology, coercing", entity.Type, slog.String("coerced_to", t))
+			}
+			entity.Type = t
+		} else if !validEntityTypes[entity.Type] {
+			// Normalize unknown types to Concept with warning
+			e.warnType(ctx, "unrecognized entity type, normalizing to Concept", entity.Type)
+			entity.Type = "Concept"
 		}
+
+		kept = append(kept, entity)
 	}
 
-	return entities, nil
+	return kept, nil
 }
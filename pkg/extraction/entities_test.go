@@ -5,7 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings"
 	"testing"
 
@@ -232,9 +232,7 @@ func TestEntityExtractorExtract_UnknownTypeNormalization(t *testing.T) {
 
 	// Capture log output
 	var logBuf bytes.Buffer
-	originalOutput := log.Writer()
-	log.SetOutput(&logBuf)
-	defer log.SetOutput(originalOutput)
+	extractor.SetLogger(slog.New(slog.NewTextHandler(&logBuf, nil)))
 
 	result, err := extractor.Extract(context.Background(), "Some text")
 	if err != nil {
@@ -252,8 +250,8 @@ func TestEntityExtractorExtract_UnknownTypeNormalization(t *testing.T) {
 
 	// Verify warning was logged (M10: entity NAMES must not be logged per security fix)
 	logOutput := logBuf.String()
-	if !strings.Contains(logOutput, "gognee:") {
-		t.Errorf("Expected log to contain 'gognee:' prefix, got: %s", logOutput)
+	if !strings.Contains(logOutput, "level=WARN") || !strings.Contains(logOutput, "component=extraction.entities") {
+		t.Errorf("Expected a warning from the entity extractor, got: %s", logOutput)
 	}
 	// M10 SECURITY: entity names must NOT appear in logs
 	if strings.Contains(logOutput, "SomeProblemInstance") {
@@ -281,9 +279,7 @@ func TestEntityExtractorExtract_MultipleUnknownTypes(t *testing.T) {
 
 	// Capture log output
 	var logBuf bytes.Buffer
-	originalOutput := log.Writer()
-	log.SetOutput(&logBuf)
-	defer log.SetOutput(originalOutput)
+	extractor.SetLogger(slog.New(slog.NewTextHandler(&logBuf, nil)))
 
 	result, err := extractor.Extract(context.Background(), "Some text")
 	if err != nil {
@@ -307,7 +303,7 @@ func TestEntityExtractorExtract_MultipleUnknownTypes(t *testing.T) {
 
 	// Verify two warnings were logged (one for each unknown type)
 	logOutput := logBuf.String()
-	warningCount := strings.Count(logOutput, "gognee:")
+	warningCount := strings.Count(logOutput, "level=WARN")
 	if warningCount != 2 {
 		t.Errorf("Expected 2 warnings, got %d. Log output: %s", warningCount, logOutput)
 	}
@@ -331,9 +327,7 @@ func TestEntityExtractorExtract_InvalidType(t *testing.T) {
 
 	// Capture log output
 	var logBuf bytes.Buffer
-	originalOutput := log.Writer()
-	log.SetOutput(&logBuf)
-	defer log.SetOutput(originalOutput)
+	extractor.SetLogger(slog.New(slog.NewTextHandler(&logBuf, nil)))
 
 	result, err := extractor.Extract(context.Background(), "Some text")
 	if err != nil {
@@ -0,0 +1,162 @@
+package extraction
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dan-solli/gognee/pkg/llm"
+)
+
+// DefaultStopwords are entity names that carry no meaning on their own:
+// pronouns, demonstratives and generic references.
+var DefaultStopwords = []string{
+	"it", "its", "they", "them", "he", "she", "him", "her", "we", "us", "i", "me", "you",
+	"this", "that", "these", "those", "there", "here",
+	"someone", "something", "somebody", "anyone", "anything", "everyone", "everything",
+	"nothing", "none", "thing", "things", "stuff", "one", "other", "others",
+	"team", "the team", "our team", "user", "users", "the user", "people", "person",
+	"today", "yesterday", "tomorrow", "now", "then",
+}
+
+// monthPattern matches full and abbreviated month names
+const monthPattern = `(jan(uary)?|feb(ruary)?|mar(ch)?|apr(il)?|may|june?|july?|aug(ust)?|sep(t(ember)?)?|oct(ober)?|nov(ember)?|dec(ember)?)`
+
+// dateLikePattern matches names that are just dates, times, numbers or weekdays
+var dateLikePattern = regexp.MustCompile(`(?i)^(` +
+	`\d+([.,:/-]\d+)*` + // 42, 2024-01-05, 1/5/24, 10:30
+	`|(q[1-4]\s+)?\d{4}` + // 2024, Q3 2024
+	`|` + monthPattern + `\.?(\s+\d{1,2}(st|nd|rd|th)?)?(,?\s+\d{4})?` + // March, March 5, March 2024
+	`|\d{1,2}(st|nd|rd|th)?\s+` + monthPattern + `(\s+\d{4})?` + // 5 March 2024
+	`|(mon|tues|wednes|thurs|fri|satur|sun)day` +
+	`)$`)
+
+// IsDateLike reports whether an entity name is only a date, time, number or weekday.
+func IsDateLike(name string) bool {
+	return dateLikePattern.MatchString(strings.TrimSpace(name))
+}
+
+// EntityFilter removes junk entities after extraction. Checks run in order:
+// stoplist, minimum name length, type-specific validators, then the optional LLM sanity check.
+// The zero value filters nothing; NewEntityFilter returns a filter with sensible defaults.
+type EntityFilter struct {
+	// Stopwords are rejected entity names, matched case-insensitively with whitespace
+	// collapsed. A leading "the", "a" or "an" is ignored, so "The team" matches "team".
+	Stopwords []string
+
+	// MinNameLength rejects names shorter than this many characters (0 = no minimum).
+	MinNameLength int
+
+	// TypeValidators reject entities of the keyed type when the validator returns false.
+	TypeValidators map[string]func(Entity) bool
+
+	// SanityCheck, when set, asks this LLM which of the remaining entities are meaningful
+	// and drops the rest. One extra LLM call per chunk.
+	SanityCheck llm.LLMClient
+}
+
+// NewEntityFilter returns a filter using DefaultStopwords, a minimum name length of 2,
+// and a validator rejecting date-like Concepts. The LLM sanity check is disabled.
+func NewEntityFilter() *EntityFilter {
+	return &EntityFilter{
+		Stopwords:     DefaultStopwords,
+		MinNameLength: 2,
+		TypeValidators: map[string]func(Entity) bool{
+			"Concept": func(e Entity) bool { return !IsDateLike(e.Name) },
+		},
+	}
+}
+
+// sanityCheckPrompt asks the LLM to keep only meaningful entities
+const sanityCheckPrompt = `You are reviewing entities extracted for a knowledge graph.
+
+Keep only entities that are specific, meaningful things worth remembering (people, systems,
+technologies, decisions, named concepts). Drop pronouns, vague references, bare dates and filler.
+
+Entities:
+%s
+
+Return ONLY a valid JSON array of the names to keep, exactly as written above:
+["...", ...]`
+
+// Filter returns the entities that pass every check and the number removed.
+// If the LLM sanity check fails, the entities that passed the local checks are
+// returned together with the error.
+func (f *EntityFilter) Filter(ctx context.Context, entities []Entity) ([]Entity, int, error) {
+	if f == nil || len(entities) == 0 {
+		return entities, 0, nil
+	}
+	stopwords := make(map[string]bool, len(f.Stopwords))
+	for _, word := range f.Stopwords {
+		stopwords[normalizeStopword(word)] = true
+	}
+
+	kept := make([]Entity, 0, len(entities))
+	for _, entity := range entities {
+		if f.rejects(entity, stopwords) {
+			continue
+		}
+		kept = append(kept, entity)
+	}
+
+	if f.SanityCheck != nil && len(kept) > 0 {
+		checked, err := f.sanityCheck(ctx, kept)
+		if err != nil {
+			return kept, len(entities) - len(kept), err
+		}
+		kept = checked
+	}
+
+	return kept, len(entities) - len(kept), nil
+}
+
+// rejects applies the local (non-LLM) checks to one entity
+func (f *EntityFilter) rejects(entity Entity, stopwords map[string]bool) bool {
+	if stopwords[normalizeStopword(entity.Name)] {
+		return true
+	}
+	if f.MinNameLength > 0 && utf8.RuneCountInString(strings.TrimSpace(entity.Name)) < f.MinNameLength {
+		return true
+	}
+	if validate, ok := f.TypeValidators[entity.Type]; ok && !validate(entity) {
+		return true
+	}
+	return false
+}
+
+// sanityCheck keeps the entities the LLM names as meaningful
+func (f *EntityFilter) sanityCheck(ctx context.Context, entities []Entity) ([]Entity, error) {
+	lines := make([]string, len(entities))
+	for i, entity := range entities {
+		lines[i] = fmt.Sprintf("- %s (%s): %s", entity.Name, entity.Type, entity.Description)
+	}
+
+	var keep []string
+	if err := f.SanityCheck.CompleteWithSchema(ctx, fmt.Sprintf(sanityCheckPrompt, strings.Join(lines, "\n")), &keep); err != nil {
+		return nil, fmt.Errorf("entity sanity check failed: %w", err)
+	}
+
+	keepSet := make(map[string]bool, len(keep))
+	for _, name := range keep {
+		keepSet[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	result := make([]Entity, 0, len(entities))
+	for _, entity := range entities {
+		if keepSet[strings.ToLower(strings.TrimSpace(entity.Name))] {
+			result = append(result, entity)
+		}
+	}
+	return result, nil
+}
+
+// normalizeStopword lowercases, collapses whitespace and strips a leading article
+func normalizeStopword(name string) string {
+	fields := strings.Fields(strings.ToLower(name))
+	if len(fields) > 1 && (fields[0] == "the" || fields[0] == "a" || fields[0] == "an") {
+		fields = fields[1:]
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,117 @@
+package extraction
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestEntityFilter_Defaults(t *testing.T) {
+	entities := []Entity{
+		{Name: "Go", Type: "Technology", Description: "A language"},
+		{Name: "It", Type: "Concept", Description: "A pronoun"},
+		{Name: "The team", Type: "Organization", Description: "Some team"},
+		{Name: "X", Type: "Concept", Description: "Too short"},
+		{Name: "2024-01-05", Type: "Concept", Description: "A date"},
+		{Name: "March 2024", Type: "Concept", Description: "A month"},
+		{Name: "Release 2024", Type: "Event", Description: "A release"},
+		{Name: "Event Sourcing", Type: "Pattern", Description: "A pattern"},
+	}
+
+	kept, dropped, err := NewEntityFilter().Filter(context.Background(), entities)
+	if err != nil {
+		t.Fatalf("Filter failed: %v", err)
+	}
+	if dropped != 5 {
+		t.Errorf("Expected 5 dropped entities, got %d", dropped)
+	}
+	want := []string{"Go", "Release 2024", "Event Sourcing"}
+	if len(kept) != len(want) {
+		t.Fatalf("Expected %v, got %+v", want, kept)
+	}
+	for i, name := range want {
+		if kept[i].Name != name {
+			t.Errorf("kept[%d] = %q, want %q", i, kept[i].Name, name)
+		}
+	}
+}
+
+func TestEntityFilter_NilAndZeroValue(t *testing.T) {
+	entities := []Entity{{Name: "It", Type: "Concept", Description: "A pronoun"}}
+
+	var nilFilter *EntityFilter
+	if kept, dropped, _ := nilFilter.Filter(context.Background(), entities); dropped != 0 || len(kept) != 1 {
+		t.Errorf("Expected nil filter to keep everything, got %d kept, %d dropped", len(kept), dropped)
+	}
+	if kept, dropped, _ := (&EntityFilter{}).Filter(context.Background(), entities); dropped != 0 || len(kept) != 1 {
+		t.Errorf("Expected zero-value filter to keep everything, got %d kept, %d dropped", len(kept), dropped)
+	}
+}
+
+func TestEntityFilter_CustomValidator(t *testing.T) {
+	filter := &EntityFilter{
+		TypeValidators: map[string]func(Entity) bool{
+			"Person": func(e Entity) bool { return e.Description != "" },
+		},
+	}
+	entities := []Entity{
+		{Name: "Alice", Type: "Person", Description: "An engineer"},
+		{Name: "Bob", Type: "Person"},
+		{Name: "Redis", Type: "Technology"},
+	}
+
+	kept, dropped, _ := filter.Filter(context.Background(), entities)
+	if dropped != 1 || len(kept) != 2 || kept[1].Name != "Redis" {
+		t.Errorf("Unexpected result: %d dropped, kept %+v", dropped, kept)
+	}
+}
+
+func TestEntityFilter_SanityCheck(t *testing.T) {
+	fake := &fakeLLMClient{response: `["go", "Kubernetes"]`}
+	filter := &EntityFilter{SanityCheck: fake}
+	entities := []Entity{
+		{Name: "Go", Type: "Technology", Description: "A language"},
+		{Name: "Kubernetes", Type: "Technology", Description: "An orchestrator"},
+		{Name: "Stuff", Type: "Concept", Description: "Filler"},
+	}
+
+	kept, dropped, err := filter.Filter(context.Background(), entities)
+	if err != nil {
+		t.Fatalf("Filter failed: %v", err)
+	}
+	if dropped != 1 || len(kept) != 2 {
+		t.Errorf("Expected sanity check to drop 1 entity, got %d dropped, kept %+v", dropped, kept)
+	}
+}
+
+func TestEntityFilter_SanityCheckError(t *testing.T) {
+	filter := &EntityFilter{
+		Stopwords:   []string{"it"},
+		SanityCheck: &fakeLLMClient{err: errors.New("llm down")},
+	}
+	entities := []Entity{
+		{Name: "Go", Type: "Technology", Description: "A language"},
+		{Name: "it", Type: "Concept", Description: "A pronoun"},
+	}
+
+	kept, dropped, err := filter.Filter(context.Background(), entities)
+	if err == nil {
+		t.Fatal("Expected sanity check error")
+	}
+	if dropped != 1 || len(kept) != 1 || kept[0].Name != "Go" {
+		t.Errorf("Expected locally filtered entities on error, got %d dropped, kept %+v", dropped, kept)
+	}
+}
+
+func TestIsDateLike(t *testing.T) {
+	for _, name := range []string{"2024", "2024-01-05", "1/5/24", "10:30", "Q3 2024", "March", "March 5th, 2024", "5 March 2024", "Tuesday"} {
+		if !IsDateLike(name) {
+			t.Errorf("Expected %q to be date-like", name)
+		}
+	}
+	for _, name := range []string{"Go 1.22", "Marchetti", "Windows 95 Migration", "SQLite"} {
+		if IsDateLike(name) {
+			t.Errorf("Expected %q not to be date-like", name)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package extraction
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// injectionPattern matches text that addresses the model rather than describing the world:
+// override instructions, role and chat-template markers, and exfiltration requests.
+var injectionPattern = regexp.MustCompile(`(?i)(` +
+	`\b(ignore|disregard|forget|override)\b.{0,40}\b(instructions?|prompts?|rules|guidelines|context)\b` +
+	`|\b(system|developer)\s+prompt\b` +
+	`|\byou\s+are\s+now\b` +
+	`|\bnew\s+instructions?\b` +
+	`|\bdo\s+not\s+follow\b` +
+	`|<\|?(im_start|im_end|system|endoftext)\|?>` +
+	`|\[/?INST\]` +
+	`|^\s*(system|assistant|user)\s*:` +
+	`|\b(send|post|upload|exfiltrate|forward)\b.{0,40}\b(to|at)\s+(https?://|www\.)` +
+	`)`)
+
+// urlPattern matches URLs and bare web addresses
+var urlPattern = regexp.MustCompile(`(?i)\b(https?|ftp|file)://\S+|\bwww\.\S+`)
+
+// Guardrail validates LLM extraction output before it reaches the graph, protecting it
+// from content poisoned by prompt injection in ingested documents. Entity and relation
+// names containing instructions or URLs, or exceeding the length limits, are rejected;
+// descriptions are sanitized (URLs removed, injected instructions dropped, length truncated).
+// A nil *Guardrail accepts everything; NewGuardrail returns one with sensible limits.
+type Guardrail struct {
+	// MaxNameLength rejects entities, subjects and objects with longer names (0 = no limit).
+	MaxNameLength int
+
+	// MaxDescriptionLength truncates longer entity descriptions (0 = no limit).
+	MaxDescriptionLength int
+
+	// MaxRelationLength rejects relation labels longer than this (0 = no limit).
+	MaxRelationLength int
+
+	// AllowURLs keeps URLs in names and descriptions.
+	AllowURLs bool
+}
+
+// NewGuardrail returns a guardrail limiting names to 100 characters, descriptions to 500
+// and relation labels to 64, with URLs disallowed.
+func NewGuardrail() *Guardrail {
+	return &Guardrail{
+		MaxNameLength:        100,
+		MaxDescriptionLength: 500,
+		MaxRelationLength:    64,
+	}
+}
+
+// GuardrailReport summarizes what a guardrail check removed or changed.
+type GuardrailReport struct {
+	Rejected   int      // Entities or triplets dropped
+	Sanitized  int      // Entities kept with a cleaned description
+	Violations []string // One human-readable entry per rejection or sanitization
+}
+
+// Flagged reports whether the checked output contained anything suspicious.
+func (r GuardrailReport) Flagged() bool {
+	return r.Rejected > 0 || r.Sanitized > 0
+}
+
+// Merge adds the counts and violations of other to r.
+func (r *GuardrailReport) Merge(other GuardrailReport) {
+	r.Rejected += other.Rejected
+	r.Sanitized += other.Sanitized
+	r.Violations = append(r.Violations, other.Violations...)
+}
+
+// CheckEntities returns the entities that pass the guardrail, with descriptions sanitized.
+func (g *Guardrail) CheckEntities(entities []Entity) ([]Entity, GuardrailReport) {
+	var report GuardrailReport
+	if g == nil || len(entities) == 0 {
+		return entities, report
+	}
+
+	kept := make([]Entity, 0, len(entities))
+	for _, entity := range entities {
+		if reason := g.nameViolation(entity.Name); reason != "" {
+			report.Rejected++
+			report.Violations = append(report.Violations, fmt.Sprintf("entity %q rejected: %s", truncateRunes(entity.Name, 60), reason))
+			continue
+		}
+
+		if description, reasons := g.sanitizeDescription(entity.Description); len(reasons) > 0 {
+			entity.Description = description
+			report.Sanitized++
+			report.Violations = append(report.Violations, fmt.Sprintf("entity %q sanitized: %s", entity.Name, strings.Join(reasons, ", ")))
+		}
+		kept = append(kept, entity)
+	}
+	return kept, report
+}
+
+// CheckTriplets returns the triplets whose subject, relation and object pass the guardrail.
+func (g *Guardrail) CheckTriplets(triplets []Triplet) ([]Triplet, GuardrailReport) {
+	var report GuardrailReport
+	if g == nil || len(triplets) == 0 {
+		return triplets, report
+	}
+
+	kept := make([]Triplet, 0, len(triplets))
+	for _, triplet := range triplets {
+		reason := g.nameViolation(triplet.Subject)
+		if reason == "" {
+			reason = g.nameViolation(triplet.Object)
+		}
+		if reason == "" {
+			reason = g.relationViolation(triplet.Relation)
+		}
+		if reason != "" {
+			report.Rejected++
+			report.Violations = append(report.Violations, fmt.Sprintf("relation %q -[%s]-> %q rejected: %s",
+				truncateRunes(triplet.Subject, 60), truncateRunes(triplet.Relation, 60), truncateRunes(triplet.Object, 60), reason))
+			continue
+		}
+		kept = append(kept, triplet)
+	}
+	return kept, report
+}
+
+// nameViolation returns why a name must be rejected, or "" if it is acceptable
+func (g *Guardrail) nameViolation(name string) string {
+	switch {
+	case g.MaxNameLength > 0 && utf8.RuneCountInString(name) > g.MaxNameLength:
+		return fmt.Sprintf("name longer than %d characters", g.MaxNameLength)
+	case hasControlChars(name):
+		return "control characters in name"
+	case injectionPattern.MatchString(name):
+		return "instructions in name"
+	case !g.AllowURLs && urlPattern.MatchString(name):
+		return "URL in name"
+	}
+	return ""
+}
+
+// relationViolation returns why a relation label must be rejected, or "" if it is acceptable
+func (g *Guardrail) relationViolation(relation string) string {
+	switch {
+	case g.MaxRelationLength > 0 && utf8.RuneCountInString(relation) > g.MaxRelationLength:
+		return fmt.Sprintf("relation longer than %d characters", g.MaxRelationLength)
+	case hasControlChars(relation):
+		return "control characters in relation"
+	case injectionPattern.MatchString(relation):
+		return "instructions in relation"
+	case !g.AllowURLs && urlPattern.MatchString(relation):
+		return "URL in relation"
+	}
+	return ""
+}
+
+// sanitizeDescription cleans a description, returning it and what was changed
+func (g *Guardrail) sanitizeDescription(description string) (string, []string) {
+	var reasons []string
+
+	if hasControlChars(description) {
+		description = strings.Map(func(r rune) rune {
+			if isDisallowedControl(r) {
+				return -1
+			}
+			return r
+		}, description)
+		reasons = append(reasons, "control characters removed")
+	}
+	if injectionPattern.MatchString(description) {
+		// The description cannot be trusted at all once it addresses the model
+		description = ""
+		reasons = append(reasons, "instructions removed")
+	}
+	if !g.AllowURLs && urlPattern.MatchString(description) {
+		description = strings.Join(strings.Fields(urlPattern.ReplaceAllString(description, "")), " ")
+		reasons = append(reasons, "URLs removed")
+	}
+	if g.MaxDescriptionLength > 0 && utf8.RuneCountInString(description) > g.MaxDescriptionLength {
+		description = truncateRunes(description, g.MaxDescriptionLength)
+		reasons = append(reasons, fmt.Sprintf("truncated to %d characters", g.MaxDescriptionLength))
+	}
+	return description, reasons
+}
+
+// hasControlChars reports whether s contains control characters other than ordinary whitespace
+func hasControlChars(s string) bool {
+	return strings.IndexFunc(s, isDisallowedControl) >= 0
+}
+
+func isDisallowedControl(r rune) bool {
+	return unicode.IsControl(r) && r != '\n' && r != '\t'
+}
+
+// truncateRunes shortens s to at most n runes
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
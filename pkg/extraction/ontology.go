@@ -0,0 +1,198 @@
+package extraction
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Policies for types an Ontology does not define (Ontology.UnknownTypes)
+const (
+	// UnknownTypesCoerce maps unknown types to the ontology's fallback types
+	UnknownTypesCoerce = "coerce"
+	// UnknownTypesReject drops entities and relations of unknown types
+	UnknownTypesReject = "reject"
+)
+
+// OntologyType is an entity or relation type of an Ontology.
+type OntologyType struct {
+	Name string
+	// Description tells the LLM what the type covers (optional)
+	Description string
+}
+
+// Ontology is a caller-defined schema for extraction. Its types and their descriptions
+// are injected into the extraction prompts, and extracted types are validated against
+// it: entity types match case-insensitively, relation names ignoring case, spaces and
+// hyphens ("depends on" matches DEPENDS_ON). Anything else is coerced to a fallback
+// type or rejected per UnknownTypes.
+type Ontology struct {
+	// EntityTypes are the allowed entity types (empty: the built-in types).
+	EntityTypes []OntologyType
+
+	// RelationTypes are the allowed relation names (empty: any relation).
+	RelationTypes []OntologyType
+
+	// UnknownTypes is UnknownTypesCoerce (default) or UnknownTypesReject.
+	UnknownTypes string
+
+	// FallbackEntityType receives coerced entities (default: the first of EntityTypes,
+	// or Concept with the built-in types).
+	FallbackEntityType string
+
+	// FallbackRelation receives coerced relations (default: RELATES_TO if defined,
+	// otherwise the first of RelationTypes).
+	FallbackRelation string
+}
+
+// Validate checks that types are named and unique and that the fallbacks are defined.
+func (o *Ontology) Validate() error {
+	if o == nil {
+		return nil
+	}
+	switch o.UnknownTypes {
+	case "", UnknownTypesCoerce, UnknownTypesReject:
+	default:
+		return fmt.Errorf("unknown ontology UnknownTypes %q (want %q or %q)", o.UnknownTypes, UnknownTypesCoerce, UnknownTypesReject)
+	}
+
+	if err := checkOntologyTypes("entity type", o.EntityTypes, strings.ToLower); err != nil {
+		return err
+	}
+	if err := checkOntologyTypes("relation", o.RelationTypes, relationKey); err != nil {
+		return err
+	}
+
+	if o.FallbackEntityType != "" {
+		if _, ok := o.matchEntityType(o.FallbackEntityType); !ok {
+			return fmt.Errorf("ontology FallbackEntityType %q is not an entity type", o.FallbackEntityType)
+		}
+	}
+	if o.FallbackRelation != "" && len(o.RelationTypes) > 0 {
+		if _, ok := o.matchRelation(o.FallbackRelation); !ok {
+			return fmt.Errorf("ontology FallbackRelation %q is not a relation type", o.FallbackRelation)
+		}
+	}
+	return nil
+}
+
+// checkOntologyTypes rejects empty and duplicate names, compared by key
+func checkOntologyTypes(kind string, types []OntologyType, key func(string) string) error {
+	seen := make(map[string]bool, len(types))
+	for i, t := range types {
+		name := strings.TrimSpace(t.Name)
+		if name == "" {
+			return fmt.Errorf("ontology %s at index %d has empty name", kind, i)
+		}
+		if seen[key(name)] {
+			return fmt.Errorf("duplicate ontology %s %q", kind, name)
+		}
+		seen[key(name)] = true
+	}
+	return nil
+}
+
+// relationKey normalizes a relation name for matching
+func relationKey(relation string) string {
+	key := strings.ToUpper(strings.TrimSpace(relation))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+}
+
+// reject reports whether unknown types are dropped rather than coerced
+func (o *Ontology) reject() bool {
+	return o.UnknownTypes == UnknownTypesReject
+}
+
+// entityTypes returns the allowed entity types
+func (o *Ontology) entityTypes() []OntologyType {
+	if len(o.EntityTypes) > 0 {
+		return o.EntityTypes
+	}
+	types := make([]OntologyType, len(builtinEntityTypes))
+	for i, name := range builtinEntityTypes {
+		types[i] = OntologyType{Name: name}
+	}
+	return types
+}
+
+// matchEntityType returns the defined entity type matching t
+func (o *Ontology) matchEntityType(t string) (string, bool) {
+	for _, defined := range o.entityTypes() {
+		if strings.EqualFold(strings.TrimSpace(t), defined.Name) {
+			return defined.Name, true
+		}
+	}
+	return "", false
+}
+
+// matchRelation returns the defined relation matching relation
+func (o *Ontology) matchRelation(relation string) (string, bool) {
+	key := relationKey(relation)
+	for _, defined := range o.RelationTypes {
+		if relationKey(defined.Name) == key {
+			return defined.Name, true
+		}
+	}
+	return "", false
+}
+
+// EntityType returns the ontology type for an extracted entity type: the matching
+// defined type, the fallback when coercing, or false when the entity is rejected.
+func (o *Ontology) EntityType(t string) (string, bool) {
+	if defined, ok := o.matchEntityType(t); ok {
+		return defined, true
+	}
+	if o.reject() {
+		return "", false
+	}
+	if o.FallbackEntityType != "" {
+		return o.matchEntityType(o.FallbackEntityType)
+	}
+	if len(o.EntityTypes) > 0 {
+		return o.EntityTypes[0].Name, true
+	}
+	return "Concept", true
+}
+
+// Relation returns the ontology relation for an extracted relation: the matching
+// defined relation, the fallback when coercing, or false when the relation is rejected.
+// Any relation is allowed when the ontology defines none.
+func (o *Ontology) Relation(relation string) (string, bool) {
+	if len(o.RelationTypes) == 0 {
+		return relation, true
+	}
+	if defined, ok := o.matchRelation(relation); ok {
+		return defined, true
+	}
+	if o.reject() {
+		return "", false
+	}
+	if o.FallbackRelation != "" {
+		return o.matchRelation(o.FallbackRelation)
+	}
+	if defined, ok := o.matchRelation("RELATES_TO"); ok {
+		return defined, true
+	}
+	return o.RelationTypes[0].Name, true
+}
+
+// describeTypes renders types as a prompt list, one "- Name: Description" line each
+func describeTypes(types []OntologyType) string {
+	var b strings.Builder
+	for _, t := range types {
+		b.WriteString("- " + t.Name)
+		if t.Description != "" {
+			b.WriteString(": " + t.Description)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// typeNames returns the names of types joined for a prompt
+func typeNames(types []OntologyType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,117 @@
+package extraction
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func testOntology(unknownTypes string) *Ontology {
+	return &Ontology{
+		EntityTypes: []OntologyType{
+			{Name: "Service", Description: "A deployable backend service"},
+			{Name: "Team", Description: "A group owning services"},
+		},
+		RelationTypes: []OntologyType{
+			{Name: "OWNS", Description: "A team owns a service"},
+			{Name: "CALLS"},
+		},
+		UnknownTypes: unknownTypes,
+	}
+}
+
+func TestOntology_Validate(t *testing.T) {
+	if err := testOntology("").Validate(); err != nil {
+		t.Fatalf("expected a valid ontology, got %v", err)
+	}
+
+	invalid := map[string]*Ontology{
+		"policy":    {UnknownTypes: "ignore"},
+		"empty":     {EntityTypes: []OntologyType{{Name: " "}}},
+		"duplicate": {RelationTypes: []OntologyType{{Name: "DEPENDS_ON"}, {Name: "depends on"}}},
+		"fallback":  {EntityTypes: []OntologyType{{Name: "Service"}}, FallbackEntityType: "Team"},
+	}
+	for name, o := range invalid {
+		if err := o.Validate(); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+}
+
+func TestEntityExtractor_Ontology(t *testing.T) {
+	response := `[{"name": "Billing", "type": "service", "description": "Bills customers"},
+		{"name": "Payments", "type": "Team", "description": "Owns billing"},
+		{"name": "Kafka", "type": "Technology", "description": "A message broker"}]`
+
+	var prompt string
+	llm := &fakeLLMClient{response: response, capturePrompt: func(p string) { prompt = p }}
+	extractor := NewEntityExtractor(llm)
+	extractor.Ontology = testOntology("")
+	var logs bytes.Buffer
+	extractor.SetLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	entities, err := extractor.Extract(context.Background(), "Payments owns Billing, which uses Kafka.")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if !strings.Contains(prompt, "One of [Service, Team]") || !strings.Contains(prompt, "- Service: A deployable backend service") {
+		t.Errorf("expected the ontology in the prompt, got:\n%s", prompt)
+	}
+	if len(entities) != 3 || entities[0].Type != "Service" || entities[2].Type != "Service" {
+		t.Errorf("expected types matched and Technology coerced to Service, got %+v", entities)
+	}
+	if !strings.Contains(logs.String(), `level=WARN msg="entity type outside the ontology, coercing" component=extraction.entities type=Technology coerced_to=Service`) {
+		t.Errorf("expected a warning about the coerced type, got: %s", logs.String())
+	}
+
+	logs.Reset()
+	extractor.Ontology = testOntology(UnknownTypesReject)
+	entities, err = extractor.Extract(context.Background(), "Payments owns Billing, which uses Kafka.")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(entities) != 2 || entities[1].Name != "Payments" {
+		t.Errorf("expected Kafka rejected, got %+v", entities)
+	}
+	if !strings.Contains(logs.String(), `msg="entity type outside the ontology, rejecting"`) || strings.Contains(logs.String(), "Kafka") {
+		t.Errorf("expected a warning naming only the rejected type, got: %s", logs.String())
+	}
+}
+
+func TestRelationExtractor_Ontology(t *testing.T) {
+	response := `[{"subject": "Payments", "relation": "owns", "object": "Billing"},
+		{"subject": "Billing", "relation": "USES", "object": "Kafka"}]`
+	entities := []Entity{
+		{Name: "Payments", Type: "Team", Description: "A team"},
+		{Name: "Billing", Type: "Service", Description: "A service"},
+		{Name: "Kafka", Type: "Service", Description: "A broker"},
+	}
+
+	var prompt string
+	llm := &fakeLLMClient{response: response, capturePrompt: func(p string) { prompt = p }}
+	extractor := NewRelationExtractor(llm)
+	extractor.Ontology = testOntology("")
+
+	triplets, err := extractor.Extract(context.Background(), "Payments owns Billing, which uses Kafka.", entities)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if !strings.Contains(prompt, "Use ONLY these relation names:\n- OWNS: A team owns a service\n- CALLS") {
+		t.Errorf("expected the ontology relations in the prompt, got:\n%s", prompt)
+	}
+	// USES is coerced to the first relation, as the ontology has no RELATES_TO
+	if len(triplets) != 2 || triplets[0].Relation != "OWNS" || triplets[1].Relation != "OWNS" {
+		t.Errorf("expected relations mapped onto the ontology, got %+v", triplets)
+	}
+
+	extractor.Ontology = testOntology(UnknownTypesReject)
+	triplets, err = extractor.Extract(context.Background(), "Payments owns Billing, which uses Kafka.", entities)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(triplets) != 1 || triplets[0].Relation != "OWNS" {
+		t.Errorf("expected USES rejected, got %+v", triplets)
+	}
+}
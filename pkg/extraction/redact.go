@@ -0,0 +1,228 @@
+package extraction
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dan-solli/gognee/pkg/llm"
+)
+
+// Redactor removes personal data and secrets from text before it is chunked, extracted or
+// stored, so emails, tokens and keys never reach the graph, the memories or the logs.
+type Redactor interface {
+	// Redact returns text with sensitive spans replaced by placeholders.
+	Redact(ctx context.Context, text string) (string, error)
+}
+
+// BatchRedactor is a Redactor that redacts several texts in one pass, e.g. with a single
+// LLM call. RedactTexts uses it when available.
+type BatchRedactor interface {
+	Redactor
+
+	// RedactBatch returns the texts redacted, in order.
+	RedactBatch(ctx context.Context, texts []string) ([]string, error)
+}
+
+// RedactionRule replaces every match of Pattern with "[REDACTED_<Name>]".
+type RedactionRule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// DefaultRedactionRules detect emails, private keys, JSON web tokens, bearer tokens,
+// well-known API key formats and password or secret assignments. Rules run in order.
+var DefaultRedactionRules = []RedactionRule{
+	{"PRIVATE_KEY", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	{"JWT", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{5,}`)},
+	{"TOKEN", regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/-]{16,}=*`)},
+	{"API_KEY", regexp.MustCompile(`\b(sk-[A-Za-z0-9_-]{20,}|AKIA[0-9A-Z]{16}|gh[pousr]_[A-Za-z0-9]{36,}|xox[abprs]-[A-Za-z0-9-]{10,}|AIza[0-9A-Za-z_-]{35})\b`)},
+	{"SECRET", regexp.MustCompile(`(?i)\b(password|passwd|pwd|secret|token|api[_-]?key|access[_-]?token|client[_-]?secret)(\s*[:=]\s*)("[^"\s]+"|'[^'\s]+'|[^\s,;]+)`)},
+	{"EMAIL", regexp.MustCompile(`(?i)\b[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}\b`)},
+}
+
+// RegexRedactor redacts text matching its rules. The zero value redacts nothing;
+// NewRegexRedactor returns one with DefaultRedactionRules.
+type RegexRedactor struct {
+	Rules []RedactionRule
+}
+
+// NewRegexRedactor returns a redactor using DefaultRedactionRules.
+func NewRegexRedactor() *RegexRedactor {
+	return &RegexRedactor{Rules: DefaultRedactionRules}
+}
+
+// Redact replaces every rule match. Secret assignments keep their key, so
+// "password=hunter2" becomes "password=[REDACTED_SECRET]".
+func (r *RegexRedactor) Redact(ctx context.Context, text string) (string, error) {
+	for _, rule := range r.Rules {
+		placeholder := "[REDACTED_" + rule.Name + "]"
+		if rule.Name == "SECRET" {
+			text = rule.Pattern.ReplaceAllString(text, "${1}${2}"+placeholder)
+			continue
+		}
+		text = rule.Pattern.ReplaceAllLiteralString(text, placeholder)
+	}
+	return text, nil
+}
+
+// llmRedactionPrompt asks the LLM for the sensitive spans of numbered texts
+const llmRedactionPrompt = `You are screening text before it is stored in a knowledge base.
+
+List every span in the texts below that is personal data or a secret: names of private
+individuals with contact details, email addresses, phone numbers, postal addresses,
+government or account numbers, passwords, API keys, tokens and credentials.
+Do not list public figures, companies, products or technologies.
+
+Texts:
+%s
+
+Return ONLY a valid JSON array of the sensitive spans, each exactly as written above:
+["...", ...]`
+
+// LLMRedactor asks an LLM for sensitive spans the regex rules miss, such as phone numbers
+// and home addresses, and replaces them with "[REDACTED]". One LLM call per batch.
+// The LLM sees the text, so use a model you trust with it, and don't wrap it in a
+// response cache: the responses list the sensitive spans.
+type LLMRedactor struct {
+	LLM llm.LLMClient
+}
+
+// Redact redacts one text.
+func (r *LLMRedactor) Redact(ctx context.Context, text string) (string, error) {
+	redacted, err := r.RedactBatch(ctx, []string{text})
+	if err != nil {
+		return "", err
+	}
+	return redacted[0], nil
+}
+
+// RedactBatch redacts the texts with a single LLM call.
+func (r *LLMRedactor) RedactBatch(ctx context.Context, texts []string) ([]string, error) {
+	var lines []string
+	for i, text := range texts {
+		if strings.TrimSpace(text) != "" {
+			lines = append(lines, fmt.Sprintf("[%d] %s", i+1, text))
+		}
+	}
+	if len(lines) == 0 {
+		return texts, nil
+	}
+
+	var spans []string
+	if err := r.LLM.CompleteWithSchema(ctx, fmt.Sprintf(llmRedactionPrompt, strings.Join(lines, "\n")), &spans); err != nil {
+		return nil, fmt.Errorf("redaction check failed: %w", err)
+	}
+
+	// Longest first, so a span containing another is replaced whole
+	sort.Slice(spans, func(i, j int) bool { return len(spans[i]) > len(spans[j]) })
+	redacted := make([]string, len(texts))
+	for i, text := range texts {
+		for _, span := range spans {
+			if span = strings.TrimSpace(span); span != "" {
+				text = strings.ReplaceAll(text, span, "[REDACTED]")
+			}
+		}
+		redacted[i] = text
+	}
+	return redacted, nil
+}
+
+// Redactors applies several redactors in order, e.g. the regex rules and then an LLM check:
+//
+//	extraction.Redactors{extraction.NewRegexRedactor(), &extraction.LLMRedactor{LLM: client}}
+type Redactors []Redactor
+
+// Redact applies each redactor to the output of the previous one.
+func (rs Redactors) Redact(ctx context.Context, text string) (string, error) {
+	for _, r := range rs {
+		var err error
+		if text, err = r.Redact(ctx, text); err != nil {
+			return "", err
+		}
+	}
+	return text, nil
+}
+
+// RedactBatch applies each redactor to all texts, batching where a redactor supports it.
+func (rs Redactors) RedactBatch(ctx context.Context, texts []string) ([]string, error) {
+	for _, r := range rs {
+		var err error
+		if texts, err = RedactTexts(ctx, r, texts); err != nil {
+			return nil, err
+		}
+	}
+	return texts, nil
+}
+
+// RedactTexts redacts texts with r, in one batch if r is a BatchRedactor. A nil r returns
+// texts unchanged.
+func RedactTexts(ctx context.Context, r Redactor, texts []string) ([]string, error) {
+	if r == nil || len(texts) == 0 {
+		return texts, nil
+	}
+	if batch, ok := r.(BatchRedactor); ok {
+		redacted, err := batch.RedactBatch(ctx, texts)
+		if err != nil {
+			return nil, err
+		}
+		if len(redacted) != len(texts) {
+			return nil, fmt.Errorf("redactor returned %d texts for %d", len(redacted), len(texts))
+		}
+		return redacted, nil
+	}
+	redacted := make([]string, len(texts))
+	for i, text := range texts {
+		var err error
+		if redacted[i], err = r.Redact(ctx, text); err != nil {
+			return nil, err
+		}
+	}
+	return redacted, nil
+}
+
+// RedactEntities redacts entity names and descriptions with r in one batch. A nil r
+// returns entities unchanged.
+func RedactEntities(ctx context.Context, r Redactor, entities []Entity) ([]Entity, error) {
+	if r == nil || len(entities) == 0 {
+		return entities, nil
+	}
+	texts := make([]string, 0, 2*len(entities))
+	for _, entity := range entities {
+		texts = append(texts, entity.Name, entity.Description)
+	}
+	redacted, err := RedactTexts(ctx, r, texts)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Entity, len(entities))
+	for i, entity := range entities {
+		entity.Name, entity.Description = redacted[2*i], redacted[2*i+1]
+		result[i] = entity
+	}
+	return result, nil
+}
+
+// RedactTriplets redacts triplet subjects and objects with r in one batch, so they keep
+// matching the redacted entity names. A nil r returns triplets unchanged.
+func RedactTriplets(ctx context.Context, r Redactor, triplets []Triplet) ([]Triplet, error) {
+	if r == nil || len(triplets) == 0 {
+		return triplets, nil
+	}
+	texts := make([]string, 0, 2*len(triplets))
+	for _, triplet := range triplets {
+		texts = append(texts, triplet.Subject, triplet.Object)
+	}
+	redacted, err := RedactTexts(ctx, r, texts)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Triplet, len(triplets))
+	for i, triplet := range triplets {
+		triplet.Subject, triplet.Object = redacted[2*i], redacted[2*i+1]
+		result[i] = triplet
+	}
+	return result, nil
+}
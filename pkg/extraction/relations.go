@@ -13,6 +13,14 @@ type Triplet struct {
 	Subject  string `json:"subject"`
 	Relation string `json:"relation"`
 	Object   string `json:"object"`
+	// Confidence is the model's certainty in the relationship, from 0 to 1.
+	// Zero means the model did not report a confidence.
+	Confidence float64 `json:"confidence,omitempty"`
+	// ValidFrom and ValidTo are the dates the text says the relationship started and
+	// stopped holding ("since 2023", "until Q3 2024"), empty when not stated.
+	// See ValidityPeriod.
+	ValidFrom string `json:"valid_from,omitempty"`
+	ValidTo   string `json:"valid_to,omitempty"`
 }
 
 // relationExtractionPrompt is the prompt template for relationship extraction
@@ -20,6 +28,7 @@ const relationExtractionPrompt = `You are a knowledge graph construction assista
 
 Given this text and the entities already extracted, identify relationships between them.
 Express each relationship as a triplet: (subject, relation, object)
+with a confidence (0.0 to 1.0) for how clearly the text states it.
 
 IMPORTANT: Use ONLY entity names from the "Known entities" list below. Do not create new entities or use partial names.
 
@@ -33,12 +42,44 @@ Text:
 
 Known entities: %s
 
+If the text says when a relationship started or stopped holding ("since 2023", "until Q3 2024"),
+add "valid_from" and/or "valid_to" as YYYY, YYYY-MM, YYYY-MM-DD or a quarter like "2024-Q3". Omit them otherwise.
+
+Return ONLY valid JSON array where subject and object are exact matches from the Known entities list:
+[{"subject": "...", "relation": "...", "object": "...", "confidence": 0.9}, ...]`
+
+// ontologyRelationPrompt is relationExtractionPrompt with the relations of an Ontology
+const ontologyRelationPrompt = `You are a knowledge graph construction assistant.
+
+Given this text and the entities already extracted, identify relationships between them.
+Express each relationship as a triplet: (subject, relation, object)
+with a confidence (0.0 to 1.0) for how clearly the text states it.
+
+IMPORTANT: Use ONLY entity names from the "Known entities" list below. Do not create new entities or use partial names.
+
+Use ONLY these relation names:
+%s
+
+Text:
+---
+%s
+---
+
+Known entities: %s
+
+If the text says when a relationship started or stopped holding ("since 2023", "until Q3 2024"),
+add "valid_from" and/or "valid_to" as YYYY, YYYY-MM, YYYY-MM-DD or a quarter like "2024-Q3". Omit them otherwise.
+
 Return ONLY valid JSON array where subject and object are exact matches from the Known entities list:
-[{"subject": "...", "relation": "...", "object": "..."}, ...]`
+[{"subject": "...", "relation": "...", "object": "...", "confidence": 0.9}, ...]`
 
 // RelationExtractor extracts relationships between entities from text using an LLM
 type RelationExtractor struct {
 	LLM llm.LLMClient
+
+	// Ontology, when set with RelationTypes, replaces the suggested relation names in
+	// the prompt and decides what happens to relations of other names.
+	Ontology *Ontology
 }
 
 // NewRelationExtractor creates a new relation extractor
@@ -60,6 +101,9 @@ func (r *RelationExtractor) Extract(ctx context.Context, text string, entities [
 
 	// Build the prompt
 	prompt := fmt.Sprintf(relationExtractionPrompt, text, entityNames)
+	if r.Ontology != nil && len(r.Ontology.RelationTypes) > 0 {
+		prompt = fmt.Sprintf(ontologyRelationPrompt, describeTypes(r.Ontology.RelationTypes), text, entityNames)
+	}
 
 	// Call the LLM
 	var triplets []Triplet
@@ -76,6 +120,20 @@ func (r *RelationExtractor) Extract(ctx context.Context, text string, entities [
 		return nil, err
 	}
 
+	// Map relations onto the ontology, dropping rejected ones
+	if r.Ontology != nil {
+		kept := validatedTriplets[:0]
+		for _, triplet := range validatedTriplets {
+			relation, ok := r.Ontology.Relation(triplet.Relation)
+			if !ok {
+				continue
+			}
+			triplet.Relation = relation
+			kept = append(kept, triplet)
+		}
+		validatedTriplets = kept
+	}
+
 	// Deduplicate triplets
 	result := deduplicateTriplets(validatedTriplets)
 
@@ -128,9 +186,12 @@ func validateAndProcessTriplets(triplets []Triplet, entityLookup map[string]bool
 
 		// Add validated and trimmed triplet
 		result = append(result, Triplet{
-			Subject:  subject,
-			Relation: relation,
-			Object:   object,
+			Subject:    subject,
+			Relation:   relation,
+			Object:     object,
+			Confidence: clampConfidence(triplet.Confidence),
+			ValidFrom:  strings.TrimSpace(triplet.ValidFrom),
+			ValidTo:    strings.TrimSpace(triplet.ValidTo),
 		})
 	}
 
@@ -138,7 +199,8 @@ func validateAndProcessTriplets(triplets []Triplet, entityLookup map[string]bool
 }
 
 // deduplicateTriplets removes duplicate triplets, preserving first occurrence order
-// Comparison is case-insensitive for subject and object (matching entity linking behavior)
+// Comparison is case-insensitive for subject and object (matching entity linking behavior);
+// the same relation stated for different validity periods is kept once per period
 func deduplicateTriplets(triplets []Triplet) []Triplet {
 	seen := make(map[string]bool)
 	result := make([]Triplet, 0, len(triplets))
@@ -147,7 +209,9 @@ func deduplicateTriplets(triplets []Triplet) []Triplet {
 		// Create a normalized key for comparison (case-insensitive)
 		key := strings.ToLower(triplet.Subject) + "|" +
 			strings.ToLower(triplet.Relation) + "|" +
-			strings.ToLower(triplet.Object)
+			strings.ToLower(triplet.Object) + "|" +
+			strings.ToLower(triplet.ValidFrom) + "|" +
+			strings.ToLower(triplet.ValidTo)
 
 		if !seen[key] {
 			seen[key] = true
@@ -334,6 +334,31 @@ func TestRelationExtractorExtract_Deduplication(t *testing.T) {
 	}
 }
 
+func TestRelationExtractorExtract_DeduplicationKeepsValidityPeriods(t *testing.T) {
+	entities := []Entity{
+		{Name: "Alice", Type: "Person", Description: "A person"},
+		{Name: "Acme", Type: "Organization", Description: "A company"},
+	}
+
+	triplets := []Triplet{
+		{Subject: "Alice", Relation: "WORKS_AT", Object: "Acme", ValidFrom: "2015", ValidTo: "2017"},
+		{Subject: "Alice", Relation: "WORKS_AT", Object: "Acme", ValidFrom: "2021"},
+		{Subject: "alice", Relation: "WORKS_AT", Object: "acme", ValidFrom: "2021"}, // duplicate
+	}
+
+	fakeLLM := &fakeLLMClient{response: tripletsJSON(triplets)}
+	extractor := NewRelationExtractor(fakeLLM)
+
+	result, err := extractor.Extract(context.Background(), "Some text", entities)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("Expected one triplet per validity period, got %+v", result)
+	}
+}
+
 func TestRelationExtractorExtract_DeduplicationPreservesOrder(t *testing.T) {
 	entities := []Entity{
 		{Name: "Alice", Type: "Person", Description: "A person"},
@@ -0,0 +1,62 @@
+package extraction
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// quarterPattern matches quarter dates such as "Q3 2024", "2024-Q3" or "2024 Q3"
+var quarterPattern = regexp.MustCompile(`^(?i)(?:q([1-4])[\s-]*(\d{4})|(\d{4})[\s-]*q([1-4]))$`)
+
+// ValidityPeriod parses the triplet's ValidFrom and ValidTo into the period the fact held,
+// nil where unbounded or unparseable. Dates may be RFC 3339 timestamps, YYYY-MM-DD,
+// YYYY-MM, YYYY or quarters ("Q3 2024", "2024-Q3"). ValidFrom resolves to the start of
+// the date it names and ValidTo to the end, so "until 2023" holds through 2023; the
+// returned end is exclusive.
+func (t Triplet) ValidityPeriod() (from, to *time.Time) {
+	if start, _, ok := parsePeriod(t.ValidFrom); ok {
+		from = &start
+	}
+	if _, end, ok := parsePeriod(t.ValidTo); ok {
+		to = &end
+	}
+	return from, to
+}
+
+// parsePeriod returns the UTC span [start, end) named by a date of any supported precision
+func parsePeriod(value string) (start, end time.Time, ok bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return start, end, false
+	}
+
+	if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		return ts.UTC(), ts.UTC(), true
+	}
+	if m := quarterPattern.FindStringSubmatch(value); m != nil {
+		quarter, year := m[1], m[2]
+		if quarter == "" {
+			quarter, year = m[4], m[3]
+		}
+		q, _ := strconv.Atoi(quarter)
+		y, _ := strconv.Atoi(year)
+		start = time.Date(y, time.Month(3*(q-1)+1), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 3, 0), true
+	}
+
+	for _, layout := range []struct {
+		format              string
+		years, months, days int
+	}{
+		{"2006-01-02", 0, 0, 1},
+		{"2006-01", 0, 1, 0},
+		{"2006", 1, 0, 0},
+	} {
+		if start, err := time.Parse(layout.format, value); err == nil {
+			return start, start.AddDate(layout.years, layout.months, layout.days), true
+		}
+	}
+	return start, end, false
+}
@@ -0,0 +1,51 @@
+package extraction
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTriplet_ValidityPeriod(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		from, to         string
+		wantFrom, wantTo time.Time
+	}{
+		{"2023", "2024", date(2023, 1, 1), date(2025, 1, 1)},
+		{"2023-05", "2023-05-14", date(2023, 5, 1), date(2023, 5, 15)},
+		{"Q3 2024", "2024-q3", date(2024, 7, 1), date(2024, 10, 1)},
+		{"2024 Q4", "2025-03-01T12:00:00Z", date(2024, 10, 1), time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		from, to := Triplet{ValidFrom: tt.from, ValidTo: tt.to}.ValidityPeriod()
+		if from == nil || !from.Equal(tt.wantFrom) {
+			t.Errorf("ValidFrom %q: expected %v, got %v", tt.from, tt.wantFrom, from)
+		}
+		if to == nil || !to.Equal(tt.wantTo) {
+			t.Errorf("ValidTo %q: expected %v, got %v", tt.to, tt.wantTo, to)
+		}
+	}
+
+	if from, to := (Triplet{ValidFrom: "recently", ValidTo: "Q5 2024"}).ValidityPeriod(); from != nil || to != nil {
+		t.Errorf("expected unparseable dates ignored, got %v and %v", from, to)
+	}
+}
+
+func TestRelationExtractor_Validity(t *testing.T) {
+	response := `[{"subject": "Alice", "relation": "WORKS_AT", "object": "Acme", "valid_from": " 2021 ", "valid_to": "2024-Q3"}]`
+	entities := []Entity{
+		{Name: "Alice", Type: "Person", Description: "An engineer"},
+		{Name: "Acme", Type: "Organization", Description: "A company"},
+	}
+
+	extractor := NewRelationExtractor(&fakeLLMClient{response: response})
+	triplets, err := extractor.Extract(context.Background(), "Alice has worked at Acme since 2021, until Q3 2024.", entities)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(triplets) != 1 || triplets[0].ValidFrom != "2021" || triplets[0].ValidTo != "2024-Q3" {
+		t.Errorf("expected the validity dates kept, got %+v", triplets)
+	}
+}
@@ -0,0 +1,108 @@
+package gognee
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// newAccessTrackingFailingGognee returns a Gognee with one cognified document whose
+// node access updates fail.
+func newAccessTrackingFailingGognee(t *testing.T, strict bool) *Gognee {
+	t.Helper()
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{{Name: "React", Type: "Technology", Description: "A JavaScript library"}},
+		},
+		RelationResponses: [][]extraction.Triplet{{}},
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:", StrictAccessTracking: strict}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	t.Cleanup(func() { g.Close() })
+
+	ctx := context.Background()
+	g.Add(ctx, "Our app uses React.", AddOptions{})
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+
+	db := g.graphStore.(*store.SQLiteGraphStore).DB()
+	if _, err := db.Exec(`CREATE TRIGGER fail_access BEFORE UPDATE OF last_accessed_at ON nodes
+		BEGIN SELECT RAISE(FAIL, 'access tracking unavailable'); END`); err != nil {
+		t.Fatalf("failed to create trigger: %v", err)
+	}
+	return g
+}
+
+func TestSearch_AccessTrackingFailureLogged(t *testing.T) {
+	g := newAccessTrackingFailingGognee(t, false)
+	handler := newCaptureHandler()
+	g.WithLogger(slog.New(handler))
+
+	resp, err := g.Search(context.Background(), "React", SearchOptions{Type: SearchTypeHybrid})
+	if err != nil {
+		t.Fatalf("expected Search to succeed despite access tracking failure, got %v", err)
+	}
+	if len(resp.Results) == 0 {
+		t.Fatal("Expected search results")
+	}
+
+	var warned bool
+	for _, r := range handler.getRecords() {
+		if r.Level == slog.LevelWarn && r.Message == "access tracking failed" {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Error("expected a WARN record for the access tracking failure")
+	}
+
+	stats, err := g.Stats()
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.AccessTrackingFailures != 1 {
+		t.Errorf("expected 1 access tracking failure, got %d", stats.AccessTrackingFailures)
+	}
+}
+
+func TestSearch_StrictAccessTracking(t *testing.T) {
+	g := newAccessTrackingFailingGognee(t, true)
+
+	_, err := g.Search(context.Background(), "React", SearchOptions{Type: SearchTypeHybrid})
+	if err == nil || !strings.Contains(err.Error(), "failed to record node access") {
+		t.Fatalf("expected Search to fail on access tracking, got %v", err)
+	}
+}
+
+func TestGetNode_AccessFlushedOnFlush(t *testing.T) {
+	ctx := context.Background()
+	g, err := NewWithClients(Config{DBPath: ":memory:", AccessFlushInterval: -1}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	if err := g.graphStore.AddNode(ctx, &store.Node{ID: "n1", Name: "Go", Type: "Technology"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if _, err := g.graphStore.GetNode(ctx, "n1"); err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	if node, _ := g.graphStore.GetNode(ctx, "n1"); node.LastAccessedAt != nil {
+		t.Errorf("Expected the read buffered, got last access %v", node.LastAccessedAt)
+	}
+
+	if err := g.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if node, _ := g.graphStore.GetNode(ctx, "n1"); node.LastAccessedAt == nil {
+		t.Error("Expected the read written by Flush")
+	}
+}
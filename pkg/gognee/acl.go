@@ -0,0 +1,13 @@
+package gognee
+
+import (
+	"context"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// SetMemoryACL replaces who can read a memory; nil makes it visible to everyone. With a
+// principal on ctx (see store.WithPrincipal), only memories it can read can be changed.
+func (g *Gognee) SetMemoryACL(ctx context.Context, memoryID string, acl *store.MemoryACL) error {
+	return g.memoryStore.SetMemoryACL(ctx, memoryID, acl)
+}
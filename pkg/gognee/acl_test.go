@@ -0,0 +1,167 @@
+package gognee
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/search"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestMemoryACL_SearchAndReads(t *testing.T) {
+	ctx := context.Background()
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{{Name: "Orion", Type: "Project", Description: "A confidential acquisition"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	mem, err := g.AddMemory(ctx, MemoryInput{
+		Topic:   "Orion",
+		Context: "Orion is going ahead.",
+		ACL:     &store.MemoryACL{Owner: "alice", Team: "exec"},
+	})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	found := func(ctx context.Context) int {
+		t.Helper()
+		resp, err := g.Search(ctx, "Orion", search.SearchOptions{Type: search.SearchTypeVector, TopK: 5})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		return len(resp.Results)
+	}
+
+	alice := store.WithPrincipal(ctx, &store.Principal{ID: "alice"})
+	exec := store.WithPrincipal(ctx, &store.Principal{ID: "carol", Teams: []string{"exec"}})
+	bob := store.WithPrincipal(ctx, &store.Principal{ID: "bob", Teams: []string{"sales"}})
+
+	if n := found(alice); n != 1 {
+		t.Errorf("Expected the owner to find the node, got %d results", n)
+	}
+	if n := found(exec); n != 1 {
+		t.Errorf("Expected a team member to find the node, got %d results", n)
+	}
+	if n := found(bob); n != 0 {
+		t.Errorf("Expected the node hidden from bob, got %d results", n)
+	}
+	if n := found(ctx); n != 1 {
+		t.Errorf("Expected the node found without a principal, got %d results", n)
+	}
+
+	if _, err := g.GetMemory(bob, mem.MemoryID); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected ErrMemoryNotFound for bob, got %v", err)
+	}
+	if memories, _ := g.ListMemories(bob, store.ListMemoriesOptions{}); len(memories) != 0 {
+		t.Errorf("Expected no memories listed for bob, got %d", len(memories))
+	}
+	if _, err := g.UpdateMemory(bob, mem.MemoryID, store.MemoryUpdate{Context: stringPtr("Orion is cancelled.")}); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected bob unable to update the memory, got %v", err)
+	}
+	if memory, err := g.GetMemory(exec, mem.MemoryID); err != nil || memory.ACL.Team != "exec" {
+		t.Errorf("Expected the team member to read the memory and its ACL, got %+v (err %v)", memory, err)
+	}
+
+	if err := g.SetMemoryACL(bob, mem.MemoryID, nil); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected bob unable to change the ACL, got %v", err)
+	}
+	if err := g.SetMemoryACL(alice, mem.MemoryID, nil); err != nil {
+		t.Fatalf("SetMemoryACL failed: %v", err)
+	}
+	if n := found(bob); n != 1 {
+		t.Errorf("Expected the node visible to bob once shared, got %d results", n)
+	}
+}
+
+func TestMemoryACL_OtherReadPaths(t *testing.T) {
+	ctx := context.Background()
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{{Name: "Orion", Type: "Project", Description: "A confidential acquisition"}, {Name: "Acme", Type: "Organization", Description: "A company"}},
+			{{Name: "Acme", Type: "Organization", Description: "A company"}},
+		},
+		RelationResponses: [][]extraction.Triplet{{{Subject: "Orion", Relation: "ACQUIRES", Object: "Acme"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	secret, err := g.AddMemory(ctx, MemoryInput{Topic: "Orion", Context: "Orion acquires Acme.", ACL: &store.MemoryACL{Owner: "alice"}})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	public, err := g.AddMemory(ctx, MemoryInput{Topic: "Acme", Context: "Acme sells anvils."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if err := g.PinMemory(ctx, secret.MemoryID, "deal"); err != nil {
+		t.Fatalf("PinMemory failed: %v", err)
+	}
+
+	alice := store.WithPrincipal(ctx, &store.Principal{ID: "alice"})
+	bob := store.WithPrincipal(ctx, &store.Principal{ID: "bob"})
+	orion, acme := g.nodeID("Orion", "Project"), g.nodeID("Acme", "Organization")
+
+	if pinned, _ := g.ListPinned(bob); len(pinned) != 0 {
+		t.Errorf("Expected no pinned memories listed for bob, got %d", len(pinned))
+	}
+	if pinned, _ := g.ListPinned(alice); len(pinned) != 1 {
+		t.Errorf("Expected alice's pinned memory listed, got %d", len(pinned))
+	}
+
+	var export bytes.Buffer
+	if err := g.ExportMemories(bob, &export, ExportMemoriesOptions{}); err != nil {
+		t.Fatalf("ExportMemories failed: %v", err)
+	}
+	if lines := strings.Count(export.String(), "\n"); lines != 1 || !strings.Contains(export.String(), public.MemoryID) {
+		t.Errorf("Expected only the public memory exported for bob, got %d lines", lines)
+	}
+
+	if _, err := g.NodeDetails(bob, orion); !errors.Is(err, store.ErrNodeNotFound) {
+		t.Errorf("Expected Orion hidden from bob, got %v", err)
+	}
+	if details, err := g.NodeDetails(bob, acme); err != nil || len(details.Edges) != 0 {
+		t.Errorf("Expected Acme without the restricted edge for bob, got %+v (err %v)", details, err)
+	}
+	if details, err := g.NodeDetails(alice, acme); err != nil || len(details.Edges) != 1 {
+		t.Errorf("Expected Acme with its edge for alice, got %+v (err %v)", details, err)
+	}
+
+	for principal, want := range map[string]int{"alice": 1, "bob": 0} {
+		pctx := store.WithPrincipal(ctx, &store.Principal{ID: principal})
+		result, err := g.Query(pctx, `MATCH (a)-[r]->(b) RETURN a, b`)
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		if len(result.Rows) != want {
+			t.Errorf("Expected %d query rows for %s, got %d", want, principal, len(result.Rows))
+		}
+
+		resp, err := g.Search(pctx, "Acme", search.SearchOptions{Type: search.SearchTypeVector, TopK: 5, IncludeEvidence: true})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		evidence := -1
+		for _, result := range resp.Results {
+			if result.NodeID == acme {
+				evidence = len(result.SupportingEdges)
+			}
+		}
+		if evidence != want {
+			t.Errorf("Expected %d supporting edges of Acme for %s, got %d", want, principal, evidence)
+		}
+	}
+
+	if _, err := g.ConsolidateMemories(ctx, []string{secret.MemoryID, public.MemoryID}, ConsolidateOptions{}); !errors.Is(err, store.ErrValidation) {
+		t.Errorf("Expected consolidating memories with different ACLs rejected, got %v", err)
+	}
+}
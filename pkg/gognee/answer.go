@@ -0,0 +1,205 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/chunker"
+	"github.com/dan-solli/gognee/pkg/search"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+const (
+	// defaultAnswerTopK is the number of search results considered by Answer
+	defaultAnswerTopK = 8
+
+	// defaultAnswerContextTokens bounds the context Answer sends to the LLM
+	defaultAnswerContextTokens = 2000
+)
+
+const answerPrompt = `Answer the question using only the numbered context below, which comes from a knowledge graph.
+If the context does not contain the answer, say that you do not know.
+
+Context:
+%s
+Question: %s
+
+Return a JSON object: {"answer": "...", "citations": [1, 2]}
+where citations lists the numbers of the context blocks the answer relies on.`
+
+// AnswerOptions configures Answer.
+type AnswerOptions struct {
+	// Search configures the retrieval step. Default: hybrid search for the top 8 nodes.
+	Search search.SearchOptions
+	// ContextTokens caps the estimated size of the context sent to the LLM (default: 2000).
+	// Lower ranked nodes that no longer fit are left out.
+	ContextTokens int
+	// Render configures how each node is rendered into the context (see RenderNode).
+	Render RenderOptions
+}
+
+// Citation is a node the answer relies on, with the memories the node came from.
+type Citation struct {
+	NodeID    string   `json:"node_id"`
+	Name      string   `json:"name"`
+	MemoryIDs []string `json:"memory_ids,omitempty"`
+	// Chunks are the stored document chunks the node's edges were extracted from, with
+	// their rune offsets in the document. Requires Config.ChunkEmbeddings.
+	Chunks []*store.Chunk `json:"chunks,omitempty"`
+}
+
+// AnswerResult is the LLM's answer to a question with its supporting graph context.
+type AnswerResult struct {
+	Answer    string     `json:"answer"`
+	Citations []Citation `json:"citations"`
+	// ContextNodeIDs lists every node given to the LLM, in rank order.
+	ContextNodeIDs []string `json:"context_node_ids"`
+	// MemoryIDs lists the memories of the cited nodes, without duplicates.
+	MemoryIDs []string `json:"memory_ids"`
+}
+
+// answerResponse is the LLM's answer to answerPrompt
+type answerResponse struct {
+	Answer    string `json:"answer"`
+	Citations []int  `json:"citations"`
+}
+
+// Answer answers a question from the knowledge graph (GraphRAG): it searches for relevant
+// nodes, renders them with their strongest edges and source memories into a numbered
+// context, and asks the LLM to answer from that context only, citing the blocks it used.
+// When the search finds nothing, no LLM call is made and the answer is empty.
+func (g *Gognee) Answer(ctx context.Context, question string, opts AnswerOptions) (*AnswerResult, error) {
+	startTime := time.Now()
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return nil, fmt.Errorf("question cannot be empty")
+	}
+	if opts.Search.Type == "" {
+		opts.Search.Type = search.SearchTypeHybrid
+	}
+	if opts.Search.TopK <= 0 {
+		opts.Search.TopK = defaultAnswerTopK
+	}
+	if opts.ContextTokens <= 0 {
+		opts.ContextTokens = defaultAnswerContextTokens
+	}
+	opts.Render.applyDefaults()
+
+	response, err := g.Search(ctx, question, opts.Search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve context: %w", err)
+	}
+
+	// Number the blocks that fit the context budget
+	result := &AnswerResult{Citations: []Citation{}, ContextNodeIDs: []string{}, MemoryIDs: []string{}}
+	var blocks strings.Builder
+	var cited []search.SearchResult
+	used := 0
+	for _, r := range response.Results {
+		rendered, err := g.RenderNode(ctx, r.NodeID, opts.Render)
+		if errors.Is(err, store.ErrNodeNotFound) {
+			continue // Deleted since the search
+		}
+		if err != nil {
+			return nil, err
+		}
+		block := fmt.Sprintf("[%d] %s\n\n", len(cited)+1, rendered)
+		tokens := chunker.EstimateTokens(block)
+		if used+tokens > opts.ContextTokens && len(cited) > 0 {
+			break
+		}
+		used += tokens
+		blocks.WriteString(block)
+		cited = append(cited, r)
+		result.ContextNodeIDs = append(result.ContextNodeIDs, r.NodeID)
+	}
+	if len(cited) == 0 {
+		return result, nil
+	}
+
+	var answer answerResponse
+	prompt := fmt.Sprintf(answerPrompt, blocks.String(), question)
+	if err := g.stageLLM(StageAnswer).CompleteWithSchema(ctx, prompt, &answer); err != nil {
+		return nil, fmt.Errorf("failed to generate answer: %w", err)
+	}
+	result.Answer = strings.TrimSpace(answer.Answer)
+
+	// Resolve cited block numbers, ignoring any the LLM made up
+	seenBlocks := make(map[int]bool)
+	seenMemories := make(map[string]bool)
+	numbers := append([]int(nil), answer.Citations...)
+	sort.Ints(numbers)
+	var citedResults []search.SearchResult
+	for _, n := range numbers {
+		if n < 1 || n > len(cited) || seenBlocks[n] {
+			continue
+		}
+		seenBlocks[n] = true
+		r := cited[n-1]
+		citation := Citation{NodeID: r.NodeID, MemoryIDs: r.MemoryIDs}
+		if r.Node != nil {
+			citation.Name = r.Node.Name
+		}
+		result.Citations = append(result.Citations, citation)
+		citedResults = append(citedResults, r)
+		for _, memoryID := range r.MemoryIDs {
+			if !seenMemories[memoryID] {
+				seenMemories[memoryID] = true
+				result.MemoryIDs = append(result.MemoryIDs, memoryID)
+			}
+		}
+	}
+	if err := g.attachCitedChunks(ctx, result.Citations, citedResults, opts.Search); err != nil {
+		return nil, err
+	}
+
+	if g.metricsCollector != nil {
+		g.metricsCollector.RecordOperation(ctx, "answer", "success", time.Since(startTime).Milliseconds())
+	}
+	return result, nil
+}
+
+// attachCitedChunks fills Citation.Chunks with the stored chunks the visible edges of
+// each cited result were extracted from, in document order.
+func (g *Gognee) attachCitedChunks(ctx context.Context, citations []Citation, results []search.SearchResult, opts SearchOptions) error {
+	if !g.config.ChunkEmbeddings || len(results) == 0 {
+		return nil
+	}
+	chunkStore, ok := g.graphStore.(store.ChunkStore)
+	if !ok {
+		return nil
+	}
+	incident, err := g.visibleIncidentEdges(ctx, results, opts, func(edge *store.Edge) bool { return edge.SourceChunkID != "" })
+	if err != nil {
+		return err
+	}
+	var chunkIDs []string
+	for _, edges := range incident {
+		for _, edge := range edges {
+			chunkIDs = append(chunkIDs, edge.SourceChunkID)
+		}
+	}
+	slices.Sort(chunkIDs)
+	chunks, err := chunkStore.ChunksByID(ctx, slices.Compact(chunkIDs))
+	if err != nil {
+		return fmt.Errorf("failed to load cited chunks: %w", err)
+	}
+
+	for i, edges := range incident {
+		used := make(map[string]bool, len(edges))
+		for _, edge := range edges {
+			used[edge.SourceChunkID] = true
+		}
+		for _, chunk := range chunks {
+			if used[chunk.ID] {
+				citations[i].Chunks = append(citations[i].Chunks, chunk)
+			}
+		}
+	}
+	return nil
+}
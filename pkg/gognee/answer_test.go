@@ -0,0 +1,140 @@
+package gognee
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/search"
+)
+
+// answerLLM extracts entities like MockLLMClient and answers questions with fixed citations
+type answerLLM struct {
+	*MockLLMClient
+	citations []int
+	prompts   []string
+}
+
+func (m *answerLLM) CompleteWithSchema(ctx context.Context, prompt string, schema interface{}) error {
+	if s, ok := schema.(*answerResponse); ok {
+		m.prompts = append(m.prompts, prompt)
+		*s = answerResponse{Answer: " Kubernetes runs the platform. ", Citations: m.citations}
+		return nil
+	}
+	return m.MockLLMClient.CompleteWithSchema(ctx, prompt, schema)
+}
+
+func TestAnswer_CitesNodesAndMemories(t *testing.T) {
+	ctx := context.Background()
+
+	llmClient := &answerLLM{
+		MockLLMClient: &MockLLMClient{
+			EntityResponses: [][]extraction.Entity{
+				{{Name: "Kubernetes", Type: "Technology", Description: "Container orchestrator"}},
+			},
+		},
+		citations: []int{1, 1, 7}, // Duplicate and out-of-range citations are dropped
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	res, err := g.AddMemory(ctx, MemoryInput{Topic: "Platform", Context: "We run on Kubernetes."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	answer, err := g.Answer(ctx, "Kubernetes", AnswerOptions{
+		Search: search.SearchOptions{Type: search.SearchTypeVector, TopK: 3},
+	})
+	if err != nil {
+		t.Fatalf("Answer failed: %v", err)
+	}
+
+	if answer.Answer != "Kubernetes runs the platform." {
+		t.Errorf("Unexpected answer %q", answer.Answer)
+	}
+	kubernetesID := g.nodeID("Kubernetes", "Technology")
+	if len(answer.Citations) != 1 || answer.Citations[0].NodeID != kubernetesID || answer.Citations[0].Name != "Kubernetes" {
+		t.Fatalf("Expected a single citation of Kubernetes, got %+v", answer.Citations)
+	}
+	if len(answer.MemoryIDs) != 1 || answer.MemoryIDs[0] != res.MemoryID {
+		t.Errorf("Expected memory %s to be cited, got %v", res.MemoryID, answer.MemoryIDs)
+	}
+	if len(answer.ContextNodeIDs) == 0 || answer.ContextNodeIDs[0] != kubernetesID {
+		t.Errorf("Expected Kubernetes first in context, got %v", answer.ContextNodeIDs)
+	}
+
+	prompt := llmClient.prompts[0]
+	if !strings.Contains(prompt, "[1] Kubernetes (Technology): Container orchestrator") || !strings.Contains(prompt, "Question: Kubernetes") {
+		t.Errorf("Prompt is missing the rendered context:\n%s", prompt)
+	}
+}
+
+func TestAnswer_NoContext(t *testing.T) {
+	ctx := context.Background()
+
+	llmClient := &answerLLM{MockLLMClient: &MockLLMClient{}}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	answer, err := g.Answer(ctx, "anything", AnswerOptions{})
+	if err != nil {
+		t.Fatalf("Answer failed: %v", err)
+	}
+	if answer.Answer != "" || len(answer.Citations) != 0 || len(llmClient.prompts) != 0 {
+		t.Errorf("Expected an empty answer without an LLM call, got %+v", answer)
+	}
+
+	if _, err := g.Answer(ctx, "  ", AnswerOptions{}); err == nil {
+		t.Error("Expected error for an empty question")
+	}
+}
+
+func TestAnswer_CitesChunkOffsets(t *testing.T) {
+	ctx := context.Background()
+
+	llmClient := &answerLLM{
+		MockLLMClient: &MockLLMClient{
+			EntityResponses: [][]extraction.Entity{{
+				{Name: "Zoë", Type: "Person", Description: "An engineer"},
+				{Name: "Go", Type: "Technology", Description: "A language"},
+			}},
+			RelationResponses: [][]extraction.Triplet{{{Subject: "Zoë", Relation: "USES", Object: "Go"}}},
+		},
+		citations: []int{1},
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:", ChunkEmbeddings: true}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	// Offsets count runes: "Zoë" and the leading "… " are wider in bytes
+	text := "… Zoë uses Go."
+	if err := g.Add(ctx, text, AddOptions{Source: "zoe.md"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if result, err := g.Cognify(ctx, CognifyOptions{}); err != nil || len(result.Errors) != 0 {
+		t.Fatalf("Cognify failed: %v %v", err, result.Errors)
+	}
+
+	answer, err := g.Answer(ctx, "Zoë", AnswerOptions{Search: search.SearchOptions{Type: search.SearchTypeVector, TopK: 1}})
+	if err != nil {
+		t.Fatalf("Answer failed: %v", err)
+	}
+	if len(answer.Citations) != 1 || len(answer.Citations[0].Chunks) != 1 {
+		t.Fatalf("expected one citation with its chunk, got %+v", answer.Citations)
+	}
+	chunk := answer.Citations[0].Chunks[0]
+	runes := []rune(text)
+	if chunk.Source != "zoe.md" || chunk.StartOffset != 0 || chunk.EndOffset != len(runes) {
+		t.Errorf("expected the chunk to span runes [0, %d) of zoe.md, got %+v", len(runes), chunk)
+	}
+}
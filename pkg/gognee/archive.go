@@ -0,0 +1,71 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+)
+
+// ArchiveOptions configures ArchiveMemory.
+type ArchiveOptions struct {
+	// RelocateEmbeddings removes the vectors of the nodes only archived memories reference
+	// from the vector index, keeping it small for search. The embeddings stay stored with
+	// the nodes and UnarchiveMemory indexes them again.
+	RelocateEmbeddings bool
+}
+
+// ArchiveMemory moves a memory to the archive tier (status Archived). Archived memories
+// are left out of ListMemories unless store.ListMemoriesOptions.IncludeArchived is set,
+// and the nodes only they reference are left out of Search unless
+// search.SearchOptions.IncludeArchived is set.
+func (g *Gognee) ArchiveMemory(ctx context.Context, id string, opts ArchiveOptions) error {
+	if err := g.memoryStore.ArchiveMemory(ctx, id); err != nil {
+		return fmt.Errorf("cannot archive memory: %w", err)
+	}
+	if !opts.RelocateEmbeddings {
+		return nil
+	}
+
+	nodeIDs, _, err := g.memoryStore.GetProvenanceByMemory(ctx, id)
+	if err != nil {
+		return err
+	}
+	archived, err := g.memoryStore.ArchivedNodeIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, nodeID := range nodeIDs {
+		if !archived[nodeID] {
+			continue
+		}
+		if err := g.vectorStore.Delete(ctx, nodeID); err != nil {
+			return fmt.Errorf("failed to remove vector of node %s: %w", nodeID, err)
+		}
+	}
+	return nil
+}
+
+// UnarchiveMemory takes a memory out of the archive, restoring the status it had when
+// archived, and indexes the embeddings of its nodes again.
+func (g *Gognee) UnarchiveMemory(ctx context.Context, id string) error {
+	if err := g.memoryStore.UnarchiveMemory(ctx, id); err != nil {
+		return fmt.Errorf("cannot unarchive memory: %w", err)
+	}
+
+	nodeIDs, _, err := g.memoryStore.GetProvenanceByMemory(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, nodeID := range nodeIDs {
+		node, err := g.graphStore.GetNode(ctx, nodeID)
+		if err != nil {
+			return err
+		}
+		if node == nil || len(node.Embedding) == 0 {
+			continue
+		}
+		if err := g.vectorStore.Add(ctx, nodeID, node.Embedding); err != nil {
+			return fmt.Errorf("failed to index node %s: %w", nodeID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/search"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestArchiveMemory(t *testing.T) {
+	ctx := context.Background()
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{{Name: "Legacy", Type: "System", Description: "The old billing system"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	mem, err := g.AddMemory(ctx, MemoryInput{Topic: "Billing", Context: "Legacy handles billing."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	results := func(opts search.SearchOptions) []search.SearchResult {
+		t.Helper()
+		opts.Type, opts.TopK = search.SearchTypeVector, 5
+		resp, err := g.Search(ctx, "Legacy", opts)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		return resp.Results
+	}
+	found := func(opts search.SearchOptions) int {
+		t.Helper()
+		return len(results(opts))
+	}
+	listed := func(opts store.ListMemoriesOptions) int {
+		t.Helper()
+		memories, err := g.ListMemories(ctx, opts)
+		if err != nil {
+			t.Fatalf("ListMemories failed: %v", err)
+		}
+		return len(memories)
+	}
+	if before := results(search.SearchOptions{}); len(before) != 1 || before[0].Source == "keyword" {
+		t.Fatalf("Expected the node found by its vector before archiving, got %+v", before)
+	}
+
+	if err := g.ArchiveMemory(ctx, mem.MemoryID, ArchiveOptions{}); err != nil {
+		t.Fatalf("ArchiveMemory failed: %v", err)
+	}
+	if n := found(search.SearchOptions{}); n != 0 {
+		t.Errorf("Expected the archived memory's node hidden from search, got %d results", n)
+	}
+	if n := found(search.SearchOptions{IncludeArchived: true}); n != 1 {
+		t.Errorf("Expected the node found with IncludeArchived, got %d results", n)
+	}
+	if n := listed(store.ListMemoriesOptions{}); n != 0 {
+		t.Errorf("Expected the archived memory left out of listings, got %d", n)
+	}
+	if n := listed(store.ListMemoriesOptions{IncludeArchived: true}); n != 1 {
+		t.Errorf("Expected the archived memory listed with IncludeArchived, got %d", n)
+	}
+
+	if err := g.UnarchiveMemory(ctx, mem.MemoryID); err != nil {
+		t.Fatalf("UnarchiveMemory failed: %v", err)
+	}
+	restored, err := g.GetMemory(ctx, mem.MemoryID)
+	if err != nil || restored.Status != "complete" {
+		t.Fatalf("Expected the status before archiving restored, got %+v (err %v)", restored, err)
+	}
+
+	// Relocated embeddings leave the vector index until unarchived
+	if err := g.ArchiveMemory(ctx, mem.MemoryID, ArchiveOptions{RelocateEmbeddings: true}); err != nil {
+		t.Fatalf("ArchiveMemory failed: %v", err)
+	}
+	for _, result := range results(search.SearchOptions{IncludeArchived: true}) {
+		if result.Source != "keyword" {
+			t.Errorf("Expected the node found by keyword only once its vector is relocated, got %+v", result)
+		}
+	}
+	if err := g.UnarchiveMemory(ctx, mem.MemoryID); err != nil {
+		t.Fatalf("UnarchiveMemory failed: %v", err)
+	}
+	if n := found(search.SearchOptions{}); n != 1 {
+		t.Errorf("Expected the node indexed again after unarchiving, got %d results", n)
+	}
+}
+
+// rankedSearcher returns the first TopK of its node IDs, best first, and records each TopK
+// and query embedding
+type rankedSearcher struct {
+	nodeIDs    []string
+	topKs      []int
+	embeddings [][]float32
+}
+
+func (s *rankedSearcher) Search(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+	s.topKs = append(s.topKs, opts.TopK)
+	s.embeddings = append(s.embeddings, opts.QueryEmbedding)
+	var results []search.SearchResult
+	for i, id := range s.nodeIDs {
+		if i == opts.TopK {
+			break
+		}
+		results = append(results, search.SearchResult{NodeID: id, Score: 1 - float64(i)/100, Source: "vector"})
+	}
+	return results, nil
+}
+
+func TestSearch_RefillsAfterHiddenNodes(t *testing.T) {
+	ctx := context.Background()
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	var nodeIDs []string
+	for i := 0; i < 60; i++ {
+		id := fmt.Sprintf("n%02d", i)
+		if err := g.graphStore.AddNode(ctx, &store.Node{ID: id, Name: id, Type: "Concept"}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+		nodeIDs = append(nodeIDs, id)
+	}
+	old := &store.MemoryRecord{Topic: "Old", Context: "Archived.", DocHash: "h1"}
+	live := &store.MemoryRecord{Topic: "Live", Context: "Current.", DocHash: "h2"}
+	for _, mem := range []*store.MemoryRecord{old, live} {
+		if err := g.memoryStore.AddMemory(ctx, mem); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+	}
+	if err := g.memoryStore.LinkProvenance(ctx, old.ID, nodeIDs[:56], nil); err != nil {
+		t.Fatalf("LinkProvenance failed: %v", err)
+	}
+	if err := g.memoryStore.LinkProvenance(ctx, live.ID, nodeIDs[56:], nil); err != nil {
+		t.Fatalf("LinkProvenance failed: %v", err)
+	}
+	if err := g.memoryStore.ArchiveMemory(ctx, old.ID); err != nil {
+		t.Fatalf("ArchiveMemory failed: %v", err)
+	}
+
+	// The 8 best ranked nodes are archived
+	searcher := &rankedSearcher{nodeIDs: append(append([]string{}, nodeIDs[:8]...), nodeIDs[56:]...)}
+	g.searcher = searcher
+	resp, err := g.Search(ctx, "anything", search.SearchOptions{TopK: 3})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) != 3 || resp.Results[0].NodeID != "n56" {
+		t.Errorf("Expected the 3 best live nodes, got %+v", resp.Results)
+	}
+	if len(searcher.topKs) != 2 || searcher.topKs[0] != 3 || searcher.topKs[1] != 3*hiddenRefillFactor {
+		t.Errorf("Expected one refill with a larger window, got TopKs %v", searcher.topKs)
+	}
+
+	// Refills are bounded when nearly everything is hidden, and reuse the query embedding
+	// rather than expanding the query again
+	expander := &countingExpander{}
+	g.queryExpander = expander
+	searcher.topKs = nil
+	searcher.embeddings = nil
+	searcher.nodeIDs = nodeIDs
+	if _, err := g.Search(ctx, "anything", search.SearchOptions{TopK: 3, Expansion: search.ExpansionHyDE}); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(searcher.topKs) != maxHiddenRefills+1 {
+		t.Errorf("Expected at most %d refills, got TopKs %v", maxHiddenRefills, searcher.topKs)
+	}
+	if expander.calls != 1 {
+		t.Errorf("Expected the query expanded once across refills, got %d expansions", expander.calls)
+	}
+	for i, embedding := range searcher.embeddings {
+		if len(embedding) == 0 {
+			t.Errorf("Expected search %d to get the query embedding", i)
+		}
+	}
+}
+
+func TestSearch_RerankOnceAfterRefills(t *testing.T) {
+	ctx := context.Background()
+	reranker := &countingReranker{}
+	g, err := NewWithClients(Config{DBPath: ":memory:", Reranker: reranker}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	var nodeIDs []string
+	for i := 0; i < 30; i++ {
+		id := fmt.Sprintf("n%02d", i)
+		if err := g.graphStore.AddNode(ctx, &store.Node{ID: id, Name: id, Type: "Concept"}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+		nodeIDs = append(nodeIDs, id)
+	}
+	old := &store.MemoryRecord{Topic: "Old", Context: "Archived.", DocHash: "h1"}
+	if err := g.memoryStore.AddMemory(ctx, old); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if err := g.memoryStore.LinkProvenance(ctx, old.ID, nodeIDs[:10], nil); err != nil {
+		t.Fatalf("LinkProvenance failed: %v", err)
+	}
+	if err := g.memoryStore.ArchiveMemory(ctx, old.ID); err != nil {
+		t.Fatalf("ArchiveMemory failed: %v", err)
+	}
+
+	// The 10 best ranked nodes are archived, so the 5 rerank candidates need a refill
+	searcher := &rankedSearcher{nodeIDs: nodeIDs}
+	g.searcher = searcher
+	resp, err := g.Search(ctx, "anything", search.SearchOptions{TopK: 2, Rerank: true, RerankCandidates: 5})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(searcher.topKs) != 2 || searcher.topKs[0] != 5 {
+		t.Errorf("Expected the rerank candidates and one refill to be retrieved, got TopKs %v", searcher.topKs)
+	}
+	if len(reranker.calls) != 1 || len(reranker.calls[0]) != 5 || !strings.HasPrefix(reranker.calls[0][0], "n10") {
+		t.Errorf("Expected one rerank of the 5 best live candidates, got %v", reranker.calls)
+	}
+	// The reranker prefers the last candidate
+	if len(resp.Results) != 2 || resp.Results[0].NodeID != "n14" {
+		t.Errorf("Expected the reranker's order, got %+v", resp.Results)
+	}
+}
+
+// countingReranker records the documents of each call and scores later documents higher
+type countingReranker struct {
+	calls [][]string
+}
+
+func (r *countingReranker) Rerank(ctx context.Context, query string, documents []string) ([]float64, error) {
+	r.calls = append(r.calls, documents)
+	scores := make([]float64, len(documents))
+	for i := range documents {
+		scores[i] = float64(i+1) / float64(len(documents))
+	}
+	return scores, nil
+}
+
+// countingExpander returns one expansion and counts its calls
+type countingExpander struct {
+	calls int
+}
+
+func (e *countingExpander) Expand(ctx context.Context, query string, mode search.QueryExpansion) ([]string, error) {
+	e.calls++
+	return []string{"an answer to " + query}, nil
+}
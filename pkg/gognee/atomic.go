@@ -0,0 +1,99 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dan-solli/gognee/pkg/chunker"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// stagedDocument collects a document's writes for CognifyOptions.Atomic. They are
+// applied in one transaction once every chunk was extracted without a hard error.
+type stagedDocument struct {
+	write        store.DocumentWrite
+	nodeVectors  bool // Node vectors go through the transaction (SQLiteVectorStore)
+	edgeVectors  bool // Edge vectors go through the transaction (SQLiteEdgeVectorStore)
+	nodesCreated int
+	edgesCreated int
+	chunks       []chunker.Chunk // Written chunks, stored once the document commits (Config.ChunkEmbeddings)
+
+	descriptionMerges []store.DescriptionMerge // Recorded in the description history once the document commits
+}
+
+// stageDocument starts staging a document's writes
+func (g *Gognee) stageDocument() *stagedDocument {
+	_, nodeVectors := g.vectorStore.(*store.SQLiteVectorStore)
+	_, edgeVectors := g.edgeVectorStore.(*store.SQLiteEdgeVectorStore)
+	return &stagedDocument{
+		write: store.DocumentWrite{
+			IndexNodes:     nodeVectors,
+			EdgeEmbeddings: make(map[string][]float32),
+			Mentions:       make(map[string]int),
+		},
+		nodeVectors: nodeVectors,
+		edgeVectors: edgeVectors,
+	}
+}
+
+// addNodes stages a chunk's nodes and counts a mention of each, as recordMentions does
+func (d *stagedDocument) addNodes(nodes []*store.Node) {
+	d.write.Nodes = append(d.write.Nodes, nodes...)
+	d.nodesCreated += len(nodes)
+	seen := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		if !seen[node.ID] {
+			seen[node.ID] = true
+			d.write.Mentions[node.ID]++
+		}
+	}
+}
+
+// addChunk stages the provenance of a written chunk
+func (d *stagedDocument) addChunk(chunk chunker.Chunk) {
+	d.write.ChunkIDs = append(d.write.ChunkIDs, chunk.ID)
+	d.chunks = append(d.chunks, chunk)
+}
+
+// addEdges stages a chunk's edges with their embeddings, if any
+func (d *stagedDocument) addEdges(edges []*store.Edge, embeddings map[string][]float32) {
+	d.write.Edges = append(d.write.Edges, edges...)
+	d.edgesCreated += len(edges)
+	for id, embedding := range embeddings {
+		d.write.EdgeEmbeddings[id] = embedding
+	}
+}
+
+// commitDocument writes a staged document in one transaction. Vectors kept outside the
+// database (MemoryVectorStore, Qdrant, ...) cannot join it and are indexed once it has
+// committed; their failures are reported without undoing the document.
+func (g *Gognee) commitDocument(ctx context.Context, writer store.AtomicWriter, d *stagedDocument, result *CognifyResult) error {
+	if err := writer.WriteDocument(ctx, &d.write); err != nil {
+		return fmt.Errorf("failed to write document: %w", err)
+	}
+	result.NodesCreated += d.nodesCreated
+	result.EdgesCreated += d.edgesCreated
+
+	if !d.nodeVectors {
+		for _, node := range d.write.Nodes {
+			if node.Embedding == nil {
+				continue
+			}
+			if err := g.vectorStore.Add(ctx, node.ID, node.Embedding); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to index node %s in vector store: %w", node.Name, err))
+			}
+		}
+	}
+	if !d.edgeVectors {
+		for _, edge := range d.write.Edges {
+			embedding, ok := d.write.EdgeEmbeddings[edge.ID]
+			if !ok {
+				continue
+			}
+			if err := g.edgeVectorStore.Add(ctx, edge.ID, embedding); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to index edge %s: %w", edge.ID, err))
+			}
+		}
+	}
+	return nil
+}
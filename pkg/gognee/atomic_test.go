@@ -0,0 +1,60 @@
+package gognee
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestCognify_AtomicRollsBackDocument(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "atomic.db")
+	g, err := NewWithClients(Config{DBPath: dbPath, ChunkStrategy: "semantic", ChunkSize: 6}, lengthEmbeddingClient{}, &topicLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	// topicLLMClient fails on Topic3, after two chunks were extracted
+	_ = g.Add(ctx, "This paragraph is about Topic1.\n\nThis paragraph is about Topic2.\n\nThis paragraph is about Topic3.", AddOptions{})
+	result, err := g.Cognify(ctx, CognifyOptions{Atomic: true})
+	if err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	if result.DocumentsRolledBack != 1 || result.NodesCreated != 0 {
+		t.Fatalf("expected the document rolled back, got %+v", result)
+	}
+	nodes, _ := g.graphStore.(store.BulkReader).GetAllNodes(ctx)
+	if len(nodes) != 0 {
+		t.Errorf("expected no nodes written, got %d", len(nodes))
+	}
+	if processed, _ := g.graphStore.(store.DocumentTracker).GetProcessedDocumentCount(ctx); processed != 0 {
+		t.Errorf("expected the document not marked processed, got %d", processed)
+	}
+
+	// A document without hard errors is committed with its vectors
+	_ = g.Add(ctx, "This paragraph is about Topic1.\n\nThis paragraph is about Topic2.", AddOptions{})
+	result, err = g.Cognify(ctx, CognifyOptions{Atomic: true})
+	if err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	if result.DocumentsRolledBack != 0 || result.NodesCreated != 2 {
+		t.Fatalf("expected the document committed, got %+v", result)
+	}
+	if nodes, _ := g.graphStore.(store.BulkReader).GetAllNodes(ctx); len(nodes) != 2 || nodes[0].MentionCount != 1 {
+		t.Errorf("expected 2 nodes with a mention each, got %+v", nodes)
+	}
+	if results, _ := g.vectorStore.Search(ctx, []float32{float32(len("Topic1 about Topic1")), 1, 0, 0}, 2); len(results) != 2 {
+		t.Errorf("expected both nodes indexed, got %v", results)
+	}
+	if processed, _ := g.graphStore.(store.DocumentTracker).GetProcessedDocumentCount(ctx); processed != 1 {
+		t.Errorf("expected the document marked processed, got %d", processed)
+	}
+	if docs, _ := g.ListDocuments(ctx); len(docs) != 1 {
+		t.Fatalf("expected the document's provenance recorded, got %+v", docs)
+	} else if nodes, _ := g.GetNodesByDocument(ctx, docs[0].Hash); len(nodes) != 2 {
+		t.Errorf("expected both nodes linked to the document, got %+v", nodes)
+	}
+}
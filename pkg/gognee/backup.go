@@ -0,0 +1,65 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// Backup writes a consistent snapshot of the whole store (graph, embeddings, memories
+// and provenance) to path using the SQLite online backup API.
+// The snapshot covers every namespace in the database, not just Config.Namespace.
+// Safe to call while the instance is serving reads and writes.
+// An existing file at path is overwritten.
+func (g *Gognee) Backup(ctx context.Context, path string) error {
+	snapshots, ok := g.graphStore.(store.Snapshotter)
+	if !ok {
+		return fmt.Errorf("backup requires a graph store implementing store.Snapshotter")
+	}
+	return snapshots.Backup(ctx, path)
+}
+
+// Restore replaces the store contents with the snapshot at path (created by Backup).
+// Like Backup it operates on the whole database, so all namespaces are replaced.
+// In-process state derived from the database is reloaded afterwards: relation synonyms
+// learned by CollapseRelations, and the in-memory vector index for ":memory:" databases.
+// Buffered documents that have not been cognified are kept.
+func (g *Gognee) Restore(ctx context.Context, path string) error {
+	snapshots, ok := g.graphStore.(store.Snapshotter)
+	if !ok {
+		return fmt.Errorf("restore requires a graph store implementing store.Snapshotter")
+	}
+
+	if err := snapshots.Restore(ctx, path); err != nil {
+		return err
+	}
+
+	if relations, ok := g.graphStore.(store.RelationMerger); ok {
+		merges, err := relations.ListRelationMerges(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to reload relation merges: %w", err)
+		}
+		synonyms := buildRelationSynonyms(merges, g.config.RelationSynonyms)
+		g.relationMu.Lock()
+		g.relationSynonyms = synonyms
+		g.relationMu.Unlock()
+	}
+
+	// The in-memory vector index is not part of the database; rebuild it from node embeddings
+	memVectors, isMemory := g.vectorStore.(*store.MemoryVectorStore)
+	if reader, ok := g.graphStore.(store.BulkReader); ok && isMemory {
+		memVectors.Clear()
+		err := reader.IterateNodes(ctx, func(node *store.Node) error {
+			if len(node.Embedding) == 0 {
+				return nil
+			}
+			return memVectors.Add(ctx, node.ID, node.Embedding)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to rebuild vector index: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package gognee
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestBackupRestore_InMemoryRebuildsVectorIndex(t *testing.T) {
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	ctx := context.Background()
+	result, err := g.AddMemory(ctx, MemoryInput{Topic: "Backups", Context: "We snapshot the store nightly."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "gognee-backup.db")
+	if err := g.Backup(ctx, path); err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+
+	// Wipe the memory and its nodes, then restore
+	if err := g.DeleteMemory(ctx, result.MemoryID); err != nil {
+		t.Fatalf("DeleteMemory failed: %v", err)
+	}
+	memVectors := g.vectorStore.(*store.MemoryVectorStore)
+	memVectors.Clear()
+
+	if err := g.Restore(ctx, path); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	if _, err := g.GetMemory(ctx, result.MemoryID); err != nil {
+		t.Fatalf("Expected memory to be restored: %v", err)
+	}
+
+	resp, err := g.Search(ctx, "TestEntity A test entity", SearchOptions{Type: SearchTypeVector})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) == 0 {
+		t.Error("Expected search results after vector index rebuild")
+	}
+}
@@ -0,0 +1,86 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// addNodes upserts nodes in one transaction when the graph store is a store.BulkWriter,
+// and one at a time otherwise. It returns the nodes written and the errors of the rest.
+func (g *Gognee) addNodes(ctx context.Context, nodes []*store.Node) ([]*store.Node, []error) {
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+	if writer, ok := g.graphStore.(store.BulkWriter); ok {
+		if err := writer.AddNodes(ctx, nodes); err != nil {
+			return nil, []error{fmt.Errorf("failed to add %d nodes: %w", len(nodes), err)}
+		}
+		return nodes, nil
+	}
+
+	added := make([]*store.Node, 0, len(nodes))
+	var errs []error
+	for _, node := range nodes {
+		if err := g.graphStore.AddNode(ctx, node); err != nil {
+			errs = append(errs, fmt.Errorf("failed to add node %s: %w", node.Name, err))
+			continue
+		}
+		added = append(added, node)
+	}
+	return added, errs
+}
+
+// withoutEmbeddings returns copies of nodes to upsert without their embeddings, when the
+// graph store lets indexNodeEmbedding store them (store.NodeEmbeddingWriter), so each is
+// written once.
+func (g *Gognee) withoutEmbeddings(nodes []*store.Node) []*store.Node {
+	if _, ok := g.graphStore.(store.NodeEmbeddingWriter); !ok {
+		return nodes
+	}
+	rows := make([]*store.Node, len(nodes))
+	for i, node := range nodes {
+		row := *node
+		row.Embedding = nil
+		rows[i] = &row
+	}
+	return rows
+}
+
+// indexNodeEmbedding adds a node's embedding to the vector store. SQLiteVectorStore keeps
+// the nodes' embedding column in sync itself; for other vector stores it is updated here.
+func (g *Gognee) indexNodeEmbedding(ctx context.Context, id string, embedding []float32) error {
+	if _, inDatabase := g.vectorStore.(*store.SQLiteVectorStore); !inDatabase {
+		if writer, ok := g.graphStore.(store.NodeEmbeddingWriter); ok {
+			if err := writer.UpdateNodeEmbedding(ctx, id, embedding); err != nil {
+				return err
+			}
+		}
+	}
+	return g.vectorStore.Add(ctx, id, embedding)
+}
+
+// addEdges is addNodes for edges.
+func (g *Gognee) addEdges(ctx context.Context, edges []*store.Edge) ([]*store.Edge, []error) {
+	if len(edges) == 0 {
+		return nil, nil
+	}
+	if writer, ok := g.graphStore.(store.BulkWriter); ok {
+		if err := writer.AddEdges(ctx, edges); err != nil {
+			return nil, []error{fmt.Errorf("failed to add %d edges: %w", len(edges), err)}
+		}
+		return edges, nil
+	}
+
+	added := make([]*store.Edge, 0, len(edges))
+	var errs []error
+	for _, edge := range edges {
+		if err := g.graphStore.AddEdge(ctx, edge); err != nil {
+			errs = append(errs, fmt.Errorf("failed to add edge %s: %w", edge.ID, err))
+			continue
+		}
+		added = append(added, edge)
+	}
+	return added, errs
+}
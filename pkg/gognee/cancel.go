@@ -0,0 +1,38 @@
+package gognee
+
+import (
+	"context"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/embeddings"
+	"github.com/dan-solli/gognee/pkg/llm"
+)
+
+// OperationStatus reports whether a long-running operation (Cognify, Prune, Reembed,
+// ResolveEntities) ran to completion. Those operations check ctx between iterations; when
+// it is canceled or its deadline passes they stop early and return the partial result
+// with OperationCancelled and a nil error, so a deadline bounds them without losing the
+// work already done.
+type OperationStatus string
+
+const (
+	// OperationCompleted means every item was processed.
+	OperationCompleted OperationStatus = "completed"
+	// OperationCancelled means ctx ended the operation early; the result counts only the
+	// work done before it stopped.
+	OperationCancelled OperationStatus = "cancelled"
+)
+
+// operationStatus returns OperationCancelled once ctx is done, else OperationCompleted.
+func operationStatus(ctx context.Context) OperationStatus {
+	if ctx.Err() != nil {
+		return OperationCancelled
+	}
+	return OperationCompleted
+}
+
+// withCallTimeouts returns a context bounding each LLM and embedding request by the given
+// per-operation timeouts, where set (see llm.WithTimeout and embeddings.WithTimeout).
+func withCallTimeouts(ctx context.Context, llmTimeout, embeddingTimeout time.Duration) context.Context {
+	return embeddings.WithTimeout(llm.WithTimeout(ctx, llmTimeout), embeddingTimeout)
+}
@@ -0,0 +1,132 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/embeddings"
+	"github.com/dan-solli/gognee/pkg/llm"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestPrune_CancelledContext(t *testing.T) {
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	ctx := context.Background()
+	old := &store.Node{ID: "old", Name: "Old", CreatedAt: time.Now().Add(-90 * 24 * time.Hour)}
+	if err := g.graphStore.AddNode(ctx, old); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	result, err := g.Prune(canceled, PruneOptions{MaxAgeDays: 30})
+	if err != nil {
+		t.Fatalf("Expected a partial result without error, got %v", err)
+	}
+	if result.Status != OperationCancelled || result.NodesPruned != 0 {
+		t.Errorf("Expected a cancelled prune with nothing pruned, got %+v", result)
+	}
+	if count, _ := g.graphStore.NodeCount(ctx); count != 1 {
+		t.Errorf("Expected the node kept by the cancelled prune, got %d nodes", count)
+	}
+
+	result, err = g.Prune(ctx, PruneOptions{MaxAgeDays: 30})
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if result.Status != OperationCompleted || result.NodesPruned != 1 {
+		t.Errorf("Expected a completed prune of 1 node, got %+v", result)
+	}
+}
+
+func TestReembed_CancelledContext(t *testing.T) {
+	g, err := NewWithClients(Config{DBPath: ":memory:", EmbeddingModel: "next-model"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	ctx := context.Background()
+	node := &store.Node{ID: "n1", Name: "Go", Embedding: []float32{0.1, 0.2, 0.3}}
+	if err := g.graphStore.AddNode(ctx, node); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	result, err := g.Reembed(canceled)
+	if err != nil {
+		t.Fatalf("Expected a partial result without error, got %v", err)
+	}
+	if result.Status != OperationCancelled || result.NodesReembedded != 0 {
+		t.Errorf("Expected a cancelled reembed, got %+v", result)
+	}
+}
+
+// deadlineLLMClient records the deadline a request would get from llm.RequestContext
+type deadlineLLMClient struct {
+	MockLLMClient
+	timeouts []time.Duration
+}
+
+func (c *deadlineLLMClient) CompleteWithSchema(ctx context.Context, prompt string, schema interface{}) error {
+	reqCtx, cancel := llm.RequestContext(ctx, 0)
+	defer cancel()
+	var timeout time.Duration
+	if deadline, ok := reqCtx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	c.timeouts = append(c.timeouts, timeout)
+	return c.MockLLMClient.CompleteWithSchema(ctx, prompt, schema)
+}
+
+func TestCognify_LLMTimeoutOverride(t *testing.T) {
+	client := &deadlineLLMClient{}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, client)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	ctx := context.Background()
+	if err := g.Add(ctx, "Alice uses Go.", AddOptions{}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := g.Cognify(ctx, CognifyOptions{LLMTimeout: 5 * time.Second}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	if len(client.timeouts) == 0 {
+		t.Fatal("Expected LLM calls")
+	}
+	for _, timeout := range client.timeouts {
+		if timeout <= 0 || timeout > 5*time.Second {
+			t.Errorf("Expected each request bounded by 5s, got %v", timeout)
+		}
+	}
+}
+
+func TestNew_ClientTimeouts(t *testing.T) {
+	g, err := New(Config{DBPath: ":memory:", OpenAIKey: "key", LLMTimeout: 20 * time.Second, EmbeddingTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	if client := g.llm.(*llm.OpenAILLM); client.Timeout != 20*time.Second {
+		t.Errorf("Expected the LLM timeout applied, got %v", client.Timeout)
+	}
+	if client := g.GetEmbeddings().(*embeddings.OpenAIClient); client.Timeout != 5*time.Second {
+		t.Errorf("Expected the embedding timeout applied, got %v", client.Timeout)
+	}
+
+	if _, err := NewWithClients(Config{DBPath: ":memory:", LLMTimeout: -time.Second}, &MockEmbeddingClient{}, &MockLLMClient{}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for a negative timeout, got %v", err)
+	}
+}
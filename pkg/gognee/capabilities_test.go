@@ -0,0 +1,113 @@
+package gognee
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/search"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// coreGraphStore exposes only the core GraphStore methods of the wrapped store,
+// standing in for a partial backend without any optional capabilities.
+type coreGraphStore struct {
+	store.GraphStore
+}
+
+func TestPartialGraphStore(t *testing.T) {
+	ctx := context.Background()
+
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{
+			{Name: "Go", Type: "Technology", Description: "Programming language"},
+		}},
+	}
+	g.llm = mockLLM
+	g.embeddings = &MockEmbeddingClient{}
+	g.entityExtractor = extraction.NewEntityExtractor(mockLLM)
+	g.relationExtractor = extraction.NewRelationExtractor(mockLLM)
+
+	core := &coreGraphStore{GraphStore: g.graphStore}
+	g.graphStore = core
+	g.searcher = search.NewHybridSearcher(g.embeddings, g.vectorStore, core)
+
+	if err := g.Add(ctx, "We write services in Go.", AddOptions{}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	result, err := g.Cognify(ctx, CognifyOptions{})
+	if err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	if result.NodesCreated != 1 {
+		t.Fatalf("Expected 1 node, got %d (errors: %v)", result.NodesCreated, result.Errors)
+	}
+
+	// Search works without access tracking
+	resp, err := g.Search(ctx, "Go Programming language", SearchOptions{Type: search.SearchTypeVector, TopK: 1})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) != 1 {
+		t.Errorf("Expected 1 search result, got %d", len(resp.Results))
+	}
+
+	// Prune skips node evaluation instead of failing
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	if err := core.AddNode(ctx, &store.Node{ID: "old", Name: "Old", CreatedAt: old}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	pruned, err := g.Prune(ctx, PruneOptions{MaxAgeDays: 30})
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if pruned.NodesEvaluated != 0 || pruned.NodesPruned != 0 {
+		t.Errorf("Expected node prune to be skipped, got %+v", pruned)
+	}
+	if n, _ := core.GetNode(ctx, "old"); n == nil {
+		t.Error("Node should not be pruned by a store without Deleter")
+	}
+
+	// Export needs BulkReader
+	if err := g.ExportGraph(ctx, &bytes.Buffer{}, ExportFormatDOT); err == nil {
+		t.Error("Expected export to fail without BulkReader")
+	}
+
+	// Path finding needs PathFinder
+	if _, err := g.FindPaths(ctx, "old", "old", store.PathOptions{}); err == nil {
+		t.Error("Expected FindPaths to fail without PathFinder")
+	}
+
+	// Community detection needs CommunityStore
+	if _, err := g.DetectCommunities(ctx, CommunityOptions{}); err == nil {
+		t.Error("Expected DetectCommunities to fail without CommunityStore")
+	}
+
+	// Diffs, the outbox and pinning need their capabilities too
+	if _, err := g.DiffGraph(ctx, time.Time{}); err == nil {
+		t.Error("Expected DiffGraph to fail without GraphDiffer")
+	}
+	if _, err := g.ReadOutbox(ctx, 0, 0); err == nil {
+		t.Error("Expected ReadOutbox to fail without Outbox")
+	}
+	if err := g.PinNode(ctx, "old", "important"); err == nil {
+		t.Error("Expected PinNode to fail without NodePinner")
+	}
+
+	// Stats leaves out the breakdowns the store cannot give
+	stats, err := g.Stats()
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.NodesByType != nil || stats.DatabaseSizeBytes != 0 {
+		t.Errorf("Expected no graph breakdowns, got %v and %d bytes", stats.NodesByType, stats.DatabaseSizeBytes)
+	}
+}
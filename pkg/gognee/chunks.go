@@ -0,0 +1,93 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/dan-solli/gognee/pkg/chunker"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// indexChunks stores the text, embedding and rune offsets in doc.Text of a document's
+// written chunks when Config.ChunkEmbeddings is enabled. Like provenance it is
+// best-effort: failures are reported in result without failing the document.
+func (g *Gognee) indexChunks(ctx context.Context, doc AddedDocument, hash string, chunks []chunker.Chunk, result *CognifyResult) {
+	if !g.config.ChunkEmbeddings || len(chunks) == 0 {
+		return
+	}
+	chunkStore, ok := g.graphStore.(store.ChunkStore)
+	if !ok {
+		return
+	}
+
+	texts := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		texts[i] = chunk.Text
+	}
+	embeddings, err := g.embeddings.Embed(ctx, texts)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("failed to embed chunks: %w", err))
+		return
+	}
+	offsets := runeOffsets(doc.Text, chunks)
+	for i, chunk := range chunks {
+		if i >= len(embeddings) || len(embeddings[i]) == 0 {
+			continue
+		}
+		record := &store.Chunk{ID: chunk.ID, DocumentHash: hash, Source: doc.Source, Index: chunk.Index, Text: chunk.Text,
+			StartOffset: offsets[chunk.StartOffset], EndOffset: offsets[chunk.EndOffset]}
+		if err := chunkStore.AddChunk(ctx, record, embeddings[i]); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("failed to index chunk %s: %w", chunk.ID, err))
+		}
+	}
+}
+
+// runeOffsets maps the byte offsets of chunks in text (see chunker.Chunk) to rune
+// offsets, counting the runes between consecutive offsets once.
+func runeOffsets(text string, chunks []chunker.Chunk) map[int]int {
+	var bytes []int
+	for _, chunk := range chunks {
+		bytes = append(bytes, chunk.StartOffset, chunk.EndOffset)
+	}
+	slices.Sort(bytes)
+	offsets := make(map[int]int, len(bytes))
+	pos, runes := 0, 0
+	for _, offset := range slices.Compact(bytes) {
+		runes += utf8.RuneCountInString(text[pos:offset])
+		offsets[offset], pos = runes, offset
+	}
+	return offsets
+}
+
+// SearchChunks returns the stored chunks whose text is most similar to the query, best
+// first (default topK: 10). Requires Config.ChunkEmbeddings; only chunks Cognify wrote
+// while it was enabled are searchable. Chunks of quarantined documents are not returned.
+func (g *Gognee) SearchChunks(ctx context.Context, query string, topK int) ([]store.ChunkMatch, error) {
+	return g.searchChunks(ctx, query, topK, nil)
+}
+
+// searchChunks is SearchChunks restricted to chunks of documents with one of sources
+func (g *Gognee) searchChunks(ctx context.Context, query string, topK int, sources []string) ([]store.ChunkMatch, error) {
+	if !g.config.ChunkEmbeddings {
+		return nil, fmt.Errorf("chunk search requires Config.ChunkEmbeddings")
+	}
+	if topK <= 0 {
+		topK = 10
+	}
+	chunkStore, ok := g.graphStore.(store.ChunkStore)
+	if !ok {
+		return nil, fmt.Errorf("chunk search requires a graph store implementing store.ChunkStore")
+	}
+
+	queryEmbedding, err := g.embeddings.EmbedOne(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	matches, err := chunkStore.SearchChunks(ctx, queryEmbedding, topK, sources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search chunks: %w", err)
+	}
+	return matches, nil
+}
@@ -0,0 +1,96 @@
+package gognee
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/search"
+)
+
+func TestSearch_ChunkRetrieval(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &MockLLMClient{EntityResponses: [][]extraction.Entity{
+		{{Name: "Alice", Type: "Person", Description: "An engineer"}},
+		{{Name: "Bob", Type: "Person", Description: "A manager"}},
+	}}
+	g, err := NewWithClients(Config{DBPath: ":memory:", ChunkEmbeddings: true}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	_ = g.Add(ctx, "Alice writes Go.", AddOptions{Source: "alice.md"})
+	_ = g.Add(ctx, "Bob manages projects.", AddOptions{Source: "bob.md"})
+	result, err := g.Cognify(ctx, CognifyOptions{})
+	if err != nil || len(result.Errors) != 0 {
+		t.Fatalf("Cognify failed: %v %v", err, result.Errors)
+	}
+
+	// The exact chunk text embeds identically, so it is the best match
+	matches, err := g.SearchChunks(ctx, "Bob manages projects.", 1)
+	if err != nil {
+		t.Fatalf("SearchChunks failed: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Chunk.Text != "Bob manages projects." || matches[0].Chunk.Source != "bob.md" {
+		t.Fatalf("unexpected chunk matches %+v", matches)
+	}
+
+	resp, err := g.Search(ctx, "Alice writes Go.", search.SearchOptions{Type: search.SearchTypeVector, TopK: 5, ChunkTopK: 5})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) == 0 || len(resp.Chunks) != 2 || resp.Chunks[0].Chunk.Text != "Alice writes Go." {
+		t.Errorf("expected nodes and both chunks, best first, got %d results and %+v", len(resp.Results), resp.Chunks)
+	}
+
+	// Source filters apply to chunks too
+	resp, err = g.Search(ctx, "Alice writes Go.", search.SearchOptions{Type: search.SearchTypeVector, ChunkTopK: 5, Sources: []string{"bob.md"}})
+	if err != nil || len(resp.Chunks) != 1 || resp.Chunks[0].Chunk.Source != "bob.md" {
+		t.Errorf("expected only bob.md's chunk, got %+v (err %v)", resp, err)
+	}
+}
+
+func TestSearch_ChunkRetrievalRequiresConfig(t *testing.T) {
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	if _, err := g.Search(context.Background(), "anything", search.SearchOptions{ChunkTopK: 3}); err == nil {
+		t.Error("expected chunk retrieval to fail without Config.ChunkEmbeddings")
+	}
+}
+
+func TestReembed_Chunks(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "chunks.db")
+	ctx := context.Background()
+
+	g, err := NewWithClients(Config{DBPath: dbPath, ChunkEmbeddings: true}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	_ = g.Add(ctx, "Rotate the API keys monthly.", AddOptions{})
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	g.Close()
+
+	g, err = NewWithClients(Config{DBPath: dbPath, ChunkEmbeddings: true}, &wideEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+	result, err := g.Reembed(ctx)
+	if err != nil {
+		t.Fatalf("Reembed failed: %v", err)
+	}
+	if result.ChunksReembedded != 1 || !result.IndexReset {
+		t.Errorf("expected the chunk re-embedded into a reset index, got %+v", result)
+	}
+	if matches, err := g.SearchChunks(ctx, "Rotate the API keys monthly.", 1); err != nil || len(matches) != 1 {
+		t.Errorf("expected the chunk searchable after Reembed, got %+v (err %v)", matches, err)
+	}
+}
@@ -0,0 +1,19 @@
+package gognee
+
+import "github.com/dan-solli/gognee/pkg/breaker"
+
+// Providers guarded by circuit breakers (Config.CircuitBreakerThreshold)
+const (
+	ProviderLLM        = "llm"
+	ProviderEmbeddings = "embeddings"
+)
+
+// CircuitStates reports the circuit breaker state of each provider, keyed by
+// ProviderLLM and ProviderEmbeddings. It is empty when circuit breakers are disabled.
+func (g *Gognee) CircuitStates() map[string]breaker.State {
+	states := make(map[string]breaker.State, len(g.breakers))
+	for provider, b := range g.breakers {
+		states[provider] = b.State()
+	}
+	return states
+}
@@ -0,0 +1,101 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/breaker"
+	"github.com/dan-solli/gognee/pkg/provider"
+)
+
+// errServiceUnavailable is what a provider in an outage answers
+var errServiceUnavailable = provider.NewStatusError(http.StatusServiceUnavailable, errors.New("service unavailable"))
+
+// downLLMClient fails every call while down is set
+type downLLMClient struct {
+	MockLLMClient
+	down bool
+}
+
+func (d *downLLMClient) Complete(ctx context.Context, prompt string) (string, error) {
+	if d.down {
+		d.CallCount++
+		return "", errServiceUnavailable
+	}
+	return d.MockLLMClient.Complete(ctx, prompt)
+}
+
+func (d *downLLMClient) CompleteWithSchema(ctx context.Context, prompt string, schema any) error {
+	if d.down {
+		d.CallCount++
+		return errServiceUnavailable
+	}
+	return d.MockLLMClient.CompleteWithSchema(ctx, prompt, schema)
+}
+
+func TestCognify_CircuitBreakerDefersDocuments(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &downLLMClient{down: true}
+	g, err := NewWithClients(Config{
+		DBPath:                  ":memory:",
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  20 * time.Millisecond,
+	}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	for _, text := range []string{"Alice uses Go", "Bob uses Rust", "Carol uses Zig"} {
+		_ = g.Add(ctx, text, AddOptions{})
+	}
+	result, err := g.Cognify(ctx, CognifyOptions{})
+	if err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+
+	// Two failures open the circuit; the third document is deferred without a call
+	if llmClient.CallCount != 2 {
+		t.Errorf("expected 2 LLM calls before the circuit opened, got %d", llmClient.CallCount)
+	}
+	if result.DocumentsDeferred != 1 || g.BufferedCount() != 1 {
+		t.Errorf("expected 1 deferred document left buffered, got %d deferred, %d buffered", result.DocumentsDeferred, g.BufferedCount())
+	}
+	if g.CircuitStates()[ProviderLLM] != breaker.StateOpen {
+		t.Errorf("expected the LLM circuit open, got %v", g.CircuitStates())
+	}
+	if !errors.Is(result.Errors[len(result.Errors)-1], breaker.ErrOpen) {
+		t.Errorf("expected the last error to be ErrOpen, got %v", result.Errors)
+	}
+
+	// Once the provider recovers and the cooldown passes, the deferred document is processed
+	llmClient.down = false
+	time.Sleep(30 * time.Millisecond)
+	result, err = g.Cognify(ctx, CognifyOptions{})
+	if err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	if result.DocumentsProcessed != 1 || result.DocumentsDeferred != 0 || g.BufferedCount() != 0 {
+		t.Errorf("expected the deferred document processed, got %+v, %d buffered", result, g.BufferedCount())
+	}
+	if g.CircuitStates()[ProviderLLM] != breaker.StateClosed {
+		t.Errorf("expected the LLM circuit closed, got %v", g.CircuitStates())
+	}
+}
+
+func TestNewWithClients_CircuitBreakerDisabled(t *testing.T) {
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+	if len(g.CircuitStates()) != 0 {
+		t.Errorf("expected no circuit breakers by default, got %v", g.CircuitStates())
+	}
+	if _, err := NewWithClients(Config{DBPath: ":memory:", CircuitBreakerThreshold: -1}, &MockEmbeddingClient{}, &MockLLMClient{}); err == nil {
+		t.Error("expected error for a negative CircuitBreakerThreshold")
+	}
+}
@@ -0,0 +1,189 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dan-solli/gognee/pkg/breaker"
+	"github.com/dan-solli/gognee/pkg/chunker"
+	"github.com/dan-solli/gognee/pkg/extraction"
+)
+
+// chunkExtraction holds the provider results for one chunk: extracted and filtered
+// entities and relations plus their embeddings. Chunks are extracted concurrently
+// (CognifyOptions.Concurrency) and written to the graph in chunk order.
+type chunkExtraction struct {
+	entities      []extraction.Entity // Canonical entities to write
+	renames       entityRenames       // Alias renames to apply to triplets
+	triplets      []extraction.Triplet
+	embeddings    [][]float32 // Embeddings of entities[entityIndices[j]]; nil if embedding failed
+	entityIndices []int
+
+	entitiesFailed bool // Entity extraction failed; nothing to write
+	deferred       bool // A provider's circuit was open
+	failures       int  // Failed stages, counted in CognifyResult.ChunksFailed
+	errors         []error
+
+	guardrail         extraction.GuardrailReport
+	entitiesFiltered  int
+	relationsFiltered int
+	junkFiltered      int
+	trace             *OperationTrace // Extract and embed spans, nil unless tracing
+}
+
+// extractChunk runs the LLM and embedding calls for one chunk. It only reads shared
+// state, so chunks can be extracted concurrently.
+func (g *Gognee) extractChunk(ctx context.Context, chunk chunker.Chunk, traceEnabled bool) *chunkExtraction {
+	ex := &chunkExtraction{}
+	if traceEnabled {
+		ex.trace = newTrace()
+	}
+
+	// Extract entities
+	extractTimer := newSpanTimer("extract", ex.trace, traceEnabled)
+	entities, err := g.entityExtractor.Extract(ctx, chunk.Text)
+	if err == nil {
+		// Catch sensitive text the LLM reproduced despite the document redaction
+		entities, err = g.redactEntities(ctx, entities)
+	}
+	if err != nil {
+		extractTimer.finish(false, err, nil)
+		ex.fail(fmt.Errorf("entity extraction failed for chunk %s: %w", chunk.ID, err))
+		ex.entitiesFailed = true
+		return ex
+	}
+
+	// Reject or sanitize entities carrying injected content
+	entities, report := g.config.Guardrail.CheckEntities(entities)
+	ex.guardrail.Merge(report)
+
+	// Drop low-confidence entities before they are linked or stored
+	entities, ex.entitiesFiltered = extraction.FilterEntitiesByConfidence(entities, g.config.MinExtractionConfidence)
+
+	// Drop junk entities (stoplist, validators, optional LLM check)
+	entities, ex.junkFiltered, err = g.config.EntityFilter.Filter(ctx, entities)
+	if err != nil {
+		ex.errors = append(ex.errors, fmt.Errorf("entity filter failed for chunk %s: %w", chunk.ID, err))
+	}
+
+	// Map known aliases to their canonical entities (see ResolveEntities). Relations are
+	// extracted against the names as written and mapped the same way.
+	extracted := entities
+	ex.entities, ex.renames = g.canonicalEntities(entities)
+
+	// Extract relations
+	triplets, err := g.relationExtractor.Extract(ctx, chunk.Text, extracted)
+	if err == nil {
+		triplets, err = g.redactTriplets(ctx, triplets)
+	}
+	if err != nil {
+		extractTimer.finish(false, err, nil)
+		// Continue with entities only if relations fail
+		ex.fail(fmt.Errorf("relation extraction failed for chunk %s: %w", chunk.ID, err))
+	} else {
+		triplets, report = g.config.Guardrail.CheckTriplets(triplets)
+		ex.guardrail.Merge(report)
+		ex.triplets, ex.relationsFiltered = extraction.FilterTripletsByConfidence(triplets, g.config.MinExtractionConfidence)
+		extractTimer.finish(true, nil, map[string]int64{
+			"entityCount":   int64(len(ex.entities)),
+			"relationCount": int64(len(ex.triplets)),
+		})
+	}
+
+	// Collect all entity texts for batch embedding (Plan 019: M1)
+	var textsToEmbed []string
+	for i, entity := range ex.entities {
+		text := strings.TrimSpace(entity.Name + " " + entity.Description)
+		if text != "" {
+			textsToEmbed = append(textsToEmbed, text)
+			ex.entityIndices = append(ex.entityIndices, i)
+		}
+	}
+
+	// Batch embed all entities in single API call (Plan 019: M2)
+	if len(textsToEmbed) > 0 {
+		embedTimer := newSpanTimer("embed", ex.trace, traceEnabled)
+		embeddings, err := g.embeddings.Embed(ctx, textsToEmbed)
+		if err != nil {
+			embedTimer.finish(false, err, nil)
+			// Continue without embeddings - nodes will be created but not indexed
+			ex.fail(fmt.Errorf("batch embedding failed for chunk %s: %w", chunk.ID, err))
+		} else {
+			embedTimer.finish(true, nil, map[string]int64{"embeddingCount": int64(len(embeddings))})
+			ex.embeddings = embeddings
+		}
+	}
+	return ex
+}
+
+// fail records a failed stage, noting whether a provider's circuit was open
+func (ex *chunkExtraction) fail(err error) {
+	ex.failures++
+	ex.errors = append(ex.errors, err)
+	ex.deferred = ex.deferred || errors.Is(err, breaker.ErrOpen)
+}
+
+// chunkExtractor hands out the extractions of a document's chunks in order
+type chunkExtractor struct {
+	get  func(i int) *chunkExtraction
+	stop func() // Cancels outstanding extractions and waits for them
+}
+
+// extractChunks extracts chunks with up to concurrency calls in flight. With a
+// concurrency of 1 or less each chunk is extracted when requested, keeping Cognify
+// strictly sequential.
+func (g *Gognee) extractChunks(ctx context.Context, chunks []chunker.Chunk, concurrency int, traceEnabled bool) chunkExtractor {
+	if concurrency <= 1 {
+		return chunkExtractor{
+			get:  func(i int) *chunkExtraction { return g.extractChunk(ctx, chunks[i], traceEnabled) },
+			stop: func() {},
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	results := make([]chan *chunkExtraction, len(chunks))
+	for i := range results {
+		results[i] = make(chan *chunkExtraction, 1)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		slots := make(chan struct{}, concurrency)
+		for i, chunk := range chunks {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] <- g.extractChunk(ctx, chunk, traceEnabled)
+				<-slots
+			}()
+		}
+	}()
+
+	return chunkExtractor{
+		get: func(i int) *chunkExtraction {
+			select {
+			case ex := <-results[i]:
+				return ex
+			case <-ctx.Done():
+				// Canceled by the caller before the chunk was extracted
+				ex := &chunkExtraction{entitiesFailed: true}
+				ex.fail(fmt.Errorf("entity extraction failed for chunk %s: %w", chunks[i].ID, ctx.Err()))
+				return ex
+			}
+		},
+		stop: func() {
+			cancel()
+			wg.Wait()
+		},
+	}
+}
@@ -0,0 +1,116 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+)
+
+// topicLLMClient extracts one entity per "TopicN" in the prompt, failing for Topic3.
+// It is safe for concurrent use and records the peak number of calls in flight.
+type topicLLMClient struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+var topicPattern = regexp.MustCompile(`Topic\d+`)
+
+func (c *topicLLMClient) Complete(ctx context.Context, prompt string) (string, error) {
+	return "[]", nil
+}
+
+func (c *topicLLMClient) CompleteWithSchema(ctx context.Context, prompt string, schema any) error {
+	c.mu.Lock()
+	c.inFlight++
+	c.peak = max(c.peak, c.inFlight)
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.inFlight--
+		c.mu.Unlock()
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	switch s := schema.(type) {
+	case *[]extraction.Entity:
+		topic := topicPattern.FindString(prompt)
+		if topic == "Topic3" {
+			return fmt.Errorf("provider rejected %s", topic)
+		}
+		*s = []extraction.Entity{{Name: topic, Type: "Concept", Description: "about " + topic}}
+	case *[]extraction.Triplet:
+		*s = nil
+	}
+	return nil
+}
+
+// lengthEmbeddingClient derives embeddings from text length; it is stateless and safe
+// for concurrent use, unlike MockEmbeddingClient
+type lengthEmbeddingClient struct{}
+
+func (lengthEmbeddingClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		embeddings[i] = []float32{float32(len(text)), 1, 0, 0}
+	}
+	return embeddings, nil
+}
+
+func (c lengthEmbeddingClient) EmbedOne(ctx context.Context, text string) ([]float32, error) {
+	embeddings, _ := c.Embed(ctx, []string{text})
+	return embeddings[0], nil
+}
+
+func TestCognify_ConcurrencyDeterministic(t *testing.T) {
+	ctx := context.Background()
+	var paragraphs []string
+	for i := 1; i <= 8; i++ {
+		paragraphs = append(paragraphs, fmt.Sprintf("This paragraph is about Topic%d.", i))
+	}
+	text := strings.Join(paragraphs, "\n\n")
+
+	run := func(concurrency int) (*CognifyResult, *topicLLMClient) {
+		llmClient := &topicLLMClient{}
+		g, err := NewWithClients(Config{DBPath: ":memory:", ChunkStrategy: "semantic", ChunkSize: 6}, lengthEmbeddingClient{}, llmClient)
+		if err != nil {
+			t.Fatalf("NewWithClients failed: %v", err)
+		}
+		defer g.Close()
+		_ = g.Add(ctx, text, AddOptions{})
+		result, err := g.Cognify(ctx, CognifyOptions{Concurrency: concurrency, TraceEnabled: true})
+		if err != nil {
+			t.Fatalf("Cognify failed: %v", err)
+		}
+		return result, llmClient
+	}
+
+	sequential, seqClient := run(0)
+	parallel, parClient := run(4)
+
+	if sequential.ChunksProcessed != 8 || sequential.ChunksFailed != 1 || sequential.NodesCreated != 7 {
+		t.Fatalf("expected 8 chunks with one failure, got %+v", sequential)
+	}
+	if seqClient.peak != 1 {
+		t.Errorf("expected sequential extraction by default, got %d calls in flight", seqClient.peak)
+	}
+	if parClient.peak < 2 || parClient.peak > 4 {
+		t.Errorf("expected 2-4 calls in flight, got %d", parClient.peak)
+	}
+
+	if parallel.NodesCreated != sequential.NodesCreated || parallel.ChunksFailed != sequential.ChunksFailed {
+		t.Errorf("expected identical results, got %+v and %+v", sequential, parallel)
+	}
+	if fmt.Sprint(parallel.Errors) != fmt.Sprint(sequential.Errors) {
+		t.Errorf("expected errors in chunk order, got %v and %v", sequential.Errors, parallel.Errors)
+	}
+	if len(parallel.Trace.Spans) != len(sequential.Trace.Spans) {
+		t.Errorf("expected the same spans, got %d and %d", len(sequential.Trace.Spans), len(parallel.Trace.Spans))
+	}
+}
@@ -0,0 +1,184 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// CommunityOptions configures DetectCommunities.
+type CommunityOptions struct {
+	// MaxIterations bounds the label propagation passes (default: 20). Detection usually
+	// converges in a handful.
+	MaxIterations int
+	// MinSize is the smallest community kept (default: 2). Members of smaller clusters,
+	// such as isolated nodes, get no community.
+	MinSize int
+}
+
+// CommunityResult reports what DetectCommunities found.
+type CommunityResult struct {
+	Communities   int  // Communities of at least MinSize nodes
+	NodesAssigned int  // Nodes given a community
+	Iterations    int  // Label propagation passes run
+	Converged     bool // Whether labels stopped changing before MaxIterations
+}
+
+// DetectCommunities clusters the graph into topics with weighted label propagation and
+// stores each node's community, replacing earlier results. Every node starts in its own
+// community and repeatedly joins the one with the most edge weight among its neighbours.
+// Nodes are visited in a seeded random order, so results are reproducible. Requires a
+// graph store implementing store.BulkReader and store.CommunityStore.
+func (g *Gognee) DetectCommunities(ctx context.Context, opts CommunityOptions) (*CommunityResult, error) {
+	reader, canRead := g.graphStore.(store.BulkReader)
+	communities, canStore := g.graphStore.(store.CommunityStore)
+	if !canRead || !canStore {
+		return nil, fmt.Errorf("community detection requires a graph store implementing store.BulkReader and store.CommunityStore")
+	}
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = 20
+	}
+	if opts.MinSize <= 0 {
+		opts.MinSize = 2
+	}
+
+	var nodeIDs []string
+	if err := reader.IterateNodes(ctx, func(node *store.Node) error {
+		nodeIDs = append(nodeIDs, node.ID)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read nodes: %w", err)
+	}
+	weights := make(map[string]map[string]float64, len(nodeIDs))
+	if err := reader.IterateEdges(ctx, func(edge *store.Edge) error {
+		if edge.SourceID == edge.TargetID {
+			return nil
+		}
+		addNeighborWeight(weights, edge.SourceID, edge.TargetID, edge.Weight)
+		addNeighborWeight(weights, edge.TargetID, edge.SourceID, edge.Weight)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read edges: %w", err)
+	}
+
+	labels, iterations, converged := propagateLabels(nodeIDs, weights, opts.MaxIterations)
+	assignments := communityAssignments(labels, opts.MinSize)
+	if err := communities.SetCommunities(ctx, assignments); err != nil {
+		return nil, fmt.Errorf("failed to store communities: %w", err)
+	}
+
+	distinct := make(map[string]bool)
+	for _, communityID := range assignments {
+		distinct[communityID] = true
+	}
+	return &CommunityResult{
+		Communities:   len(distinct),
+		NodesAssigned: len(assignments),
+		Iterations:    iterations,
+		Converged:     converged,
+	}, nil
+}
+
+// ListCommunities returns the communities stored by DetectCommunities, largest first,
+// each with up to representatives of its best-connected nodes (default: 3).
+func (g *Gognee) ListCommunities(ctx context.Context, representatives int) ([]store.Community, error) {
+	communities, ok := g.graphStore.(store.CommunityStore)
+	if !ok {
+		return nil, fmt.Errorf("communities require a graph store implementing store.CommunityStore")
+	}
+	return communities.ListCommunities(ctx, representatives)
+}
+
+// addNeighborWeight adds the weight of an edge from one node to another
+func addNeighborWeight(weights map[string]map[string]float64, from, to string, weight float64) {
+	if weight <= 0 {
+		weight = 1
+	}
+	if weights[from] == nil {
+		weights[from] = make(map[string]float64)
+	}
+	weights[from][to] += weight
+}
+
+// propagateLabels runs asynchronous label propagation and returns each node's label,
+// the passes run and whether the labels converged
+func propagateLabels(nodeIDs []string, weights map[string]map[string]float64, maxIterations int) (map[string]string, int, bool) {
+	sort.Strings(nodeIDs)
+	labels := make(map[string]string, len(nodeIDs))
+	for _, id := range nodeIDs {
+		labels[id] = id
+	}
+
+	order := append([]string(nil), nodeIDs...)
+	rng := rand.New(rand.NewPCG(1, 2))
+	for iteration := 1; iteration <= maxIterations; iteration++ {
+		rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+
+		changed := false
+		for _, id := range order {
+			if label := dominantLabel(id, labels, weights[id]); label != labels[id] {
+				labels[id] = label
+				changed = true
+			}
+		}
+		if !changed {
+			return labels, iteration, true
+		}
+	}
+	return labels, maxIterations, false
+}
+
+// dominantLabel returns the label with the most edge weight among a node's neighbours.
+// Ties keep the node's current label if it is among them, else the smallest label.
+func dominantLabel(id string, labels map[string]string, neighbors map[string]float64) string {
+	if len(neighbors) == 0 {
+		return labels[id]
+	}
+	// Sum in a fixed order so float ties are reproducible
+	ids := make([]string, 0, len(neighbors))
+	for neighbor := range neighbors {
+		ids = append(ids, neighbor)
+	}
+	sort.Strings(ids)
+	scores := make(map[string]float64)
+	for _, neighbor := range ids {
+		if label, ok := labels[neighbor]; ok {
+			scores[label] += neighbors[neighbor]
+		}
+	}
+
+	best, bestScore := "", -1.0
+	for label, score := range scores {
+		if score > bestScore || (score == bestScore && label < best) {
+			best, bestScore = label, score
+		}
+	}
+	if current := labels[id]; scores[current] == bestScore {
+		return current
+	}
+	return best
+}
+
+// communityAssignments maps each node of a community of at least minSize nodes to its
+// community ID, the smallest member ID, which stays stable while membership does
+func communityAssignments(labels map[string]string, minSize int) map[string]string {
+	members := make(map[string][]string)
+	for id, label := range labels {
+		members[label] = append(members[label], id)
+	}
+
+	assignments := make(map[string]string)
+	for _, ids := range members {
+		if len(ids) < minSize {
+			continue
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			assignments[id] = ids[0]
+		}
+	}
+	return assignments
+}
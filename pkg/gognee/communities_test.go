@@ -0,0 +1,80 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestDetectCommunities_TwoClusters(t *testing.T) {
+	ctx := context.Background()
+
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	// Two 4-cliques joined by one weak bridge, plus an isolated node
+	clusters := [][]string{{"db1", "db2", "db3", "db4"}, {"ui1", "ui2", "ui3", "ui4"}}
+	for _, cluster := range clusters {
+		for _, id := range cluster {
+			if err := g.graphStore.AddNode(ctx, &store.Node{ID: id, Name: id, Type: "Concept"}); err != nil {
+				t.Fatalf("AddNode failed: %v", err)
+			}
+		}
+		for i := range cluster {
+			for j := i + 1; j < len(cluster); j++ {
+				edge := &store.Edge{ID: fmt.Sprintf("%s-%s", cluster[i], cluster[j]), SourceID: cluster[i], Relation: "RELATES_TO", TargetID: cluster[j]}
+				if err := g.graphStore.AddEdge(ctx, edge); err != nil {
+					t.Fatalf("AddEdge failed: %v", err)
+				}
+			}
+		}
+	}
+	if err := g.graphStore.AddNode(ctx, &store.Node{ID: "alone", Name: "alone", Type: "Concept"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	bridge := &store.Edge{ID: "bridge", SourceID: "db1", Relation: "FEEDS", TargetID: "ui1", Weight: 0.1}
+	if err := g.graphStore.AddEdge(ctx, bridge); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+
+	result, err := g.DetectCommunities(ctx, CommunityOptions{})
+	if err != nil {
+		t.Fatalf("DetectCommunities failed: %v", err)
+	}
+	if result.Communities != 2 || result.NodesAssigned != 8 || !result.Converged {
+		t.Errorf("Expected 2 converged communities over 8 nodes, got %+v", result)
+	}
+
+	communities, err := g.ListCommunities(ctx, 2)
+	if err != nil {
+		t.Fatalf("ListCommunities failed: %v", err)
+	}
+	if len(communities) != 2 {
+		t.Fatalf("Expected 2 communities, got %d", len(communities))
+	}
+	for i, community := range communities {
+		want := clusters[i][0] // ID is the smallest member
+		if community.ID != want || community.Size != 4 || len(community.Representatives) != 2 {
+			t.Errorf("community %d: expected %s with 4 members and 2 representatives, got %s with %d and %d",
+				i, want, community.ID, community.Size, len(community.Representatives))
+		}
+		// The bridge endpoint has the most edges
+		if community.Representatives[0].ID != clusters[i][0] {
+			t.Errorf("community %d: expected %s as first representative, got %s", i, clusters[i][0], community.Representatives[0].ID)
+		}
+	}
+
+	// Re-running gives the same result
+	again, err := g.DetectCommunities(ctx, CommunityOptions{})
+	if err != nil {
+		t.Fatalf("DetectCommunities failed: %v", err)
+	}
+	if *again != *result {
+		t.Errorf("Expected reproducible result %+v, got %+v", result, again)
+	}
+}
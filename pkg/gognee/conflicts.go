@@ -0,0 +1,121 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// Conflict detection modes for Config.ConflictDetection
+const (
+	// ConflictDetectionReport returns conflicting memories in MemoryResult.ConflictCandidates
+	ConflictDetectionReport = "report"
+	// ConflictDetectionSupersede also marks them as superseded by the new memory
+	ConflictDetectionSupersede = "supersede"
+)
+
+const (
+	// defaultConflictThreshold is the topic similarity above which two memories conflict
+	defaultConflictThreshold = 0.85
+
+	// maxConflictCandidates bounds the related memories whose topics are compared
+	maxConflictCandidates = 10
+)
+
+// validateConflictDetection checks a Config.ConflictDetection mode
+func validateConflictDetection(mode string) error {
+	switch mode {
+	case ConflictDetectionReport, ConflictDetectionSupersede:
+		return nil
+	default:
+		return store.Invalidf("invalid ConflictDetection %q: must be %q or %q", mode, ConflictDetectionReport, ConflictDetectionSupersede)
+	}
+}
+
+// findConflictingMemories returns the current memories that likely cover the same topic as
+// memoryID. Candidates are its related memories (see RelatedMemories) that are still current
+// and whose topic embedding is at least Config.ConflictThreshold similar to topic.
+// Memories in exclude (already superseded explicitly) are skipped.
+func (g *Gognee) findConflictingMemories(ctx context.Context, memoryID, topic string, exclude []string) ([]store.MemorySummary, error) {
+	related, err := g.RelatedMemories(ctx, memoryID, maxConflictCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	skip := make(map[string]bool, len(exclude))
+	for _, id := range exclude {
+		skip[id] = true
+	}
+
+	var candidates []*store.MemoryRecord
+	for _, r := range related {
+		if skip[r.MemoryID] {
+			continue
+		}
+		memory, err := g.memoryStore.GetMemory(ctx, r.MemoryID)
+		if err != nil {
+			continue // Deleted concurrently
+		}
+		// Pinned, archived and in-flight memories are never conflict candidates
+		if memory.Status != "complete" && memory.Status != "Active" {
+			continue
+		}
+		candidates = append(candidates, memory)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	texts := make([]string, 0, len(candidates)+1)
+	texts = append(texts, topic)
+	for _, memory := range candidates {
+		texts = append(texts, memory.Topic)
+	}
+	vectors, err := g.embeddings.Embed(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed memory topics: %w", err)
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("failed to embed memory topics: got %d embeddings for %d topics", len(vectors), len(texts))
+	}
+
+	threshold := g.config.ConflictThreshold
+	if threshold == 0 {
+		threshold = defaultConflictThreshold
+	}
+
+	var conflicts []store.MemorySummary
+	for i, memory := range candidates {
+		if store.CosineSimilarity(vectors[0], vectors[i+1]) >= threshold {
+			conflicts = append(conflicts, memory.Summary())
+		}
+	}
+	return conflicts, nil
+}
+
+// supersedeMemory records that memoryID supersedes supersededID and marks the latter Superseded.
+func (g *Gognee) supersedeMemory(ctx context.Context, memoryID, supersededID, reason string) error {
+	// Validate that superseded memory exists and is current
+	supersededMemory, err := g.memoryStore.GetMemory(ctx, supersededID)
+	if err != nil {
+		return fmt.Errorf("cannot supersede memory %s: %w", supersededID, err)
+	}
+
+	// Allow superseding current or already-Superseded memories (creates chains)
+	switch supersededMemory.Status {
+	case "Active", "complete", "Superseded":
+	default:
+		return fmt.Errorf("cannot supersede memory %s: status is '%s', must be 'Active', 'complete' or 'Superseded'", supersededID, supersededMemory.Status)
+	}
+
+	if err := g.memoryStore.RecordSupersession(ctx, memoryID, supersededID, reason); err != nil {
+		return fmt.Errorf("failed to record supersession: %w", err)
+	}
+
+	supersededStatus := "Superseded"
+	if err := g.memoryStore.UpdateMemory(ctx, supersededID, store.MemoryUpdate{Status: &supersededStatus}); err != nil {
+		return fmt.Errorf("failed to mark memory %s as superseded: %w", supersededID, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+)
+
+// newConflictTestGognee returns an instance whose memories all mention the same entity,
+// so every earlier memory is related to a new one and only the topic decides conflicts.
+func newConflictTestGognee(t *testing.T, mode string) *Gognee {
+	t.Helper()
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{
+			{Name: "SQLite", Type: "Technology", Description: "Embedded database"},
+		}},
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:", ConflictDetection: mode}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	t.Cleanup(func() { g.Close() })
+	return g
+}
+
+func TestAddMemory_ReportsConflicts(t *testing.T) {
+	ctx := context.Background()
+	g := newConflictTestGognee(t, ConflictDetectionReport)
+
+	old, err := g.AddMemory(ctx, MemoryInput{Topic: "Database choice", Context: "We store everything in SQLite."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if len(old.ConflictCandidates) != 0 {
+		t.Fatalf("Expected no conflicts for the first memory, got %+v", old.ConflictCandidates)
+	}
+	if _, err := g.AddMemory(ctx, MemoryInput{Topic: "Release cadence", Context: "SQLite upgrades ship monthly."}); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	res, err := g.AddMemory(ctx, MemoryInput{Topic: "Database choice", Context: "We moved from SQLite to Postgres."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if len(res.ConflictCandidates) != 1 || res.ConflictCandidates[0].ID != old.MemoryID {
+		t.Fatalf("Expected %s as the only conflict, got %+v", old.MemoryID, res.ConflictCandidates)
+	}
+	if res.MemoriesSuperseded != 0 {
+		t.Errorf("Report mode must not supersede, got %d", res.MemoriesSuperseded)
+	}
+
+	memory, err := g.GetMemory(ctx, old.MemoryID)
+	if err != nil {
+		t.Fatalf("GetMemory failed: %v", err)
+	}
+	if memory.Status == "Superseded" {
+		t.Error("Report mode must leave the conflicting memory current")
+	}
+}
+
+func TestAddMemory_SupersedesConflicts(t *testing.T) {
+	ctx := context.Background()
+	g := newConflictTestGognee(t, ConflictDetectionSupersede)
+
+	old, err := g.AddMemory(ctx, MemoryInput{Topic: "Database choice", Context: "We store everything in SQLite."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	res, err := g.AddMemory(ctx, MemoryInput{Topic: "Database choice", Context: "We moved from SQLite to Postgres."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if res.MemoriesSuperseded != 1 || len(res.ConflictCandidates) != 1 {
+		t.Fatalf("Expected 1 conflict superseded, got %d superseded, candidates %+v (errors: %v)",
+			res.MemoriesSuperseded, res.ConflictCandidates, res.Errors)
+	}
+
+	memory, err := g.GetMemory(ctx, old.MemoryID)
+	if err != nil {
+		t.Fatalf("GetMemory failed: %v", err)
+	}
+	if memory.Status != "Superseded" {
+		t.Errorf("Expected old memory to be Superseded, got %q", memory.Status)
+	}
+
+	// A superseded memory is no longer a conflict candidate
+	again, err := g.AddMemory(ctx, MemoryInput{Topic: "Database choice", Context: "Postgres runs on managed hosting, not SQLite."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	for _, c := range again.ConflictCandidates {
+		if c.ID == old.MemoryID {
+			t.Error("Superseded memory must not be reported as a conflict again")
+		}
+	}
+}
+
+func TestConflictDetection_InvalidConfig(t *testing.T) {
+	if _, err := NewWithClients(Config{DBPath: ":memory:", ConflictDetection: "merge"}, &MockEmbeddingClient{}, &MockLLMClient{}); err == nil {
+		t.Error("Expected error for unknown ConflictDetection mode")
+	}
+	if _, err := NewWithClients(Config{DBPath: ":memory:", ConflictThreshold: 1.5}, &MockEmbeddingClient{}, &MockLLMClient{}); err == nil {
+		t.Error("Expected error for ConflictThreshold above 1")
+	}
+}
+
+func TestGetCurrentMemory(t *testing.T) {
+	ctx := context.Background()
+	g := newConflictTestGognee(t, "")
+
+	v1, err := g.AddMemory(ctx, MemoryInput{Topic: "Database choice", Context: "We use SQLite."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	v2, err := g.AddMemory(ctx, MemoryInput{Topic: "Database choice", Context: "We use Postgres.", Supersedes: []string{v1.MemoryID}})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	v3, err := g.AddMemory(ctx, MemoryInput{Topic: "Database choice", Context: "We use CockroachDB.", Supersedes: []string{v2.MemoryID}})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	for _, id := range []string{v1.MemoryID, v2.MemoryID, v3.MemoryID} {
+		current, err := g.GetCurrentMemory(ctx, id)
+		if err != nil {
+			t.Fatalf("GetCurrentMemory failed: %v", err)
+		}
+		if current.ID != v3.MemoryID {
+			t.Errorf("Expected %s as the current memory of %s, got %s", v3.MemoryID, id, current.ID)
+		}
+	}
+	if _, err := g.GetCurrentMemory(ctx, "missing"); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected ErrMemoryNotFound, got %v", err)
+	}
+}
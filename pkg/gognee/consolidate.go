@@ -0,0 +1,224 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/store"
+	"github.com/google/uuid"
+)
+
+// defaultConsolidationSource is the source of memories created by ConsolidateMemories
+const defaultConsolidationSource = "consolidation"
+
+const consolidatePrompt = `You are merging related notes into one canonical record.
+
+Notes, oldest first (where notes disagree, the later note is current):
+%s
+Write a single record that keeps every decision still in effect and its rationale, and
+drops decisions later notes replaced. Use only the information above.
+
+Return a JSON object: {"topic": "...", "context": "...", "decisions": ["..."], "rationale": ["..."]}`
+
+// ConsolidateOptions configures ConsolidateMemories.
+type ConsolidateOptions struct {
+	// Topic overrides the topic proposed by the LLM.
+	Topic string
+	// Reason is recorded with each supersession (default: "consolidated").
+	Reason string
+	// Source of the consolidated memory (default: "consolidation").
+	Source string
+	// RetentionPolicy of the consolidated memory (default: standard).
+	RetentionPolicy string
+}
+
+// ConsolidateResult reports the memory created by ConsolidateMemories.
+type ConsolidateResult struct {
+	MemoryID      string   // The consolidated memory
+	Topic         string   // Its topic
+	SupersededIDs []string // Source memories marked Superseded by it
+	NodesLinked   int      // Nodes relinked from the sources
+	EdgesLinked   int      // Edges relinked from the sources
+}
+
+// consolidateResponse is the LLM's answer to consolidatePrompt
+type consolidateResponse struct {
+	Topic     string   `json:"topic"`
+	Context   string   `json:"context"`
+	Decisions []string `json:"decisions"`
+	Rationale []string `json:"rationale"`
+}
+
+// ConsolidateMemories asks the LLM to merge related memories into one canonical memory,
+// links it to the nodes and edges derived from the sources and records that it
+// supersedes each of them. The graph is not re-extracted: the consolidated memory
+// takes over the provenance of its sources, so their nodes survive garbage collection.
+// The sources must be current (not yet superseded) and share one ACL, which the
+// consolidated memory inherits. The memory, its provenance and the supersessions are
+// written in one transaction: either every source is superseded or nothing changes.
+func (g *Gognee) ConsolidateMemories(ctx context.Context, ids []string, opts ConsolidateOptions) (*ConsolidateResult, error) {
+	startTime := time.Now()
+	if opts.Reason == "" {
+		opts.Reason = "consolidated"
+	}
+	if opts.Source == "" {
+		opts.Source = defaultConsolidationSource
+	}
+	if opts.RetentionPolicy == "" {
+		opts.RetentionPolicy = "standard"
+	}
+	if _, valid := RetentionPolicies[opts.RetentionPolicy]; !valid {
+		return nil, store.Invalidf("invalid retention_policy '%s': must be one of: permanent, decision, standard, ephemeral, session", opts.RetentionPolicy)
+	}
+
+	sources, err := g.consolidationSources(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var response consolidateResponse
+	if err := g.stageLLM(StageSummarization).CompleteWithSchema(ctx, consolidatePromptFor(sources), &response); err != nil {
+		return nil, fmt.Errorf("failed to consolidate memories: %w", err)
+	}
+	response.Context = strings.TrimSpace(response.Context)
+	if response.Context == "" {
+		return nil, fmt.Errorf("failed to consolidate memories: LLM returned an empty context")
+	}
+	topic := strings.TrimSpace(opts.Topic)
+	if topic == "" {
+		topic = strings.TrimSpace(response.Topic)
+	}
+	if topic == "" {
+		topic = sources[len(sources)-1].Topic
+	}
+
+	var nodeIDs, edgeIDs []string
+	seenNodes, seenEdges := make(map[string]bool), make(map[string]bool)
+	var tags, sourceIDs []string
+	seenTags := make(map[string]bool)
+	for _, source := range sources {
+		nodes, edges, err := g.memoryStore.GetProvenanceByMemory(ctx, source.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get provenance of memory %s: %w", source.ID, err)
+		}
+		for _, id := range nodes {
+			if !seenNodes[id] {
+				seenNodes[id] = true
+				nodeIDs = append(nodeIDs, id)
+			}
+		}
+		for _, id := range edges {
+			if !seenEdges[id] {
+				seenEdges[id] = true
+				edgeIDs = append(edgeIDs, id)
+			}
+		}
+		for _, tag := range source.Tags {
+			if !seenTags[tag] {
+				seenTags[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+		sourceIDs = append(sourceIDs, source.ID)
+	}
+
+	memory := &store.MemoryRecord{
+		ID:              uuid.New().String(),
+		Topic:           topic,
+		Context:         response.Context,
+		Decisions:       response.Decisions,
+		Rationale:       response.Rationale,
+		Metadata:        map[string]interface{}{"consolidated_from": sourceIDs},
+		DocHash:         store.ComputeDocHash(topic, response.Context, response.Decisions, response.Rationale),
+		Source:          opts.Source,
+		Status:          "complete",
+		RetentionPolicy: opts.RetentionPolicy,
+		Tags:            tags,
+		ACL:             sources[0].ACL,
+	}
+	// The LLM may reproduce sensitive text from its sources
+	if err := g.redactMemoryRecord(ctx, memory); err != nil {
+		return nil, err
+	}
+	if err := g.memoryStore.ConsolidateMemory(ctx, memory, nodeIDs, edgeIDs, sourceIDs, opts.Reason); err != nil {
+		if g.metricsCollector != nil {
+			g.metricsCollector.RecordOperation(ctx, "consolidate_memories", "error", time.Since(startTime).Milliseconds())
+		}
+		return nil, fmt.Errorf("failed to store consolidated memory: %w", err)
+	}
+
+	if g.metricsCollector != nil {
+		g.metricsCollector.RecordOperation(ctx, "consolidate_memories", "success", time.Since(startTime).Milliseconds())
+	}
+	return &ConsolidateResult{
+		MemoryID:      memory.ID,
+		Topic:         memory.Topic,
+		SupersededIDs: sourceIDs,
+		NodesLinked:   len(nodeIDs),
+		EdgesLinked:   len(edgeIDs),
+	}, nil
+}
+
+// consolidationSources loads the distinct memories to consolidate, oldest first
+func (g *Gognee) consolidationSources(ctx context.Context, ids []string) ([]*store.MemoryRecord, error) {
+	var sources []*store.MemoryRecord
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		memory, err := g.memoryStore.GetMemory(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("cannot consolidate memory %s: %w", id, err)
+		}
+		// A superseded source already has a successor; consolidating it would fork its chain
+		switch memory.Status {
+		case "Active", "complete":
+		default:
+			return nil, store.Invalidf("cannot consolidate memory %s: status is '%s', must be 'Active' or 'complete'", id, memory.Status)
+		}
+		sources = append(sources, memory)
+	}
+	if len(sources) < 2 {
+		return nil, store.Invalidf("consolidation requires at least 2 distinct memories, got %d", len(sources))
+	}
+	// A merged memory readable by a wider audience than a source would leak it
+	for _, source := range sources[1:] {
+		if !sameACL(source.ACL, sources[0].ACL) {
+			return nil, store.Invalidf("cannot consolidate memories %s and %s: their ACLs differ", sources[0].ID, source.ID)
+		}
+	}
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sources[i].CreatedAt.Before(sources[j].CreatedAt)
+	})
+	return sources, nil
+}
+
+// sameACL reports whether two memory ACLs grant the same access
+func sameACL(a, b *store.MemoryACL) bool {
+	if a.IsEmpty() || b.IsEmpty() {
+		return a.IsEmpty() && b.IsEmpty()
+	}
+	return a.Owner == b.Owner && a.Team == b.Team && slices.Equal(a.Labels, b.Labels)
+}
+
+// consolidatePromptFor renders the consolidate prompt for memories, oldest first
+func consolidatePromptFor(memories []*store.MemoryRecord) string {
+	var notes strings.Builder
+	for i, memory := range memories {
+		fmt.Fprintf(&notes, "%d. [%s] %s\n%s\n", i+1, memory.CreatedAt.Format("2006-01-02"), memory.Topic, memory.Context)
+		for _, decision := range memory.Decisions {
+			fmt.Fprintf(&notes, "   Decision: %s\n", decision)
+		}
+		for _, rationale := range memory.Rationale {
+			fmt.Fprintf(&notes, "   Rationale: %s\n", rationale)
+		}
+		notes.WriteString("\n")
+	}
+	return fmt.Sprintf(consolidatePrompt, notes.String())
+}
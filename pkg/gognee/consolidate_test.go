@@ -0,0 +1,138 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// consolidateLLM answers consolidate prompts and defers extraction to MockLLMClient
+type consolidateLLM struct {
+	MockLLMClient
+	prompts []string
+}
+
+func (m *consolidateLLM) CompleteWithSchema(ctx context.Context, prompt string, schema interface{}) error {
+	if s, ok := schema.(*consolidateResponse); ok {
+		m.prompts = append(m.prompts, prompt)
+		*s = consolidateResponse{
+			Topic:     "Database choice",
+			Context:   "We use Postgres for all services.",
+			Decisions: []string{"Use Postgres"},
+			Rationale: []string{"Needs concurrent writers"},
+		}
+		return nil
+	}
+	return m.MockLLMClient.CompleteWithSchema(ctx, prompt, schema)
+}
+
+func TestConsolidateMemories(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &consolidateLLM{MockLLMClient: MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{{Name: "SQLite", Type: "Technology", Description: "Embedded database"}},
+			{{Name: "Postgres", Type: "Technology", Description: "Relational database"}},
+		},
+	}}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	first, err := g.AddMemory(ctx, MemoryInput{Topic: "Database", Context: "We start with SQLite.", Tags: []string{"db"}})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	second, err := g.AddMemory(ctx, MemoryInput{Topic: "Database", Context: "We move to Postgres.", Tags: []string{"infra"}})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	result, err := g.ConsolidateMemories(ctx, []string{second.MemoryID, first.MemoryID}, ConsolidateOptions{Reason: "compaction"})
+	if err != nil {
+		t.Fatalf("ConsolidateMemories failed: %v", err)
+	}
+	if len(result.SupersededIDs) != 2 {
+		t.Fatalf("Expected both sources superseded, got %+v", result)
+	}
+	if len(llmClient.prompts) != 1 || strings.Index(llmClient.prompts[0], "SQLite") > strings.Index(llmClient.prompts[0], "Postgres") {
+		t.Errorf("Expected the sources in the prompt oldest first, got %q", llmClient.prompts)
+	}
+
+	memory, err := g.GetMemory(ctx, result.MemoryID)
+	if err != nil {
+		t.Fatalf("GetMemory failed: %v", err)
+	}
+	if memory.Topic != "Database choice" || memory.Status != "complete" || memory.Source != defaultConsolidationSource {
+		t.Errorf("Unexpected consolidated memory: %+v", memory)
+	}
+	if len(memory.Tags) != 2 {
+		t.Errorf("Expected the tags of both sources, got %v", memory.Tags)
+	}
+
+	nodeIDs, _, err := g.memoryStore.GetProvenanceByMemory(ctx, result.MemoryID)
+	if err != nil {
+		t.Fatalf("GetProvenanceByMemory failed: %v", err)
+	}
+	if len(nodeIDs) != 2 || result.NodesLinked != 2 {
+		t.Errorf("Expected the nodes of both sources relinked, got %v", nodeIDs)
+	}
+
+	for _, id := range []string{first.MemoryID, second.MemoryID} {
+		current, err := g.GetCurrentMemory(ctx, id)
+		if err != nil {
+			t.Fatalf("GetCurrentMemory failed: %v", err)
+		}
+		if current.ID != result.MemoryID {
+			t.Errorf("Expected %s to be superseded by the consolidated memory, got %s", id, current.ID)
+		}
+	}
+
+	// Deleting a source keeps the nodes now linked to the consolidated memory
+	if err := g.DeleteMemory(ctx, first.MemoryID); err != nil {
+		t.Fatalf("DeleteMemory failed: %v", err)
+	}
+	if node, err := g.graphStore.GetNode(ctx, nodeIDs[0]); err != nil || node == nil {
+		t.Errorf("Expected node %s kept, got %v (%v)", nodeIDs[0], node, err)
+	}
+}
+
+func TestConsolidateMemories_Validation(t *testing.T) {
+	ctx := context.Background()
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &consolidateLLM{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	added, err := g.AddMemory(ctx, MemoryInput{Topic: "Database", Context: "We use SQLite."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	if _, err := g.ConsolidateMemories(ctx, []string{added.MemoryID, added.MemoryID}, ConsolidateOptions{}); !errors.Is(err, store.ErrValidation) {
+		t.Errorf("Expected a validation error for a single distinct memory, got %v", err)
+	}
+	if _, err := g.ConsolidateMemories(ctx, []string{added.MemoryID, "missing"}, ConsolidateOptions{}); err == nil {
+		t.Error("Expected an error for a missing memory")
+	}
+	if _, err := g.ConsolidateMemories(ctx, []string{added.MemoryID, "other"}, ConsolidateOptions{RetentionPolicy: "forever"}); !errors.Is(err, store.ErrValidation) {
+		t.Errorf("Expected a validation error for an unknown retention policy, got %v", err)
+	}
+
+	older, err := g.AddMemory(ctx, MemoryInput{Topic: "Database", Context: "We used MySQL."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if err := g.memoryStore.RecordSupersession(ctx, added.MemoryID, older.MemoryID, "replaced"); err != nil {
+		t.Fatalf("RecordSupersession failed: %v", err)
+	}
+	if _, err := g.ConsolidateMemories(ctx, []string{added.MemoryID, older.MemoryID}, ConsolidateOptions{}); !errors.Is(err, store.ErrValidation) {
+		t.Errorf("Expected a validation error for a superseded source, got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package gognee
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// DemoSource is the memory source of everything created by SeedDemo.
+const DemoSource = "gognee-demo"
+
+//go:embed demo/corpus.json
+var demoCorpusJSON []byte
+
+// demoMemory is one memory of the demo corpus with its pre-extracted subgraph
+type demoMemory struct {
+	Topic     string               `json:"topic"`
+	Context   string               `json:"context"`
+	Decisions []string             `json:"decisions"`
+	Rationale []string             `json:"rationale"`
+	Tags      []string             `json:"tags"`
+	Entities  []extraction.Entity  `json:"entities"`
+	Relations []extraction.Triplet `json:"relations"`
+}
+
+// SeedResult reports what SeedDemo created.
+type SeedResult struct {
+	MemoriesCreated int
+	MemoriesSkipped int // Already present from an earlier SeedDemo
+	NodesCreated    int
+	EdgesCreated    int
+}
+
+// SeedDemo loads a small built-in corpus (the history of Unix, C, Go, Linux, Git and
+// SQLite) as memories with their entities and relations, so the Search, Prune and
+// memory APIs can be tried on a populated graph. The corpus is pre-extracted: no LLM
+// calls are made, but entities are embedded with the configured embedding client.
+// Memories carry Source DemoSource and are skipped if already present, so SeedDemo can
+// be called repeatedly.
+func (g *Gognee) SeedDemo(ctx context.Context) (*SeedResult, error) {
+	var corpus struct {
+		Memories []demoMemory `json:"memories"`
+	}
+	if err := json.Unmarshal(demoCorpusJSON, &corpus); err != nil {
+		return nil, fmt.Errorf("failed to parse demo corpus: %w", err)
+	}
+
+	result := &SeedResult{}
+	seenNodes := make(map[string]bool)
+	for _, demo := range corpus.Memories {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		created, err := g.seedDemoMemory(ctx, demo, seenNodes, result)
+		if err != nil {
+			return result, fmt.Errorf("failed to seed demo memory %q: %w", demo.Topic, err)
+		}
+		if created {
+			result.MemoriesCreated++
+		} else {
+			result.MemoriesSkipped++
+		}
+	}
+	return result, nil
+}
+
+// seedDemoMemory stores one demo memory and its subgraph. It returns false if the
+// memory already exists.
+func (g *Gognee) seedDemoMemory(ctx context.Context, demo demoMemory, seenNodes map[string]bool, result *SeedResult) (bool, error) {
+	docHash := store.ComputeDocHash(demo.Topic, demo.Context, demo.Decisions, demo.Rationale)
+	var existingID string
+	err := g.memoryStore.DB().QueryRowContext(ctx,
+		`SELECT id FROM memories WHERE doc_hash = ? AND namespace = ? AND status != ? LIMIT 1`,
+		docHash, g.config.Namespace, store.StatusDeleted).Scan(&existingID)
+	if err == nil {
+		return false, nil
+	}
+	if err != sql.ErrNoRows {
+		return false, fmt.Errorf("failed to check for duplicate memory: %w", err)
+	}
+
+	memory := &store.MemoryRecord{
+		Topic:     demo.Topic,
+		Context:   demo.Context,
+		Decisions: demo.Decisions,
+		Rationale: demo.Rationale,
+		DocHash:   docHash,
+		Source:    DemoSource,
+		Status:    "complete",
+		Tags:      demo.Tags,
+	}
+	if err := g.memoryStore.AddMemory(ctx, memory); err != nil {
+		return false, fmt.Errorf("failed to add memory record: %w", err)
+	}
+
+	entityTexts := make([]string, len(demo.Entities))
+	for i, entity := range demo.Entities {
+		entityTexts[i] = entity.Name + " " + entity.Description
+	}
+	embeddings, err := g.embeddings.Embed(ctx, entityTexts)
+	if err != nil {
+		return false, fmt.Errorf("batch embed failed: %w", err)
+	}
+
+	nodeIDs := make([]string, 0, len(demo.Entities))
+	for i, entity := range demo.Entities {
+		nodeID := g.nodeID(entity.Name, entity.Type)
+		node := &store.Node{
+			ID:          nodeID,
+			Name:        entity.Name,
+			Type:        entity.Type,
+			Description: entity.Description,
+			CreatedAt:   time.Now(),
+			Metadata:    g.entityMetadata(nodeID),
+			Embedding:   embeddings[i],
+		}
+		if err := g.graphStore.AddNode(ctx, node); err != nil {
+			return false, fmt.Errorf("failed to add node: %w", err)
+		}
+		if err := g.vectorStore.Add(ctx, nodeID, embeddings[i]); err != nil {
+			return false, fmt.Errorf("failed to index node in vector store: %w", err)
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+		if !seenNodes[nodeID] {
+			seenNodes[nodeID] = true
+			result.NodesCreated++
+		}
+	}
+	g.recordMentions(ctx, demo.Entities)
+
+	entityMap, ambiguous := buildEntityTypeMap(demo.Entities)
+	edgeIDs := make([]string, 0, len(demo.Relations))
+	var newEdges edgeBatch
+	for _, triplet := range demo.Relations {
+		sourceType, sourceFound := lookupEntityType(triplet.Subject, entityMap, ambiguous)
+		targetType, targetFound := lookupEntityType(triplet.Object, entityMap, ambiguous)
+		if !sourceFound || !targetFound {
+			return false, fmt.Errorf("relation %s %s %s references an unknown entity", triplet.Subject, triplet.Relation, triplet.Object)
+		}
+
+		sourceID := g.nodeID(triplet.Subject, sourceType)
+		targetID := g.nodeID(triplet.Object, targetType)
+		edge := &store.Edge{
+			ID:        derivedEdgeID(sourceID, triplet, targetID),
+			SourceID:  sourceID,
+			Relation:  triplet.Relation,
+			TargetID:  targetID,
+			Weight:    1.0,
+			CreatedAt: time.Now(),
+			Evidence:  evidenceSnippet(demo.Context, triplet.Subject, triplet.Object),
+		}
+		edge.ValidFrom, edge.ValidTo = triplet.ValidityPeriod()
+		if err := g.graphStore.AddEdge(ctx, edge); err != nil {
+			return false, fmt.Errorf("failed to add edge: %w", err)
+		}
+		edgeIDs = append(edgeIDs, edge.ID)
+		result.EdgesCreated++
+		newEdges.add(edge, triplet.Subject, triplet.Object)
+	}
+	if err := g.indexEdges(ctx, &newEdges); err != nil {
+		return false, err
+	}
+
+	if err := g.memoryStore.LinkProvenance(ctx, memory.ID, nodeIDs, edgeIDs); err != nil {
+		return false, fmt.Errorf("failed to link provenance: %w", err)
+	}
+	return true, nil
+}
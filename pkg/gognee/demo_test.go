@@ -0,0 +1,53 @@
+package gognee
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/search"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestSeedDemo(t *testing.T) {
+	ctx := context.Background()
+
+	// No LLM responses are configured: seeding must not call the LLM
+	mockLLM := &MockLLMClient{}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	result, err := g.SeedDemo(ctx)
+	if err != nil {
+		t.Fatalf("SeedDemo failed: %v", err)
+	}
+	if result.MemoriesCreated != 6 || result.NodesCreated != 14 || result.EdgesCreated != 18 {
+		t.Errorf("Unexpected seed result: %+v", result)
+	}
+
+	if count, _ := g.CountMemories(ctx); count != 6 {
+		t.Errorf("Expected 6 memories, got %d", count)
+	}
+	tagged, err := g.ListMemories(ctx, store.ListMemoriesOptions{Tags: []string{"languages"}})
+	if err != nil || len(tagged) != 2 {
+		t.Errorf("Expected 2 memories tagged languages, got %d (err %v)", len(tagged), err)
+	}
+
+	response, err := g.Search(ctx, "Go", search.SearchOptions{Type: search.SearchTypeVector, TopK: 3})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(response.Results) == 0 || len(response.Results[0].MemoryIDs) == 0 {
+		t.Errorf("Expected search results linked to demo memories, got %+v", response.Results)
+	}
+
+	again, err := g.SeedDemo(ctx)
+	if err != nil {
+		t.Fatalf("Second SeedDemo failed: %v", err)
+	}
+	if again.MemoriesCreated != 0 || again.MemoriesSkipped != 6 {
+		t.Errorf("Expected reseeding to skip every memory, got %+v", again)
+	}
+}
@@ -0,0 +1,119 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// Description merge strategies for Config.DescriptionMerge
+const (
+	DescriptionMergeAppend  = "append"
+	DescriptionMergeLLM     = "llm"
+	DescriptionMergeReplace = "replace"
+)
+
+// mergeDescriptions combines the description of each node about to be upserted with the
+// description already known for it: the latest of pending (nodes staged but not yet
+// written) or else the stored one. A node without a description keeps the known one.
+// It returns the merges to record once the nodes are written; a failed merge falls back
+// to appending and is reported.
+func (g *Gognee) mergeDescriptions(ctx context.Context, nodes, pending []*store.Node) ([]store.DescriptionMerge, []error) {
+	history, ok := g.graphStore.(store.DescriptionHistory)
+	if !ok || g.config.DescriptionMerge == DescriptionMergeReplace || len(nodes) == 0 {
+		return nil, nil
+	}
+
+	known := make(map[string]string)
+	for _, node := range pending {
+		known[node.ID] = node.Description
+	}
+	var lookup []string
+	for _, node := range nodes {
+		if _, ok := known[node.ID]; !ok {
+			lookup = append(lookup, node.ID)
+		}
+	}
+	stored, err := history.CurrentDescriptions(ctx, lookup)
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to load descriptions to merge: %w", err)}
+	}
+	for id, description := range stored {
+		known[id] = description
+	}
+
+	var merges []store.DescriptionMerge
+	var errs []error
+	for _, node := range nodes {
+		existing, incoming := known[node.ID], node.Description
+		switch {
+		case strings.TrimSpace(incoming) == "":
+			node.Description = existing
+		case strings.TrimSpace(existing) != "" && !strings.EqualFold(strings.TrimSpace(existing), strings.TrimSpace(incoming)):
+			merged, err := g.descriptionMerger.MergeDescriptions(ctx, node.Name, existing, incoming)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to merge description of %s: %w", node.Name, err))
+				merged, _ = extraction.AppendDescriptions{}.MergeDescriptions(ctx, node.Name, existing, incoming)
+			}
+			node.Description = merged
+			merges = append(merges, store.DescriptionMerge{NodeID: node.ID, Previous: existing, Incoming: incoming})
+		}
+		known[node.ID] = node.Description
+	}
+	return merges, errs
+}
+
+// recordDescriptionMerges keeps the merged descriptions of written nodes in their history
+func (g *Gognee) recordDescriptionMerges(ctx context.Context, merges []store.DescriptionMerge) error {
+	history, ok := g.graphStore.(store.DescriptionHistory)
+	if !ok || len(merges) == 0 {
+		return nil
+	}
+	if err := history.RecordDescriptionMerges(ctx, merges); err != nil {
+		return fmt.Errorf("failed to record description history: %w", err)
+	}
+	return nil
+}
+
+// writtenMerges returns the merges of the nodes that were written
+func writtenMerges(merges []store.DescriptionMerge, written []*store.Node) []store.DescriptionMerge {
+	ids := make(map[string]bool, len(written))
+	for _, node := range written {
+		ids[node.ID] = true
+	}
+	var kept []store.DescriptionMerge
+	for _, merge := range merges {
+		if ids[merge.NodeID] {
+			kept = append(kept, merge)
+		}
+	}
+	return kept
+}
+
+// addEntityNode upserts an extracted entity node, merging its description with the
+// stored one. Merge failures are returned as warnings; err is the upsert's failure.
+func (g *Gognee) addEntityNode(ctx context.Context, node *store.Node) (warnings []error, err error) {
+	merges, warnings := g.mergeDescriptions(ctx, []*store.Node{node}, nil)
+	if err := g.graphStore.AddNode(ctx, node); err != nil {
+		return warnings, err
+	}
+	if err := g.recordDescriptionMerges(ctx, merges); err != nil {
+		warnings = append(warnings, err)
+	}
+	return warnings, nil
+}
+
+// GetNodeDescriptions returns the descriptions a node was given by the mentions merged
+// into its current description, oldest first (see Config.DescriptionMerge). A node whose
+// description was never merged has no history. Requires a graph store implementing
+// store.DescriptionHistory.
+func (g *Gognee) GetNodeDescriptions(ctx context.Context, nodeID string) ([]store.NodeDescription, error) {
+	history, ok := g.graphStore.(store.DescriptionHistory)
+	if !ok {
+		return nil, fmt.Errorf("description history requires a graph store implementing store.DescriptionHistory, got %T", g.graphStore)
+	}
+	return history.NodeDescriptions(ctx, nodeID)
+}
@@ -0,0 +1,81 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+)
+
+func TestCognify_MergesRepeatedDescriptions(t *testing.T) {
+	for _, atomic := range []bool{false, true} {
+		ctx := context.Background()
+		llmClient := &MockLLMClient{EntityResponses: [][]extraction.Entity{
+			{{Name: "Alice", Type: "Person", Description: "An engineer."}},
+			{{Name: "Alice", Type: "Person", Description: "Leads the platform team."}},
+			{{Name: "Alice", Type: "Person", Description: "an engineer"}},
+		}}
+		g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+		if err != nil {
+			t.Fatalf("NewWithClients failed: %v", err)
+		}
+		defer g.Close()
+
+		for _, text := range []string{"Alice is an engineer.", "Alice leads the platform team.", "Alice engineers."} {
+			_ = g.Add(ctx, text, AddOptions{})
+			result, err := g.Cognify(ctx, CognifyOptions{Atomic: atomic})
+			if err != nil || len(result.Errors) != 0 {
+				t.Fatalf("Cognify (atomic %v) failed: %v %v", atomic, err, result.Errors)
+			}
+		}
+
+		id := g.nodeID("Alice", "Person")
+		node, err := g.GetGraphStore().GetNode(ctx, id)
+		if err != nil {
+			t.Fatalf("GetNode failed: %v", err)
+		}
+		if node.Description != "An engineer. Leads the platform team." {
+			t.Errorf("atomic %v: expected the descriptions merged, got %q", atomic, node.Description)
+		}
+		history, err := g.GetNodeDescriptions(ctx, id)
+		if err != nil {
+			t.Fatalf("GetNodeDescriptions failed: %v", err)
+		}
+		if len(history) != 3 || history[0].Description != "An engineer." || history[1].Description != "Leads the platform team." {
+			t.Errorf("atomic %v: unexpected history %+v", atomic, history)
+		}
+	}
+}
+
+func TestCognify_ReplaceDescriptions(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &MockLLMClient{EntityResponses: [][]extraction.Entity{
+		{{Name: "Alice", Type: "Person", Description: "An engineer."}},
+		{{Name: "Alice", Type: "Person", Description: "A manager."}},
+	}}
+	g, err := NewWithClients(Config{DBPath: ":memory:", DescriptionMerge: DescriptionMergeReplace}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	for _, text := range []string{"Alice is an engineer.", "Alice became a manager."} {
+		_ = g.Add(ctx, text, AddOptions{})
+		if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+			t.Fatalf("Cognify failed: %v", err)
+		}
+	}
+	id := g.nodeID("Alice", "Person")
+	if node, _ := g.GetGraphStore().GetNode(ctx, id); node == nil || node.Description != "A manager." {
+		t.Errorf("expected the newest description, got %+v", node)
+	}
+	if history, _ := g.GetNodeDescriptions(ctx, id); len(history) != 0 {
+		t.Errorf("expected no history, got %+v", history)
+	}
+
+	_, err = NewWithClients(Config{DBPath: ":memory:", DescriptionMerge: "concat"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ErrValidation for an unknown DescriptionMerge, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// DiffGraph reports what changed in the knowledge base since the given time, e.g. for a
+// "what changed this week" report: the nodes and edges added and the memories
+// superseded. Deleted nodes and edges leave no trace, so the diff has no removals; use
+// DiffBackups against an earlier Backup to see them.
+func (g *Gognee) DiffGraph(ctx context.Context, since time.Time) (*store.GraphDiff, error) {
+	differ, ok := g.graphStore.(store.GraphDiffer)
+	if !ok {
+		return nil, fmt.Errorf("graph diff requires a graph store implementing store.GraphDiffer")
+	}
+	return differ.DiffSince(ctx, since)
+}
+
+// DiffBackups compares two snapshots created by Backup in Config.Namespace: the nodes and
+// edges added and removed from basePath to headPath, and the memories superseded in
+// between. An empty headPath compares basePath with the live store.
+func (g *Gognee) DiffBackups(ctx context.Context, basePath, headPath string) (*store.GraphDiff, error) {
+	differ, ok := g.graphStore.(store.GraphDiffer)
+	if !ok {
+		return nil, fmt.Errorf("graph diff requires a graph store implementing store.GraphDiffer")
+	}
+	return differ.DiffSnapshots(ctx, basePath, headPath)
+}
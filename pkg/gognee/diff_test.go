@@ -0,0 +1,80 @@
+package gognee
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+)
+
+func TestDiffGraph(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &MockLLMClient{EntityResponses: [][]extraction.Entity{
+		{{Name: "Gamma", Type: "Concept", Description: "Dropped"}},
+		{{Name: "Alpha", Type: "Concept", Description: "Original"}},
+		{{Name: "Beta", Type: "Concept", Description: "Replacement"}},
+	}}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	dropped, err := g.AddMemory(ctx, MemoryInput{Topic: "Dropped", Context: "Gamma is obsolete."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	original, err := g.AddMemory(ctx, MemoryInput{Topic: "Plan", Context: "We use Alpha."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	base := filepath.Join(t.TempDir(), "base.db")
+	if err := g.Backup(ctx, base); err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	since := time.Now()
+
+	if _, err := g.AddMemory(ctx, MemoryInput{Topic: "Plan", Context: "We use Beta.", Supersedes: []string{original.MemoryID}}); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if err := g.DeleteMemory(ctx, dropped.MemoryID); err != nil {
+		t.Fatalf("DeleteMemory failed: %v", err)
+	}
+
+	diff, err := g.DiffGraph(ctx, since)
+	if err != nil {
+		t.Fatalf("DiffGraph failed: %v", err)
+	}
+	if len(diff.NodesAdded) != 1 || diff.NodesAdded[0].Name != "Beta" || len(diff.NodesRemoved) != 0 {
+		t.Errorf("Expected Beta added, got %+v", diff)
+	}
+	if len(diff.Supersessions) != 1 || diff.Supersessions[0].SupersededID != original.MemoryID {
+		t.Errorf("Expected the original memory superseded, got %+v", diff.Supersessions)
+	}
+
+	head := filepath.Join(t.TempDir(), "head.db")
+	if err := g.Backup(ctx, head); err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	for _, headPath := range []string{"", head} {
+		diff, err := g.DiffBackups(ctx, base, headPath)
+		if err != nil {
+			t.Fatalf("DiffBackups(%q) failed: %v", headPath, err)
+		}
+		if len(diff.NodesAdded) != 1 || diff.NodesAdded[0].Name != "Beta" {
+			t.Errorf("DiffBackups(%q): expected Beta added, got %+v", headPath, diff.NodesAdded)
+		}
+		if len(diff.NodesRemoved) != 1 || diff.NodesRemoved[0].Name != "Gamma" {
+			t.Errorf("DiffBackups(%q): expected Gamma removed, got %+v", headPath, diff.NodesRemoved)
+		}
+		if len(diff.Supersessions) != 1 {
+			t.Errorf("DiffBackups(%q): expected one supersession, got %+v", headPath, diff.Supersessions)
+		}
+	}
+
+	if _, err := g.DiffBackups(ctx, filepath.Join(t.TempDir(), "missing.db"), ""); err == nil {
+		t.Error("Expected an error for a missing snapshot")
+	}
+}
@@ -0,0 +1,93 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dan-solli/gognee/pkg/chunker"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// documentStore returns the graph store's document provenance, or an error naming op
+func (g *Gognee) documentStore(op string) (store.DocumentStore, error) {
+	documents, ok := g.graphStore.(store.DocumentStore)
+	if !ok {
+		return nil, fmt.Errorf("%s requires a graph store implementing store.DocumentStore", op)
+	}
+	return documents, nil
+}
+
+// linkChunk records that a chunk of doc produced the given nodes and edges. Provenance is
+// best-effort: failures are reported in result without failing the chunk.
+func (g *Gognee) linkChunk(ctx context.Context, doc AddedDocument, hash string, chunk chunker.Chunk, nodes []*store.Node, edges []*store.Edge, result *CognifyResult) {
+	documents, ok := g.graphStore.(store.DocumentStore)
+	if !ok {
+		return
+	}
+	nodeIDs := make([]string, len(nodes))
+	for i, node := range nodes {
+		nodeIDs[i] = node.ID
+	}
+	edgeIDs := make([]string, len(edges))
+	for i, edge := range edges {
+		edgeIDs[i] = edge.ID
+	}
+	record := store.Document{Hash: hash, Source: doc.Source, AddedAt: doc.AddedAt}
+	if err := documents.LinkDocument(ctx, record, nodeIDs, edgeIDs, []string{chunk.ID}); err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("failed to record document provenance: %w", err))
+	}
+}
+
+// ListDocuments returns the documents Cognify has processed, oldest first. Documents are
+// identified by Hash, the SHA-256 of their text (see AddedDocument.Hash).
+func (g *Gognee) ListDocuments(ctx context.Context) ([]store.Document, error) {
+	documents, err := g.documentStore("listing documents")
+	if err != nil {
+		return nil, err
+	}
+	return documents.ListDocuments(ctx)
+}
+
+// GetNodesByDocument returns the nodes Cognify extracted from the document with the
+// given hash, ordered by ID. Nodes merged by entity resolution are reported as their
+// canonical node.
+func (g *Gognee) GetNodesByDocument(ctx context.Context, hash string) ([]*store.Node, error) {
+	documents, err := g.documentStore("listing document nodes")
+	if err != nil {
+		return nil, err
+	}
+	return documents.GetNodesByDocument(ctx, hash)
+}
+
+// DeleteDocument forgets a processed document: its provenance links and processed record
+// are removed, so adding it again reprocesses it. With cascade, nodes and edges no other
+// document or memory links to are deleted along with their vectors; pinned nodes and
+// nodes still connected to other edges are kept. Returns store.ErrDocumentNotFound for
+// an unknown hash.
+func (g *Gognee) DeleteDocument(ctx context.Context, hash string, cascade bool) (nodesDeleted, edgesDeleted int, err error) {
+	documents, err := g.documentStore("deleting documents")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var nodes []*store.Node
+	if cascade {
+		if nodes, err = documents.GetNodesByDocument(ctx, hash); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	nodesDeleted, edgeIDs, err := documents.DeleteDocument(ctx, hash, cascade)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Drop the vectors of deleted nodes and edges
+	nodeIDs := make([]string, len(nodes))
+	for i, node := range nodes {
+		nodeIDs[i] = node.ID
+	}
+	g.dropNodeVectors(ctx, nodeIDs)
+	g.dropEdgeVectors(ctx, edgeIDs)
+	return nodesDeleted, len(edgeIDs), nil
+}
@@ -0,0 +1,133 @@
+package gognee
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/search"
+)
+
+func TestCognify_DocumentProvenance(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &MockLLMClient{EntityResponses: [][]extraction.Entity{
+		{{Name: "Alice", Type: "Person", Description: "An engineer"}, {Name: "Go", Type: "Technology", Description: "A language"}},
+		{{Name: "Bob", Type: "Person", Description: "A manager"}, {Name: "Go", Type: "Technology", Description: "A language"}},
+	}}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	_ = g.Add(ctx, "Alice writes Go.", AddOptions{Source: "alice.md"})
+	_ = g.Add(ctx, "Bob manages Go projects.", AddOptions{Source: "bob.md"})
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+
+	docs, err := g.ListDocuments(ctx)
+	if err != nil || len(docs) != 2 || docs[0].Source != "alice.md" || docs[0].Hash != computeDocumentHash("Alice writes Go.") {
+		t.Fatalf("unexpected documents %+v, %v", docs, err)
+	}
+	nodes, err := g.GetNodesByDocument(ctx, docs[0].Hash)
+	if err != nil || len(nodes) != 2 {
+		t.Fatalf("expected Alice and Go for the first document, got %+v, %v", nodes, err)
+	}
+
+	nodesDeleted, _, err := g.DeleteDocument(ctx, docs[0].Hash, true)
+	if err != nil {
+		t.Fatalf("DeleteDocument failed: %v", err)
+	}
+	if nodesDeleted != 1 {
+		t.Errorf("expected only Alice deleted, got %d nodes", nodesDeleted)
+	}
+	if node, _ := g.graphStore.GetNode(ctx, g.nodeID("Go", "Technology")); node == nil {
+		t.Error("expected Go kept for the second document")
+	}
+	if docs, _ := g.ListDocuments(ctx); len(docs) != 1 || docs[0].Source != "bob.md" {
+		t.Errorf("expected only bob.md left, got %+v", docs)
+	}
+}
+
+func TestDeleteDocument_DropsEdgeVectors(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &MockLLMClient{
+		EntityResponses:   [][]extraction.Entity{{{Name: "Alice", Type: "Person", Description: "An engineer"}, {Name: "Go", Type: "Technology", Description: "A language"}}},
+		RelationResponses: [][]extraction.Triplet{{{Subject: "Alice", Relation: "USES", Object: "Go"}}},
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:", EdgeEmbeddings: true}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	_ = g.Add(ctx, "Alice writes Go.", AddOptions{Source: "alice.md"})
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	query, _ := g.embeddings.EmbedOne(ctx, "Alice USES Go")
+	if matches, _ := g.edgeVectorStore.Search(ctx, query, 10); len(matches) != 1 {
+		t.Fatalf("expected the edge indexed, got %+v", matches)
+	}
+
+	_, edgesDeleted, err := g.DeleteDocument(ctx, computeDocumentHash("Alice writes Go."), true)
+	if err != nil {
+		t.Fatalf("DeleteDocument failed: %v", err)
+	}
+	if edgesDeleted != 1 {
+		t.Errorf("expected the edge deleted, got %d", edgesDeleted)
+	}
+	if matches, _ := g.edgeVectorStore.Search(ctx, query, 10); len(matches) != 0 {
+		t.Errorf("expected the deleted edge's vector dropped, got %+v", matches)
+	}
+}
+
+func TestSearch_SourceFilter(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &MockLLMClient{EntityResponses: [][]extraction.Entity{
+		{{Name: "Alice", Type: "Person", Description: "An engineer"}},
+		{{Name: "Bob", Type: "Person", Description: "A manager"}},
+		{{Name: "Carol", Type: "Person", Description: "A designer"}},
+	}}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	_ = g.Add(ctx, "Alice writes Go.", AddOptions{Source: "alice.md"})
+	_ = g.Add(ctx, "Bob manages projects.", AddOptions{Source: "bob.md"})
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	if _, err := g.AddMemory(ctx, MemoryInput{Topic: "Carol", Context: "Carol joined.", Source: "alice.md"}); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	found := func(query string, sources ...string) map[string]bool {
+		t.Helper()
+		resp, err := g.Search(ctx, query, search.SearchOptions{Type: search.SearchTypeVector, TopK: 10, Sources: sources})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		names := make(map[string]bool)
+		for _, result := range resp.Results {
+			names[result.Node.Name] = true
+		}
+		return names
+	}
+
+	if names := found("Bob", "alice.md"); names["Bob"] || !names["Alice"] {
+		t.Errorf("expected only alice.md nodes, got %v", names)
+	}
+	if names := found("Carol", "alice.md"); !names["Carol"] {
+		t.Errorf("expected the memory's node for its source, got %v", names)
+	}
+	if names := found("Bob", "bob.md", "missing.md"); len(names) != 1 || !names["Bob"] {
+		t.Errorf("expected only Bob for bob.md, got %v", names)
+	}
+	if names := found("Alice", "missing.md"); len(names) != 0 {
+		t.Errorf("expected no results for an unknown source, got %v", names)
+	}
+}
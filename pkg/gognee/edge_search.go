@@ -0,0 +1,194 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// EdgeSearchResult is a relationship matched by SearchEdges.
+type EdgeSearchResult struct {
+	Edge       *store.Edge `json:"edge"`
+	SourceName string      `json:"source_name"`
+	TargetName string      `json:"target_name"`
+	Score      float64     `json:"score"` // Similarity of the query to the relationship text
+}
+
+// edgeBatch collects the edges created for a chunk so their text can be embedded in one call
+type edgeBatch struct {
+	ids   []string
+	texts []string
+}
+
+// add records an edge and its textual rendering
+func (b *edgeBatch) add(edge *store.Edge, subject, object string) {
+	b.ids = append(b.ids, edge.ID)
+	b.texts = append(b.texts, renderEdge(subject, edge.Relation, object))
+}
+
+// renderEdge renders a relationship as text for embedding, e.g. "Alice USES Go"
+func renderEdge(subject, relation, object string) string {
+	return subject + " " + relation + " " + object
+}
+
+// indexEdges embeds the batched edges into the edge vector store when
+// Config.EdgeEmbeddings is enabled.
+func (g *Gognee) indexEdges(ctx context.Context, batch *edgeBatch) error {
+	embeddings, err := g.embedEdges(ctx, batch)
+	if err != nil {
+		return err
+	}
+	for _, id := range batch.ids {
+		embedding, ok := embeddings[id]
+		if !ok {
+			continue
+		}
+		if err := g.edgeVectorStore.Add(ctx, id, embedding); err != nil {
+			return fmt.Errorf("failed to index edge %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// embedEdges returns the embeddings of the batched edges by edge ID, or nil unless
+// Config.EdgeEmbeddings is enabled.
+func (g *Gognee) embedEdges(ctx context.Context, batch *edgeBatch) (map[string][]float32, error) {
+	if !g.config.EdgeEmbeddings || len(batch.ids) == 0 {
+		return nil, nil
+	}
+
+	embeddings, err := g.embeddings.Embed(ctx, batch.texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed edges: %w", err)
+	}
+	byID := make(map[string][]float32, len(batch.ids))
+	for i, id := range batch.ids {
+		if i < len(embeddings) && len(embeddings[i]) > 0 {
+			byID[id] = embeddings[i]
+		}
+	}
+	return byID, nil
+}
+
+// dropEdgeVectors deletes the vectors of deleted edges. Errors are ignored: a dangling
+// vector only costs a search candidate that is skipped.
+func (g *Gognee) dropEdgeVectors(ctx context.Context, edgeIDs []string) {
+	if !g.config.EdgeEmbeddings {
+		return
+	}
+	for _, id := range edgeIDs {
+		_ = g.edgeVectorStore.Delete(ctx, id)
+	}
+}
+
+// syncEdgeVectors brings the edge vector store in step with a graph rewrite: removed
+// edges lose their vectors and written edges are embedded from their new text.
+func (g *Gognee) syncEdgeVectors(ctx context.Context, changes store.EdgeChanges) error {
+	if !g.config.EdgeEmbeddings {
+		return nil
+	}
+	g.dropEdgeVectors(ctx, changes.Removed)
+	edgeReader, ok := g.graphStore.(store.EdgeReader)
+	if !ok {
+		return fmt.Errorf("edge embeddings require a graph store implementing store.EdgeReader")
+	}
+
+	edges := make([]*store.Edge, 0, len(changes.Written))
+	endpoints := make([]string, 0, 2*len(changes.Written))
+	for _, id := range changes.Written {
+		edge, err := edgeReader.GetEdge(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to load edge %s: %w", id, err)
+		}
+		if edge == nil {
+			continue
+		}
+		edges = append(edges, edge)
+		endpoints = append(endpoints, edge.SourceID, edge.TargetID)
+	}
+	names, err := store.GetNodesByIDs(ctx, g.graphStore, endpoints)
+	if err != nil {
+		return fmt.Errorf("failed to load edge endpoints: %w", err)
+	}
+
+	var batch edgeBatch
+	for _, edge := range edges {
+		source, target := names[edge.SourceID], names[edge.TargetID]
+		if source == nil || target == nil {
+			continue
+		}
+		batch.add(edge, source.Name, target.Name)
+	}
+	return g.indexEdges(ctx, &batch)
+}
+
+// SearchEdges returns the relationships whose text ("Alice USES Go") is most similar to
+// the query, best first (default topK: 10). Requires Config.EdgeEmbeddings; only edges
+// created while it was enabled are searchable.
+func (g *Gognee) SearchEdges(ctx context.Context, query string, topK int) ([]EdgeSearchResult, error) {
+	if !g.config.EdgeEmbeddings {
+		return nil, fmt.Errorf("edge search requires Config.EdgeEmbeddings")
+	}
+	if topK <= 0 {
+		topK = 10
+	}
+
+	edges, ok := g.graphStore.(store.EdgeReader)
+	if !ok {
+		return nil, fmt.Errorf("edge search requires a graph store implementing store.EdgeReader")
+	}
+
+	queryEmbedding, err := g.embeddings.EmbedOne(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+
+	matches, err := g.edgeVectorStore.Search(ctx, queryEmbedding, topK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search edges: %w", err)
+	}
+
+	results := make([]EdgeSearchResult, 0, len(matches))
+	for _, match := range matches {
+		edge, err := edges.GetEdge(ctx, match.ID)
+		if err != nil {
+			return nil, err
+		}
+		if edge == nil {
+			continue // Deleted or expired since it was indexed
+		}
+
+		result := EdgeSearchResult{Edge: edge, Score: match.Score}
+		if source, err := g.graphStore.GetNode(ctx, edge.SourceID); err == nil && source != nil {
+			result.SourceName = source.Name
+		}
+		if target, err := g.graphStore.GetNode(ctx, edge.TargetID); err == nil && target != nil {
+			result.TargetName = target.Name
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// edgeSeedNodes returns the endpoints of the relationships closest to the query,
+// used as graph search seeds when the caller provides none.
+func (g *Gognee) edgeSeedNodes(ctx context.Context, query string, topK int) []string {
+	matches, err := g.SearchEdges(ctx, query, topK)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var seeds []string
+	for _, match := range matches {
+		for _, id := range []string{match.Edge.SourceID, match.Edge.TargetID} {
+			if !seen[id] {
+				seen[id] = true
+				seeds = append(seeds, id)
+			}
+		}
+	}
+	return seeds
+}
@@ -0,0 +1,162 @@
+package gognee
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/search"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func newEdgeSearchGognee(t *testing.T, edgeEmbeddings bool) *Gognee {
+	t.Helper()
+	g, err := New(Config{DBPath: ":memory:", EdgeEmbeddings: edgeEmbeddings})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { g.Close() })
+
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{
+				{Name: "Alice", Type: "Person", Description: "Engineer"},
+				{Name: "Go", Type: "Technology", Description: "Programming language"},
+				{Name: "Bob", Type: "Person", Description: "Manager"},
+			},
+		},
+		RelationResponses: [][]extraction.Triplet{
+			{
+				{Subject: "Alice", Relation: "USES", Object: "Go"},
+				{Subject: "Bob", Relation: "MANAGES", Object: "Alice"},
+			},
+		},
+	}
+	g.llm = mockLLM
+	g.embeddings = &MockEmbeddingClient{}
+	g.searcher = search.NewHybridSearcher(g.embeddings, g.vectorStore, g.graphStore)
+	g.entityExtractor = extraction.NewEntityExtractor(mockLLM)
+	g.relationExtractor = extraction.NewRelationExtractor(mockLLM)
+	return g
+}
+
+func TestSearchEdges(t *testing.T) {
+	ctx := context.Background()
+	g := newEdgeSearchGognee(t, true)
+
+	if _, err := g.AddMemory(ctx, MemoryInput{Topic: "Team", Context: "Alice uses Go. Bob manages Alice."}); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	results, err := g.SearchEdges(ctx, "Alice USES Go", 1)
+	if err != nil {
+		t.Fatalf("SearchEdges failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	top := results[0]
+	if top.Edge.Relation != "USES" || top.SourceName != "Alice" || top.TargetName != "Go" {
+		t.Errorf("Expected Alice USES Go, got %s %s %s", top.SourceName, top.Edge.Relation, top.TargetName)
+	}
+	if top.Score < 0.99 {
+		t.Errorf("Expected exact match score ~1.0, got %f", top.Score)
+	}
+}
+
+func TestSearchEdges_RequiresEdgeEmbeddings(t *testing.T) {
+	g := newEdgeSearchGognee(t, false)
+	if _, err := g.SearchEdges(context.Background(), "Alice USES Go", 5); err == nil {
+		t.Error("Expected error when EdgeEmbeddings is disabled")
+	}
+}
+
+func TestSearch_GraphSeedsFromEdges(t *testing.T) {
+	ctx := context.Background()
+	g := newEdgeSearchGognee(t, true)
+
+	if _, err := g.AddMemory(ctx, MemoryInput{Topic: "Team", Context: "Alice uses Go. Bob manages Alice."}); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	results, err := g.Search(ctx, "Alice USES Go", SearchOptions{Type: search.SearchTypeGraph, TopK: 1, GraphDepth: 1})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results.Results) == 0 {
+		t.Fatal("Expected graph search seeded from edge matches to return results")
+	}
+}
+
+func TestResolveEntities_SyncsEdgeVectors(t *testing.T) {
+	g, err := NewWithClients(Config{DBPath: ":memory:", EdgeEmbeddings: true}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+	ctx := context.Background()
+
+	ids := seedEntityGraph(t, g, []string{"PostgreSQL", "Postgre SQL", "MySQL"}, nil, map[string]int{"PostgreSQL": 2})
+	if err := g.graphStore.AddNode(ctx, &store.Node{ID: "billing", Name: "Billing", Type: "System"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	edges := []*store.Edge{
+		{ID: "e1", SourceID: "billing", Relation: "USES", TargetID: ids["PostgreSQL"]},
+		{ID: "e2", SourceID: "billing", Relation: "USES", TargetID: ids["Postgre SQL"]}, // Duplicate after merge
+		{ID: "e3", SourceID: ids["Postgre SQL"], Relation: "REPLACES", TargetID: ids["MySQL"]},
+	}
+	names := map[string]string{"billing": "Billing", ids["PostgreSQL"]: "PostgreSQL", ids["Postgre SQL"]: "Postgre SQL", ids["MySQL"]: "MySQL"}
+	var batch edgeBatch
+	for _, e := range edges {
+		if err := g.graphStore.AddEdge(ctx, e); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+		batch.add(e, names[e.SourceID], names[e.TargetID])
+	}
+	if err := g.indexEdges(ctx, &batch); err != nil {
+		t.Fatalf("indexEdges failed: %v", err)
+	}
+
+	if _, err := g.ResolveEntities(ctx, ResolveEntitiesOptions{Method: ResolutionExact}); err != nil {
+		t.Fatalf("ResolveEntities failed: %v", err)
+	}
+
+	results, err := g.SearchEdges(ctx, "Billing USES Postgre SQL", 10)
+	if err != nil {
+		t.Fatalf("SearchEdges failed: %v", err)
+	}
+	for _, r := range results {
+		if r.Edge.ID == "e2" {
+			t.Error("Expected the dropped duplicate edge's vector deleted")
+		}
+	}
+
+	results, err = g.SearchEdges(ctx, "PostgreSQL REPLACES MySQL", 1)
+	if err != nil {
+		t.Fatalf("SearchEdges failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Edge.ID != "e3" || results[0].Score < 0.99 {
+		t.Errorf("Expected the repointed edge re-embedded as PostgreSQL REPLACES MySQL, got %+v", results)
+	}
+}
+
+func TestDeleteMemory_DropsEdgeVectors(t *testing.T) {
+	ctx := context.Background()
+	g := newEdgeSearchGognee(t, true)
+
+	result, err := g.AddMemory(ctx, MemoryInput{Topic: "Team", Context: "Alice uses Go. Bob manages Alice."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	query, _ := g.embeddings.EmbedOne(ctx, "Alice USES Go")
+	if matches, _ := g.edgeVectorStore.Search(ctx, query, 10); len(matches) != 2 {
+		t.Fatalf("Expected both edges indexed, got %+v", matches)
+	}
+
+	if err := g.DeleteMemory(ctx, result.MemoryID); err != nil {
+		t.Fatalf("DeleteMemory failed: %v", err)
+	}
+	if matches, _ := g.edgeVectorStore.Search(ctx, query, 10); len(matches) != 0 {
+		t.Errorf("Expected the garbage-collected edges' vectors dropped, got %+v", matches)
+	}
+}
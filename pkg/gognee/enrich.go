@@ -0,0 +1,274 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+const (
+	// enrichmentKey is the memory metadata key tracking a quick-added note's enrichment state
+	enrichmentKey = "enrichment"
+
+	enrichmentPending  = "pending"
+	enrichmentComplete = "complete"
+
+	// defaultEnrichmentBatchSize is used by EnrichNotes when no limit is given
+	defaultEnrichmentBatchSize = 10
+)
+
+// EnrichmentResult reports the outcome of an EnrichNotes() pass.
+type EnrichmentResult struct {
+	NotesEnriched int // Notes whose extraction succeeded and left the queue
+	NotesFailed   int // Notes left queued for a later pass
+	NodesCreated  int
+	EdgesCreated  int
+	Errors        []error
+}
+
+// PendingEnrichmentCount returns how many quick-added notes are waiting for enrichment.
+func (g *Gognee) PendingEnrichmentCount(ctx context.Context) (int, error) {
+	var count int
+	err := g.memoryStore.DB().QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM memories
+		WHERE namespace = ? AND json_extract(metadata_json, '$.`+enrichmentKey+`') = ?
+	`, g.config.Namespace, enrichmentPending).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending notes: %w", err)
+	}
+	return count, nil
+}
+
+// EnrichNotes runs full LLM extraction on up to limit quick-added notes, oldest first
+// (default limit: 10). Extracted entities and relations join the graph, each entity is
+// linked from the note node with a MENTIONS edge, and everything is added to the note
+// memory's provenance. Notes whose entity extraction fails stay queued for the next pass.
+// It is called by the background scheduler when Config.EnrichmentBatchSize is set.
+func (g *Gognee) EnrichNotes(ctx context.Context, limit int) (*EnrichmentResult, error) {
+	if limit <= 0 {
+		limit = defaultEnrichmentBatchSize
+	}
+
+	rows, err := g.memoryStore.DB().QueryContext(ctx, `
+		SELECT id FROM memories
+		WHERE namespace = ? AND json_extract(metadata_json, '$.`+enrichmentKey+`') = ?
+		ORDER BY created_at ASC
+		LIMIT ?
+	`, g.config.Namespace, enrichmentPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending notes: %w", err)
+	}
+	var memoryIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan pending note: %w", err)
+		}
+		memoryIDs = append(memoryIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list pending notes: %w", err)
+	}
+
+	result := &EnrichmentResult{Errors: make([]error, 0)}
+	for _, memoryID := range memoryIDs {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err := g.enrichNote(ctx, memoryID, result); err != nil {
+			result.NotesFailed++
+			result.Errors = append(result.Errors, fmt.Errorf("failed to enrich note %s: %w", memoryID, err))
+			continue
+		}
+		result.NotesEnriched++
+	}
+
+	return result, nil
+}
+
+// enrichNote extracts a subgraph from one quick-added note and marks it enriched.
+// Partial failures (a relation extraction, a single node) are recorded in result;
+// an error is returned only when the note should be retried.
+func (g *Gognee) enrichNote(ctx context.Context, memoryID string, result *EnrichmentResult) error {
+	memory, err := g.memoryStore.GetMemory(ctx, memoryID)
+	if err != nil {
+		return err
+	}
+
+	noteID := g.nodeID(memory.DocHash, NoteNodeType)
+	createdNodeIDs := make([]string, 0)
+	createdEdgeIDs := make([]string, 0)
+	var guardrailReport extraction.GuardrailReport
+
+	for _, chunk := range g.textChunker.Chunk(memory.Context) {
+		entities, err := g.entityExtractor.Extract(ctx, chunk.Text)
+		if err == nil {
+			entities, err = g.redactEntities(ctx, entities)
+		}
+		if err != nil {
+			return fmt.Errorf("entity extraction failed: %w", err)
+		}
+		entities, report := g.config.Guardrail.CheckEntities(entities)
+		guardrailReport.Merge(report)
+		entities, _ = extraction.FilterEntitiesByConfidence(entities, g.config.MinExtractionConfidence)
+		entities, _, err = g.config.EntityFilter.Filter(ctx, entities)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("entity filter failed: %w", err))
+		}
+
+		// Map known aliases to their canonical entities (see ResolveEntities). Relations are
+		// extracted against the names as written and mapped the same way.
+		extracted := entities
+		entities, renames := g.canonicalEntities(entities)
+
+		entityMap, ambiguous := buildEntityTypeMap(entities)
+
+		triplets, err := g.relationExtractor.Extract(ctx, chunk.Text, extracted)
+		if err == nil {
+			triplets, err = g.redactTriplets(ctx, triplets)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("relation extraction failed: %w", err))
+			triplets = nil
+		}
+		triplets, report = g.config.Guardrail.CheckTriplets(triplets)
+		guardrailReport.Merge(report)
+		triplets, _ = extraction.FilterTripletsByConfidence(triplets, g.config.MinExtractionConfidence)
+
+		entityTexts := make([]string, len(entities))
+		for i, entity := range entities {
+			entityTexts[i] = entity.Name + " " + entity.Description
+		}
+		embeddings, err := g.embeddings.Embed(ctx, entityTexts)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("batch embed failed: %w", err))
+			embeddings = make([][]float32, len(entities))
+		}
+
+		for i, entity := range entities {
+			nodeID := g.nodeID(entity.Name, entity.Type)
+			node := &store.Node{
+				ID:          nodeID,
+				Name:        entity.Name,
+				Type:        entity.Type,
+				Description: entity.Description,
+				CreatedAt:   time.Now(),
+				Metadata:    g.entityMetadata(nodeID),
+				Embedding:   embeddings[i],
+			}
+			warnings, err := g.addEntityNode(ctx, node)
+			result.Errors = append(result.Errors, warnings...)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to add node: %w", err))
+				continue
+			}
+			createdNodeIDs = append(createdNodeIDs, nodeID)
+			result.NodesCreated++
+
+			if len(embeddings[i]) > 0 {
+				if err := g.vectorStore.Add(ctx, nodeID, embeddings[i]); err != nil {
+					result.Errors = append(result.Errors, fmt.Errorf("failed to index node in vector store: %w", err))
+				}
+			}
+
+			// Link the original note to what was extracted from it
+			if edgeID, err := g.addEnrichmentEdge(ctx, noteID, nodeID, chunk.ID); err != nil {
+				result.Errors = append(result.Errors, err)
+			} else {
+				createdEdgeIDs = append(createdEdgeIDs, edgeID)
+				result.EdgesCreated++
+			}
+		}
+		g.recordMentions(ctx, entities)
+
+		var newEdges edgeBatch
+		for _, triplet := range triplets {
+			triplet.Relation = g.canonicalRelation(triplet.Relation)
+			triplet.Subject, triplet.Object = renames.apply(triplet.Subject), renames.apply(triplet.Object)
+
+			sourceType, sourceFound := lookupEntityType(triplet.Subject, entityMap, ambiguous)
+			if !sourceFound {
+				continue
+			}
+			targetType, targetFound := lookupEntityType(triplet.Object, entityMap, ambiguous)
+			if !targetFound {
+				continue
+			}
+
+			sourceID := g.nodeID(triplet.Subject, sourceType)
+			targetID := g.nodeID(triplet.Object, targetType)
+			edgeID := derivedEdgeID(sourceID, triplet, targetID)
+
+			edge := &store.Edge{
+				ID:        edgeID,
+				SourceID:  sourceID,
+				Relation:  triplet.Relation,
+				TargetID:  targetID,
+				Weight:    1.0,
+				CreatedAt: time.Now(),
+			}
+			edge.ExpiresAt = g.relationExpiry(edge.Relation, edge.CreatedAt)
+			edge.SourceChunkID = chunk.ID
+			edge.Evidence = evidenceSnippet(chunk.Text, triplet.Subject, triplet.Object)
+			edge.ValidFrom, edge.ValidTo = triplet.ValidityPeriod()
+
+			if err := g.graphStore.AddEdge(ctx, edge); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to add edge: %w", err))
+				continue
+			}
+			createdEdgeIDs = append(createdEdgeIDs, edgeID)
+			result.EdgesCreated++
+			newEdges.add(edge, triplet.Subject, triplet.Object)
+		}
+		if err := g.indexEdges(ctx, &newEdges); err != nil {
+			result.Errors = append(result.Errors, err)
+		}
+	}
+
+	if err := g.memoryStore.LinkProvenance(ctx, memoryID, createdNodeIDs, createdEdgeIDs); err != nil {
+		return fmt.Errorf("failed to link provenance: %w", err)
+	}
+
+	metadata := memory.Metadata
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata[enrichmentKey] = enrichmentComplete
+	metadata["enriched_at"] = time.Now().UTC().Format(time.RFC3339)
+	if guardrailReport.Flagged() {
+		setGuardrailMetadata(metadata, guardrailReport)
+		g.logGuardrail(ctx, "memory", memoryID, guardrailReport)
+		if g.config.QuarantineFlagged {
+			if _, err := g.quarantine(ctx, QuarantineMemory, memoryID, memory.Source, guardrailReport, createdNodeIDs); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to quarantine note: %w", err))
+			}
+		}
+	}
+	if err := g.memoryStore.UpdateMemory(ctx, memoryID, store.MemoryUpdate{Metadata: &metadata}); err != nil {
+		return fmt.Errorf("failed to mark note enriched: %w", err)
+	}
+
+	return nil
+}
+
+// addEnrichmentEdge links a note node to an entity extracted from it
+func (g *Gognee) addEnrichmentEdge(ctx context.Context, noteID, entityID, chunkID string) (string, error) {
+	edge := &store.Edge{
+		ID:            fmt.Sprintf("%s-MENTIONS-%s", noteID, entityID),
+		SourceID:      noteID,
+		Relation:      "MENTIONS",
+		TargetID:      entityID,
+		Weight:        1.0,
+		CreatedAt:     time.Now(),
+		SourceChunkID: chunkID,
+	}
+	if err := g.graphStore.AddEdge(ctx, edge); err != nil {
+		return "", fmt.Errorf("failed to link note to entity: %w", err)
+	}
+	return edge.ID, nil
+}
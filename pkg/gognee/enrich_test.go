@@ -0,0 +1,128 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+)
+
+// failingLLMClient fails every call, simulating an unavailable or over-budget LLM
+type failingLLMClient struct{}
+
+func (failingLLMClient) Complete(ctx context.Context, prompt string) (string, error) {
+	return "", errors.New("llm unavailable")
+}
+
+func (failingLLMClient) CompleteWithSchema(ctx context.Context, prompt string, schema interface{}) error {
+	return errors.New("llm unavailable")
+}
+
+func TestEnrichNotes_BuildsSubgraph(t *testing.T) {
+	ctx := context.Background()
+
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{
+				{Name: "Postgres", Type: "Technology", Description: "Relational database"},
+				{Name: "Billing Service", Type: "System", Description: "Handles invoices"},
+			},
+		},
+		RelationResponses: [][]extraction.Triplet{
+			{{Subject: "Billing Service", Relation: "USES", Object: "Postgres"}},
+		},
+	}
+	g.llm = mockLLM
+	g.embeddings = &MockEmbeddingClient{}
+	g.entityExtractor = extraction.NewEntityExtractor(mockLLM)
+	g.relationExtractor = extraction.NewRelationExtractor(mockLLM)
+
+	note, err := g.QuickAdd(ctx, "The billing service stores invoices in Postgres")
+	if err != nil {
+		t.Fatalf("QuickAdd failed: %v", err)
+	}
+	if pending, _ := g.PendingEnrichmentCount(ctx); pending != 1 {
+		t.Fatalf("Expected 1 pending note, got %d", pending)
+	}
+
+	result, err := g.EnrichNotes(ctx, 0)
+	if err != nil {
+		t.Fatalf("EnrichNotes failed: %v", err)
+	}
+	if result.NotesEnriched != 1 || result.NodesCreated != 2 {
+		t.Errorf("Expected 1 note enriched with 2 nodes, got %+v", result)
+	}
+	// Two MENTIONS edges from the note plus the extracted relation
+	if result.EdgesCreated != 3 {
+		t.Errorf("Expected 3 edges created, got %d", result.EdgesCreated)
+	}
+
+	nodeIDs, edgeIDs, err := g.memoryStore.GetProvenanceByMemory(ctx, note.MemoryID)
+	if err != nil {
+		t.Fatalf("GetProvenanceByMemory failed: %v", err)
+	}
+	if len(nodeIDs) != 3 || len(edgeIDs) != 3 {
+		t.Errorf("Expected note, 2 entities and 3 edges in provenance, got %d nodes and %d edges", len(nodeIDs), len(edgeIDs))
+	}
+
+	neighbors, err := g.graphStore.GetNeighbors(ctx, note.NodeID, 1)
+	if err != nil {
+		t.Fatalf("GetNeighbors failed: %v", err)
+	}
+	if len(neighbors) != 2 {
+		t.Errorf("Expected note node linked to 2 entities, got %d", len(neighbors))
+	}
+
+	if pending, _ := g.PendingEnrichmentCount(ctx); pending != 0 {
+		t.Errorf("Expected empty queue after enrichment, got %d", pending)
+	}
+	memory, err := g.GetMemory(ctx, note.MemoryID)
+	if err != nil {
+		t.Fatalf("GetMemory failed: %v", err)
+	}
+	if memory.Metadata[enrichmentKey] != enrichmentComplete {
+		t.Errorf("Expected note marked enriched, got metadata %v", memory.Metadata)
+	}
+}
+
+func TestEnrichNotes_FailureStaysQueued(t *testing.T) {
+	ctx := context.Background()
+
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	g.embeddings = &MockEmbeddingClient{}
+	g.entityExtractor = extraction.NewEntityExtractor(failingLLMClient{})
+	g.relationExtractor = extraction.NewRelationExtractor(failingLLMClient{})
+
+	if _, err := g.QuickAdd(ctx, "Ask finance about the Q3 budget"); err != nil {
+		t.Fatalf("QuickAdd failed: %v", err)
+	}
+
+	result, err := g.EnrichNotes(ctx, 5)
+	if err != nil {
+		t.Fatalf("EnrichNotes failed: %v", err)
+	}
+	if result.NotesEnriched != 0 || result.NotesFailed != 1 || len(result.Errors) == 0 {
+		t.Errorf("Expected 1 failed note, got %+v", result)
+	}
+	if pending, _ := g.PendingEnrichmentCount(ctx); pending != 1 {
+		t.Errorf("Expected failed note to stay queued, got %d pending", pending)
+	}
+}
+
+func TestNew_EnrichmentBatchSizeValidation(t *testing.T) {
+	if _, err := New(Config{DBPath: ":memory:", EnrichmentBatchSize: -1}); err == nil {
+		t.Error("Expected error for negative EnrichmentBatchSize")
+	}
+}
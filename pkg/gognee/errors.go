@@ -5,6 +5,54 @@ import (
 	"errors"
 	"net"
 	"strings"
+
+	"github.com/dan-solli/gognee/pkg/breaker"
+	"github.com/dan-solli/gognee/pkg/embeddings"
+	"github.com/dan-solli/gognee/pkg/llm"
+	"github.com/dan-solli/gognee/pkg/provider"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// Sentinel errors re-exported for branching with errors.Is instead of matching messages.
+// Errors returned through the API wrap them wherever they apply.
+var (
+	// ErrValidation matches invalid configuration, options or input, rejected before any work.
+	ErrValidation = store.ErrValidation
+	// ErrStoreConflict matches writes rejected because of stored state: constraint
+	// violations, or a database busy or locked by another writer.
+	ErrStoreConflict = store.ErrConflict
+	// ErrEmbeddingDimensionMismatch matches embeddings whose length differs from the
+	// stored ones, typically after switching models without ReEmbed.
+	ErrEmbeddingDimensionMismatch = store.ErrDimensionMismatch
+	// ErrEmbeddingModelMismatch matches New refusing a database whose embeddings were
+	// made with another model than Config.EmbeddingModel (see Config.AllowEmbeddingModelChange).
+	ErrEmbeddingModelMismatch = store.ErrEmbeddingModelMismatch
+	// ErrSchemaTooNew matches databases migrated by a newer version of gognee, which New
+	// refuses to open.
+	ErrSchemaTooNew = store.ErrSchemaTooNew
+	// ErrNodeNotFound, ErrMemoryNotFound and ErrDocumentNotFound match lookups of missing records.
+	ErrNodeNotFound     = store.ErrNodeNotFound
+	ErrMemoryNotFound   = store.ErrMemoryNotFound
+	ErrDocumentNotFound = store.ErrDocumentNotFound
+	// ErrSupersessionCycle matches supersessions that would make a memory supersede itself,
+	// directly or through other memories.
+	ErrSupersessionCycle = store.ErrSupersessionCycle
+	// ErrProviderRateLimited matches LLM and embedding requests the provider throttled.
+	ErrProviderRateLimited = provider.ErrRateLimited
+	// ErrProviderUnavailable matches LLM and embedding requests the provider failed to serve.
+	ErrProviderUnavailable = provider.ErrUnavailable
+	// ErrProviderAuthentication matches LLM and embedding requests rejected for their credentials.
+	ErrProviderAuthentication = provider.ErrAuthentication
+	// ErrMalformedLLMResponse matches LLM completions that are not the requested JSON.
+	ErrMalformedLLMResponse = llm.ErrMalformedResponse
+	// ErrMalformedEmbeddingResponse matches embedding responses missing embeddings.
+	ErrMalformedEmbeddingResponse = embeddings.ErrMalformedResponse
+	// ErrCircuitOpen matches provider calls short-circuited by an open circuit breaker
+	// (Config.CircuitBreakerThreshold).
+	ErrCircuitOpen = breaker.ErrOpen
+	// ErrCancelled matches operations stopped by a canceled ctx or a passed deadline.
+	// Long-running operations that can keep partial work report OperationCancelled instead.
+	ErrCancelled = store.ErrCancelled
 )
 
 // Error type constants for classification
@@ -24,6 +72,18 @@ func ClassifyError(err error) string {
 		return ""
 	}
 
+	// Typed errors classify without inspecting the message
+	switch {
+	case errors.Is(err, ErrValidation), errors.Is(err, ErrSupersessionCycle), errors.Is(err, ErrEmbeddingModelMismatch):
+		return ErrTypeValidation
+	case errors.Is(err, ErrStoreConflict), errors.Is(err, ErrSchemaTooNew):
+		return ErrTypeDatabase
+	case errors.Is(err, ErrProviderRateLimited), errors.Is(err, ErrProviderUnavailable),
+		errors.Is(err, ErrProviderAuthentication), errors.Is(err, ErrMalformedLLMResponse),
+		errors.Is(err, ErrMalformedEmbeddingResponse):
+		return ErrTypeLLM
+	}
+
 	errStr := err.Error()
 	errStrLower := strings.ToLower(errStr)
 
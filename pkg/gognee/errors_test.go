@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"net"
 	"testing"
+
+	"github.com/dan-solli/gognee/pkg/provider"
+	"github.com/dan-solli/gognee/pkg/store"
 )
 
 func TestClassifyError_Timeout(t *testing.T) {
@@ -144,3 +147,35 @@ func TestClassifyError_NetworkOpError(t *testing.T) {
 		t.Errorf("ClassifyError(net.OpError) = %v, want %v", got, ErrTypeNetwork)
 	}
 }
+
+func TestClassifyError_Typed(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{store.Invalidf("depth must be at least 1"), ErrTypeValidation},
+		{fmt.Errorf("failed to embed: %w", provider.NewStatusError(429, errors.New("slow down"))), ErrTypeLLM},
+		{fmt.Errorf("failed to add edge: %w", ErrStoreConflict), ErrTypeDatabase},
+	}
+	for _, tt := range tests {
+		if got := ClassifyError(tt.err); got != tt.want {
+			t.Errorf("ClassifyError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestNew_ValidationErrors(t *testing.T) {
+	_, err := NewWithClients(Config{DBPath: ":memory:", MentionBoost: -1}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ErrValidation for a negative MentionBoost, got %v", err)
+	}
+
+	_, err = NewWithClients(Config{DBPath: ":memory:", EncryptionKey: []byte("short")}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ErrValidation for a 5-byte EncryptionKey, got %v", err)
+	}
+
+	if err := (SearchOptions{GraphDepth: 9}).Validate(); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ErrValidation for an excessive graph depth, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package gognee
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxEvidenceRunes caps the length of the supporting text stored on an edge
+const maxEvidenceRunes = 300
+
+// evidenceSnippet picks the passage of chunk text that supports a subject-object relation:
+// the first sentence naming both entities, else the first naming either, else the chunk start.
+// Matching is case-insensitive; the result is truncated to maxEvidenceRunes.
+func evidenceSnippet(text, subject, object string) string {
+	sentences := splitEvidenceSentences(text)
+	subj := strings.ToLower(strings.TrimSpace(subject))
+	obj := strings.ToLower(strings.TrimSpace(object))
+
+	best := ""
+	for _, sentence := range sentences {
+		lower := strings.ToLower(sentence)
+		hasSubj := subj != "" && strings.Contains(lower, subj)
+		hasObj := obj != "" && strings.Contains(lower, obj)
+		if hasSubj && hasObj {
+			best = sentence
+			break
+		}
+		if best == "" && (hasSubj || hasObj) {
+			best = sentence
+		}
+	}
+	if best == "" {
+		best = strings.TrimSpace(text)
+	}
+
+	return truncateRunes(strings.Join(strings.Fields(best), " "), maxEvidenceRunes)
+}
+
+// splitEvidenceSentences splits text on ., ! and ? followed by whitespace or end of text
+func splitEvidenceSentences(text string) []string {
+	var sentences []string
+	runes := []rune(text)
+	start := 0
+	for i, r := range runes {
+		if r != '.' && r != '!' && r != '?' {
+			continue
+		}
+		if i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+			continue
+		}
+		if sentence := strings.TrimSpace(string(runes[start : i+1])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = i + 1
+	}
+	if sentence := strings.TrimSpace(string(runes[start:])); sentence != "" {
+		sentences = append(sentences, sentence)
+	}
+	return sentences
+}
+
+// truncateRunes shortens s to at most n runes, marking the cut with an ellipsis
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,91 @@
+package gognee
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/search"
+)
+
+func TestEvidenceSnippet(t *testing.T) {
+	text := "React is popular. The frontend uses React with TypeScript! Nothing else matters."
+
+	tests := []struct {
+		name            string
+		subject, object string
+		want            string
+	}{
+		{"both entities", "react", "TypeScript", "The frontend uses React with TypeScript!"},
+		{"one entity", "Frontend", "Vue", "The frontend uses React with TypeScript!"},
+		{"no entity", "Go", "Rust", text},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := evidenceSnippet(text, tt.subject, tt.object); got != tt.want {
+				t.Errorf("evidenceSnippet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	long := strings.Repeat("word ", 200)
+	if got := evidenceSnippet(long, "word", "word"); len([]rune(got)) != maxEvidenceRunes {
+		t.Errorf("Expected snippet truncated to %d runes, got %d", maxEvidenceRunes, len([]rune(got)))
+	}
+}
+
+func TestCognify_EdgeEvidenceInSearch(t *testing.T) {
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{
+				{Name: "React", Type: "Technology", Description: "A JavaScript library"},
+				{Name: "TypeScript", Type: "Technology", Description: "A typed superset of JavaScript"},
+			},
+		},
+		RelationResponses: [][]extraction.Triplet{
+			{
+				{Subject: "React", Relation: "USES", Object: "TypeScript"},
+			},
+		},
+	}
+	mockEmbed := &MockEmbeddingClient{}
+	g.llm = mockLLM
+	g.embeddings = mockEmbed
+	g.entityExtractor = extraction.NewEntityExtractor(mockLLM)
+	g.relationExtractor = extraction.NewRelationExtractor(mockLLM)
+	g.searcher = search.NewHybridSearcher(mockEmbed, g.vectorStore, g.graphStore)
+
+	ctx := context.Background()
+	g.Add(ctx, "We like Go. Our React app uses TypeScript.", AddOptions{})
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+
+	resp, err := g.Search(ctx, "React", SearchOptions{Type: SearchTypeHybrid, IncludeEvidence: true})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) == 0 {
+		t.Fatal("Expected search results")
+	}
+
+	for _, r := range resp.Results {
+		if len(r.SupportingEdges) != 1 {
+			t.Fatalf("Expected 1 supporting edge for %s, got %d", r.NodeID, len(r.SupportingEdges))
+		}
+		edge := r.SupportingEdges[0]
+		if edge.SourceChunkID == "" {
+			t.Error("Expected edge to record its source chunk")
+		}
+		if edge.Evidence != "Our React app uses TypeScript." {
+			t.Errorf("Unexpected evidence %q", edge.Evidence)
+		}
+	}
+}
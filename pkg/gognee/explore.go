@@ -0,0 +1,144 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// NodeDetails gathers what is known about a node, for browsing the graph.
+type NodeDetails struct {
+	Node      *store.Node
+	Edges     []NodeEdge            // Incident edges, excluding expired ones
+	Memories  []store.MemorySummary // Memories the node was extracted from, most recently updated first
+	Documents []store.Document      // Documents the node was extracted from, oldest first
+}
+
+// NodeEdge is an edge incident to a NodeDetails node, with the node at its other end.
+type NodeEdge struct {
+	Edge     *store.Edge
+	Outgoing bool        // The node is the edge's source
+	Neighbor *store.Node // The other endpoint; nil if it no longer exists
+}
+
+// MemoryDetails gathers a memory with the graph it produced, for browsing the graph.
+type MemoryDetails struct {
+	Memory        *store.MemoryRecord
+	Nodes         []*store.Node              // Nodes derived from the memory
+	Edges         []*store.Edge              // Edges derived from the memory
+	Supersessions []store.SupersessionRecord // Supersession chain through the memory
+}
+
+// NodeDetails returns a node with its edges, linked memories and source documents.
+// Returns store.ErrNodeNotFound for unknown nodes and for nodes derived only from
+// memories the context's principal cannot read; such neighbors and edges are left out.
+// Listing the linked memories does not count as an access.
+func (g *Gognee) NodeDetails(ctx context.Context, nodeID string) (*NodeDetails, error) {
+	node, err := g.graphStore.GetNode(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, fmt.Errorf("%w: %s", store.ErrNodeNotFound, nodeID)
+	}
+
+	edges, err := g.graphStore.GetEdges(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	candidates := []string{nodeID}
+	edgeIDs := make([]string, len(edges))
+	for i, edge := range edges {
+		candidates = append(candidates, edge.SourceID, edge.TargetID)
+		edgeIDs[i] = edge.ID
+	}
+	restricted, err := g.memoryStore.RestrictedAmong(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+	if restricted[nodeID] {
+		return nil, fmt.Errorf("%w: %s", store.ErrNodeNotFound, nodeID)
+	}
+	restrictedEdges, err := g.memoryStore.RestrictedEdgesAmong(ctx, edgeIDs)
+	if err != nil {
+		return nil, err
+	}
+	details := &NodeDetails{Node: node}
+
+	for _, edge := range edges {
+		neighborID := edge.TargetID
+		if edge.TargetID == nodeID {
+			neighborID = edge.SourceID
+		}
+		if restricted[neighborID] || restrictedEdges[edge.ID] {
+			continue
+		}
+		neighbor, err := g.graphStore.GetNode(ctx, neighborID)
+		if err != nil {
+			return nil, err
+		}
+		details.Edges = append(details.Edges, NodeEdge{Edge: edge, Outgoing: edge.SourceID == nodeID, Neighbor: neighbor})
+	}
+
+	memoryIDs, err := g.memoryStore.GetMemoriesByNodeID(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	summaries, err := g.memoryStore.GetMemorySummariesBatched(ctx, memoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range memoryIDs {
+		if summary, ok := summaries[id]; ok {
+			details.Memories = append(details.Memories, summary)
+		}
+	}
+
+	if documents, ok := g.graphStore.(store.DocumentStore); ok {
+		if details.Documents, err = documents.DocumentsByNode(ctx, nodeID); err != nil {
+			return nil, err
+		}
+	}
+	return details, nil
+}
+
+// MemoryDetails returns a memory with the nodes and edges derived from it and its
+// supersession chain. Reading it counts as an access, like GetMemory.
+func (g *Gognee) MemoryDetails(ctx context.Context, memoryID string) (*MemoryDetails, error) {
+	memory, err := g.memoryStore.GetMemory(ctx, memoryID)
+	if err != nil {
+		return nil, err
+	}
+	details := &MemoryDetails{Memory: memory}
+
+	nodeIDs, edgeIDs, err := g.memoryStore.GetProvenanceByMemory(ctx, memoryID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range nodeIDs {
+		node, err := g.graphStore.GetNode(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			details.Nodes = append(details.Nodes, node)
+		}
+	}
+	if edges, ok := g.graphStore.(store.EdgeReader); ok {
+		for _, id := range edgeIDs {
+			edge, err := edges.GetEdge(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if edge != nil {
+				details.Edges = append(details.Edges, edge)
+			}
+		}
+	}
+
+	if details.Supersessions, err = g.memoryStore.GetSupersessionChain(ctx, memoryID); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
@@ -0,0 +1,74 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestNodeAndMemoryDetails(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{{Name: "Alice", Type: "Person", Description: "Engineer"}, {Name: "Go", Type: "Technology", Description: "Language"}},
+			{{Name: "Alice", Type: "Person", Description: "Engineer"}},
+		},
+		RelationResponses: [][]extraction.Triplet{
+			{{Subject: "Alice", Relation: "USES", Object: "Go"}},
+			{},
+		},
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	memory, err := g.AddMemory(ctx, MemoryInput{Topic: "Stack", Context: "Alice uses Go."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if err := g.Add(ctx, "Alice joined the team.", AddOptions{Source: "onboarding"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+
+	alice, err := g.NodeDetails(ctx, g.nodeID("Alice", "Person"))
+	if err != nil {
+		t.Fatalf("NodeDetails failed: %v", err)
+	}
+	if alice.Node.Name != "Alice" {
+		t.Errorf("Expected Alice, got %s", alice.Node.Name)
+	}
+	if len(alice.Edges) != 1 || !alice.Edges[0].Outgoing || alice.Edges[0].Neighbor == nil || alice.Edges[0].Neighbor.Name != "Go" {
+		t.Errorf("Expected one outgoing edge to Go, got %+v", alice.Edges)
+	}
+	if len(alice.Memories) != 1 || alice.Memories[0].ID != memory.MemoryID {
+		t.Errorf("Expected the memory, got %+v", alice.Memories)
+	}
+	if len(alice.Documents) != 1 || alice.Documents[0].Source != "onboarding" {
+		t.Errorf("Expected the onboarding document, got %+v", alice.Documents)
+	}
+
+	goDetails, err := g.NodeDetails(ctx, g.nodeID("Go", "Technology"))
+	if err != nil || len(goDetails.Edges) != 1 || goDetails.Edges[0].Outgoing {
+		t.Errorf("Expected one incoming edge on Go, got %+v (%v)", goDetails, err)
+	}
+
+	if _, err := g.NodeDetails(ctx, "missing"); !errors.Is(err, store.ErrNodeNotFound) {
+		t.Errorf("Expected ErrNodeNotFound, got %v", err)
+	}
+
+	details, err := g.MemoryDetails(ctx, memory.MemoryID)
+	if err != nil {
+		t.Fatalf("MemoryDetails failed: %v", err)
+	}
+	if details.Memory.Topic != "Stack" || len(details.Nodes) != 2 || len(details.Edges) != 1 || details.Edges[0].Relation != "USES" {
+		t.Errorf("Expected the memory with its two nodes and edge, got %+v", details)
+	}
+}
@@ -0,0 +1,164 @@
+package gognee
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// ExportFormat selects the output format for ExportGraph.
+type ExportFormat string
+
+const (
+	// ExportFormatGraphML writes GraphML (XML), readable by Gephi, yEd and networkx.
+	ExportFormatGraphML ExportFormat = "graphml"
+
+	// ExportFormatDOT writes Graphviz DOT.
+	ExportFormatDOT ExportFormat = "dot"
+)
+
+// ExportGraph writes the whole knowledge graph to w in the requested format.
+// Nodes carry their name, type and description; edges carry their relation and weight.
+// Output is streamed row by row from the store, so memory use stays flat for large graphs.
+func (g *Gognee) ExportGraph(ctx context.Context, w io.Writer, format ExportFormat) error {
+	reader, ok := g.graphStore.(store.BulkReader)
+	if !ok {
+		return fmt.Errorf("export requires a graph store implementing store.BulkReader")
+	}
+
+	bw := bufio.NewWriter(w)
+
+	var err error
+	switch format {
+	case ExportFormatGraphML:
+		err = exportGraphML(ctx, reader, bw)
+	case ExportFormatDOT:
+		err = exportDOT(ctx, reader, bw)
+	default:
+		return fmt.Errorf("invalid export format %q: must be one of: graphml, dot", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to flush export: %w", err)
+	}
+	return nil
+}
+
+// exportGraphML streams the graph as GraphML.
+func exportGraphML(ctx context.Context, s store.BulkReader, w *bufio.Writer) error {
+	header := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://graphml.graphdrawing.org/xmlns http://graphml.graphdrawing.org/xmlns/1.0/graphml.xsd">
+  <key id="name" for="node" attr.name="name" attr.type="string"/>
+  <key id="type" for="node" attr.name="type" attr.type="string"/>
+  <key id="description" for="node" attr.name="description" attr.type="string"/>
+  <key id="relation" for="edge" attr.name="relation" attr.type="string"/>
+  <key id="weight" for="edge" attr.name="weight" attr.type="double"/>
+  <graph id="gognee" edgedefault="directed">
+`
+	if _, err := w.WriteString(header); err != nil {
+		return fmt.Errorf("failed to write graphml header: %w", err)
+	}
+
+	err := s.IterateNodes(ctx, func(node *store.Node) error {
+		w.WriteString(`    <node id="`)
+		writeXMLEscaped(w, node.ID)
+		w.WriteString("\">\n")
+		writeGraphMLData(w, "name", node.Name)
+		writeGraphMLData(w, "type", node.Type)
+		writeGraphMLData(w, "description", node.Description)
+		_, err := w.WriteString("    </node>\n")
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export nodes: %w", err)
+	}
+
+	err = s.IterateEdges(ctx, func(edge *store.Edge) error {
+		w.WriteString(`    <edge id="`)
+		writeXMLEscaped(w, edge.ID)
+		w.WriteString(`" source="`)
+		writeXMLEscaped(w, edge.SourceID)
+		w.WriteString(`" target="`)
+		writeXMLEscaped(w, edge.TargetID)
+		w.WriteString("\">\n")
+		writeGraphMLData(w, "relation", edge.Relation)
+		writeGraphMLData(w, "weight", strconv.FormatFloat(edge.Weight, 'g', -1, 64))
+		_, err := w.WriteString("    </edge>\n")
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export edges: %w", err)
+	}
+
+	if _, err := w.WriteString("  </graph>\n</graphml>\n"); err != nil {
+		return fmt.Errorf("failed to write graphml footer: %w", err)
+	}
+	return nil
+}
+
+// writeGraphMLData writes a single <data> element, omitting empty values.
+func writeGraphMLData(w *bufio.Writer, key, value string) {
+	if value == "" {
+		return
+	}
+	w.WriteString(`      <data key="`)
+	w.WriteString(key)
+	w.WriteString(`">`)
+	writeXMLEscaped(w, value)
+	w.WriteString("</data>\n")
+}
+
+// writeXMLEscaped writes s with XML special characters escaped.
+func writeXMLEscaped(w *bufio.Writer, s string) {
+	// xml.EscapeText only fails if the underlying writer fails, which bufio defers to Flush
+	_ = xml.EscapeText(w, []byte(s))
+}
+
+// exportDOT streams the graph as a Graphviz digraph.
+func exportDOT(ctx context.Context, s store.BulkReader, w *bufio.Writer) error {
+	if _, err := w.WriteString("digraph gognee {\n"); err != nil {
+		return fmt.Errorf("failed to write dot header: %w", err)
+	}
+
+	err := s.IterateNodes(ctx, func(node *store.Node) error {
+		_, err := fmt.Fprintf(w, "  %s [label=%s, type=%s, description=%s];\n",
+			dotQuote(node.ID), dotQuote(node.Name), dotQuote(node.Type), dotQuote(node.Description))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export nodes: %w", err)
+	}
+
+	err = s.IterateEdges(ctx, func(edge *store.Edge) error {
+		_, err := fmt.Fprintf(w, "  %s -> %s [label=%s, weight=%s];\n",
+			dotQuote(edge.SourceID), dotQuote(edge.TargetID), dotQuote(edge.Relation),
+			strconv.FormatFloat(edge.Weight, 'g', -1, 64))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export edges: %w", err)
+	}
+
+	if _, err := w.WriteString("}\n"); err != nil {
+		return fmt.Errorf("failed to write dot footer: %w", err)
+	}
+	return nil
+}
+
+// dotQuote returns s as a double-quoted DOT ID with quotes, backslashes and newlines escaped.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\r", "")
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
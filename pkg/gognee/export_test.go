@@ -0,0 +1,138 @@
+package gognee
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// seedExportGraph adds a small two-node graph with one edge.
+func seedExportGraph(t *testing.T, g *Gognee) {
+	t.Helper()
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	nodes := []*store.Node{
+		{ID: "n1", Name: `Go "lang"`, Type: "Technology", Description: "A <compiled> language", CreatedAt: base},
+		{ID: "n2", Name: "SQLite", Type: "System", Description: "Embedded\ndatabase", CreatedAt: base.Add(time.Hour)},
+	}
+	for _, n := range nodes {
+		if err := g.graphStore.AddNode(ctx, n); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	edge := &store.Edge{ID: "e1", SourceID: "n1", Relation: "USES", TargetID: "n2", Weight: 0.5}
+	if err := g.graphStore.AddEdge(ctx, edge); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+}
+
+func TestExportGraph_GraphML(t *testing.T) {
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+	seedExportGraph(t, g)
+
+	var buf bytes.Buffer
+	if err := g.ExportGraph(context.Background(), &buf, ExportFormatGraphML); err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+
+	// Output must be well-formed XML with the expected structure
+	var doc struct {
+		Graph struct {
+			Nodes []struct {
+				ID   string `xml:"id,attr"`
+				Data []struct {
+					Key   string `xml:"key,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"data"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+				Data   []struct {
+					Key   string `xml:"key,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"data"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("GraphML is not valid XML: %v\n%s", err, buf.String())
+	}
+
+	if len(doc.Graph.Nodes) != 2 {
+		t.Fatalf("Expected 2 nodes, got %d", len(doc.Graph.Nodes))
+	}
+	data := map[string]string{}
+	for _, d := range doc.Graph.Nodes[0].Data {
+		data[d.Key] = d.Value
+	}
+	if data["name"] != `Go "lang"` || data["type"] != "Technology" || data["description"] != "A <compiled> language" {
+		t.Errorf("Unexpected node data: %v", data)
+	}
+
+	if len(doc.Graph.Edges) != 1 {
+		t.Fatalf("Expected 1 edge, got %d", len(doc.Graph.Edges))
+	}
+	e := doc.Graph.Edges[0]
+	if e.Source != "n1" || e.Target != "n2" {
+		t.Errorf("Unexpected edge endpoints: %s -> %s", e.Source, e.Target)
+	}
+	edgeData := map[string]string{}
+	for _, d := range e.Data {
+		edgeData[d.Key] = d.Value
+	}
+	if edgeData["relation"] != "USES" || edgeData["weight"] != "0.5" {
+		t.Errorf("Unexpected edge data: %v", edgeData)
+	}
+}
+
+func TestExportGraph_DOT(t *testing.T) {
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+	seedExportGraph(t, g)
+
+	var buf bytes.Buffer
+	if err := g.ExportGraph(context.Background(), &buf, ExportFormatDOT); err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "digraph gognee {\n") || !strings.HasSuffix(out, "}\n") {
+		t.Errorf("Unexpected DOT framing:\n%s", out)
+	}
+	if !strings.Contains(out, `"n1" [label="Go \"lang\"", type="Technology"`) {
+		t.Errorf("Expected escaped node label in DOT output:\n%s", out)
+	}
+	if !strings.Contains(out, `description="Embedded\ndatabase"`) {
+		t.Errorf("Expected newline to be escaped in DOT output:\n%s", out)
+	}
+	if !strings.Contains(out, `"n1" -> "n2" [label="USES", weight=0.5];`) {
+		t.Errorf("Expected edge line in DOT output:\n%s", out)
+	}
+}
+
+func TestExportGraph_InvalidFormat(t *testing.T) {
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	var buf bytes.Buffer
+	if err := g.ExportGraph(context.Background(), &buf, ExportFormat("csv")); err == nil {
+		t.Fatal("Expected error for unsupported format")
+	}
+}
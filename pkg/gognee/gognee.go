@@ -3,13 +3,16 @@ package gognee
 import (
 	"context"
 	"crypto/sha256"
-	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/dan-solli/gognee/pkg/breaker"
 	"github.com/dan-solli/gognee/pkg/chunker"
 	"github.com/dan-solli/gognee/pkg/embeddings"
 	"github.com/dan-solli/gognee/pkg/extraction"
@@ -21,6 +24,14 @@ import (
 	"github.com/google/uuid"
 )
 
+// Vector backends for Config.VectorBackend
+const (
+	// VectorBackendSQLite stores node embeddings in the SQLite database (sqlite-vec)
+	VectorBackendSQLite = "sqlite"
+	// VectorBackendQdrant stores node embeddings in a Qdrant collection
+	VectorBackendQdrant = "qdrant"
+)
+
 // Config holds configuration for the Gognee system
 type Config struct {
 	// OpenAI API key for embeddings and LLM
@@ -29,19 +40,121 @@ type Config struct {
 	// Embedding model (default: "text-embedding-3-small")
 	EmbeddingModel string
 
+	// AllowEmbeddingModelChange opens a database whose embeddings were made with another
+	// model than EmbeddingModel, so Reembed can convert them. Without it New fails with
+	// ErrEmbeddingModelMismatch, since searches would compare vectors of two models.
+	AllowEmbeddingModelChange bool
+
+	// EmbeddingBaseURL points New at a local OpenAI-compatible embedding server
+	// (llama.cpp, LM Studio, vLLM), e.g. "http://localhost:8080". Embeddings then need
+	// no OpenAI key.
+	EmbeddingBaseURL string
+
+	// EmbeddingAPIKey is sent as the bearer token to the EmbeddingBaseURL server, if
+	// it requires one. OpenAIKey is never sent there.
+	EmbeddingAPIKey string
+
+	// AzureOpenAIEndpoint points New at Azure OpenAI instead of OpenAI, e.g.
+	// "https://my-resource.openai.azure.com". OpenAIKey is then the Azure key, and
+	// EmbeddingModel and LLMModel (and ModelRouting values) name Azure deployments.
+	AzureOpenAIEndpoint string
+
+	// AzureOpenAIAPIVersion is the Azure OpenAI api-version (default: "2024-10-21")
+	AzureOpenAIAPIVersion string
+
 	// LLM model for entity extraction (default: "gpt-4o-mini")
 	LLMModel string
 
 	// Chunk size in tokens (default: 512)
 	ChunkSize int
 
-	// Chunk overlap in tokens (default: 50)
+	// Chunk overlap in tokens (default: 50). Only used by the "token" strategy.
 	ChunkOverlap int
 
+	// ChunkStrategy selects how text is split before extraction (default: "token").
+	//   - "token": overlapping windows of ChunkSize tokens, cut at sentence ends
+	//   - "semantic": paragraph and sentence boundaries, up to ChunkSize tokens, no overlap
+	//   - "markdown": headings, lists and code fences, with heading context prepended to each chunk
+	ChunkStrategy string
+
+	// ChunkBreakpointThreshold makes the "semantic" strategy also start a new chunk where
+	// the embedding similarity of consecutive sentences falls below this value
+	// (default: 0 = disabled). Costs one embedding call per sentence.
+	ChunkBreakpointThreshold float64
+
 	// DBPath is the path to the SQLite database file.
 	// If empty or ":memory:", an in-memory database is used.
+	// A leading "~" is expanded and missing parent directories are created. SQLite URI
+	// options can follow a "?", e.g. "~/app/gognee.db?cache=shared" or "data.db?mode=ro"
+	// (see store.ResolveDBPath).
 	DBPath string
 
+	// Namespace scopes every read and write (documents, nodes, edges, memories, search)
+	// to one tenant, so several projects can share a single database file.
+	// Open one Gognee instance per namespace. Default: "" (the unnamed namespace).
+	Namespace string
+
+	// VectorBackend selects where node embeddings are stored and searched (default: "sqlite").
+	//   - "sqlite": in the database at DBPath (in process memory for ":memory:")
+	//   - "qdrant": in a Qdrant collection at QdrantURL, for large deployments; the graph,
+	//     memories and edge embeddings stay in SQLite
+	VectorBackend string
+
+	// QdrantURL is the base URL of the Qdrant server, e.g. "http://localhost:6333".
+	// Required when VectorBackend is "qdrant".
+	QdrantURL string
+
+	// QdrantAPIKey is sent with every Qdrant request (default: "" = no authentication).
+	QdrantAPIKey string
+
+	// QdrantCollection is the Qdrant collection holding node embeddings (default:
+	// store.DefaultQdrantCollection). It is created on first use; namespaces can share it.
+	QdrantCollection string
+
+	// VectorStore stores and searches node embeddings in place of VectorBackend, e.g. a
+	// store.PgVectorStore sharing a Postgres pool with the application or a
+	// store.RedisVectorStore for low-latency search (default: nil).
+	// It should be scoped to Namespace; Close does not close it.
+	VectorStore store.VectorStore
+
+	// MinExtractionConfidence drops extracted entities and relations whose LLM-reported
+	// confidence is below this value before they reach the graph (default: 0 = keep all).
+	// Items without a reported confidence are always kept.
+	MinExtractionConfidence float64
+
+	// Ontology constrains extraction to caller-defined entity and relation types: they
+	// are described in the extraction prompts, and other types are coerced or rejected
+	// per Ontology.UnknownTypes (default: nil = built-in entity types, any relation).
+	Ontology *extraction.Ontology
+
+	// EntityFilter drops junk entities (pronouns, generic references, bare dates)
+	// after extraction and before relation extraction (default: nil = no filtering).
+	// Use extraction.NewEntityFilter() for the built-in stoplist and validators.
+	EntityFilter *extraction.EntityFilter
+
+	// Guardrail rejects or sanitizes extracted entities and relations that carry
+	// prompt-injection artifacts (instructions, URLs, oversized fields) before they reach
+	// the graph, and flags the source document (default: nil = no validation).
+	// Use extraction.NewGuardrail() for the built-in limits.
+	Guardrail *extraction.Guardrail
+
+	// Redactor removes personal data and secrets (emails, tokens, keys) from documents and
+	// memories before they are chunked, extracted or stored, and from extracted entities
+	// and relations before they reach the graph (default: nil = no redaction).
+	// Use extraction.NewRegexRedactor() for the built-in patterns.
+	Redactor extraction.Redactor
+
+	// QuarantineFlagged quarantines the nodes derived from documents and memories flagged
+	// by Guardrail: they are excluded from search (unless SearchOptions.IncludeQuarantined)
+	// until released with ReleaseQuarantine. A flagged document only quarantines the nodes
+	// it added; nodes already in the graph stay searchable. Requires Guardrail.
+	QuarantineFlagged bool
+
+	// SoftDelete makes DeleteMemory move memories to the trash (status "Deleted") instead
+	// of removing them, so accidental deletes can be undone with RestoreMemory.
+	// Trashed memories keep their graph nodes until PurgeDeleted removes them.
+	SoftDelete bool
+
 	// DecayEnabled enables time-based memory decay scoring (default: false)
 	DecayEnabled bool
 
@@ -59,47 +172,194 @@ type Config struct {
 	// ReferenceAccessCount is the access count at which heat_multiplier = 1.0 (default: 10)
 	// Memories with this many accesses get full heat protection from decay
 	ReferenceAccessCount int
+
+	// DecayPolicy selects the decay curve (exponential, power-law or step) and overrides
+	// the half-life of nodes by type and of memories by retention policy. It applies to
+	// search decay, Prune's MinDecayScore and memory scores (MemoryRecord.CurrentScore)
+	// (default: nil = exponential, DecayHalfLifeDays for every node type and the built-in
+	// retention policy half-lives).
+	DecayPolicy *store.DecayPolicy
+
+	// StrictAccessTracking makes Search fail when recording node or memory access fails.
+	// By default such failures are counted (Stats.AccessTrackingFailures), logged at WARN
+	// and the search succeeds. Intended for tests.
+	StrictAccessTracking bool
+
+	// AccessFlushInterval and AccessFlushSize batch the access times recorded when nodes
+	// are read with GetNode: they are written once the interval has passed since the first
+	// unwritten read or once that many nodes are waiting (defaults: 5s and 256; a negative
+	// interval writes them only when the buffer fills, on Flush and on Close). Access
+	// recorded by Search is written immediately.
+	AccessFlushInterval time.Duration
+	AccessFlushSize     int
+
+	// MentionBoost ranks frequently mentioned entities higher in search results: a node's score
+	// is multiplied by up to (1 + MentionBoost) depending on how many cognified chunks mention it
+	// (default: 0 = disabled). Mention counts are tracked regardless and exposed as Node.MentionCount.
+	MentionBoost float64
+
+	// ReferenceMentionCount is the mention count at which the full MentionBoost applies (default: 10)
+	ReferenceMentionCount int
+
+	// RelationSynonyms maps relation labels to a canonical label applied when edges are created,
+	// e.g. {"USING": "USES", "UTILIZES": "USES"}. Keys match case-insensitively with spaces
+	// treated as underscores. Merges recorded by CollapseRelations are also applied.
+	RelationSynonyms map[string]string
+
+	// RelationTTLs gives edges with the listed relation labels an expiry time, for short-lived
+	// facts such as {"ON_CALL": 24 * time.Hour}. Keys match like RelationSynonyms.
+	// Expired edges are excluded from search and removed by RunMaintenance.
+	RelationTTLs map[string]time.Duration
+
+	// MaintenanceInterval runs RunMaintenance in the background at this interval (default: 0 = disabled).
+	// The scheduler stops when Close is called. Requires a file-backed DBPath, since each
+	// connection to an in-memory database sees a separate database.
+	MaintenanceInterval time.Duration
+
+	// EdgeEmbeddings embeds each new edge's text ("Alice USES Go") into a separate edge
+	// vector index, enabling SearchEdges and seeding graph searches that have no
+	// SeedNodeIDs (default: false). Costs one embedding per edge.
+	EdgeEmbeddings bool
+
+	// ChunkEmbeddings stores the text and embedding of each chunk Cognify writes, enabling
+	// SearchChunks and SearchOptions.ChunkTopK, which return the supporting text alongside
+	// the nodes (default: false). Costs one embedding per chunk and the chunk text on disk.
+	ChunkEmbeddings bool
+
+	// EntityResolution merges entity nodes that name the same thing ("PostgreSQL",
+	// "Postgres") at the end of each Cognify and in each RunMaintenance pass, using
+	// ResolveEntities with this method: "exact", "embedding" or "llm"
+	// (default: "" = disabled; ResolveEntities can still be called on demand).
+	EntityResolution string
+
+	// EntityResolutionThreshold is the embedding similarity used by EntityResolution
+	// (default: 0.9 for "embedding", 0.75 for "llm").
+	EntityResolutionThreshold float64
+
+	// DescriptionMerge is how an entity's stored description is combined with the one a
+	// later mention gives it: "append" adds the new sentences, "llm" has the LLM rewrite
+	// both into one, and "replace" keeps only the newest (default: "append"). Merged
+	// descriptions are kept in the node's history (see GetNodeDescriptions).
+	DescriptionMerge string
+
+	// EnrichmentBatchSize makes each RunMaintenance pass enrich up to this many
+	// quick-added notes with EnrichNotes (default: 0 = notes are only enriched on demand).
+	// This bounds the LLM calls spent per interval.
+	EnrichmentBatchSize int
+
+	// ModelRouting selects the LLM model used by each pipeline stage, e.g. a cheap model
+	// for StageEntityExtraction and a stronger one for StageRelationExtraction.
+	// Stages not listed use LLMModel. Requires an LLM client implementing
+	// llm.ModelSelector (the OpenAI and Ollama clients do). Usage per stage is
+	// reported by LLMUsage.
+	ModelRouting map[string]string
+
+	// ConflictDetection makes AddMemory look for current memories on the same topic as the
+	// new one: "report" returns them in MemoryResult.ConflictCandidates so the caller can
+	// decide, "supersede" also marks them superseded by the new memory
+	// (default: "" = disabled; supersession stays manual via MemoryInput.Supersedes).
+	ConflictDetection string
+
+	// ConflictThreshold is the topic embedding similarity at which two memories
+	// conflict (default: 0.85).
+	ConflictThreshold float64
+
+	// Reranker rescores search results when SearchOptions.Rerank is set, e.g.
+	// search.NewHTTPReranker for a local cross-encoder (default: nil = the LLM of
+	// StageRerank rates the candidates).
+	Reranker search.Reranker
+
+	// LLMCache stores every LLM response in the database, keyed by a hash of model and
+	// prompt, so re-running Cognify on unchanged documents or retrying a failed batch
+	// replays earlier extraction results instead of calling the LLM again. Cache hits
+	// are not counted by LLMUsage. Clear entries with store.SQLiteLLMCache.Clear.
+	LLMCache bool
+
+	// CircuitBreakerThreshold wraps the LLM and embedding clients in circuit breakers
+	// that open after this many consecutive outages of a provider (5xx, 429 or an
+	// unreachable endpoint; other errors are not counted), so calls fail fast
+	// with breaker.ErrOpen instead of waiting on a dead endpoint (default: 0 = disabled).
+	// Cognify keeps documents hit by an open circuit buffered and unmarked, so the next
+	// Cognify retries them (CognifyResult.DocumentsDeferred).
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is how long an open circuit rejects calls before letting a
+	// trial call through (default: 30s).
+	CircuitBreakerCooldown time.Duration
+
+	// LLMTimeout and EmbeddingTimeout bound each request New's OpenAI clients make, so a
+	// single slow call fails (and is retried) instead of stalling a whole batch (default:
+	// 0 = the HTTP client's limits only). CognifyOptions and search.SearchOptions override
+	// them per call. Clients passed to NewWithClients keep their own Timeout.
+	LLMTimeout       time.Duration
+	EmbeddingTimeout time.Duration
+
+	// Outbox records every mutation of nodes, edges, memories and their links, tags and
+	// supersessions in an outbox table, for consumers mirroring the knowledge base into
+	// other systems (see ReadOutbox and AckOutbox). Recording stays on in the database
+	// until DisableOutbox is called (default: false).
+	Outbox bool
+
+	// EncryptionKey encrypts memory context and decisions (with their revisions), node
+	// descriptions, edge evidence, chunk text and LLM cache entries with AES-GCM before
+	// they reach the database (default: nil = plaintext). It must be 16, 24 or 32 bytes; keep it outside
+	// the database. Plaintext written earlier stays readable. Encrypted descriptions and
+	// evidence are not keyword-searchable or matchable in graph queries.
+	EncryptionKey []byte
 }
 
 // Gognee is the main entry point for the memory system
 type Gognee struct {
 	config            Config
 	chunker           *chunker.Chunker
+	textChunker       chunker.TextChunker // Chunker selected by Config.ChunkStrategy
 	embeddings        embeddings.EmbeddingClient
 	llm               llm.LLMClient
+	stageLLMs         map[string]llm.LLMClient    // Routed, metered client per pipeline stage
+	stageMeters       map[string]*llm.UsageMeter  // LLM usage per pipeline stage
+	breakers          map[string]*breaker.Breaker // Circuit breaker per provider (Config.CircuitBreakerThreshold)
 	graphStore        store.GraphStore
 	vectorStore       store.VectorStore
+	edgeVectorStore   store.VectorStore // Edge embeddings (Config.EdgeEmbeddings)
 	memoryStore       *store.SQLiteMemoryStore
 	searcher          search.Searcher
+	queryExpander     search.QueryExpander // Applies SearchOptions.Expansion
+	reranker          search.Reranker      // Applies SearchOptions.Rerank
 	entityExtractor   *extraction.EntityExtractor
 	relationExtractor *extraction.RelationExtractor
+	descriptionMerger extraction.DescriptionMerger // Merges repeated entity descriptions (Config.DescriptionMerge)
 	buffer            []AddedDocument
+	bufferDuplicates  int // Documents Add dropped as already buffered, reported by the next Cognify
 	lastCognified     time.Time
-	metricsCollector  metrics.Collector // Optional metrics collector
-	traceExporter     tracepkg.Exporter // Optional trace exporter (Plan 016 M4)
-	logger            *slog.Logger      // Optional structured logger (Plan 023 M2)
+	accessFailures    atomic.Int64             // Failures to record access, reported by Stats
+	metricsCollector  metrics.Collector        // Optional metrics collector
+	traceExporter     tracepkg.Exporter        // Optional trace exporter (Plan 016 M4)
+	logger            *slog.Logger             // Optional structured logger (Plan 023 M2)
+	relationSynonyms  map[string]string        // Normalized relation label -> canonical label
+	relationMu        sync.RWMutex             // Guards relationSynonyms
+	relationTTLs      map[string]time.Duration // Normalized relation label -> edge lifetime
+	aliasMu           sync.RWMutex             // Guards entityAliases and nodeAliases
+	entityAliases     map[string]string        // Alias name|type -> canonical entity name
+	nodeAliases       map[string][]string      // Canonical node ID -> names merged into it
+	maintenanceStop   chan struct{}            // Closed to stop the maintenance scheduler
+	maintenanceDone   chan struct{}            // Closed when the maintenance scheduler exits
 }
 
 // RetentionPolicyDef defines the parameters for a retention policy (M6: Plan 021)
-type RetentionPolicyDef struct {
-	HalfLifeDays int  // Decay half-life in days (0 = no decay for permanent)
-	Prunable     bool // Whether memories with this policy can be pruned
-}
+type RetentionPolicyDef = store.RetentionPolicyDef
 
-// RetentionPolicies defines all available retention policies (M6: Plan 021)
-var RetentionPolicies = map[string]RetentionPolicyDef{
-	"permanent": {HalfLifeDays: 0, Prunable: false},  // Never decays, never pruned
-	"decision":  {HalfLifeDays: 365, Prunable: true}, // 1 year half-life, prunable only when superseded
-	"standard":  {HalfLifeDays: 90, Prunable: true},  // 3 months half-life (default)
-	"ephemeral": {HalfLifeDays: 7, Prunable: true},   // 1 week half-life
-	"session":   {HalfLifeDays: 1, Prunable: true},   // 1 day half-life
-}
+// RetentionPolicies defines all available retention policies (M6: Plan 021). It is the
+// table memories are scored with (store.RetentionPolicies).
+var RetentionPolicies = store.RetentionPolicies
 
 // AddedDocument represents a document added to the buffer for processing
 type AddedDocument struct {
 	Text    string
 	Source  string
 	AddedAt time.Time
+	Hash    string // SHA-256 of Text, the document's identity for incremental Cognify
+
+	queueID string // ID in the store's ingestion queue, if any
 }
 
 // AddOptions configures the Add() method
@@ -124,24 +384,56 @@ type CognifyOptions struct {
 	// Default: false (off by default to minimize overhead).
 	// When enabled, timing spans are collected and returned in CognifyResult.Trace.
 	TraceEnabled bool
+
+	// Concurrency is the number of chunks of a document extracted and embedded in
+	// parallel (default: 0 = one at a time). Graph writes stay sequential in chunk order,
+	// so results do not depend on it. The LLM and embedding clients must be safe for
+	// concurrent use, as the built-in clients are.
+	Concurrency int
+
+	// Atomic writes each document in one transaction: its nodes, edges, vector entries,
+	// mention counts, processed record and provenance are committed together once every chunk has
+	// been extracted, or not at all. A hard error (a failed extraction or embedding call,
+	// or a failed write) rolls the document back instead of leaving it partially written;
+	// it is counted in CognifyResult.DocumentsRolledBack and can be added again. Skipped
+	// edges are not hard errors. Requires a graph store implementing store.AtomicWriter
+	// (the SQLite store does); vectors kept outside the database are indexed after commit.
+	Atomic bool
+
+	// LLMTimeout and EmbeddingTimeout bound each extraction and embedding request of this
+	// run, overriding Config.LLMTimeout and Config.EmbeddingTimeout (default: 0 = keep the
+	// clients' timeouts). Chunks whose requests time out are counted in ChunksFailed.
+	LLMTimeout       time.Duration
+	EmbeddingTimeout time.Duration
 }
 
 // CognifyResult reports the outcome of a Cognify() operation
 type CognifyResult struct {
-	DocumentsProcessed int // Documents actually processed (chunked + extracted)
-	DocumentsSkipped   int // Documents skipped due to incremental caching
-	ChunksProcessed    int
-	ChunksFailed       int
-	NodesCreated       int
-	EdgesCreated       int
-	EdgesSkipped       int             // Count of edges skipped due to entity lookup failure or ambiguity
-	Errors             []error         // Includes details of skipped edges ("skipped edge" in message)
-	Trace              *OperationTrace // Timing data (populated when CognifyOptions.TraceEnabled is true)
+	DocumentsProcessed   int // Documents actually processed (chunked + extracted)
+	DocumentsSkipped     int // Documents skipped due to incremental caching
+	DocumentsDeferred    int // Documents left buffered for retry: a provider's circuit was open or ctx was canceled
+	DocumentsRolledBack  int // Documents left unwritten after a hard error (CognifyOptions.Atomic)
+	ChunksProcessed      int
+	ChunksFailed         int
+	ChunksResumed        int // Chunks skipped because an interrupted Cognify had already written them
+	NodesCreated         int
+	EdgesCreated         int
+	EdgesSkipped         int               // Count of edges skipped due to entity lookup failure or ambiguity
+	EntitiesFiltered     int               // Entities dropped for confidence below Config.MinExtractionConfidence
+	RelationsFiltered    int               // Relations dropped for confidence below Config.MinExtractionConfidence
+	JunkEntitiesFiltered int               // Entities dropped by Config.EntityFilter
+	EntitiesMerged       int               // Alias nodes merged by Config.EntityResolution
+	GuardrailRejected    int               // Entities and relations rejected by Config.Guardrail
+	FlaggedDocuments     []FlaggedDocument // Documents whose extraction output tripped Config.Guardrail
+	Errors               []error           // Includes details of skipped edges ("skipped edge" in message)
+	Trace                *OperationTrace   // Timing data (populated when CognifyOptions.TraceEnabled is true)
+	Status               OperationStatus   // OperationCancelled when ctx ended Cognify with documents deferred
 }
 
 // SearchResponse wraps search results with optional timing trace
 type SearchResponse struct {
 	Results []search.SearchResult // The search results
+	Chunks  []store.ChunkMatch    // Chunks matching the query (populated when SearchOptions.ChunkTopK is set)
 	Trace   *OperationTrace       // Timing data (populated when SearchOptions.TraceEnabled is true)
 }
 
@@ -150,8 +442,26 @@ type Stats struct {
 	NodeCount     int64
 	EdgeCount     int64
 	MemoryCount   int64
-	BufferedDocs  int
+	BufferedDocs  int // Documents added and not yet cognified, including those restored from the ingestion queue
 	LastCognified time.Time
+
+	// AccessTrackingFailures counts failures to record node or memory access during
+	// Search since this instance was created.
+	AccessTrackingFailures int64
+
+	// Breakdowns for dashboards. The node, edge and size fields are only filled for graph
+	// stores implementing store.GraphStatistics, the housekeeping fields for those
+	// implementing store.Housekeeper; they are left zero otherwise.
+	MemoriesByStatus          map[string]int64 // Including soft-deleted memories
+	MemoriesByRetentionPolicy map[string]int64 // Excluding soft-deleted memories
+	PinnedMemories            int64
+	AverageMemoryScore        float64 // Mean decay score of the memories (see store.MemoryScore)
+	NodesByType               map[string]int64
+	EdgesByRelation           map[string]int64
+	DatabaseSizeBytes         int64     // Shared by all namespaces of the database
+	LastGC                    time.Time // Last garbage collection after a memory update or delete; zero if never
+	LastPrune                 time.Time // Last Prune that was not a dry run; zero if never
+	LastMaintenance           time.Time // Last RunMaintenance pass; zero if never
 }
 
 // PruneOptions configures the Prune() method
@@ -168,16 +478,24 @@ type PruneOptions struct {
 	// DryRun reports what would be pruned without actually deleting.
 	DryRun bool
 
-	// PruneSuperseded enables pruning of Superseded memories (M5: Plan 021, default: true)
-	PruneSuperseded bool
+	// PruneSuperseded enables pruning of Superseded memories (M5: Plan 021, default: true).
+	// Use pointer to distinguish unset from explicit false.
+	PruneSuperseded *bool
 
 	// SupersededAgeDays only prunes Superseded memories older than this (M5: Plan 021, default: 30)
 	SupersededAgeDays int
+
+	// BatchSize is the number of nodes deleted per transaction when the graph store
+	// selects and deletes nodes itself (store.NodePruner, default: 500).
+	BatchSize int
+
+	// Progress, if set, is called after each batch of nodes is deleted.
+	Progress func(PruneProgress)
 }
 
 // PruneResult reports the outcome of a Prune() operation
 type PruneResult struct {
-	NodesEvaluated int      // Total number of nodes considered
+	NodesEvaluated int      // Nodes considered; with the SQLite store, only those old enough to prune
 	NodesPruned    int      // Number of nodes deleted
 	EdgesPruned    int      // Number of edges deleted (via cascade)
 	NodeIDs        []string // IDs of pruned nodes (for verification)
@@ -185,21 +503,43 @@ type PruneResult struct {
 	SupersededMemoriesPruned int
 	// MemoriesEvaluated is the total number of memories considered for pruning (M5: Plan 021)
 	MemoriesEvaluated int
+	// Status is OperationCancelled when ctx ended the prune early; the counts and NodeIDs
+	// then cover only what was evaluated and deleted before it stopped.
+	Status OperationStatus
 }
 
 // New creates a new Gognee instance using OpenAI clients
 func New(cfg Config) (*Gognee, error) {
 	// Initialize embeddings client
-	embeddingsClient := embeddings.NewOpenAIClient(cfg.OpenAIKey)
-	if cfg.EmbeddingModel != "" {
-		embeddingsClient.Model = cfg.EmbeddingModel
+	var embeddingsClient embeddings.EmbeddingClient
+	if cfg.EmbeddingBaseURL != "" {
+		localClient := embeddings.NewLocalClient(cfg.EmbeddingBaseURL, cfg.EmbeddingModel)
+		localClient.APIKey = cfg.EmbeddingAPIKey
+		localClient.Timeout = cfg.EmbeddingTimeout
+		embeddingsClient = localClient
+	} else {
+		openAIClient := embeddings.NewOpenAIClient(cfg.OpenAIKey)
+		if cfg.AzureOpenAIEndpoint != "" {
+			openAIClient = embeddings.NewAzureOpenAIClient(cfg.AzureOpenAIEndpoint, openAIClient.Model, cfg.AzureOpenAIAPIVersion, cfg.OpenAIKey)
+		}
+		if cfg.EmbeddingModel != "" {
+			openAIClient.Model = cfg.EmbeddingModel
+		}
+		cfg.EmbeddingModel = openAIClient.Model
+		openAIClient.Timeout = cfg.EmbeddingTimeout
+		embeddingsClient = openAIClient
 	}
 
 	// Initialize LLM client
 	llmClient := llm.NewOpenAILLM(cfg.OpenAIKey)
+	if cfg.AzureOpenAIEndpoint != "" {
+		llmClient = llm.NewAzureOpenAILLM(cfg.AzureOpenAIEndpoint, llmClient.Model, cfg.AzureOpenAIAPIVersion, cfg.OpenAIKey)
+	}
 	if cfg.LLMModel != "" {
 		llmClient.Model = cfg.LLMModel
 	}
+	cfg.LLMModel = llmClient.Model
+	llmClient.Timeout = cfg.LLMTimeout
 
 	return NewWithClients(cfg, embeddingsClient, llmClient)
 }
@@ -214,6 +554,18 @@ func NewWithClients(cfg Config, embClient embeddings.EmbeddingClient, llmClient
 	if cfg.ChunkOverlap == 0 {
 		cfg.ChunkOverlap = 50
 	}
+	if cfg.ChunkStrategy == "" {
+		cfg.ChunkStrategy = chunker.StrategyToken
+	}
+	switch cfg.ChunkStrategy {
+	case chunker.StrategyToken, chunker.StrategySemantic, chunker.StrategyMarkdown:
+	default:
+		return nil, store.Invalidf("ChunkStrategy must be %q, %q or %q, got %q",
+			chunker.StrategyToken, chunker.StrategySemantic, chunker.StrategyMarkdown, cfg.ChunkStrategy)
+	}
+	if cfg.ChunkBreakpointThreshold < 0 || cfg.ChunkBreakpointThreshold > 1 {
+		return nil, store.Invalidf("ChunkBreakpointThreshold must be between 0 and 1, got %v", cfg.ChunkBreakpointThreshold)
+	}
 	if cfg.DecayBasis == "" {
 		cfg.DecayBasis = "access"
 	}
@@ -230,54 +582,213 @@ func NewWithClients(cfg Config, embClient embeddings.EmbeddingClient, llmClient
 		cfg.ReferenceAccessCount = 10
 	}
 
+	if cfg.MentionBoost < 0 {
+		return nil, store.Invalidf("MentionBoost must not be negative, got %v", cfg.MentionBoost)
+	}
+	if cfg.ReferenceMentionCount == 0 {
+		cfg.ReferenceMentionCount = 10
+	}
+
+	if cfg.EntityResolution != "" {
+		if err := validateResolutionMethod(cfg.EntityResolution); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.EntityResolutionThreshold < 0 || cfg.EntityResolutionThreshold > 1 {
+		return nil, store.Invalidf("EntityResolutionThreshold must be between 0 and 1, got %v", cfg.EntityResolutionThreshold)
+	}
+	if cfg.DescriptionMerge == "" {
+		cfg.DescriptionMerge = DescriptionMergeAppend
+	}
+	switch cfg.DescriptionMerge {
+	case DescriptionMergeAppend, DescriptionMergeLLM, DescriptionMergeReplace:
+	default:
+		return nil, store.Invalidf("DescriptionMerge must be %q, %q or %q, got %q",
+			DescriptionMergeAppend, DescriptionMergeLLM, DescriptionMergeReplace, cfg.DescriptionMerge)
+	}
+
+	if cfg.QuarantineFlagged && cfg.Guardrail == nil {
+		return nil, store.Invalidf("QuarantineFlagged requires Guardrail")
+	}
+
+	if cfg.EnrichmentBatchSize < 0 {
+		return nil, store.Invalidf("EnrichmentBatchSize must not be negative, got %d", cfg.EnrichmentBatchSize)
+	}
+
+	if cfg.ConflictDetection != "" {
+		if err := validateConflictDetection(cfg.ConflictDetection); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.ConflictThreshold < 0 || cfg.ConflictThreshold > 1 {
+		return nil, store.Invalidf("ConflictThreshold must be between 0 and 1, got %v", cfg.ConflictThreshold)
+	}
+
+	switch cfg.VectorBackend {
+	case "", VectorBackendSQLite:
+	case VectorBackendQdrant:
+		if cfg.QdrantURL == "" {
+			return nil, store.Invalidf("VectorBackend %q requires QdrantURL", VectorBackendQdrant)
+		}
+	default:
+		return nil, store.Invalidf("VectorBackend must be %q or %q, got %q", VectorBackendSQLite, VectorBackendQdrant, cfg.VectorBackend)
+	}
+
+	stageLLMs, stageMeters, err := buildStageClients(llmClient, cfg.ModelRouting)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.LLMTimeout < 0 || cfg.EmbeddingTimeout < 0 {
+		return nil, store.Invalidf("LLMTimeout and EmbeddingTimeout must not be negative, got %v and %v", cfg.LLMTimeout, cfg.EmbeddingTimeout)
+	}
+
+	if cfg.AccessFlushSize < 0 {
+		return nil, store.Invalidf("AccessFlushSize must be non-negative, got %d", cfg.AccessFlushSize)
+	}
+	if cfg.CircuitBreakerThreshold < 0 {
+		return nil, store.Invalidf("CircuitBreakerThreshold must be non-negative, got %d", cfg.CircuitBreakerThreshold)
+	}
+	breakers := make(map[string]*breaker.Breaker)
+	if cfg.CircuitBreakerThreshold > 0 {
+		breakerCfg := breaker.Config{FailureThreshold: cfg.CircuitBreakerThreshold, Cooldown: cfg.CircuitBreakerCooldown}
+		breakers[ProviderLLM] = breaker.New(ProviderLLM, breakerCfg)
+		breakers[ProviderEmbeddings] = breaker.New(ProviderEmbeddings, breakerCfg)
+		// One breaker for all stages: they share the provider. Short-circuited calls
+		// never reach the meters.
+		for stage, client := range stageLLMs {
+			stageLLMs[stage] = llm.NewBreakerClient(client, breakers[ProviderLLM])
+		}
+		embClient = embeddings.NewBreakerClient(embClient, breakers[ProviderEmbeddings])
+	}
+
+	if cfg.MinExtractionConfidence < 0 || cfg.MinExtractionConfidence > 1 {
+		return nil, store.Invalidf("MinExtractionConfidence must be between 0 and 1, got %v", cfg.MinExtractionConfidence)
+	}
+	if err := cfg.Ontology.Validate(); err != nil {
+		return nil, store.Invalidf("invalid Ontology: %w", err)
+	}
+
 	// Validate decay configuration (before applying half-life default)
 	if cfg.DecayEnabled {
 		if cfg.DecayHalfLifeDays < 0 {
-			return nil, fmt.Errorf("DecayHalfLifeDays must be positive, got %d", cfg.DecayHalfLifeDays)
+			return nil, store.Invalidf("DecayHalfLifeDays must be positive, got %d", cfg.DecayHalfLifeDays)
 		}
 		if cfg.DecayBasis != "access" && cfg.DecayBasis != "creation" {
-			return nil, fmt.Errorf("DecayBasis must be 'access' or 'creation', got %q", cfg.DecayBasis)
+			return nil, store.Invalidf("DecayBasis must be 'access' or 'creation', got %q", cfg.DecayBasis)
 		}
 	}
+	if err := cfg.DecayPolicy.Validate(); err != nil {
+		return nil, store.Invalidf("invalid DecayPolicy: %w", err)
+	}
 
 	// Apply half-life default after validation
 	if cfg.DecayHalfLifeDays == 0 {
 		cfg.DecayHalfLifeDays = 30
 	}
 
-	// Initialize GraphStore
-	dbPath := cfg.DBPath
-	if dbPath == "" {
-		dbPath = ":memory:"
+	var fieldCipher *store.FieldCipher
+	if cfg.EncryptionKey != nil {
+		if fieldCipher, err = store.NewFieldCipher(cfg.EncryptionKey); err != nil {
+			return nil, err
+		}
 	}
-	graphStore, err := store.NewSQLiteGraphStore(dbPath)
+
+	// Initialize GraphStore
+	graphStore, err := store.NewSQLiteGraphStore(cfg.DBPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize graph store: %w", err)
 	}
+	graphStore.WithNamespace(cfg.Namespace).WithEncryption(fieldCipher)
+	graphStore.SetAccessFlush(cfg.AccessFlushInterval, cfg.AccessFlushSize)
+
+	if cfg.Outbox {
+		if err := graphStore.EnableOutbox(context.Background()); err != nil {
+			graphStore.Close()
+			return nil, fmt.Errorf("failed to enable outbox: %w", err)
+		}
+	}
+
+	// Record the embedding model of a new database, and refuse one embedded with another
+	// model: switching models needs Reembed. Recording is best effort, since a read-only
+	// database cannot record it.
+	if cfg.EmbeddingModel != "" {
+		if registry, err := graphStore.EmbeddingConfig(context.Background()); err == nil {
+			switch {
+			case registry.Model == "":
+				_ = graphStore.SetEmbeddingModel(context.Background(), cfg.EmbeddingModel)
+			case registry.Model != cfg.EmbeddingModel && !cfg.AllowEmbeddingModelChange:
+				graphStore.Close()
+				return nil, &store.ModelMismatchError{Stored: registry.Model, Configured: cfg.EmbeddingModel}
+			}
+		}
+	}
+
+	// Cache outside the meters, so usage reflects the calls actually made.
+	// The cache key includes the stage's model, so routing changes miss the cache.
+	if cfg.LLMCache {
+		cache := store.NewSQLiteLLMCache(graphStore.DB()).WithEncryption(fieldCipher)
+		for stage, client := range stageLLMs {
+			model := cfg.LLMModel
+			if routed, ok := cfg.ModelRouting[stage]; ok {
+				model = routed
+			}
+			stageLLMs[stage] = llm.NewCachedClient(client, model, cache)
+		}
+	}
 
 	// Initialize VectorStore
 	// Use SQLiteVectorStore for persistent databases, MemoryVectorStore for :memory:
-	var vectorStore store.VectorStore
-	if dbPath == ":memory:" {
+	var vectorStore, edgeVectorStore store.VectorStore
+	if store.IsInMemoryDSN(cfg.DBPath) {
 		vectorStore = store.NewMemoryVectorStore()
+		edgeVectorStore = store.NewMemoryVectorStore()
 	} else {
 		// Share the database connection from GraphStore
-		vectorStore = store.NewSQLiteVectorStore(graphStore.DB())
+		vectorStore = store.NewSQLiteVectorStore(graphStore.DB()).WithNamespace(cfg.Namespace)
+		edgeVectorStore = store.NewSQLiteEdgeVectorStore(graphStore.DB()).WithNamespace(cfg.Namespace)
+	}
+	if cfg.VectorStore != nil {
+		vectorStore = cfg.VectorStore
+	} else if cfg.VectorBackend == VectorBackendQdrant {
+		vectorStore = store.NewQdrantVectorStore(cfg.QdrantURL, cfg.QdrantCollection).
+			WithAPIKey(cfg.QdrantAPIKey).
+			WithNamespace(cfg.Namespace)
 	}
 
 	// Initialize extractors
-	entityExtractor := extraction.NewEntityExtractor(llmClient)
-	relationExtractor := extraction.NewRelationExtractor(llmClient)
+	entityExtractor := extraction.NewEntityExtractor(stageLLMs[StageEntityExtraction])
+	relationExtractor := extraction.NewRelationExtractor(stageLLMs[StageRelationExtraction])
+	entityExtractor.Ontology = cfg.Ontology
+	relationExtractor.Ontology = cfg.Ontology
+	var descriptionMerger extraction.DescriptionMerger = extraction.AppendDescriptions{}
+	if cfg.DescriptionMerge == DescriptionMergeLLM {
+		descriptionMerger = &extraction.LLMDescriptionMerger{LLM: stageLLMs[StageDescriptionMerge]}
+	}
 
 	// Initialize searcher
-	baseSearcher := search.NewHybridSearcher(embClient, vectorStore, graphStore)
+	queryExpander := search.NewLLMQueryExpander(stageLLMs[StageQueryExpansion])
+	router := &searchRouter{
+		hybrid:  search.NewHybridSearcher(embClient, vectorStore, graphStore),
+		keyword: search.NewKeywordSearcher(graphStore),
+	}
+	router.SetQueryExpander(queryExpander)
+	var baseSearcher search.Searcher = router
+	if cfg.MentionBoost > 0 {
+		baseSearcher = search.NewMentionBoostSearcher(baseSearcher, cfg.MentionBoost, cfg.ReferenceMentionCount)
+	}
+	// Search reranks the final candidates itself, once, rather than on every hidden-node refill
+	reranker := cfg.Reranker
+	if reranker == nil {
+		reranker = search.NewLLMReranker(stageLLMs[StageRerank])
+	}
 
 	// Wrap with DecayingSearcher if decay is enabled
 	var searcher search.Searcher
 	if cfg.DecayEnabled {
 		// Initialize MemoryStore early for DecayingSearcher (M2: Plan 021)
-		memoryStore := store.NewSQLiteMemoryStore(graphStore.DB())
-		searcher = search.NewDecayingSearcher(
+		memoryStore := store.NewSQLiteMemoryStore(graphStore.DB()).WithNamespace(cfg.Namespace).WithEncryption(fieldCipher)
+		decayingSearcher := search.NewDecayingSearcher(
 			baseSearcher,
 			graphStore,
 			memoryStore,
@@ -287,36 +798,90 @@ func NewWithClients(cfg Config, embClient embeddings.EmbeddingClient, llmClient
 			cfg.AccessFrequencyEnabled,
 			cfg.ReferenceAccessCount,
 		)
+		decayingSearcher.SetDecayPolicy(cfg.DecayPolicy)
+		searcher = decayingSearcher
 	} else {
 		searcher = baseSearcher
 	}
 
 	// Initialize MemoryStore (shares DB connection with GraphStore)
 	// Note: If decay is enabled, this is a second instance; consider refactoring if needed
-	memoryStore := store.NewSQLiteMemoryStore(graphStore.DB())
+	memoryStore := store.NewSQLiteMemoryStore(graphStore.DB()).WithNamespace(cfg.Namespace).WithDecayPolicy(cfg.DecayPolicy).
+		WithEncryption(fieldCipher)
+
+	// Load relation merges from earlier CollapseRelations runs
+	relationMerges, err := graphStore.ListRelationMerges(context.Background())
+	if err != nil {
+		graphStore.Close()
+		return nil, fmt.Errorf("failed to load relation merges: %w", err)
+	}
+
+	// Load entity merges from earlier ResolveEntities runs
+	entityMerges, err := graphStore.ListEntityMerges(context.Background())
+	if err != nil {
+		graphStore.Close()
+		return nil, fmt.Errorf("failed to load entity merges: %w", err)
+	}
 
 	// Initialize chunker
 	c := &chunker.Chunker{
 		MaxTokens: cfg.ChunkSize,
 		Overlap:   cfg.ChunkOverlap,
 	}
+	var textChunker chunker.TextChunker = c
+	switch cfg.ChunkStrategy {
+	case chunker.StrategySemantic:
+		textChunker = &chunker.SemanticChunker{
+			MaxTokens:           cfg.ChunkSize,
+			Embeddings:          embClient,
+			BreakpointThreshold: cfg.ChunkBreakpointThreshold,
+		}
+	case chunker.StrategyMarkdown:
+		textChunker = &chunker.MarkdownChunker{MaxTokens: cfg.ChunkSize}
+	}
 
-	return &Gognee{
+	relationTTLs := make(map[string]time.Duration, len(cfg.RelationTTLs))
+	for rel, ttl := range cfg.RelationTTLs {
+		if ttl <= 0 {
+			graphStore.Close()
+			return nil, store.Invalidf("RelationTTLs[%q] must be positive, got %v", rel, ttl)
+		}
+		relationTTLs[sanitizeRelation(rel)] = ttl
+	}
+
+	g := &Gognee{
 		config:            cfg,
 		chunker:           c,
+		textChunker:       textChunker,
 		embeddings:        embClient,
 		llm:               llmClient,
+		stageLLMs:         stageLLMs,
+		stageMeters:       stageMeters,
+		breakers:          breakers,
 		graphStore:        graphStore,
 		vectorStore:       vectorStore,
+		edgeVectorStore:   edgeVectorStore,
 		memoryStore:       memoryStore,
 		searcher:          searcher,
+		queryExpander:     queryExpander,
+		reranker:          reranker,
 		entityExtractor:   entityExtractor,
 		relationExtractor: relationExtractor,
+		descriptionMerger: descriptionMerger,
 		buffer:            make([]AddedDocument, 0),
 		lastCognified:     time.Time{},
 		metricsCollector:  nil, // Set via WithMetricsCollector
 		traceExporter:     nil, // Set via WithTraceExporter (Plan 016 M4)
-	}, nil
+		relationSynonyms:  buildRelationSynonyms(relationMerges, cfg.RelationSynonyms),
+		relationTTLs:      relationTTLs,
+	}
+	g.buildEntityAliases(entityMerges)
+
+	if cfg.MaintenanceInterval > 0 {
+		g.startMaintenance(cfg.MaintenanceInterval)
+	}
+
+	return g, nil
 }
 
 // WithMetricsCollector sets the metrics collector for this Gognee instance
@@ -333,10 +898,10 @@ func (g *Gognee) WithTraceExporter(exporter tracepkg.Exporter) *Gognee {
 
 // WithLogger sets the structured logger for this Gognee instance (Plan 023 M2).
 // When nil, logging is disabled (zero overhead).
-// Propagates logger to DecayingSearcher if present.
+// Propagates logger to the graph and memory stores, the entity extractor and the searchers.
 func (g *Gognee) WithLogger(logger *slog.Logger) *Gognee {
 	g.logger = logger
-	
+
 	// Log decay configuration at startup (M4)
 	if g.logger != nil {
 		g.logger.LogAttrs(context.Background(), slog.LevelInfo, "decay config initialized",
@@ -347,16 +912,22 @@ func (g *Gognee) WithLogger(logger *slog.Logger) *Gognee {
 			slog.Int("reference_access_count", g.config.ReferenceAccessCount),
 		)
 	}
-	
-	// M8: Propagate to DecayingSearcher
-	if ds, ok := g.searcher.(*search.DecayingSearcher); ok {
-		ds.SetLogger(logger)
+
+	// Propagate to the stores and the searcher chain (which passes it on to the searchers it wraps)
+	if setter, ok := g.graphStore.(search.LoggerSetter); ok {
+		setter.SetLogger(logger)
+	}
+	g.memoryStore.SetLogger(logger)
+	g.entityExtractor.SetLogger(logger)
+	if setter, ok := g.searcher.(search.LoggerSetter); ok {
+		setter.SetLogger(logger)
 	}
-	
+
 	return g
 }
 
-// GetChunker returns the configured chunker
+// GetChunker returns the token chunker configured by ChunkSize and ChunkOverlap.
+// Pipelines use the chunker selected by Config.ChunkStrategy, which may differ.
 func (g *Gognee) GetChunker() *chunker.Chunker {
 	return g.chunker
 }
@@ -449,11 +1020,44 @@ func (g *Gognee) Add(ctx context.Context, text string, opts AddOptions) error {
 		return fmt.Errorf("text cannot be empty")
 	}
 
+	// Redact before the text is hashed, queued or chunked
+	text, err := g.redact(ctx, text)
+	if err != nil {
+		return err
+	}
+	if opts.Source, err = g.redact(ctx, opts.Source); err != nil {
+		return err
+	}
+
 	doc := AddedDocument{
 		Text:    text,
 		Source:  opts.Source,
 		AddedAt: time.Now(),
+		Hash:    computeDocumentHash(text),
 	}
+
+	// Identical text already waiting for Cognify would only repeat its extraction
+	for _, buffered := range g.buffer {
+		if buffered.Hash == doc.Hash {
+			g.bufferDuplicates++
+			return nil
+		}
+	}
+
+	// Persist the buffer so ResumeCognify can recover it after a crash
+	if queue, ok := g.graphStore.(store.IngestionQueue); ok {
+		doc.queueID = uuid.New().String()
+		err := queue.EnqueueDocument(ctx, store.PendingDocument{
+			ID:      doc.queueID,
+			Text:    doc.Text,
+			Source:  doc.Source,
+			AddedAt: doc.AddedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	g.buffer = append(g.buffer, doc)
 	return nil
 }
@@ -470,7 +1074,9 @@ func (g *Gognee) Cognify(ctx context.Context, opts CognifyOptions) (*CognifyResu
 
 	result := &CognifyResult{
 		Errors: make([]error, 0),
+		Status: OperationCompleted,
 	}
+	ctx = withCallTimeouts(ctx, opts.LLMTimeout, opts.EmbeddingTimeout)
 
 	// Initialize trace if enabled
 	var trace *OperationTrace
@@ -479,6 +1085,10 @@ func (g *Gognee) Cognify(ctx context.Context, opts CognifyOptions) (*CognifyResu
 		result.Trace = trace
 	}
 
+	// Documents dropped by Add as duplicates count as skipped
+	result.DocumentsSkipped += g.bufferDuplicates
+	g.bufferDuplicates = 0
+
 	// No-op if buffer is empty
 	if len(g.buffer) == 0 {
 		return result, nil
@@ -494,10 +1104,28 @@ func (g *Gognee) Cognify(ctx context.Context, opts CognifyOptions) (*CognifyResu
 	// If not available, incremental mode is disabled
 	tracker, _ := g.graphStore.(store.DocumentTracker)
 
+	atomicWriter, _ := g.graphStore.(store.AtomicWriter)
+	if opts.Atomic && atomicWriter == nil {
+		return nil, fmt.Errorf("atomic cognify requires a graph store implementing store.AtomicWriter")
+	}
+
 	// Process each document
+	var retry []AddedDocument
+	var writtenNodeIDs []string // Entity resolution compares only these with the graph
 	for _, doc := range g.buffer {
+		// Once ctx is canceled, keep the remaining documents for the next Cognify
+		if ctx.Err() != nil {
+			result.DocumentsDeferred++
+			result.Status = OperationCancelled
+			retry = append(retry, doc)
+			continue
+		}
+
 		// Compute document hash for identity
-		hash := computeDocumentHash(doc.Text)
+		hash := doc.Hash
+		if hash == "" {
+			hash = computeDocumentHash(doc.Text)
+		}
 
 		// Check if document is already processed (incremental mode)
 		// Only if tracker is available and incremental mode is enabled
@@ -509,135 +1137,172 @@ func (g *Gognee) Cognify(ctx context.Context, opts CognifyOptions) (*CognifyResu
 
 			if processed {
 				result.DocumentsSkipped++
+				g.dequeueDocument(ctx, doc, result)
 				continue // Skip this document
 			}
 		}
 
 		// Track chunks for this document
 		docChunkCount := 0
+		deferred := false // A provider's circuit opened or ctx was canceled; retry the document next Cognify
+		var guardrailReport extraction.GuardrailReport
+		var docNodeIDs []string
+		var createdNodeIDs []string // Nodes the document added to the graph, quarantined if it is flagged
+		var staged *stagedDocument  // Writes held for one transaction (CognifyOptions.Atomic)
+		rolledBack := false
+		if opts.Atomic {
+			staged = g.stageDocument()
+		}
 		result.DocumentsProcessed++
 
 		// Chunk the text
 		chunkTimer := newSpanTimer("chunk", trace, opts.TraceEnabled)
-		chunks := g.chunker.Chunk(doc.Text)
+		chunks := g.textChunker.Chunk(doc.Text)
 		chunkTimer.finish(true, nil, map[string]int64{"chunkCount": int64(len(chunks))})
 
-		// Process each chunk
-		for _, chunk := range chunks {
+		// Skip the chunks an interrupted Cognify already wrote (see ResumeCognify)
+		queue, _ := g.graphStore.(store.IngestionQueue)
+		if queue != nil && doc.queueID != "" {
+			done, err := queue.CompletedChunks(ctx, doc.queueID)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to load completed chunks: %w", err))
+			}
+			remaining := chunks[:0:0]
+			for _, chunk := range chunks {
+				if done[chunk.ID] {
+					result.ChunksResumed++
+					docChunkCount++
+					continue
+				}
+				remaining = append(remaining, chunk)
+			}
+			chunks = remaining
+		}
+
+		// Extract chunks in parallel (CognifyOptions.Concurrency), write them in order
+		extractor := g.extractChunks(ctx, chunks, opts.Concurrency, opts.TraceEnabled)
+		for i, chunk := range chunks {
+			// An interrupted document is retried from its first unwritten chunk
+			if ctx.Err() != nil {
+				deferred = true
+				break
+			}
 			result.ChunksProcessed++
 			docChunkCount++
 
-			// Extract entities
-			extractTimer := newSpanTimer("extract", trace, opts.TraceEnabled)
-			entities, err := g.entityExtractor.Extract(ctx, chunk.Text)
-			if err != nil {
-				extractTimer.finish(false, err, nil)
-				result.ChunksFailed++
-				result.Errors = append(result.Errors, fmt.Errorf("entity extraction failed for chunk %s: %w", chunk.ID, err))
+			ex := extractor.get(i)
+			trace.merge(ex.trace)
+			result.ChunksFailed += ex.failures
+			result.Errors = append(result.Errors, ex.errors...)
+			result.EntitiesFiltered += ex.entitiesFiltered
+			result.RelationsFiltered += ex.relationsFiltered
+			result.JunkEntitiesFiltered += ex.junkFiltered
+			guardrailReport.Merge(ex.guardrail)
+			deferred = deferred || ex.deferred
+			if staged != nil && ex.failures > 0 {
+				// Any failed stage is a hard error for an atomic document
+				rolledBack = !deferred
+				break
+			}
+			if ex.entitiesFailed {
+				if ex.deferred {
+					break
+				}
 				continue
 			}
+			entities, triplets, renames := ex.entities, ex.triplets, ex.renames
 
 			// Build entity name->type lookup map before processing triplets
 			entityMap, ambiguous := buildEntityTypeMap(entities)
 
-			// Extract relations
-			triplets, err := g.relationExtractor.Extract(ctx, chunk.Text, entities)
-			if err != nil {
-				extractTimer.finish(false, err, nil)
-				result.ChunksFailed++
-				result.Errors = append(result.Errors, fmt.Errorf("relation extraction failed for chunk %s: %w", chunk.ID, err))
-				// Continue with entities only if relations fail
-			} else {
-				extractTimer.finish(true, nil, map[string]int64{
-					"entityCount":   int64(len(entities)),
-					"relationCount": int64(len(triplets)),
-				})
-			}
-
-			// Create nodes for each entity
 			graphWriteTimer := newSpanTimer("write-graph", trace, opts.TraceEnabled)
-			embedTimer := newSpanTimer("embed", trace, opts.TraceEnabled)
 			vectorWriteTimer := newSpanTimer("write-vector", trace, opts.TraceEnabled)
 
-			// Collect all entity texts for batch embedding (Plan 019: M1)
-			var textsToEmbed []string
-			var entityIndices []int
+			// Create nodes with their embeddings (Plan 019: M3), written in one batch
+			nodes := make([]*store.Node, len(entities))
 			for i, entity := range entities {
-				text := strings.TrimSpace(entity.Name + " " + entity.Description)
-				if text != "" {
-					textsToEmbed = append(textsToEmbed, text)
-					entityIndices = append(entityIndices, i)
-				}
-			}
-
-			// Batch embed all entities in single API call (Plan 019: M2)
-			var embeddings [][]float32
-			var embedErr error
-			if len(textsToEmbed) > 0 {
-				embeddings, embedErr = g.embeddings.Embed(ctx, textsToEmbed)
-				if embedErr != nil {
-					embedTimer.finish(false, embedErr, nil)
-					result.ChunksFailed++
-					result.Errors = append(result.Errors, fmt.Errorf("batch embedding failed for chunk %s: %w", chunk.ID, embedErr))
-					// Continue without embeddings - nodes will be created but not indexed
-				} else {
-					embedTimer.finish(true, nil, map[string]int64{"embeddingCount": int64(len(embeddings))})
-				}
-			}
-
-			// Create nodes and assign embeddings (Plan 019: M3)
-			nodesAdded := 0
-			for i, entity := range entities {
-				nodeID := generateDeterministicNodeID(entity.Name, entity.Type)
-				node := &store.Node{
+				nodeID := g.nodeID(entity.Name, entity.Type)
+				nodes[i] = &store.Node{
 					ID:          nodeID,
 					Name:        entity.Name,
 					Type:        entity.Type,
 					Description: entity.Description,
 					CreatedAt:   time.Now(),
-					Metadata:    make(map[string]interface{}),
+					Metadata:    g.entityMetadata(nodeID),
+				}
+			}
+			for j, entityIdx := range ex.entityIndices {
+				if j < len(ex.embeddings) {
+					nodes[entityIdx].Embedding = ex.embeddings[j]
 				}
+			}
 
-				// Add to graph store
-				if err := g.graphStore.AddNode(ctx, node); err != nil {
-					result.Errors = append(result.Errors, fmt.Errorf("failed to add node %s: %w", entity.Name, err))
-					continue
+			var created map[string]bool
+			if g.config.QuarantineFlagged {
+				var err error
+				if created, err = g.unstoredNodes(ctx, nodes); err != nil {
+					result.Errors = append(result.Errors, err)
+				}
+			}
+
+			var nodesAdded int
+			var addedNodes []*store.Node
+			if staged != nil {
+				merges, mergeErrs := g.mergeDescriptions(ctx, nodes, staged.write.Nodes)
+				result.Errors = append(result.Errors, mergeErrs...)
+				staged.descriptionMerges = append(staged.descriptionMerges, merges...)
+				staged.addNodes(nodes)
+				nodesAdded = len(nodes)
+				for _, node := range nodes {
+					docNodeIDs = append(docNodeIDs, node.ID)
+					if created[node.ID] {
+						createdNodeIDs = append(createdNodeIDs, node.ID)
+					}
+				}
+			} else {
+				merges, mergeErrs := g.mergeDescriptions(ctx, nodes, nil)
+				result.Errors = append(result.Errors, mergeErrs...)
+				var nodeErrs []error
+				addedNodes, nodeErrs = g.addNodes(ctx, g.withoutEmbeddings(nodes))
+				result.Errors = append(result.Errors, nodeErrs...)
+				if err := g.recordDescriptionMerges(ctx, writtenMerges(merges, addedNodes)); err != nil {
+					result.Errors = append(result.Errors, err)
 				}
-				result.NodesCreated++
-				nodesAdded++
-
-				// Find embedding for this entity from batch results
-				var embedding []float32
-				if embedErr == nil {
-					// Find index in entityIndices that corresponds to this entity
-					for j, entityIdx := range entityIndices {
-						if entityIdx == i && j < len(embeddings) {
-							embedding = embeddings[j]
-							break
-						}
+				nodesAdded = len(addedNodes)
+				result.NodesCreated += nodesAdded
+				added := make(map[string]bool, len(addedNodes))
+				for _, node := range addedNodes {
+					docNodeIDs = append(docNodeIDs, node.ID)
+					if created[node.ID] {
+						createdNodeIDs = append(createdNodeIDs, node.ID)
 					}
+					added[node.ID] = true
 				}
 
-				// Update node with embedding if available
-				if embedding != nil {
-					node.Embedding = embedding
-					if err := g.graphStore.AddNode(ctx, node); err != nil {
-						result.Errors = append(result.Errors, fmt.Errorf("failed to update node embedding %s: %w", entity.Name, err))
+				// Index in vector store, which writes each embedding once
+				for _, node := range nodes {
+					if !added[node.ID] || node.Embedding == nil {
 						continue
 					}
-
-					// Index in vector store
-					if err := g.vectorStore.Add(ctx, nodeID, embedding); err != nil {
-						result.Errors = append(result.Errors, fmt.Errorf("failed to index node %s in vector store: %w", entity.Name, err))
+					if err := g.indexNodeEmbedding(ctx, node.ID, node.Embedding); err != nil {
+						result.Errors = append(result.Errors, fmt.Errorf("failed to index node %s in vector store: %w", node.Name, err))
 					}
 				}
 			}
 
 			vectorWriteTimer.finish(true, nil, map[string]int64{"nodeUpserts": int64(nodesAdded)})
+			if staged == nil {
+				g.recordMentions(ctx, entities)
+			}
 
-			// Create edges for each triplet
-			edgesAdded := 0
+			// Create edges for each triplet, written in one batch
+			var edges []*store.Edge
+			edgeTriplets := make(map[*store.Edge]extraction.Triplet)
 			for _, triplet := range triplets {
+				// Map synonymous relation labels to their canonical form
+				triplet.Relation = g.canonicalRelation(triplet.Relation)
+				triplet.Subject, triplet.Object = renames.apply(triplet.Subject), renames.apply(triplet.Object)
+
 				// Look up source entity type
 				sourceType, sourceFound := lookupEntityType(triplet.Subject, entityMap, ambiguous)
 				if !sourceFound {
@@ -667,43 +1332,154 @@ func (g *Gognee) Cognify(ctx context.Context, opts CognifyOptions) (*CognifyResu
 				}
 
 				// Generate edge IDs using correct entity types (FIX: was using empty string)
-				sourceID := generateDeterministicNodeID(triplet.Subject, sourceType)
-				targetID := generateDeterministicNodeID(triplet.Object, targetType)
+				sourceID := g.nodeID(triplet.Subject, sourceType)
+				targetID := g.nodeID(triplet.Object, targetType)
 
 				edge := &store.Edge{
-					ID:        fmt.Sprintf("%s-%s-%s", sourceID, sanitizeRelation(triplet.Relation), targetID),
+					ID:        derivedEdgeID(sourceID, triplet, targetID),
 					SourceID:  sourceID,
 					Relation:  triplet.Relation,
 					TargetID:  targetID,
 					Weight:    1.0,
 					CreatedAt: time.Now(),
 				}
+				edge.ExpiresAt = g.relationExpiry(edge.Relation, edge.CreatedAt)
+				edge.SourceChunkID = chunk.ID
+				edge.Evidence = evidenceSnippet(chunk.Text, triplet.Subject, triplet.Object)
+				edge.ValidFrom, edge.ValidTo = triplet.ValidityPeriod()
 
-				if err := g.graphStore.AddEdge(ctx, edge); err != nil {
-					result.Errors = append(result.Errors, fmt.Errorf("failed to add edge %s-%s-%s: %w", triplet.Subject, triplet.Relation, triplet.Object, err))
-					continue
+				edges = append(edges, edge)
+				edgeTriplets[edge] = triplet
+			}
+
+			var edgesAdded int
+			var addedEdges []*store.Edge
+			if staged != nil {
+				var newEdges edgeBatch
+				for _, edge := range edges {
+					triplet := edgeTriplets[edge]
+					newEdges.add(edge, triplet.Subject, triplet.Object)
+				}
+				embeddings, err := g.embedEdges(ctx, &newEdges)
+				if err != nil {
+					graphWriteTimer.finish(false, err, nil)
+					result.ChunksFailed++
+					result.Errors = append(result.Errors, err)
+					deferred = errors.Is(err, breaker.ErrOpen)
+					rolledBack = !deferred
+					break
+				}
+				staged.addEdges(edges, embeddings)
+				edgesAdded = len(edges)
+			} else {
+				var edgeErrs []error
+				addedEdges, edgeErrs = g.addEdges(ctx, edges)
+				result.Errors = append(result.Errors, edgeErrs...)
+				edgesAdded = len(addedEdges)
+				result.EdgesCreated += edgesAdded
+				var newEdges edgeBatch
+				for _, edge := range addedEdges {
+					triplet := edgeTriplets[edge]
+					newEdges.add(edge, triplet.Subject, triplet.Object)
+				}
+				if err := g.indexEdges(ctx, &newEdges); err != nil {
+					result.Errors = append(result.Errors, err)
 				}
-				result.EdgesCreated++
-				edgesAdded++
 			}
 
 			graphWriteTimer.finish(true, nil, map[string]int64{
 				"nodeUpserts": int64(nodesAdded),
 				"edgeUpserts": int64(edgesAdded),
 			})
+
+			// Link the chunk's output to its document
+			if staged != nil {
+				staged.addChunk(chunk)
+			} else {
+				g.linkChunk(ctx, doc, hash, chunk, addedNodes, addedEdges, result)
+				g.indexChunks(ctx, doc, hash, []chunker.Chunk{chunk}, result)
+			}
+
+			if queue != nil && doc.queueID != "" && staged == nil {
+				if err := queue.MarkChunkDone(ctx, doc.queueID, chunk.ID); err != nil {
+					result.Errors = append(result.Errors, err)
+				}
+			}
+		}
+		extractor.stop()
+		writtenNodeIDs = append(writtenNodeIDs, docNodeIDs...)
+
+		// Keep documents cut short by an open circuit or cancellation for the next Cognify.
+		// Chunks already written are skipped on retry if the store keeps an ingestion queue.
+		if deferred {
+			result.DocumentsDeferred++
+			result.Status = operationStatus(ctx)
+			retry = append(retry, doc)
+			continue
+		}
+
+		// Commit an atomic document in one transaction, or drop all of its writes
+		if staged != nil && !rolledBack {
+			staged.write.Hash, staged.write.Source, staged.write.AddedAt = hash, doc.Source, doc.AddedAt
+			staged.write.ChunkCount = docChunkCount
+			if err := g.commitDocument(ctx, atomicWriter, staged, result); err != nil {
+				result.Errors = append(result.Errors, err)
+				rolledBack = true
+			} else {
+				g.indexChunks(ctx, doc, hash, staged.chunks, result)
+				if err := g.recordDescriptionMerges(ctx, staged.descriptionMerges); err != nil {
+					result.Errors = append(result.Errors, err)
+				}
+			}
+		}
+		if rolledBack {
+			result.DocumentsRolledBack++
+			g.dequeueDocument(ctx, doc, result)
+			continue
 		}
 
-		// Mark document as processed after successful processing (if tracker available)
-		if tracker != nil {
+		if guardrailReport.Flagged() {
+			result.GuardrailRejected += guardrailReport.Rejected
+			flagged := FlaggedDocument{
+				Source:     doc.Source,
+				Hash:       hash,
+				Violations: guardrailReport.Violations,
+			}
+			g.logGuardrail(ctx, "document", hash, guardrailReport)
+			if g.config.QuarantineFlagged {
+				id, err := g.quarantine(ctx, QuarantineDocument, hash, doc.Source, guardrailReport, createdNodeIDs)
+				if err != nil {
+					result.Errors = append(result.Errors, fmt.Errorf("failed to quarantine document: %w", err))
+				}
+				flagged.QuarantineID = id
+			}
+			result.FlaggedDocuments = append(result.FlaggedDocuments, flagged)
+		}
+
+		// Mark document as processed after successful processing (if tracker available).
+		// An atomic document was marked in its transaction.
+		if tracker != nil && staged == nil {
 			if err := tracker.MarkDocumentProcessed(ctx, hash, doc.Source, docChunkCount); err != nil {
 				// Log but don't fail - tracking failure shouldn't break Cognify
 				result.Errors = append(result.Errors, fmt.Errorf("failed to mark document as processed: %w", err))
 			}
 		}
+		g.dequeueDocument(ctx, doc, result)
 	}
 
-	// Always clear buffer after processing (best-effort semantics)
-	g.buffer = make([]AddedDocument, 0)
+	// Merge aliases of the entities just extracted into their canonical nodes
+	if g.config.EntityResolution != "" && result.NodesCreated > 0 {
+		resolved, err := g.ResolveEntities(ctx, ResolveEntitiesOptions{NodeIDs: writtenNodeIDs})
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("entity resolution failed: %w", err))
+		} else {
+			result.EntitiesMerged = len(resolved.Merges)
+		}
+	}
+
+	// Always clear buffer after processing (best-effort semantics), except for
+	// documents deferred by an open circuit
+	g.buffer = append(make([]AddedDocument, 0), retry...)
 	g.lastCognified = time.Now()
 
 	// Record metrics if collector is available
@@ -748,6 +1524,7 @@ func (g *Gognee) Search(ctx context.Context, query string, opts search.SearchOpt
 	startTime := time.Now()
 	operationID := uuid.New().String() // Generate operation ID for trace correlation
 	search.ApplyDefaults(&opts)
+	ctx = withCallTimeouts(ctx, opts.LLMTimeout, opts.EmbeddingTimeout)
 
 	// Initialize trace if enabled
 	var trace *OperationTrace
@@ -757,13 +1534,83 @@ func (g *Gognee) Search(ctx context.Context, query string, opts search.SearchOpt
 		searchTimer = newSpanTimer("search-vector", trace, true)
 	}
 
-	// Apply default for IncludeMemoryIDs (true by default)
+	// Apply default for IncludeMemoryIDs (true by default, and needed by IncludeMemories)
 	includeMemoryIDs := true
-	if opts.IncludeMemoryIDs != nil {
+	if opts.IncludeMemoryIDs != nil && !opts.IncludeMemories {
 		includeMemoryIDs = *opts.IncludeMemoryIDs
 	}
 
-	results, err := g.searcher.Search(ctx, query, opts)
+	// Seed graph searches from the closest relationships when the caller gives no seeds
+	if opts.Type == search.SearchTypeGraph && len(opts.SeedNodeIDs) == 0 && g.config.EdgeEmbeddings {
+		opts.SeedNodeIDs = g.edgeSeedNodes(ctx, query, opts.TopK)
+	}
+
+	// Restrict to nodes derived from memories carrying all requested tags, and from the
+	// requested sources
+	var allowed map[string]bool
+	if len(opts.Tags) > 0 {
+		var err error
+		if allowed, err = g.memoryStore.TaggedNodeIDs(ctx, opts.Tags); err != nil {
+			return nil, err
+		}
+	}
+	if len(opts.Sources) > 0 {
+		sourced, err := g.sourceNodeIDs(ctx, opts.Sources)
+		if err != nil {
+			return nil, err
+		}
+		allowed = intersectNodeIDs(allowed, sourced)
+	}
+	searchOpts := opts
+	wanted := opts.TopK
+	if opts.Rerank {
+		// Retrieve the rerank candidates; they are reranked once the hidden nodes are gone
+		wanted = search.RerankCandidateCount(opts)
+		searchOpts.TopK, searchOpts.Rerank = wanted, false
+	}
+	if allowed != nil {
+		searchOpts.TopK *= tagSearchOverfetch
+	}
+
+	// Embed (and expand) the query once, for the first search and every refill
+	var results []search.SearchResult
+	var err error
+	if opts.Type != search.SearchTypeKeyword && searchOpts.QueryEmbedding == nil {
+		searchOpts.QueryEmbedding, err = search.EmbedQuery(ctx, g.embeddings, g.queryExpander, query, opts.Expansion)
+	}
+
+	// Drop hidden nodes, searching again with a larger window while they leave results short
+	for refills := 0; err == nil; refills++ {
+		if results, err = g.searcher.Search(ctx, query, searchOpts); err != nil {
+			break
+		}
+		fetched := len(results)
+		if allowed != nil {
+			results = onlyAllowed(results, allowed, fetched)
+		}
+		var hidden map[string]bool
+		if hidden, err = g.hiddenNodes(ctx, results, opts); err != nil {
+			break
+		}
+		results = withoutHidden(results, hidden, wanted)
+		if len(results) >= wanted || len(hidden) == 0 || fetched < searchOpts.TopK || refills == maxHiddenRefills {
+			break
+		}
+		searchOpts.TopK *= hiddenRefillFactor
+	}
+	if err == nil && opts.Rerank {
+		results, err = search.Rerank(ctx, g.reranker, query, results, opts)
+	} else if err == nil && !opts.Diversify {
+		// Decay leaves results in retrieval order; diversified results keep MMR's order
+		search.SortResults(results)
+	}
+	if err == nil {
+		results = search.ApplyMinScore(results, opts.MinScore, opts.MinResults)
+	}
+	var chunks []store.ChunkMatch
+	if err == nil && opts.ChunkTopK > 0 {
+		chunks, err = g.searchChunks(ctx, query, opts.ChunkTopK, opts.Sources)
+	}
 	if err != nil {
 		if searchTimer != nil {
 			searchTimer.finish(false, err, nil)
@@ -794,10 +1641,11 @@ func (g *Gognee) Search(ctx context.Context, query string, opts search.SearchOpt
 			nodeIDs[i] = result.NodeID
 		}
 
-		// Cast to SQLiteGraphStore to access UpdateAccessTime
-		if sqlStore, ok := g.graphStore.(*store.SQLiteGraphStore); ok {
-			// Best-effort update - don't fail search if access tracking fails
-			_ = sqlStore.UpdateAccessTime(ctx, nodeIDs)
+		// Record access for decay reinforcement on stores that support it
+		if tracker, ok := g.graphStore.(store.AccessTracker); ok {
+			if err := g.accessTrackingFailed(ctx, "node", len(nodeIDs), tracker.UpdateAccessTime(ctx, nodeIDs)); err != nil {
+				return nil, err
+			}
 		}
 
 		// Enrich with memory provenance (batched query, no N+1)
@@ -823,13 +1671,25 @@ func (g *Gognee) Search(ctx context.Context, query string, opts search.SearchOpt
 					allMemoryIDs = append(allMemoryIDs, memIDs...)
 				}
 				if len(allMemoryIDs) > 0 {
-					// Best-effort update - don't fail search if access tracking fails
-					_ = g.memoryStore.BatchUpdateMemoryAccess(ctx, allMemoryIDs)
+					if err := g.accessTrackingFailed(ctx, "memory", len(allMemoryIDs), g.memoryStore.BatchUpdateMemoryAccess(ctx, allMemoryIDs)); err != nil {
+						return nil, err
+					}
+				}
+
+				if opts.IncludeMemories {
+					g.attachMemories(ctx, results, opts.MaxMemoriesPerResult)
 				}
 			}
 		}
 	}
 
+	// Attach the source text behind each result's edges
+	if opts.IncludeEvidence {
+		if err := g.attachEvidence(ctx, results, opts); err != nil {
+			return nil, err
+		}
+	}
+
 	// Record success metrics
 	if g.metricsCollector != nil {
 		durationMs := time.Since(startTime).Milliseconds()
@@ -852,16 +1712,116 @@ func (g *Gognee) Search(ctx context.Context, query string, opts search.SearchOpt
 
 	return &SearchResponse{
 		Results: results,
+		Chunks:  chunks,
 		Trace:   trace,
 	}, nil
 }
 
+// attachEvidence fills SearchResult.SupportingEdges with each result's edges that carry
+// evidence (valid at opts.AsOf, if set). Edges leading to nodes search hides, and edges
+// derived only from memories the caller's principal cannot read, are left out.
+func (g *Gognee) attachEvidence(ctx context.Context, results []search.SearchResult, opts SearchOptions) error {
+	incident, err := g.visibleIncidentEdges(ctx, results, opts, func(edge *store.Edge) bool { return edge.Evidence != "" })
+	if err != nil {
+		return err
+	}
+	for i := range results {
+		results[i].SupportingEdges = incident[i]
+	}
+	return nil
+}
+
+// visibleIncidentEdges returns, for each result, its edges that keep accepts and that are
+// valid at opts.AsOf, if set. Edges leading to nodes search hides, and edges derived only
+// from memories the caller's principal cannot read, are left out.
+func (g *Gognee) visibleIncidentEdges(ctx context.Context, results []search.SearchResult, opts SearchOptions, keep func(*store.Edge) bool) ([][]*store.Edge, error) {
+	incident := make([][]*store.Edge, len(results))
+	var edgeIDs, neighborIDs []string
+	for i := range results {
+		edges, err := g.graphStore.GetEdges(ctx, results[i].NodeID)
+		if err != nil {
+			continue // Best-effort enrichment
+		}
+		for _, edge := range edges {
+			if !keep(edge) || (!opts.AsOf.IsZero() && !edge.ValidAt(opts.AsOf)) {
+				continue
+			}
+			incident[i] = append(incident[i], edge)
+			edgeIDs = append(edgeIDs, edge.ID)
+			neighborIDs = append(neighborIDs, edge.SourceID, edge.TargetID)
+		}
+	}
+
+	hidden, err := g.hiddenAmong(ctx, neighborIDs, opts)
+	if err != nil {
+		return nil, err
+	}
+	restricted, err := g.memoryStore.RestrictedEdgesAmong(ctx, edgeIDs)
+	if err != nil {
+		return nil, err
+	}
+	visible := make([][]*store.Edge, len(results))
+	for i := range results {
+		visible[i] = make([]*store.Edge, 0, len(incident[i]))
+		for _, edge := range incident[i] {
+			if !hidden[edge.SourceID] && !hidden[edge.TargetID] && !restricted[edge.ID] {
+				visible[i] = append(visible[i], edge)
+			}
+		}
+	}
+	return visible, nil
+}
+
+// attachMemories fills SearchResult.Memories with summaries of the maxPerResult
+// (default: 3) highest-scoring of each result's MemoryIDs (see store.MemoryScore),
+// fetched in one batched query. Best-effort: on error, results are left without memories.
+func (g *Gognee) attachMemories(ctx context.Context, results []search.SearchResult, maxPerResult int) {
+	if maxPerResult <= 0 {
+		maxPerResult = 3
+	}
+	var ids []string
+	seen := make(map[string]bool)
+	for _, result := range results {
+		for _, id := range result.MemoryIDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	summaries, err := g.memoryStore.GetMemorySummariesBatched(ctx, ids)
+	if err != nil {
+		return
+	}
+	for i := range results {
+		results[i].Memories = []store.MemorySummary{}
+		for _, id := range results[i].MemoryIDs {
+			if summary, ok := summaries[id]; ok {
+				results[i].Memories = append(results[i].Memories, summary)
+			}
+		}
+		store.SortByScore(results[i].Memories)
+		results[i].Memories = results[i].Memories[:min(len(results[i].Memories), maxPerResult)]
+	}
+}
+
 // Close releases all resources
 func (g *Gognee) Close() error {
+	g.stopMaintenance()
 	g.buffer = make([]AddedDocument, 0)
 	return g.graphStore.Close()
 }
 
+// Flush writes the buffered access times of nodes read since the last write (see
+// Config.AccessFlushInterval), so decay and Stats see them right away. Close flushes too.
+func (g *Gognee) Flush(ctx context.Context) error {
+	if flusher, ok := g.graphStore.(store.AccessFlusher); ok {
+		return flusher.FlushAccessTimes(ctx)
+	}
+	return nil
+}
+
 // Stats returns basic telemetry
 func (g *Gognee) Stats() (Stats, error) {
 	ctx := context.Background()
@@ -880,13 +1840,87 @@ func (g *Gognee) Stats() (Stats, error) {
 		return Stats{}, fmt.Errorf("failed to get memory count: %w", err)
 	}
 
-	return Stats{
+	memoryStats, err := g.memoryStore.MemoryStats(ctx)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	stats := Stats{
 		NodeCount:     nodeCount,
 		EdgeCount:     edgeCount,
 		MemoryCount:   memoryCount,
 		BufferedDocs:  len(g.buffer),
 		LastCognified: g.lastCognified,
-	}, nil
+
+		AccessTrackingFailures: g.accessFailures.Load(),
+
+		MemoriesByStatus:          memoryStats.ByStatus,
+		MemoriesByRetentionPolicy: memoryStats.ByRetentionPolicy,
+		PinnedMemories:            memoryStats.Pinned,
+		AverageMemoryScore:        memoryStats.AverageScore,
+	}
+
+	if statistics, ok := g.graphStore.(store.GraphStatistics); ok {
+		if stats.NodesByType, err = statistics.NodeCountsByType(ctx); err != nil {
+			return Stats{}, err
+		}
+		if stats.EdgesByRelation, err = statistics.EdgeCountsByRelation(ctx); err != nil {
+			return Stats{}, err
+		}
+		if stats.DatabaseSizeBytes, err = statistics.DatabaseSize(ctx); err != nil {
+			return Stats{}, err
+		}
+	}
+	if housekeeper, ok := g.graphStore.(store.Housekeeper); ok {
+		last, err := housekeeper.LastHousekeeping(ctx)
+		if err != nil {
+			return Stats{}, err
+		}
+		stats.LastGC = last[store.HousekeepingGC]
+		stats.LastPrune = last[store.HousekeepingPrune]
+		stats.LastMaintenance = last[store.HousekeepingMaintenance]
+	}
+	return stats, nil
+}
+
+// recordHousekeeping records that a housekeeping pass ran now, for Stats. Best-effort:
+// failures are logged at WARN and do not fail the pass.
+func (g *Gognee) recordHousekeeping(ctx context.Context, pass string) {
+	housekeeper, ok := g.graphStore.(store.Housekeeper)
+	if !ok {
+		return
+	}
+	if err := housekeeper.RecordHousekeeping(ctx, pass, time.Now()); err != nil && g.logger != nil {
+		g.logger.LogAttrs(ctx, slog.LevelWarn, "failed to record housekeeping run",
+			slog.String("pass", pass),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// accessTrackingFailed handles err from recording access to count nodes or memories (kind).
+// A failure is counted, reported to the metrics collector and logged at WARN; it is
+// returned only with Config.StrictAccessTracking, so by default Search still succeeds.
+func (g *Gognee) accessTrackingFailed(ctx context.Context, kind string, count int, err error) error {
+	if err == nil {
+		return nil
+	}
+	failures := g.accessFailures.Add(1)
+	if g.metricsCollector != nil {
+		g.metricsCollector.RecordError(ctx, "search", "access_tracking")
+	}
+	if g.logger != nil {
+		g.logger.LogAttrs(ctx, slog.LevelWarn, "access tracking failed",
+			slog.String("kind", kind),
+			slog.Int("count", count),
+			slog.Int64("failures", failures),
+			slog.String("error", err.Error()),
+		)
+	}
+	if g.config.StrictAccessTracking {
+		return fmt.Errorf("failed to record %s access: %w", kind, err)
+	}
+	return nil
 }
 
 // Prune removes old or low-scoring nodes from the knowledge graph.
@@ -895,18 +1929,33 @@ func (g *Gognee) Stats() (Stats, error) {
 func (g *Gognee) Prune(ctx context.Context, opts PruneOptions) (*PruneResult, error) {
 	// M6: Capture start time for duration logging
 	startTime := time.Now()
-	
+
 	result := &PruneResult{
 		NodeIDs: make([]string, 0),
+		Status:  OperationCompleted,
+	}
+
+	// cancelled returns the partial result once ctx ends the prune early
+	cancelled := func() (*PruneResult, error) {
+		result.Status = OperationCancelled
+		if g.logger != nil {
+			g.logger.LogAttrs(ctx, slog.LevelWarn, "prune cancelled",
+				slog.Int("memories_pruned", result.SupersededMemoriesPruned),
+				slog.Int("nodes_pruned", result.NodesPruned),
+				slog.Int64("duration_ms", time.Since(startTime).Milliseconds()),
+			)
+		}
+		return result, nil
 	}
 
 	// Apply default: PruneSuperseded defaults to true (Plan 022 M3)
-	if !opts.PruneSuperseded {
-		opts.PruneSuperseded = true
+	pruneSuperseded := true
+	if opts.PruneSuperseded != nil {
+		pruneSuperseded = *opts.PruneSuperseded
 	}
 
 	// Set default values for supersession options (M5: Plan 021)
-	if opts.PruneSuperseded && opts.SupersededAgeDays == 0 {
+	if pruneSuperseded && opts.SupersededAgeDays == 0 {
 		opts.SupersededAgeDays = 30 // Default grace period
 	}
 
@@ -916,13 +1965,16 @@ func (g *Gognee) Prune(ctx context.Context, opts PruneOptions) (*PruneResult, er
 			slog.Bool("dry_run", opts.DryRun),
 			slog.Int("max_age_days", opts.MaxAgeDays),
 			slog.Float64("min_decay_score", opts.MinDecayScore),
-			slog.Bool("prune_superseded", opts.PruneSuperseded),
+			slog.Bool("prune_superseded", pruneSuperseded),
 			slog.Int("superseded_age_days", opts.SupersededAgeDays),
 		)
 	}
 
 	// **Phase 1: Evaluate and prune memories based on supersession and retention policies (M5, M8: Plan 021)**
-	if opts.PruneSuperseded {
+	if ctx.Err() != nil {
+		return cancelled()
+	}
+	if pruneSuperseded {
 		// Query all memories with status='Superseded'
 		allMemories, err := g.memoryStore.ListMemories(ctx, store.ListMemoriesOptions{
 			Offset: 0,
@@ -937,7 +1989,11 @@ func (g *Gognee) Prune(ctx context.Context, opts PruneOptions) (*PruneResult, er
 		now := time.Now()
 		memoriesToPrune := make([]string, 0)
 
-		for _, summary := range allMemories {
+		for i, summary := range allMemories {
+			if ctx.Err() != nil {
+				result.MemoriesEvaluated = i
+				return cancelled()
+			}
 			shouldPrune := false
 
 			// M9: Never prune pinned memories
@@ -1045,7 +2101,11 @@ func (g *Gognee) Prune(ctx context.Context, opts PruneOptions) (*PruneResult, er
 
 		// If not dry run, delete the memories
 		if !opts.DryRun {
-			for _, memoryID := range memoriesToPrune {
+			for i, memoryID := range memoriesToPrune {
+				if ctx.Err() != nil {
+					result.SupersededMemoriesPruned = i
+					return cancelled()
+				}
 				if err := g.DeleteMemory(ctx, memoryID); err != nil {
 					// Continue on error to prune as much as possible
 					_ = err
@@ -1055,84 +2115,43 @@ func (g *Gognee) Prune(ctx context.Context, opts PruneOptions) (*PruneResult, er
 	}
 
 	// **Phase 2: Evaluate and prune nodes based on decay/age (existing logic)**
-	// Get all nodes for evaluation
-	sqlStore, ok := g.graphStore.(*store.SQLiteGraphStore)
-	if !ok {
-		return nil, fmt.Errorf("prune requires SQLiteGraphStore")
+	// Stores that cannot enumerate and delete nodes only get memory pruning
+	reader, canRead := g.graphStore.(store.BulkReader)
+	deleter, canDelete := g.graphStore.(store.Deleter)
+	if !canRead || !canDelete {
+		if g.logger != nil {
+			g.logger.LogAttrs(ctx, slog.LevelWarn, "node prune skipped",
+				slog.String("reason", "graph store does not implement store.BulkReader and store.Deleter"),
+			)
+		}
+		return result, nil
 	}
 
-	// Query all nodes
-	allNodes, err := sqlStore.GetAllNodes(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get nodes: %w", err)
+	if ctx.Err() != nil {
+		return cancelled()
 	}
 
-	result.NodesEvaluated = len(allNodes)
+	// Nodes derived from pinned memories are kept like pinned nodes
+	pinnedMemoryNodes, err := g.memoryStore.PinnedNodeIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes of pinned memories: %w", err)
+	}
 
-	// Evaluate each node for pruning
+	// Stores implementing store.NodePruner select the nodes in SQL; others are streamed
+	// and evaluated one by one
 	now := time.Now()
-	nodesToPrune := make([]string, 0)
-
-	for _, node := range allNodes {
-		shouldPrune := false
-		var decayScore float64 = 1.0
-
-		// Check MaxAgeDays criterion
-		if opts.MaxAgeDays > 0 {
-			var age time.Duration
-			if g.config.DecayBasis == "access" && node.LastAccessedAt != nil {
-				age = now.Sub(*node.LastAccessedAt)
-			} else {
-				age = now.Sub(node.CreatedAt)
-			}
-
-			ageDays := int(age.Hours() / 24)
-			if ageDays > opts.MaxAgeDays {
-				shouldPrune = true
-			}
-		}
-
-		// Check MinDecayScore criterion
-		if opts.MinDecayScore > 0 && g.config.DecayEnabled {
-			var age time.Duration
-			if g.config.DecayBasis == "access" && node.LastAccessedAt != nil {
-				age = now.Sub(*node.LastAccessedAt)
-			} else {
-				age = now.Sub(node.CreatedAt)
-			}
-
-			decayScore = calculateDecay(age, g.config.DecayHalfLifeDays)
-			if decayScore < opts.MinDecayScore {
-				shouldPrune = true
-			}
-		}
-
-		// M6: Log node evaluation (DEBUG) - safe attributes only (no Name, Description)
-		if g.logger != nil {
-			var age time.Duration
-			if g.config.DecayBasis == "access" && node.LastAccessedAt != nil {
-				age = now.Sub(*node.LastAccessedAt)
-			} else {
-				age = now.Sub(node.CreatedAt)
-			}
-			ageDays := int(age.Hours() / 24)
-			
-			decision := "keep"
-			if shouldPrune {
-				decision = "prune"
-			}
-
-			g.logger.LogAttrs(ctx, slog.LevelDebug, "node evaluated",
-				slog.String("node_id", node.ID),
-				slog.Int("age_days", ageDays),
-				slog.Float64("decay_score", decayScore),
-				slog.String("decision", decision),
-			)
-		}
-
-		if shouldPrune {
-			nodesToPrune = append(nodesToPrune, node.ID)
-		}
+	var nodesToPrune []string
+	pruner, batched := g.graphStore.(store.NodePruner)
+	if batched {
+		nodesToPrune, err = g.selectAgedNodes(ctx, pruner, opts, now, pinnedMemoryNodes, result)
+	} else {
+		nodesToPrune, err = g.scanNodesToPrune(ctx, reader, opts, now, pinnedMemoryNodes, result)
+	}
+	if ctx.Err() != nil {
+		return cancelled()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %w", err)
 	}
 
 	result.NodesPruned = len(nodesToPrune)
@@ -1142,12 +2161,15 @@ func (g *Gognee) Prune(ctx context.Context, opts PruneOptions) (*PruneResult, er
 	if opts.DryRun {
 		// Estimate edges that would be pruned
 		for _, nodeID := range nodesToPrune {
+			if ctx.Err() != nil {
+				return cancelled()
+			}
 			edges, err := g.graphStore.GetEdges(ctx, nodeID)
 			if err == nil {
 				result.EdgesPruned += len(edges)
 			}
 		}
-		
+
 		// M6: Log prune completion summary at INFO level (dry run path)
 		if g.logger != nil {
 			durationMs := time.Since(startTime).Milliseconds()
@@ -1160,36 +2182,50 @@ func (g *Gognee) Prune(ctx context.Context, opts PruneOptions) (*PruneResult, er
 				slog.Int64("duration_ms", durationMs),
 			)
 		}
-		
+
 		return result, nil
 	}
 
-	// Actually prune nodes and edges
-	for _, nodeID := range nodesToPrune {
-		// Delete edges first (cascade)
-		edges, err := g.graphStore.GetEdges(ctx, nodeID)
-		if err != nil {
-			continue
+	// Actually prune nodes and edges, in batched transactions when the store supports it
+	if batched {
+		if err := g.deleteNodeBatches(ctx, pruner, nodesToPrune, opts, result); err != nil {
+			if ctx.Err() != nil {
+				return cancelled()
+			}
+			return nil, err
 		}
-		result.EdgesPruned += len(edges)
+	} else {
+		for i, nodeID := range nodesToPrune {
+			if ctx.Err() != nil {
+				result.NodesPruned = i
+				result.NodeIDs = nodesToPrune[:i]
+				return cancelled()
+			}
 
-		// Delete the edges
-		for _, edge := range edges {
-			if err := sqlStore.DeleteEdge(ctx, edge.ID); err != nil {
-				// Continue on error to prune as much as possible
+			// Delete edges first (cascade)
+			edges, err := g.graphStore.GetEdges(ctx, nodeID)
+			if err != nil {
 				continue
 			}
-		}
+			result.EdgesPruned += len(edges)
 
-		// Delete from vector store (ignore errors to prune as much as possible)
-		if err := g.vectorStore.Delete(ctx, nodeID); err != nil {
-			_ = err
-		}
+			// Delete the edges
+			for _, edge := range edges {
+				if err := deleter.DeleteEdge(ctx, edge.ID); err != nil {
+					// Continue on error to prune as much as possible
+					continue
+				}
+				g.dropEdgeVectors(ctx, []string{edge.ID})
+			}
 
-		// Delete the node
-		if err := sqlStore.DeleteNode(ctx, nodeID); err != nil {
-			// Continue on error
-			continue
+			// Delete the node
+			if err := deleter.DeleteNode(ctx, nodeID); err != nil {
+				// Continue on error
+				continue
+			}
+
+			// Delete from vector store once the node is gone (ignore errors to prune as much as possible)
+			_ = g.vectorStore.Delete(ctx, nodeID)
 		}
 	}
 
@@ -1206,9 +2242,20 @@ func (g *Gognee) Prune(ctx context.Context, opts PruneOptions) (*PruneResult, er
 		)
 	}
 
+	g.recordHousekeeping(ctx, store.HousekeepingPrune)
 	return result, nil
 }
 
+// nodeID returns the deterministic node ID for an entity in this instance's namespace.
+// The unnamed namespace keeps the original IDs; other namespaces hash the namespace
+// into the key so the same entity in two namespaces gets distinct nodes.
+func (g *Gognee) nodeID(name, nodeType string) string {
+	if g.config.Namespace == store.DefaultNamespace {
+		return generateDeterministicNodeID(name, nodeType)
+	}
+	return generateDeterministicNodeID(name, g.config.Namespace+"|"+nodeType)
+}
+
 // generateDeterministicNodeID creates a deterministic node ID from name and type
 func generateDeterministicNodeID(name, nodeType string) string {
 	// Normalize the name
@@ -1230,6 +2277,14 @@ func sanitizeRelation(relation string) string {
 	return strings.ToUpper(strings.ReplaceAll(relation, " ", "_"))
 }
 
+// derivedEdgeID returns the ID of the edge extracted from triplet between sourceID and
+// targetID: store.DerivedEdgeID over the triplet's relation and validity period, so
+// the same relation stated for different periods yields distinct edges.
+func derivedEdgeID(sourceID string, triplet extraction.Triplet, targetID string) string {
+	from, to := triplet.ValidityPeriod()
+	return store.DerivedEdgeID(sourceID, triplet.Relation, targetID, from, to)
+}
+
 // computeDocumentHash computes a SHA-256 hash of document text for identity.
 // Used for document-level deduplication in incremental Cognify.
 // Hash is computed on exact text without normalization to detect any changes.
@@ -1252,6 +2307,12 @@ type MemoryInput struct {
 	Source    string
 	// TraceEnabled enables timing instrumentation (Plan 015)
 	TraceEnabled bool
+	// Tags are attached to the new memory (see AddTags)
+	Tags []string
+	// ACL restricts who can read the memory and the search results derived only from it,
+	// enforced for callers that set a principal with store.WithPrincipal (default: nil =
+	// visible to everyone)
+	ACL *store.MemoryACL
 	// Supersedes lists memory IDs that this new memory replaces (M4: Plan 021)
 	Supersedes []string
 	// SupersessionReason explains why this memory supersedes the old ones (M4: Plan 021)
@@ -1259,6 +2320,10 @@ type MemoryInput struct {
 	// RetentionPolicy sets the retention policy for this memory (M6: Plan 021)
 	// Valid values: permanent, decision, standard, ephemeral, session (default: standard)
 	RetentionPolicy string
+	// AutoCognify runs entity and relation extraction over the topic, context and
+	// decisions in the same call, linking the nodes and edges produced as provenance.
+	// Default: true. Set to false to store the memory without touching the graph.
+	AutoCognify *bool
 }
 
 // MemoryResult reports the outcome of memory operations.
@@ -1273,6 +2338,16 @@ type MemoryResult struct {
 	Trace *OperationTrace
 	// MemoriesSuperseded is the count of memories marked as Superseded (M4: Plan 021)
 	MemoriesSuperseded int
+	// ConflictCandidates lists current memories on the same topic as the new one
+	// (AddMemory with Config.ConflictDetection set). Their summaries are taken before
+	// any automatic supersession.
+	ConflictCandidates []store.MemorySummary
+	// GuardrailViolations lists what Config.Guardrail rejected or sanitized in the
+	// content extracted from this memory; the memory's metadata is flagged as well.
+	GuardrailViolations []string
+	// QuarantineID identifies the quarantine holding the memory's nodes
+	// (Config.QuarantineFlagged), empty if it was not quarantined.
+	QuarantineID string
 }
 
 // AddMemory creates a new first-class memory with full CRUD support.
@@ -1294,10 +2369,10 @@ func (g *Gognee) AddMemory(ctx context.Context, input MemoryInput) (*MemoryResul
 
 	// Validate input
 	if strings.TrimSpace(input.Topic) == "" {
-		return nil, fmt.Errorf("topic cannot be empty")
+		return nil, store.Invalidf("topic cannot be empty")
 	}
 	if strings.TrimSpace(input.Context) == "" {
-		return nil, fmt.Errorf("context cannot be empty")
+		return nil, store.Invalidf("context cannot be empty")
 	}
 
 	// Validate retention policy (M6: Plan 021)
@@ -1305,29 +2380,28 @@ func (g *Gognee) AddMemory(ctx context.Context, input MemoryInput) (*MemoryResul
 		input.RetentionPolicy = "standard" // Default
 	}
 	if _, valid := RetentionPolicies[input.RetentionPolicy]; !valid {
-		return nil, fmt.Errorf("invalid retention_policy '%s': must be one of: permanent, decision, standard, ephemeral, session", input.RetentionPolicy)
+		return nil, store.Invalidf("invalid retention_policy '%s': must be one of: permanent, decision, standard, ephemeral, session", input.RetentionPolicy)
+	}
+
+	// Redact before hashing, so the stored record and its hash never see the original text
+	if err := g.redactMemoryInput(ctx, &input); err != nil {
+		return nil, err
 	}
 
 	// Compute doc_hash
 	docHash := store.ComputeDocHash(input.Topic, input.Context, input.Decisions, input.Rationale)
 
 	// **Phase 1: Short transaction - persist memory record**
-	// Check for duplicate by doc_hash
-	// For v1.0.0, we'll do a simple query to check existence
-	// If exists, return existing memory_id
-
-	existingQuery := `SELECT id FROM memories WHERE doc_hash = ? LIMIT 1`
-	var existingID string
-	err := g.memoryStore.DB().QueryRowContext(ctx, existingQuery, docHash).Scan(&existingID)
-	if err == nil {
-		// Duplicate found
+	// A memory with the same content (in the same session, if any) is returned instead
+	sessionID, _ := input.Metadata[store.SessionMetadataKey].(string)
+	existingID, err := g.memoryStore.FindDuplicateMemory(ctx, docHash, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if existingID != "" {
 		result.MemoryID = existingID
 		return result, nil
 	}
-	// If error is not ErrNoRows, return error
-	if err != sql.ErrNoRows {
-		return nil, fmt.Errorf("failed to check for duplicate memory: %w", err)
-	}
 
 	// Create memory record with status "pending"
 	memoryID := uuid.New().String()
@@ -1342,6 +2416,8 @@ func (g *Gognee) AddMemory(ctx context.Context, input MemoryInput) (*MemoryResul
 		Source:          input.Source,
 		Status:          "pending",
 		RetentionPolicy: input.RetentionPolicy, // M6: Plan 021
+		Tags:            input.Tags,
+		ACL:             input.ACL,
 	}
 
 	if err := g.memoryStore.AddMemory(ctx, memory); err != nil {
@@ -1354,14 +2430,18 @@ func (g *Gognee) AddMemory(ctx context.Context, input MemoryInput) (*MemoryResul
 	cognifyTimer := newSpanTimer("cognify", trace, input.TraceEnabled)
 
 	// Format text for cognify
-	text := fmt.Sprintf("Topic: %s\n\n%s", input.Topic, input.Context)
+	text := memoryText(input.Topic, input.Context, input.Decisions)
 
 	// Track created node/edge IDs
 	createdNodeIDs := make([]string, 0)
 	createdEdgeIDs := make([]string, 0)
+	var guardrailReport extraction.GuardrailReport
 
-	// Chunk the text
-	chunks := g.chunker.Chunk(text)
+	// Chunk the text, unless extraction is turned off for this memory
+	var chunks []chunker.Chunk
+	if input.AutoCognify == nil || *input.AutoCognify {
+		chunks = g.textChunker.Chunk(text)
+	}
 	fmt.Fprintf(os.Stderr, "gognee: AddMemory starting: memoryID=%s chunks=%d\n", memoryID, len(chunks))
 
 	// Process each chunk
@@ -1378,19 +2458,43 @@ func (g *Gognee) AddMemory(ctx context.Context, input MemoryInput) (*MemoryResul
 			result.Errors = append(result.Errors, fmt.Errorf("entity extraction failed for memory %s: %w", memoryID, err))
 			continue
 		}
+		entities, err = g.redactEntities(ctx, entities)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("entity extraction failed for memory %s: %w", memoryID, err))
+			continue
+		}
+		entities, report := g.config.Guardrail.CheckEntities(entities)
+		guardrailReport.Merge(report)
+		entities, _ = extraction.FilterEntitiesByConfidence(entities, g.config.MinExtractionConfidence)
+		entities, _, err = g.config.EntityFilter.Filter(ctx, entities)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("entity filter failed for memory %s: %w", memoryID, err))
+		}
+
+		// Map known aliases to their canonical entities (see ResolveEntities). Relations are
+		// extracted against the names as written and mapped the same way.
+		extracted := entities
+		entities, renames := g.canonicalEntities(entities)
 
 		// Build entity name->type lookup map
 		entityMap, ambiguous := buildEntityTypeMap(entities)
 
 		// Extract relations
 		relationStart := time.Now()
-		triplets, err := g.relationExtractor.Extract(ctx, chunk.Text, entities)
+		triplets, err := g.relationExtractor.Extract(ctx, chunk.Text, extracted)
 		relationDuration := time.Since(relationStart)
 		fmt.Fprintf(os.Stderr, "gognee: chunk[%d] relation extraction: duration=%v count=%d\n", chunkIdx, relationDuration, len(triplets))
+		if err == nil {
+			triplets, err = g.redactTriplets(ctx, triplets)
+		}
 		if err != nil {
 			result.Errors = append(result.Errors, fmt.Errorf("relation extraction failed for memory %s: %w", memoryID, err))
+			triplets = nil
 			// Continue with entities only
 		}
+		triplets, report = g.config.Guardrail.CheckTriplets(triplets)
+		guardrailReport.Merge(report)
+		triplets, _ = extraction.FilterTripletsByConfidence(triplets, g.config.MinExtractionConfidence)
 
 		// Create nodes for each entity
 		// First pass: collect texts for batch embedding
@@ -1413,19 +2517,21 @@ func (g *Gognee) AddMemory(ctx context.Context, input MemoryInput) (*MemoryResul
 		// Second pass: create nodes with embeddings
 		dbStart := time.Now()
 		for i, entity := range entities {
-			nodeID := generateDeterministicNodeID(entity.Name, entity.Type)
+			nodeID := g.nodeID(entity.Name, entity.Type)
 			node := &store.Node{
 				ID:          nodeID,
 				Name:        entity.Name,
 				Type:        entity.Type,
 				Description: entity.Description,
 				CreatedAt:   time.Now(),
-				Metadata:    make(map[string]interface{}),
+				Metadata:    g.entityMetadata(nodeID),
 				Embedding:   embeddings[i],
 			}
 
 			// Add to graph store (upsert) with embedding
-			if err := g.graphStore.AddNode(ctx, node); err != nil {
+			warnings, err := g.addEntityNode(ctx, node)
+			result.Errors = append(result.Errors, warnings...)
+			if err != nil {
 				result.Errors = append(result.Errors, fmt.Errorf("failed to add node %s: %w", entity.Name, err))
 				continue
 			}
@@ -1439,11 +2545,17 @@ func (g *Gognee) AddMemory(ctx context.Context, input MemoryInput) (*MemoryResul
 				}
 			}
 		}
+		g.recordMentions(ctx, entities)
 		dbNodesDuration := time.Since(dbStart)
 
 		// Create edges for each triplet
 		edgeStart := time.Now()
+		var newEdges edgeBatch
 		for _, triplet := range triplets {
+			// Map synonymous relation labels to their canonical form
+			triplet.Relation = g.canonicalRelation(triplet.Relation)
+			triplet.Subject, triplet.Object = renames.apply(triplet.Subject), renames.apply(triplet.Object)
+
 			// Look up source and target entity types
 			sourceType, sourceFound := lookupEntityType(triplet.Subject, entityMap, ambiguous)
 			if !sourceFound {
@@ -1455,10 +2567,10 @@ func (g *Gognee) AddMemory(ctx context.Context, input MemoryInput) (*MemoryResul
 				continue
 			}
 
-			sourceID := generateDeterministicNodeID(triplet.Subject, sourceType)
-			targetID := generateDeterministicNodeID(triplet.Object, targetType)
+			sourceID := g.nodeID(triplet.Subject, sourceType)
+			targetID := g.nodeID(triplet.Object, targetType)
 
-			edgeID := fmt.Sprintf("%s-%s-%s", sourceID, sanitizeRelation(triplet.Relation), targetID)
+			edgeID := derivedEdgeID(sourceID, triplet, targetID)
 			edge := &store.Edge{
 				ID:        edgeID,
 				SourceID:  sourceID,
@@ -1467,6 +2579,10 @@ func (g *Gognee) AddMemory(ctx context.Context, input MemoryInput) (*MemoryResul
 				Weight:    1.0,
 				CreatedAt: time.Now(),
 			}
+			edge.ExpiresAt = g.relationExpiry(edge.Relation, edge.CreatedAt)
+			edge.SourceChunkID = chunk.ID
+			edge.Evidence = evidenceSnippet(chunk.Text, triplet.Subject, triplet.Object)
+			edge.ValidFrom, edge.ValidTo = triplet.ValidityPeriod()
 
 			if err := g.graphStore.AddEdge(ctx, edge); err != nil {
 				result.Errors = append(result.Errors, fmt.Errorf("failed to add edge: %w", err))
@@ -1474,6 +2590,10 @@ func (g *Gognee) AddMemory(ctx context.Context, input MemoryInput) (*MemoryResul
 			}
 			createdEdgeIDs = append(createdEdgeIDs, edgeID)
 			result.EdgesCreated++
+			newEdges.add(edge, triplet.Subject, triplet.Object)
+		}
+		if err := g.indexEdges(ctx, &newEdges); err != nil {
+			result.Errors = append(result.Errors, err)
 		}
 		edgeDuration := time.Since(edgeStart)
 		chunkDuration := time.Since(chunkStart)
@@ -1490,40 +2610,44 @@ func (g *Gognee) AddMemory(ctx context.Context, input MemoryInput) (*MemoryResul
 	if err := g.memoryStore.LinkProvenance(ctx, memoryID, createdNodeIDs, createdEdgeIDs); err != nil {
 		return nil, fmt.Errorf("failed to link provenance: %w", err)
 	}
-
-	// **Phase 4: Handle supersession if provided (M4: Plan 021)**
-	if len(input.Supersedes) > 0 {
-		for _, supersededID := range input.Supersedes {
-			// Validate that superseded memory exists and is Active
-			supersededMemory, err := g.memoryStore.GetMemory(ctx, supersededID)
+	if guardrailReport.Flagged() {
+		result.GuardrailViolations = guardrailReport.Violations
+		if err := g.flagMemory(ctx, memoryID, guardrailReport); err != nil {
+			result.Errors = append(result.Errors, err)
+		}
+		if g.config.QuarantineFlagged {
+			id, err := g.quarantine(ctx, QuarantineMemory, memoryID, input.Source, guardrailReport, createdNodeIDs)
 			if err != nil {
-				result.Errors = append(result.Errors, fmt.Errorf("cannot supersede memory %s: %w", supersededID, err))
-				continue
-			}
-
-			// Allow superseding Active or already-Superseded memories (creates chains)
-			if supersededMemory.Status != "Active" && supersededMemory.Status != "Superseded" {
-				result.Errors = append(result.Errors, fmt.Errorf("cannot supersede memory %s: status is '%s', must be 'Active' or 'Superseded'", supersededID, supersededMemory.Status))
-				continue
+				result.Errors = append(result.Errors, fmt.Errorf("failed to quarantine memory: %w", err))
 			}
+			result.QuarantineID = id
+		}
+	}
 
-			// Record supersession
-			if err := g.memoryStore.RecordSupersession(ctx, memoryID, supersededID, input.SupersessionReason); err != nil {
-				result.Errors = append(result.Errors, fmt.Errorf("failed to record supersession: %w", err))
-				continue
-			}
+	// **Phase 4: Handle supersession if provided (M4: Plan 021)**
+	for _, supersededID := range input.Supersedes {
+		if err := g.supersedeMemory(ctx, memoryID, supersededID, input.SupersessionReason); err != nil {
+			result.Errors = append(result.Errors, err)
+			continue
+		}
+		result.MemoriesSuperseded++
+	}
 
-			// Mark superseded memory as Superseded
-			supersededStatus := "Superseded"
-			supersededUpdates := store.MemoryUpdate{
-				Status: &supersededStatus,
-			}
-			if err := g.memoryStore.UpdateMemory(ctx, supersededID, supersededUpdates); err != nil {
-				result.Errors = append(result.Errors, fmt.Errorf("failed to mark memory %s as superseded: %w", supersededID, err))
-				continue
+	// Detect current memories on the same topic (see Config.ConflictDetection)
+	if g.config.ConflictDetection != "" {
+		conflicts, err := g.findConflictingMemories(ctx, memoryID, memory.Topic, input.Supersedes)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("conflict detection failed: %w", err))
+		}
+		result.ConflictCandidates = conflicts
+		if g.config.ConflictDetection == ConflictDetectionSupersede {
+			for _, conflict := range conflicts {
+				if err := g.supersedeMemory(ctx, memoryID, conflict.ID, "conflicting topic detected at add time"); err != nil {
+					result.Errors = append(result.Errors, err)
+					continue
+				}
+				result.MemoriesSuperseded++
 			}
-
-			result.MemoriesSuperseded++
 		}
 	}
 
@@ -1574,16 +2698,64 @@ func (g *Gognee) AddMemory(ctx context.Context, input MemoryInput) (*MemoryResul
 	return result, nil
 }
 
+// memoryText renders the text of a memory that extraction runs over
+func memoryText(topic, context string, decisions []string) string {
+	text := fmt.Sprintf("Topic: %s\n\n%s", topic, context)
+	if len(decisions) > 0 {
+		text += "\n\nDecisions:\n- " + strings.Join(decisions, "\n- ")
+	}
+	return text
+}
+
 // GetMemory retrieves a memory by ID.
 func (g *Gognee) GetMemory(ctx context.Context, id string) (*store.MemoryRecord, error) {
 	return g.memoryStore.GetMemory(ctx, id)
 }
 
+// GetSupersessionChain returns the supersessions of the chain a memory is part of,
+// oldest first.
+func (g *Gognee) GetSupersessionChain(ctx context.Context, id string) ([]store.SupersessionRecord, error) {
+	return g.memoryStore.GetSupersessionChain(ctx, id)
+}
+
+// GetCurrentMemory returns the memory that currently replaces the given one: the head of
+// its supersession chain, or the memory itself if it is not superseded.
+func (g *Gognee) GetCurrentMemory(ctx context.Context, id string) (*store.MemoryRecord, error) {
+	headID, err := store.SupersessionHead(ctx, g.memoryStore, id)
+	if err != nil {
+		return nil, err
+	}
+	return g.memoryStore.GetMemory(ctx, headID)
+}
+
+// GetMemoryHistory returns every recorded version of a memory, oldest first, ending with
+// the current one. Each UpdateMemory (including status changes) creates a new version.
+func (g *Gognee) GetMemoryHistory(ctx context.Context, id string) ([]store.MemoryRevision, error) {
+	return g.memoryStore.GetMemoryHistory(ctx, id)
+}
+
+// GetMemoryAtVersion returns the content of a memory as it was at the given version.
+func (g *Gognee) GetMemoryAtVersion(ctx context.Context, id string, version int) (*store.MemoryRevision, error) {
+	return g.memoryStore.GetMemoryAtVersion(ctx, id, version)
+}
+
 // ListMemories returns paginated memory summaries.
 func (g *Gognee) ListMemories(ctx context.Context, opts store.ListMemoriesOptions) ([]store.MemorySummary, error) {
 	return g.memoryStore.ListMemories(ctx, opts)
 }
 
+// ListMemoriesPage returns a page of memories with the total number matching the filters,
+// using keyset pagination: pass the returned NextCursor to fetch the next page.
+func (g *Gognee) ListMemoriesPage(ctx context.Context, opts store.ListMemoriesOptions, cursor string) (*store.MemoryPage, error) {
+	return g.memoryStore.ListMemoriesPage(ctx, opts, cursor)
+}
+
+// EnsureMetadataIndex indexes a memory metadata key filtered on with
+// ListMemoriesOptions.MetadataFilters. store.IndexedMetadataKeys are indexed by default.
+func (g *Gognee) EnsureMetadataIndex(ctx context.Context, key string) error {
+	return g.memoryStore.EnsureMetadataIndex(ctx, key)
+}
+
 // CountMemories returns the total number of memories in the store.
 func (g *Gognee) CountMemories(ctx context.Context) (int64, error) {
 	return g.memoryStore.CountMemories(ctx)
@@ -1602,6 +2774,10 @@ func (g *Gognee) UpdateMemory(ctx context.Context, id string, updates store.Memo
 		return nil, err
 	}
 
+	if err := g.redactMemoryUpdate(ctx, &updates); err != nil {
+		return nil, err
+	}
+
 	// Compute new doc_hash
 	topic := existing.Topic
 	context := existing.Context
@@ -1659,7 +2835,7 @@ func (g *Gognee) UpdateMemory(ctx context.Context, id string, updates store.Memo
 	}
 
 	// GC candidates: old artifacts
-	nodesDeleted, edgesDeleted, err := g.memoryStore.GarbageCollectCandidates(ctx, oldNodeIDs, oldEdgeIDs)
+	nodesDeleted, edgesDeleted, err := g.garbageCollect(ctx, oldNodeIDs, oldEdgeIDs)
 	if err != nil {
 		result.Errors = append(result.Errors, fmt.Errorf("garbage collection failed: %w", err))
 	}
@@ -1667,24 +2843,47 @@ func (g *Gognee) UpdateMemory(ctx context.Context, id string, updates store.Memo
 	result.EdgesDeleted = edgesDeleted
 
 	// **Phase 3: Re-cognify (same as AddMemory Phase 2)**
-	text := fmt.Sprintf("Topic: %s\n\n%s", topic, context)
+	text := memoryText(topic, context, decisions)
 	createdNodeIDs := make([]string, 0)
 	createdEdgeIDs := make([]string, 0)
+	var guardrailReport extraction.GuardrailReport
 
-	chunks := g.chunker.Chunk(text)
+	chunks := g.textChunker.Chunk(text)
 	for _, chunk := range chunks {
 		entities, err := g.entityExtractor.Extract(ctx, chunk.Text)
+		if err == nil {
+			entities, err = g.redactEntities(ctx, entities)
+		}
 		if err != nil {
 			result.Errors = append(result.Errors, fmt.Errorf("entity extraction failed: %w", err))
 			continue
 		}
+		entities, report := g.config.Guardrail.CheckEntities(entities)
+		guardrailReport.Merge(report)
+		entities, _ = extraction.FilterEntitiesByConfidence(entities, g.config.MinExtractionConfidence)
+		entities, _, err = g.config.EntityFilter.Filter(ctx, entities)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("entity filter failed: %w", err))
+		}
+
+		// Map known aliases to their canonical entities (see ResolveEntities). Relations are
+		// extracted against the names as written and mapped the same way.
+		extracted := entities
+		entities, renames := g.canonicalEntities(entities)
 
 		entityMap, ambiguous := buildEntityTypeMap(entities)
 
-		triplets, err := g.relationExtractor.Extract(ctx, chunk.Text, entities)
+		triplets, err := g.relationExtractor.Extract(ctx, chunk.Text, extracted)
+		if err == nil {
+			triplets, err = g.redactTriplets(ctx, triplets)
+		}
 		if err != nil {
 			result.Errors = append(result.Errors, fmt.Errorf("relation extraction failed: %w", err))
+			triplets = nil
 		}
+		triplets, report = g.config.Guardrail.CheckTriplets(triplets)
+		guardrailReport.Merge(report)
+		triplets, _ = extraction.FilterTripletsByConfidence(triplets, g.config.MinExtractionConfidence)
 
 		// First pass: collect texts for batch embedding
 		entityTexts := make([]string, len(entities))
@@ -1702,18 +2901,20 @@ func (g *Gognee) UpdateMemory(ctx context.Context, id string, updates store.Memo
 
 		// Second pass: create nodes with embeddings
 		for i, entity := range entities {
-			nodeID := generateDeterministicNodeID(entity.Name, entity.Type)
+			nodeID := g.nodeID(entity.Name, entity.Type)
 			node := &store.Node{
 				ID:          nodeID,
 				Name:        entity.Name,
 				Type:        entity.Type,
 				Description: entity.Description,
 				CreatedAt:   time.Now(),
-				Metadata:    make(map[string]interface{}),
+				Metadata:    g.entityMetadata(nodeID),
 				Embedding:   embeddings[i],
 			}
 
-			if err := g.graphStore.AddNode(ctx, node); err != nil {
+			warnings, err := g.addEntityNode(ctx, node)
+			result.Errors = append(result.Errors, warnings...)
+			if err != nil {
 				result.Errors = append(result.Errors, fmt.Errorf("failed to add node: %w", err))
 				continue
 			}
@@ -1727,7 +2928,12 @@ func (g *Gognee) UpdateMemory(ctx context.Context, id string, updates store.Memo
 			}
 		}
 
+		var newEdges edgeBatch
 		for _, triplet := range triplets {
+			// Map synonymous relation labels to their canonical form
+			triplet.Relation = g.canonicalRelation(triplet.Relation)
+			triplet.Subject, triplet.Object = renames.apply(triplet.Subject), renames.apply(triplet.Object)
+
 			sourceType, sourceFound := lookupEntityType(triplet.Subject, entityMap, ambiguous)
 			if !sourceFound {
 				continue
@@ -1738,9 +2944,9 @@ func (g *Gognee) UpdateMemory(ctx context.Context, id string, updates store.Memo
 				continue
 			}
 
-			sourceID := generateDeterministicNodeID(triplet.Subject, sourceType)
-			targetID := generateDeterministicNodeID(triplet.Object, targetType)
-			edgeID := fmt.Sprintf("%s-%s-%s", sourceID, sanitizeRelation(triplet.Relation), targetID)
+			sourceID := g.nodeID(triplet.Subject, sourceType)
+			targetID := g.nodeID(triplet.Object, targetType)
+			edgeID := derivedEdgeID(sourceID, triplet, targetID)
 
 			edge := &store.Edge{
 				ID:        edgeID,
@@ -1750,6 +2956,10 @@ func (g *Gognee) UpdateMemory(ctx context.Context, id string, updates store.Memo
 				Weight:    1.0,
 				CreatedAt: time.Now(),
 			}
+			edge.ExpiresAt = g.relationExpiry(edge.Relation, edge.CreatedAt)
+			edge.SourceChunkID = chunk.ID
+			edge.Evidence = evidenceSnippet(chunk.Text, triplet.Subject, triplet.Object)
+			edge.ValidFrom, edge.ValidTo = triplet.ValidityPeriod()
 
 			if err := g.graphStore.AddEdge(ctx, edge); err != nil {
 				result.Errors = append(result.Errors, fmt.Errorf("failed to add edge: %w", err))
@@ -1757,6 +2967,10 @@ func (g *Gognee) UpdateMemory(ctx context.Context, id string, updates store.Memo
 			}
 			createdEdgeIDs = append(createdEdgeIDs, edgeID)
 			result.EdgesCreated++
+			newEdges.add(edge, triplet.Subject, triplet.Object)
+		}
+		if err := g.indexEdges(ctx, &newEdges); err != nil {
+			result.Errors = append(result.Errors, err)
 		}
 	}
 
@@ -1764,6 +2978,19 @@ func (g *Gognee) UpdateMemory(ctx context.Context, id string, updates store.Memo
 	if err := g.memoryStore.LinkProvenance(ctx, id, createdNodeIDs, createdEdgeIDs); err != nil {
 		return nil, fmt.Errorf("failed to link new provenance: %w", err)
 	}
+	if guardrailReport.Flagged() {
+		result.GuardrailViolations = guardrailReport.Violations
+		if err := g.flagMemory(ctx, id, guardrailReport); err != nil {
+			result.Errors = append(result.Errors, err)
+		}
+		if g.config.QuarantineFlagged {
+			qid, err := g.quarantine(ctx, QuarantineMemory, id, existing.Source, guardrailReport, createdNodeIDs)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to quarantine memory: %w", err))
+			}
+			result.QuarantineID = qid
+		}
+	}
 
 	completeUpdate := store.MemoryUpdate{
 		Topic:   &topic,
@@ -1778,7 +3005,17 @@ func (g *Gognee) UpdateMemory(ctx context.Context, id string, updates store.Memo
 }
 
 // DeleteMemory removes a memory and runs garbage collection on orphaned artifacts.
+// With Config.SoftDelete the memory is moved to the trash instead (see RestoreMemory
+// and PurgeDeleted).
 func (g *Gognee) DeleteMemory(ctx context.Context, id string) error {
+	if g.config.SoftDelete {
+		return g.memoryStore.SoftDeleteMemory(ctx, id)
+	}
+	return g.purgeMemory(ctx, id)
+}
+
+// purgeMemory permanently deletes a memory and garbage-collects its orphaned artifacts.
+func (g *Gognee) purgeMemory(ctx context.Context, id string) error {
 	// Get provenance before delete
 	nodeIDs, edgeIDs, err := g.memoryStore.GetProvenanceByMemory(ctx, id)
 	if err != nil {
@@ -1791,7 +3028,7 @@ func (g *Gognee) DeleteMemory(ctx context.Context, id string) error {
 	}
 
 	// Run GC on candidates
-	_, _, err = g.memoryStore.GarbageCollectCandidates(ctx, nodeIDs, edgeIDs)
+	_, _, err = g.garbageCollect(ctx, nodeIDs, edgeIDs)
 	if err != nil {
 		return fmt.Errorf("garbage collection failed: %w", err)
 	}
@@ -1799,6 +3036,35 @@ func (g *Gognee) DeleteMemory(ctx context.Context, id string) error {
 	return nil
 }
 
+// garbageCollect deletes the candidate nodes and edges no memory or document references
+// any more, along with the vectors of the deleted nodes and edges.
+func (g *Gognee) garbageCollect(ctx context.Context, nodeIDs, edgeIDs []string) (nodesDeleted, edgesDeleted int, err error) {
+	nodesDeleted, deletedEdgeIDs, err := g.memoryStore.GarbageCollectCandidates(ctx, nodeIDs, edgeIDs)
+	if err != nil {
+		return 0, 0, err
+	}
+	if nodesDeleted > 0 {
+		g.dropNodeVectors(ctx, nodeIDs)
+	}
+	g.dropEdgeVectors(ctx, deletedEdgeIDs)
+	g.recordHousekeeping(ctx, store.HousekeepingGC)
+	return nodesDeleted, len(deletedEdgeIDs), nil
+}
+
+// dropNodeVectors deletes the vectors of those nodeIDs that are no longer in the graph.
+// Errors are ignored: a dangling vector only costs a search candidate that is skipped.
+func (g *Gognee) dropNodeVectors(ctx context.Context, nodeIDs []string) {
+	remaining, err := store.GetNodesByIDs(ctx, g.graphStore, nodeIDs)
+	if err != nil {
+		return
+	}
+	for _, id := range nodeIDs {
+		if remaining[id] == nil {
+			_ = g.vectorStore.Delete(ctx, id)
+		}
+	}
+}
+
 // GarbageCollect manually triggers garbage collection.
 // Returns counts of deleted nodes and edges.
 func (g *Gognee) GarbageCollect(ctx context.Context) (nodesDeleted, edgesDeleted int, err error) {
@@ -1807,42 +3073,29 @@ func (g *Gognee) GarbageCollect(ctx context.Context) (nodesDeleted, edgesDeleted
 	return 0, 0, fmt.Errorf("manual garbage collection not yet implemented; use DeleteMemory/UpdateMemory for automatic GC")
 }
 
-// PinMemory marks a memory as pinned, exempting it from decay and prune (M9: Plan 021).
+// PinMemory pins a memory with a reason, exempting it from decay, retention and prune
+// (M9: Plan 021). The nodes derived from a pinned memory are not pruned either. Pinning a
+// pinned memory replaces its reason.
 func (g *Gognee) PinMemory(ctx context.Context, id string, reason string) error {
-	// Verify memory exists
 	memory, err := g.memoryStore.GetMemory(ctx, id)
 	if err != nil {
 		return fmt.Errorf("cannot pin memory: %w", err)
 	}
 
-	// Already pinned?
-	if memory.Pinned {
-		return nil // Idempotent
-	}
-
-	now := time.Now()
-	pinnedStatus := "Pinned"
-	updates := store.MemoryUpdate{
-		Status: &pinnedStatus,
-	}
-	if err := g.memoryStore.UpdateMemory(ctx, id, updates); err != nil {
-		return fmt.Errorf("failed to update memory status: %w", err)
+	if !memory.Pinned {
+		pinnedStatus := "Pinned"
+		if err := g.memoryStore.UpdateMemory(ctx, id, store.MemoryUpdate{Status: &pinnedStatus}); err != nil {
+			return fmt.Errorf("failed to update memory status: %w", err)
+		}
 	}
-
-	// Update pinned fields directly (since MemoryUpdate doesn't have them yet)
-	query := `UPDATE memories SET pinned = TRUE, pinned_at = ?, pinned_reason = ? WHERE id = ?`
-	reasonPtr := &reason
-	_, err = g.memoryStore.DB().ExecContext(ctx, query, now, reasonPtr, id)
-	if err != nil {
-		return fmt.Errorf("failed to set pinned fields: %w", err)
+	if err := g.memoryStore.PinMemory(ctx, id, reason); err != nil {
+		return fmt.Errorf("cannot pin memory: %w", err)
 	}
-
 	return nil
 }
 
 // UnpinMemory removes pinning from a memory, allowing normal decay/prune (M9: Plan 021).
 func (g *Gognee) UnpinMemory(ctx context.Context, id string) error {
-	// Verify memory exists
 	memory, err := g.memoryStore.GetMemory(ctx, id)
 	if err != nil {
 		return fmt.Errorf("cannot unpin memory: %w", err)
@@ -1854,20 +3107,43 @@ func (g *Gognee) UnpinMemory(ctx context.Context, id string) error {
 	}
 
 	activeStatus := "Active"
-	updates := store.MemoryUpdate{
-		Status: &activeStatus,
-	}
-	if err := g.memoryStore.UpdateMemory(ctx, id, updates); err != nil {
+	if err := g.memoryStore.UpdateMemory(ctx, id, store.MemoryUpdate{Status: &activeStatus}); err != nil {
 		return fmt.Errorf("failed to update memory status: %w", err)
 	}
+	if err := g.memoryStore.UnpinMemory(ctx, id); err != nil {
+		return fmt.Errorf("cannot unpin memory: %w", err)
+	}
+	return nil
+}
 
-	// Update pinned fields directly
-	query := `UPDATE memories SET pinned = FALSE, pinned_at = NULL, pinned_reason = NULL WHERE id = ?`
-	_, err = g.memoryStore.DB().ExecContext(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("failed to clear pinned fields: %w", err)
+// ListPinned returns the pinned memories with the reason they were pinned, most recently
+// pinned first.
+func (g *Gognee) ListPinned(ctx context.Context) ([]store.PinnedMemory, error) {
+	return g.memoryStore.ListPinned(ctx)
+}
+
+// PinNode marks a node as pinned, exempting it from decay and prune.
+// Use it for critical entities such as the product itself or key people.
+func (g *Gognee) PinNode(ctx context.Context, nodeID string, reason string) error {
+	pinner, ok := g.graphStore.(store.NodePinner)
+	if !ok {
+		return fmt.Errorf("node pinning requires a graph store implementing store.NodePinner")
+	}
+	if err := pinner.SetNodePinned(ctx, nodeID, true, reason); err != nil {
+		return fmt.Errorf("cannot pin node: %w", err)
 	}
+	return nil
+}
 
+// UnpinNode removes pinning from a node, allowing normal decay/prune.
+func (g *Gognee) UnpinNode(ctx context.Context, nodeID string) error {
+	pinner, ok := g.graphStore.(store.NodePinner)
+	if !ok {
+		return fmt.Errorf("node pinning requires a graph store implementing store.NodePinner")
+	}
+	if err := pinner.SetNodePinned(ctx, nodeID, false, ""); err != nil {
+		return fmt.Errorf("cannot unpin node: %w", err)
+	}
 	return nil
 }
 
@@ -3,8 +3,10 @@ package gognee
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
+	"github.com/dan-solli/gognee/pkg/chunker"
 	"github.com/dan-solli/gognee/pkg/embeddings"
 	"github.com/dan-solli/gognee/pkg/extraction"
 	"github.com/dan-solli/gognee/pkg/llm"
@@ -127,6 +129,38 @@ func TestNewAppliesDefaults(t *testing.T) {
 	}
 }
 
+func TestNewChunkStrategy(t *testing.T) {
+	g, err := New(Config{DBPath: ":memory:", ChunkStrategy: chunker.StrategySemantic, ChunkSize: 200})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	defer g.Close()
+
+	semantic, ok := g.textChunker.(*chunker.SemanticChunker)
+	if !ok {
+		t.Fatalf("textChunker type: got %T, want *chunker.SemanticChunker", g.textChunker)
+	}
+	if semantic.MaxTokens != 200 {
+		t.Errorf("MaxTokens: got %d, want %d", semantic.MaxTokens, 200)
+	}
+
+	md, err := New(Config{DBPath: ":memory:", ChunkStrategy: chunker.StrategyMarkdown})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	defer md.Close()
+	if _, ok := md.textChunker.(*chunker.MarkdownChunker); !ok {
+		t.Errorf("textChunker type: got %T, want *chunker.MarkdownChunker", md.textChunker)
+	}
+
+	if _, err := New(Config{DBPath: ":memory:", ChunkStrategy: "sliding"}); err == nil {
+		t.Error("Expected error for unknown ChunkStrategy")
+	}
+	if _, err := New(Config{DBPath: ":memory:", ChunkBreakpointThreshold: 2}); err == nil {
+		t.Error("Expected error for ChunkBreakpointThreshold above 1")
+	}
+}
+
 func TestNewRespectsConfig(t *testing.T) {
 	g, err := New(Config{
 		OpenAIKey:      "k-test",
@@ -237,6 +271,23 @@ func TestNew_DecayValidation(t *testing.T) {
 			wantErr: true,
 			errMsg:  "DecayBasis must be 'access' or 'creation'",
 		},
+		{
+			name: "valid_decay_policy",
+			config: Config{
+				DBPath:      ":memory:",
+				DecayPolicy: &store.DecayPolicy{Curve: store.DecayPowerLaw, NodeTypeHalfLifeDays: map[string]int{"Person": 365}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid_decay_curve",
+			config: Config{
+				DBPath:      ":memory:",
+				DecayPolicy: &store.DecayPolicy{Curve: "linear"},
+			},
+			wantErr: true,
+			errMsg:  "unknown decay curve",
+		},
 		// Note: "decay_disabled_ignores_invalid_config" test removed in Plan 022 M2
 		// because DecayEnabled now defaults to true and cannot be easily disabled
 		// due to Go's zero-value behavior for booleans.
@@ -566,6 +617,56 @@ func TestCognifyWithMockedDependencies(t *testing.T) {
 	}
 }
 
+func TestStats_Breakdown(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &MockLLMClient{EntityResponses: [][]extraction.Entity{
+		{{Name: "Go", Type: "Technology", Description: "Programming language"}, {Name: "Alice", Type: "Person", Description: "Engineer"}},
+	}}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	kept, err := g.AddMemory(ctx, MemoryInput{Topic: "Language", Context: "Alice writes Go.", RetentionPolicy: "decision"})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	deleted, err := g.AddMemory(ctx, MemoryInput{Topic: "Lunch", Context: "Pizza on Fridays.", AutoCognify: new(bool)})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if err := g.PinMemory(ctx, kept.MemoryID, "core"); err != nil {
+		t.Fatalf("PinMemory failed: %v", err)
+	}
+	if err := g.DeleteMemory(ctx, deleted.MemoryID); err != nil {
+		t.Fatalf("DeleteMemory failed: %v", err)
+	}
+	if _, err := g.Prune(ctx, PruneOptions{MaxAgeDays: 365}); err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+
+	stats, err := g.Stats()
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.MemoriesByStatus["Pinned"] != 1 || stats.MemoriesByRetentionPolicy["decision"] != 1 || stats.PinnedMemories != 1 {
+		t.Errorf("Unexpected memory breakdown: %v, %v, %d pinned", stats.MemoriesByStatus, stats.MemoriesByRetentionPolicy, stats.PinnedMemories)
+	}
+	if stats.AverageMemoryScore != 1 {
+		t.Errorf("Expected the pinned memory to score 1, got %v", stats.AverageMemoryScore)
+	}
+	if stats.NodesByType["Technology"] != 1 || stats.NodesByType["Person"] != 1 {
+		t.Errorf("Unexpected node breakdown: %v", stats.NodesByType)
+	}
+	if stats.DatabaseSizeBytes <= 0 {
+		t.Errorf("Expected a database size, got %d", stats.DatabaseSizeBytes)
+	}
+	if stats.LastGC.IsZero() || stats.LastPrune.IsZero() || !stats.LastMaintenance.IsZero() {
+		t.Errorf("Expected GC and prune runs recorded and no maintenance, got %v, %v, %v", stats.LastGC, stats.LastPrune, stats.LastMaintenance)
+	}
+}
+
 // TestSearchWithMockedDependencies exercises Search path using injected mocks.
 func TestSearchWithMockedDependencies(t *testing.T) {
 	g, err := New(Config{DBPath: ":memory:"})
@@ -1341,6 +1442,55 @@ func TestAddMemory_Deduplication(t *testing.T) {
 	}
 }
 
+// promptRecordingLLM records the prompts it receives and answers like MockLLMClient
+type promptRecordingLLM struct {
+	MockLLMClient
+	prompts []string
+}
+
+func (m *promptRecordingLLM) CompleteWithSchema(ctx context.Context, prompt string, schema interface{}) error {
+	m.prompts = append(m.prompts, prompt)
+	return m.MockLLMClient.CompleteWithSchema(ctx, prompt, schema)
+}
+
+// TestAddMemory_AutoCognify validates that extraction covers the decisions and can be
+// turned off.
+func TestAddMemory_AutoCognify(t *testing.T) {
+	ctx := context.Background()
+	mockLLM := &promptRecordingLLM{}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	result, err := g.AddMemory(ctx, MemoryInput{
+		Topic:     "Storage",
+		Context:   "We need an embedded database.",
+		Decisions: []string{"Adopt SQLite"},
+	})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if result.NodesCreated == 0 || len(mockLLM.prompts) == 0 || !strings.Contains(mockLLM.prompts[0], "Adopt SQLite") {
+		t.Errorf("Expected extraction over the decisions, got %d nodes and prompts %q", result.NodesCreated, mockLLM.prompts)
+	}
+
+	mockLLM.prompts = nil
+	off := false
+	result, err = g.AddMemory(ctx, MemoryInput{Topic: "Meetings", Context: "Standups at ten.", AutoCognify: &off})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if result.NodesCreated != 0 || len(mockLLM.prompts) != 0 {
+		t.Errorf("Expected no extraction, got %d nodes and %d prompts", result.NodesCreated, len(mockLLM.prompts))
+	}
+	memory, err := g.GetMemory(ctx, result.MemoryID)
+	if err != nil || memory.Status != "complete" {
+		t.Errorf("Expected the memory stored as complete, got %+v (err %v)", memory, err)
+	}
+}
+
 // TestListMemories validates pagination.
 func TestListMemories(t *testing.T) {
 	ctx := context.Background()
@@ -1508,6 +1658,38 @@ func TestDeleteMemory(t *testing.T) {
 	}
 }
 
+// TestDeleteMemory_DropsNodeVectors validates GC deletes the vectors of the nodes it deletes.
+func TestDeleteMemory_DropsNodeVectors(t *testing.T) {
+	ctx := context.Background()
+
+	vectors := store.NewMemoryVectorStore()
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{{Name: "ToDelete", Type: "Concept", Description: "Entity to delete"}},
+		},
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:", VectorStore: vectors}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	result, err := g.AddMemory(ctx, MemoryInput{Topic: "Test", Context: "Test context"})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if hits, _ := vectors.Search(ctx, deterministicEmbedding("ToDelete"), 10); len(hits) != 1 {
+		t.Fatalf("expected 1 node vector after AddMemory, got %d", len(hits))
+	}
+
+	if err := g.DeleteMemory(ctx, result.MemoryID); err != nil {
+		t.Fatalf("DeleteMemory failed: %v", err)
+	}
+	if hits, _ := vectors.Search(ctx, deterministicEmbedding("ToDelete"), 10); len(hits) != 0 {
+		t.Errorf("expected node vectors deleted with their nodes, got %v", hits)
+	}
+}
+
 // TestDeleteMemory_PreservesSharedNodes validates GC preserves shared nodes.
 func TestDeleteMemory_PreservesSharedNodes(t *testing.T) {
 	ctx := context.Background()
@@ -1679,3 +1861,411 @@ func TestGarbageCollect_Placeholder(t *testing.T) {
 		t.Errorf("Expected (0,0) from placeholder, got (%d,%d)", nodesDeleted, edgesDeleted)
 	}
 }
+
+// TestCognify_MinExtractionConfidence verifies low-confidence entities and relations never reach the graph
+func TestCognify_MinExtractionConfidence(t *testing.T) {
+	g, err := New(Config{DBPath: ":memory:", MinExtractionConfidence: 0.5})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{
+				{Name: "React", Type: "Technology", Description: "A JavaScript library", Confidence: 0.9},
+				{Name: "TypeScript", Type: "Technology", Description: "A typed superset of JavaScript", Confidence: 0.8},
+				{Name: "The team", Type: "Organization", Description: "Some team", Confidence: 0.1},
+			},
+		},
+		RelationResponses: [][]extraction.Triplet{
+			{
+				{Subject: "React", Relation: "USES", Object: "TypeScript", Confidence: 0.9},
+				{Subject: "TypeScript", Relation: "RELATES_TO", Object: "React", Confidence: 0.2},
+			},
+		},
+	}
+	g.llm = mockLLM
+	g.embeddings = &MockEmbeddingClient{}
+	g.entityExtractor = extraction.NewEntityExtractor(mockLLM)
+	g.relationExtractor = extraction.NewRelationExtractor(mockLLM)
+
+	ctx := context.Background()
+	g.Add(ctx, "The team says React uses TypeScript", AddOptions{})
+
+	result, err := g.Cognify(ctx, CognifyOptions{})
+	if err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+
+	if result.NodesCreated != 2 || result.EntitiesFiltered != 1 {
+		t.Errorf("Expected 2 nodes created and 1 entity filtered, got %d and %d", result.NodesCreated, result.EntitiesFiltered)
+	}
+	if result.EdgesCreated != 1 || result.RelationsFiltered != 1 {
+		t.Errorf("Expected 1 edge created and 1 relation filtered, got %d and %d", result.EdgesCreated, result.RelationsFiltered)
+	}
+
+	nodes, err := g.graphStore.FindNodesByName(ctx, "The team")
+	if err != nil {
+		t.Fatalf("FindNodesByName failed: %v", err)
+	}
+	if len(nodes) != 0 {
+		t.Errorf("Expected low-confidence entity to be dropped, found %d nodes", len(nodes))
+	}
+}
+
+func TestNew_MinExtractionConfidenceValidation(t *testing.T) {
+	if _, err := New(Config{MinExtractionConfidence: 1.5}); err == nil {
+		t.Error("Expected error for MinExtractionConfidence above 1")
+	}
+}
+
+func TestNew_OntologyValidation(t *testing.T) {
+	ontology := &extraction.Ontology{UnknownTypes: "ignore"}
+	if _, err := New(Config{Ontology: ontology}); err == nil {
+		t.Error("Expected error for an invalid Ontology")
+	}
+
+	ontology = &extraction.Ontology{EntityTypes: []extraction.OntologyType{{Name: "Service"}}}
+	g, err := New(Config{Ontology: ontology})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+	if g.entityExtractor.Ontology != ontology || g.relationExtractor.Ontology != ontology {
+		t.Error("Expected the ontology set on the extractors")
+	}
+}
+
+// TestCognify_TracksMentionCounts verifies each chunk mentioning an entity increments its count
+func TestCognify_TracksMentionCounts(t *testing.T) {
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	react := extraction.Entity{Name: "React", Type: "Technology", Description: "A JavaScript library"}
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{react, {Name: "Vite", Type: "Technology", Description: "A build tool"}},
+			{react, react}, // Duplicate mentions in one chunk count once
+		},
+	}
+	g.llm = mockLLM
+	g.embeddings = &MockEmbeddingClient{}
+	g.entityExtractor = extraction.NewEntityExtractor(mockLLM)
+	g.relationExtractor = extraction.NewRelationExtractor(mockLLM)
+
+	ctx := context.Background()
+	g.Add(ctx, "React apps are built with Vite.", AddOptions{})
+	g.Add(ctx, "React renders components.", AddOptions{})
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+
+	want := map[string]int64{"React": 2, "Vite": 1}
+	for name, count := range want {
+		node, err := g.graphStore.FindNodeByName(ctx, name)
+		if err != nil {
+			t.Fatalf("FindNodeByName(%s) failed: %v", name, err)
+		}
+		if node.MentionCount != count {
+			t.Errorf("%s: expected MentionCount %d, got %d", name, count, node.MentionCount)
+		}
+	}
+}
+
+// TestCognify_EntityFilter verifies junk entities are dropped and counted
+func TestCognify_EntityFilter(t *testing.T) {
+	g, err := New(Config{DBPath: ":memory:", EntityFilter: extraction.NewEntityFilter()})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{
+				{Name: "React", Type: "Technology", Description: "A JavaScript library"},
+				{Name: "It", Type: "Concept", Description: "A pronoun"},
+				{Name: "2024-06-01", Type: "Concept", Description: "A date"},
+			},
+		},
+		RelationResponses: [][]extraction.Triplet{{}},
+	}
+	g.llm = mockLLM
+	g.embeddings = &MockEmbeddingClient{}
+	g.entityExtractor = extraction.NewEntityExtractor(mockLLM)
+	g.relationExtractor = extraction.NewRelationExtractor(mockLLM)
+
+	ctx := context.Background()
+	g.Add(ctx, "On 2024-06-01 we adopted React. It works well.", AddOptions{})
+
+	result, err := g.Cognify(ctx, CognifyOptions{})
+	if err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	if result.NodesCreated != 1 || result.JunkEntitiesFiltered != 2 {
+		t.Errorf("Expected 1 node created and 2 junk entities filtered, got %d and %d", result.NodesCreated, result.JunkEntitiesFiltered)
+	}
+}
+
+// nameReranker prefers documents naming a fixed entity
+type nameReranker struct {
+	name string
+}
+
+func (n *nameReranker) Rerank(ctx context.Context, query string, documents []string) ([]float64, error) {
+	scores := make([]float64, len(documents))
+	for i, document := range documents {
+		if strings.HasPrefix(document, n.name) {
+			scores[i] = 1
+		}
+	}
+	return scores, nil
+}
+
+func TestSearch_Rerank(t *testing.T) {
+	ctx := context.Background()
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{
+			{Name: "Kubernetes", Type: "Technology", Description: "Container orchestrator"},
+			{Name: "Grafana", Type: "Technology", Description: "Dashboards"},
+		}},
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:", Reranker: &nameReranker{name: "Grafana"}}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	if _, err := g.AddMemory(ctx, MemoryInput{Topic: "Platform", Context: "Kubernetes with Grafana dashboards."}); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	opts := search.SearchOptions{Type: search.SearchTypeVector, TopK: 1}
+	response, err := g.Search(ctx, "Kubernetes", opts)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(response.Results) != 1 || response.Results[0].Node.Name != "Kubernetes" {
+		t.Fatalf("Expected Kubernetes without reranking, got %+v", response.Results)
+	}
+
+	opts.Rerank = true
+	response, err = g.Search(ctx, "Kubernetes", opts)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(response.Results) != 1 || response.Results[0].Node.Name != "Grafana" {
+		t.Errorf("Expected the reranker's choice Grafana, got %+v", response.Results)
+	}
+}
+
+func TestSearch_IncludeMemories(t *testing.T) {
+	ctx := context.Background()
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{{Name: "Kubernetes", Type: "Technology", Description: "Container orchestrator"}},
+			{{Name: "Kubernetes", Type: "Technology", Description: "Container orchestrator"}},
+		},
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	first, err := g.AddMemory(ctx, MemoryInput{Topic: "Platform", Context: "We run on Kubernetes."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	second, err := g.AddMemory(ctx, MemoryInput{Topic: "Upgrade", Context: "Kubernetes was upgraded to 1.30."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	includeMemoryIDs := false
+	response, err := g.Search(ctx, "Kubernetes", search.SearchOptions{
+		Type:             search.SearchTypeVector,
+		TopK:             1,
+		IncludeMemoryIDs: &includeMemoryIDs, // Overridden by IncludeMemories
+		IncludeMemories:  true,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(response.Results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(response.Results))
+	}
+	memories := response.Results[0].Memories
+	if len(memories) != 2 {
+		t.Fatalf("Expected both memories, got %+v", memories)
+	}
+	got := map[string]string{memories[0].ID: memories[0].Topic, memories[1].ID: memories[1].Topic}
+	if got[first.MemoryID] != "Platform" || got[second.MemoryID] != "Upgrade" {
+		t.Errorf("Expected the summaries of both memories, got %+v", memories)
+	}
+	if memories[0].CurrentScore < memories[1].CurrentScore {
+		t.Errorf("Expected memories ordered by score, got %+v", memories)
+	}
+
+	response, err = g.Search(ctx, "Kubernetes", search.SearchOptions{
+		Type: search.SearchTypeVector, TopK: 1, IncludeMemories: true, MaxMemoriesPerResult: 1,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if n := len(response.Results[0].Memories); n != 1 {
+		t.Errorf("Expected MaxMemoriesPerResult to cap memories at 1, got %d", n)
+	}
+
+	// A pinned memory does not decay, so it is kept over the newer one
+	if err := g.PinMemory(ctx, first.MemoryID, "baseline"); err != nil {
+		t.Fatalf("PinMemory failed: %v", err)
+	}
+	response, err = g.Search(ctx, "Kubernetes", search.SearchOptions{
+		Type: search.SearchTypeVector, TopK: 1, IncludeMemories: true, MaxMemoriesPerResult: 1,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if kept := response.Results[0].Memories; len(kept) != 1 || kept[0].ID != first.MemoryID || kept[0].CurrentScore != 1 {
+		t.Errorf("Expected the pinned memory kept, got %+v", kept)
+	}
+
+	response, err = g.Search(ctx, "Kubernetes", search.SearchOptions{Type: search.SearchTypeVector, TopK: 1})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if response.Results[0].Memories != nil {
+		t.Errorf("Expected no memories without IncludeMemories")
+	}
+}
+
+func TestNewWithClients_VectorBackend(t *testing.T) {
+	mockLLM := &MockLLMClient{}
+
+	g, err := NewWithClients(Config{
+		DBPath:        ":memory:",
+		VectorBackend: VectorBackendQdrant,
+		QdrantURL:     "http://localhost:6333",
+	}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+	if _, ok := g.GetVectorStore().(*store.QdrantVectorStore); !ok {
+		t.Errorf("expected a Qdrant vector store, got %T", g.GetVectorStore())
+	}
+
+	if _, err := NewWithClients(Config{DBPath: ":memory:", VectorBackend: VectorBackendQdrant}, &MockEmbeddingClient{}, mockLLM); err == nil {
+		t.Error("expected error for the qdrant backend without QdrantURL")
+	}
+	if _, err := NewWithClients(Config{DBPath: ":memory:", VectorBackend: "faiss"}, &MockEmbeddingClient{}, mockLLM); err == nil {
+		t.Error("expected error for an unknown vector backend")
+	}
+}
+
+func TestNewWithClients_CustomVectorStore(t *testing.T) {
+	vectors := store.NewMemoryVectorStore()
+	g, err := NewWithClients(Config{DBPath: ":memory:", VectorStore: vectors}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	if g.GetVectorStore() != vectors {
+		t.Errorf("expected the configured vector store, got %T", g.GetVectorStore())
+	}
+}
+
+func TestNew_EmbeddingBaseURL(t *testing.T) {
+	g, err := New(Config{
+		DBPath:           ":memory:",
+		OpenAIKey:        "sk-openai",
+		EmbeddingBaseURL: "http://localhost:8080",
+		EmbeddingAPIKey:  "local-token",
+		EmbeddingModel:   "nomic-embed-text",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	client, ok := g.GetEmbeddings().(*embeddings.LocalClient)
+	if !ok {
+		t.Fatalf("expected a local embedding client, got %T", g.GetEmbeddings())
+	}
+	if client.BaseURL != "http://localhost:8080" || client.Model != "nomic-embed-text" {
+		t.Errorf("unexpected client settings: %+v", client)
+	}
+	// The OpenAI key must not leak to a third-party embedding server.
+	if client.APIKey != "local-token" {
+		t.Errorf("expected the embedding API key as bearer token, got %q", client.APIKey)
+	}
+}
+
+func TestNew_AzureOpenAI(t *testing.T) {
+	g, err := New(Config{
+		DBPath:              ":memory:",
+		OpenAIKey:           "azure-key",
+		AzureOpenAIEndpoint: "https://my-resource.openai.azure.com",
+		EmbeddingModel:      "embed-prod",
+		LLMModel:            "chat-prod",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	client, ok := g.GetEmbeddings().(*embeddings.OpenAIClient)
+	if !ok || client.Model != "embed-prod" || client.APIVersion != embeddings.DefaultAzureAPIVersion {
+		t.Errorf("expected an Azure embedding client for embed-prod, got %+v", g.GetEmbeddings())
+	}
+	llmClient, ok := g.llm.(*llm.OpenAILLM)
+	if !ok || llmClient.Model != "chat-prod" || llmClient.APIVersion != llm.DefaultAzureAPIVersion {
+		t.Errorf("expected an Azure LLM client for chat-prod, got %+v", g.llm)
+	}
+}
+
+func TestCognify_IdempotentDocuments(t *testing.T) {
+	ctx := context.Background()
+	mockLLM := &MockLLMClient{}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	notes := "Standup: Alice migrates the billing service to Go."
+	_ = g.Add(ctx, notes, AddOptions{Source: "monday.md"})
+	_ = g.Add(ctx, notes, AddOptions{Source: "monday-copy.md"})
+	if g.BufferedCount() != 1 {
+		t.Fatalf("expected the duplicate to be dropped by Add, got %d buffered", g.BufferedCount())
+	}
+
+	result, err := g.Cognify(ctx, CognifyOptions{})
+	if err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	if result.DocumentsProcessed != 1 || result.DocumentsSkipped != 1 {
+		t.Errorf("expected 1 processed and 1 skipped, got %+v", result)
+	}
+	calls := mockLLM.CallCount
+	stats, _ := g.Stats()
+
+	// Re-feeding the same notes later is skipped by hash without touching the graph
+	_ = g.Add(ctx, notes, AddOptions{Source: "monday.md"})
+	result, err = g.Cognify(ctx, CognifyOptions{})
+	if err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	if result.DocumentsProcessed != 0 || result.DocumentsSkipped != 1 || mockLLM.CallCount != calls {
+		t.Errorf("expected the re-fed notes to be skipped without LLM calls, got %+v", result)
+	}
+	if after, _ := g.Stats(); after.NodeCount != stats.NodeCount || after.EdgeCount != stats.EdgeCount {
+		t.Errorf("expected an unchanged graph, got %+v then %+v", stats, after)
+	}
+}
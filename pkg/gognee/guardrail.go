@@ -0,0 +1,69 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+const (
+	// guardrailFlaggedKey is the memory metadata key set when Config.Guardrail rejected or
+	// sanitized content extracted from the memory
+	guardrailFlaggedKey = "guardrail_flagged"
+
+	// guardrailViolationsKey is the memory metadata key listing the guardrail violations
+	guardrailViolationsKey = "guardrail_violations"
+)
+
+// FlaggedDocument is an ingested document whose extraction output tripped Config.Guardrail,
+// a sign that it may contain prompt-injection content.
+type FlaggedDocument struct {
+	Source     string   `json:"source"`
+	Hash       string   `json:"hash"`
+	Violations []string `json:"violations"`
+	// QuarantineID identifies the quarantine holding the document's nodes
+	// (Config.QuarantineFlagged), empty if it was not quarantined.
+	QuarantineID string `json:"quarantine_id,omitempty"`
+}
+
+// logGuardrail warns about a document or memory whose extraction output tripped the guardrail
+func (g *Gognee) logGuardrail(ctx context.Context, kind, id string, report extraction.GuardrailReport) {
+	if g.logger == nil || !report.Flagged() {
+		return
+	}
+	g.logger.LogAttrs(ctx, slog.LevelWarn, "extraction guardrail flagged "+kind,
+		slog.String("id", id),
+		slog.Int("rejected", report.Rejected),
+		slog.Int("sanitized", report.Sanitized),
+		slog.Any("violations", report.Violations),
+	)
+}
+
+// flagMemory records guardrail violations in a memory's metadata
+func (g *Gognee) flagMemory(ctx context.Context, memoryID string, report extraction.GuardrailReport) error {
+	memory, err := g.memoryStore.GetMemory(ctx, memoryID)
+	if err != nil {
+		return err
+	}
+
+	metadata := memory.Metadata
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	setGuardrailMetadata(metadata, report)
+	if err := g.memoryStore.UpdateMemory(ctx, memoryID, store.MemoryUpdate{Metadata: &metadata}); err != nil {
+		return fmt.Errorf("failed to flag memory: %w", err)
+	}
+	g.logGuardrail(ctx, "memory", memoryID, report)
+	return nil
+}
+
+// setGuardrailMetadata marks memory metadata as flagged by the guardrail
+func setGuardrailMetadata(metadata map[string]interface{}, report extraction.GuardrailReport) {
+	metadata[guardrailFlaggedKey] = time.Now().UTC().Format(time.RFC3339)
+	metadata[guardrailViolationsKey] = report.Violations
+}
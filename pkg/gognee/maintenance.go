@@ -0,0 +1,128 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// MaintenanceResult reports the outcome of a RunMaintenance() pass
+type MaintenanceResult struct {
+	EdgesExpired   int64 // Edges removed because their ExpiresAt has passed
+	NotesEnriched  int   // Quick-added notes enriched (see Config.EnrichmentBatchSize)
+	EntitiesMerged int   // Alias nodes merged (see Config.EntityResolution)
+	SessionsClosed int   // Expired sessions whose memories were purged (see OpenSession)
+	DurationMs     int64
+}
+
+// RunMaintenance performs one pass of periodic housekeeping: it sweeps edges whose
+// expiry (see Config.RelationTTLs and store.Edge.ExpiresAt) has passed, purges the
+// memories of expired sessions (see OpenSession), enriches up to
+// Config.EnrichmentBatchSize quick-added notes, then resolves entity aliases when
+// Config.EntityResolution is set.
+// Expired edges are already hidden from search; this reclaims their storage.
+// It is called by the background scheduler when Config.MaintenanceInterval is set.
+func (g *Gognee) RunMaintenance(ctx context.Context) (*MaintenanceResult, error) {
+	expirer, ok := g.graphStore.(store.EdgeExpirer)
+	if !ok {
+		return nil, fmt.Errorf("maintenance requires a graph store implementing store.EdgeExpirer")
+	}
+
+	start := time.Now()
+	result := &MaintenanceResult{}
+
+	expired, err := expirer.DeleteExpiredEdges(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	g.dropEdgeVectors(ctx, expired)
+	result.EdgesExpired = int64(len(expired))
+
+	closed, err := g.PurgeExpiredSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.SessionsClosed = closed
+
+	if g.config.EnrichmentBatchSize > 0 {
+		enriched, err := g.EnrichNotes(ctx, g.config.EnrichmentBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		result.NotesEnriched = enriched.NotesEnriched
+	}
+
+	if g.config.EntityResolution != "" {
+		resolved, err := g.ResolveEntities(ctx, ResolveEntitiesOptions{})
+		if err != nil {
+			return nil, err
+		}
+		result.EntitiesMerged = len(resolved.Merges)
+	}
+
+	result.DurationMs = time.Since(start).Milliseconds()
+	g.recordHousekeeping(ctx, store.HousekeepingMaintenance)
+
+	if g.logger != nil {
+		g.logger.LogAttrs(ctx, slog.LevelInfo, "maintenance complete",
+			slog.Int64("edges_expired", result.EdgesExpired),
+			slog.Int("notes_enriched", result.NotesEnriched),
+			slog.Int("entities_merged", result.EntitiesMerged),
+			slog.Int("sessions_closed", result.SessionsClosed),
+			slog.Int64("duration_ms", result.DurationMs),
+		)
+	}
+
+	return result, nil
+}
+
+// relationExpiry returns the expiry time for a new edge with the given relation,
+// or nil if no TTL is configured for it.
+func (g *Gognee) relationExpiry(relation string, createdAt time.Time) *time.Time {
+	ttl, ok := g.relationTTLs[sanitizeRelation(relation)]
+	if !ok {
+		return nil
+	}
+	expiresAt := createdAt.Add(ttl)
+	return &expiresAt
+}
+
+// startMaintenance launches the background maintenance scheduler.
+func (g *Gognee) startMaintenance(interval time.Duration) {
+	g.maintenanceStop = make(chan struct{})
+	g.maintenanceDone = make(chan struct{})
+
+	go func() {
+		defer close(g.maintenanceDone)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-g.maintenanceStop:
+				return
+			case <-ticker.C:
+				if _, err := g.RunMaintenance(context.Background()); err != nil && g.logger != nil {
+					g.logger.LogAttrs(context.Background(), slog.LevelWarn, "maintenance failed",
+						slog.String("error", err.Error()),
+					)
+				}
+			}
+		}
+	}()
+}
+
+// stopMaintenance stops the background scheduler and waits for an in-flight pass to finish.
+// Safe to call when the scheduler was never started or has already stopped.
+func (g *Gognee) stopMaintenance() {
+	if g.maintenanceStop == nil {
+		return
+	}
+	close(g.maintenanceStop)
+	<-g.maintenanceDone
+	g.maintenanceStop = nil
+}
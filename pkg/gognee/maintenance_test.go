@@ -0,0 +1,136 @@
+package gognee
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestCognify_AppliesRelationTTLs(t *testing.T) {
+	llmClient := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{
+			{Name: "Alice", Type: "Person", Description: "engineer"},
+			{Name: "Payments", Type: "System", Description: "service"},
+		}},
+		RelationResponses: [][]extraction.Triplet{{
+			{Subject: "Alice", Relation: "ON_CALL", Object: "Payments"},
+			{Subject: "Alice", Relation: "OWNS", Object: "Payments"},
+		}},
+	}
+	g, err := NewWithClients(Config{
+		DBPath:       ":memory:",
+		RelationTTLs: map[string]time.Duration{"on call": 24 * time.Hour},
+	}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	ctx := context.Background()
+	if err := g.Add(ctx, "Alice is on call for Payments, which she owns.", AddOptions{}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+
+	edges, err := g.graphStore.GetEdges(ctx, generateDeterministicNodeID("Alice", "Person"))
+	if err != nil {
+		t.Fatalf("GetEdges failed: %v", err)
+	}
+	if len(edges) != 2 {
+		t.Fatalf("Expected 2 edges, got %d", len(edges))
+	}
+	for _, e := range edges {
+		switch e.Relation {
+		case "ON_CALL":
+			if e.ExpiresAt == nil {
+				t.Errorf("Expected ON_CALL edge to expire")
+			} else if d := e.ExpiresAt.Sub(e.CreatedAt); d != 24*time.Hour {
+				t.Errorf("Expected 24h TTL, got %v", d)
+			}
+		case "OWNS":
+			if e.ExpiresAt != nil {
+				t.Errorf("Expected OWNS edge to never expire, got %v", e.ExpiresAt)
+			}
+		}
+	}
+}
+
+func TestNewWithClients_InvalidRelationTTL(t *testing.T) {
+	_, err := NewWithClients(Config{
+		DBPath:       ":memory:",
+		RelationTTLs: map[string]time.Duration{"ON_CALL": 0},
+	}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err == nil {
+		t.Fatal("Expected error for non-positive TTL")
+	}
+}
+
+func TestRunMaintenance_SweepsExpiredEdges(t *testing.T) {
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+	seedExportGraph(t, g)
+
+	ctx := context.Background()
+	past := time.Now().Add(-time.Minute)
+	if err := g.graphStore.AddEdge(ctx, &store.Edge{ID: "e2", SourceID: "n2", Relation: "ON_CALL", TargetID: "n1", ExpiresAt: &past}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+
+	result, err := g.RunMaintenance(ctx)
+	if err != nil {
+		t.Fatalf("RunMaintenance failed: %v", err)
+	}
+	if result.EdgesExpired != 1 {
+		t.Errorf("Expected 1 expired edge, got %d", result.EdgesExpired)
+	}
+	count, err := g.graphStore.EdgeCount(ctx)
+	if err != nil {
+		t.Fatalf("EdgeCount failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 edge remaining, got %d", count)
+	}
+}
+
+func TestMaintenanceScheduler(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "maintenance.db")
+	g, err := New(Config{DBPath: dbPath, MaintenanceInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	seedExportGraph(t, g)
+
+	ctx := context.Background()
+	soon := time.Now().Add(20 * time.Millisecond)
+	if err := g.graphStore.AddEdge(ctx, &store.Edge{ID: "e2", SourceID: "n2", Relation: "ON_CALL", TargetID: "n1", ExpiresAt: &soon}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		count, err := g.graphStore.EdgeCount(ctx)
+		if err != nil {
+			t.Fatalf("EdgeCount failed: %v", err)
+		}
+		if count == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expired edge was not swept by the scheduler")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := g.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+}
@@ -0,0 +1,309 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// ImportOptions configures ImportMarkdown.
+type ImportOptions struct {
+	// Force re-imports every file, including files unchanged since the last import.
+	Force bool
+
+	// RemoveDeleted deletes the documents of files imported earlier that no longer exist,
+	// along with the nodes and edges only they produced (see DeleteDocument).
+	RemoveDeleted bool
+
+	// Cognify configures the Cognify run over the imported files. Force is implied by
+	// ImportOptions.Force.
+	Cognify CognifyOptions
+}
+
+// ImportResult reports the outcome of ImportMarkdown.
+type ImportResult struct {
+	FilesScanned   int            // Markdown files found
+	FilesImported  int            // New or changed files added and cognified
+	FilesUnchanged int            // Files skipped because they did not change since the last import
+	FilesRemoved   int            // Files imported earlier that no longer exist (RemoveDeleted)
+	Cognify        *CognifyResult // Result of cognifying the imported files; nil if none changed
+}
+
+// wikiLinkPattern matches [[Target]], [[Target|Display]], [[Target#Heading]] and embeds (![[...]])
+var wikiLinkPattern = regexp.MustCompile(`!?\[\[([^\]|#]*)(#[^\]|]*)?(?:\|([^\]]*))?\]\]`)
+
+// ImportMarkdown imports a directory of markdown files, such as an Obsidian vault. Each
+// .md file (hidden directories like .obsidian are skipped) is added with its Source set
+// to its slash-separated path relative to dir, and the files are then cognified.
+//
+// Front matter (title, aliases, tags and other scalar fields) and the targets of
+// wiki-links ([[Note]], [[Note|text]]) are written as a header above the body, so
+// extraction sees them as hints; wiki-links in the body are replaced by their text.
+//
+// The document hash of each imported file is recorded, so importing the same directory
+// again only processes new and changed files. A changed file's previous document is
+// deleted with the nodes and edges only it produced. Files are only recorded once
+// Cognify processed all of them; deferred or rolled-back runs are retried by the next
+// import. Requires a graph store implementing store.ImportTracker.
+func (g *Gognee) ImportMarkdown(ctx context.Context, dir string, opts ImportOptions) (*ImportResult, error) {
+	imports, ok := g.graphStore.(store.ImportTracker)
+	if !ok {
+		return nil, fmt.Errorf("markdown import requires a graph store implementing store.ImportTracker")
+	}
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve import directory: %w", err)
+	}
+	imported, err := imports.ImportedFiles(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	type changedFile struct {
+		path, hash, previous string
+	}
+	var changed []changedFile
+	seen := make(map[string]bool)
+	result := &ImportResult{}
+	err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		result.FilesScanned++
+		seen[rel] = true
+
+		text := parseMarkdownNote(rel, string(content)).render()
+		hash := computeDocumentHash(text)
+		if !opts.Force && imported[rel] == hash {
+			result.FilesUnchanged++
+			return nil
+		}
+		if err := g.Add(ctx, text, AddOptions{Source: rel}); err != nil {
+			return fmt.Errorf("failed to add %s: %w", rel, err)
+		}
+		changed = append(changed, changedFile{path: rel, hash: hash, previous: imported[rel]})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import %s: %w", dir, err)
+	}
+
+	if len(changed) > 0 {
+		cognifyOpts := opts.Cognify
+		cognifyOpts.Force = cognifyOpts.Force || opts.Force
+		if result.Cognify, err = g.Cognify(ctx, cognifyOpts); err != nil {
+			return nil, err
+		}
+		if result.Cognify.DocumentsDeferred > 0 || result.Cognify.DocumentsRolledBack > 0 {
+			return result, nil
+		}
+		for _, file := range changed {
+			if file.previous != "" && file.previous != file.hash {
+				if err := g.forgetDocument(ctx, file.previous); err != nil {
+					return nil, err
+				}
+			}
+			if err := imports.RecordImportedFile(ctx, root, file.path, file.hash); err != nil {
+				return nil, err
+			}
+			result.FilesImported++
+		}
+	}
+
+	if opts.RemoveDeleted {
+		for path, hash := range imported {
+			if seen[path] {
+				continue
+			}
+			if err := g.forgetDocument(ctx, hash); err != nil {
+				return nil, err
+			}
+			if err := imports.ForgetImportedFile(ctx, root, path); err != nil {
+				return nil, err
+			}
+			result.FilesRemoved++
+		}
+	}
+	return result, nil
+}
+
+// forgetDocument deletes a document with the nodes and edges only it produced; unknown
+// documents are ignored
+func (g *Gognee) forgetDocument(ctx context.Context, hash string) error {
+	if _, _, err := g.DeleteDocument(ctx, hash, true); err != nil && !errors.Is(err, store.ErrDocumentNotFound) {
+		return err
+	}
+	return nil
+}
+
+// markdownNote is a markdown file split into its front matter, links and body.
+type markdownNote struct {
+	Title   string
+	Aliases []string
+	Tags    []string
+	Fields  [][2]string // Other front-matter fields as key and value, in file order
+	Links   []string    // Wiki-link targets, in order of first appearance
+	Body    string      // Body with wiki-links replaced by their text
+}
+
+// parseMarkdownNote parses the markdown file at path. The title defaults to the file
+// name without extension. Front matter is read as flat YAML: "key: value" lines, with
+// lists written inline ([a, b]) or as "- item" lines.
+func parseMarkdownNote(path, content string) markdownNote {
+	note := markdownNote{Title: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))}
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	body := content
+	if rest, ok := strings.CutPrefix(content, "---\n"); ok {
+		if end := strings.Index(rest, "\n---"); end >= 0 || strings.HasPrefix(rest, "---") {
+			frontMatter := ""
+			if end >= 0 {
+				frontMatter = rest[:end]
+				body = rest[end+len("\n---"):]
+			} else {
+				body = rest[len("---"):]
+			}
+			// Drop the rest of the closing delimiter line
+			if i := strings.Index(body, "\n"); i >= 0 {
+				body = body[i+1:]
+			} else {
+				body = ""
+			}
+			note.parseFrontMatter(frontMatter)
+		}
+	}
+
+	seen := make(map[string]bool)
+	note.Body = strings.TrimSpace(wikiLinkPattern.ReplaceAllStringFunc(body, func(link string) string {
+		parts := wikiLinkPattern.FindStringSubmatch(link)
+		target := strings.TrimSpace(parts[1])
+		if target != "" && !seen[target] {
+			seen[target] = true
+			note.Links = append(note.Links, target)
+		}
+		if display := strings.TrimSpace(parts[3]); display != "" {
+			return display
+		}
+		if target == "" {
+			return strings.TrimPrefix(parts[2], "#")
+		}
+		return target
+	}))
+	return note
+}
+
+// parseFrontMatter reads the fields of a front-matter block into the note
+func (n *markdownNote) parseFrontMatter(frontMatter string) {
+	var key string
+	var values []string
+	flush := func() {
+		if key == "" {
+			return
+		}
+		switch strings.ToLower(key) {
+		case "title":
+			if len(values) > 0 && values[0] != "" {
+				n.Title = values[0]
+			}
+		case "aliases", "alias":
+			n.Aliases = append(n.Aliases, values...)
+		case "tags", "tag":
+			for _, tag := range values {
+				n.Tags = append(n.Tags, strings.TrimPrefix(tag, "#"))
+			}
+		default:
+			if value := strings.Join(values, ", "); value != "" {
+				n.Fields = append(n.Fields, [2]string{key, value})
+			}
+		}
+		key, values = "", nil
+	}
+
+	for _, line := range strings.Split(frontMatter, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if item, ok := strings.CutPrefix(trimmed, "- "); ok && key != "" {
+			values = append(values, unquote(item))
+			continue
+		}
+		name, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		flush()
+		key = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		if inner, ok := strings.CutPrefix(value, "["); ok && strings.HasSuffix(inner, "]") {
+			for _, item := range strings.Split(strings.TrimSuffix(inner, "]"), ",") {
+				if item = unquote(item); item != "" {
+					values = append(values, item)
+				}
+			}
+		} else if value != "" {
+			values = append(values, unquote(value))
+		}
+	}
+	flush()
+}
+
+// unquote trims whitespace and surrounding quotes from a front-matter value
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// render writes the note as the text to cognify: a header with the title, front matter
+// and link targets, then the body.
+func (n markdownNote) render() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Title: %s\n", n.Title)
+	if len(n.Aliases) > 0 {
+		fmt.Fprintf(&b, "Also known as: %s\n", strings.Join(n.Aliases, ", "))
+	}
+	if len(n.Tags) > 0 {
+		fmt.Fprintf(&b, "Tags: %s\n", strings.Join(n.Tags, ", "))
+	}
+	for _, field := range n.Fields {
+		fmt.Fprintf(&b, "%s: %s\n", field[0], field[1])
+	}
+	if len(n.Links) > 0 {
+		fmt.Fprintf(&b, "Links to: %s\n", strings.Join(n.Links, ", "))
+	}
+	if n.Body != "" {
+		b.WriteString("\n")
+		b.WriteString(n.Body)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,129 @@
+package gognee
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseMarkdownNote(t *testing.T) {
+	content := "---\ntitle: \"Project Atlas\"\naliases: [Atlas, PA]\ntags:\n  - project\n  - \"#infra\"\nstatus: active\n---\nAtlas is owned by [[Alice]] and runs on [[Kubernetes|k8s]].\nSee [[Alice#Contact]] and ![[diagram.png]].\n"
+	note := parseMarkdownNote("projects/atlas.md", content)
+
+	if note.Title != "Project Atlas" {
+		t.Errorf("Title = %q", note.Title)
+	}
+	if strings.Join(note.Aliases, ",") != "Atlas,PA" {
+		t.Errorf("Aliases = %v", note.Aliases)
+	}
+	if strings.Join(note.Tags, ",") != "project,infra" {
+		t.Errorf("Tags = %v", note.Tags)
+	}
+	if len(note.Fields) != 1 || note.Fields[0] != [2]string{"status", "active"} {
+		t.Errorf("Fields = %v", note.Fields)
+	}
+	if strings.Join(note.Links, ",") != "Alice,Kubernetes,diagram.png" {
+		t.Errorf("Links = %v", note.Links)
+	}
+	wantBody := "Atlas is owned by Alice and runs on k8s.\nSee Alice and diagram.png."
+	if note.Body != wantBody {
+		t.Errorf("Body = %q, want %q", note.Body, wantBody)
+	}
+
+	rendered := note.render()
+	for _, want := range []string{"Title: Project Atlas\n", "Also known as: Atlas, PA\n", "Tags: project, infra\n", "status: active\n", "Links to: Alice, Kubernetes, diagram.png\n"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("rendered note missing %q:\n%s", want, rendered)
+		}
+	}
+
+	// Without front matter the title is the file name
+	if note := parseMarkdownNote("Daily Note.md", "Just text."); note.Title != "Daily Note" || note.Body != "Just text." {
+		t.Errorf("unexpected note %+v", note)
+	}
+}
+
+func TestImportMarkdown_Incremental(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	write := func(path, content string) {
+		t.Helper()
+		full := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("alice.md", "Alice works on [[Atlas]].")
+	write("projects/atlas.md", "---\ntags: [project]\n---\nAtlas is a project.")
+	write(".obsidian/workspace.md", "ignored")
+	write("image.png", "ignored")
+
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	result, err := g.ImportMarkdown(ctx, dir, ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportMarkdown failed: %v", err)
+	}
+	if result.FilesScanned != 2 || result.FilesImported != 2 || result.Cognify == nil || result.Cognify.DocumentsProcessed != 2 {
+		t.Fatalf("unexpected first import %+v", result)
+	}
+	docs, err := g.ListDocuments(ctx)
+	if err != nil || len(docs) != 2 {
+		t.Fatalf("expected 2 documents, got %+v, %v", docs, err)
+	}
+	sources := map[string]bool{}
+	for _, doc := range docs {
+		sources[doc.Source] = true
+	}
+	if !sources["alice.md"] || !sources["projects/atlas.md"] {
+		t.Errorf("expected relative paths as sources, got %+v", docs)
+	}
+
+	// Nothing changed
+	result, err = g.ImportMarkdown(ctx, dir, ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportMarkdown failed: %v", err)
+	}
+	if result.FilesUnchanged != 2 || result.FilesImported != 0 || result.Cognify != nil {
+		t.Errorf("expected no work on re-import, got %+v", result)
+	}
+
+	// One file changed, one removed
+	write("alice.md", "Alice leads [[Atlas]].")
+	if err := os.Remove(filepath.Join(dir, "projects", "atlas.md")); err != nil {
+		t.Fatal(err)
+	}
+	result, err = g.ImportMarkdown(ctx, dir, ImportOptions{RemoveDeleted: true})
+	if err != nil {
+		t.Fatalf("ImportMarkdown failed: %v", err)
+	}
+	if result.FilesImported != 1 || result.FilesRemoved != 1 || result.Cognify.DocumentsProcessed != 1 {
+		t.Errorf("unexpected incremental import %+v", result)
+	}
+	docs, _ = g.ListDocuments(ctx)
+	if len(docs) != 1 || docs[0].Source != "alice.md" || !strings.Contains(renderedImport(t, dir, "alice.md"), "Alice leads Atlas.") {
+		t.Errorf("expected only the new alice.md document, got %+v", docs)
+	}
+	if docs[0].Hash != computeDocumentHash(renderedImport(t, dir, "alice.md")) {
+		t.Errorf("expected the changed version's document, got %+v", docs[0])
+	}
+}
+
+// renderedImport returns the text ImportMarkdown adds for the file at path under dir
+func renderedImport(t *testing.T, dir, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parseMarkdownNote(path, string(content)).render()
+}
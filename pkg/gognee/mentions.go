@@ -0,0 +1,29 @@
+package gognee
+
+import (
+	"context"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// recordMentions counts one mention for each distinct entity extracted from a chunk.
+// Counting is best-effort: failures never fail the write path.
+func (g *Gognee) recordMentions(ctx context.Context, entities []extraction.Entity) {
+	counter, ok := g.graphStore.(store.MentionCounter)
+	if !ok || len(entities) == 0 {
+		return
+	}
+
+	seen := make(map[string]bool, len(entities))
+	nodeIDs := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		id := g.nodeID(entity.Name, entity.Type)
+		if !seen[id] {
+			seen[id] = true
+			nodeIDs = append(nodeIDs, id)
+		}
+	}
+
+	_ = counter.IncrementMentionCounts(ctx, nodeIDs)
+}
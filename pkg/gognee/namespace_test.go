@@ -0,0 +1,68 @@
+package gognee
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestNamespace_NodeIDs(t *testing.T) {
+	def := &Gognee{config: Config{}}
+	projA := &Gognee{config: Config{Namespace: "project-a"}}
+	projB := &Gognee{config: Config{Namespace: "project-b"}}
+
+	if got, want := def.nodeID("SQLite", "Technology"), generateDeterministicNodeID("SQLite", "Technology"); got != want {
+		t.Errorf("Default namespace must keep existing IDs: got %s, want %s", got, want)
+	}
+	if projA.nodeID("SQLite", "Technology") == projB.nodeID("SQLite", "Technology") {
+		t.Error("Expected distinct node IDs for the same entity in different namespaces")
+	}
+	if projA.nodeID("SQLite", "Technology") != projA.nodeID("  sqlite ", "Technology") {
+		t.Error("Expected name normalization to still apply within a namespace")
+	}
+}
+
+func TestNamespace_MemoriesScopedPerInstance(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "shared.db")
+	ctx := context.Background()
+
+	open := func(namespace string) *Gognee {
+		g, err := NewWithClients(Config{DBPath: dbPath, Namespace: namespace}, &MockEmbeddingClient{}, &MockLLMClient{})
+		if err != nil {
+			t.Fatalf("NewWithClients failed: %v", err)
+		}
+		t.Cleanup(func() { g.Close() })
+		return g
+	}
+	a := open("project-a")
+	b := open("project-b")
+
+	input := MemoryInput{Topic: "Database choice", Context: "We use SQLite."}
+	resA, err := a.AddMemory(ctx, input)
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	resB, err := b.AddMemory(ctx, input)
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	// Identical content is deduplicated only within a namespace
+	if resA.MemoryID == resB.MemoryID {
+		t.Fatal("Expected separate memories per namespace")
+	}
+
+	listA, err := a.ListMemories(ctx, store.ListMemoriesOptions{})
+	if err != nil {
+		t.Fatalf("ListMemories failed: %v", err)
+	}
+	if len(listA) != 1 || listA[0].ID != resA.MemoryID {
+		t.Errorf("Expected only project-a's memory, got %+v", listA)
+	}
+
+	if _, err := b.GetMemory(ctx, resA.MemoryID); err == nil {
+		t.Error("Expected project-a memory to be invisible to project-b")
+	}
+}
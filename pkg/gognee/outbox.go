@@ -0,0 +1,41 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// ReadOutbox returns up to limit recorded mutations of Config.Namespace with a sequence
+// number above afterSeq, oldest first (limit <= 0 returns all). A consumer mirroring the
+// knowledge base applies them in order and then acknowledges the last one with
+// AckOutbox; entries not yet acknowledged are returned again after a restart, giving
+// at-least-once delivery. Requires Config.Outbox.
+func (g *Gognee) ReadOutbox(ctx context.Context, afterSeq int64, limit int) ([]store.OutboxEntry, error) {
+	outbox, ok := g.graphStore.(store.Outbox)
+	if !ok {
+		return nil, fmt.Errorf("outbox requires a graph store implementing store.Outbox")
+	}
+	return outbox.ReadOutbox(ctx, afterSeq, limit)
+}
+
+// AckOutbox acknowledges the outbox entries of Config.Namespace up to and including seq,
+// removing them. It returns the number of entries removed.
+func (g *Gognee) AckOutbox(ctx context.Context, seq int64) (int64, error) {
+	outbox, ok := g.graphStore.(store.Outbox)
+	if !ok {
+		return 0, fmt.Errorf("outbox requires a graph store implementing store.Outbox")
+	}
+	return outbox.AckOutbox(ctx, seq)
+}
+
+// DisableOutbox stops recording mutations in the outbox of the database, for every
+// namespace. Recorded entries stay readable until acknowledged.
+func (g *Gognee) DisableOutbox(ctx context.Context) error {
+	outbox, ok := g.graphStore.(store.Outbox)
+	if !ok {
+		return fmt.Errorf("outbox requires a graph store implementing store.Outbox")
+	}
+	return outbox.DisableOutbox(ctx)
+}
@@ -0,0 +1,75 @@
+package gognee
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestOutbox_SurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "outbox.db")
+	llmClient := &MockLLMClient{EntityResponses: [][]extraction.Entity{
+		{{Name: "Alpha", Type: "Concept", Description: "First"}},
+		{{Name: "Beta", Type: "Concept", Description: "Second"}},
+	}}
+	g, err := NewWithClients(Config{DBPath: dbPath, Outbox: true}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	first, err := g.AddMemory(ctx, MemoryInput{Topic: "Plan", Context: "We use Alpha."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	entries, err := g.ReadOutbox(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("ReadOutbox failed: %v", err)
+	}
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		seen[entry.Entity+":"+entry.EntityID] = true
+	}
+	if !seen[store.OutboxEntityMemory+":"+first.MemoryID] || !seen[store.OutboxEntityNode+":"+g.nodeID("Alpha", "Concept")] {
+		t.Fatalf("Expected the memory and its node in the outbox, got %+v", entries)
+	}
+	if _, err := g.AckOutbox(ctx, entries[len(entries)-1].Seq); err != nil {
+		t.Fatalf("AckOutbox failed: %v", err)
+	}
+	g.Close()
+
+	// Recording stays on without Config.Outbox, and unacknowledged entries are kept
+	g, err = NewWithClients(Config{DBPath: dbPath}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+	if err := g.DeleteMemory(ctx, first.MemoryID); err != nil {
+		t.Fatalf("DeleteMemory failed: %v", err)
+	}
+	entries, err = g.ReadOutbox(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("ReadOutbox failed: %v", err)
+	}
+	if len(entries) == 0 {
+		t.Fatal("Expected the deletion to be recorded after restart")
+	}
+	for _, entry := range entries {
+		if entry.Entity == store.OutboxEntityNode && entry.EntityID == g.nodeID("Alpha", "Concept") && entry.Op == store.OutboxUpsert {
+			t.Errorf("Acknowledged entry returned again: %+v", entry)
+		}
+	}
+
+	if err := g.DisableOutbox(ctx); err != nil {
+		t.Fatalf("DisableOutbox failed: %v", err)
+	}
+	if _, err := g.AddMemory(ctx, MemoryInput{Topic: "Later", Context: "We use Beta."}); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if after, _ := g.ReadOutbox(ctx, 0, 0); len(after) != len(entries) {
+		t.Errorf("Expected no entries after DisableOutbox, got %d more", len(after)-len(entries))
+	}
+}
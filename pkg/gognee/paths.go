@@ -0,0 +1,19 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// FindPaths returns how two nodes are connected: up to opts.MaxPaths loop-free paths from
+// fromID to toID, strongest (lowest total 1/weight) first, each with its nodes and edges
+// in order. An empty result means the nodes are not connected within opts.MaxHops.
+func (g *Gognee) FindPaths(ctx context.Context, fromID, toID string, opts store.PathOptions) ([]store.Path, error) {
+	finder, ok := g.graphStore.(store.PathFinder)
+	if !ok {
+		return nil, fmt.Errorf("path finding requires a graph store implementing store.PathFinder")
+	}
+	return finder.FindPaths(ctx, fromID, toID, opts)
+}
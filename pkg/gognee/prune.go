@@ -0,0 +1,234 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// defaultPruneBatchSize is the number of nodes Prune deletes per transaction by default
+const defaultPruneBatchSize = 500
+
+// PruneProgress reports how far Prune is through deleting the nodes it selected.
+type PruneProgress struct {
+	NodesToPrune int // Nodes selected for pruning
+	NodesPruned  int // Nodes deleted so far
+	EdgesPruned  int // Edges deleted so far
+}
+
+// pruneAgeFilter converts the age and decay criteria of opts into the creation or access
+// time from which nodes are old enough to prune, per node type. Every decay curve only
+// drops with age, so a node scores below MinDecayScore exactly when its age reaches
+// DecayPolicy.DecayAge for its half-life.
+func (g *Gognee) pruneAgeFilter(opts PruneOptions, now time.Time) store.NodeAgeFilter {
+	filter := store.NodeAgeFilter{FromLastAccess: g.config.DecayBasis == "access"}
+
+	// cutoff is the latest time from which a node with the given half-life is pruned by
+	// either criterion, or zero when neither applies
+	cutoff := func(halfLifeDays int) time.Time {
+		var before time.Time
+		if opts.MaxAgeDays > 0 {
+			// Whole days of age above MaxAgeDays, as the per-node evaluation counts them
+			before = now.Add(-time.Duration(opts.MaxAgeDays+1) * 24 * time.Hour)
+		}
+		if opts.MinDecayScore > 0 && g.config.DecayEnabled {
+			if age, ok := g.config.DecayPolicy.DecayAge(opts.MinDecayScore, halfLifeDays); ok {
+				if decayed := now.Add(-age); decayed.After(before) {
+					before = decayed
+				}
+			}
+		}
+		return before
+	}
+
+	filter.Before = cutoff(g.config.DecayHalfLifeDays)
+	if g.config.DecayPolicy != nil && len(g.config.DecayPolicy.NodeTypeHalfLifeDays) > 0 {
+		filter.TypeBefore = make(map[string]time.Time, len(g.config.DecayPolicy.NodeTypeHalfLifeDays))
+		for nodeType, halfLifeDays := range g.config.DecayPolicy.NodeTypeHalfLifeDays {
+			filter.TypeBefore[nodeType] = cutoff(halfLifeDays)
+		}
+	}
+	return filter
+}
+
+// selectAgedNodes returns the nodes to prune, selected by the store in SQL, leaving out
+// those derived from pinned memories. Only the nodes the query selects are evaluated;
+// younger ones are never read.
+func (g *Gognee) selectAgedNodes(ctx context.Context, pruner store.NodePruner, opts PruneOptions, now time.Time, pinnedMemoryNodes map[string]bool, result *PruneResult) ([]string, error) {
+	// Access-based ages need the buffered reads written first
+	if g.config.DecayBasis == "access" {
+		if err := g.Flush(ctx); err != nil {
+			return nil, err
+		}
+	}
+	candidates, err := pruner.AgedNodeIDs(ctx, g.pruneAgeFilter(opts, now))
+	if err != nil {
+		return nil, err
+	}
+	result.NodesEvaluated = len(candidates)
+
+	nodesToPrune := make([]string, 0, len(candidates))
+	for _, id := range candidates {
+		decision := "prune"
+		if pinnedMemoryNodes[id] {
+			decision = "keep_pinned"
+		} else {
+			nodesToPrune = append(nodesToPrune, id)
+		}
+		if g.logger != nil {
+			g.logger.LogAttrs(ctx, slog.LevelDebug, "node evaluated",
+				slog.String("node_id", id),
+				slog.String("decision", decision),
+			)
+		}
+	}
+	return nodesToPrune, nil
+}
+
+// deleteNodeBatches deletes nodesToPrune and their edges, opts.BatchSize nodes per
+// transaction, then their vectors, reporting each batch to opts.Progress. When ctx ends
+// the deletion early, result covers the batches deleted before it.
+func (g *Gognee) deleteNodeBatches(ctx context.Context, pruner store.NodePruner, nodesToPrune []string, opts PruneOptions, result *PruneResult) error {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPruneBatchSize
+	}
+	progress := PruneProgress{NodesToPrune: len(nodesToPrune)}
+	result.NodesPruned, result.EdgesPruned = 0, 0
+
+	for done := 0; done < len(nodesToPrune); {
+		if err := ctx.Err(); err != nil {
+			result.NodeIDs = nodesToPrune[:done]
+			return err
+		}
+		batch := nodesToPrune[done:min(done+batchSize, len(nodesToPrune))]
+		nodesDeleted, edgeIDs, err := pruner.DeleteNodes(ctx, batch)
+		if err != nil {
+			result.NodeIDs = nodesToPrune[:done]
+			return fmt.Errorf("failed to delete nodes: %w", err)
+		}
+		done += len(batch)
+
+		// Delete from vector store once the nodes are gone (ignore errors to prune as much as possible)
+		for _, nodeID := range batch {
+			_ = g.vectorStore.Delete(ctx, nodeID)
+		}
+		g.dropEdgeVectors(ctx, edgeIDs)
+
+		result.NodesPruned += nodesDeleted
+		result.EdgesPruned += len(edgeIDs)
+		progress.NodesPruned, progress.EdgesPruned = result.NodesPruned, result.EdgesPruned
+		if g.logger != nil {
+			g.logger.LogAttrs(ctx, slog.LevelDebug, "prune batch deleted",
+				slog.Int("nodes_pruned", progress.NodesPruned),
+				slog.Int("nodes_to_prune", progress.NodesToPrune),
+				slog.Int("edges_pruned", progress.EdgesPruned),
+			)
+		}
+		if opts.Progress != nil {
+			opts.Progress(progress)
+		}
+	}
+	return nil
+}
+
+// scanNodesToPrune returns the nodes to prune, evaluating every node of a graph store
+// that cannot select them itself.
+func (g *Gognee) scanNodesToPrune(ctx context.Context, reader store.BulkReader, opts PruneOptions, now time.Time, pinnedMemoryNodes map[string]bool, result *PruneResult) ([]string, error) {
+	// Stream the nodes rather than loading the whole graph; stores implementing
+	// store.NodeScanner leave out the embeddings, which prune does not need
+	scanNodes := reader.IterateNodes
+	if scanner, ok := g.graphStore.(store.NodeScanner); ok {
+		scanNodes = func(ctx context.Context, fn func(*store.Node) error) error {
+			return scanner.ScanNodes(ctx, store.NodeScanOptions{SkipEmbeddings: true}, fn)
+		}
+	}
+
+	// Evaluate each node for pruning
+	nodesToPrune := make([]string, 0)
+
+	err := scanNodes(ctx, func(node *store.Node) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		result.NodesEvaluated++
+
+		// Never prune pinned nodes
+		if node.Pinned || pinnedMemoryNodes[node.ID] {
+			if g.logger != nil {
+				g.logger.LogAttrs(ctx, slog.LevelDebug, "node evaluated",
+					slog.String("node_id", node.ID),
+					slog.Bool("pinned", true),
+					slog.String("decision", "keep_pinned"),
+				)
+			}
+			return nil
+		}
+
+		shouldPrune := false
+		var decayScore float64 = 1.0
+
+		// Check MaxAgeDays criterion
+		if opts.MaxAgeDays > 0 {
+			var age time.Duration
+			if g.config.DecayBasis == "access" && node.LastAccessedAt != nil {
+				age = now.Sub(*node.LastAccessedAt)
+			} else {
+				age = now.Sub(node.CreatedAt)
+			}
+
+			ageDays := int(age.Hours() / 24)
+			if ageDays > opts.MaxAgeDays {
+				shouldPrune = true
+			}
+		}
+
+		// Check MinDecayScore criterion
+		if opts.MinDecayScore > 0 && g.config.DecayEnabled {
+			var age time.Duration
+			if g.config.DecayBasis == "access" && node.LastAccessedAt != nil {
+				age = now.Sub(*node.LastAccessedAt)
+			} else {
+				age = now.Sub(node.CreatedAt)
+			}
+
+			halfLifeDays := g.config.DecayPolicy.NodeHalfLife(node.Type, g.config.DecayHalfLifeDays)
+			decayScore = g.config.DecayPolicy.Multiplier(age, halfLifeDays)
+			if decayScore < opts.MinDecayScore {
+				shouldPrune = true
+			}
+		}
+
+		// M6: Log node evaluation (DEBUG) - safe attributes only (no Name, Description)
+		if g.logger != nil {
+			var age time.Duration
+			if g.config.DecayBasis == "access" && node.LastAccessedAt != nil {
+				age = now.Sub(*node.LastAccessedAt)
+			} else {
+				age = now.Sub(node.CreatedAt)
+			}
+			ageDays := int(age.Hours() / 24)
+
+			decision := "keep"
+			if shouldPrune {
+				decision = "prune"
+			}
+
+			g.logger.LogAttrs(ctx, slog.LevelDebug, "node evaluated",
+				slog.String("node_id", node.ID),
+				slog.Int("age_days", ageDays),
+				slog.Float64("decay_score", decayScore),
+				slog.String("decision", decision),
+			)
+		}
+
+		if shouldPrune {
+			nodesToPrune = append(nodesToPrune, node.ID)
+		}
+		return nil
+	})
+	return nodesToPrune, err
+}
@@ -36,11 +36,12 @@ func TestPrune_LogsStartAtInfo(t *testing.T) {
 	handler.reset() // Clear config logging
 
 	ctx := context.Background()
+	pruneSuperseded := true
 	pruneOpts := PruneOptions{
 		DryRun:            true,
 		MaxAgeDays:        90,
 		MinDecayScore:     0.1,
-		PruneSuperseded:   true,
+		PruneSuperseded:   &pruneSuperseded,
 		SupersededAgeDays: 45,
 	}
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dan-solli/gognee/pkg/extraction"
 	"github.com/dan-solli/gognee/pkg/store"
 )
 
@@ -258,3 +259,215 @@ func TestPrune_PruneSupersededDefault(t *testing.T) {
 		t.Error("Expected memory to be deleted, but it still exists")
 	}
 }
+
+// TestPrune_KeepsSupersededWhenDisabled verifies an explicit PruneSuperseded=false is honored
+func TestPrune_KeepsSupersededWhenDisabled(t *testing.T) {
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	ctx := context.Background()
+	mem, err := g.AddMemory(ctx, MemoryInput{Topic: "Test Memory", Context: "This will be superseded"})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if _, err := g.memoryStore.DB().ExecContext(ctx,
+		"UPDATE memories SET status = 'Superseded', updated_at = ? WHERE id = ?",
+		time.Now().Add(-40*24*time.Hour), mem.MemoryID); err != nil {
+		t.Fatalf("Failed to update memory status: %v", err)
+	}
+
+	keep := false
+	result, err := g.Prune(ctx, PruneOptions{PruneSuperseded: &keep})
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if result.SupersededMemoriesPruned != 0 || result.MemoriesEvaluated != 0 {
+		t.Errorf("expected superseded memories to be left alone, got %+v", result)
+	}
+	if _, err := g.memoryStore.GetMemory(ctx, mem.MemoryID); err != nil {
+		t.Errorf("expected the memory to survive: %v", err)
+	}
+}
+
+// TestPrune_SkipsPinnedNodes verifies pinned nodes survive age-based pruning
+func TestPrune_SkipsPinnedNodes(t *testing.T) {
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	ctx := context.Background()
+
+	old := time.Now().Add(-90 * 24 * time.Hour)
+	for _, node := range []*store.Node{
+		{ID: "product", Name: "Product", CreatedAt: old},
+		{ID: "stale", Name: "Stale", CreatedAt: old},
+	} {
+		if err := g.graphStore.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+
+	if err := g.PinNode(ctx, "product", "the product itself"); err != nil {
+		t.Fatalf("PinNode failed: %v", err)
+	}
+
+	result, err := g.Prune(ctx, PruneOptions{MaxAgeDays: 30})
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if result.NodesPruned != 1 || result.NodeIDs[0] != "stale" {
+		t.Errorf("Expected only the unpinned node pruned, got %v", result.NodeIDs)
+	}
+
+	if err := g.UnpinNode(ctx, "product"); err != nil {
+		t.Fatalf("UnpinNode failed: %v", err)
+	}
+	result, err = g.Prune(ctx, PruneOptions{MaxAgeDays: 30})
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if result.NodesPruned != 1 {
+		t.Errorf("Expected unpinned node to be pruned, got %d", result.NodesPruned)
+	}
+
+	if err := g.PinNode(ctx, "missing", ""); err == nil {
+		t.Error("Expected error pinning a missing node")
+	}
+}
+
+// TestPrune_SkipsPinnedMemories verifies pinned memories and the nodes derived from them
+// survive pruning
+func TestPrune_SkipsPinnedMemories(t *testing.T) {
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{{Name: "Mission", Type: "Concept", Description: "What we build"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	ctx := context.Background()
+	mem, err := g.AddMemory(ctx, MemoryInput{Topic: "Mission", Context: "We build a memory engine.", RetentionPolicy: "session"})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if err := g.PinMemory(ctx, mem.MemoryID, "core knowledge"); err != nil {
+		t.Fatalf("PinMemory failed: %v", err)
+	}
+
+	pinned, err := g.ListPinned(ctx)
+	if err != nil || len(pinned) != 1 || pinned[0].ID != mem.MemoryID || pinned[0].PinnedReason != "core knowledge" {
+		t.Fatalf("Expected the pinned memory listed, got %+v (err %v)", pinned, err)
+	}
+
+	// Age the derived node past the prune cutoff
+	if _, err := g.memoryStore.DB().ExecContext(ctx, "UPDATE nodes SET created_at = ?", time.Now().Add(-90*24*time.Hour)); err != nil {
+		t.Fatalf("failed to age nodes: %v", err)
+	}
+	result, err := g.Prune(ctx, PruneOptions{MaxAgeDays: 30})
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if result.NodesPruned != 0 || result.SupersededMemoriesPruned != 0 {
+		t.Errorf("Expected nothing pruned, got %d nodes and %d memories", result.NodesPruned, result.SupersededMemoriesPruned)
+	}
+
+	if err := g.UnpinMemory(ctx, mem.MemoryID); err != nil {
+		t.Fatalf("UnpinMemory failed: %v", err)
+	}
+	result, err = g.Prune(ctx, PruneOptions{MaxAgeDays: 30})
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if result.NodesPruned != 1 {
+		t.Errorf("Expected the node pruned once unpinned, got %d", result.NodesPruned)
+	}
+}
+
+// TestPrune_MinDecayScoreByNodeType verifies the decay threshold is applied with each
+// node type's half-life
+func TestPrune_MinDecayScoreByNodeType(t *testing.T) {
+	g, err := NewWithClients(Config{
+		DBPath:            ":memory:",
+		DecayEnabled:      true,
+		DecayHalfLifeDays: 10,
+		DecayBasis:        "creation",
+		DecayPolicy:       &store.DecayPolicy{Curve: store.DecayStep, NodeTypeHalfLifeDays: map[string]int{"Person": 100}},
+	}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	day := 24 * time.Hour
+	for _, node := range []*store.Node{
+		{ID: "concept-9d", Name: "Concept 9d", Type: "Concept", CreatedAt: now.Add(-9 * day)},    // score 1
+		{ID: "concept-15d", Name: "Concept 15d", Type: "Concept", CreatedAt: now.Add(-15 * day)}, // score 0.5
+		{ID: "concept-25d", Name: "Concept 25d", Type: "Concept", CreatedAt: now.Add(-25 * day)}, // score 0.25
+		{ID: "person-25d", Name: "Person 25d", Type: "Person", CreatedAt: now.Add(-25 * day)},    // score 1
+		{ID: "person-250d", Name: "Person 250d", Type: "Person", CreatedAt: now.Add(-250 * day)}, // score 0.25
+	} {
+		if err := g.graphStore.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+
+	result, err := g.Prune(ctx, PruneOptions{MinDecayScore: 0.3, DryRun: true})
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	want := []string{"concept-25d", "person-250d"}
+	// Only the nodes old enough to prune are read
+	if result.NodesEvaluated != len(want) || len(result.NodeIDs) != len(want) || result.NodeIDs[0] != want[0] || result.NodeIDs[1] != want[1] {
+		t.Errorf("Expected %v evaluated and pruned, got %v of %d", want, result.NodeIDs, result.NodesEvaluated)
+	}
+}
+
+// TestPrune_ReportsBatchProgress verifies nodes are deleted in batches of BatchSize
+func TestPrune_ReportsBatchProgress(t *testing.T) {
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	ctx := context.Background()
+	old := time.Now().Add(-90 * 24 * time.Hour)
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		if err := g.graphStore.AddNode(ctx, &store.Node{ID: id, Name: id, CreatedAt: old}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	if err := g.graphStore.AddEdge(ctx, &store.Edge{ID: "ab", SourceID: "a", Relation: "USES", TargetID: "b"}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+
+	var progress []PruneProgress
+	result, err := g.Prune(ctx, PruneOptions{
+		MaxAgeDays: 30,
+		BatchSize:  2,
+		Progress:   func(p PruneProgress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if result.NodesPruned != 5 || result.EdgesPruned != 1 {
+		t.Errorf("Expected 5 nodes and 1 edge pruned, got %+v", result)
+	}
+	if len(progress) != 3 {
+		t.Fatalf("Expected 3 batches, got %v", progress)
+	}
+	if last := progress[2]; last.NodesPruned != 5 || last.NodesToPrune != 5 || last.EdgesPruned != 1 || progress[0].NodesPruned != 2 {
+		t.Errorf("Unexpected progress: %v", progress)
+	}
+	if count, _ := g.graphStore.NodeCount(ctx); count != 0 {
+		t.Errorf("Expected every node pruned, got %d left", count)
+	}
+}
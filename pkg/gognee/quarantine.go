@@ -0,0 +1,159 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/search"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// Quarantine kinds
+const (
+	QuarantineDocument = "document"
+	QuarantineMemory   = "memory"
+)
+
+// quarantine holds the nodes derived from flagged content out of search (see
+// Config.QuarantineFlagged) and returns the quarantine ID.
+func (g *Gognee) quarantine(ctx context.Context, kind, reference, source string, report extraction.GuardrailReport, nodeIDs []string) (string, error) {
+	quarantines, ok := g.graphStore.(store.QuarantineStore)
+	if !ok {
+		return "", fmt.Errorf("quarantine requires a graph store implementing store.QuarantineStore")
+	}
+
+	q := &store.Quarantine{
+		Kind:       kind,
+		Reference:  reference,
+		Source:     source,
+		Violations: report.Violations,
+		NodeIDs:    nodeIDs,
+	}
+	if err := quarantines.AddQuarantine(ctx, q); err != nil {
+		return "", err
+	}
+	if g.logger != nil {
+		g.logger.LogAttrs(ctx, slog.LevelWarn, "nodes quarantined",
+			slog.String("quarantine_id", q.ID),
+			slog.String("kind", kind),
+			slog.String("reference", reference),
+			slog.Int("nodes", len(nodeIDs)),
+		)
+	}
+	return q.ID, nil
+}
+
+// unstoredNodes returns the IDs of the nodes that are not in the graph yet, the ones
+// writing them would create. Nodes already present are left to their earlier sources
+// when a flagged document is quarantined.
+func (g *Gognee) unstoredNodes(ctx context.Context, nodes []*store.Node) (map[string]bool, error) {
+	history, ok := g.graphStore.(store.DescriptionHistory)
+	if !ok {
+		return nil, nil
+	}
+	ids := make([]string, len(nodes))
+	for i, node := range nodes {
+		ids[i] = node.ID
+	}
+	stored, err := history.CurrentDescriptions(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check stored nodes: %w", err)
+	}
+	unstored := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if _, ok := stored[id]; !ok {
+			unstored[id] = true
+		}
+	}
+	return unstored, nil
+}
+
+// Quarantines returns the open quarantines for review, oldest first.
+func (g *Gognee) Quarantines(ctx context.Context) ([]store.Quarantine, error) {
+	quarantines, ok := g.graphStore.(store.QuarantineStore)
+	if !ok {
+		return nil, fmt.Errorf("Quarantines requires a graph store implementing store.QuarantineStore")
+	}
+	return quarantines.ListQuarantines(ctx)
+}
+
+// ReleaseQuarantine accepts the content of a quarantine after review: its nodes are
+// returned by search again unless another open quarantine still holds them.
+func (g *Gognee) ReleaseQuarantine(ctx context.Context, id string) error {
+	quarantines, ok := g.graphStore.(store.QuarantineStore)
+	if !ok {
+		return fmt.Errorf("ReleaseQuarantine requires a graph store implementing store.QuarantineStore")
+	}
+	return quarantines.ReleaseQuarantine(ctx, id)
+}
+
+// Search drops hidden nodes from each page of results. When that leaves fewer than TopK,
+// it searches again with a window hiddenRefillFactor times larger, at most
+// maxHiddenRefills times, so heavily hidden graphs may return fewer results.
+const (
+	hiddenRefillFactor = 4
+	maxHiddenRefills   = 2
+)
+
+// hiddenNodes returns those of the results' nodes search must not return: quarantined
+// and archived nodes unless opts includes them, and nodes derived only from memories the
+// caller's principal cannot read. Only the results' nodes are checked.
+func (g *Gognee) hiddenNodes(ctx context.Context, results []search.SearchResult, opts search.SearchOptions) (map[string]bool, error) {
+	nodeIDs := make([]string, len(results))
+	for i, result := range results {
+		nodeIDs[i] = result.NodeID
+	}
+	return g.hiddenAmong(ctx, nodeIDs, opts)
+}
+
+// hiddenAmong is hiddenNodes for a list of node IDs.
+func (g *Gognee) hiddenAmong(ctx context.Context, nodeIDs []string, opts search.SearchOptions) (map[string]bool, error) {
+	if len(nodeIDs) == 0 {
+		return nil, nil
+	}
+
+	hidden := make(map[string]bool)
+	if quarantines, ok := g.graphStore.(store.QuarantineStore); ok && !opts.IncludeQuarantined {
+		quarantined, err := quarantines.QuarantinedAmong(ctx, nodeIDs)
+		if err != nil {
+			return nil, err
+		}
+		for id := range quarantined {
+			hidden[id] = true
+		}
+	}
+	if !opts.IncludeArchived {
+		archived, err := g.memoryStore.ArchivedAmong(ctx, nodeIDs)
+		if err != nil {
+			return nil, err
+		}
+		for id := range archived {
+			hidden[id] = true
+		}
+	}
+	restricted, err := g.memoryStore.RestrictedAmong(ctx, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	for id := range restricted {
+		hidden[id] = true
+	}
+	return hidden, nil
+}
+
+// withoutHidden drops hidden (quarantined, archived or restricted) nodes from results and trims them
+// to topK
+func withoutHidden(results []search.SearchResult, hidden map[string]bool, topK int) []search.SearchResult {
+	kept := results[:0]
+	for _, result := range results {
+		if !hidden[result.NodeID] {
+			kept = append(kept, result)
+		}
+	}
+	if len(kept) > topK {
+		kept = kept[:topK]
+	}
+	return kept
+}
@@ -0,0 +1,32 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// Query runs a Cypher-like pattern query over the graph, for questions GetNeighbors and
+// FindPaths can't express:
+//
+//	result, err := g.Query(ctx, `MATCH (a:Technology)-[:DEPENDS_ON]->(b) RETURN a, b`)
+//
+// See store.ParseGraphQuery for the supported syntax. Malformed queries return an error
+// matching ErrValidation.
+func (g *Gognee) Query(ctx context.Context, query string) (*store.QueryResult, error) {
+	q, err := store.ParseGraphQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return g.QueryGraph(ctx, q)
+}
+
+// QueryGraph runs a query built with store.GraphQuery, the programmatic form of Query.
+func (g *Gognee) QueryGraph(ctx context.Context, q *store.GraphQuery) (*store.QueryResult, error) {
+	querier, ok := g.graphStore.(store.GraphQuerier)
+	if !ok {
+		return nil, fmt.Errorf("graph queries require a graph store implementing store.GraphQuerier")
+	}
+	return querier.QueryGraph(ctx, q)
+}
@@ -0,0 +1,49 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestQuery(t *testing.T) {
+	ctx := context.Background()
+
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	for _, node := range []*store.Node{
+		{ID: "api", Name: "API", Type: "Technology"},
+		{ID: "db", Name: "Postgres", Type: "Technology"},
+		{ID: "team", Name: "Platform", Type: "Organization"},
+	} {
+		if err := g.graphStore.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	for _, edge := range []*store.Edge{
+		{ID: "e1", SourceID: "api", Relation: "DEPENDS_ON", TargetID: "db", Weight: 1.0},
+		{ID: "e2", SourceID: "team", Relation: "OWNS", TargetID: "api", Weight: 1.0},
+	} {
+		if err := g.graphStore.AddEdge(ctx, edge); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+
+	result, err := g.Query(ctx, `MATCH (o:Organization)-[:OWNS]->(a:Technology)-[:DEPENDS_ON]->(b) RETURN o, b`)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(result.Rows) != 1 || result.Rows[0].Nodes["o"].Name != "Platform" || result.Rows[0].Nodes["b"].Name != "Postgres" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	if _, err := g.Query(ctx, `MATCH (a) RETURN`); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ErrValidation, got %v", err)
+	}
+}
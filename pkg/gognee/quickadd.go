@@ -0,0 +1,128 @@
+package gognee
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/store"
+	"github.com/google/uuid"
+)
+
+const (
+	// NoteNodeType is the node type created by QuickAdd for unstructured notes.
+	NoteNodeType = "Note"
+
+	// QuickAddSource is the memory Source recorded for quick-added notes.
+	QuickAddSource = "quick_add"
+
+	// maxNoteTopicRunes caps the topic derived from a note's first line
+	maxNoteTopicRunes = 80
+)
+
+// QuickAddResult reports the outcome of a QuickAdd() operation.
+type QuickAddResult struct {
+	MemoryID string
+	NodeID   string
+	// Duplicate is true when an identical note already existed and was returned instead.
+	Duplicate bool
+}
+
+// QuickAdd captures text without any LLM calls: it stores a memory record and a single
+// Note node holding the text and its embedding, linked as the memory's provenance.
+// The note is searchable immediately and queued for EnrichNotes to extract structure later.
+func (g *Gognee) QuickAdd(ctx context.Context, text string) (*QuickAddResult, error) {
+	startTime := time.Now()
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("text cannot be empty")
+	}
+	text, err := g.redact(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	topic := noteTopic(text)
+	docHash := store.ComputeDocHash(topic, text, nil, nil)
+
+	var existingID string
+	err = g.memoryStore.DB().QueryRowContext(ctx,
+		`SELECT id FROM memories WHERE doc_hash = ? AND namespace = ? LIMIT 1`,
+		docHash, g.config.Namespace).Scan(&existingID)
+	if err == nil {
+		nodeIDs, _, err := g.memoryStore.GetProvenanceByMemory(ctx, existingID)
+		if err != nil {
+			return nil, err
+		}
+		result := &QuickAddResult{MemoryID: existingID, Duplicate: true}
+		if len(nodeIDs) > 0 {
+			result.NodeID = nodeIDs[0]
+		}
+		return result, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to check for duplicate memory: %w", err)
+	}
+
+	embeddings, err := g.embeddings.Embed(ctx, []string{text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed note: %w", err)
+	}
+	var embedding []float32
+	if len(embeddings) > 0 {
+		embedding = embeddings[0]
+	}
+
+	memoryID := uuid.New().String()
+	memory := &store.MemoryRecord{
+		ID:      memoryID,
+		Topic:   topic,
+		Context: text,
+		DocHash: docHash,
+		Source:  QuickAddSource,
+		Status:  "complete",
+		// Queued for EnrichNotes
+		Metadata: map[string]interface{}{enrichmentKey: enrichmentPending},
+	}
+	if err := g.memoryStore.AddMemory(ctx, memory); err != nil {
+		return nil, fmt.Errorf("failed to add memory record: %w", err)
+	}
+
+	nodeID := g.nodeID(docHash, NoteNodeType)
+	node := &store.Node{
+		ID:          nodeID,
+		Name:        topic,
+		Type:        NoteNodeType,
+		Description: text,
+		Embedding:   embedding,
+		CreatedAt:   time.Now(),
+		Metadata:    map[string]interface{}{"memory_id": memoryID},
+	}
+	if err := g.graphStore.AddNode(ctx, node); err != nil {
+		return nil, fmt.Errorf("failed to add note node: %w", err)
+	}
+	if len(embedding) > 0 {
+		if err := g.vectorStore.Add(ctx, nodeID, embedding); err != nil {
+			return nil, fmt.Errorf("failed to index note node: %w", err)
+		}
+	}
+
+	if err := g.memoryStore.LinkProvenance(ctx, memoryID, []string{nodeID}, nil); err != nil {
+		return nil, fmt.Errorf("failed to link provenance: %w", err)
+	}
+
+	if g.metricsCollector != nil {
+		g.metricsCollector.RecordOperation(ctx, "quick_add", "success", time.Since(startTime).Milliseconds())
+	}
+
+	return &QuickAddResult{MemoryID: memoryID, NodeID: nodeID}, nil
+}
+
+// noteTopic derives a topic from the first non-empty line of a note
+func noteTopic(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return truncateRunes(strings.TrimSpace(line), maxNoteTopicRunes)
+}
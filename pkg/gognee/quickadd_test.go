@@ -0,0 +1,91 @@
+package gognee
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/search"
+)
+
+func TestQuickAdd_NoLLMCalls(t *testing.T) {
+	ctx := context.Background()
+
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	mockLLM := &MockLLMClient{}
+	mockEmbed := &MockEmbeddingClient{}
+	g.llm = mockLLM
+	g.embeddings = mockEmbed
+	g.searcher = search.NewHybridSearcher(mockEmbed, g.vectorStore, g.graphStore)
+
+	result, err := g.QuickAdd(ctx, "Call the vendor about the license renewal\nThey want an answer by Friday.")
+	if err != nil {
+		t.Fatalf("QuickAdd failed: %v", err)
+	}
+	if mockLLM.CallCount != 0 {
+		t.Errorf("Expected no LLM calls, got %d", mockLLM.CallCount)
+	}
+
+	memory, err := g.GetMemory(ctx, result.MemoryID)
+	if err != nil {
+		t.Fatalf("GetMemory failed: %v", err)
+	}
+	if memory.Topic != "Call the vendor about the license renewal" || memory.Source != QuickAddSource {
+		t.Errorf("Unexpected memory record: topic=%q source=%q", memory.Topic, memory.Source)
+	}
+
+	node, err := g.graphStore.GetNode(ctx, result.NodeID)
+	if err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	if node.Type != NoteNodeType || len(node.Embedding) == 0 {
+		t.Errorf("Expected an embedded Note node, got type=%q embedding=%d", node.Type, len(node.Embedding))
+	}
+
+	nodeIDs, _, err := g.memoryStore.GetProvenanceByMemory(ctx, result.MemoryID)
+	if err != nil {
+		t.Fatalf("GetProvenanceByMemory failed: %v", err)
+	}
+	if len(nodeIDs) != 1 || nodeIDs[0] != result.NodeID {
+		t.Errorf("Expected note node as provenance, got %v", nodeIDs)
+	}
+
+	resp, err := g.Search(ctx, "license renewal", search.SearchOptions{Type: search.SearchTypeVector, TopK: 5})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) == 0 || resp.Results[0].NodeID != result.NodeID {
+		t.Errorf("Expected note to be searchable, got %+v", resp.Results)
+	}
+}
+
+func TestQuickAdd_Duplicate(t *testing.T) {
+	ctx := context.Background()
+
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+	g.embeddings = &MockEmbeddingClient{}
+
+	first, err := g.QuickAdd(ctx, "Remember to rotate the API keys")
+	if err != nil {
+		t.Fatalf("QuickAdd failed: %v", err)
+	}
+	second, err := g.QuickAdd(ctx, "  Remember to rotate the API keys  ")
+	if err != nil {
+		t.Fatalf("QuickAdd failed: %v", err)
+	}
+	if !second.Duplicate || second.MemoryID != first.MemoryID || second.NodeID != first.NodeID {
+		t.Errorf("Expected duplicate of %+v, got %+v", first, second)
+	}
+
+	if _, err := g.QuickAdd(ctx, "   "); err == nil {
+		t.Error("Expected error for empty text")
+	}
+}
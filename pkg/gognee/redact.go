@@ -0,0 +1,204 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// redact applies Config.Redactor to text. Callers fail the operation on error rather than
+// store the text unredacted.
+func (g *Gognee) redact(ctx context.Context, text string) (string, error) {
+	if g.config.Redactor == nil {
+		return text, nil
+	}
+	redacted, err := g.config.Redactor.Redact(ctx, text)
+	if err != nil {
+		return "", fmt.Errorf("redaction failed: %w", err)
+	}
+	return redacted, nil
+}
+
+// redactMemoryInput applies Config.Redactor to the topic, context, decisions, rationale,
+// source, tags and metadata string values of a memory in one batch.
+func (g *Gognee) redactMemoryInput(ctx context.Context, input *MemoryInput) error {
+	if g.config.Redactor == nil {
+		return nil
+	}
+	texts := []string{input.Topic, input.Context, input.Source}
+	texts = append(texts, input.Decisions...)
+	texts = append(texts, input.Rationale...)
+	texts = append(texts, input.Tags...)
+	texts = appendMetadataStrings(texts, input.Metadata)
+	redacted, err := extraction.RedactTexts(ctx, g.config.Redactor, texts)
+	if err != nil {
+		return fmt.Errorf("redaction failed: %w", err)
+	}
+	input.Topic, input.Context, input.Source = redacted[0], redacted[1], redacted[2]
+	redacted = redacted[3:]
+	take := func(values []string) []string {
+		if len(values) == 0 {
+			return values
+		}
+		taken := redacted[:len(values):len(values)]
+		redacted = redacted[len(values):]
+		return taken
+	}
+	input.Decisions = take(input.Decisions)
+	input.Rationale = take(input.Rationale)
+	input.Tags = take(input.Tags)
+	if input.Metadata != nil {
+		input.Metadata = replaceMetadataStrings(input.Metadata, &redacted).(map[string]interface{})
+	}
+	return nil
+}
+
+// redactMemoryRecord applies Config.Redactor to the content of a memory written directly
+// to the store (imports, consolidations), recomputing its content hash.
+func (g *Gognee) redactMemoryRecord(ctx context.Context, record *store.MemoryRecord) error {
+	if g.config.Redactor == nil {
+		return nil
+	}
+	input := MemoryInput{
+		Topic:     record.Topic,
+		Context:   record.Context,
+		Decisions: record.Decisions,
+		Rationale: record.Rationale,
+		Metadata:  record.Metadata,
+		Source:    record.Source,
+		Tags:      record.Tags,
+	}
+	if err := g.redactMemoryInput(ctx, &input); err != nil {
+		return err
+	}
+	record.Topic, record.Context, record.Decisions, record.Rationale = input.Topic, input.Context, input.Decisions, input.Rationale
+	record.Metadata, record.Source, record.Tags = input.Metadata, input.Source, input.Tags
+	record.DocHash = store.ComputeDocHash(record.Topic, record.Context, record.Decisions, record.Rationale)
+	return nil
+}
+
+// appendMetadataStrings appends the string values of metadata, including those nested in
+// maps and slices, in key order. The session ID is an identifier, not content, and is
+// left out.
+func appendMetadataStrings(texts []string, value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return append(texts, v)
+	case []string:
+		return append(texts, v...)
+	case []interface{}:
+		for _, item := range v {
+			texts = appendMetadataStrings(texts, item)
+		}
+	case map[string]interface{}:
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			if key != store.SessionMetadataKey {
+				texts = appendMetadataStrings(texts, v[key])
+			}
+		}
+	}
+	return texts
+}
+
+// replaceMetadataStrings returns a copy of value with the strings appendMetadataStrings
+// collected replaced by the next of redacted, in the same order.
+func replaceMetadataStrings(value interface{}, redacted *[]string) interface{} {
+	next := func() string {
+		text := (*redacted)[0]
+		*redacted = (*redacted)[1:]
+		return text
+	}
+	switch v := value.(type) {
+	case string:
+		return next()
+	case []string:
+		replaced := make([]string, len(v))
+		for i := range v {
+			replaced[i] = next()
+		}
+		return replaced
+	case []interface{}:
+		replaced := make([]interface{}, len(v))
+		for i, item := range v {
+			replaced[i] = replaceMetadataStrings(item, redacted)
+		}
+		return replaced
+	case map[string]interface{}:
+		replaced := make(map[string]interface{}, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			if key == store.SessionMetadataKey {
+				replaced[key] = v[key]
+				continue
+			}
+			replaced[key] = replaceMetadataStrings(v[key], redacted)
+		}
+		return replaced
+	}
+	return value
+}
+
+// redactEntities applies Config.Redactor to extracted entity names and descriptions, in
+// case the LLM reproduced sensitive text the input redaction missed.
+func (g *Gognee) redactEntities(ctx context.Context, entities []extraction.Entity) ([]extraction.Entity, error) {
+	redacted, err := extraction.RedactEntities(ctx, g.config.Redactor, entities)
+	if err != nil {
+		return nil, fmt.Errorf("redaction failed: %w", err)
+	}
+	return redacted, nil
+}
+
+// redactTriplets applies Config.Redactor to extracted triplet subjects and objects.
+func (g *Gognee) redactTriplets(ctx context.Context, triplets []extraction.Triplet) ([]extraction.Triplet, error) {
+	redacted, err := extraction.RedactTriplets(ctx, g.config.Redactor, triplets)
+	if err != nil {
+		return nil, fmt.Errorf("redaction failed: %w", err)
+	}
+	return redacted, nil
+}
+
+// redactMemoryUpdate applies Config.Redactor to the content fields set in updates,
+// replacing them rather than writing through the caller's pointers.
+func (g *Gognee) redactMemoryUpdate(ctx context.Context, updates *store.MemoryUpdate) error {
+	if g.config.Redactor == nil {
+		return nil
+	}
+	var input MemoryInput
+	if updates.Topic != nil {
+		input.Topic = *updates.Topic
+	}
+	if updates.Metadata != nil {
+		input.Metadata = *updates.Metadata
+	}
+	if updates.Context != nil {
+		input.Context = *updates.Context
+	}
+	if updates.Rationale != nil {
+		input.Rationale = *updates.Rationale
+	}
+	if updates.Decisions != nil {
+		input.Decisions = *updates.Decisions
+	}
+	if err := g.redactMemoryInput(ctx, &input); err != nil {
+		return err
+	}
+	if updates.Topic != nil {
+		updates.Topic = &input.Topic
+	}
+	if updates.Metadata != nil {
+		updates.Metadata = &input.Metadata
+	}
+	if updates.Context != nil {
+		updates.Context = &input.Context
+	}
+	if updates.Rationale != nil {
+		updates.Rationale = &input.Rationale
+	}
+	if updates.Decisions != nil {
+		updates.Decisions = &input.Decisions
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package gognee
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// leakyLLMClient returns extraction output that repeats an email address
+func leakyLLMClient() *MockLLMClient {
+	return &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{
+			{Name: "Alice", Type: "Person", Description: "Engineer, alice@example.com"},
+			{Name: "Go", Type: "Technology", Description: "Programming language"},
+		}},
+		RelationResponses: [][]extraction.Triplet{{
+			{Subject: "Alice", Relation: "USES", Object: "Go"},
+		}},
+	}
+}
+
+func TestAddMemory_Redactor(t *testing.T) {
+	ctx := context.Background()
+
+	g, err := NewWithClients(Config{DBPath: ":memory:", Redactor: extraction.NewRegexRedactor()}, &MockEmbeddingClient{}, leakyLLMClient())
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	result, err := g.AddMemory(ctx, MemoryInput{
+		Topic:     "Deploy access",
+		Context:   "Alice (alice@example.com) deploys with token=s3cr3t",
+		Decisions: []string{"Rotate password: hunter2 monthly"},
+	})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	memory, err := g.GetMemory(ctx, result.MemoryID)
+	if err != nil {
+		t.Fatalf("GetMemory failed: %v", err)
+	}
+	if memory.Context != "Alice ([REDACTED_EMAIL]) deploys with token=[REDACTED_SECRET]" {
+		t.Errorf("Expected redacted context, got %q", memory.Context)
+	}
+	if memory.Decisions[0] != "Rotate password: [REDACTED_SECRET] monthly" {
+		t.Errorf("Expected redacted decision, got %q", memory.Decisions[0])
+	}
+
+	node, err := g.graphStore.GetNode(ctx, g.nodeID("Alice", "Person"))
+	if err != nil || node == nil {
+		t.Fatalf("Expected Alice node, got %v (err %v)", node, err)
+	}
+	if strings.Contains(node.Description, "@") {
+		t.Errorf("Expected redacted node description, got %q", node.Description)
+	}
+
+	// Updated content is redacted too
+	updated := "Reach Alice at alice@example.com"
+	if _, err := g.UpdateMemory(ctx, result.MemoryID, store.MemoryUpdate{Context: &updated}); err != nil {
+		t.Fatalf("UpdateMemory failed: %v", err)
+	}
+	memory, _ = g.GetMemory(ctx, result.MemoryID)
+	if memory.Context != "Reach Alice at [REDACTED_EMAIL]" {
+		t.Errorf("Expected redacted update, got %q", memory.Context)
+	}
+	if updated != "Reach Alice at alice@example.com" {
+		t.Error("Expected the caller's value to be left alone")
+	}
+}
+
+func TestAdd_Redactor(t *testing.T) {
+	ctx := context.Background()
+
+	g, err := NewWithClients(Config{DBPath: ":memory:", Redactor: extraction.NewRegexRedactor()}, &MockEmbeddingClient{}, leakyLLMClient())
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	if err := g.Add(ctx, "Alice uses Go. Contact: alice@example.com", AddOptions{}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if text := g.buffer[0].Text; text != "Alice uses Go. Contact: [REDACTED_EMAIL]" {
+		t.Errorf("Expected redacted buffered text, got %q", text)
+	}
+	pending, err := g.graphStore.(store.IngestionQueue).PendingDocuments(ctx)
+	if err != nil {
+		t.Fatalf("PendingDocuments failed: %v", err)
+	}
+	if len(pending) != 1 || strings.Contains(pending[0].Text, "@") {
+		t.Errorf("Expected the queued document redacted, got %+v", pending)
+	}
+}
+
+// failingRedactor fails every call
+type failingRedactor struct{}
+
+func (failingRedactor) Redact(ctx context.Context, text string) (string, error) {
+	return "", errors.New("detector unavailable")
+}
+
+func TestRedactor_FailureStoresNothing(t *testing.T) {
+	ctx := context.Background()
+
+	g, err := NewWithClients(Config{DBPath: ":memory:", Redactor: failingRedactor{}}, &MockEmbeddingClient{}, leakyLLMClient())
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	if err := g.Add(ctx, "alice@example.com", AddOptions{}); err == nil {
+		t.Error("Expected Add to fail when redaction fails")
+	}
+	if _, err := g.AddMemory(ctx, MemoryInput{Topic: "t", Context: "alice@example.com"}); err == nil {
+		t.Error("Expected AddMemory to fail when redaction fails")
+	}
+	if count, _ := g.CountMemories(ctx); count != 0 || g.BufferedCount() != 0 {
+		t.Errorf("Expected nothing stored, got %d memories and %d buffered", count, g.BufferedCount())
+	}
+}
+
+// leakyConsolidateLLM answers consolidate prompts with text repeating an email address
+type leakyConsolidateLLM struct {
+	MockLLMClient
+}
+
+func (m *leakyConsolidateLLM) CompleteWithSchema(ctx context.Context, prompt string, schema interface{}) error {
+	if s, ok := schema.(*consolidateResponse); ok {
+		*s = consolidateResponse{Topic: "Deploys", Context: "Ask alice@example.com before deploying."}
+		return nil
+	}
+	return m.MockLLMClient.CompleteWithSchema(ctx, prompt, schema)
+}
+
+func TestRedactor_MetadataImportsAndConsolidation(t *testing.T) {
+	ctx := context.Background()
+	g, err := NewWithClients(Config{DBPath: ":memory:", Redactor: extraction.NewRegexRedactor()}, &MockEmbeddingClient{}, &leakyConsolidateLLM{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	// Source, tags and metadata values, nested ones included, are redacted
+	result, err := g.AddMemory(ctx, MemoryInput{
+		Topic:    "Deploys",
+		Context:  "Deploys need approval.",
+		Source:   "mail:alice@example.com",
+		Tags:     []string{"alice@example.com"},
+		Metadata: map[string]interface{}{"owner": "alice@example.com", "cc": []interface{}{"bob@example.com", 2.0}},
+	})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	memory, err := g.GetMemory(ctx, result.MemoryID)
+	if err != nil {
+		t.Fatalf("GetMemory failed: %v", err)
+	}
+	if memory.Source != "mail:[REDACTED_EMAIL]" || len(memory.Tags) != 1 || strings.Contains(memory.Tags[0], "@") {
+		t.Errorf("Expected redacted source and tags, got %q and %v", memory.Source, memory.Tags)
+	}
+	cc, _ := memory.Metadata["cc"].([]interface{})
+	if memory.Metadata["owner"] != "[REDACTED_EMAIL]" || len(cc) != 2 || cc[0] != "[REDACTED_EMAIL]" || cc[1] != 2.0 {
+		t.Errorf("Expected redacted metadata, got %v", memory.Metadata)
+	}
+
+	// Consolidated text comes from the LLM and is redacted before it is stored
+	second, err := g.AddMemory(ctx, MemoryInput{Topic: "Deploys", Context: "Deploys run on Fridays."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	consolidated, err := g.ConsolidateMemories(ctx, []string{result.MemoryID, second.MemoryID}, ConsolidateOptions{})
+	if err != nil {
+		t.Fatalf("ConsolidateMemories failed: %v", err)
+	}
+	if memory, _ := g.GetMemory(ctx, consolidated.MemoryID); memory == nil || memory.Context != "Ask [REDACTED_EMAIL] before deploying." {
+		t.Errorf("Expected the consolidated context redacted, got %+v", memory)
+	}
+
+	// Imported memories pass through the redactor too
+	plain, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer plain.Close()
+	exported, err := plain.AddMemory(ctx, MemoryInput{Topic: "Contacts", Context: "Page carol@example.com on call."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := plain.ExportMemories(ctx, &buf, ExportMemoriesOptions{}); err != nil {
+		t.Fatalf("ExportMemories failed: %v", err)
+	}
+	if _, err := g.ImportMemories(ctx, &buf, ImportMemoriesOptions{}); err != nil {
+		t.Fatalf("ImportMemories failed: %v", err)
+	}
+	imported, err := g.GetMemory(ctx, exported.MemoryID)
+	if err != nil || imported.Context != "Page [REDACTED_EMAIL] on call." {
+		t.Errorf("Expected the imported context redacted, got %+v (err %v)", imported, err)
+	}
+}
@@ -0,0 +1,192 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// reembedBatchSize is the number of texts sent per embedding call during Reembed
+const reembedBatchSize = 100
+
+// ReembedResult reports the work done by Reembed.
+type ReembedResult struct {
+	NodesReembedded  int  // Node embeddings regenerated
+	EdgesReembedded  int  // Edge embeddings regenerated (Config.EdgeEmbeddings)
+	ChunksReembedded int  // Chunk embeddings regenerated (Config.ChunkEmbeddings)
+	Dimensions       int  // Vector length of the new embeddings
+	IndexReset       bool // The vector indexes were recreated for a new vector length
+	// Status is OperationCancelled when ctx ended Reembed between batches; rerun it to
+	// finish, as the embedding model is only recorded once every batch is done.
+	Status OperationStatus
+}
+
+// Reembed regenerates the namespace's embeddings with the current embedding client, for
+// switching embedding models. Every node that has an embedding is re-embedded from its
+// name and description, as Cognify embeds entities, with Config.EdgeEmbeddings every
+// edge is re-embedded from its text, and with Config.ChunkEmbeddings every stored chunk.
+//
+// When the new vectors differ in length from the stored ones, the SQLite vector indexes
+// are recreated first (store.EmbeddingRegistry.ResetEmbeddings), which fails if another
+// namespace holds embeddings. Config.EmbeddingModel is recorded in the embedding registry;
+// opening a database recorded with another model needs Config.AllowEmbeddingModelChange.
+// Reembed is safe to rerun after a failure.
+func (g *Gognee) Reembed(ctx context.Context) (*ReembedResult, error) {
+	reader, ok := g.graphStore.(store.BulkReader)
+	if !ok {
+		return nil, fmt.Errorf("reembed requires a graph store implementing store.BulkReader")
+	}
+
+	if ctx.Err() != nil {
+		return &ReembedResult{Status: OperationCancelled}, nil
+	}
+
+	type target struct{ id, text string }
+	var nodes []target
+	names := make(map[string]string)
+	err := reader.IterateNodes(ctx, func(node *store.Node) error {
+		names[node.ID] = node.Name
+		if len(node.Embedding) > 0 {
+			nodes = append(nodes, target{node.ID, strings.TrimSpace(node.Name + " " + node.Description)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var edges []target
+	if g.config.EdgeEmbeddings {
+		err := reader.IterateEdges(ctx, func(edge *store.Edge) error {
+			edges = append(edges, target{edge.ID, renderEdge(names[edge.SourceID], edge.Relation, names[edge.TargetID])})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list edges: %w", err)
+		}
+	}
+
+	result := &ReembedResult{Status: OperationCompleted}
+	registry, hasRegistry := g.graphStore.(store.EmbeddingRegistry)
+	chunkStore, hasChunks := g.graphStore.(store.ChunkStore)
+
+	var chunks []target
+	chunkRecords := make(map[string]*store.Chunk)
+	if g.config.ChunkEmbeddings && hasChunks {
+		err := chunkStore.IterateChunks(ctx, func(chunk *store.Chunk) error {
+			key := strconv.Itoa(len(chunks))
+			chunkRecords[key] = chunk
+			chunks = append(chunks, target{key, chunk.Text})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// prepare runs once the new vector length is known, before anything is written
+	prepared := false
+	prepare := func(dimensions int) error {
+		prepared = true
+		result.Dimensions = dimensions
+		if memVectors, ok := g.vectorStore.(*store.MemoryVectorStore); ok {
+			memVectors.Clear()
+		}
+		if memVectors, ok := g.edgeVectorStore.(*store.MemoryVectorStore); ok && g.config.EdgeEmbeddings {
+			memVectors.Clear()
+		}
+		if !hasRegistry {
+			return nil
+		}
+		recorded, err := registry.EmbeddingConfig(ctx)
+		if err != nil {
+			return err
+		}
+		if recorded.Dimensions == dimensions {
+			return nil
+		}
+		if err := registry.ResetEmbeddings(ctx, dimensions); err != nil {
+			return fmt.Errorf("failed to reset vector indexes: %w", err)
+		}
+		result.IndexReset = true
+		return nil
+	}
+
+	reembed := func(targets []target, add func(id string, embedding []float32) error) (int, error) {
+		count := 0
+		for start := 0; start < len(targets); start += reembedBatchSize {
+			if ctx.Err() != nil {
+				result.Status = OperationCancelled
+				return count, nil
+			}
+			batch := targets[start:min(start+reembedBatchSize, len(targets))]
+			texts := make([]string, len(batch))
+			for i, t := range batch {
+				texts[i] = t.text
+			}
+			embeddings, err := g.embeddings.Embed(ctx, texts)
+			if err != nil {
+				return count, fmt.Errorf("failed to embed batch: %w", err)
+			}
+			for i, t := range batch {
+				if i >= len(embeddings) || len(embeddings[i]) == 0 {
+					continue
+				}
+				if !prepared {
+					if err := prepare(len(embeddings[i])); err != nil {
+						return count, err
+					}
+				}
+				if err := add(t.id, embeddings[i]); err != nil {
+					return count, err
+				}
+				count++
+			}
+		}
+		return count, nil
+	}
+
+	result.NodesReembedded, err = reembed(nodes, func(id string, embedding []float32) error {
+		if err := g.indexNodeEmbedding(ctx, id, embedding); err != nil {
+			return fmt.Errorf("failed to index node %s: %w", id, err)
+		}
+		return nil
+	})
+	if err != nil || result.Status == OperationCancelled {
+		return result, err
+	}
+
+	result.EdgesReembedded, err = reembed(edges, func(id string, embedding []float32) error {
+		if err := g.edgeVectorStore.Add(ctx, id, embedding); err != nil {
+			return fmt.Errorf("failed to index edge %s: %w", id, err)
+		}
+		return nil
+	})
+	if err != nil || result.Status == OperationCancelled {
+		return result, err
+	}
+
+	result.ChunksReembedded, err = reembed(chunks, func(key string, embedding []float32) error {
+		chunk := chunkRecords[key]
+		if err := chunkStore.AddChunk(ctx, chunk, embedding); err != nil {
+			return fmt.Errorf("failed to index chunk %s: %w", chunk.ID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if result.Status == OperationCancelled {
+		return result, nil
+	}
+	if hasRegistry && g.config.EmbeddingModel != "" {
+		if err := registry.SetEmbeddingModel(ctx, g.config.EmbeddingModel); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
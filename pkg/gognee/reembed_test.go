@@ -0,0 +1,96 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/search"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// wideEmbeddingClient stands in for a new embedding model: MockEmbeddingClient's
+// vectors padded to 8 dimensions
+type wideEmbeddingClient struct {
+	MockEmbeddingClient
+}
+
+func (w *wideEmbeddingClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors, _ := w.MockEmbeddingClient.Embed(ctx, texts)
+	for i, v := range vectors {
+		vectors[i] = append(v, 0, 0, 0, 1)
+	}
+	return vectors, nil
+}
+
+func (w *wideEmbeddingClient) EmbedOne(ctx context.Context, text string) ([]float32, error) {
+	vectors, err := w.Embed(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return vectors[0], nil
+}
+
+func TestReembed_NewModel(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reembed.db")
+	ctx := context.Background()
+
+	g, err := NewWithClients(Config{DBPath: dbPath, EmbeddingModel: "small-model"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	if _, err := g.QuickAdd(ctx, "Rotate the API keys"); err != nil {
+		t.Fatalf("QuickAdd failed: %v", err)
+	}
+	g.Close()
+
+	// Reopening with another model is refused unless the caller means to re-embed
+	_, err = NewWithClients(Config{DBPath: dbPath, EmbeddingModel: "wide-model"}, &wideEmbeddingClient{}, &MockLLMClient{})
+	var mismatch *store.ModelMismatchError
+	if !errors.As(err, &mismatch) || !errors.Is(err, ErrEmbeddingModelMismatch) ||
+		mismatch.Stored != "small-model" || mismatch.Configured != "wide-model" {
+		t.Fatalf("expected a model mismatch, got %v", err)
+	}
+
+	// Allowed, searches fail with a typed error until Reembed
+	g, err = NewWithClients(Config{DBPath: dbPath, EmbeddingModel: "wide-model", AllowEmbeddingModelChange: true},
+		&wideEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+	opts := search.SearchOptions{Type: search.SearchTypeVector, TopK: 5}
+	if _, err := g.Search(ctx, "Rotate the API keys", opts); !errors.Is(err, store.ErrDimensionMismatch) {
+		t.Fatalf("expected a dimension mismatch before Reembed, got %v", err)
+	}
+	sqlStore := g.GetGraphStore().(*store.SQLiteGraphStore)
+	if cfg, _ := sqlStore.EmbeddingConfig(ctx); cfg.Model != "small-model" || cfg.Dimensions != 4 {
+		t.Errorf("expected the original model in the registry, got %+v", cfg)
+	}
+
+	result, err := g.Reembed(ctx)
+	if err != nil {
+		t.Fatalf("Reembed failed: %v", err)
+	}
+	if result.NodesReembedded != 1 || result.Dimensions != 8 || !result.IndexReset {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if cfg, _ := sqlStore.EmbeddingConfig(ctx); cfg.Model != "wide-model" || cfg.Dimensions != 8 {
+		t.Errorf("expected the new model in the registry, got %+v", cfg)
+	}
+
+	response, err := g.Search(ctx, "Rotate the API keys", opts)
+	if err != nil {
+		t.Fatalf("Search failed after Reembed: %v", err)
+	}
+	if len(response.Results) != 1 {
+		t.Errorf("expected the note to be found, got %d results", len(response.Results))
+	}
+
+	// Rerunning with the same model keeps the index
+	result, err = g.Reembed(ctx)
+	if err != nil || result.IndexReset || result.NodesReembedded != 1 {
+		t.Errorf("expected an in-place rerun, got %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,63 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// ReinforceTargets lists the nodes and memories to reinforce.
+type ReinforceTargets struct {
+	NodeIDs   []string
+	MemoryIDs []string
+}
+
+// ReinforceResult reports what Reinforce updated.
+type ReinforceResult struct {
+	NodesReinforced    int
+	MemoriesReinforced int
+}
+
+// Reinforce marks nodes and memories as confirmed or important, spaced-repetition style:
+// each is credited with strength accesses and its last access is reset to now, so its
+// decay restarts and, for memories, its access heat rises (see store.MemoryScore).
+// Unlike the access tracking of Search and GetMemory, reinforcement is an explicit
+// signal and strength lets one confirmation count for several reads. Unknown IDs are
+// skipped; nodes are only reinforced when the graph store implements store.Reinforcer.
+func (g *Gognee) Reinforce(ctx context.Context, targets ReinforceTargets, strength int) (*ReinforceResult, error) {
+	if strength <= 0 {
+		return nil, store.Invalidf("reinforcement strength must be positive, got %d", strength)
+	}
+	startTime := time.Now()
+	result := &ReinforceResult{}
+
+	if len(targets.NodeIDs) > 0 {
+		if reinforcer, ok := g.graphStore.(store.Reinforcer); ok {
+			n, err := reinforcer.ReinforceNodes(ctx, targets.NodeIDs, strength)
+			if err != nil {
+				return nil, fmt.Errorf("failed to reinforce nodes: %w", err)
+			}
+			result.NodesReinforced = n
+		} else if g.logger != nil {
+			g.logger.LogAttrs(ctx, slog.LevelWarn, "node reinforcement skipped",
+				slog.String("reason", "graph store does not implement store.Reinforcer"),
+			)
+		}
+	}
+
+	if len(targets.MemoryIDs) > 0 {
+		n, err := g.memoryStore.ReinforceMemories(ctx, targets.MemoryIDs, strength)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reinforce memories: %w", err)
+		}
+		result.MemoriesReinforced = n
+	}
+
+	if g.metricsCollector != nil {
+		g.metricsCollector.RecordOperation(ctx, "reinforce", "success", time.Since(startTime).Milliseconds())
+	}
+	return result, nil
+}
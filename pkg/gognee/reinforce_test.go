@@ -0,0 +1,60 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestReinforce(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &MockLLMClient{EntityResponses: [][]extraction.Entity{
+		{{Name: "Go", Type: "Technology", Description: "Programming language"}},
+	}}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	confirmed, err := g.AddMemory(ctx, MemoryInput{Topic: "Language", Context: "We write services in Go."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if _, err := g.AddMemory(ctx, MemoryInput{Topic: "Lunch", Context: "Pizza on Fridays.", AutoCognify: new(bool)}); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	nodeIDs, _, err := g.memoryStore.GetProvenanceByMemory(ctx, confirmed.MemoryID)
+	if err != nil || len(nodeIDs) != 1 {
+		t.Fatalf("Expected one node for the memory, got %v (%v)", nodeIDs, err)
+	}
+
+	result, err := g.Reinforce(ctx, ReinforceTargets{NodeIDs: nodeIDs, MemoryIDs: []string{confirmed.MemoryID}}, 10)
+	if err != nil {
+		t.Fatalf("Reinforce failed: %v", err)
+	}
+	if result.NodesReinforced != 1 || result.MemoriesReinforced != 1 {
+		t.Errorf("Expected one node and one memory reinforced, got %+v", result)
+	}
+
+	node, err := g.graphStore.GetNode(ctx, nodeIDs[0])
+	if err != nil || node.LastAccessedAt == nil {
+		t.Errorf("Expected the node's last access recorded, got %+v (%v)", node, err)
+	}
+
+	// The reinforced memory ranks first although the other one is newer
+	memories, err := g.ListMemories(ctx, store.ListMemoriesOptions{OrderBy: "score", OrderDesc: true})
+	if err != nil {
+		t.Fatalf("ListMemories failed: %v", err)
+	}
+	if len(memories) != 2 || memories[0].ID != confirmed.MemoryID || memories[0].AccessCount != 10 {
+		t.Errorf("Expected the reinforced memory first with 10 accesses, got %+v", memories)
+	}
+
+	if _, err := g.Reinforce(ctx, ReinforceTargets{MemoryIDs: []string{confirmed.MemoryID}}, -1); !errors.Is(err, store.ErrValidation) {
+		t.Errorf("Expected a validation error for a negative strength, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// relatedSearchFanout is how many vector neighbours are fetched per requested related
+// memory when looking for memories with similar content but no shared nodes.
+const relatedSearchFanout = 4
+
+// RelatedMemory is a memory connected to another through shared graph nodes or
+// semantically similar content, with its status and decisions.
+type RelatedMemory struct {
+	MemoryID    string   `json:"memory_id"`
+	Topic       string   `json:"topic"`
+	Score       float64  `json:"score"`        // Mean of node overlap and embedding similarity (0-1)
+	SharedNodes []string `json:"shared_nodes"` // Node IDs derived from both memories
+	Overlap     float64  `json:"overlap"`      // Jaccard overlap of the two memories' node sets
+	Similarity  float64  `json:"similarity"`   // Cosine similarity of the memories' node embedding centroids
+	Status      string   `json:"status"`
+	Decisions   []string `json:"decisions,omitempty"`
+}
+
+// RelatedMemoriesOptions configures GetRelatedMemories.
+type RelatedMemoriesOptions struct {
+	// TopK is the maximum number of related memories returned (default: 5).
+	TopK int
+	// MinScore drops related memories scoring below it (0-1). Default: 0 (keep all).
+	MinScore float64
+	// IncludeSuperseded returns memories that were superseded, which are otherwise left
+	// out so only decisions still in effect surface.
+	IncludeSuperseded bool
+	// IncludeArchived returns archived memories. Default: false.
+	IncludeArchived bool
+}
+
+// RelatedMemories returns up to topK memories related to memoryID, best first (default
+// topK: 5), superseded and archived ones included. See GetRelatedMemories.
+func (g *Gognee) RelatedMemories(ctx context.Context, memoryID string, topK int) ([]RelatedMemory, error) {
+	return g.GetRelatedMemories(ctx, memoryID, RelatedMemoriesOptions{TopK: topK, IncludeSuperseded: true, IncludeArchived: true})
+}
+
+// GetRelatedMemories returns the memories about the same entities as memoryID, best
+// first, with their decisions, so callers can surface what was decided before about a
+// component. Candidates are memories sharing provenance nodes with memoryID plus
+// memories linked to the nearest nodes of its embedding centroid. Each is scored by
+// node-set overlap and by the cosine similarity of the mean embeddings of the two
+// memories' nodes.
+func (g *Gognee) GetRelatedMemories(ctx context.Context, memoryID string, opts RelatedMemoriesOptions) ([]RelatedMemory, error) {
+	topK := opts.TopK
+	if topK <= 0 {
+		topK = 5
+	}
+
+	if _, err := g.memoryStore.GetMemory(ctx, memoryID); err != nil {
+		return nil, fmt.Errorf("cannot find related memories: %w", err)
+	}
+
+	sourceNodes, _, err := g.memoryStore.GetProvenanceByMemory(ctx, memoryID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sourceNodes) == 0 {
+		return []RelatedMemory{}, nil
+	}
+
+	embeddingCache := make(map[string][]float32)
+	sourceCentroid, err := g.nodeCentroid(ctx, sourceNodes, embeddingCache)
+	if err != nil {
+		return nil, err
+	}
+
+	// Candidates through shared nodes
+	lookupNodes := append([]string(nil), sourceNodes...)
+
+	// Candidates through nodes close to this memory's content
+	if sourceCentroid != nil {
+		neighbours, err := g.vectorStore.Search(ctx, sourceCentroid, topK*relatedSearchFanout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search similar nodes: %w", err)
+		}
+		for _, n := range neighbours {
+			lookupNodes = append(lookupNodes, n.ID)
+		}
+	}
+
+	byNode, err := g.memoryStore.GetMemoriesByNodeIDBatched(ctx, lookupNodes)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make(map[string]bool)
+	for _, ids := range byNode {
+		for _, id := range ids {
+			if id != memoryID {
+				candidates[id] = true
+			}
+		}
+	}
+
+	sourceSet := make(map[string]bool, len(sourceNodes))
+	for _, id := range sourceNodes {
+		sourceSet[id] = true
+	}
+
+	related := make([]RelatedMemory, 0, len(candidates))
+	for candidateID := range candidates {
+		memory, err := g.memoryStore.GetMemory(ctx, candidateID)
+		if err != nil {
+			continue // Deleted concurrently or outside this namespace
+		}
+		if (memory.Status == "Superseded" && !opts.IncludeSuperseded) || (memory.Status == store.StatusArchived && !opts.IncludeArchived) {
+			continue
+		}
+
+		nodes, _, err := g.memoryStore.GetProvenanceByMemory(ctx, candidateID)
+		if err != nil {
+			return nil, err
+		}
+
+		shared := make([]string, 0)
+		for _, id := range nodes {
+			if sourceSet[id] {
+				shared = append(shared, id)
+			}
+		}
+		overlap := float64(len(shared)) / float64(len(sourceNodes)+len(nodes)-len(shared))
+
+		similarity := 0.0
+		if sourceCentroid != nil {
+			centroid, err := g.nodeCentroid(ctx, nodes, embeddingCache)
+			if err != nil {
+				return nil, err
+			}
+			if centroid != nil {
+				similarity = max(store.CosineSimilarity(sourceCentroid, centroid), 0)
+			}
+		}
+
+		score := (overlap + similarity) / 2
+		if score < opts.MinScore {
+			continue
+		}
+		related = append(related, RelatedMemory{
+			MemoryID:    candidateID,
+			Topic:       memory.Topic,
+			Status:      memory.Status,
+			Decisions:   memory.Decisions,
+			Score:       score,
+			SharedNodes: shared,
+			Overlap:     overlap,
+			Similarity:  similarity,
+		})
+	}
+
+	sort.Slice(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		return related[i].MemoryID < related[j].MemoryID
+	})
+	if len(related) > topK {
+		related = related[:topK]
+	}
+
+	return related, nil
+}
+
+// nodeCentroid returns the mean embedding of the given nodes, or nil if none has an embedding.
+// Embeddings are memoized in cache (nil for nodes without one) across calls.
+func (g *Gognee) nodeCentroid(ctx context.Context, nodeIDs []string, cache map[string][]float32) ([]float32, error) {
+	var sum []float32
+	count := 0
+	for _, id := range nodeIDs {
+		embedding, ok := cache[id]
+		if !ok {
+			node, err := g.graphStore.GetNode(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load node %s: %w", id, err)
+			}
+			if node != nil {
+				embedding = node.Embedding
+			}
+			cache[id] = embedding
+		}
+		if len(embedding) == 0 || (sum != nil && len(embedding) != len(sum)) {
+			continue
+		}
+		if sum == nil {
+			sum = make([]float32, len(embedding))
+		}
+		for i, v := range embedding {
+			sum[i] += v
+		}
+		count++
+	}
+
+	if count == 0 {
+		return nil, nil
+	}
+	for i := range sum {
+		sum[i] /= float32(count)
+	}
+	return sum, nil
+}
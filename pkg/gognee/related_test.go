@@ -0,0 +1,133 @@
+package gognee
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+)
+
+func TestRelatedMemories_SharedNodesRankFirst(t *testing.T) {
+	ctx := context.Background()
+
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{
+				{Name: "SQLite", Type: "Technology", Description: "Embedded database"},
+				{Name: "Go", Type: "Technology", Description: "Programming language"},
+			},
+			{
+				{Name: "SQLite", Type: "Technology", Description: "Embedded database"},
+				{Name: "Backups", Type: "Process", Description: "Nightly snapshots"},
+			},
+			{
+				{Name: "Kubernetes", Type: "System", Description: "Container orchestrator"},
+			},
+		},
+	}
+	g.llm = mockLLM
+	g.embeddings = &MockEmbeddingClient{}
+	g.entityExtractor = extraction.NewEntityExtractor(mockLLM)
+	g.relationExtractor = extraction.NewRelationExtractor(mockLLM)
+
+	add := func(topic, context string) string {
+		res, err := g.AddMemory(ctx, MemoryInput{Topic: topic, Context: context})
+		if err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+		return res.MemoryID
+	}
+	storage := add("Storage engine", "We write the service in Go on top of SQLite.")
+	backups := add("Backup policy", "SQLite files are snapshotted nightly.")
+	deploy := add("Deployment", "Everything runs on Kubernetes.")
+
+	related, err := g.RelatedMemories(ctx, storage, 5)
+	if err != nil {
+		t.Fatalf("RelatedMemories failed: %v", err)
+	}
+	if len(related) == 0 {
+		t.Fatal("Expected at least one related memory")
+	}
+
+	top := related[0]
+	if top.MemoryID != backups {
+		t.Fatalf("Expected %s (shares SQLite) to rank first, got %+v", backups, related)
+	}
+	if len(top.SharedNodes) != 1 || top.SharedNodes[0] != generateDeterministicNodeID("SQLite", "Technology") {
+		t.Errorf("Expected SQLite as the only shared node, got %v", top.SharedNodes)
+	}
+	if top.Overlap <= 0 || top.Score <= 0 {
+		t.Errorf("Expected positive overlap and score, got %+v", top)
+	}
+
+	for _, r := range related {
+		if r.MemoryID == storage {
+			t.Error("Source memory must not be returned as related to itself")
+		}
+		if r.MemoryID == deploy && r.Overlap != 0 {
+			t.Errorf("Expected no node overlap with %s, got %v", deploy, r.Overlap)
+		}
+	}
+}
+
+func TestRelatedMemories_UnknownMemory(t *testing.T) {
+	g, err := New(Config{DBPath: ":memory:"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer g.Close()
+
+	if _, err := g.RelatedMemories(context.Background(), "does-not-exist", 5); err == nil {
+		t.Error("Expected error for unknown memory ID")
+	}
+}
+
+func TestGetRelatedMemories_Options(t *testing.T) {
+	ctx := context.Background()
+	sqlite := extraction.Entity{Name: "SQLite", Type: "Technology", Description: "Embedded database"}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{sqlite}, {sqlite}, {sqlite}},
+	})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	add := func(input MemoryInput) string {
+		res, err := g.AddMemory(ctx, input)
+		if err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+		return res.MemoryID
+	}
+	storage := add(MemoryInput{Topic: "Storage engine", Context: "The service stores data in SQLite."})
+	old := add(MemoryInput{Topic: "SQLite mode", Context: "SQLite runs in rollback mode.", Decisions: []string{"Use rollback journal"}})
+	current := add(MemoryInput{Topic: "SQLite mode", Context: "SQLite runs in WAL mode.", Decisions: []string{"Use WAL"}, Supersedes: []string{old}})
+
+	related, err := g.GetRelatedMemories(ctx, storage, RelatedMemoriesOptions{})
+	if err != nil {
+		t.Fatalf("GetRelatedMemories failed: %v", err)
+	}
+	if len(related) != 1 || related[0].MemoryID != current {
+		t.Fatalf("Expected only the current decision, got %+v", related)
+	}
+	if related[0].Status != "complete" || len(related[0].Decisions) != 1 || related[0].Decisions[0] != "Use WAL" {
+		t.Errorf("Expected the related memory's status and decisions, got %+v", related[0])
+	}
+
+	related, err = g.GetRelatedMemories(ctx, storage, RelatedMemoriesOptions{IncludeSuperseded: true})
+	if err != nil || len(related) != 2 {
+		t.Errorf("Expected the superseded memory with IncludeSuperseded, got %+v (err %v)", related, err)
+	}
+
+	related, err = g.GetRelatedMemories(ctx, storage, RelatedMemoriesOptions{MinScore: 1.01})
+	if err != nil || len(related) != 0 {
+		t.Errorf("Expected MinScore to drop every memory, got %+v (err %v)", related, err)
+	}
+}
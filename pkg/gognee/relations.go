@@ -0,0 +1,199 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// CollapseRelationsOptions configures the CollapseRelations() method
+type CollapseRelationsOptions struct {
+	// SimilarityThreshold is the minimum cosine similarity between two relation label
+	// embeddings for the less frequent label to be merged into the more frequent one.
+	// Default: 0.9
+	SimilarityThreshold float64
+
+	// SkipEmbedding disables the embedding-based pass; only the synonym map is applied.
+	SkipEmbedding bool
+
+	// DryRun reports what would be merged without relabelling edges or writing the audit log.
+	DryRun bool
+}
+
+// CollapseRelationsResult reports the outcome of a CollapseRelations() operation
+type CollapseRelationsResult struct {
+	LabelsEvaluated int                   // Distinct relation labels considered
+	Merges          []store.RelationMerge // Merges applied (or proposed when DryRun)
+	EdgesUpdated    int64                 // Total edges relabelled
+}
+
+// canonicalRelation maps a relation label to its canonical form using the synonym map.
+// Labels without a mapping are returned unchanged. Chains (A→B, B→C) are followed.
+func (g *Gognee) canonicalRelation(relation string) string {
+	g.relationMu.RLock()
+	defer g.relationMu.RUnlock()
+	current := relation
+	for i := 0; i <= len(g.relationSynonyms); i++ {
+		next, ok := g.relationSynonyms[sanitizeRelation(current)]
+		if !ok || sanitizeRelation(next) == sanitizeRelation(current) {
+			break
+		}
+		current = next
+	}
+	return current
+}
+
+// CollapseRelations merges near-duplicate relation labels graph-wide.
+// Labels are first mapped through the configured synonym map (Config.RelationSynonyms);
+// remaining labels are embedded and any label whose embedding is within SimilarityThreshold
+// of a more frequently used label is merged into it.
+// Every merge is recorded in the relation_merges audit table and added to the synonym map,
+// so subsequent Cognify/AddMemory calls emit the canonical label directly.
+func (g *Gognee) CollapseRelations(ctx context.Context, opts CollapseRelationsOptions) (*CollapseRelationsResult, error) {
+	relations, ok := g.graphStore.(store.RelationMerger)
+	if !ok {
+		return nil, fmt.Errorf("collapse relations requires a graph store implementing store.RelationMerger")
+	}
+
+	if opts.SimilarityThreshold == 0 {
+		opts.SimilarityThreshold = 0.9
+	}
+	if opts.SimilarityThreshold < 0 || opts.SimilarityThreshold > 1 {
+		return nil, fmt.Errorf("SimilarityThreshold must be between 0 and 1, got %v", opts.SimilarityThreshold)
+	}
+
+	counts, err := relations.RelationCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CollapseRelationsResult{
+		LabelsEvaluated: len(counts),
+		Merges:          make([]store.RelationMerge, 0),
+	}
+
+	// Pass 1: explicit synonyms
+	remaining := make([]string, 0, len(counts))
+	for _, rc := range counts {
+		canonical := g.canonicalRelation(rc.Relation)
+		if canonical != rc.Relation {
+			result.Merges = append(result.Merges, store.RelationMerge{
+				FromRelation: rc.Relation,
+				ToRelation:   canonical,
+				Method:       "synonym",
+				Similarity:   1.0,
+			})
+			continue
+		}
+		remaining = append(remaining, rc.Relation)
+	}
+
+	// Pass 2: embedding similarity. Labels arrive ordered by frequency, so each label
+	// is compared only against more frequent labels that were kept as canonical.
+	if !opts.SkipEmbedding && len(remaining) > 1 {
+		texts := make([]string, len(remaining))
+		for i, label := range remaining {
+			texts[i] = relationEmbeddingText(label)
+		}
+
+		vectors, err := g.embeddings.Embed(ctx, texts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed relation labels: %w", err)
+		}
+		if len(vectors) != len(remaining) {
+			return nil, fmt.Errorf("embedding client returned %d vectors for %d relation labels", len(vectors), len(remaining))
+		}
+
+		canonicalIdx := make([]int, 0, len(remaining))
+		for i, label := range remaining {
+			best, bestSim := -1, 0.0
+			for _, j := range canonicalIdx {
+				sim := store.CosineSimilarity(vectors[i], vectors[j])
+				if sim >= opts.SimilarityThreshold && sim > bestSim {
+					best, bestSim = j, sim
+				}
+			}
+
+			if best < 0 {
+				canonicalIdx = append(canonicalIdx, i)
+				continue
+			}
+
+			result.Merges = append(result.Merges, store.RelationMerge{
+				FromRelation: label,
+				ToRelation:   remaining[best],
+				Method:       "embedding",
+				Similarity:   bestSim,
+			})
+		}
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	for i := range result.Merges {
+		merge := &result.Merges[i]
+
+		updated, changes, err := relations.RenameRelation(ctx, merge.FromRelation, merge.ToRelation)
+		if err != nil {
+			return nil, err
+		}
+		merge.EdgesUpdated = updated
+		result.EdgesUpdated += updated
+		if err := g.syncEdgeVectors(ctx, changes); err != nil {
+			return nil, err
+		}
+
+		if err := relations.RecordRelationMerge(ctx, merge); err != nil {
+			return nil, err
+		}
+		g.relationMu.Lock()
+		g.relationSynonyms[sanitizeRelation(merge.FromRelation)] = merge.ToRelation
+		g.relationMu.Unlock()
+
+		if g.logger != nil {
+			g.logger.LogAttrs(ctx, slog.LevelInfo, "relation merged",
+				slog.String("from", merge.FromRelation),
+				slog.String("to", merge.ToRelation),
+				slog.String("method", merge.Method),
+				slog.Float64("similarity", merge.Similarity),
+				slog.Int64("edges_updated", merge.EdgesUpdated),
+			)
+		}
+	}
+
+	return result, nil
+}
+
+// RelationMerges returns the audit log of relation labels collapsed by CollapseRelations.
+func (g *Gognee) RelationMerges(ctx context.Context) ([]store.RelationMerge, error) {
+	relations, ok := g.graphStore.(store.RelationMerger)
+	if !ok {
+		return nil, fmt.Errorf("relation merge audit requires a graph store implementing store.RelationMerger")
+	}
+	return relations.ListRelationMerges(ctx)
+}
+
+// relationEmbeddingText converts a relation label like DEPENDS_ON into plain words
+// ("depends on") so that embeddings compare meaning rather than formatting.
+func relationEmbeddingText(relation string) string {
+	text := strings.ToLower(strings.ReplaceAll(relation, "_", " "))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// buildRelationSynonyms merges persisted merges with configured synonyms.
+// Configured synonyms take precedence over merges learned by earlier CollapseRelations runs.
+func buildRelationSynonyms(merges []store.RelationMerge, configured map[string]string) map[string]string {
+	synonyms := make(map[string]string, len(merges)+len(configured))
+	for _, m := range merges {
+		synonyms[sanitizeRelation(m.FromRelation)] = m.ToRelation
+	}
+	for from, to := range configured {
+		synonyms[sanitizeRelation(from)] = to
+	}
+	return synonyms
+}
@@ -0,0 +1,244 @@
+package gognee
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// labelEmbeddingClient returns fixed vectors per text so similarity is controllable in tests.
+type labelEmbeddingClient struct {
+	vectors map[string][]float32
+}
+
+func (c *labelEmbeddingClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	result := make([][]float32, len(texts))
+	for i, text := range texts {
+		if v, ok := c.vectors[text]; ok {
+			result[i] = v
+		} else {
+			result[i] = deterministicEmbedding(text)
+		}
+	}
+	return result, nil
+}
+
+func (c *labelEmbeddingClient) EmbedOne(ctx context.Context, text string) ([]float32, error) {
+	vs, _ := c.Embed(ctx, []string{text})
+	return vs[0], nil
+}
+
+func seedRelationGraph(t *testing.T, g *Gognee, relations map[string]string) {
+	t.Helper()
+	ctx := context.Background()
+	for _, id := range []string{"a", "b", "c", "d"} {
+		if err := g.graphStore.AddNode(ctx, &store.Node{ID: id, Name: id}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	for id, rel := range relations {
+		edge := &store.Edge{ID: id, SourceID: "a", Relation: rel, TargetID: id[len(id)-1:]}
+		if err := g.graphStore.AddEdge(ctx, edge); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+}
+
+func TestCanonicalRelation_ConfiguredSynonyms(t *testing.T) {
+	g, err := NewWithClients(Config{
+		DBPath:           ":memory:",
+		RelationSynonyms: map[string]string{"using": "USES", "Utilizes": "USING"},
+	}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	tests := map[string]string{
+		"USING":      "USES",
+		"using":      "USES",
+		"UTILIZES":   "USES", // chained via USING
+		"DEPENDS_ON": "DEPENDS_ON",
+	}
+	for in, want := range tests {
+		if got := g.canonicalRelation(in); got != want {
+			t.Errorf("canonicalRelation(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCognify_AppliesRelationSynonyms(t *testing.T) {
+	llmClient := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{
+			{Name: "Gognee", Type: "System", Description: "memory library"},
+			{Name: "SQLite", Type: "Technology", Description: "database"},
+		}},
+		RelationResponses: [][]extraction.Triplet{{
+			{Subject: "Gognee", Relation: "UTILIZES", Object: "SQLite"},
+		}},
+	}
+	g, err := NewWithClients(Config{
+		DBPath:           ":memory:",
+		RelationSynonyms: map[string]string{"UTILIZES": "USES"},
+	}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	ctx := context.Background()
+	if err := g.Add(ctx, "Gognee utilizes SQLite.", AddOptions{}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+
+	sourceID := generateDeterministicNodeID("Gognee", "System")
+	edges, err := g.graphStore.GetEdges(ctx, sourceID)
+	if err != nil {
+		t.Fatalf("GetEdges failed: %v", err)
+	}
+	if len(edges) != 1 || edges[0].Relation != "USES" {
+		t.Fatalf("Expected one USES edge, got %+v", edges)
+	}
+}
+
+func TestCollapseRelations_SynonymAndEmbedding(t *testing.T) {
+	emb := &labelEmbeddingClient{vectors: map[string][]float32{
+		"uses":     {1, 0, 0},
+		"using":    {0.98, 0.2, 0},
+		"utilizes": {0.97, 0.24, 0},
+		"owns":     {0, 1, 0},
+	}}
+	g, err := NewWithClients(Config{
+		DBPath:           ":memory:",
+		RelationSynonyms: map[string]string{"UTILIZES": "USES"},
+	}, emb, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	// USES is most frequent so it is the canonical target
+	seedRelationGraph(t, g, map[string]string{
+		"e-b": "USES",
+		"e-c": "USES",
+		"e-d": "USING",
+		"x-b": "UTILIZES",
+		"y-c": "OWNS",
+	})
+	ctx := context.Background()
+
+	result, err := g.CollapseRelations(ctx, CollapseRelationsOptions{})
+	if err != nil {
+		t.Fatalf("CollapseRelations failed: %v", err)
+	}
+	if result.LabelsEvaluated != 4 {
+		t.Errorf("LabelsEvaluated: got %d, want 4", result.LabelsEvaluated)
+	}
+
+	methods := map[string]string{}
+	for _, m := range result.Merges {
+		if m.ToRelation != "USES" {
+			t.Errorf("Unexpected merge target %s for %s", m.ToRelation, m.FromRelation)
+		}
+		methods[m.FromRelation] = m.Method
+	}
+	if methods["UTILIZES"] != "synonym" || methods["USING"] != "embedding" {
+		t.Errorf("Unexpected merges: %+v", result.Merges)
+	}
+	if _, merged := methods["OWNS"]; merged {
+		t.Error("OWNS should not have been merged")
+	}
+	if result.EdgesUpdated != 2 {
+		t.Errorf("EdgesUpdated: got %d, want 2", result.EdgesUpdated)
+	}
+
+	counts, err := g.graphStore.(*store.SQLiteGraphStore).RelationCounts(ctx)
+	if err != nil {
+		t.Fatalf("RelationCounts failed: %v", err)
+	}
+	// x-b duplicated e-b (a USES b) once relabelled, so it was merged into it
+	if len(counts) != 2 || counts[0].Relation != "USES" || counts[0].Count != 3 {
+		t.Errorf("Unexpected relation counts after collapse: %+v", counts)
+	}
+
+	audit, err := g.RelationMerges(ctx)
+	if err != nil {
+		t.Fatalf("RelationMerges failed: %v", err)
+	}
+	if len(audit) != 2 {
+		t.Errorf("Expected 2 audit records, got %d", len(audit))
+	}
+
+	// Newly learned merges apply to future edges
+	if got := g.canonicalRelation("USING"); got != "USES" {
+		t.Errorf("canonicalRelation(USING) after collapse = %q, want USES", got)
+	}
+}
+
+func TestCollapseRelations_DryRun(t *testing.T) {
+	g, err := NewWithClients(Config{
+		DBPath:           ":memory:",
+		RelationSynonyms: map[string]string{"UTILIZES": "USES"},
+	}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	seedRelationGraph(t, g, map[string]string{"e-b": "USES", "e-c": "UTILIZES"})
+
+	ctx := context.Background()
+	result, err := g.CollapseRelations(ctx, CollapseRelationsOptions{DryRun: true, SkipEmbedding: true})
+	if err != nil {
+		t.Fatalf("CollapseRelations failed: %v", err)
+	}
+	if len(result.Merges) != 1 {
+		t.Fatalf("Expected 1 proposed merge, got %d", len(result.Merges))
+	}
+
+	audit, err := g.RelationMerges(ctx)
+	if err != nil {
+		t.Fatalf("RelationMerges failed: %v", err)
+	}
+	if len(audit) != 0 {
+		t.Errorf("DryRun must not write audit records, got %d", len(audit))
+	}
+	counts, _ := g.graphStore.(*store.SQLiteGraphStore).RelationCounts(ctx)
+	if len(counts) != 2 {
+		t.Errorf("DryRun must not relabel edges, got %+v", counts)
+	}
+}
+
+func TestCollapseRelations_MergesPersistAcrossRestart(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "relations.db")
+
+	g, err := NewWithClients(Config{
+		DBPath:           dbPath,
+		RelationSynonyms: map[string]string{"UTILIZES": "USES"},
+	}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	seedRelationGraph(t, g, map[string]string{"e-b": "USES", "e-c": "UTILIZES"})
+	if _, err := g.CollapseRelations(context.Background(), CollapseRelationsOptions{SkipEmbedding: true}); err != nil {
+		t.Fatalf("CollapseRelations failed: %v", err)
+	}
+	g.Close()
+
+	// Reopen without configured synonyms: the audited merge is still applied
+	g2, err := NewWithClients(Config{DBPath: dbPath}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients (reopen) failed: %v", err)
+	}
+	defer g2.Close()
+
+	if got := g2.canonicalRelation("UTILIZES"); got != "USES" {
+		t.Errorf("canonicalRelation(UTILIZES) after reopen = %q, want USES", got)
+	}
+}
@@ -0,0 +1,234 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dan-solli/gognee/pkg/chunker"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+const (
+	defaultRenderEdges       = 8
+	defaultRenderMemories    = 3
+	defaultRenderTokenBudget = 256
+
+	// renderPreviewLength bounds the memory context shown per linked memory
+	renderPreviewLength = 160
+)
+
+// RenderOptions configures compact node rendering for prompts.
+type RenderOptions struct {
+	MaxEdges    int // Strongest incident edges to include (default: 8)
+	MaxMemories int // Most recently updated linked memories to include (default: 3)
+	// TokenBudget caps the estimated size of the rendered text (default: 256).
+	// Lines that would exceed it are left out, least important first.
+	TokenBudget int
+}
+
+func (o *RenderOptions) applyDefaults() {
+	if o.MaxEdges <= 0 {
+		o.MaxEdges = defaultRenderEdges
+	}
+	if o.MaxMemories <= 0 {
+		o.MaxMemories = defaultRenderMemories
+	}
+	if o.TokenBudget <= 0 {
+		o.TokenBudget = defaultRenderTokenBudget
+	}
+}
+
+// RenderNode serializes a node, its strongest edges and summaries of the memories it came
+// from into a compact text block for LLM prompts:
+//
+//	PostgreSQL (Technology): Relational database
+//	- USES -> Go
+//	- <- DEPENDS_ON Billing
+//	- memory "Database choice": We moved from SQLite to Postgres.
+//
+// Output is deterministic for the same graph state: edges are ordered by weight, relation
+// and neighbour name; memories by most recent update. The result stays within
+// opts.TokenBudget (estimated with chunker.EstimateTokens); the header line is always kept.
+func (g *Gognee) RenderNode(ctx context.Context, nodeID string, opts RenderOptions) (string, error) {
+	opts.applyDefaults()
+	lines, err := g.renderNodeLines(ctx, nodeID, opts)
+	if err != nil {
+		return "", err
+	}
+
+	var b budgetWriter
+	b.budget = opts.TokenBudget
+	b.force(lines[0])
+	for _, line := range lines[1:] {
+		if !b.write(line) {
+			break
+		}
+	}
+	return b.String(), nil
+}
+
+// RenderNodes renders several nodes in order, separated by blank lines, sharing one
+// token budget. Nodes that no longer fit are left out; at least the first header is kept.
+func (g *Gognee) RenderNodes(ctx context.Context, nodeIDs []string, opts RenderOptions) (string, error) {
+	opts.applyDefaults()
+
+	var b budgetWriter
+	b.budget = opts.TokenBudget
+	for i, nodeID := range nodeIDs {
+		lines, err := g.renderNodeLines(ctx, nodeID, opts)
+		if err != nil {
+			return "", err
+		}
+		header := lines[0]
+		if i > 0 {
+			header = "\n" + header
+		}
+		if i == 0 {
+			b.force(header)
+		} else if !b.write(header) {
+			break
+		}
+		for _, line := range lines[1:] {
+			if !b.write(line) {
+				break
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+// renderNodeLines returns the header line of a node followed by its edge and memory lines
+func (g *Gognee) renderNodeLines(ctx context.Context, nodeID string, opts RenderOptions) ([]string, error) {
+	node, err := g.graphStore.GetNode(ctx, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load node %s: %w", nodeID, err)
+	}
+	if node == nil {
+		return nil, fmt.Errorf("cannot render %s: %w", nodeID, store.ErrNodeNotFound)
+	}
+
+	header := fmt.Sprintf("%s (%s)", node.Name, node.Type)
+	if description := singleLine(node.Description); description != "" {
+		header += ": " + description
+	}
+	lines := []string{header}
+
+	edges, err := g.graphStore.GetEdges(ctx, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load edges of %s: %w", nodeID, err)
+	}
+	type renderedEdge struct {
+		weight   float64
+		relation string
+		name     string
+		line     string
+	}
+	rendered := make([]renderedEdge, 0, len(edges))
+	names := map[string]string{nodeID: node.Name}
+	for _, edge := range edges {
+		otherID, outgoing := edge.TargetID, true
+		if edge.SourceID != nodeID {
+			otherID, outgoing = edge.SourceID, false
+		}
+		name, ok := names[otherID]
+		if !ok {
+			other, err := g.graphStore.GetNode(ctx, otherID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load node %s: %w", otherID, err)
+			}
+			if other != nil {
+				name = other.Name
+			}
+			names[otherID] = name
+		}
+		if name == "" {
+			continue // Dangling edge
+		}
+		line := fmt.Sprintf("- %s -> %s", edge.Relation, name)
+		if !outgoing {
+			line = fmt.Sprintf("- <- %s %s", edge.Relation, name)
+		}
+		rendered = append(rendered, renderedEdge{weight: edge.Weight, relation: edge.Relation, name: name, line: line})
+	}
+	sort.Slice(rendered, func(i, j int) bool {
+		a, b := rendered[i], rendered[j]
+		if a.weight != b.weight {
+			return a.weight > b.weight
+		}
+		if a.relation != b.relation {
+			return a.relation < b.relation
+		}
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		return a.line < b.line
+	})
+	for i, e := range rendered {
+		if i == opts.MaxEdges {
+			break
+		}
+		lines = append(lines, e.line)
+	}
+
+	memoryIDs, err := g.memoryStore.GetMemoriesByNodeIDBatched(ctx, []string{nodeID})
+	if err != nil {
+		return nil, err
+	}
+	for i, memoryID := range memoryIDs[nodeID] {
+		if i == opts.MaxMemories {
+			break
+		}
+		memory, err := g.memoryStore.GetMemory(ctx, memoryID)
+		if err != nil {
+			continue // Deleted concurrently
+		}
+		lines = append(lines, fmt.Sprintf("- memory %q: %s", memory.Topic, truncateText(singleLine(memory.Context), renderPreviewLength)))
+	}
+
+	return lines, nil
+}
+
+// budgetWriter joins lines while their estimated token count stays within budget
+type budgetWriter struct {
+	budget int
+	used   int
+	b      strings.Builder
+}
+
+// force writes a line regardless of the budget
+func (w *budgetWriter) force(line string) {
+	if w.b.Len() > 0 {
+		w.b.WriteByte('\n')
+	}
+	w.b.WriteString(line)
+	w.used += chunker.EstimateTokens(line)
+}
+
+// write adds a line if it fits the remaining budget and reports whether it did
+func (w *budgetWriter) write(line string) bool {
+	if w.used+chunker.EstimateTokens(line) > w.budget {
+		return false
+	}
+	w.force(line)
+	return true
+}
+
+func (w *budgetWriter) String() string {
+	return w.b.String()
+}
+
+// singleLine collapses all whitespace runs, including newlines, to single spaces
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// truncateText shortens s to at most n runes, marking the cut with an ellipsis
+func truncateText(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:n])) + "…"
+}
@@ -0,0 +1,96 @@
+package gognee
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+)
+
+func newRenderTestGognee(t *testing.T) *Gognee {
+	t.Helper()
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{
+			{Name: "Postgres", Type: "Technology", Description: "Relational\ndatabase"},
+			{Name: "Go", Type: "Technology", Description: "Programming language"},
+			{Name: "Billing", Type: "System", Description: "Invoicing service"},
+		}},
+		RelationResponses: [][]extraction.Triplet{{
+			{Subject: "Billing", Relation: "DEPENDS_ON", Object: "Postgres"},
+			{Subject: "Postgres", Relation: "USED_WITH", Object: "Go"},
+		}},
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	t.Cleanup(func() { g.Close() })
+
+	if _, err := g.AddMemory(context.Background(), MemoryInput{
+		Topic:   "Database choice",
+		Context: "Billing is written in Go and stores invoices in Postgres.",
+	}); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	return g
+}
+
+func TestRenderNode(t *testing.T) {
+	ctx := context.Background()
+	g := newRenderTestGognee(t)
+	postgres := g.nodeID("Postgres", "Technology")
+
+	text, err := g.RenderNode(ctx, postgres, RenderOptions{})
+	if err != nil {
+		t.Fatalf("RenderNode failed: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"Postgres (Technology): Relational database",
+		"- <- DEPENDS_ON Billing",
+		"- USED_WITH -> Go",
+		`- memory "Database choice": Billing is written in Go and stores invoices in Postgres.`,
+	}, "\n")
+	if text != want {
+		t.Errorf("RenderNode =\n%s\nwant\n%s", text, want)
+	}
+
+	again, _ := g.RenderNode(ctx, postgres, RenderOptions{})
+	if again != text {
+		t.Error("Expected deterministic rendering")
+	}
+}
+
+func TestRenderNode_TokenBudget(t *testing.T) {
+	ctx := context.Background()
+	g := newRenderTestGognee(t)
+	postgres := g.nodeID("Postgres", "Technology")
+
+	text, err := g.RenderNode(ctx, postgres, RenderOptions{TokenBudget: 8})
+	if err != nil {
+		t.Fatalf("RenderNode failed: %v", err)
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) != 2 || lines[0] != "Postgres (Technology): Relational database" {
+		t.Errorf("Expected header and one edge within budget, got %q", text)
+	}
+
+	text, err = g.RenderNodes(ctx, []string{postgres, g.nodeID("Go", "Technology")}, RenderOptions{MaxEdges: 1, MaxMemories: 1, TokenBudget: 1000})
+	if err != nil {
+		t.Fatalf("RenderNodes failed: %v", err)
+	}
+	if !strings.Contains(text, "\n\nGo (Technology): Programming language") {
+		t.Errorf("Expected nodes separated by a blank line, got %q", text)
+	}
+	if strings.Contains(text, "USED_WITH -> Go") {
+		t.Errorf("Expected MaxEdges to keep only the first edge per node, got %q", text)
+	}
+}
+
+func TestRenderNode_UnknownNode(t *testing.T) {
+	g := newRenderTestGognee(t)
+	if _, err := g.RenderNode(context.Background(), "missing", RenderOptions{}); err == nil {
+		t.Error("Expected error for unknown node")
+	}
+}
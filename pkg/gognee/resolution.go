@@ -0,0 +1,421 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// Entity resolution methods (Config.EntityResolution, ResolveEntitiesOptions.Method).
+// Each method also applies the ones before it.
+const (
+	// ResolutionExact merges entities of the same type whose names match after lowercasing
+	// and dropping punctuation and spaces ("Node.js" and "NodeJS").
+	ResolutionExact = "exact"
+	// ResolutionEmbedding also merges entities whose embeddings reach the similarity threshold.
+	ResolutionEmbedding = "embedding"
+	// ResolutionLLM asks the LLM whether entities above the similarity threshold are the same.
+	ResolutionLLM = "llm"
+)
+
+const (
+	defaultEmbeddingResolutionThreshold = 0.9
+	defaultLLMResolutionThreshold       = 0.75
+
+	// maxResolutionCandidates caps the LLM adjudications per entity
+	maxResolutionCandidates = 3
+)
+
+const resolutionPrompt = `Do these two knowledge graph entities refer to the same real-world thing?
+Answer true only if one is an alias, abbreviation or alternative spelling of the other.
+
+A: %s (%s): %s
+B: %s (%s): %s
+
+Return a JSON object: {"same": true} or {"same": false}`
+
+// ResolveEntitiesOptions configures the ResolveEntities() method
+type ResolveEntitiesOptions struct {
+	// Method is ResolutionExact, ResolutionEmbedding or ResolutionLLM.
+	// Default: Config.EntityResolution, else ResolutionExact.
+	Method string
+
+	// SimilarityThreshold is the minimum cosine similarity between two node embeddings for
+	// them to be merged (ResolutionEmbedding) or adjudicated by the LLM (ResolutionLLM).
+	// Default: Config.EntityResolutionThreshold, else 0.9 (embedding) or 0.75 (llm).
+	SimilarityThreshold float64
+
+	// DryRun reports what would be merged without changing the graph or writing the audit log.
+	DryRun bool
+
+	// NodeIDs limits resolution to pairs involving at least one of these nodes, so Cognify
+	// only compares the entities it just wrote with the rest of the graph.
+	// Default: every pair of entities of the same type.
+	NodeIDs []string
+}
+
+// ResolveEntitiesResult reports the outcome of a ResolveEntities() operation
+type ResolveEntitiesResult struct {
+	NodesEvaluated int                 // Entity nodes considered
+	Merges         []store.EntityMerge // Merges applied (or proposed when DryRun)
+	EdgesUpdated   int64               // Total edges repointed or dropped as duplicates
+	Status         OperationStatus     // OperationCancelled when ctx ended the resolution early
+}
+
+// ResolveEntities merges entity nodes that name the same thing ("PostgreSQL", "Postgres",
+// "postgres db") into one canonical node. Only nodes of the same type are compared; the
+// most mentioned (then oldest) node of each alias group is kept as canonical.
+// Merged names are recorded in the canonical node's "aliases" metadata and in the
+// entity_merges audit table, and later extractions of an alias are mapped to the
+// canonical entity.
+func (g *Gognee) ResolveEntities(ctx context.Context, opts ResolveEntitiesOptions) (*ResolveEntitiesResult, error) {
+	reader, readable := g.graphStore.(store.BulkReader)
+	entities, ok := g.graphStore.(store.EntityMerger)
+	if !ok || !readable {
+		return nil, fmt.Errorf("entity resolution requires a graph store implementing store.BulkReader and store.EntityMerger")
+	}
+
+	if opts.Method == "" {
+		opts.Method = g.config.EntityResolution
+	}
+	if opts.Method == "" {
+		opts.Method = ResolutionExact
+	}
+	if err := validateResolutionMethod(opts.Method); err != nil {
+		return nil, err
+	}
+	if opts.SimilarityThreshold == 0 {
+		opts.SimilarityThreshold = g.config.EntityResolutionThreshold
+	}
+	if opts.SimilarityThreshold == 0 {
+		opts.SimilarityThreshold = defaultEmbeddingResolutionThreshold
+		if opts.Method == ResolutionLLM {
+			opts.SimilarityThreshold = defaultLLMResolutionThreshold
+		}
+	}
+	if opts.SimilarityThreshold < 0 || opts.SimilarityThreshold > 1 {
+		return nil, fmt.Errorf("SimilarityThreshold must be between 0 and 1, got %v", opts.SimilarityThreshold)
+	}
+
+	nodes, err := reader.GetAllNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// With NodeIDs, only types holding one of them are compared
+	var scope map[string]bool
+	scopedTypes := make(map[string]bool)
+	if len(opts.NodeIDs) > 0 {
+		scope = make(map[string]bool, len(opts.NodeIDs))
+		for _, id := range opts.NodeIDs {
+			scope[id] = true
+		}
+		for _, node := range nodes {
+			if scope[node.ID] {
+				scopedTypes[node.Type] = true
+			}
+		}
+	}
+
+	byType := make(map[string][]*store.Node)
+	var types []string
+	for _, node := range nodes {
+		if node.Type == NoteNodeType {
+			continue // Quick-added notes are documents, not entities
+		}
+		if scope != nil && !scopedTypes[node.Type] {
+			continue
+		}
+		if _, ok := byType[node.Type]; !ok {
+			types = append(types, node.Type)
+		}
+		byType[node.Type] = append(byType[node.Type], node)
+	}
+	sort.Strings(types)
+
+	// Once ctx is done, merges already applied are reported; proposals not yet applied are dropped
+	result := &ResolveEntitiesResult{Merges: make([]store.EntityMerge, 0), Status: OperationCompleted}
+	for _, typ := range types {
+		if ctx.Err() != nil {
+			break
+		}
+		group := byType[typ]
+		result.NodesEvaluated += len(group)
+
+		// Canonical candidates first: most mentioned, then oldest
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].MentionCount != group[j].MentionCount {
+				return group[i].MentionCount > group[j].MentionCount
+			}
+			return group[i].CreatedAt.Before(group[j].CreatedAt)
+		})
+
+		// Out of scope nodes were resolved before, so they are only compared with scoped ones
+		var canonical, scopedCanonical []*store.Node
+		for _, node := range group {
+			if ctx.Err() != nil {
+				break
+			}
+			candidates := canonical
+			if scope != nil && !scope[node.ID] {
+				candidates = scopedCanonical
+			}
+			target, method, similarity, err := g.findCanonicalEntity(ctx, node, candidates, opts)
+			if err != nil {
+				return nil, err
+			}
+			if target == nil {
+				canonical = append(canonical, node)
+				if scope[node.ID] {
+					scopedCanonical = append(scopedCanonical, node)
+				}
+				continue
+			}
+
+			result.Merges = append(result.Merges, store.EntityMerge{
+				FromNodeID: node.ID,
+				FromName:   node.Name,
+				ToNodeID:   target.ID,
+				ToName:     target.Name,
+				Type:       typ,
+				Method:     method,
+				Similarity: similarity,
+			})
+		}
+	}
+
+	if ctx.Err() != nil {
+		result.Status = OperationCancelled
+		if !opts.DryRun {
+			result.Merges = result.Merges[:0]
+		}
+		return result, nil
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	for i := range result.Merges {
+		if ctx.Err() != nil {
+			result.Status = OperationCancelled
+			result.Merges = result.Merges[:i]
+			return result, nil
+		}
+		merge := &result.Merges[i]
+
+		updated, changes, err := entities.MergeNodes(ctx, merge.FromNodeID, merge.ToNodeID)
+		if err != nil {
+			return nil, err
+		}
+		merge.EdgesUpdated = updated
+		result.EdgesUpdated += updated
+		if err := g.syncEdgeVectors(ctx, changes); err != nil {
+			return nil, err
+		}
+
+		if err := g.vectorStore.Delete(ctx, merge.FromNodeID); err != nil {
+			return nil, fmt.Errorf("failed to delete merged node embedding: %w", err)
+		}
+		if err := entities.RecordEntityMerge(ctx, merge); err != nil {
+			return nil, err
+		}
+		g.learnEntityMerge(*merge)
+
+		if g.logger != nil {
+			g.logger.LogAttrs(ctx, slog.LevelInfo, "entity merged",
+				slog.String("from", merge.FromName),
+				slog.String("to", merge.ToName),
+				slog.String("type", merge.Type),
+				slog.String("method", merge.Method),
+				slog.Float64("similarity", merge.Similarity),
+				slog.Int64("edges_updated", merge.EdgesUpdated),
+			)
+		}
+	}
+
+	return result, nil
+}
+
+// EntityMerges returns the audit log of entities merged by ResolveEntities.
+func (g *Gognee) EntityMerges(ctx context.Context) ([]store.EntityMerge, error) {
+	entities, ok := g.graphStore.(store.EntityMerger)
+	if !ok {
+		return nil, fmt.Errorf("entity merge audit requires a graph store implementing store.EntityMerger")
+	}
+	return entities.ListEntityMerges(ctx)
+}
+
+// findCanonicalEntity returns the canonical node that node is an alias of, with the
+// method and similarity that matched, or nil if node is canonical itself.
+func (g *Gognee) findCanonicalEntity(ctx context.Context, node *store.Node, canonical []*store.Node, opts ResolveEntitiesOptions) (*store.Node, string, float64, error) {
+	key := entityResolutionKey(node.Name)
+	for _, c := range canonical {
+		if key != "" && entityResolutionKey(c.Name) == key {
+			return c, ResolutionExact, 1.0, nil
+		}
+	}
+
+	if opts.Method == ResolutionExact || len(node.Embedding) == 0 {
+		return nil, "", 0, nil
+	}
+
+	type candidate struct {
+		node       *store.Node
+		similarity float64
+	}
+	var candidates []candidate
+	for _, c := range canonical {
+		if len(c.Embedding) != len(node.Embedding) {
+			continue
+		}
+		if sim := store.CosineSimilarity(node.Embedding, c.Embedding); sim >= opts.SimilarityThreshold {
+			candidates = append(candidates, candidate{c, sim})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].similarity > candidates[j].similarity })
+
+	if opts.Method == ResolutionEmbedding {
+		if len(candidates) == 0 {
+			return nil, "", 0, nil
+		}
+		return candidates[0].node, ResolutionEmbedding, candidates[0].similarity, nil
+	}
+
+	if len(candidates) > maxResolutionCandidates {
+		candidates = candidates[:maxResolutionCandidates]
+	}
+	for _, c := range candidates {
+		var verdict struct {
+			Same bool `json:"same"`
+		}
+		prompt := fmt.Sprintf(resolutionPrompt, c.node.Name, c.node.Type, c.node.Description, node.Name, node.Type, node.Description)
+		if err := g.stageLLM(StageEntityResolution).CompleteWithSchema(ctx, prompt, &verdict); err != nil {
+			return nil, "", 0, fmt.Errorf("entity resolution adjudication failed: %w", err)
+		}
+		if verdict.Same {
+			return c.node, ResolutionLLM, c.similarity, nil
+		}
+	}
+	return nil, "", 0, nil
+}
+
+// validateResolutionMethod checks an entity resolution method name
+func validateResolutionMethod(method string) error {
+	switch method {
+	case ResolutionExact, ResolutionEmbedding, ResolutionLLM:
+		return nil
+	}
+	return store.Invalidf("entity resolution method must be %q, %q or %q, got %q",
+		ResolutionExact, ResolutionEmbedding, ResolutionLLM, method)
+}
+
+// entityResolutionKey lowercases a name and drops everything but letters and digits,
+// so "Node.js", "NodeJS" and "node js" share a key.
+func entityResolutionKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// entityAliasKey identifies an entity name of a given type in the alias map
+func entityAliasKey(name, entityType string) string {
+	return normalizeEntityName(name) + "|" + entityType
+}
+
+// learnEntityMerge records a merge in the in-memory alias maps so later extractions
+// of the alias resolve to the canonical entity.
+func (g *Gognee) learnEntityMerge(merge store.EntityMerge) {
+	g.aliasMu.Lock()
+	defer g.aliasMu.Unlock()
+	g.recordEntityAlias(merge)
+}
+
+// recordEntityAlias is learnEntityMerge for callers holding aliasMu
+func (g *Gognee) recordEntityAlias(merge store.EntityMerge) {
+	g.entityAliases[entityAliasKey(merge.FromName, merge.Type)] = merge.ToName
+
+	aliases := append(g.nodeAliases[merge.ToNodeID], merge.FromName)
+	aliases = append(aliases, g.nodeAliases[merge.FromNodeID]...)
+	g.nodeAliases[merge.ToNodeID] = aliases
+	delete(g.nodeAliases, merge.FromNodeID)
+}
+
+// canonicalEntityName maps an entity name to the canonical name it was merged into.
+// Names without a merge are returned unchanged. Chains (A→B, B→C) are followed.
+// Callers hold aliasMu.
+func (g *Gognee) canonicalEntityName(name, entityType string) string {
+	current := name
+	for i := 0; i <= len(g.entityAliases); i++ {
+		next, ok := g.entityAliases[entityAliasKey(current, entityType)]
+		if !ok || normalizeEntityName(next) == normalizeEntityName(current) {
+			break
+		}
+		current = next
+	}
+	return current
+}
+
+// entityRenames maps normalized extracted names to the canonical names they resolved to
+type entityRenames map[string]string
+
+// apply returns the canonical name for an extracted name, or the name unchanged
+func (r entityRenames) apply(name string) string {
+	if canonical, ok := r[normalizeEntityName(name)]; ok {
+		return canonical
+	}
+	return name
+}
+
+// canonicalEntities returns a copy of the extracted entities with known aliases renamed to
+// their canonical names, and the renames so relation triplets can be mapped the same way.
+func (g *Gognee) canonicalEntities(entities []extraction.Entity) ([]extraction.Entity, entityRenames) {
+	renames := make(entityRenames)
+	g.aliasMu.RLock()
+	defer g.aliasMu.RUnlock()
+	if len(g.entityAliases) == 0 {
+		return entities, renames
+	}
+	resolved := make([]extraction.Entity, len(entities))
+	for i, entity := range entities {
+		canonical := g.canonicalEntityName(entity.Name, entity.Type)
+		if canonical != entity.Name {
+			renames[normalizeEntityName(entity.Name)] = canonical
+			entity.Name = canonical
+		}
+		resolved[i] = entity
+	}
+	return resolved, renames
+}
+
+// entityMetadata returns the metadata for an upserted entity node, carrying over the
+// aliases merged into it so re-extraction does not drop them.
+func (g *Gognee) entityMetadata(nodeID string) map[string]interface{} {
+	metadata := make(map[string]interface{})
+	g.aliasMu.RLock()
+	defer g.aliasMu.RUnlock()
+	if aliases := g.nodeAliases[nodeID]; len(aliases) > 0 {
+		metadata[store.AliasesMetadataKey] = append([]string(nil), aliases...)
+	}
+	return metadata
+}
+
+// buildEntityAliases replays persisted entity merges into the in-memory alias maps
+func (g *Gognee) buildEntityAliases(merges []store.EntityMerge) {
+	g.aliasMu.Lock()
+	defer g.aliasMu.Unlock()
+	g.entityAliases = make(map[string]string, len(merges))
+	g.nodeAliases = make(map[string][]string)
+	for _, merge := range merges {
+		g.recordEntityAlias(merge)
+	}
+}
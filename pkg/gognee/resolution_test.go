@@ -0,0 +1,295 @@
+package gognee
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// verdictLLMClient answers every entity resolution adjudication with a fixed verdict
+type verdictLLMClient struct {
+	MockLLMClient
+	same bool
+}
+
+func (c *verdictLLMClient) CompleteWithSchema(ctx context.Context, prompt string, schema interface{}) error {
+	c.CallCount++
+	data, _ := json.Marshal(map[string]bool{"same": c.same})
+	return json.Unmarshal(data, schema)
+}
+
+// seedEntityGraph adds Technology nodes with the given embeddings (nil for none) and
+// mention counts, returning their node IDs by name.
+func seedEntityGraph(t *testing.T, g *Gognee, names []string, embeddings map[string][]float32, mentions map[string]int) map[string]string {
+	t.Helper()
+	ctx := context.Background()
+	sqlStore := g.graphStore.(*store.SQLiteGraphStore)
+
+	ids := make(map[string]string, len(names))
+	for _, name := range names {
+		id := g.nodeID(name, "Technology")
+		ids[name] = id
+		node := &store.Node{ID: id, Name: name, Type: "Technology", Embedding: embeddings[name]}
+		if err := g.graphStore.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+		if node.Embedding != nil {
+			if err := g.vectorStore.Add(ctx, id, node.Embedding); err != nil {
+				t.Fatalf("vector Add failed: %v", err)
+			}
+		}
+		for i := 0; i < mentions[name]; i++ {
+			if err := sqlStore.IncrementMentionCounts(ctx, []string{id}); err != nil {
+				t.Fatalf("IncrementMentionCounts failed: %v", err)
+			}
+		}
+	}
+	return ids
+}
+
+func TestResolveEntities_ExactAndEmbedding(t *testing.T) {
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+	ctx := context.Background()
+
+	ids := seedEntityGraph(t, g,
+		[]string{"PostgreSQL", "Postgre SQL", "Postgres", "MySQL"},
+		map[string][]float32{
+			"PostgreSQL": {1, 0, 0},
+			"Postgres":   {0.98, 0.2, 0},
+			"MySQL":      {0, 1, 0},
+		},
+		map[string]int{"PostgreSQL": 3},
+	)
+	if err := g.graphStore.AddNode(ctx, &store.Node{ID: "billing", Name: "Billing", Type: "System"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	edges := []*store.Edge{
+		{ID: "e1", SourceID: "billing", Relation: "USES", TargetID: ids["PostgreSQL"]},
+		{ID: "e2", SourceID: "billing", Relation: "USES", TargetID: ids["Postgres"]},             // Duplicate after merge
+		{ID: "e3", SourceID: ids["Postgres"], Relation: "ALIAS_OF", TargetID: ids["PostgreSQL"]}, // Self-loop after merge
+		{ID: "e4", SourceID: ids["Postgre SQL"], Relation: "REPLACES", TargetID: ids["MySQL"]},
+	}
+	for _, e := range edges {
+		if err := g.graphStore.AddEdge(ctx, e); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+
+	result, err := g.ResolveEntities(ctx, ResolveEntitiesOptions{Method: ResolutionEmbedding})
+	if err != nil {
+		t.Fatalf("ResolveEntities failed: %v", err)
+	}
+	if result.NodesEvaluated != 5 {
+		t.Errorf("NodesEvaluated: got %d, want 5", result.NodesEvaluated)
+	}
+
+	methods := map[string]string{}
+	for _, m := range result.Merges {
+		if m.ToNodeID != ids["PostgreSQL"] {
+			t.Errorf("Unexpected merge target %s for %s", m.ToName, m.FromName)
+		}
+		methods[m.FromName] = m.Method
+	}
+	if len(result.Merges) != 2 || methods["Postgre SQL"] != ResolutionExact || methods["Postgres"] != ResolutionEmbedding {
+		t.Fatalf("Unexpected merges: %+v", result.Merges)
+	}
+
+	for _, alias := range []string{"Postgre SQL", "Postgres"} {
+		if node, _ := g.graphStore.GetNode(ctx, ids[alias]); node != nil {
+			t.Errorf("Alias node %s should have been deleted", alias)
+		}
+	}
+	canonical, err := g.graphStore.GetNode(ctx, ids["PostgreSQL"])
+	if err != nil || canonical == nil {
+		t.Fatalf("GetNode(canonical) failed: %v", err)
+	}
+	aliases, _ := canonical.Metadata[store.AliasesMetadataKey].([]interface{})
+	if len(aliases) != 2 {
+		t.Errorf("Expected 2 aliases in metadata, got %v", canonical.Metadata)
+	}
+
+	// e2 duplicates e1 and e3 became a self-loop; e4 now starts at the canonical node
+	remaining, err := g.graphStore.GetEdges(ctx, ids["PostgreSQL"])
+	if err != nil {
+		t.Fatalf("GetEdges failed: %v", err)
+	}
+	got := map[string]string{}
+	for _, e := range remaining {
+		got[e.ID] = e.SourceID
+	}
+	if len(got) != 2 || got["e4"] != ids["PostgreSQL"] {
+		t.Errorf("Unexpected edges after merge: %+v", got)
+	}
+
+	audit, err := g.EntityMerges(ctx)
+	if err != nil {
+		t.Fatalf("EntityMerges failed: %v", err)
+	}
+	if len(audit) != 2 {
+		t.Errorf("Expected 2 audit records, got %d", len(audit))
+	}
+
+	if got := g.canonicalEntityName("postgres", "Technology"); got != "PostgreSQL" {
+		t.Errorf("canonicalEntityName(postgres) = %q, want PostgreSQL", got)
+	}
+	if got := g.canonicalEntityName("Postgres", "Person"); got != "Postgres" {
+		t.Errorf("aliases must not apply across types, got %q", got)
+	}
+}
+
+func TestResolveEntities_NodeIDsLimitComparisons(t *testing.T) {
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+	ctx := context.Background()
+
+	ids := seedEntityGraph(t, g, []string{"Node.js", "NodeJS", "PostgreSQL", "Postgre SQL"}, nil,
+		map[string]int{"PostgreSQL": 2, "Node.js": 1})
+
+	// Only pairs involving the scoped node are compared, whichever side is canonical
+	result, err := g.ResolveEntities(ctx, ResolveEntitiesOptions{NodeIDs: []string{ids["Postgre SQL"]}})
+	if err != nil {
+		t.Fatalf("ResolveEntities failed: %v", err)
+	}
+	if len(result.Merges) != 1 || result.Merges[0].FromName != "Postgre SQL" || result.Merges[0].ToName != "PostgreSQL" {
+		t.Fatalf("Expected only the scoped alias merged, got %+v", result.Merges)
+	}
+	if node, _ := g.graphStore.GetNode(ctx, ids["NodeJS"]); node == nil {
+		t.Error("Expected the unscoped alias pair to be left alone")
+	}
+
+	result, err = g.ResolveEntities(ctx, ResolveEntitiesOptions{NodeIDs: []string{ids["Node.js"]}})
+	if err != nil {
+		t.Fatalf("ResolveEntities failed: %v", err)
+	}
+	if len(result.Merges) != 1 || result.Merges[0].FromName != "NodeJS" {
+		t.Errorf("Expected NodeJS merged into the scoped canonical node, got %+v", result.Merges)
+	}
+}
+
+func TestResolveEntities_ExactIgnoresEmbeddings(t *testing.T) {
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	seedEntityGraph(t, g, []string{"PostgreSQL", "Postgres"},
+		map[string][]float32{"PostgreSQL": {1, 0, 0}, "Postgres": {1, 0, 0}}, nil)
+
+	result, err := g.ResolveEntities(context.Background(), ResolveEntitiesOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ResolveEntities failed: %v", err)
+	}
+	if len(result.Merges) != 0 {
+		t.Errorf("Exact resolution should not merge different names, got %+v", result.Merges)
+	}
+}
+
+func TestResolveEntities_LLMAdjudication(t *testing.T) {
+	for _, same := range []bool{true, false} {
+		llm := &verdictLLMClient{same: same}
+		g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llm)
+		if err != nil {
+			t.Fatalf("NewWithClients failed: %v", err)
+		}
+
+		seedEntityGraph(t, g, []string{"PostgreSQL", "postgres db", "MySQL"},
+			map[string][]float32{
+				"PostgreSQL":  {1, 0, 0},
+				"postgres db": {0.8, 0.6, 0},
+				"MySQL":       {0, 1, 0},
+			},
+			map[string]int{"PostgreSQL": 1},
+		)
+
+		result, err := g.ResolveEntities(context.Background(), ResolveEntitiesOptions{Method: ResolutionLLM, DryRun: true})
+		if err != nil {
+			t.Fatalf("ResolveEntities failed: %v", err)
+		}
+		// Only "postgres db" is similar enough to PostgreSQL to be adjudicated
+		if llm.CallCount != 1 {
+			t.Errorf("Expected 1 adjudication, got %d", llm.CallCount)
+		}
+		if same && (len(result.Merges) != 1 || result.Merges[0].Method != ResolutionLLM) {
+			t.Errorf("Expected LLM-approved merge, got %+v", result.Merges)
+		}
+		if !same && len(result.Merges) != 0 {
+			t.Errorf("Expected no merges when the LLM rejects, got %+v", result.Merges)
+		}
+		g.Close()
+	}
+}
+
+func TestResolveEntities_AliasesApplyToLaterExtraction(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "entities.db")
+	ctx := context.Background()
+
+	g, err := NewWithClients(Config{DBPath: dbPath}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	ids := seedEntityGraph(t, g, []string{"Node.js", "NodeJS"}, nil, map[string]int{"Node.js": 2})
+	if _, err := g.ResolveEntities(ctx, ResolveEntitiesOptions{}); err != nil {
+		t.Fatalf("ResolveEntities failed: %v", err)
+	}
+	g.Close()
+
+	// Reopen: the audited merge still maps the alias to the canonical entity
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{
+			{Name: "NodeJS", Type: "Technology", Description: "JavaScript runtime"},
+			{Name: "API Gateway", Type: "System", Description: "Routes requests"},
+		}},
+		RelationResponses: [][]extraction.Triplet{{
+			{Subject: "API Gateway", Relation: "RUNS_ON", Object: "NodeJS"},
+		}},
+	}
+	g2, err := NewWithClients(Config{DBPath: dbPath}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients (reopen) failed: %v", err)
+	}
+	defer g2.Close()
+
+	result, err := g2.AddMemory(ctx, MemoryInput{Topic: "Gateway", Context: "The API gateway runs on NodeJS."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if result.EdgesCreated != 1 {
+		t.Fatalf("Expected 1 edge, got %d (errors: %v)", result.EdgesCreated, result.Errors)
+	}
+
+	if node, _ := g2.graphStore.GetNode(ctx, ids["NodeJS"]); node != nil {
+		t.Error("Alias node must not be recreated by extraction")
+	}
+	canonical, err := g2.graphStore.GetNode(ctx, ids["Node.js"])
+	if err != nil || canonical == nil {
+		t.Fatalf("GetNode(canonical) failed: %v", err)
+	}
+	if canonical.Name != "Node.js" {
+		t.Errorf("Canonical name changed to %q", canonical.Name)
+	}
+	if aliases, _ := canonical.Metadata[store.AliasesMetadataKey].([]interface{}); len(aliases) != 1 {
+		t.Errorf("Aliases must survive re-extraction, got %v", canonical.Metadata)
+	}
+	edges, _ := g2.graphStore.GetEdges(ctx, ids["Node.js"])
+	if len(edges) != 1 {
+		t.Errorf("Expected the extracted edge on the canonical node, got %d", len(edges))
+	}
+}
+
+func TestNewRejectsUnknownEntityResolution(t *testing.T) {
+	if _, err := New(Config{DBPath: ":memory:", EntityResolution: "fuzzy"}); err == nil {
+		t.Error("Expected error for unknown EntityResolution method")
+	}
+}
@@ -0,0 +1,54 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// ResumeCognify recovers the documents an earlier instance added but did not finish
+// processing, e.g. because the process crashed mid-Cognify, and runs Cognify on them
+// together with the current buffer. Chunks already written before the interruption are
+// skipped (CognifyResult.ChunksResumed). Added documents are persisted in the store's
+// ingestion queue (store.IngestionQueue) until Cognify has processed them.
+func (g *Gognee) ResumeCognify(ctx context.Context) (*CognifyResult, error) {
+	queue, ok := g.graphStore.(store.IngestionQueue)
+	if !ok {
+		return nil, fmt.Errorf("resume requires a graph store implementing store.IngestionQueue")
+	}
+
+	pending, err := queue.PendingDocuments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	buffered := make(map[string]bool, len(g.buffer))
+	for _, doc := range g.buffer {
+		buffered[doc.queueID] = true
+	}
+	for _, doc := range pending {
+		if buffered[doc.ID] {
+			continue
+		}
+		g.buffer = append(g.buffer, AddedDocument{
+			Text:    doc.Text,
+			Source:  doc.Source,
+			AddedAt: doc.AddedAt,
+			Hash:    computeDocumentHash(doc.Text),
+			queueID: doc.ID,
+		})
+	}
+
+	return g.Cognify(ctx, CognifyOptions{})
+}
+
+// dequeueDocument drops a document Cognify has finished with from the ingestion queue
+func (g *Gognee) dequeueDocument(ctx context.Context, doc AddedDocument, result *CognifyResult) {
+	queue, ok := g.graphStore.(store.IngestionQueue)
+	if !ok || doc.queueID == "" {
+		return
+	}
+	if err := queue.RemovePendingDocument(ctx, doc.queueID); err != nil {
+		result.Errors = append(result.Errors, err)
+	}
+}
@@ -0,0 +1,83 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// cancelingLLMClient cancels the Cognify context on its nth call, simulating a crash
+type cancelingLLMClient struct {
+	MockLLMClient
+	cancelAt int
+	cancel   context.CancelFunc
+}
+
+func (c *cancelingLLMClient) CompleteWithSchema(ctx context.Context, prompt string, schema any) error {
+	if c.CallCount+1 == c.cancelAt {
+		c.CallCount++
+		c.cancel()
+		return ctx.Err()
+	}
+	return c.MockLLMClient.CompleteWithSchema(ctx, prompt, schema)
+}
+
+func TestResumeCognify(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "resume.db")
+	cfg := Config{DBPath: dbPath, ChunkStrategy: "semantic", ChunkSize: 6}
+	var paragraphs []string
+	for i := 1; i <= 3; i++ {
+		paragraphs = append(paragraphs, fmt.Sprintf("This paragraph is about Topic%d.", i))
+	}
+
+	// The first instance is interrupted on the entity extraction of the second chunk
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupted := &cancelingLLMClient{cancelAt: 3, cancel: cancel}
+	g, err := NewWithClients(cfg, &MockEmbeddingClient{}, interrupted)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	_ = g.Add(ctx, strings.Join(paragraphs, "\n\n"), AddOptions{Source: "notes.md"})
+	_ = g.Add(ctx, "Dave maintains the Topic9 service.", AddOptions{})
+	result, err := g.Cognify(ctx, CognifyOptions{})
+	if err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	if result.DocumentsDeferred != 2 || g.BufferedCount() != 2 {
+		t.Errorf("expected both documents deferred, got %+v", result)
+	}
+	if result.Status != OperationCancelled {
+		t.Errorf("expected status %q, got %q", OperationCancelled, result.Status)
+	}
+	g.Close()
+
+	// A new instance resumes from the persisted queue, skipping the written chunk
+	resumed := &MockLLMClient{}
+	g, err = NewWithClients(cfg, &MockEmbeddingClient{}, resumed)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+	result, err = g.ResumeCognify(context.Background())
+	if err != nil {
+		t.Fatalf("ResumeCognify failed: %v", err)
+	}
+	if result.DocumentsProcessed != 2 || result.ChunksResumed != 1 || result.ChunksProcessed != 3 {
+		t.Errorf("expected 2 documents with 3 remaining chunks, got %+v", result)
+	}
+	if resumed.CallCount != 6 {
+		t.Errorf("expected 2 LLM calls for each of 3 chunks, got %d", resumed.CallCount)
+	}
+
+	pending, err := g.GetGraphStore().(store.IngestionQueue).PendingDocuments(context.Background())
+	if err != nil || len(pending) != 0 {
+		t.Errorf("expected an empty queue, got %v, %v", pending, err)
+	}
+	if result, _ := g.ResumeCognify(context.Background()); result.DocumentsProcessed != 0 {
+		t.Errorf("expected nothing left to resume, got %+v", result)
+	}
+}
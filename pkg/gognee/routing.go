@@ -0,0 +1,84 @@
+package gognee
+
+import (
+	"fmt"
+
+	"github.com/dan-solli/gognee/pkg/llm"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// Pipeline stages that call the LLM, used as keys of Config.ModelRouting and LLMUsage.
+const (
+	StageEntityExtraction   = "entity_extraction"
+	StageRelationExtraction = "relation_extraction"
+	StageEntityResolution   = "entity_resolution"
+	StageSummarization      = "summarization"
+	StageAnswer             = "answer"
+	StageRerank             = "rerank"
+	StageQueryExpansion     = "query_expansion"
+	StageDescriptionMerge   = "description_merge"
+)
+
+// llmStages lists every stage that calls the LLM
+var llmStages = []string{StageEntityExtraction, StageRelationExtraction, StageEntityResolution, StageSummarization, StageAnswer, StageRerank, StageQueryExpansion, StageDescriptionMerge}
+
+// buildStageClients returns the LLM client for each stage, routed to the model configured
+// in routing (if any) and metered per stage.
+func buildStageClients(base llm.LLMClient, routing map[string]string) (map[string]llm.LLMClient, map[string]*llm.UsageMeter, error) {
+	for stage, model := range routing {
+		if !isLLMStage(stage) {
+			return nil, nil, store.Invalidf("ModelRouting: unknown stage %q (valid stages: %v)", stage, llmStages)
+		}
+		if model == "" {
+			return nil, nil, store.Invalidf("ModelRouting: empty model for stage %q", stage)
+		}
+	}
+
+	var selector llm.ModelSelector
+	if len(routing) > 0 {
+		var ok bool
+		if selector, ok = base.(llm.ModelSelector); !ok {
+			return nil, nil, fmt.Errorf("ModelRouting requires an LLM client implementing llm.ModelSelector, got %T", base)
+		}
+	}
+
+	clients := make(map[string]llm.LLMClient, len(llmStages))
+	meters := make(map[string]*llm.UsageMeter, len(llmStages))
+	for _, stage := range llmStages {
+		client := base
+		if model, ok := routing[stage]; ok {
+			client = selector.WithModel(model)
+		}
+		meters[stage] = &llm.UsageMeter{}
+		clients[stage] = llm.NewMeteredClient(client, meters[stage])
+	}
+	return clients, meters, nil
+}
+
+// isLLMStage reports whether stage names a pipeline stage that calls the LLM
+func isLLMStage(stage string) bool {
+	for _, s := range llmStages {
+		if s == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// stageLLM returns the LLM client for a pipeline stage (see Config.ModelRouting)
+func (g *Gognee) stageLLM(stage string) llm.LLMClient {
+	if client, ok := g.stageLLMs[stage]; ok {
+		return client
+	}
+	return g.llm
+}
+
+// LLMUsage reports the LLM calls, failures and tokens spent by each pipeline stage
+// since this instance was created. Token counts are those reported by the provider.
+func (g *Gognee) LLMUsage() map[string]llm.Usage {
+	usage := make(map[string]llm.Usage, len(g.stageMeters))
+	for stage, meter := range g.stageMeters {
+		usage[stage] = meter.Usage()
+	}
+	return usage
+}
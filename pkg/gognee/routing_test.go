@@ -0,0 +1,142 @@
+package gognee
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/llm"
+)
+
+// routingLLMClient records which model served each kind of request
+type routingLLMClient struct {
+	*MockLLMClient
+	model  string
+	served map[string]string // Schema kind -> model
+}
+
+func (c *routingLLMClient) CompleteWithSchema(ctx context.Context, prompt string, schema interface{}) error {
+	switch schema.(type) {
+	case *[]extraction.Entity:
+		c.served["entities"] = c.model
+	case *[]extraction.Triplet:
+		c.served["relations"] = c.model
+	}
+	llm.RecordTokens(ctx, 10, 5)
+	return c.MockLLMClient.CompleteWithSchema(ctx, prompt, schema)
+}
+
+func (c *routingLLMClient) WithModel(model string) llm.LLMClient {
+	return &routingLLMClient{MockLLMClient: c.MockLLMClient, model: model, served: c.served}
+}
+
+func TestModelRouting(t *testing.T) {
+	ctx := context.Background()
+
+	base := &routingLLMClient{
+		MockLLMClient: &MockLLMClient{
+			EntityResponses: [][]extraction.Entity{{
+				{Name: "Go", Type: "Technology", Description: "Programming language"},
+				{Name: "Docker", Type: "Technology", Description: "Container runtime"},
+			}},
+			RelationResponses: [][]extraction.Triplet{{
+				{Subject: "Go", Relation: "USED_WITH", Object: "Docker"},
+			}},
+		},
+		model:  "default",
+		served: make(map[string]string),
+	}
+
+	g, err := NewWithClients(Config{
+		DBPath: ":memory:",
+		ModelRouting: map[string]string{
+			StageEntityExtraction:   "cheap",
+			StageRelationExtraction: "strong",
+		},
+	}, &MockEmbeddingClient{}, base)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	if err := g.Add(ctx, "We ship Go services in Docker.", AddOptions{}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+
+	if base.served["entities"] != "cheap" {
+		t.Errorf("Expected entity extraction on cheap model, got %q", base.served["entities"])
+	}
+	if base.served["relations"] != "strong" {
+		t.Errorf("Expected relation extraction on strong model, got %q", base.served["relations"])
+	}
+
+	usage := g.LLMUsage()
+	for _, stage := range []string{StageEntityExtraction, StageRelationExtraction} {
+		if usage[stage].Calls != 1 {
+			t.Errorf("Expected 1 call for %s, got %+v", stage, usage[stage])
+		}
+		if usage[stage].PromptTokens != 10 || usage[stage].CompletionTokens != 5 {
+			t.Errorf("Expected 10/5 tokens for %s, got %+v", stage, usage[stage])
+		}
+	}
+	if usage[StageEntityResolution].Calls != 0 {
+		t.Errorf("Expected no entity resolution calls, got %+v", usage[StageEntityResolution])
+	}
+}
+
+func TestModelRouting_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  llm.LLMClient
+		routing map[string]string
+		wantErr string
+	}{
+		{"unknown stage", &routingLLMClient{MockLLMClient: &MockLLMClient{}}, map[string]string{"translation": "gpt-4o"}, "unknown stage"},
+		{"empty model", &routingLLMClient{MockLLMClient: &MockLLMClient{}}, map[string]string{StageEntityExtraction: ""}, "empty model"},
+		{"client without model selection", &MockLLMClient{}, map[string]string{StageEntityExtraction: "cheap"}, "llm.ModelSelector"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWithClients(Config{DBPath: ":memory:", ModelRouting: tt.routing}, &MockEmbeddingClient{}, tt.client)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLLMCache_ReplaysCognify(t *testing.T) {
+	ctx := context.Background()
+	mockLLM := &MockLLMClient{}
+	g, err := NewWithClients(Config{DBPath: ":memory:", LLMModel: "m", LLMCache: true}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	_ = g.Add(ctx, "Alice uses Go", AddOptions{})
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	calls := mockLLM.CallCount
+	if calls == 0 {
+		t.Fatal("expected LLM calls on the first run")
+	}
+	usage := g.LLMUsage()[StageEntityExtraction]
+
+	_ = g.Add(ctx, "Alice uses Go", AddOptions{})
+	if _, err := g.Cognify(ctx, CognifyOptions{Force: true}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	if mockLLM.CallCount != calls {
+		t.Errorf("expected the rerun to be served from the cache, got %d new calls", mockLLM.CallCount-calls)
+	}
+	if g.LLMUsage()[StageEntityExtraction].Calls != usage.Calls {
+		t.Error("expected cache hits to stay out of LLMUsage")
+	}
+}
@@ -0,0 +1,42 @@
+package gognee
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dan-solli/gognee/pkg/search"
+)
+
+// searchRouter sends keyword searches (search.SearchTypeKeyword) to the keyword searcher,
+// so they neither embed the query nor traverse the graph, and every other search to the
+// hybrid searcher.
+type searchRouter struct {
+	hybrid  search.Searcher
+	keyword search.Searcher
+}
+
+// Search runs the searcher matching opts.Type.
+func (r *searchRouter) Search(ctx context.Context, query string, opts search.SearchOptions) ([]search.SearchResult, error) {
+	if opts.Type == search.SearchTypeKeyword {
+		return r.keyword.Search(ctx, query, opts)
+	}
+	return r.hybrid.Search(ctx, query, opts)
+}
+
+// SetQueryExpander passes the query expander on to the searchers that embed queries.
+func (r *searchRouter) SetQueryExpander(expander search.QueryExpander) {
+	for _, searcher := range []search.Searcher{r.hybrid, r.keyword} {
+		if setter, ok := searcher.(search.QueryExpanderSetter); ok {
+			setter.SetQueryExpander(expander)
+		}
+	}
+}
+
+// SetLogger passes the structured logger on to both searchers.
+func (r *searchRouter) SetLogger(logger *slog.Logger) {
+	for _, searcher := range []search.Searcher{r.hybrid, r.keyword} {
+		if setter, ok := searcher.(search.LoggerSetter); ok {
+			setter.SetLogger(logger)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package gognee
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/search"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestSearch_KeywordTypeSkipsEmbedding(t *testing.T) {
+	embedder := &MockEmbeddingClient{}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, embedder, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	ctx := context.Background()
+	for _, node := range []*store.Node{
+		{ID: "reset", Name: "ERR_CONN_RESET", Type: "Error", Description: "Connection reset by the peer"},
+		{ID: "go", Name: "Go", Type: "Language", Description: "A programming language"},
+	} {
+		if err := g.graphStore.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+
+	resp, err := g.Search(ctx, "connection reset", search.SearchOptions{Type: SearchTypeKeyword, TopK: 5})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if embedder.CallCount != 0 {
+		t.Errorf("Expected a keyword search not to embed the query, got %d embedding calls", embedder.CallCount)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].NodeID != "reset" || resp.Results[0].Source != "keyword" {
+		t.Errorf("Expected only the keyword match, got %+v", resp.Results)
+	}
+}
@@ -0,0 +1,137 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/store"
+	"github.com/google/uuid"
+)
+
+// SessionOptions configures OpenSession.
+type SessionOptions struct {
+	// TTL purges the session's memories once it has been open this long, when
+	// PurgeExpiredSessions or RunMaintenance next runs. Default: 0 (until CloseSession).
+	TTL time.Duration
+}
+
+// Session is a scratch workspace for a single agent run. Memories added through it
+// default to the "session" retention policy and are purged, with the nodes and edges
+// only they reference, when the session is closed or expires.
+type Session struct {
+	g         *Gognee
+	id        string
+	expiresAt *time.Time
+}
+
+// ID returns the session ID.
+func (s *Session) ID() string {
+	return s.id
+}
+
+// ExpiresAt returns when the session expires, or nil if it lasts until closed.
+func (s *Session) ExpiresAt() *time.Time {
+	return s.expiresAt
+}
+
+// AddMemory adds a memory to the session. RetentionPolicy defaults to "session" and the
+// session ID is recorded in the memory's metadata (store.SessionMetadataKey). Only
+// identical memories of the same session are deduplicated. Fails with
+// store.ErrSessionClosed once the session is closed.
+func (s *Session) AddMemory(ctx context.Context, input MemoryInput) (*MemoryResult, error) {
+	open, err := s.g.memoryStore.GetSession(ctx, s.id)
+	if err != nil {
+		return nil, err
+	}
+	if open == nil {
+		return nil, fmt.Errorf("session %s: %w", s.id, store.ErrSessionClosed)
+	}
+	if input.RetentionPolicy == "" {
+		input.RetentionPolicy = "session"
+	}
+	metadata := make(map[string]interface{}, len(input.Metadata)+1)
+	for key, value := range input.Metadata {
+		metadata[key] = value
+	}
+	metadata[store.SessionMetadataKey] = s.id
+	input.Metadata = metadata
+	return s.g.AddMemory(ctx, input)
+}
+
+// Add adds unstructured text to the session as a memory, titled by its first line, and
+// extracts entities and relations from it right away.
+func (s *Session) Add(ctx context.Context, text string) (*MemoryResult, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, store.Invalidf("text cannot be empty")
+	}
+	return s.AddMemory(ctx, MemoryInput{Topic: noteTopic(text), Context: text, Source: "session:" + s.id})
+}
+
+// Close closes the session and purges its memories; see Gognee.CloseSession.
+func (s *Session) Close(ctx context.Context) (int, error) {
+	return s.g.CloseSession(ctx, s.id)
+}
+
+// OpenSession opens a session workspace, or reopens an open one with a new TTL. An empty
+// id opens a session with a generated ID.
+func (g *Gognee) OpenSession(ctx context.Context, id string, opts SessionOptions) (*Session, error) {
+	if opts.TTL < 0 {
+		return nil, store.Invalidf("session TTL cannot be negative")
+	}
+	if id == "" {
+		id = uuid.New().String()
+	}
+	var expiresAt *time.Time
+	if opts.TTL > 0 {
+		expiry := time.Now().Add(opts.TTL)
+		expiresAt = &expiry
+	}
+	session, err := g.memoryStore.OpenSession(ctx, id, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{g: g, id: session.ID, expiresAt: session.ExpiresAt}, nil
+}
+
+// CloseSession permanently deletes the memories added in a session, garbage-collecting
+// the nodes and edges only they referenced, and closes it. Returns the number of
+// memories purged.
+func (g *Gognee) CloseSession(ctx context.Context, id string) (int, error) {
+	ids, err := g.memoryStore.SessionMemoryIDs(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, memoryID := range ids {
+		if err := g.purgeMemory(ctx, memoryID); err != nil {
+			return purged, fmt.Errorf("failed to purge memory %s: %w", memoryID, err)
+		}
+		purged++
+	}
+	if err := g.memoryStore.CloseSession(ctx, id); err != nil {
+		return purged, err
+	}
+	return purged, nil
+}
+
+// PurgeExpiredSessions closes the sessions whose TTL has passed, purging their
+// memories. Returns the number of sessions closed. RunMaintenance calls it.
+func (g *Gognee) PurgeExpiredSessions(ctx context.Context) (int, error) {
+	ids, err := g.memoryStore.ExpiredSessions(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	for _, id := range ids {
+		if _, err := g.CloseSession(ctx, id); err != nil {
+			return closed, fmt.Errorf("failed to close session %s: %w", id, err)
+		}
+		closed++
+	}
+	return closed, nil
+}
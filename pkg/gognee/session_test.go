@@ -0,0 +1,163 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestSession_ClosePurgesMemories(t *testing.T) {
+	ctx := context.Background()
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{{Name: "Postgres", Type: "Technology", Description: "Relational database"}},
+			{{Name: "Scratch", Type: "Concept", Description: "A scratch note"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	longTerm, err := g.AddMemory(ctx, MemoryInput{Topic: "Database", Context: "We use Postgres."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	session, err := g.OpenSession(ctx, "run-1", SessionOptions{})
+	if err != nil {
+		t.Fatalf("OpenSession failed: %v", err)
+	}
+	if session.ID() != "run-1" || session.ExpiresAt() != nil {
+		t.Errorf("Unexpected session %s expiring at %v", session.ID(), session.ExpiresAt())
+	}
+	scratch, err := session.Add(ctx, "Trying a scratch idea.\nMore detail.")
+	if err != nil {
+		t.Fatalf("Session.Add failed: %v", err)
+	}
+	memory, err := g.GetMemory(ctx, scratch.MemoryID)
+	if err != nil {
+		t.Fatalf("GetMemory failed: %v", err)
+	}
+	if memory.RetentionPolicy != "session" || memory.Metadata[store.SessionMetadataKey] != "run-1" {
+		t.Errorf("Expected a session memory, got policy %q and metadata %v", memory.RetentionPolicy, memory.Metadata)
+	}
+	scratchNodes, _, _ := g.memoryStore.GetProvenanceByMemory(ctx, scratch.MemoryID)
+
+	purged, err := session.Close(ctx)
+	if err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("Expected 1 memory purged, got %d", purged)
+	}
+	if _, err := g.GetMemory(ctx, scratch.MemoryID); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected the session memory purged, got %v", err)
+	}
+	for _, id := range scratchNodes {
+		if node, _ := g.graphStore.GetNode(ctx, id); node != nil {
+			t.Errorf("Expected session node %s garbage-collected", id)
+		}
+	}
+	if _, err := g.GetMemory(ctx, longTerm.MemoryID); err != nil {
+		t.Errorf("Expected the long-term memory kept, got %v", err)
+	}
+}
+
+func TestSession_TTLExpiry(t *testing.T) {
+	ctx := context.Background()
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	expiring, err := g.OpenSession(ctx, "", SessionOptions{TTL: time.Millisecond})
+	if err != nil {
+		t.Fatalf("OpenSession failed: %v", err)
+	}
+	if expiring.ID() == "" || expiring.ExpiresAt() == nil {
+		t.Fatalf("Expected a generated ID and an expiry, got %q and %v", expiring.ID(), expiring.ExpiresAt())
+	}
+	lasting, err := g.OpenSession(ctx, "lasting", SessionOptions{TTL: time.Hour})
+	if err != nil {
+		t.Fatalf("OpenSession failed: %v", err)
+	}
+	if _, err := expiring.AddMemory(ctx, MemoryInput{Topic: "Scratch", Context: "Expires soon."}); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	kept, err := lasting.AddMemory(ctx, MemoryInput{Topic: "Notes", Context: "Still needed.", RetentionPolicy: "ephemeral"})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	result, err := g.RunMaintenance(ctx)
+	if err != nil {
+		t.Fatalf("RunMaintenance failed: %v", err)
+	}
+	if result.SessionsClosed != 1 {
+		t.Errorf("Expected 1 expired session closed, got %d", result.SessionsClosed)
+	}
+	if count, _ := g.memoryStore.CountMemories(ctx); count != 1 {
+		t.Errorf("Expected only the open session's memory left, got %d memories", count)
+	}
+	memory, err := g.GetMemory(ctx, kept.MemoryID)
+	if err != nil || memory.RetentionPolicy != "ephemeral" {
+		t.Errorf("Expected the explicit retention policy kept, got %+v (err %v)", memory, err)
+	}
+
+	if _, err := g.OpenSession(ctx, "bad", SessionOptions{TTL: -time.Second}); !errors.Is(err, store.ErrValidation) {
+		t.Errorf("Expected a validation error for a negative TTL, got %v", err)
+	}
+}
+
+func TestSession_DeduplicatesWithinSession(t *testing.T) {
+	ctx := context.Background()
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	input := MemoryInput{Topic: "Database", Context: "We use Postgres."}
+	longTerm, err := g.AddMemory(ctx, input)
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	session, err := g.OpenSession(ctx, "run-1", SessionOptions{})
+	if err != nil {
+		t.Fatalf("OpenSession failed: %v", err)
+	}
+
+	// The same content in a session is a separate memory, deduplicated within the session
+	first, err := session.AddMemory(ctx, input)
+	if err != nil {
+		t.Fatalf("Session.AddMemory failed: %v", err)
+	}
+	if first.MemoryID == longTerm.MemoryID {
+		t.Fatal("Expected the session memory not to resolve to the long-term memory")
+	}
+	again, err := session.AddMemory(ctx, input)
+	if err != nil || again.MemoryID != first.MemoryID {
+		t.Errorf("Expected the repeated session memory deduplicated to %s, got %+v (err %v)", first.MemoryID, again, err)
+	}
+
+	// Closing the session leaves the long-term memory and rejects further additions
+	if _, err := session.Close(ctx); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := g.GetMemory(ctx, longTerm.MemoryID); err != nil {
+		t.Errorf("Expected the long-term memory kept, got %v", err)
+	}
+	if _, err := session.AddMemory(ctx, MemoryInput{Topic: "Late", Context: "Too late."}); !errors.Is(err, store.ErrSessionClosed) {
+		t.Errorf("Expected ErrSessionClosed after Close, got %v", err)
+	}
+	if _, err := session.Add(ctx, "Too late."); !errors.Is(err, store.ErrSessionClosed) {
+		t.Errorf("Expected ErrSessionClosed from Add after Close, got %v", err)
+	}
+}
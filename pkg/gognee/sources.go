@@ -0,0 +1,35 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// sourceNodeIDs returns the nodes derived from documents or memories with one of the
+// given sources (search.SearchOptions.Sources).
+func (g *Gognee) sourceNodeIDs(ctx context.Context, sources []string) (map[string]bool, error) {
+	index, ok := g.graphStore.(store.SourceIndex)
+	if !ok {
+		return nil, fmt.Errorf("source filters require a graph store implementing store.SourceIndex")
+	}
+	return index.SourceNodeIDs(ctx, sources)
+}
+
+// intersectNodeIDs returns the node IDs in both sets; a nil set matches every node.
+func intersectNodeIDs(a, b map[string]bool) map[string]bool {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	both := make(map[string]bool)
+	for id := range a {
+		if b[id] {
+			both[id] = true
+		}
+	}
+	return both
+}
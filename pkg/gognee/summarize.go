@@ -0,0 +1,296 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+const (
+	// SummaryNodeType is the node type created by Summarize.
+	SummaryNodeType = "Summary"
+
+	// SummarizesRelation links a summary node to each node it summarizes. Search does not
+	// follow it during graph expansion unless SearchOptions.IncludeSummaries is set.
+	SummarizesRelation = store.SummarizesRelation
+
+	// defaultSummaryMaxNodes caps the nodes sent to the LLM by Summarize
+	defaultSummaryMaxNodes = 40
+)
+
+const summarizePrompt = `You are summarizing part of a knowledge graph.
+
+Entities:
+%s
+Relationships:
+%s
+Write a short title and a summary of one or two paragraphs explaining what these entities
+are and how they relate. Use only the information above.
+
+Return a JSON object: {"title": "...", "summary": "..."}`
+
+// SummarizeOptions selects the subgraph to summarize. Set exactly one of SeedNodeIDs,
+// CommunityID or MemoryID.
+type SummarizeOptions struct {
+	// SeedNodeIDs summarizes these nodes and their neighbours within Depth hops.
+	SeedNodeIDs []string
+	// Depth is the neighbourhood around SeedNodeIDs (default: 1).
+	Depth int
+	// CommunityID summarizes a community found by DetectCommunities.
+	CommunityID string
+	// MemoryID summarizes the nodes derived from a memory.
+	MemoryID string
+	// MaxNodes caps the nodes summarized (default: 40). Seeds and closer nodes are kept first.
+	MaxNodes int
+}
+
+// SummaryResult reports the summary created by Summarize.
+type SummaryResult struct {
+	NodeID  string   // The Summary node
+	Title   string   // Node name
+	Summary string   // Node description
+	NodeIDs []string // Nodes summarized, each linked from the summary with a SUMMARIZES edge
+}
+
+// summaryResponse is the LLM's answer to summarizePrompt
+type summaryResponse struct {
+	Title   string `json:"title"`
+	Summary string `json:"summary"`
+}
+
+// Summarize asks the LLM to summarize a subgraph and stores the result as a Summary node,
+// embedded for search, with a SUMMARIZES edge to every summarized node as provenance.
+// Summarizing the same selection again replaces the earlier summary.
+func (g *Gognee) Summarize(ctx context.Context, opts SummarizeOptions) (*SummaryResult, error) {
+	startTime := time.Now()
+	if opts.MaxNodes <= 0 {
+		opts.MaxNodes = defaultSummaryMaxNodes
+	}
+
+	selection, nodeIDs, err := g.summarySelection(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*store.Node, 0, len(nodeIDs))
+	selected := make(map[string]bool, len(nodeIDs))
+	for _, id := range nodeIDs {
+		if len(nodes) == opts.MaxNodes {
+			break
+		}
+		if selected[id] {
+			continue
+		}
+		node, err := g.graphStore.GetNode(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		// Summaries are not summarized again
+		if node == nil || node.Type == SummaryNodeType {
+			continue
+		}
+		nodes = append(nodes, node)
+		selected[id] = true
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("nothing to summarize: %s has no nodes", selection)
+	}
+
+	edges, err := g.edgesWithin(ctx, nodes, selected)
+	if err != nil {
+		return nil, err
+	}
+
+	var response summaryResponse
+	if err := g.stageLLM(StageSummarization).CompleteWithSchema(ctx, summaryPromptFor(nodes, edges), &response); err != nil {
+		return nil, fmt.Errorf("failed to generate summary: %w", err)
+	}
+	response.Title = strings.TrimSpace(response.Title)
+	response.Summary = strings.TrimSpace(response.Summary)
+	if response.Summary == "" {
+		return nil, fmt.Errorf("failed to generate summary: LLM returned an empty summary")
+	}
+	if response.Title == "" {
+		response.Title = "Summary of " + nodes[0].Name
+	}
+
+	summaryID := g.nodeID(selection, SummaryNodeType)
+	if err := g.storeSummary(ctx, summaryID, selection, response, nodes); err != nil {
+		return nil, err
+	}
+
+	if g.metricsCollector != nil {
+		g.metricsCollector.RecordOperation(ctx, "summarize", "success", time.Since(startTime).Milliseconds())
+	}
+
+	result := &SummaryResult{NodeID: summaryID, Title: response.Title, Summary: response.Summary}
+	for _, node := range nodes {
+		result.NodeIDs = append(result.NodeIDs, node.ID)
+	}
+	return result, nil
+}
+
+// summarySelection validates the selector of opts and returns a key identifying the
+// selection with the candidate node IDs, most relevant first
+func (g *Gognee) summarySelection(ctx context.Context, opts SummarizeOptions) (string, []string, error) {
+	selectors := 0
+	for _, set := range []bool{len(opts.SeedNodeIDs) > 0, opts.CommunityID != "", opts.MemoryID != ""} {
+		if set {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		return "", nil, fmt.Errorf("set exactly one of SeedNodeIDs, CommunityID or MemoryID")
+	}
+
+	switch {
+	case opts.CommunityID != "":
+		communities, ok := g.graphStore.(store.CommunityStore)
+		if !ok {
+			return "", nil, fmt.Errorf("summarizing a community requires a graph store implementing store.CommunityStore")
+		}
+		ids, err := communities.CommunityNodeIDs(ctx, opts.CommunityID)
+		if err != nil {
+			return "", nil, err
+		}
+		return "community:" + opts.CommunityID, ids, nil
+
+	case opts.MemoryID != "":
+		ids, _, err := g.memoryStore.GetProvenanceByMemory(ctx, opts.MemoryID)
+		if err != nil {
+			return "", nil, err
+		}
+		return "memory:" + opts.MemoryID, ids, nil
+
+	default:
+		depth := opts.Depth
+		if depth <= 0 {
+			depth = 1
+		}
+		seeds := append([]string(nil), opts.SeedNodeIDs...)
+		sort.Strings(seeds)
+		ids := append([]string(nil), seeds...)
+		for _, seed := range seeds {
+			// SQLiteGraphStore returns closer neighbours first
+			neighbors, err := g.graphStore.GetNeighbors(ctx, seed, depth)
+			if err != nil {
+				return "", nil, err
+			}
+			for _, neighbor := range neighbors {
+				ids = append(ids, neighbor.ID)
+			}
+		}
+		return fmt.Sprintf("seeds:%s|depth:%d", strings.Join(seeds, ","), depth), ids, nil
+	}
+}
+
+// edgesWithin returns the edges between the given nodes
+func (g *Gognee) edgesWithin(ctx context.Context, nodes []*store.Node, selected map[string]bool) ([]*store.Edge, error) {
+	var edges []*store.Edge
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		incident, err := g.graphStore.GetEdges(ctx, node.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range incident {
+			if seen[edge.ID] || !selected[edge.SourceID] || !selected[edge.TargetID] {
+				continue
+			}
+			seen[edge.ID] = true
+			edges = append(edges, edge)
+		}
+	}
+	return edges, nil
+}
+
+// summaryPromptFor renders the summarize prompt for a subgraph
+func summaryPromptFor(nodes []*store.Node, edges []*store.Edge) string {
+	names := make(map[string]string, len(nodes))
+	var entities strings.Builder
+	for _, node := range nodes {
+		names[node.ID] = node.Name
+		fmt.Fprintf(&entities, "- %s (%s): %s\n", node.Name, node.Type, node.Description)
+	}
+	var relations strings.Builder
+	for _, edge := range edges {
+		fmt.Fprintf(&relations, "- %s %s %s\n", names[edge.SourceID], edge.Relation, names[edge.TargetID])
+	}
+	if relations.Len() == 0 {
+		relations.WriteString("(none)\n")
+	}
+	return fmt.Sprintf(summarizePrompt, entities.String(), relations.String())
+}
+
+// storeSummary upserts the summary node and its SUMMARIZES edges, dropping edges to
+// nodes no longer in the selection when the store supports deletes
+func (g *Gognee) storeSummary(ctx context.Context, summaryID, selection string, response summaryResponse, nodes []*store.Node) error {
+	embeddings, err := g.embeddings.Embed(ctx, []string{response.Title + " " + response.Summary})
+	if err != nil {
+		return fmt.Errorf("failed to embed summary: %w", err)
+	}
+	var embedding []float32
+	if len(embeddings) > 0 {
+		embedding = embeddings[0]
+	}
+
+	summary := &store.Node{
+		ID:          summaryID,
+		Name:        response.Title,
+		Type:        SummaryNodeType,
+		Description: response.Summary,
+		Embedding:   embedding,
+		CreatedAt:   time.Now(),
+		Metadata: map[string]interface{}{
+			"selection":        selection,
+			"summarized_nodes": len(nodes),
+		},
+	}
+	if err := g.graphStore.AddNode(ctx, summary); err != nil {
+		return fmt.Errorf("failed to add summary node: %w", err)
+	}
+	if len(embedding) > 0 {
+		if err := g.vectorStore.Add(ctx, summaryID, embedding); err != nil {
+			return fmt.Errorf("failed to index summary node: %w", err)
+		}
+	}
+
+	if deleter, ok := g.graphStore.(store.Deleter); ok {
+		existing, err := g.graphStore.GetEdges(ctx, summaryID)
+		if err != nil {
+			return err
+		}
+		kept := make(map[string]bool, len(nodes))
+		for _, node := range nodes {
+			kept[node.ID] = true
+		}
+		for _, edge := range existing {
+			if edge.SourceID == summaryID && edge.Relation == SummarizesRelation && !kept[edge.TargetID] {
+				if err := deleter.DeleteEdge(ctx, edge.ID); err != nil {
+					return fmt.Errorf("failed to remove stale summary edge: %w", err)
+				}
+				g.dropEdgeVectors(ctx, []string{edge.ID})
+			}
+		}
+	}
+
+	edges := make([]*store.Edge, len(nodes))
+	for i, node := range nodes {
+		edges[i] = &store.Edge{
+			ID:        fmt.Sprintf("%s-%s-%s", summaryID, SummarizesRelation, node.ID),
+			SourceID:  summaryID,
+			Relation:  SummarizesRelation,
+			TargetID:  node.ID,
+			Weight:    1.0,
+			CreatedAt: time.Now(),
+		}
+	}
+	if _, errs := g.addEdges(ctx, edges); len(errs) > 0 {
+		return fmt.Errorf("failed to link summary: %w", errs[0])
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package gognee
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/search"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// summaryLLM answers summarize prompts and records them
+type summaryLLM struct {
+	prompts []string
+}
+
+func (m *summaryLLM) Complete(ctx context.Context, prompt string) (string, error) {
+	return "", nil
+}
+
+func (m *summaryLLM) CompleteWithSchema(ctx context.Context, prompt string, schema interface{}) error {
+	m.prompts = append(m.prompts, prompt)
+	if s, ok := schema.(*summaryResponse); ok {
+		*s = summaryResponse{Title: "Storage stack", Summary: "Go services persist data in SQLite."}
+	}
+	return nil
+}
+
+func setupSummaryGraph(t *testing.T) (*Gognee, *summaryLLM) {
+	t.Helper()
+	llmClient := &summaryLLM{}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, node := range []*store.Node{
+		{ID: "go", Name: "Go", Type: "Technology", Description: "Programming language"},
+		{ID: "sqlite", Name: "SQLite", Type: "Technology", Description: "Embedded database"},
+		{ID: "wal", Name: "WAL", Type: "Concept", Description: "Write-ahead log"},
+	} {
+		if err := g.graphStore.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	for _, edge := range []*store.Edge{
+		{ID: "e1", SourceID: "go", Relation: "USES", TargetID: "sqlite"},
+		{ID: "e2", SourceID: "sqlite", Relation: "SUPPORTS", TargetID: "wal"},
+	} {
+		if err := g.graphStore.AddEdge(ctx, edge); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+	return g, llmClient
+}
+
+func summarizedTargets(t *testing.T, g *Gognee, summaryID string) map[string]bool {
+	t.Helper()
+	edges, err := g.graphStore.GetEdges(context.Background(), summaryID)
+	if err != nil {
+		t.Fatalf("GetEdges failed: %v", err)
+	}
+	targets := make(map[string]bool)
+	for _, edge := range edges {
+		if edge.Relation == SummarizesRelation {
+			targets[edge.TargetID] = true
+		}
+	}
+	return targets
+}
+
+func TestSummarize_SeedNodes(t *testing.T) {
+	ctx := context.Background()
+	g, llmClient := setupSummaryGraph(t)
+	defer g.Close()
+
+	result, err := g.Summarize(ctx, SummarizeOptions{SeedNodeIDs: []string{"sqlite"}})
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+	if result.Title != "Storage stack" || len(result.NodeIDs) != 3 || result.NodeIDs[0] != "sqlite" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+
+	prompt := llmClient.prompts[0]
+	if !strings.Contains(prompt, "- Go USES SQLite") || !strings.Contains(prompt, "- WAL (Concept): Write-ahead log") {
+		t.Errorf("Prompt is missing the subgraph:\n%s", prompt)
+	}
+
+	node, err := g.graphStore.GetNode(ctx, result.NodeID)
+	if err != nil || node == nil {
+		t.Fatalf("Summary node not stored: %v", err)
+	}
+	if node.Type != SummaryNodeType || node.Description != result.Summary {
+		t.Errorf("Unexpected summary node: %+v", node)
+	}
+	if targets := summarizedTargets(t, g, result.NodeID); len(targets) != 3 {
+		t.Errorf("Expected SUMMARIZES edges to 3 nodes, got %v", targets)
+	}
+
+	// Summarizing the same selection again replaces the summary and drops stale links
+	again, err := g.Summarize(ctx, SummarizeOptions{SeedNodeIDs: []string{"sqlite"}, MaxNodes: 2})
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+	if again.NodeID != result.NodeID {
+		t.Errorf("Expected the same summary node, got %s and %s", result.NodeID, again.NodeID)
+	}
+	if targets := summarizedTargets(t, g, again.NodeID); len(targets) != 2 || !targets["sqlite"] {
+		t.Errorf("Expected SUMMARIZES edges to 2 nodes including the seed, got %v", targets)
+	}
+
+	// Summary nodes are not summarized again
+	third, err := g.Summarize(ctx, SummarizeOptions{SeedNodeIDs: []string{"go"}})
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+	for _, id := range third.NodeIDs {
+		if id == result.NodeID {
+			t.Error("Summary node should not be summarized")
+		}
+	}
+}
+
+func TestSearch_SkipsSummaryEdges(t *testing.T) {
+	ctx := context.Background()
+	g, _ := setupSummaryGraph(t)
+	defer g.Close()
+
+	summary, err := g.Summarize(ctx, SummarizeOptions{SeedNodeIDs: []string{"sqlite"}})
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+
+	searcher := search.NewGraphSearcher(g.graphStore)
+	reached := func(opts search.SearchOptions) map[string]bool {
+		t.Helper()
+		results, err := searcher.Search(ctx, "", opts)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		ids := make(map[string]bool)
+		for _, r := range results {
+			ids[r.NodeID] = true
+		}
+		return ids
+	}
+
+	// The summary links Go to every summarized node; expansion does not go through it
+	opts := search.SearchOptions{Type: search.SearchTypeGraph, SeedNodeIDs: []string{"go"}, GraphDepth: 2, TopK: 10}
+	if ids := reached(opts); ids[summary.NodeID] || len(ids) != 3 {
+		t.Errorf("expected go, sqlite and wal without the summary, got %v", ids)
+	}
+	opts.IncludeSummaries = true
+	if ids := reached(opts); !ids[summary.NodeID] {
+		t.Errorf("expected the summary with IncludeSummaries, got %v", ids)
+	}
+}
+
+func TestSummarize_Community(t *testing.T) {
+	ctx := context.Background()
+	g, _ := setupSummaryGraph(t)
+	defer g.Close()
+
+	if _, err := g.DetectCommunities(ctx, CommunityOptions{}); err != nil {
+		t.Fatalf("DetectCommunities failed: %v", err)
+	}
+	communities, err := g.ListCommunities(ctx, 1)
+	if err != nil || len(communities) != 1 {
+		t.Fatalf("Expected one community, got %d (err %v)", len(communities), err)
+	}
+
+	result, err := g.Summarize(ctx, SummarizeOptions{CommunityID: communities[0].ID})
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+	if len(result.NodeIDs) != 3 {
+		t.Errorf("Expected 3 summarized nodes, got %v", result.NodeIDs)
+	}
+}
+
+func TestSummarize_InvalidSelection(t *testing.T) {
+	ctx := context.Background()
+	g, _ := setupSummaryGraph(t)
+	defer g.Close()
+
+	if _, err := g.Summarize(ctx, SummarizeOptions{}); err == nil {
+		t.Error("Expected error without a selector")
+	}
+	if _, err := g.Summarize(ctx, SummarizeOptions{SeedNodeIDs: []string{"go"}, CommunityID: "go"}); err == nil {
+		t.Error("Expected error with two selectors")
+	}
+	if _, err := g.Summarize(ctx, SummarizeOptions{CommunityID: "none"}); err == nil {
+		t.Error("Expected error for an empty community")
+	}
+}
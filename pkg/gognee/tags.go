@@ -0,0 +1,41 @@
+package gognee
+
+import (
+	"context"
+
+	"github.com/dan-solli/gognee/pkg/search"
+)
+
+// tagSearchOverfetch multiplies TopK for tag- and source-filtered searches, so enough
+// results remain after dropping nodes not derived from a tagged memory or a source.
+const tagSearchOverfetch = 5
+
+// AddTags attaches tags to a memory. Tags are trimmed and lower-cased; tags the memory
+// already carries are ignored.
+func (g *Gognee) AddTags(ctx context.Context, memoryID string, tags ...string) error {
+	return g.memoryStore.AddTags(ctx, memoryID, tags...)
+}
+
+// RemoveTags detaches tags from a memory.
+func (g *Gognee) RemoveTags(ctx context.Context, memoryID string, tags ...string) error {
+	return g.memoryStore.RemoveTags(ctx, memoryID, tags...)
+}
+
+// ListTags returns the tags of a memory, sorted alphabetically.
+func (g *Gognee) ListTags(ctx context.Context, memoryID string) ([]string, error) {
+	return g.memoryStore.ListTags(ctx, memoryID)
+}
+
+// onlyAllowed keeps the results whose node is in allowed and trims them to topK
+func onlyAllowed(results []search.SearchResult, allowed map[string]bool, topK int) []search.SearchResult {
+	kept := results[:0]
+	for _, result := range results {
+		if allowed[result.NodeID] {
+			kept = append(kept, result)
+		}
+	}
+	if len(kept) > topK {
+		kept = kept[:topK]
+	}
+	return kept
+}
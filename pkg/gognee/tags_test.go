@@ -0,0 +1,52 @@
+package gognee
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/search"
+)
+
+func TestSearch_TagFilter(t *testing.T) {
+	ctx := context.Background()
+
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{
+			{{Name: "Kubernetes", Type: "Technology", Description: "Container orchestrator"}},
+			{{Name: "Grafana", Type: "Technology", Description: "Dashboards"}},
+		},
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	if _, err := g.AddMemory(ctx, MemoryInput{Topic: "Platform", Context: "We run on Kubernetes.", Tags: []string{"infra"}}); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	res, err := g.AddMemory(ctx, MemoryInput{Topic: "Monitoring", Context: "Dashboards live in Grafana."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	response, err := g.Search(ctx, "Kubernetes", search.SearchOptions{Type: search.SearchTypeVector, TopK: 5, Tags: []string{"Infra"}})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(response.Results) != 1 || response.Results[0].NodeID != g.nodeID("Kubernetes", "Technology") {
+		t.Errorf("Expected only the node from the tagged memory, got %+v", response.Results)
+	}
+
+	if err := g.AddTags(ctx, res.MemoryID, "infra"); err != nil {
+		t.Fatalf("AddTags failed: %v", err)
+	}
+	response, err = g.Search(ctx, "Kubernetes", search.SearchOptions{Type: search.SearchTypeVector, TopK: 5, Tags: []string{"infra"}})
+	if err != nil || len(response.Results) != 2 {
+		t.Errorf("Expected both nodes once both memories are tagged, got %d (err %v)", len(response.Results), err)
+	}
+	if tags, _ := g.ListTags(ctx, res.MemoryID); len(tags) != 1 || tags[0] != "infra" {
+		t.Errorf("Expected tags [infra], got %v", tags)
+	}
+}
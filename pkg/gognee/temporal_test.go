@@ -0,0 +1,96 @@
+package gognee
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+)
+
+func TestCognify_TemporalValidityInSearch(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{
+			{Name: "Alice", Type: "Person", Description: "An engineer"},
+			{Name: "Acme", Type: "Organization", Description: "A company"},
+		}},
+		RelationResponses: [][]extraction.Triplet{{
+			{Subject: "Alice", Relation: "WORKS_AT", Object: "Acme", ValidFrom: "2019", ValidTo: "2022"},
+		}},
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	_ = g.Add(ctx, "Alice worked at Acme from 2019 until 2022.", AddOptions{})
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+
+	edges, err := g.graphStore.GetEdges(ctx, g.nodeID("Alice", "Person"))
+	if err != nil || len(edges) != 1 || edges[0].ValidFrom == nil || edges[0].ValidTo == nil {
+		t.Fatalf("expected the edge's validity period stored, got %+v, %v", edges, err)
+	}
+	if want := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC); !edges[0].ValidTo.Equal(want) {
+		t.Errorf("expected the edge valid through 2022, got ValidTo %v", edges[0].ValidTo)
+	}
+
+	seeds := []string{g.nodeID("Alice", "Person")}
+	for asOf, want := range map[int]int{2020: 1, 2024: 0} {
+		resp, err := g.Search(ctx, "", SearchOptions{
+			Type:        SearchTypeGraph,
+			SeedNodeIDs: seeds,
+			AsOf:        time.Date(asOf, 6, 1, 0, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		reached := 0
+		for _, r := range resp.Results {
+			if r.NodeID == g.nodeID("Acme", "Organization") {
+				reached++
+			}
+		}
+		if reached != want {
+			t.Errorf("as of %d: expected Acme reached %d times, got %+v", asOf, want, resp.Results)
+		}
+	}
+}
+
+func TestCognify_KeepsRelationsOfDistinctPeriods(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{
+			{Name: "Alice", Type: "Person", Description: "An engineer"},
+			{Name: "Acme", Type: "Organization", Description: "A company"},
+		}},
+		RelationResponses: [][]extraction.Triplet{{
+			{Subject: "Alice", Relation: "WORKS_AT", Object: "Acme", ValidFrom: "2015", ValidTo: "2017"},
+			{Subject: "Alice", Relation: "WORKS_AT", Object: "Acme", ValidFrom: "2021"},
+		}},
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	_ = g.Add(ctx, "Alice worked at Acme from 2015 to 2017 and rejoined in 2021.", AddOptions{})
+	if _, err := g.Cognify(ctx, CognifyOptions{}); err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+
+	// Each stint is its own edge, rather than the second overwriting the first
+	edges, err := g.graphStore.GetEdges(ctx, g.nodeID("Alice", "Person"))
+	if err != nil || len(edges) != 2 {
+		t.Fatalf("expected an edge per period, got %+v, %v", edges, err)
+	}
+	for _, edge := range edges {
+		if edge.ValidFrom == nil {
+			t.Errorf("expected both edges to keep their start, got %+v", edge)
+		}
+	}
+}
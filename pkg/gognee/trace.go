@@ -60,6 +60,16 @@ func (t *OperationTrace) addSpan(span Span) {
 	t.TotalDurationMs += span.DurationMs
 }
 
+// merge appends the spans of other, e.g. spans recorded by a concurrent worker
+func (t *OperationTrace) merge(other *OperationTrace) {
+	if t == nil || other == nil {
+		return
+	}
+	for _, span := range other.Spans {
+		t.addSpan(span)
+	}
+}
+
 // spanTimer is a helper for measuring span duration
 type spanTimer struct {
 	name    string
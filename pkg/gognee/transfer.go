@@ -0,0 +1,228 @@
+package gognee
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// ExportedMemory is one line of ExportMemories output: a full memory record with the
+// supersessions it recorded and the IDs of the nodes and edges derived from it.
+type ExportedMemory struct {
+	store.MemoryRecord
+	Supersedes []store.SupersessionRecord `json:"supersedes,omitempty"` // Memories this one supersedes
+	NodeIDs    []string                   `json:"node_ids,omitempty"`   // Provenance: nodes derived from the memory
+	EdgeIDs    []string                   `json:"edge_ids,omitempty"`   // Provenance: edges derived from the memory
+}
+
+// ExportMemoriesOptions configures ExportMemories.
+type ExportMemoriesOptions struct {
+	// IncludeDeleted also exports soft-deleted memories (see Config.SoftDelete).
+	IncludeDeleted bool
+}
+
+// MemoryConflictStrategy selects what ImportMemories does with a memory whose ID is
+// already in the store.
+type MemoryConflictStrategy string
+
+const (
+	// MemoryConflictSkip keeps the existing memory (the default).
+	MemoryConflictSkip MemoryConflictStrategy = "skip"
+
+	// MemoryConflictOverwrite replaces the existing memory with the imported record.
+	MemoryConflictOverwrite MemoryConflictStrategy = "overwrite"
+
+	// MemoryConflictNewVersion stores the imported content as the next version of the
+	// existing memory, keeping the replaced version in its history (see GetMemoryHistory).
+	// Its status, access statistics and pin state are kept. Imports with unchanged
+	// content are skipped.
+	MemoryConflictNewVersion MemoryConflictStrategy = "new-version"
+)
+
+// ImportMemoriesOptions configures ImportMemories.
+type ImportMemoriesOptions struct {
+	// OnConflict handles memories whose ID already exists. Default MemoryConflictSkip.
+	OnConflict MemoryConflictStrategy
+}
+
+// ImportMemoriesResult reports the outcome of ImportMemories.
+type ImportMemoriesResult struct {
+	Imported          int // New memories
+	Overwritten       int // Existing memories replaced (MemoryConflictOverwrite)
+	Versioned         int // Existing memories given a new version (MemoryConflictNewVersion)
+	Skipped           int // Existing memories left unchanged
+	Supersessions     int // Supersessions recorded
+	MissingReferences int // Provenance node and edge IDs not in this graph, left unlinked
+}
+
+// ExportMemories writes every memory of the namespace the context's principal can read
+// to w as JSON lines, oldest first, one ExportedMemory per line. Reading memories for
+// export does not count as access. Together with ImportMemories this migrates memories
+// between environments; the provenance references only resolve if the graph is
+// migrated too (see Backup).
+func (g *Gognee) ExportMemories(ctx context.Context, w io.Writer, opts ExportMemoriesOptions) error {
+	bw := bufio.NewWriter(w)
+	encoder := json.NewEncoder(bw)
+	err := g.memoryStore.IterateMemories(ctx, opts.IncludeDeleted, func(record *store.MemoryRecord) error {
+		line := ExportedMemory{MemoryRecord: *record}
+		var err error
+		if line.Supersedes, err = g.memoryStore.GetSupersessions(ctx, record.ID); err != nil {
+			return err
+		}
+		if line.NodeIDs, line.EdgeIDs, err = g.memoryStore.GetProvenanceByMemory(ctx, record.ID); err != nil {
+			return err
+		}
+		return encoder.Encode(line)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export memories: %w", err)
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to flush export: %w", err)
+	}
+	return nil
+}
+
+// ImportMemories reads memories written by ExportMemories from r into the namespace.
+// New memories are stored as exported, keeping their IDs, versions, timestamps, status,
+// access statistics, pin state and tags. Provenance is linked to the nodes and edges that
+// exist in this graph; nodes and edges an overwritten or versioned memory no longer
+// references are garbage-collected. Supersessions are recorded once every memory is
+// imported, for pairs that both exist.
+func (g *Gognee) ImportMemories(ctx context.Context, r io.Reader, opts ImportMemoriesOptions) (*ImportMemoriesResult, error) {
+	strategy := opts.OnConflict
+	switch strategy {
+	case "":
+		strategy = MemoryConflictSkip
+	case MemoryConflictSkip, MemoryConflictOverwrite, MemoryConflictNewVersion:
+	default:
+		return nil, store.Invalidf("invalid memory conflict strategy %q: must be one of: skip, overwrite, new-version", strategy)
+	}
+
+	result := &ImportMemoriesResult{}
+	var supersessions []store.SupersessionRecord
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var line ExportedMemory
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return result, store.Invalidf("line %d: invalid memory: %w", lineNo, err)
+		}
+		if line.ID == "" {
+			return result, store.Invalidf("line %d: memory has no id", lineNo)
+		}
+		imported, err := g.importMemory(ctx, &line, strategy, result)
+		if err != nil {
+			return result, fmt.Errorf("line %d: failed to import memory %s: %w", lineNo, line.ID, err)
+		}
+		if imported {
+			supersessions = append(supersessions, line.Supersedes...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("failed to read memories: %w", err)
+	}
+
+	for _, supersession := range supersessions {
+		recorded, err := g.memoryStore.ImportSupersession(ctx, supersession)
+		if err != nil {
+			return result, err
+		}
+		if recorded {
+			result.Supersessions++
+		}
+	}
+	return result, nil
+}
+
+// importMemory writes one exported memory according to the conflict strategy and counts
+// the outcome. Returns whether the memory was written.
+func (g *Gognee) importMemory(ctx context.Context, line *ExportedMemory, strategy MemoryConflictStrategy, result *ImportMemoriesResult) (bool, error) {
+	existing, err := g.memoryStore.PeekMemory(ctx, line.ID)
+	if err != nil {
+		return false, err
+	}
+	exists := existing != nil
+	if exists && strategy == MemoryConflictSkip {
+		result.Skipped++
+		return false, nil
+	}
+	if err := g.redactMemoryRecord(ctx, &line.MemoryRecord); err != nil {
+		return false, err
+	}
+
+	var oldNodeIDs, oldEdgeIDs []string
+	if exists {
+		if oldNodeIDs, oldEdgeIDs, err = g.memoryStore.GetProvenanceByMemory(ctx, line.ID); err != nil {
+			return false, err
+		}
+	}
+
+	switch {
+	case !exists:
+		if err := g.memoryStore.PutMemory(ctx, &line.MemoryRecord); err != nil {
+			return false, err
+		}
+		result.Imported++
+	case strategy == MemoryConflictOverwrite:
+		if err := g.memoryStore.PutMemory(ctx, &line.MemoryRecord); err != nil {
+			return false, err
+		}
+		result.Overwritten++
+	default:
+		versioned, err := g.importVersion(ctx, existing, line)
+		if err != nil || !versioned {
+			if err == nil {
+				result.Skipped++
+			}
+			return false, err
+		}
+		result.Versioned++
+	}
+
+	missing, err := g.memoryStore.ReplaceProvenance(ctx, line.ID, line.NodeIDs, line.EdgeIDs)
+	if err != nil {
+		return false, err
+	}
+	result.MissingReferences += missing
+	if len(oldNodeIDs) > 0 || len(oldEdgeIDs) > 0 {
+		if _, _, err := g.garbageCollect(ctx, oldNodeIDs, oldEdgeIDs); err != nil {
+			return false, fmt.Errorf("garbage collection failed: %w", err)
+		}
+	}
+	return true, nil
+}
+
+// importVersion stores the content of an exported memory as the next version of the
+// existing one. Returns false if the content is unchanged.
+func (g *Gognee) importVersion(ctx context.Context, existing *store.MemoryRecord, line *ExportedMemory) (bool, error) {
+	if store.ComputeDocHash(line.Topic, line.Context, line.Decisions, line.Rationale) ==
+		store.ComputeDocHash(existing.Topic, existing.Context, existing.Decisions, existing.Rationale) {
+		return false, nil
+	}
+	update := store.MemoryUpdate{
+		Topic:     &line.Topic,
+		Context:   &line.Context,
+		Decisions: &line.Decisions,
+		Rationale: &line.Rationale,
+	}
+	if line.Metadata != nil {
+		update.Metadata = &line.Metadata
+	}
+	if err := g.memoryStore.UpdateMemory(ctx, line.ID, update); err != nil {
+		return false, err
+	}
+	if len(line.Tags) > 0 {
+		if err := g.memoryStore.AddTags(ctx, line.ID, line.Tags...); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
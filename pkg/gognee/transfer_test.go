@@ -0,0 +1,98 @@
+package gognee
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+)
+
+func TestExportImportMemories_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	llmClient := &MockLLMClient{EntityResponses: [][]extraction.Entity{
+		{{Name: "SQLite", Type: "Technology", Description: "A database"}},
+		{{Name: "Postgres", Type: "Technology", Description: "A database"}},
+	}}
+	source, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, llmClient)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer source.Close()
+
+	first, err := source.AddMemory(ctx, MemoryInput{Topic: "Database", Context: "We use SQLite.", Tags: []string{"db"}})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	second, err := source.AddMemory(ctx, MemoryInput{Topic: "Database v2", Context: "We moved to Postgres.", Supersedes: []string{first.MemoryID}})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if err := source.PinMemory(ctx, second.MemoryID, "current choice"); err != nil {
+		t.Fatalf("PinMemory failed: %v", err)
+	}
+
+	var exported bytes.Buffer
+	if err := source.ExportMemories(ctx, &exported, ExportMemoriesOptions{}); err != nil {
+		t.Fatalf("ExportMemories failed: %v", err)
+	}
+	if lines := strings.Count(exported.String(), "\n"); lines != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", lines, exported.String())
+	}
+
+	target, err := NewWithClients(Config{DBPath: ":memory:"}, &MockEmbeddingClient{}, &MockLLMClient{})
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer target.Close()
+
+	result, err := target.ImportMemories(ctx, bytes.NewReader(exported.Bytes()), ImportMemoriesOptions{})
+	if err != nil {
+		t.Fatalf("ImportMemories failed: %v", err)
+	}
+	if result.Imported != 2 || result.Supersessions != 1 || result.MissingReferences != 2 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	imported, err := target.GetMemory(ctx, first.MemoryID)
+	if err != nil {
+		t.Fatalf("GetMemory failed: %v", err)
+	}
+	if imported.Status != "Superseded" || imported.SupersededBy == nil || *imported.SupersededBy != second.MemoryID || len(imported.Tags) != 1 {
+		t.Errorf("unexpected imported memory %+v", imported)
+	}
+	if head, err := target.GetCurrentMemory(ctx, first.MemoryID); err != nil || head.ID != second.MemoryID || !head.Pinned {
+		t.Errorf("expected the pinned second memory as chain head, got %+v, %v", head, err)
+	}
+
+	// Importing again skips existing memories
+	result, err = target.ImportMemories(ctx, bytes.NewReader(exported.Bytes()), ImportMemoriesOptions{})
+	if err != nil || result.Skipped != 2 || result.Imported != 0 {
+		t.Errorf("expected all memories skipped, got %+v, %v", result, err)
+	}
+
+	// Changed content becomes a new version
+	changed := strings.Replace(exported.String(), "We use SQLite.", "We used SQLite.", 1)
+	result, err = target.ImportMemories(ctx, strings.NewReader(changed), ImportMemoriesOptions{OnConflict: MemoryConflictNewVersion})
+	if err != nil || result.Versioned != 1 || result.Skipped != 1 {
+		t.Fatalf("unexpected new-version result %+v, %v", result, err)
+	}
+	history, err := target.GetMemoryHistory(ctx, first.MemoryID)
+	if err != nil || len(history) != 2 || history[1].Context != "We used SQLite." {
+		t.Errorf("expected the import as a second version, got %+v, %v", history, err)
+	}
+
+	// Overwrite restores the exported record
+	result, err = target.ImportMemories(ctx, bytes.NewReader(exported.Bytes()), ImportMemoriesOptions{OnConflict: MemoryConflictOverwrite})
+	if err != nil || result.Overwritten != 2 {
+		t.Fatalf("unexpected overwrite result %+v, %v", result, err)
+	}
+	if memory, _ := target.GetMemory(ctx, first.MemoryID); memory.Context != "We use SQLite." || memory.Version != imported.Version {
+		t.Errorf("expected the exported record back, got %+v", memory)
+	}
+
+	if _, err := target.ImportMemories(ctx, strings.NewReader(""), ImportMemoriesOptions{OnConflict: "merge"}); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ErrValidation for an unknown strategy, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package gognee
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RestoreMemory takes a soft-deleted memory out of the trash (see Config.SoftDelete),
+// restoring the status it had before deletion.
+func (g *Gognee) RestoreMemory(ctx context.Context, id string) error {
+	return g.memoryStore.RestoreMemory(ctx, id)
+}
+
+// PurgeDeleted permanently deletes memories that have been in the trash for longer than
+// olderThan (0 purges the whole trash), garbage-collecting the nodes and edges only they
+// referenced. Returns the number of memories purged.
+func (g *Gognee) PurgeDeleted(ctx context.Context, olderThan time.Duration) (int, error) {
+	ids, err := g.memoryStore.DeletedMemoriesBefore(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if err := g.purgeMemory(ctx, id); err != nil {
+			return purged, fmt.Errorf("failed to purge memory %s: %w", id, err)
+		}
+		purged++
+	}
+	return purged, nil
+}
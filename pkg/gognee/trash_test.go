@@ -0,0 +1,89 @@
+package gognee
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestSoftDelete_RestoreAndPurge(t *testing.T) {
+	ctx := context.Background()
+
+	mockLLM := &MockLLMClient{
+		EntityResponses: [][]extraction.Entity{{
+			{Name: "SQLite", Type: "Technology", Description: "Embedded database"},
+		}},
+	}
+	g, err := NewWithClients(Config{DBPath: ":memory:", SoftDelete: true}, &MockEmbeddingClient{}, mockLLM)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	res, err := g.AddMemory(ctx, MemoryInput{Topic: "Storage", Context: "We use SQLite."})
+	if err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	nodeID := g.nodeID("SQLite", "Technology")
+
+	if err := g.DeleteMemory(ctx, res.MemoryID); err != nil {
+		t.Fatalf("DeleteMemory failed: %v", err)
+	}
+
+	memory, err := g.GetMemory(ctx, res.MemoryID)
+	if err != nil {
+		t.Fatalf("Soft-deleted memory should still be readable: %v", err)
+	}
+	if memory.Status != store.StatusDeleted {
+		t.Errorf("Expected status %q, got %q", store.StatusDeleted, memory.Status)
+	}
+	if count, _ := g.CountMemories(ctx); count != 0 {
+		t.Errorf("Expected soft-deleted memory to be excluded from count, got %d", count)
+	}
+	deleted := store.StatusDeleted
+	trash, err := g.ListMemories(ctx, store.ListMemoriesOptions{Status: &deleted})
+	if err != nil || len(trash) != 1 {
+		t.Errorf("Expected 1 memory in the trash, got %v (err %v)", trash, err)
+	}
+	if node, _ := g.graphStore.GetNode(ctx, nodeID); node == nil {
+		t.Error("Soft delete must keep the memory's nodes")
+	}
+
+	if err := g.RestoreMemory(ctx, res.MemoryID); err != nil {
+		t.Fatalf("RestoreMemory failed: %v", err)
+	}
+	memory, _ = g.GetMemory(ctx, res.MemoryID)
+	if memory.Status != "complete" {
+		t.Errorf("Expected restored status %q, got %q", "complete", memory.Status)
+	}
+	if err := g.RestoreMemory(ctx, res.MemoryID); err == nil {
+		t.Error("Expected error restoring a memory that is not deleted")
+	}
+
+	// Recently deleted memories survive a purge with a grace period
+	if err := g.DeleteMemory(ctx, res.MemoryID); err != nil {
+		t.Fatalf("DeleteMemory failed: %v", err)
+	}
+	purged, err := g.PurgeDeleted(ctx, time.Hour)
+	if err != nil || purged != 0 {
+		t.Fatalf("Expected nothing purged within the grace period, got %d (err %v)", purged, err)
+	}
+
+	purged, err = g.PurgeDeleted(ctx, 0)
+	if err != nil {
+		t.Fatalf("PurgeDeleted failed: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("Expected 1 memory purged, got %d", purged)
+	}
+	if _, err := g.GetMemory(ctx, res.MemoryID); !errors.Is(err, store.ErrMemoryNotFound) {
+		t.Errorf("Expected purged memory to be gone, got %v", err)
+	}
+	if node, _ := g.graphStore.GetNode(ctx, nodeID); node != nil {
+		t.Error("Expected orphaned node to be garbage-collected on purge")
+	}
+}
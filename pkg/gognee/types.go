@@ -18,9 +18,10 @@ type SearchType = search.SearchType
 
 // SearchType constants re-exported from search package
 const (
-	SearchTypeVector = search.SearchTypeVector
-	SearchTypeGraph  = search.SearchTypeGraph
-	SearchTypeHybrid = search.SearchTypeHybrid
+	SearchTypeVector  = search.SearchTypeVector
+	SearchTypeGraph   = search.SearchTypeGraph
+	SearchTypeHybrid  = search.SearchTypeHybrid
+	SearchTypeKeyword = search.SearchTypeKeyword
 )
 
 // Node is re-exported from store package
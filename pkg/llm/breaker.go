@@ -0,0 +1,37 @@
+package llm
+
+import (
+	"context"
+
+	"github.com/dan-solli/gognee/pkg/breaker"
+)
+
+// breakerClient guards an LLMClient with a circuit breaker
+type breakerClient struct {
+	client  LLMClient
+	breaker *breaker.Breaker
+}
+
+// NewBreakerClient wraps client so calls fail fast with breaker.ErrOpen while b is open.
+// Several clients of one provider may share b.
+func NewBreakerClient(client LLMClient, b *breaker.Breaker) LLMClient {
+	return &breakerClient{client: client, breaker: b}
+}
+
+// Complete forwards to the wrapped client unless the circuit is open
+func (c *breakerClient) Complete(ctx context.Context, prompt string) (string, error) {
+	var response string
+	err := c.breaker.Do(ctx, func() error {
+		var err error
+		response, err = c.client.Complete(ctx, prompt)
+		return err
+	})
+	return response, err
+}
+
+// CompleteWithSchema forwards to the wrapped client unless the circuit is open
+func (c *breakerClient) CompleteWithSchema(ctx context.Context, prompt string, schema any) error {
+	return c.breaker.Do(ctx, func() error {
+		return c.client.CompleteWithSchema(ctx, prompt, schema)
+	})
+}
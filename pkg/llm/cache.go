@@ -0,0 +1,72 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// ResponseCache stores LLM responses by key. store.SQLiteLLMCache persists it in the
+// gognee database.
+type ResponseCache interface {
+	// GetResponse returns the cached response for key, if any
+	GetResponse(ctx context.Context, key string) (string, bool, error)
+
+	// PutResponse stores the response for key
+	PutResponse(ctx context.Context, key, model, response string) error
+}
+
+// cachedClient answers repeated prompts from a ResponseCache instead of the wrapped client
+type cachedClient struct {
+	client LLMClient
+	model  string
+	cache  ResponseCache
+}
+
+// NewCachedClient wraps client so each prompt is sent once per model: responses are
+// stored in cache under a hash of model and prompt and replayed on later calls. Failed
+// calls are not cached. Cache read and write errors fall back to the wrapped client.
+func NewCachedClient(client LLMClient, model string, cache ResponseCache) LLMClient {
+	return &cachedClient{client: client, model: model, cache: cache}
+}
+
+// CacheKey returns the cache key of a prompt: a SHA-256 over the call kind, model and prompt.
+func CacheKey(kind, model, prompt string) string {
+	sum := sha256.Sum256([]byte(kind + "\x00" + model + "\x00" + prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+// Complete returns the cached completion or forwards to the wrapped client
+func (c *cachedClient) Complete(ctx context.Context, prompt string) (string, error) {
+	key := CacheKey("complete", c.model, prompt)
+	if response, ok, err := c.cache.GetResponse(ctx, key); err == nil && ok {
+		return response, nil
+	}
+
+	response, err := c.client.Complete(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	_ = c.cache.PutResponse(ctx, key, c.model, response)
+	return response, nil
+}
+
+// CompleteWithSchema decodes the cached result into schema or forwards to the wrapped
+// client. The parsed result is cached, so replays skip the wrapped client's normalization.
+func (c *cachedClient) CompleteWithSchema(ctx context.Context, prompt string, schema any) error {
+	key := CacheKey("schema", c.model, prompt)
+	if response, ok, err := c.cache.GetResponse(ctx, key); err == nil && ok {
+		if err := json.Unmarshal([]byte(response), schema); err == nil {
+			return nil
+		}
+	}
+
+	if err := c.client.CompleteWithSchema(ctx, prompt, schema); err != nil {
+		return err
+	}
+	if encoded, err := json.Marshal(schema); err == nil {
+		_ = c.cache.PutResponse(ctx, key, c.model, string(encoded))
+	}
+	return nil
+}
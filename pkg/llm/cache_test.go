@@ -0,0 +1,94 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// mapCache is an in-memory ResponseCache
+type mapCache map[string]string
+
+func (m mapCache) GetResponse(ctx context.Context, key string) (string, bool, error) {
+	response, ok := m[key]
+	return response, ok, nil
+}
+
+func (m mapCache) PutResponse(ctx context.Context, key, model, response string) error {
+	m[key] = response
+	return nil
+}
+
+// countingClient answers every prompt with a fixed result and counts calls
+type countingClient struct {
+	calls int
+	fail  bool
+}
+
+func (c *countingClient) Complete(ctx context.Context, prompt string) (string, error) {
+	c.calls++
+	if c.fail {
+		return "", errors.New("provider down")
+	}
+	return "answer to " + prompt, nil
+}
+
+func (c *countingClient) CompleteWithSchema(ctx context.Context, prompt string, schema any) error {
+	c.calls++
+	if c.fail {
+		return errors.New("provider down")
+	}
+	*schema.(*[]string) = []string{"Go", prompt}
+	return nil
+}
+
+func TestCachedClient(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingClient{}
+	cache := mapCache{}
+	client := NewCachedClient(inner, "gpt-4o-mini", cache)
+
+	for i := 0; i < 2; i++ {
+		response, err := client.Complete(ctx, "hello")
+		if err != nil || response != "answer to hello" {
+			t.Fatalf("Complete returned %q, %v", response, err)
+		}
+		var entities []string
+		if err := client.CompleteWithSchema(ctx, "hello", &entities); err != nil {
+			t.Fatalf("CompleteWithSchema failed: %v", err)
+		}
+		if len(entities) != 2 || entities[1] != "hello" {
+			t.Errorf("unexpected schema result %v", entities)
+		}
+	}
+	if inner.calls != 2 {
+		t.Errorf("expected one call per kind, got %d", inner.calls)
+	}
+
+	// Another model does not share entries
+	if _, err := NewCachedClient(inner, "gpt-4o", cache).Complete(ctx, "hello"); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if inner.calls != 3 {
+		t.Errorf("expected a miss for another model, got %d calls", inner.calls)
+	}
+}
+
+func TestCachedClient_FailuresNotCached(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingClient{fail: true}
+	cache := mapCache{}
+	client := NewCachedClient(inner, "m", cache)
+
+	if _, err := client.Complete(ctx, "hello"); err == nil {
+		t.Fatal("expected the provider error")
+	}
+	if len(cache) != 0 {
+		t.Errorf("expected nothing cached after a failure, got %v", cache)
+	}
+
+	inner.fail = false
+	if response, err := client.Complete(ctx, "hello"); err != nil || response != "answer to hello" {
+		t.Errorf("expected a retry to reach the client, got %q, %v", response, err)
+	}
+}
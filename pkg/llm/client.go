@@ -1,7 +1,32 @@
 // Package llm provides interfaces and implementations for LLM completion clients
 package llm
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrMalformedResponse is matched by CompleteWithSchema errors for completions that are
+// not valid JSON for the schema.
+var ErrMalformedResponse = errors.New("malformed LLM response")
+
+// malformedError wraps a CompleteWithSchema parse error so it matches ErrMalformedResponse
+type malformedError struct {
+	err error
+}
+
+func (e *malformedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *malformedError) Unwrap() error {
+	return e.err
+}
+
+// Is makes errors.Is(err, ErrMalformedResponse) match.
+func (e *malformedError) Is(target error) bool {
+	return target == ErrMalformedResponse
+}
 
 // LLMClient defines the interface for interacting with large language models
 type LLMClient interface {
@@ -9,10 +9,15 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/dan-solli/gognee/pkg/provider"
 )
 
 // OllamaClient implements LLMClient using local Ollama API
 type OllamaClient struct {
+	// Timeout bounds each request; zero leaves only the HTTP client's 5 minute limit.
+	// WithTimeout overrides it per context.
+	Timeout time.Duration
 	baseURL string
 	model   string
 	client  *http.Client
@@ -39,12 +44,24 @@ type ollamaGenerateRequest struct {
 }
 
 type ollamaGenerateResponse struct {
-	Response string `json:"response"`
-	Done     bool   `json:"done"`
+	Response        string `json:"response"`
+	Done            bool   `json:"done"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+}
+
+// WithModel returns a client for another model on the same Ollama server.
+func (c *OllamaClient) WithModel(model string) LLMClient {
+	clone := *c
+	clone.model = model
+	return &clone
 }
 
 // Complete sends a prompt to the LLM and returns the raw completion text
 func (c *OllamaClient) Complete(ctx context.Context, prompt string) (string, error) {
+	ctx, cancel := RequestContext(ctx, c.Timeout)
+	defer cancel()
+
 	reqBody := ollamaGenerateRequest{
 		Model:  c.model,
 		Prompt: prompt,
@@ -70,19 +87,23 @@ func (c *OllamaClient) Complete(ctx context.Context, prompt string) (string, err
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("ollama returned %d: %s", resp.StatusCode, string(body))
+		return "", provider.NewStatusError(resp.StatusCode, fmt.Errorf("ollama returned %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result ollamaGenerateResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return "", fmt.Errorf("decode response: %w", err)
 	}
+	RecordTokens(ctx, result.PromptEvalCount, result.EvalCount)
 
 	return result.Response, nil
 }
 
 // CompleteWithSchema sends a prompt and unmarshals the response into the provided schema
 func (c *OllamaClient) CompleteWithSchema(ctx context.Context, prompt string, schema any) error {
+	ctx, cancel := RequestContext(ctx, c.Timeout)
+	defer cancel()
+
 	reqBody := ollamaGenerateRequest{
 		Model:  c.model,
 		Prompt: prompt,
@@ -109,16 +130,17 @@ func (c *OllamaClient) CompleteWithSchema(ctx context.Context, prompt string, sc
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("ollama returned %d: %s", resp.StatusCode, string(body))
+		return provider.NewStatusError(resp.StatusCode, fmt.Errorf("ollama returned %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result ollamaGenerateResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return fmt.Errorf("decode response: %w", err)
 	}
+	RecordTokens(ctx, result.PromptEvalCount, result.EvalCount)
 
 	if err := json.Unmarshal([]byte(result.Response), schema); err != nil {
-		return fmt.Errorf("unmarshal schema: %w (response: %s)", err, result.Response)
+		return &malformedError{err: fmt.Errorf("unmarshal schema: %w (response: %s)", err, result.Response)}
 	}
 
 	return nil
@@ -9,9 +9,12 @@ import (
 	"log"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/dan-solli/gognee/pkg/provider"
 )
 
 const (
@@ -22,11 +25,20 @@ const (
 	backoffFactor        = 2.0
 )
 
+// DefaultAzureAPIVersion is the Azure OpenAI api-version used when none is given
+const DefaultAzureAPIVersion = "2024-10-21"
+
 // OpenAILLM implements LLMClient for OpenAI's Chat Completions API
 type OpenAILLM struct {
 	APIKey  string
 	Model   string
 	BaseURL string
+	// APIVersion selects Azure OpenAI when set: BaseURL is the resource endpoint,
+	// Model the deployment name, and APIKey is sent in the api-key header.
+	APIVersion string
+	// Timeout bounds each HTTP request, retries included one by one; zero leaves only the
+	// HTTP client's 60s limit. WithTimeout overrides it per context.
+	Timeout time.Duration
 	client  *http.Client
 }
 
@@ -54,12 +66,49 @@ type openAIResponse struct {
 	Choices []struct {
 		Message message `json:"message"`
 	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage,omitempty"`
 	Error *struct {
 		Message string `json:"message"`
 		Type    string `json:"type"`
 	} `json:"error,omitempty"`
 }
 
+// NewAzureOpenAILLM creates a client for an Azure OpenAI chat deployment.
+// endpoint is the resource URL, e.g. "https://my-resource.openai.azure.com";
+// apiVersion defaults to DefaultAzureAPIVersion.
+func NewAzureOpenAILLM(endpoint, deployment, apiVersion, apiKey string) *OpenAILLM {
+	if apiVersion == "" {
+		apiVersion = DefaultAzureAPIVersion
+	}
+	return &OpenAILLM{
+		APIKey:     apiKey,
+		Model:      deployment,
+		BaseURL:    strings.TrimRight(endpoint, "/"),
+		APIVersion: apiVersion,
+		client:     &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// endpoint returns the chat completions URL for OpenAI or, with APIVersion, Azure OpenAI
+func (o *OpenAILLM) endpoint() string {
+	if o.APIVersion != "" {
+		return fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s",
+			o.BaseURL, url.PathEscape(o.Model), url.QueryEscape(o.APIVersion))
+	}
+	return o.BaseURL + "/chat/completions"
+}
+
+// WithModel returns a client for another model sharing this client's key, base URL and HTTP client.
+// For Azure OpenAI the model is a deployment name.
+func (o *OpenAILLM) WithModel(model string) LLMClient {
+	clone := *o
+	clone.Model = model
+	return &clone
+}
+
 // Complete sends a prompt to the OpenAI Chat Completions API and returns the response
 func (o *OpenAILLM) Complete(ctx context.Context, prompt string) (string, error) {
 	var lastErr error
@@ -111,7 +160,7 @@ func (o *OpenAILLM) CompleteWithSchema(ctx context.Context, prompt string, schem
 	// Normalize arrays to strings where needed (handles LLM non-compliance)
 	normalized, changed, err := NormalizeJSONArraysToStrings([]byte(cleaned))
 	if err != nil {
-		return fmt.Errorf("failed to normalize LLM response: %w", err)
+		return &malformedError{err: fmt.Errorf("failed to normalize LLM response: %w", err)}
 	}
 
 	if changed {
@@ -119,7 +168,7 @@ func (o *OpenAILLM) CompleteWithSchema(ctx context.Context, prompt string, schem
 	}
 
 	if err := json.Unmarshal(normalized, schema); err != nil {
-		return fmt.Errorf("failed to unmarshal LLM response: %w", err)
+		return &malformedError{err: fmt.Errorf("failed to unmarshal LLM response: %w", err)}
 	}
 
 	return nil
@@ -141,6 +190,9 @@ func stripMarkdownCodeFence(s string) string {
 }
 
 func (o *OpenAILLM) makeRequest(ctx context.Context, prompt string) (string, error) {
+	ctx, cancel := RequestContext(ctx, o.Timeout)
+	defer cancel()
+
 	reqBody := openAIRequest{
 		Model: o.Model,
 		Messages: []message{
@@ -156,13 +208,17 @@ func (o *OpenAILLM) makeRequest(ctx context.Context, prompt string) (string, err
 		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", o.BaseURL+"/chat/completions", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", o.endpoint(), bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+o.APIKey)
+	if o.APIVersion != "" {
+		req.Header.Set("api-key", o.APIKey)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+o.APIKey)
+	}
 
 	resp, err := o.client.Do(req)
 	if err != nil {
@@ -179,9 +235,9 @@ func (o *OpenAILLM) makeRequest(ctx context.Context, prompt string) (string, err
 	if resp.StatusCode != http.StatusOK {
 		// Retry on 429 (rate limit) and 5xx errors
 		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
-			return "", &retryableError{err: fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))}
+			return "", &retryableError{err: provider.NewStatusError(resp.StatusCode, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body)))}
 		}
-		return "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return "", provider.NewStatusError(resp.StatusCode, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var apiResp openAIResponse
@@ -193,6 +249,10 @@ func (o *OpenAILLM) makeRequest(ctx context.Context, prompt string) (string, err
 		return "", fmt.Errorf("OpenAI API error: %s", apiResp.Error.Message)
 	}
 
+	if apiResp.Usage != nil {
+		RecordTokens(ctx, apiResp.Usage.PromptTokens, apiResp.Usage.CompletionTokens)
+	}
+
 	if len(apiResp.Choices) == 0 {
 		return "", fmt.Errorf("no completion choices returned")
 	}
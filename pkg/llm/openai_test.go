@@ -3,11 +3,14 @@ package llm
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/dan-solli/gognee/pkg/provider"
 )
 
 func TestOpenAILLMComplete_Success(t *testing.T) {
@@ -292,6 +295,9 @@ func TestOpenAILLMComplete_MaxRetriesExceeded(t *testing.T) {
 	if !strings.Contains(err.Error(), "failed after") {
 		t.Errorf("Expected 'failed after' error, got: %v", err)
 	}
+	if !errors.Is(err, provider.ErrUnavailable) || errors.Is(err, provider.ErrRateLimited) {
+		t.Errorf("Expected error to match provider.ErrUnavailable only, got: %v", err)
+	}
 
 	// Should be 4 attempts total (initial + 3 retries)
 	if attemptCount != 4 {
@@ -374,6 +380,9 @@ func TestOpenAILLMCompleteWithSchema_InvalidJSON(t *testing.T) {
 	if !strings.Contains(err.Error(), "normalize") && !strings.Contains(err.Error(), "unmarshal") {
 		t.Errorf("Expected normalize or unmarshal error, got: %v", err)
 	}
+	if !errors.Is(err, ErrMalformedResponse) {
+		t.Errorf("Expected error to match ErrMalformedResponse, got: %v", err)
+	}
 }
 
 func TestStripMarkdownCodeFence(t *testing.T) {
@@ -468,3 +477,35 @@ func TestCompleteWithSchema_StripsMarkdownFence(t *testing.T) {
 		t.Errorf("Expected type 'Technology', got %q", entities[0].Type)
 	}
 }
+
+func TestAzureOpenAILLMComplete(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if v := r.URL.Query().Get("api-version"); v != "2024-06-01" {
+			t.Errorf("Expected api-version 2024-06-01, got %s", v)
+		}
+		if r.Header.Get("api-key") != "azure-key" || r.Header.Get("Authorization") != "" {
+			t.Errorf("Expected the key in the api-key header only")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "ok"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAzureOpenAILLM(server.URL, "chat-prod", "2024-06-01", "azure-key")
+	if _, err := client.Complete(context.Background(), "hello"); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	// WithModel switches the deployment
+	if _, err := client.WithModel("chat-large").Complete(context.Background(), "hello"); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	want := []string{"/openai/deployments/chat-prod/chat/completions", "/openai/deployments/chat-large/chat/completions"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected paths %v, got %v", want, paths)
+	}
+}
@@ -0,0 +1,33 @@
+package llm
+
+import (
+	"context"
+	"time"
+)
+
+type timeoutKey struct{}
+
+// WithTimeout returns a context whose LLM requests are each bounded by d, overriding the
+// client's own Timeout, e.g. for one Cognify run. Zero or negative d leaves the client's
+// timeout in place.
+func WithTimeout(ctx context.Context, d time.Duration) context.Context {
+	if d <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, timeoutKey{}, d)
+}
+
+// RequestContext bounds one provider request by the context's WithTimeout value, else by
+// fallback; with neither, the request is bounded by ctx alone. Client implementations call
+// it around each HTTP request, so a slow request fails (and may be retried) instead of
+// stalling the caller.
+func RequestContext(ctx context.Context, fallback time.Duration) (context.Context, context.CancelFunc) {
+	timeout := fallback
+	if d, ok := ctx.Value(timeoutKey{}).(time.Duration); ok {
+		timeout = d
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,55 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestContext(t *testing.T) {
+	ctx := context.Background()
+
+	bounded, cancel := RequestContext(ctx, time.Minute)
+	defer cancel()
+	if deadline, ok := bounded.Deadline(); !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("Expected the fallback deadline, got %v, %v", deadline, ok)
+	}
+
+	bounded, cancel = RequestContext(WithTimeout(ctx, time.Second), time.Minute)
+	defer cancel()
+	if deadline, ok := bounded.Deadline(); !ok || time.Until(deadline) > time.Second {
+		t.Errorf("Expected the context's timeout to override the fallback, got %v, %v", deadline, ok)
+	}
+
+	unbounded, cancel := RequestContext(WithTimeout(ctx, 0), 0)
+	defer cancel()
+	if _, ok := unbounded.Deadline(); ok {
+		t.Error("Expected no deadline without a timeout")
+	}
+}
+
+func TestOllamaClient_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewOllamaClient(server.URL, "mistral")
+	client.Timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	if _, err := client.Complete(context.Background(), "slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the request cut at the timeout, took %v", elapsed)
+	}
+}
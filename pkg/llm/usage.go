@@ -0,0 +1,98 @@
+package llm
+
+import (
+	"context"
+	"sync"
+)
+
+// ModelSelector is implemented by clients that can issue requests against another model
+// of the same provider, used to route pipeline stages to cheaper or stronger models.
+type ModelSelector interface {
+	// WithModel returns a client for the given model sharing this client's settings.
+	WithModel(model string) LLMClient
+}
+
+// Usage reports LLM consumption.
+type Usage struct {
+	Calls            int64 `json:"calls"`
+	Failures         int64 `json:"failures"`
+	PromptTokens     int64 `json:"prompt_tokens"`     // As reported by the provider (0 if not reported)
+	CompletionTokens int64 `json:"completion_tokens"` // As reported by the provider (0 if not reported)
+}
+
+// UsageMeter accumulates Usage. It is safe for concurrent use.
+type UsageMeter struct {
+	mu    sync.Mutex
+	usage Usage
+}
+
+// Usage returns the usage accumulated so far.
+func (m *UsageMeter) Usage() Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.usage
+}
+
+func (m *UsageMeter) add(u Usage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.usage.Calls += u.Calls
+	m.usage.Failures += u.Failures
+	m.usage.PromptTokens += u.PromptTokens
+	m.usage.CompletionTokens += u.CompletionTokens
+}
+
+type usageMeterKey struct{}
+
+// WithUsageMeter returns a context whose LLM calls report token usage to meter.
+func WithUsageMeter(ctx context.Context, meter *UsageMeter) context.Context {
+	return context.WithValue(ctx, usageMeterKey{}, meter)
+}
+
+// RecordTokens reports the tokens used by one completion to the context's UsageMeter, if any.
+// Client implementations call it after each successful provider response.
+func RecordTokens(ctx context.Context, promptTokens, completionTokens int) {
+	if meter, ok := ctx.Value(usageMeterKey{}).(*UsageMeter); ok {
+		meter.add(Usage{PromptTokens: int64(promptTokens), CompletionTokens: int64(completionTokens)})
+	}
+}
+
+// meteredClient counts the calls, failures and tokens of an LLMClient into a UsageMeter
+type meteredClient struct {
+	client LLMClient
+	meter  *UsageMeter
+}
+
+// NewMeteredClient wraps client so every call, failure and reported token count is
+// accumulated in meter.
+func NewMeteredClient(client LLMClient, meter *UsageMeter) LLMClient {
+	return &meteredClient{client: client, meter: meter}
+}
+
+// Complete forwards to the wrapped client and records the call
+func (m *meteredClient) Complete(ctx context.Context, prompt string) (string, error) {
+	result, err := m.client.Complete(WithUsageMeter(ctx, m.meter), prompt)
+	m.record(err)
+	return result, err
+}
+
+// CompleteWithSchema forwards to the wrapped client and records the call
+func (m *meteredClient) CompleteWithSchema(ctx context.Context, prompt string, schema any) error {
+	err := m.client.CompleteWithSchema(WithUsageMeter(ctx, m.meter), prompt, schema)
+	m.record(err)
+	return err
+}
+
+func (m *meteredClient) record(err error) {
+	u := Usage{Calls: 1}
+	if err != nil {
+		u.Failures = 1
+	}
+	m.meter.add(u)
+}
+
+// Compile-time interface checks
+var (
+	_ ModelSelector = (*OpenAILLM)(nil)
+	_ ModelSelector = (*OllamaClient)(nil)
+)
@@ -0,0 +1,76 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMeteredClient_RecordsOpenAIUsage(t *testing.T) {
+	var models []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openAIRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		models = append(models, req.Model)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"ok"}}],"usage":{"prompt_tokens":12,"completion_tokens":3}}`))
+	}))
+	defer server.Close()
+
+	base := NewOpenAILLM("test-key")
+	base.BaseURL = server.URL
+
+	meter := &UsageMeter{}
+	client := NewMeteredClient(base.WithModel("gpt-4o"), meter)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Complete(context.Background(), "prompt"); err != nil {
+			t.Fatalf("Complete failed: %v", err)
+		}
+	}
+
+	usage := meter.Usage()
+	if usage.Calls != 2 || usage.Failures != 0 {
+		t.Errorf("Expected 2 calls and 0 failures, got %+v", usage)
+	}
+	if usage.PromptTokens != 24 || usage.CompletionTokens != 6 {
+		t.Errorf("Expected 24 prompt and 6 completion tokens, got %+v", usage)
+	}
+	for _, model := range models {
+		if model != "gpt-4o" {
+			t.Errorf("Expected requests against gpt-4o, got %q", model)
+		}
+	}
+	if base.Model == "gpt-4o" {
+		t.Error("WithModel should not modify the original client")
+	}
+}
+
+type failingClient struct{}
+
+func (failingClient) Complete(ctx context.Context, prompt string) (string, error) {
+	return "", errors.New("boom")
+}
+
+func (failingClient) CompleteWithSchema(ctx context.Context, prompt string, schema any) error {
+	return errors.New("boom")
+}
+
+func TestMeteredClient_CountsFailures(t *testing.T) {
+	meter := &UsageMeter{}
+	client := NewMeteredClient(failingClient{}, meter)
+
+	var out struct{}
+	if err := client.CompleteWithSchema(context.Background(), "prompt", &out); err == nil {
+		t.Fatal("Expected error from failing client")
+	}
+
+	usage := meter.Usage()
+	if usage.Calls != 1 || usage.Failures != 1 {
+		t.Errorf("Expected 1 call and 1 failure, got %+v", usage)
+	}
+}
@@ -0,0 +1,51 @@
+// Package provider defines the errors shared by the LLM and embedding clients, so
+// callers can tell rate limiting, outages and rejected credentials apart with errors.Is.
+package provider
+
+import (
+	"errors"
+	"net/http"
+)
+
+var (
+	// ErrRateLimited is matched by errors for requests the provider throttled (HTTP 429).
+	ErrRateLimited = errors.New("provider rate limited")
+	// ErrUnavailable is matched by errors for requests the provider failed to serve (HTTP 5xx).
+	ErrUnavailable = errors.New("provider unavailable")
+	// ErrAuthentication is matched by errors for requests the provider rejected for their
+	// credentials (HTTP 401 or 403).
+	ErrAuthentication = errors.New("provider authentication failed")
+)
+
+// StatusError is an unsuccessful HTTP response from a provider. It keeps the message of
+// Err and matches the sentinel error of its status code.
+type StatusError struct {
+	StatusCode int
+	Err        error
+}
+
+// NewStatusError wraps err, which describes the response, with its status code.
+func NewStatusError(statusCode int, err error) *StatusError {
+	return &StatusError{StatusCode: statusCode, Err: err}
+}
+
+func (e *StatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is match ErrRateLimited, ErrUnavailable or ErrAuthentication by status code.
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrUnavailable:
+		return e.StatusCode >= 500
+	case ErrAuthentication:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
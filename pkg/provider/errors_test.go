@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestStatusError_Is(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{429, ErrRateLimited},
+		{503, ErrUnavailable},
+		{401, ErrAuthentication},
+		{403, ErrAuthentication},
+	}
+	sentinels := []error{ErrRateLimited, ErrUnavailable, ErrAuthentication}
+	for _, tt := range tests {
+		err := fmt.Errorf("failed to embed: %w", NewStatusError(tt.status, fmt.Errorf("HTTP %d: busy", tt.status)))
+		for _, sentinel := range sentinels {
+			if errors.Is(err, sentinel) != (sentinel == tt.want) {
+				t.Errorf("status %d: errors.Is(%v) = %v", tt.status, sentinel, !(sentinel == tt.want))
+			}
+		}
+		if err.Error() != fmt.Sprintf("failed to embed: HTTP %d: busy", tt.status) {
+			t.Errorf("expected the wrapped message kept, got %q", err)
+		}
+	}
+
+	if err := NewStatusError(400, errors.New("bad request")); errors.Is(err, ErrRateLimited) || errors.Is(err, ErrUnavailable) || errors.Is(err, ErrAuthentication) {
+		t.Errorf("expected a 400 to match no sentinel")
+	}
+}
@@ -21,6 +21,7 @@ type DecayingSearcher struct {
 	accessFrequencyEnabled bool        // M2: Enable access frequency decay (Plan 021)
 	referenceAccessCount   int         // M2: Reference access count for heat calculation (Plan 021)
 	logger                 *slog.Logger // M8: Optional structured logger (Plan 023)
+	policy                 *store.DecayPolicy // Decay curve and half-life overrides (nil = exponential)
 }
 
 // NewDecayingSearcher creates a new decaying searcher wrapper.
@@ -56,10 +57,24 @@ func NewDecayingSearcher(
 	}
 }
 
-// SetLogger sets the structured logger for this DecayingSearcher (M8: Plan 023).
-// When nil, logging is disabled (zero overhead).
+// SetLogger sets the structured logger for this DecayingSearcher (M8: Plan 023), the
+// searcher it wraps and its memory store. When nil, logging is disabled (zero overhead).
 func (d *DecayingSearcher) SetLogger(logger *slog.Logger) {
-	d.logger = logger
+	d.logger = nil
+	if logger != nil {
+		d.logger = logger.With(slog.String("component", componentDecay))
+	}
+	propagateLogger(d.underlying, logger)
+	if setter, ok := d.memoryStore.(LoggerSetter); ok {
+		setter.SetLogger(logger)
+	}
+}
+
+// SetDecayPolicy sets the decay curve and the per-node-type and per-retention-policy
+// half-life overrides. When nil, scores decay exponentially with halfLifeDays and the
+// built-in retention policy half-lives.
+func (d *DecayingSearcher) SetDecayPolicy(policy *store.DecayPolicy) {
+	d.policy = policy
 }
 
 // Search performs search with decay applied to scores.
@@ -79,19 +94,30 @@ func (d *DecayingSearcher) Search(ctx context.Context, query string, opts Search
 	now := time.Now()
 	decayedResults := make([]SearchResult, 0, len(results))
 
+	// Fetch nodes to get timestamps
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.NodeID
+	}
+	nodes, err := store.GetNodesByIDs(ctx, d.graphStore, ids)
+	if err != nil {
+		// On error, skip decay but keep the results
+		return results, nil
+	}
+
 	for _, result := range results {
-		// Fetch node to get timestamps
-		node, err := d.graphStore.GetNode(ctx, result.NodeID)
-		if err != nil {
-			// On error, skip decay for this node but include it
-			decayedResults = append(decayedResults, result)
-			continue
-		}
+		node := nodes[result.NodeID]
 		if node == nil {
 			// Node was deleted, skip it
 			continue
 		}
 
+		// Pinned nodes never decay
+		if node.Pinned {
+			decayedResults = append(decayedResults, result)
+			continue
+		}
+
 		// Determine age based on decay basis
 		var age time.Duration
 		if d.basis == "access" && node.LastAccessedAt != nil {
@@ -105,7 +131,8 @@ func (d *DecayingSearcher) Search(ctx context.Context, query string, opts Search
 		// Calculate decay multiplier
 		// M7: Check for retention policy override (Plan 021)
 		decayMultiplier := 1.0
-		retentionHalfLife := d.halfLifeDays // Default
+		nodeHalfLife := d.policy.NodeHalfLife(node.Type, d.halfLifeDays)
+		retentionHalfLife := nodeHalfLife // Default
 
 		if d.accessFrequencyEnabled && d.memoryStore != nil {
 			// Fetch memory IDs for this node to check retention policy
@@ -132,22 +159,14 @@ func (d *DecayingSearcher) Search(ctx context.Context, query string, opts Search
 							retentionPolicy = "standard"
 						}
 
-						// Only override if retention policy is explicitly set and non-standard
-						if retentionPolicy != "standard" {
+						// Only override if retention policy is explicitly set and non-standard,
+						// or the decay policy overrides the standard half-life
+						if retentionPolicy != "standard" || d.overridesRetention("standard") {
 							hasExplicitRetentionPolicy = true
-							policyHalfLife := 0
-							switch retentionPolicy {
-							case "permanent":
+							policyHalfLife, known := d.policy.RetentionHalfLife(retentionPolicy)
+							if known && policyHalfLife == 0 {
 								isPermanent = true
-								break
-							case "decision":
-								policyHalfLife = 365
-							case "ephemeral":
-								policyHalfLife = 7
-							case "session":
-								policyHalfLife = 1
 							}
-
 							if policyHalfLife > maxHalfLife {
 								maxHalfLife = policyHalfLife
 							}
@@ -170,7 +189,7 @@ func (d *DecayingSearcher) Search(ctx context.Context, query string, opts Search
 						decayMultiplier = d.calculateDecayWithHalfLife(age, retentionHalfLife)
 					} else {
 						// Use configured default half-life
-						decayMultiplier = d.calculateDecay(age)
+						decayMultiplier = d.calculateDecayWithHalfLife(age, nodeHalfLife)
 					}
 				}
 
@@ -182,12 +201,12 @@ func (d *DecayingSearcher) Search(ctx context.Context, query string, opts Search
 				result.Score = result.Score * decayMultiplier * frequencyFactor
 			} else {
 				// No memory found - use default time decay
-				decayMultiplier = d.calculateDecay(age)
+				decayMultiplier = d.calculateDecayWithHalfLife(age, nodeHalfLife)
 				result.Score = result.Score * decayMultiplier
 			}
 		} else {
 			// Access frequency disabled - apply time decay only
-			decayMultiplier = d.calculateDecay(age)
+			decayMultiplier = d.calculateDecayWithHalfLife(age, nodeHalfLife)
 			result.Score = result.Score * decayMultiplier
 		}
 
@@ -195,8 +214,24 @@ func (d *DecayingSearcher) Search(ctx context.Context, query string, opts Search
 		// For now, keep all results (even very low scores)
 		if result.Score < 0.001 {
 			// Skip nodes with extremely low scores
+			if d.logger != nil {
+				d.logger.LogAttrs(ctx, slog.LevelDebug, "node filtered by decay",
+					slog.String("node_id", result.NodeID),
+					slog.Float64("decay_multiplier", decayMultiplier),
+					slog.Float64("score", result.Score),
+				)
+			}
 			continue
 		}
+		if d.logger != nil {
+			d.logger.LogAttrs(ctx, slog.LevelDebug, "node decay evaluated",
+				slog.String("node_id", result.NodeID),
+				slog.Float64("age_days", age.Hours()/24),
+				slog.Int("half_life_days", retentionHalfLife),
+				slog.Float64("decay_multiplier", decayMultiplier),
+				slog.Float64("score", result.Score),
+			)
+		}
 
 		decayedResults = append(decayedResults, result)
 	}
@@ -204,33 +239,20 @@ func (d *DecayingSearcher) Search(ctx context.Context, query string, opts Search
 	return decayedResults, nil
 }
 
-// calculateDecay computes the exponential decay multiplier.
-// Formula: 0.5^(age_days / half_life_days)
-func (d *DecayingSearcher) calculateDecay(age time.Duration) float64 {
-	if age < 0 {
-		return 1.0
-	}
-	if d.halfLifeDays <= 0 {
-		return 1.0
-	}
-
-	ageDays := age.Hours() / 24.0
-	exponent := ageDays / float64(d.halfLifeDays)
-	return math.Pow(0.5, exponent)
+// calculateDecayWithHalfLife computes decay with a custom half-life (M7: Plan 021),
+// along the curve of the decay policy.
+func (d *DecayingSearcher) calculateDecayWithHalfLife(age time.Duration, halfLifeDays int) float64 {
+	return d.policy.Multiplier(age, halfLifeDays)
 }
 
-// calculateDecayWithHalfLife computes decay with a custom half-life (M7: Plan 021).
-func (d *DecayingSearcher) calculateDecayWithHalfLife(age time.Duration, halfLifeDays int) float64 {
-	if age < 0 {
-		return 1.0
-	}
-	if halfLifeDays <= 0 {
-		return 1.0
+// overridesRetention reports whether the decay policy overrides the half-life of a
+// retention policy.
+func (d *DecayingSearcher) overridesRetention(retentionPolicy string) bool {
+	if d.policy == nil {
+		return false
 	}
-
-	ageDays := age.Hours() / 24.0
-	exponent := ageDays / float64(halfLifeDays)
-	return math.Pow(0.5, exponent)
+	_, ok := d.policy.RetentionHalfLifeDays[retentionPolicy]
+	return ok
 }
 
 // calculateHeatMultiplier computes the access frequency heat multiplier (M2: Plan 021).
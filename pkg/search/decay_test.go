@@ -108,6 +108,26 @@ func (m *MockMemoryStore) GetSupersededMemories(ctx context.Context, memoryID st
 	return nil, nil
 }
 
+func (m *MockMemoryStore) PinMemory(ctx context.Context, id, reason string) error {
+	return nil
+}
+
+func (m *MockMemoryStore) UnpinMemory(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *MockMemoryStore) ListPinned(ctx context.Context) ([]store.PinnedMemory, error) {
+	return nil, nil
+}
+
+func (m *MockMemoryStore) ArchiveMemory(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *MockMemoryStore) UnarchiveMemory(ctx context.Context, id string) error {
+	return nil
+}
+
 func TestDecayingSearcher_DecayDisabled(t *testing.T) {
 	now := time.Now()
 	mockSearcher := &MockSearcher{
@@ -312,3 +332,81 @@ func TestDecayingSearcher_MinimumThreshold(t *testing.T) {
 		t.Errorf("Very old node score: got %.6f, want < 0.01", results[0].Score)
 	}
 }
+
+func TestDecayingSearcher_PinnedNodeDoesNotDecay(t *testing.T) {
+	old := time.Now().Add(-90 * 24 * time.Hour) // 3 half-lives
+
+	mockSearcher := &MockSearcher{
+		Results: []SearchResult{
+			{NodeID: "pinned", Score: 0.8},
+			{NodeID: "unpinned", Score: 0.8},
+		},
+	}
+	mockGraphStore := &MockGraphStore{
+		Nodes: map[string]*store.Node{
+			"pinned":   {ID: "pinned", Name: "Product", CreatedAt: old, Pinned: true},
+			"unpinned": {ID: "unpinned", Name: "Other", CreatedAt: old},
+		},
+	}
+
+	decaySearcher := NewDecayingSearcher(mockSearcher, mockGraphStore, &MockMemoryStore{}, true, 30, "creation", true, 10)
+
+	results, err := decaySearcher.Search(context.Background(), "test query", SearchOptions{TopK: 10})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		switch r.NodeID {
+		case "pinned":
+			if r.Score != 0.8 {
+				t.Errorf("Pinned node score: got %.6f, want 0.8", r.Score)
+			}
+		case "unpinned":
+			if r.Score > 0.11 {
+				t.Errorf("Unpinned node score: got %.6f, want ~0.1", r.Score)
+			}
+		}
+	}
+}
+
+func TestDecayingSearcher_DecayPolicy(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-30 * 24 * time.Hour)
+
+	mockSearcher := &MockSearcher{
+		Results: []SearchResult{
+			{NodeID: "person", Score: 1.0},
+			{NodeID: "event", Score: 1.0},
+		},
+	}
+	mockGraphStore := &MockGraphStore{
+		Nodes: map[string]*store.Node{
+			"person": {ID: "person", Type: "Person", CreatedAt: old},
+			"event":  {ID: "event", Type: "Event", CreatedAt: old},
+		},
+	}
+
+	decaySearcher := NewDecayingSearcher(mockSearcher, mockGraphStore, &MockMemoryStore{}, true, 30, "creation", false, 10)
+	decaySearcher.SetDecayPolicy(&store.DecayPolicy{
+		Curve:                store.DecayStep,
+		NodeTypeHalfLifeDays: map[string]int{"Person": 365, "Event": 10},
+	})
+
+	results, err := decaySearcher.Search(context.Background(), "test query", SearchOptions{TopK: 10})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	// Step curve: no decay within the first half-life, then halved per full half-life
+	if results[0].Score != 1.0 {
+		t.Errorf("Person score: got %.4f, want 1.0", results[0].Score)
+	}
+	if results[1].Score != 0.125 {
+		t.Errorf("Event score: got %.4f, want 0.125", results[1].Score)
+	}
+}
@@ -0,0 +1,168 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dan-solli/gognee/pkg/embeddings"
+	"github.com/dan-solli/gognee/pkg/llm"
+)
+
+// QueryExpansion selects an optional pre-retrieval step that embeds LLM-generated texts
+// alongside the query, improving recall on short queries (SearchOptions.Expansion).
+type QueryExpansion string
+
+const (
+	// ExpansionNone embeds the query alone (the default).
+	ExpansionNone QueryExpansion = "none"
+	// ExpansionParaphrase also embeds paraphrases of the query.
+	ExpansionParaphrase QueryExpansion = "paraphrase"
+	// ExpansionHyDE also embeds hypothetical answers to the query (Hypothetical Document
+	// Embeddings), which sit closer to the stored descriptions than the question does.
+	ExpansionHyDE QueryExpansion = "hyde"
+)
+
+// defaultExpansions is the number of texts an LLMQueryExpander generates
+const defaultExpansions = 3
+
+// QueryExpander generates the texts embedded alongside a query for an expansion mode.
+type QueryExpander interface {
+	Expand(ctx context.Context, query string, mode QueryExpansion) ([]string, error)
+}
+
+// QueryExpanderSetter is implemented by searchers that embed queries and so can apply
+// SearchOptions.Expansion.
+type QueryExpanderSetter interface {
+	SetQueryExpander(expander QueryExpander)
+}
+
+const paraphrasePrompt = `Rewrite this search query %d different ways, using other words and
+spelling out abbreviations, without changing what is asked.
+
+Query: %s
+
+Return a JSON object: {"texts": ["...", "..."]}`
+
+const hydePrompt = `Write %d short passages (one or two sentences each) that could answer this
+query, as they might appear in a knowledge base. Plausible details are fine; they are only
+used to find similar passages.
+
+Query: %s
+
+Return a JSON object: {"texts": ["...", "..."]}`
+
+// LLMQueryExpander generates paraphrases or hypothetical answers with an LLM, in one call.
+type LLMQueryExpander struct {
+	client llm.LLMClient
+	// Count is the number of texts generated per query (default: 3).
+	Count int
+}
+
+// NewLLMQueryExpander creates a query expander backed by an LLM.
+func NewLLMQueryExpander(client llm.LLMClient) *LLMQueryExpander {
+	return &LLMQueryExpander{client: client}
+}
+
+// llmExpansionResponse is the LLM's answer to paraphrasePrompt and hydePrompt
+type llmExpansionResponse struct {
+	Texts []string `json:"texts"`
+}
+
+// Expand returns up to Count non-empty texts for ExpansionParaphrase or ExpansionHyDE,
+// and nothing for ExpansionNone.
+func (e *LLMQueryExpander) Expand(ctx context.Context, query string, mode QueryExpansion) ([]string, error) {
+	count := e.Count
+	if count <= 0 {
+		count = defaultExpansions
+	}
+	var prompt string
+	switch mode {
+	case "", ExpansionNone:
+		return nil, nil
+	case ExpansionParaphrase:
+		prompt = paraphrasePrompt
+	case ExpansionHyDE:
+		prompt = hydePrompt
+	default:
+		return nil, fmt.Errorf("unknown query expansion %q", mode)
+	}
+
+	var response llmExpansionResponse
+	if err := e.client.CompleteWithSchema(ctx, fmt.Sprintf(prompt, count, query), &response); err != nil {
+		return nil, err
+	}
+	texts := make([]string, 0, count)
+	for _, text := range response.Texts {
+		if text = strings.TrimSpace(text); text != "" && len(texts) < count {
+			texts = append(texts, text)
+		}
+	}
+	return texts, nil
+}
+
+// queryEmbedding returns opts.QueryEmbedding when the caller computed it, and otherwise
+// embeds the query with EmbedQuery.
+func queryEmbedding(ctx context.Context, client embeddings.EmbeddingClient, expander QueryExpander, query string, opts SearchOptions) ([]float32, error) {
+	if opts.QueryEmbedding != nil {
+		return opts.QueryEmbedding, nil
+	}
+	return EmbedQuery(ctx, client, expander, query, opts.Expansion)
+}
+
+// EmbedQuery embeds the query for vector search. With an expansion mode and an expander,
+// the query and its expansions are embedded in one call and their normalized embeddings
+// averaged, so the query counts as one of the texts. Callers searching several times for
+// one query pass the result as SearchOptions.QueryEmbedding, so it is computed once.
+func EmbedQuery(ctx context.Context, client embeddings.EmbeddingClient, expander QueryExpander, query string, mode QueryExpansion) ([]float32, error) {
+	if expander == nil || mode == "" || mode == ExpansionNone {
+		return client.EmbedOne(ctx, query)
+	}
+	expansions, err := expander.Expand(ctx, query, mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand query: %w", err)
+	}
+	if len(expansions) == 0 {
+		return client.EmbedOne(ctx, query)
+	}
+
+	vectors, err := client.Embed(ctx, append([]string{query}, expansions...))
+	if err != nil {
+		return nil, err
+	}
+	var fused []float32
+	for _, vector := range vectors {
+		if len(vector) == 0 || (fused != nil && len(vector) != len(fused)) {
+			continue
+		}
+		if fused == nil {
+			fused = make([]float32, len(vector))
+		}
+		norm := vectorNorm(vector)
+		if norm == 0 {
+			continue
+		}
+		for i, v := range vector {
+			fused[i] += float32(float64(v) / norm)
+		}
+	}
+	if fused == nil {
+		return nil, fmt.Errorf("no embedding returned for the query")
+	}
+	if norm := vectorNorm(fused); norm > 0 {
+		for i := range fused {
+			fused[i] = float32(float64(fused[i]) / norm)
+		}
+	}
+	return fused, nil
+}
+
+// vectorNorm returns the Euclidean length of v
+func vectorNorm(v []float32) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,89 @@
+package search
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestLLMQueryExpander(t *testing.T) {
+	client := &scoringLLM{response: `{"texts": ["Postgres is the primary database", " ", "We run PostgreSQL 16", "extra", "more"]}`}
+	expander := NewLLMQueryExpander(client)
+
+	texts, err := expander.Expand(context.Background(), "which db?", ExpansionHyDE)
+	if err != nil {
+		t.Fatalf("Expand failed: %v", err)
+	}
+	if len(texts) != 3 || texts[0] != "Postgres is the primary database" || texts[1] != "We run PostgreSQL 16" {
+		t.Errorf("Expected 3 non-empty texts, got %q", texts)
+	}
+	if !strings.Contains(client.prompt, "which db?") || !strings.Contains(client.prompt, "could answer") {
+		t.Errorf("Expected a HyDE prompt with the query, got %s", client.prompt)
+	}
+
+	if _, err := expander.Expand(context.Background(), "which db?", ExpansionParaphrase); err != nil || !strings.Contains(client.prompt, "Rewrite") {
+		t.Errorf("Expected a paraphrase prompt, got %s (err %v)", client.prompt, err)
+	}
+	if texts, err := expander.Expand(context.Background(), "which db?", ExpansionNone); err != nil || texts != nil {
+		t.Errorf("Expected no expansion, got %q (err %v)", texts, err)
+	}
+}
+
+func TestVectorSearcher_Expansion(t *testing.T) {
+	ctx := context.Background()
+	graphStore := &mockGraphStore{nodes: map[string]*store.Node{"node1": {ID: "node1", Name: "PostgreSQL"}}}
+
+	var searched []float32
+	vectorStore := &mockVectorStore{
+		searchFunc: func(ctx context.Context, query []float32, topK int) ([]store.SearchResult, error) {
+			searched = query
+			return []store.SearchResult{{ID: "node1", Score: 0.9}}, nil
+		},
+	}
+	embClient := &mockEmbeddingClient{
+		embedOneFunc: func(ctx context.Context, text string) ([]float32, error) {
+			if text == "db?" {
+				return []float32{1, 0}, nil
+			}
+			return []float32{0, 2}, nil
+		},
+	}
+	searcher := NewVectorSearcher(embClient, vectorStore, graphStore)
+	searcher.SetQueryExpander(NewLLMQueryExpander(&scoringLLM{response: `{"texts": ["PostgreSQL database"]}`}))
+
+	if _, err := searcher.Search(ctx, "db?", SearchOptions{}); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if searched[0] != 1 || searched[1] != 0 {
+		t.Errorf("Expected the query embedded alone without Expansion, got %v", searched)
+	}
+
+	if _, err := searcher.Search(ctx, "db?", SearchOptions{Expansion: ExpansionParaphrase}); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	// The normalized query and paraphrase embeddings are averaged
+	want := float32(1 / math.Sqrt2)
+	if math.Abs(float64(searched[0]-want)) > 1e-6 || math.Abs(float64(searched[1]-want)) > 1e-6 {
+		t.Errorf("Expected the fused embedding [%v %v], got %v", want, want, searched)
+	}
+
+	// A query embedding computed by the caller is used as is
+	if _, err := searcher.Search(ctx, "db?", SearchOptions{Expansion: ExpansionParaphrase, QueryEmbedding: []float32{0, 1}}); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if searched[0] != 0 || searched[1] != 1 {
+		t.Errorf("Expected the given query embedding, got %v", searched)
+	}
+}
+
+func TestSearchOptions_ValidateExpansion(t *testing.T) {
+	if err := (SearchOptions{Expansion: ExpansionHyDE}).Validate(); err != nil {
+		t.Errorf("Expected hyde to be valid, got %v", err)
+	}
+	if err := (SearchOptions{Expansion: "synonyms"}).Validate(); err == nil {
+		t.Error("Expected an unknown expansion to be rejected")
+	}
+}
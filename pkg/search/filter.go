@@ -0,0 +1,53 @@
+package search
+
+import (
+	"context"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// searchVectors returns the topK vectors nearest to embedding whose nodes match filter.
+// Stores implementing store.FilteredVectorSearcher filter in the query; for others the
+// search is widened until enough matching nodes are found or the index is exhausted.
+func searchVectors(ctx context.Context, vectorStore store.VectorStore, graphStore store.GraphStore, embedding []float32, topK int, filter store.NodeFilter) ([]store.SearchResult, error) {
+	if filter.IsZero() {
+		return vectorStore.Search(ctx, embedding, topK)
+	}
+	if filtered, ok := vectorStore.(store.FilteredVectorSearcher); ok {
+		return filtered.SearchFiltered(ctx, embedding, topK, filter)
+	}
+
+	k := topK
+	for {
+		candidates, err := vectorStore.Search(ctx, embedding, k)
+		if err != nil {
+			return nil, err
+		}
+		nodes, err := store.GetNodesByIDs(ctx, graphStore, hitIDs(candidates))
+		if err != nil {
+			return nil, err
+		}
+		matched := make([]store.SearchResult, 0, topK)
+		for _, candidate := range candidates {
+			if filter.Matches(nodes[candidate.ID]) {
+				matched = append(matched, candidate)
+			}
+			if len(matched) == topK {
+				return matched, nil
+			}
+		}
+		if len(candidates) < k {
+			return matched, nil
+		}
+		k *= 4
+	}
+}
+
+// hitIDs returns the node IDs of store search hits, in order
+func hitIDs(results []store.SearchResult) []string {
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+	}
+	return ids
+}
@@ -0,0 +1,127 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestVectorSearcher_NodeFilterWidensSearch(t *testing.T) {
+	ctx := context.Background()
+
+	// Three Concepts are closer to the query than the only Decision
+	graphStore := &mockGraphStore{
+		nodes: map[string]*store.Node{
+			"c1": {ID: "c1", Name: "C1", Type: "Concept"},
+			"c2": {ID: "c2", Name: "C2", Type: "Concept"},
+			"c3": {ID: "c3", Name: "C3", Type: "Concept"},
+			"d1": {ID: "d1", Name: "D1", Type: "Decision"},
+		},
+	}
+	ranked := []store.SearchResult{
+		{ID: "c1", Score: 0.9}, {ID: "c2", Score: 0.8}, {ID: "c3", Score: 0.7}, {ID: "d1", Score: 0.6},
+	}
+	var requested []int
+	vectorStore := &mockVectorStore{
+		searchFunc: func(ctx context.Context, query []float32, topK int) ([]store.SearchResult, error) {
+			requested = append(requested, topK)
+			return ranked[:min(topK, len(ranked))], nil
+		},
+	}
+
+	searcher := NewVectorSearcher(&mockEmbeddingClient{}, vectorStore, graphStore)
+	results, err := searcher.Search(ctx, "query", SearchOptions{TopK: 1, NodeTypes: []string{"Decision"}})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].NodeID != "d1" {
+		t.Fatalf("expected d1, got %+v", results)
+	}
+	if len(requested) < 2 {
+		t.Errorf("expected the search to be widened, got requests %v", requested)
+	}
+}
+
+func TestGraphSearcher_NodeFilter(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Now()
+	nodes := map[string]*store.Node{
+		"seed":     {ID: "seed", Name: "Seed", Type: "Concept", CreatedAt: now},
+		"person":   {ID: "person", Name: "Person", Type: "Person", CreatedAt: now},
+		"recent":   {ID: "recent", Name: "Recent", Type: "Decision", CreatedAt: now.AddDate(0, 0, -3)},
+		"outdated": {ID: "outdated", Name: "Outdated", Type: "Decision", CreatedAt: now.AddDate(0, -3, 0)},
+	}
+	// The recent decision is only reachable through the person
+	graphStore := &testGraphStore{
+		nodes: nodes,
+		neighbors: map[string][]*store.Node{
+			"seed":   {nodes["person"], nodes["outdated"]},
+			"person": {nodes["recent"]},
+		},
+	}
+
+	searcher := NewGraphSearcher(coreOnlyGraphStore{graphStore})
+	results, err := searcher.Search(ctx, "", SearchOptions{
+		SeedNodeIDs:  []string{"seed"},
+		GraphDepth:   2,
+		NodeTypes:    []string{"Decision"},
+		CreatedAfter: now.AddDate(0, -1, 0),
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].NodeID != "recent" {
+		t.Fatalf("expected only the recent decision, got %+v", results)
+	}
+	if results[0].GraphDepth != 2 {
+		t.Errorf("expected depth 2, got %d", results[0].GraphDepth)
+	}
+}
+
+func TestHybridSearcher_NodeFilter(t *testing.T) {
+	ctx := context.Background()
+
+	nodes := map[string]*store.Node{
+		"concept":  {ID: "concept", Name: "Concept", Type: "Concept"},
+		"decision": {ID: "decision", Name: "Decision", Type: "Decision"},
+		"linked":   {ID: "linked", Name: "Linked", Type: "Decision"},
+	}
+	graphStore := &testGraphStore{
+		nodes:     nodes,
+		neighbors: map[string][]*store.Node{"concept": {nodes["linked"]}},
+	}
+	vectorStore := &mockVectorStore{
+		searchFunc: func(ctx context.Context, query []float32, topK int) ([]store.SearchResult, error) {
+			return []store.SearchResult{{ID: "concept", Score: 0.9}, {ID: "decision", Score: 0.4}}, nil
+		},
+	}
+
+	searcher := NewHybridSearcher(&mockEmbeddingClient{}, vectorStore, coreOnlyGraphStore{graphStore})
+	results, err := searcher.Search(ctx, "query", SearchOptions{TopK: 5, GraphDepth: 1, NodeTypes: []string{"Decision"}})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, r := range results {
+		got[r.NodeID] = true
+	}
+	if len(results) != 2 || !got["decision"] || !got["linked"] {
+		t.Errorf("expected the decision and the decision linked from the concept, got %+v", results)
+	}
+}
+
+func TestSearchOptions_ValidateTimeRange(t *testing.T) {
+	now := time.Now()
+	opts := SearchOptions{CreatedAfter: now, CreatedBefore: now.AddDate(0, -1, 0)}
+	if err := opts.Validate(); err == nil {
+		t.Error("expected error when CreatedAfter is not before CreatedBefore")
+	}
+	opts.CreatedBefore = time.Time{}
+	if err := opts.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -3,7 +3,8 @@ package search
 import (
 	"context"
 	"errors"
-	"sort"
+	"log/slog"
+	"time"
 
 	"github.com/dan-solli/gognee/pkg/store"
 )
@@ -14,6 +15,7 @@ var ErrNoSeeds = errors.New("graph search requires seed node IDs in SearchOption
 // GraphSearcher performs graph traversal search from seed nodes.
 type GraphSearcher struct {
 	graphStore store.GraphStore
+	searchLogger
 }
 
 // NewGraphSearcher creates a new graph searcher.
@@ -23,9 +25,15 @@ func NewGraphSearcher(graphStore store.GraphStore) *GraphSearcher {
 	}
 }
 
+// SetLogger sets the structured logger for this GraphSearcher. When nil, logging is disabled.
+func (g *GraphSearcher) SetLogger(logger *slog.Logger) {
+	g.setLogger(logger, componentGraph)
+}
+
 // Search performs graph traversal from seed nodes.
 // The query parameter is ignored (graph search uses opts.SeedNodeIDs).
 func (g *GraphSearcher) Search(ctx context.Context, query string, opts SearchOptions) ([]SearchResult, error) {
+	start := time.Now()
 	ApplyDefaults(&opts)
 
 	if len(opts.SeedNodeIDs) == 0 {
@@ -34,78 +42,59 @@ func (g *GraphSearcher) Search(ctx context.Context, query string, opts SearchOpt
 
 	// Track nodes and their best scores
 	nodeScores := make(map[string]nodeScore)
-	visited := make(map[string]bool)
+	traversal := traversalOptions(opts)
 
-	// BFS traversal from all seeds
-	type queueItem struct {
-		nodeID string
-		depth  int
+	seeds, err := store.GetNodesByIDs(ctx, g.graphStore, opts.SeedNodeIDs)
+	if err != nil {
+		return nil, err
 	}
-	queue := make([]queueItem, 0)
-
-	// Initialize with seeds
 	for _, seedID := range opts.SeedNodeIDs {
-		seedNode, err := g.graphStore.GetNode(ctx, seedID)
-		if err != nil {
-			return nil, err
-		}
-		if seedNode != nil {
-			updateNodeScore(nodeScores, seedID, seedNode, 0)
-			queue = append(queue, queueItem{seedID, 0})
-			visited[seedID] = true
-		}
-	}
-
-	// BFS traversal
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		// Stop if we've reached max depth
-		if current.depth >= opts.GraphDepth {
+		seedNode := seeds[seedID]
+		if seedNode == nil {
 			continue
 		}
+		updateNodeScore(nodeScores, seedID, seedNode, 0)
 
-		// Get direct neighbors (depth=1 from current node)
-		neighbors, err := g.graphStore.GetNeighbors(ctx, current.nodeID, 1)
+		reached, err := expandNeighbors(ctx, g.graphStore, seedID, opts.GraphDepth, traversal)
 		if err != nil {
 			return nil, err
 		}
-
-		nextDepth := current.depth + 1
-		for _, neighbor := range neighbors {
-			updateNodeScore(nodeScores, neighbor.ID, neighbor, nextDepth)
-
-			// Add to queue if not visited
-			if !visited[neighbor.ID] {
-				visited[neighbor.ID] = true
-				queue = append(queue, queueItem{neighbor.ID, nextDepth})
-			}
+		for _, neighbor := range reached {
+			updateNodeScore(nodeScores, neighbor.Node.ID, neighbor.Node, neighbor.Depth)
 		}
 	}
 
-	// Convert to results and sort
+	// Convert to results and sort, keeping only nodes that match the filters; expansion
+	// above still walks through nodes that do not
+	filter := opts.NodeFilter()
 	results := make([]SearchResult, 0, len(nodeScores))
 	for nodeID, ns := range nodeScores {
+		if !filter.IsZero() && !filter.Matches(ns.node) {
+			continue
+		}
 		results = append(results, SearchResult{
 			NodeID:     nodeID,
 			Node:       ns.node,
 			Score:      ns.score,
 			Source:     "graph",
+			LegScores:  LegScores{Graph: ns.score},
 			GraphDepth: ns.depth,
 		})
 	}
 
 	// Sort by score descending
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
+	SortResults(results)
 
 	// Apply TopK limit
 	if len(results) > opts.TopK {
 		results = results[:opts.TopK]
 	}
 
+	g.logSearch(ctx, query, start, len(results),
+		slog.Int("seeds", len(opts.SeedNodeIDs)),
+		slog.Int("reached", len(nodeScores)),
+		slog.Int("graph_depth", opts.GraphDepth),
+	)
 	return results, nil
 }
 
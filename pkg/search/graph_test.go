@@ -321,8 +321,13 @@ func (t *testGraphStore) AddEdge(ctx context.Context, edge *store.Edge) error {
 	return nil
 }
 
+// GetEdges links nodeID to each of its neighbors, for traversals that filter edges
 func (t *testGraphStore) GetEdges(ctx context.Context, nodeID string) ([]*store.Edge, error) {
-	return nil, nil
+	var edges []*store.Edge
+	for _, n := range t.neighbors[nodeID] {
+		edges = append(edges, &store.Edge{ID: nodeID + "-" + n.ID, SourceID: nodeID, Relation: "RELATED_TO", TargetID: n.ID, Weight: 1})
+	}
+	return edges, nil
 }
 
 func (t *testGraphStore) GetNeighbors(ctx context.Context, nodeID string, depth int) ([]*store.Node, error) {
@@ -366,3 +371,102 @@ func (t *testGraphStore) Close() error {
 }
 
 var errNoSeeds = errors.New("graph search requires seed node IDs")
+
+// coreOnlyGraphStore hides the optional capabilities of a store, forcing the BFS fallback
+type coreOnlyGraphStore struct {
+	store.GraphStore
+}
+
+func TestGraphSearcher_TraversalMatchesBFS(t *testing.T) {
+	ctx := context.Background()
+
+	graphStore, err := store.NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	// Ring of 8 nodes with a chord, so some nodes are reachable along several paths
+	ids := []string{"n0", "n1", "n2", "n3", "n4", "n5", "n6", "n7"}
+	for _, id := range ids {
+		if err := graphStore.AddNode(ctx, &store.Node{ID: id, Name: id, Type: "Test"}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	for i, id := range ids {
+		next := ids[(i+1)%len(ids)]
+		if err := graphStore.AddEdge(ctx, &store.Edge{ID: id + "-" + next, SourceID: id, Relation: "NEXT", TargetID: next}); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+	if err := graphStore.AddEdge(ctx, &store.Edge{ID: "chord", SourceID: "n0", Relation: "CHORD", TargetID: "n4"}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+
+	for _, opts := range []SearchOptions{
+		{SeedNodeIDs: []string{"n0", "n2"}, GraphDepth: 2, TopK: 20},
+		{SeedNodeIDs: []string{"n0"}, GraphDepth: 3, TopK: 20, GraphDirection: store.DirectionOutgoing},
+		{SeedNodeIDs: []string{"n0"}, GraphDepth: 3, TopK: 20, GraphDirection: store.DirectionIncoming, GraphRelations: []string{"NEXT"}},
+		{SeedNodeIDs: []string{"n0"}, GraphDepth: 2, TopK: 20, GraphMaxFanOut: 1},
+	} {
+		fast, err := NewGraphSearcher(graphStore).Search(ctx, "", opts)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		slow, err := NewGraphSearcher(coreOnlyGraphStore{graphStore}).Search(ctx, "", opts)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+
+		if len(fast) != len(slow) {
+			t.Fatalf("%+v: expected %d results, got %d", opts, len(slow), len(fast))
+		}
+		depths := make(map[string]int)
+		for _, r := range slow {
+			depths[r.NodeID] = r.GraphDepth
+		}
+		for _, r := range fast {
+			depth, ok := depths[r.NodeID]
+			if !ok || depth != r.GraphDepth {
+				t.Errorf("%+v: node %s: expected depth %d (found %v), got %d", opts, r.NodeID, depth, ok, r.GraphDepth)
+			}
+		}
+	}
+}
+
+func TestGraphSearcher_IncomingRelation(t *testing.T) {
+	ctx := context.Background()
+
+	graphStore, err := store.NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	for _, id := range []string{"api", "db", "team"} {
+		if err := graphStore.AddNode(ctx, &store.Node{ID: id, Name: id, Type: "Component"}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	for _, edge := range []*store.Edge{
+		{ID: "e1", SourceID: "api", Relation: "DEPENDS_ON", TargetID: "db"},
+		{ID: "e2", SourceID: "db", Relation: "OWNED_BY", TargetID: "team"},
+	} {
+		if err := graphStore.AddEdge(ctx, edge); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+
+	// What depends on db?
+	results, err := NewGraphSearcher(graphStore).Search(ctx, "", SearchOptions{
+		SeedNodeIDs:    []string{"db"},
+		GraphRelations: []string{"DEPENDS_ON"},
+		GraphDirection: store.DirectionIncoming,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 2 || results[0].NodeID != "db" || results[1].NodeID != "api" {
+		t.Errorf("Expected db then api, got %+v", results)
+	}
+}
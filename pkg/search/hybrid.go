@@ -2,8 +2,9 @@ package search
 
 import (
 	"context"
+	"log/slog"
 	"math"
-	"sort"
+	"time"
 
 	"github.com/dan-solli/gognee/pkg/embeddings"
 	"github.com/dan-solli/gognee/pkg/store"
@@ -14,6 +15,8 @@ type HybridSearcher struct {
 	embeddings  embeddings.EmbeddingClient
 	vectorStore store.VectorStore
 	graphStore  store.GraphStore
+	expander    QueryExpander
+	searchLogger
 }
 
 // NewHybridSearcher creates a new hybrid searcher.
@@ -29,14 +32,28 @@ func NewHybridSearcher(
 	}
 }
 
-// Search performs hybrid search combining vector similarity and graph expansion.
-// Score formula: combined_score = vector_score + graph_score
-// where vector_score = 0 if not found by vector, graph_score = 0 if not found by graph.
+// SetQueryExpander sets the expander applied when SearchOptions.Expansion asks for it.
+// Without one, queries are embedded alone.
+func (h *HybridSearcher) SetQueryExpander(expander QueryExpander) {
+	h.expander = expander
+}
+
+// SetLogger sets the structured logger for this HybridSearcher. When nil, logging is disabled.
+func (h *HybridSearcher) SetLogger(logger *slog.Logger) {
+	h.setLogger(logger, componentHybrid)
+}
+
+// Search performs hybrid search combining vector similarity, keyword matching and graph
+// expansion.
+// Score formula: combined_score = vector_score + keyword_score + graph_score
+// where each score is 0 if the node was not found that way. Keyword scores are BM25
+// relative to the best keyword hit, so they fall in (0, 1] like vector scores.
 func (h *HybridSearcher) Search(ctx context.Context, query string, opts SearchOptions) ([]SearchResult, error) {
+	start := time.Now()
 	ApplyDefaults(&opts)
 
 	// Step 1: Embed the query
-	embedding, err := h.embeddings.EmbedOne(ctx, query)
+	embedding, err := queryEmbedding(ctx, h.embeddings, h.expander, query, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -49,57 +66,89 @@ func (h *HybridSearcher) Search(ctx context.Context, query string, opts SearchOp
 		return nil, err
 	}
 
-	// Track combined scores and metadata
-	type nodeInfo struct {
-		node        *store.Node
-		vectorScore float64
-		graphScore  float64
-		graphDepth  int
-		foundBy     map[string]bool // "vector" and/or "graph"
+	// With type or creation time filters, the nearest matching nodes join the expansion
+	// base so direct hits are not crowded out by closer nodes that get filtered below
+	filter := opts.NodeFilter()
+	if !filter.IsZero() {
+		matching, err := searchVectors(ctx, h.vectorStore, h.graphStore, embedding, opts.TopK, filter)
+		if err != nil {
+			return nil, err
+		}
+		vectorResults = mergeVectorResults(vectorResults, matching)
 	}
-	nodes := make(map[string]*nodeInfo)
 
-	// Step 3: Process vector results and expand via graph
-	for _, vr := range vectorResults {
-		node, err := h.graphStore.GetNode(ctx, vr.ID)
+	// Step 3: Keyword search for exact terms embeddings miss, such as identifiers and
+	// error codes, on stores with a full-text index
+	var keywordResults []store.SearchResult
+	if keywords, ok := h.graphStore.(store.KeywordSearcher); ok && !opts.SkipKeyword {
+		keywordResults, err = keywords.SearchKeyword(ctx, query, initialFetch, filter)
 		if err != nil {
 			return nil, err
 		}
-		if node == nil {
-			continue // Skip stale entries
-		}
+		normalizeScores(keywordResults)
+	}
 
-		// Record vector score
-		if _, exists := nodes[vr.ID]; !exists {
-			nodes[vr.ID] = &nodeInfo{
-				node:       node,
-				foundBy:    make(map[string]bool),
-				graphDepth: 0, // Direct vector hit
+	// Track combined scores and metadata
+	type nodeInfo struct {
+		node         *store.Node
+		vectorScore  float64
+		keywordScore float64
+		graphScore   float64
+		graphDepth   int
+		foundBy      map[string]bool // "vector", "keyword" and/or "graph"
+	}
+	nodes := make(map[string]*nodeInfo)
+
+	// Step 4: Process vector and keyword hits and expand from each via graph
+	legs := []struct {
+		source  string
+		results []store.SearchResult
+	}{
+		{"vector", vectorResults},
+		{"keyword", keywordResults},
+	}
+	hits, err := store.GetNodesByIDs(ctx, h.graphStore, append(hitIDs(vectorResults), hitIDs(keywordResults)...))
+	if err != nil {
+		return nil, err
+	}
+	expanded := make(map[string]bool)
+	for _, leg := range legs {
+		for _, hit := range leg.results {
+			info, exists := nodes[hit.ID]
+			if !exists {
+				node := hits[hit.ID]
+				if node == nil {
+					continue // Skip stale entries
+				}
+				info = &nodeInfo{
+					node:       node,
+					foundBy:    make(map[string]bool),
+					graphDepth: 0, // Direct hit
+				}
+				nodes[hit.ID] = info
 			}
-		}
-		nodes[vr.ID].vectorScore = vr.Score
-		nodes[vr.ID].foundBy["vector"] = true
 
-		// Step 4: Graph expansion from this vector result
-		if opts.GraphDepth > 0 {
-			neighbors, err := h.expandFromNode(ctx, vr.ID, opts.GraphDepth)
+			// Record the leg's score
+			if leg.source == "vector" {
+				info.vectorScore = hit.Score
+			} else {
+				info.keywordScore = hit.Score
+			}
+			info.foundBy[leg.source] = true
+
+			if opts.GraphDepth <= 0 || expanded[hit.ID] {
+				continue
+			}
+			expanded[hit.ID] = true
+
+			// Graph expansion from this hit
+			neighbors, err := h.expandFromNode(ctx, hit.ID, opts.GraphDepth, traversalOptions(opts))
 			if err != nil {
 				return nil, err
 			}
 
 			for neighborID, depthInfo := range neighbors {
-				// Skip if it's the same node
-				if neighborID == vr.ID {
-					continue
-				}
-
-				neighborNode, err := h.graphStore.GetNode(ctx, neighborID)
-				if err != nil {
-					return nil, err
-				}
-				if neighborNode == nil {
-					continue
-				}
+				neighborNode := depthInfo.node
 
 				// Calculate graph score: 1 / (1 + depth)
 				graphScore := 1.0 / float64(1+depthInfo.depth)
@@ -112,7 +161,7 @@ func (h *HybridSearcher) Search(ctx context.Context, query string, opts SearchOp
 						foundBy:    map[string]bool{"graph": true},
 					}
 				} else {
-					// Node already exists (maybe from vector or another expansion)
+					// Node already exists (maybe from a direct hit or another expansion)
 					// Update graph score if this path is better
 					if graphScore > existing.graphScore {
 						existing.graphScore = graphScore
@@ -127,17 +176,19 @@ func (h *HybridSearcher) Search(ctx context.Context, query string, opts SearchOp
 	// Step 5: Deduplicate, merge scores, and build results
 	results := make([]SearchResult, 0, len(nodes))
 	for nodeID, info := range nodes {
-		// Combined score = vector_score + graph_score
-		combinedScore := info.vectorScore + info.graphScore
-
-		// Determine source
-		source := ""
-		if info.foundBy["vector"] && info.foundBy["graph"] {
-			source = "hybrid"
-		} else if info.foundBy["vector"] {
-			source = "vector"
-		} else {
-			source = "graph"
+		if !filter.IsZero() && !filter.Matches(info.node) {
+			continue
+		}
+
+		// Combined score = vector_score + keyword_score + graph_score
+		combinedScore := info.vectorScore + info.keywordScore + info.graphScore
+
+		// Determine source: the only leg that found the node, or "hybrid"
+		source := "hybrid"
+		if len(info.foundBy) == 1 {
+			for leg := range info.foundBy {
+				source = leg
+			}
 		}
 
 		results = append(results, SearchResult{
@@ -145,65 +196,74 @@ func (h *HybridSearcher) Search(ctx context.Context, query string, opts SearchOp
 			Node:       info.node,
 			Score:      combinedScore,
 			Source:     source,
+			LegScores:  LegScores{Vector: info.vectorScore, Keyword: info.keywordScore, Graph: info.graphScore},
 			GraphDepth: info.graphDepth,
 		})
 	}
 
 	// Step 6: Sort by combined score descending
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
+	SortResults(results)
 
-	// Step 7: Return top-K results
-	if len(results) > opts.TopK {
+	// Step 7: Return top-K results, diversified if requested
+	if opts.Diversify {
+		results = diversify(results, opts.TopK, opts.DiversifyLambda)
+	} else if len(results) > opts.TopK {
 		results = results[:opts.TopK]
 	}
 
+	h.logSearch(ctx, query, start, len(results),
+		slog.Int("vector_hits", len(vectorResults)),
+		slog.Int("keyword_hits", len(keywordResults)),
+		slog.Int("expanded", len(expanded)),
+		slog.Int("candidates", len(nodes)),
+	)
 	return results, nil
 }
 
-type depthInfo struct {
-	depth int
+// normalizeScores scales scores in place relative to the highest, which becomes 1
+func normalizeScores(results []store.SearchResult) {
+	best := 0.0
+	for _, r := range results {
+		best = max(best, r.Score)
+	}
+	if best <= 0 {
+		return
+	}
+	for i := range results {
+		results[i].Score /= best
+	}
 }
 
-// expandFromNode performs BFS graph traversal from a starting node.
-func (h *HybridSearcher) expandFromNode(ctx context.Context, startNodeID string, maxDepth int) (map[string]depthInfo, error) {
-	result := make(map[string]depthInfo)
-	visited := make(map[string]bool)
-
-	type queueItem struct {
-		nodeID string
-		depth  int
+// mergeVectorResults appends the results of extra not already in results
+func mergeVectorResults(results, extra []store.SearchResult) []store.SearchResult {
+	seen := make(map[string]bool, len(results))
+	for _, r := range results {
+		seen[r.ID] = true
 	}
-	queue := []queueItem{{startNodeID, 0}}
-	visited[startNodeID] = true
-
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		if current.depth >= maxDepth {
-			continue
-		}
-
-		neighbors, err := h.graphStore.GetNeighbors(ctx, current.nodeID, 1)
-		if err != nil {
-			return nil, err
+	for _, r := range extra {
+		if !seen[r.ID] {
+			seen[r.ID] = true
+			results = append(results, r)
 		}
+	}
+	return results
+}
 
-		nextDepth := current.depth + 1
-		for _, neighbor := range neighbors {
-			// Record depth info (keep shortest path)
-			if existing, exists := result[neighbor.ID]; !exists || nextDepth < existing.depth {
-				result[neighbor.ID] = depthInfo{depth: nextDepth}
-			}
+type depthInfo struct {
+	depth int
+	node  *store.Node
+}
 
-			if !visited[neighbor.ID] {
-				visited[neighbor.ID] = true
-				queue = append(queue, queueItem{neighbor.ID, nextDepth})
-			}
-		}
+// expandFromNode returns the nodes within maxDepth hops of a starting node with their
+// shortest depth (see expandNeighbors).
+func (h *HybridSearcher) expandFromNode(ctx context.Context, startNodeID string, maxDepth int, traversal store.TraversalOptions) (map[string]depthInfo, error) {
+	reached, err := expandNeighbors(ctx, h.graphStore, startNodeID, maxDepth, traversal)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]depthInfo, len(reached))
+	for _, neighbor := range reached {
+		result[neighbor.Node.ID] = depthInfo{depth: neighbor.Depth, node: neighbor.Node}
 	}
-
 	return result, nil
 }
@@ -129,6 +129,9 @@ func TestHybridSearcher_NodeFoundByBoth(t *testing.T) {
 	if node2Result.Score != expectedScore {
 		t.Errorf("node2 score should be %f (vector + graph), got %f", expectedScore, node2Result.Score)
 	}
+	if node2Result.LegScores != (LegScores{Vector: 0.6, Graph: 0.5}) {
+		t.Errorf("node2 should keep its leg scores, got %+v", node2Result.LegScores)
+	}
 }
 
 func TestHybridSearcher_VectorOnlyNode(t *testing.T) {
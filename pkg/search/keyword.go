@@ -0,0 +1,68 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// KeywordSearcher performs BM25 keyword search over node names and descriptions.
+type KeywordSearcher struct {
+	graphStore store.GraphStore
+	searchLogger
+}
+
+// NewKeywordSearcher creates a new keyword searcher. The graph store must implement
+// store.KeywordSearcher.
+func NewKeywordSearcher(graphStore store.GraphStore) *KeywordSearcher {
+	return &KeywordSearcher{
+		graphStore: graphStore,
+	}
+}
+
+// SetLogger sets the structured logger for this KeywordSearcher. When nil, logging is disabled.
+func (k *KeywordSearcher) SetLogger(logger *slog.Logger) {
+	k.setLogger(logger, componentKeyword)
+}
+
+// Search returns the nodes containing the query's words, ranked by BM25 relevance.
+func (k *KeywordSearcher) Search(ctx context.Context, query string, opts SearchOptions) ([]SearchResult, error) {
+	start := time.Now()
+	ApplyDefaults(&opts)
+
+	keywords, ok := k.graphStore.(store.KeywordSearcher)
+	if !ok {
+		return nil, fmt.Errorf("keyword search requires a graph store implementing store.KeywordSearcher")
+	}
+	keywordResults, err := keywords.SearchKeyword(ctx, query, opts.TopK, opts.NodeFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	// Enrich with full node data
+	nodes, err := store.GetNodesByIDs(ctx, k.graphStore, hitIDs(keywordResults))
+	if err != nil {
+		return nil, err
+	}
+	results := make([]SearchResult, 0, len(keywordResults))
+	for _, kr := range keywordResults {
+		node := nodes[kr.ID]
+		if node == nil {
+			continue
+		}
+		results = append(results, SearchResult{
+			NodeID:     kr.ID,
+			Node:       node,
+			Score:      kr.Score,
+			Source:     "keyword",
+			LegScores:  LegScores{Keyword: kr.Score},
+			GraphDepth: 0,
+		})
+	}
+	SortResults(results)
+	k.logSearch(ctx, query, start, len(results), slog.Int("keyword_hits", len(keywordResults)))
+	return results, nil
+}
@@ -0,0 +1,96 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// keywordGraphStore adds fixed keyword search results to a testGraphStore
+type keywordGraphStore struct {
+	*testGraphStore
+	keywordResults []store.SearchResult
+}
+
+func (k *keywordGraphStore) SearchKeyword(ctx context.Context, query string, topK int, filter store.NodeFilter) ([]store.SearchResult, error) {
+	return append([]store.SearchResult(nil), k.keywordResults...), nil
+}
+
+func TestKeywordSearcher(t *testing.T) {
+	graphStore := &keywordGraphStore{
+		testGraphStore: &testGraphStore{nodes: map[string]*store.Node{
+			"err": {ID: "err", Name: "ERR_CONN_RESET", Type: "Error"},
+		}},
+		keywordResults: []store.SearchResult{{ID: "err", Score: 3.2}, {ID: "stale", Score: 1.1}},
+	}
+
+	results, err := NewKeywordSearcher(graphStore).Search(context.Background(), "ERR_CONN_RESET", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].NodeID != "err" || results[0].Source != "keyword" || results[0].Score != 3.2 {
+		t.Errorf("unexpected results %+v", results)
+	}
+
+	if _, err := NewKeywordSearcher(&testGraphStore{}).Search(context.Background(), "q", SearchOptions{}); err == nil {
+		t.Error("expected error for a store without keyword search")
+	}
+}
+
+func TestHybridSearcher_KeywordLeg(t *testing.T) {
+	ctx := context.Background()
+
+	nodes := map[string]*store.Node{
+		"similar": {ID: "similar", Name: "Network errors", Type: "Concept"},
+		"exact":   {ID: "exact", Name: "ERR_CONN_RESET", Type: "Error"},
+		"both":    {ID: "both", Name: "Connection reset", Type: "Concept"},
+		"linked":  {ID: "linked", Name: "Load balancer", Type: "Component"},
+	}
+	graphStore := &keywordGraphStore{
+		testGraphStore: &testGraphStore{
+			nodes:     nodes,
+			neighbors: map[string][]*store.Node{"exact": {nodes["linked"]}},
+		},
+		keywordResults: []store.SearchResult{{ID: "exact", Score: 8}, {ID: "both", Score: 2}},
+	}
+	vectorStore := &mockVectorStore{
+		searchFunc: func(ctx context.Context, query []float32, topK int) ([]store.SearchResult, error) {
+			return []store.SearchResult{{ID: "similar", Score: 0.8}, {ID: "both", Score: 0.6}}, nil
+		},
+	}
+	searcher := NewHybridSearcher(&mockEmbeddingClient{}, vectorStore, graphStore)
+
+	results, err := searcher.Search(ctx, "ERR_CONN_RESET", SearchOptions{TopK: 10, GraphDepth: 1})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	byID := make(map[string]SearchResult)
+	for _, r := range results {
+		byID[r.NodeID] = r
+	}
+
+	// Keyword scores are relative to the best keyword hit
+	if r := byID["exact"]; r.Source != "keyword" || r.Score != 1.0 {
+		t.Errorf("expected exact as a keyword hit scoring 1, got %+v", r)
+	}
+	if r := byID["both"]; r.Source != "hybrid" || r.Score != 0.6+0.25 {
+		t.Errorf("expected both as a hybrid hit scoring 0.85, got %+v", r)
+	}
+	if r := byID["linked"]; r.Source != "graph" || r.GraphDepth != 1 {
+		t.Errorf("expected keyword hits to be expanded, got %+v", r)
+	}
+	if results[0].NodeID != "exact" {
+		t.Errorf("expected the exact identifier first, got %s", results[0].NodeID)
+	}
+
+	results, err = searcher.Search(ctx, "ERR_CONN_RESET", SearchOptions{TopK: 10, GraphDepth: 1, SkipKeyword: true})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	for _, r := range results {
+		if r.NodeID == "exact" || r.NodeID == "linked" {
+			t.Errorf("expected no keyword hits with SkipKeyword, got %s", r.NodeID)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package search
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"unicode/utf8"
+)
+
+// Values of the "component" attribute on records logged by the searchers
+const (
+	componentHybrid  = "search.hybrid"
+	componentVector  = "search.vector"
+	componentGraph   = "search.graph"
+	componentKeyword = "search.keyword"
+	componentDecay   = "search.decay"
+	componentRerank  = "search.rerank"
+)
+
+// LoggerSetter is implemented by searchers that accept a structured logger. Wrapping
+// searchers pass the logger on to the searcher they wrap.
+type LoggerSetter interface {
+	SetLogger(logger *slog.Logger)
+}
+
+// searchLogger is the optional structured logger of a searcher. Query text is never
+// logged, only its length; nodes are identified by ID, never by name or description.
+type searchLogger struct {
+	logger *slog.Logger
+}
+
+// setLogger sets the logger, tagging its records with component; nil disables logging
+func (l *searchLogger) setLogger(logger *slog.Logger, component string) {
+	if logger != nil {
+		logger = logger.With(slog.String("component", component))
+	}
+	l.logger = logger
+}
+
+// propagateLogger passes logger on to a wrapped searcher that accepts one
+func propagateLogger(underlying Searcher, logger *slog.Logger) {
+	if setter, ok := underlying.(LoggerSetter); ok {
+		setter.SetLogger(logger)
+	}
+}
+
+// logSearch logs a completed search at DEBUG with its duration and result count
+func (l *searchLogger) logSearch(ctx context.Context, query string, start time.Time, results int, attrs ...slog.Attr) {
+	if l.logger == nil {
+		return
+	}
+	attrs = append([]slog.Attr{
+		slog.Int("query_len", utf8.RuneCountInString(query)),
+		slog.Int("results", results),
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+	}, attrs...)
+	l.logger.LogAttrs(ctx, slog.LevelDebug, "search completed", attrs...)
+}
@@ -0,0 +1,42 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestSearchers_LogSearchesWithoutQueryText(t *testing.T) {
+	graphStore := &testGraphStore{
+		nodes: map[string]*store.Node{
+			"node1": {ID: "node1", Name: "React", Type: "Tech"},
+		},
+	}
+	vectorStore := &mockVectorStore{
+		searchFunc: func(ctx context.Context, query []float32, topK int) ([]store.SearchResult, error) {
+			return []store.SearchResult{{ID: "node1", Score: 0.8}}, nil
+		},
+	}
+	searcher := NewRerankingSearcher(NewHybridSearcher(&mockEmbeddingClient{}, vectorStore, graphStore), &fakeReranker{})
+
+	var buf bytes.Buffer
+	searcher.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	if _, err := searcher.Search(context.Background(), "confidential plans", SearchOptions{TopK: 5, Rerank: true}); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{`"component":"search.hybrid"`, `"component":"search.rerank"`, `"msg":"search completed"`, `"query_len":18`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %s in logs, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "confidential") || strings.Contains(out, "React") {
+		t.Errorf("expected query text and node names redacted, got:\n%s", out)
+	}
+}
@@ -0,0 +1,74 @@
+package search
+
+import (
+	"context"
+	"log/slog"
+	"math"
+)
+
+// MentionBoostSearcher is a decorator that favours central concepts: results whose node
+// is mentioned in many cognified chunks (store.Node.MentionCount) score higher than
+// one-off mentions.
+type MentionBoostSearcher struct {
+	underlying            Searcher
+	weight                float64 // Maximum relative boost (0.5 = up to +50%)
+	referenceMentionCount int     // Mention count at which the full boost applies
+}
+
+// NewMentionBoostSearcher creates a new mention-frequency ranking wrapper.
+//
+// Parameters:
+//   - underlying: The base searcher to wrap
+//   - weight: Maximum relative score boost for frequently mentioned nodes
+//   - referenceMentionCount: Mention count at which the full boost applies (default: 10)
+func NewMentionBoostSearcher(underlying Searcher, weight float64, referenceMentionCount int) *MentionBoostSearcher {
+	if referenceMentionCount <= 0 {
+		referenceMentionCount = 10
+	}
+	return &MentionBoostSearcher{
+		underlying:            underlying,
+		weight:                weight,
+		referenceMentionCount: referenceMentionCount,
+	}
+}
+
+// SetLogger passes the structured logger on to the wrapped searcher.
+func (m *MentionBoostSearcher) SetLogger(logger *slog.Logger) {
+	propagateLogger(m.underlying, logger)
+}
+
+// Search performs search and re-ranks results by mention frequency.
+// Formula: score × (1 + weight × min(1, log(mentions + 1) / log(reference + 1)))
+func (m *MentionBoostSearcher) Search(ctx context.Context, query string, opts SearchOptions) ([]SearchResult, error) {
+	results, err := m.underlying.Search(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	if m.weight <= 0 {
+		return results, nil
+	}
+
+	for i := range results {
+		if results[i].Node == nil {
+			continue
+		}
+		results[i].Score *= 1 + m.weight*m.mentionFactor(results[i].Node.MentionCount)
+	}
+
+	// Diversified results are ordered by selection, not score; keep that order
+	if opts.Diversify {
+		return results, nil
+	}
+	SortResults(results)
+
+	return results, nil
+}
+
+// mentionFactor maps a mention count to [0, 1] on a logarithmic scale.
+func (m *MentionBoostSearcher) mentionFactor(mentions int64) float64 {
+	if mentions <= 0 {
+		return 0
+	}
+	factor := math.Log(float64(mentions)+1) / math.Log(float64(m.referenceMentionCount)+1)
+	return math.Min(factor, 1)
+}
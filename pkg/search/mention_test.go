@@ -0,0 +1,71 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestMentionBoostSearcher_ReranksByMentions(t *testing.T) {
+	mock := &MockSearcher{
+		Results: []SearchResult{
+			{NodeID: "one-off", Node: &store.Node{ID: "one-off", MentionCount: 1}, Score: 1.0},
+			{NodeID: "central", Node: &store.Node{ID: "central", MentionCount: 10}, Score: 0.9},
+			{NodeID: "deleted", Score: 0.5},
+		},
+	}
+
+	searcher := NewMentionBoostSearcher(mock, 0.5, 10)
+	results, err := searcher.Search(context.Background(), "query", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if results[0].NodeID != "central" {
+		t.Errorf("Expected frequently mentioned node first, got %s", results[0].NodeID)
+	}
+	// Reference count reached: full boost
+	if got, want := results[0].Score, 0.9*1.5; got < want-1e-9 || got > want+1e-9 {
+		t.Errorf("Expected central score %v, got %v", want, got)
+	}
+	if results[2].NodeID != "deleted" || results[2].Score != 0.5 {
+		t.Errorf("Expected result without node to keep its score, got %+v", results[2])
+	}
+}
+
+func TestMentionBoostSearcher_ZeroWeight(t *testing.T) {
+	mock := &MockSearcher{
+		Results: []SearchResult{
+			{NodeID: "a", Node: &store.Node{ID: "a", MentionCount: 50}, Score: 0.4},
+		},
+	}
+
+	results, err := NewMentionBoostSearcher(mock, 0, 10).Search(context.Background(), "query", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if results[0].Score != 0.4 {
+		t.Errorf("Expected unchanged score with zero weight, got %v", results[0].Score)
+	}
+}
+
+func TestMentionBoostSearcher_KeepsDiversifiedOrder(t *testing.T) {
+	mock := &MockSearcher{
+		Results: []SearchResult{
+			{NodeID: "one-off", Node: &store.Node{ID: "one-off", MentionCount: 1}, Score: 1.0},
+			{NodeID: "central", Node: &store.Node{ID: "central", MentionCount: 10}, Score: 0.9},
+		},
+	}
+
+	results, err := NewMentionBoostSearcher(mock, 0.5, 10).Search(context.Background(), "query", SearchOptions{Diversify: true})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if results[0].NodeID != "one-off" {
+		t.Errorf("Expected diversified order to be kept, got %s first", results[0].NodeID)
+	}
+	if got, want := results[1].Score, 0.9*1.5; got < want-1e-9 || got > want+1e-9 {
+		t.Errorf("Expected boosted score %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,72 @@
+package search
+
+import (
+	"math"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+const (
+	// defaultDiversifyLambda favours relevance while still breaking up near-duplicates
+	defaultDiversifyLambda = 0.7
+
+	// diversifyPoolFactor bounds the candidates MMR considers to this multiple of topK
+	diversifyPoolFactor = 4
+)
+
+// diversify picks up to topK of results, which must be sorted by score, with Maximal
+// Marginal Relevance. Each pick maximizes
+//
+//	lambda × relevance − (1 − lambda) × max similarity to the nodes already picked
+//
+// where relevance is the score relative to the best one and similarity is the cosine
+// similarity of node embeddings. Nodes without an embedding count as unlike any other.
+// A lambda of 0 takes the default.
+func diversify(results []SearchResult, topK int, lambda float64) []SearchResult {
+	if lambda <= 0 {
+		lambda = defaultDiversifyLambda
+	}
+	pool := results[:min(len(results), max(topK*diversifyPoolFactor, 20))]
+	if len(pool) == 0 {
+		return pool
+	}
+	best := pool[0].Score
+
+	picked := make([]bool, len(pool))
+	maxSimilarity := make([]float64, len(pool))
+	selected := make([]SearchResult, 0, min(topK, len(pool)))
+	for len(selected) < topK && len(selected) < len(pool) {
+		choice, choiceScore := -1, math.Inf(-1)
+		for i, r := range pool {
+			if picked[i] {
+				continue
+			}
+			relevance := r.Score
+			if best > 0 {
+				relevance /= best
+			}
+			// Strictly greater keeps the higher scored node on ties
+			if mmr := lambda*relevance - (1-lambda)*maxSimilarity[i]; mmr > choiceScore {
+				choice, choiceScore = i, mmr
+			}
+		}
+
+		picked[choice] = true
+		selected = append(selected, pool[choice])
+		chosen := resultEmbedding(pool[choice])
+		for i := range pool {
+			if !picked[i] {
+				maxSimilarity[i] = max(maxSimilarity[i], store.CosineSimilarity(chosen, resultEmbedding(pool[i])))
+			}
+		}
+	}
+	return selected
+}
+
+// resultEmbedding returns the embedding of a result's node, or nil if it has none
+func resultEmbedding(r SearchResult) []float32 {
+	if r.Node == nil {
+		return nil
+	}
+	return r.Node.Embedding
+}
@@ -0,0 +1,96 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+func TestDiversify_SkipsNearDuplicates(t *testing.T) {
+	results := []SearchResult{
+		{NodeID: "k8s", Score: 0.95, Node: &store.Node{Embedding: []float32{1, 0, 0}}},
+		{NodeID: "kubernetes", Score: 0.94, Node: &store.Node{Embedding: []float32{0.99, 0.01, 0}}},
+		{NodeID: "k8s-cluster", Score: 0.93, Node: &store.Node{Embedding: []float32{0.98, 0.02, 0}}},
+		{NodeID: "grafana", Score: 0.80, Node: &store.Node{Embedding: []float32{0, 1, 0}}},
+	}
+
+	picked := diversify(results, 2, 0.5)
+	if len(picked) != 2 || picked[0].NodeID != "k8s" || picked[1].NodeID != "grafana" {
+		t.Errorf("expected [k8s grafana], got %v", resultIDs(picked))
+	}
+
+	// Relevance only keeps the score order
+	picked = diversify(results, 3, 1)
+	if got := resultIDs(picked); len(got) != 3 || got[0] != "k8s" || got[1] != "kubernetes" || got[2] != "k8s-cluster" {
+		t.Errorf("expected score order with lambda 1, got %v", got)
+	}
+}
+
+func TestDiversify_WithoutEmbeddings(t *testing.T) {
+	results := []SearchResult{
+		{NodeID: "a", Score: 0.9, Node: &store.Node{}},
+		{NodeID: "b", Score: 0.8},
+		{NodeID: "c", Score: 0.7, Node: &store.Node{}},
+	}
+	if got := resultIDs(diversify(results, 5, 0)); len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Errorf("expected score order when nothing can be compared, got %v", got)
+	}
+	if got := diversify(nil, 5, 0); len(got) != 0 {
+		t.Errorf("expected no results, got %v", got)
+	}
+}
+
+func TestHybridSearcher_Diversify(t *testing.T) {
+	ctx := context.Background()
+
+	graphStore := &testGraphStore{
+		nodes: map[string]*store.Node{
+			"go":      {ID: "go", Name: "Go", Embedding: []float32{1, 0}},
+			"golang":  {ID: "golang", Name: "Golang", Embedding: []float32{1, 0.01}},
+			"sqlite":  {ID: "sqlite", Name: "SQLite", Embedding: []float32{0, 1}},
+			"go-lang": {ID: "go-lang", Name: "Go language", Embedding: []float32{1, 0.02}},
+		},
+	}
+	vectorStore := &mockVectorStore{
+		searchFunc: func(ctx context.Context, query []float32, topK int) ([]store.SearchResult, error) {
+			return []store.SearchResult{
+				{ID: "go", Score: 0.9}, {ID: "golang", Score: 0.89}, {ID: "go-lang", Score: 0.88}, {ID: "sqlite", Score: 0.7},
+			}, nil
+		},
+	}
+	searcher := NewHybridSearcher(&mockEmbeddingClient{}, vectorStore, graphStore)
+
+	plain, err := searcher.Search(ctx, "go", SearchOptions{TopK: 2})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if got := resultIDs(plain); got[0] != "go" || got[1] != "golang" {
+		t.Errorf("expected the near-duplicates without Diversify, got %v", got)
+	}
+
+	diverse, err := searcher.Search(ctx, "go", SearchOptions{TopK: 2, Diversify: true})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if got := resultIDs(diverse); len(got) != 2 || got[0] != "go" || got[1] != "sqlite" {
+		t.Errorf("expected [go sqlite] with Diversify, got %v", got)
+	}
+}
+
+func TestSearchOptions_ValidateDiversifyLambda(t *testing.T) {
+	if err := (SearchOptions{Diversify: true, DiversifyLambda: 1.5}).Validate(); err == nil {
+		t.Error("expected error for lambda above 1")
+	}
+	if err := (SearchOptions{Diversify: true, DiversifyLambda: 0.3}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func resultIDs(results []SearchResult) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.NodeID
+	}
+	return ids
+}
@@ -0,0 +1,29 @@
+package search
+
+import "sort"
+
+// SortResults orders results by score, highest first, breaking ties by node ID so equal
+// scores come back in the same order on every search.
+func SortResults(results []SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].NodeID < results[j].NodeID
+	})
+}
+
+// ApplyMinScore drops the results scoring below minScore, except for the first
+// minResults, keeping the order of the rest.
+func ApplyMinScore(results []SearchResult, minScore float64, minResults int) []SearchResult {
+	if minScore <= 0 {
+		return results
+	}
+	kept := results[:0]
+	for i, result := range results {
+		if result.Score >= minScore || i < minResults {
+			kept = append(kept, result)
+		}
+	}
+	return kept
+}
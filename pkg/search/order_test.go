@@ -0,0 +1,46 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortResults_TieBreakByNodeID(t *testing.T) {
+	results := []SearchResult{
+		{NodeID: "c", Score: 0.5},
+		{NodeID: "b", Score: 0.9},
+		{NodeID: "a", Score: 0.5},
+		{NodeID: "d", Score: 0.5},
+	}
+	SortResults(results)
+	if got := resultIDs(results); !reflect.DeepEqual(got, []string{"b", "a", "c", "d"}) {
+		t.Errorf("Expected score order with ties by node ID, got %v", got)
+	}
+}
+
+func TestApplyMinScore(t *testing.T) {
+	results := func() []SearchResult {
+		return []SearchResult{{NodeID: "a", Score: 0.9}, {NodeID: "b", Score: 0.4}, {NodeID: "c", Score: 0.2}}
+	}
+	tests := []struct {
+		name       string
+		minScore   float64
+		minResults int
+		want       []string
+	}{
+		{"no threshold", 0, 0, []string{"a", "b", "c"}},
+		{"threshold", 0.3, 0, []string{"a", "b"}},
+		{"threshold inclusive", 0.4, 0, []string{"a", "b"}},
+		{"min results", 0.95, 2, []string{"a", "b"}},
+		{"min results already met", 0.3, 1, []string{"a", "b"}},
+		{"min results beyond results", 0.95, 5, []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resultIDs(ApplyMinScore(results(), tt.minScore, tt.minResults))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyMinScore(%g, %d) = %v, want %v", tt.minScore, tt.minResults, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dan-solli/gognee/pkg/llm"
+)
+
+// defaultRerankCandidates is the number of retrieved results rescored by a Reranker
+const defaultRerankCandidates = 20
+
+// Reranker rescores retrieved documents against the query, typically with a model that
+// reads query and document together (an LLM or a cross-encoder) and so judges relevance
+// better than embedding similarity.
+type Reranker interface {
+	// Rerank returns a relevance score in [0, 1] for each document, in order.
+	Rerank(ctx context.Context, query string, documents []string) ([]float64, error)
+}
+
+// RerankingSearcher is a decorator that rescores the top candidates of the underlying
+// searcher with a Reranker when SearchOptions.Rerank is set.
+type RerankingSearcher struct {
+	underlying Searcher
+	reranker   Reranker
+	searchLogger
+}
+
+// NewRerankingSearcher creates a new reranking wrapper.
+func NewRerankingSearcher(underlying Searcher, reranker Reranker) *RerankingSearcher {
+	return &RerankingSearcher{
+		underlying: underlying,
+		reranker:   reranker,
+	}
+}
+
+// SetLogger sets the structured logger for this RerankingSearcher and the searcher it wraps.
+// When nil, logging is disabled.
+func (r *RerankingSearcher) SetLogger(logger *slog.Logger) {
+	r.setLogger(logger, componentRerank)
+	propagateLogger(r.underlying, logger)
+}
+
+// Search retrieves RerankCandidateCount(opts) results from the underlying searcher and
+// reranks them with Rerank. Without SearchOptions.Rerank, it returns the underlying
+// results unchanged.
+func (r *RerankingSearcher) Search(ctx context.Context, query string, opts SearchOptions) ([]SearchResult, error) {
+	if !opts.Rerank || r.reranker == nil {
+		return r.underlying.Search(ctx, query, opts)
+	}
+	ApplyDefaults(&opts)
+
+	retrieveOpts := opts
+	retrieveOpts.TopK = RerankCandidateCount(opts)
+	results, err := r.underlying.Search(ctx, query, retrieveOpts)
+	if err != nil || len(results) == 0 {
+		return results, err
+	}
+
+	start := time.Now()
+	candidates := len(results)
+	if results, err = Rerank(ctx, r.reranker, query, results, opts); err != nil {
+		return nil, err
+	}
+	r.logSearch(ctx, query, start, len(results), slog.Int("reranked", candidates))
+	return results, nil
+}
+
+// RerankCandidateCount is the number of results to retrieve for reranking:
+// SearchOptions.RerankCandidates (default: 20), and at least TopK.
+func RerankCandidateCount(opts SearchOptions) int {
+	candidates := opts.RerankCandidates
+	if candidates <= 0 {
+		candidates = defaultRerankCandidates
+	}
+	return max(opts.TopK, candidates)
+}
+
+// Rerank replaces the scores of results with the reranker's and returns the best
+// opts.TopK of them in the reranker's order, or picked by MMR over the new scores when
+// opts.Diversify is set.
+func Rerank(ctx context.Context, reranker Reranker, query string, results []SearchResult, opts SearchOptions) ([]SearchResult, error) {
+	if len(results) == 0 {
+		return results, nil
+	}
+	documents := make([]string, len(results))
+	for i, result := range results {
+		documents[i] = rerankDocument(result)
+	}
+	scores, err := reranker.Rerank(ctx, query, documents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rerank results: %w", err)
+	}
+	if len(scores) != len(results) {
+		return nil, fmt.Errorf("failed to rerank results: got %d scores for %d documents", len(scores), len(results))
+	}
+
+	for i := range results {
+		results[i].Score = scores[i]
+	}
+	SortResults(results)
+	if opts.Diversify {
+		return diversify(results, opts.TopK, opts.DiversifyLambda), nil
+	}
+	if len(results) > opts.TopK {
+		results = results[:opts.TopK]
+	}
+	return results, nil
+}
+
+// rerankDocument renders a result's node as the text the reranker reads
+func rerankDocument(result SearchResult) string {
+	if result.Node == nil {
+		return result.NodeID
+	}
+	if result.Node.Description == "" {
+		return fmt.Sprintf("%s (%s)", result.Node.Name, result.Node.Type)
+	}
+	return fmt.Sprintf("%s (%s): %s", result.Node.Name, result.Node.Type, result.Node.Description)
+}
+
+const llmRerankPrompt = `Rate how relevant each numbered passage is to the query, from 0 (irrelevant)
+to 10 (directly answers it).
+
+Query: %s
+
+Passages:
+%s
+Return a JSON object with one score per passage, in order: {"scores": [7, 0, 3]}`
+
+// LLMReranker scores documents by asking an LLM to rate each against the query, all
+// in one call.
+type LLMReranker struct {
+	client llm.LLMClient
+}
+
+// NewLLMReranker creates a reranker backed by an LLM.
+func NewLLMReranker(client llm.LLMClient) *LLMReranker {
+	return &LLMReranker{client: client}
+}
+
+// llmRerankResponse is the LLM's answer to llmRerankPrompt
+type llmRerankResponse struct {
+	Scores []float64 `json:"scores"`
+}
+
+// Rerank returns the LLM's 0-10 ratings scaled to [0, 1]. Documents the LLM did not
+// rate score 0.
+func (l *LLMReranker) Rerank(ctx context.Context, query string, documents []string) ([]float64, error) {
+	var passages strings.Builder
+	for i, document := range documents {
+		fmt.Fprintf(&passages, "[%d] %s\n", i+1, document)
+	}
+
+	var response llmRerankResponse
+	if err := l.client.CompleteWithSchema(ctx, fmt.Sprintf(llmRerankPrompt, query, passages.String()), &response); err != nil {
+		return nil, err
+	}
+
+	scores := make([]float64, len(documents))
+	for i := range scores {
+		if i < len(response.Scores) {
+			scores[i] = min(max(response.Scores[i], 0), 10) / 10
+		}
+	}
+	return scores, nil
+}
+
+// HTTPReranker scores documents with a cross-encoder served over HTTP, using the
+// /rerank API of Hugging Face text-embeddings-inference and compatible servers.
+type HTTPReranker struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPReranker creates a reranker for a cross-encoder endpoint,
+// e.g. "http://localhost:8080/rerank".
+func NewHTTPReranker(url string) *HTTPReranker {
+	return &HTTPReranker{
+		url: url,
+		client: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+type httpRerankRequest struct {
+	Query string   `json:"query"`
+	Texts []string `json:"texts"`
+}
+
+type httpRerankScore struct {
+	Index int     `json:"index"`
+	Score float64 `json:"score"`
+}
+
+// Rerank posts the query and documents to the endpoint and returns its scores in
+// document order. Documents the endpoint did not score get 0.
+func (h *HTTPReranker) Rerank(ctx context.Context, query string, documents []string) ([]float64, error) {
+	jsonData, err := json.Marshal(httpRerankRequest{Query: query, Texts: documents})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", h.url, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("rerank request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("reranker returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var ranked []httpRerankScore
+	if err := json.NewDecoder(resp.Body).Decode(&ranked); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	scores := make([]float64, len(documents))
+	for _, r := range ranked {
+		if r.Index >= 0 && r.Index < len(scores) {
+			scores[r.Index] = r.Score
+		}
+	}
+	return scores, nil
+}
@@ -0,0 +1,172 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// fakeReranker scores documents by a fixed score per document text
+type fakeReranker struct {
+	scores    map[string]float64
+	documents []string
+	err       error
+}
+
+func (f *fakeReranker) Rerank(ctx context.Context, query string, documents []string) ([]float64, error) {
+	f.documents = documents
+	if f.err != nil {
+		return nil, f.err
+	}
+	scores := make([]float64, len(documents))
+	for i, document := range documents {
+		scores[i] = f.scores[document]
+	}
+	return scores, nil
+}
+
+func TestRerankingSearcher_Rescores(t *testing.T) {
+	var requestedTopK int
+	mock := &recordingSearcher{
+		results: []SearchResult{
+			{NodeID: "a", Node: &store.Node{Name: "A", Type: "Concept"}, Score: 0.9},
+			{NodeID: "b", Node: &store.Node{Name: "B", Type: "Concept", Description: "the answer"}, Score: 0.8},
+			{NodeID: "c", Node: &store.Node{Name: "C", Type: "Concept"}, Score: 0.7},
+		},
+		topK: &requestedTopK,
+	}
+	reranker := &fakeReranker{scores: map[string]float64{"B (Concept): the answer": 0.95, "C (Concept)": 0.5}}
+
+	results, err := NewRerankingSearcher(mock, reranker).Search(context.Background(), "question", SearchOptions{TopK: 2, Rerank: true})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if requestedTopK != defaultRerankCandidates {
+		t.Errorf("expected %d candidates to be retrieved, got %d", defaultRerankCandidates, requestedTopK)
+	}
+	if got := resultIDs(results); len(got) != 2 || got[0] != "b" || got[1] != "c" {
+		t.Errorf("expected [b c] after reranking, got %v", got)
+	}
+	if results[0].Score != 0.95 {
+		t.Errorf("expected the reranker's score, got %v", results[0].Score)
+	}
+}
+
+func TestRerankingSearcher_Disabled(t *testing.T) {
+	var requestedTopK int
+	mock := &recordingSearcher{results: []SearchResult{{NodeID: "a", Score: 0.9}}, topK: &requestedTopK}
+	reranker := &fakeReranker{}
+
+	results, err := NewRerankingSearcher(mock, reranker).Search(context.Background(), "question", SearchOptions{TopK: 3})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if reranker.documents != nil || requestedTopK != 3 || results[0].Score != 0.9 {
+		t.Errorf("expected results to pass through without Rerank, got %+v", results)
+	}
+}
+
+func TestRerankingSearcher_Error(t *testing.T) {
+	mock := &recordingSearcher{results: []SearchResult{{NodeID: "a", Score: 0.9}}}
+	reranker := &fakeReranker{err: errors.New("endpoint down")}
+
+	_, err := NewRerankingSearcher(mock, reranker).Search(context.Background(), "question", SearchOptions{Rerank: true})
+	if err == nil || !strings.Contains(err.Error(), "endpoint down") {
+		t.Errorf("expected the reranker error, got %v", err)
+	}
+}
+
+func TestRerank_Diversify(t *testing.T) {
+	// a and b are near duplicates; the reranker likes both best
+	results := []SearchResult{
+		{NodeID: "c", Node: &store.Node{Name: "C", Type: "Concept", Embedding: []float32{0, 1}}, Score: 0.9},
+		{NodeID: "a", Node: &store.Node{Name: "A", Type: "Concept", Embedding: []float32{1, 0}}, Score: 0.8},
+		{NodeID: "b", Node: &store.Node{Name: "B", Type: "Concept", Embedding: []float32{1, 0.01}}, Score: 0.7},
+	}
+	reranker := &fakeReranker{scores: map[string]float64{"A (Concept)": 1, "B (Concept)": 0.95, "C (Concept)": 0.6}}
+
+	got, err := Rerank(context.Background(), reranker, "question", results, SearchOptions{TopK: 2, Diversify: true, DiversifyLambda: 0.5})
+	if err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	if ids := resultIDs(got); len(ids) != 2 || ids[0] != "a" || ids[1] != "c" {
+		t.Errorf("expected MMR over the reranked scores to pick [a c], got %v", ids)
+	}
+}
+
+// recordingSearcher returns fixed results and records the requested TopK
+type recordingSearcher struct {
+	results []SearchResult
+	topK    *int
+}
+
+func (r *recordingSearcher) Search(ctx context.Context, query string, opts SearchOptions) ([]SearchResult, error) {
+	if r.topK != nil {
+		*r.topK = opts.TopK
+	}
+	return append([]SearchResult(nil), r.results...), nil
+}
+
+// scoringLLM answers CompleteWithSchema with a fixed JSON response
+type scoringLLM struct {
+	response string
+	prompt   string
+}
+
+func (s *scoringLLM) Complete(ctx context.Context, prompt string) (string, error) {
+	s.prompt = prompt
+	return s.response, nil
+}
+
+func (s *scoringLLM) CompleteWithSchema(ctx context.Context, prompt string, schema any) error {
+	s.prompt = prompt
+	return json.Unmarshal([]byte(s.response), schema)
+}
+
+func TestLLMReranker(t *testing.T) {
+	client := &scoringLLM{response: `{"scores": [10, 2.5, 14]}`}
+	scores, err := NewLLMReranker(client).Rerank(context.Background(), "what is go?", []string{"Go", "Rust", "Golang", "Java"})
+	if err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	want := []float64{1, 0.25, 1, 0}
+	for i := range want {
+		if scores[i] != want[i] {
+			t.Errorf("score %d = %v, want %v", i, scores[i], want[i])
+		}
+	}
+	if !strings.Contains(client.prompt, "what is go?") || !strings.Contains(client.prompt, "[4] Java") {
+		t.Errorf("prompt is missing the query or passages: %s", client.prompt)
+	}
+}
+
+func TestHTTPReranker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req httpRerankRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Query != "q" || len(req.Texts) != 3 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		// Sorted by score, as text-embeddings-inference returns them
+		w.Write([]byte(`[{"index": 2, "score": 0.9}, {"index": 0, "score": 0.4}]`))
+	}))
+	defer server.Close()
+
+	scores, err := NewHTTPReranker(server.URL).Rerank(context.Background(), "q", []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	if len(scores) != 3 || scores[0] != 0.4 || scores[1] != 0 || scores[2] != 0.9 {
+		t.Errorf("unexpected scores %v", scores)
+	}
+
+	if _, err := NewHTTPReranker(server.URL).Rerank(context.Background(), "other", []string{"a"}); err == nil {
+		t.Error("expected error for a non-200 response")
+	}
+}
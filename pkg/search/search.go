@@ -3,6 +3,7 @@ package search
 
 import (
 	"context"
+	"time"
 
 	"github.com/dan-solli/gognee/pkg/store"
 )
@@ -17,8 +18,11 @@ const (
 	// SearchTypeGraph performs graph traversal search only (requires seed nodes).
 	SearchTypeGraph SearchType = "graph"
 
-	// SearchTypeHybrid combines vector similarity and graph traversal.
+	// SearchTypeHybrid combines vector similarity, keyword matching and graph traversal.
 	SearchTypeHybrid SearchType = "hybrid"
+
+	// SearchTypeKeyword performs BM25 keyword search over node names and descriptions only.
+	SearchTypeKeyword SearchType = "keyword"
 )
 
 // SearchResult represents a single search result with scoring metadata.
@@ -26,7 +30,10 @@ type SearchResult struct {
 	NodeID string      // Unique identifier of the node
 	Node   *store.Node // Full node data (nil if node was deleted)
 	Score  float64     // Combined relevance score (higher is better)
-	Source string      // Origin: "vector", "graph", or "hybrid"
+	Source string      // Origin: "vector", "keyword", "graph", or "hybrid"
+	// LegScores holds the score each retrieval leg gave the node, before decay, boosts
+	// and reranking, for downstream rerankers.
+	LegScores LegScores
 	// GraphDepth indicates the minimum graph distance from the search origin.
 	// 0 for direct vector hits, >0 for nodes discovered via graph expansion.
 	GraphDepth int
@@ -34,23 +41,186 @@ type SearchResult struct {
 	// Sorted by memory updated_at DESC (most recent first).
 	// Empty for legacy nodes (created via Add/Cognify without provenance).
 	MemoryIDs []string
+	// Memories holds summaries of the most recently updated memories that reference
+	// this node, in MemoryIDs order. Populated only when SearchOptions.IncludeMemories is set.
+	Memories []store.MemorySummary
+	// SupportingEdges lists edges incident to this node that carry source evidence
+	// (originating chunk ID and supporting text), for citing answers.
+	// Populated only when SearchOptions.IncludeEvidence is set.
+	SupportingEdges []*store.Edge
+}
+
+// LegScores are the per-leg scores behind a result. A leg that did not find the node
+// scores 0.
+type LegScores struct {
+	Vector  float64 // Cosine similarity to the query embedding
+	Keyword float64 // BM25 relevance; relative to the best keyword hit in hybrid search
+	Graph   float64 // 1 / (1 + depth) from the closest hit or seed
 }
 
 // SearchOptions configures search behavior.
+// JSON tags let servers embedding gognee decode a full options object from a request body;
+// call Validate before searching with decoded options.
 type SearchOptions struct {
-	Type       SearchType // Type of search to perform
-	TopK       int        // Maximum number of results to return (default: 10)
-	GraphDepth int        // Maximum graph traversal depth (default: 1)
+	Type       SearchType `json:"type,omitempty"`        // Type of search to perform
+	TopK       int        `json:"top_k,omitempty"`       // Maximum number of results to return (default: 10)
+	GraphDepth int        `json:"graph_depth,omitempty"` // Maximum graph traversal depth (default: 1)
 	// SeedNodeIDs specifies starting nodes for graph search.
-	// Required for SearchTypeGraph; ignored for SearchTypeVector.
+	// Required for SearchTypeGraph unless edge embeddings are enabled, in which case the
+	// endpoints of the closest relationships are used. Ignored for SearchTypeVector.
 	// For SearchTypeHybrid, seeds augment vector results.
-	SeedNodeIDs []string
+	SeedNodeIDs []string `json:"seed_node_ids,omitempty"`
+	// GraphRelations limits graph expansion to edges with these relation names.
+	GraphRelations []string `json:"graph_relations,omitempty"`
+	// GraphDirection limits graph expansion to outgoing or incoming edges, e.g.
+	// store.DirectionIncoming with GraphRelations ["DEPENDS_ON"] finds what depends on the
+	// seeds. Default: both directions.
+	GraphDirection store.TraversalDirection `json:"graph_direction,omitempty"`
+	// GraphMaxFanOut caps the edges followed from each node during graph expansion,
+	// keeping the heaviest. Default: 0 (unlimited).
+	GraphMaxFanOut int `json:"graph_max_fan_out,omitempty"`
+	// IncludeSummaries lets graph expansion follow store.SummarizesRelation edges, which
+	// link a summary node to every node it summarizes. They are skipped by default (and
+	// when GraphRelations does not list them), so summaries do not act as hubs.
+	IncludeSummaries bool `json:"include_summaries,omitempty"`
+	// AsOf queries the graph as of this time: graph expansion follows only edges valid
+	// then (store.Edge.ValidFrom/ValidTo), as do supporting edges with IncludeEvidence.
+	// Edges without a validity period always qualify. Default: zero (no restriction).
+	AsOf time.Time `json:"as_of,omitzero"`
+	// SkipKeyword leaves out the keyword (BM25) leg of hybrid search, ranking by vector
+	// similarity and graph expansion only. Default: false.
+	SkipKeyword bool `json:"skip_keyword,omitempty"`
+	// Diversify re-ranks hybrid results with Maximal Marginal Relevance (MMR), so
+	// near-duplicate nodes give way to nodes covering other topics. Results keep their
+	// scores but are returned in selection order. Default: false.
+	Diversify bool `json:"diversify,omitempty"`
+	// DiversifyLambda trades relevance against diversity when Diversify is set, from 1
+	// (relevance only) towards 0 (diversity only). Default: 0.7.
+	DiversifyLambda float64 `json:"diversify_lambda,omitempty"`
+	// Expansion embeds LLM-generated paraphrases (ExpansionParaphrase) or hypothetical
+	// answers (ExpansionHyDE) with the query and searches with their averaged embedding,
+	// improving recall on short queries at the cost of an LLM call. Keyword search still
+	// uses the query alone. Default: ExpansionNone.
+	Expansion QueryExpansion `json:"expansion,omitempty"`
+	// QueryEmbedding is the query's embedding, with Expansion applied (see EmbedQuery),
+	// when the caller has already computed it; searchers then use it rather than
+	// embedding and expanding the query again. Default: nil.
+	QueryEmbedding []float32 `json:"-"`
+	// Rerank rescores the top candidates with the configured Reranker (an LLM or a
+	// cross-encoder) and returns them in the reranker's order, with its scores; with
+	// Diversify, MMR picks from the reranked candidates. Default: false.
+	Rerank bool `json:"rerank,omitempty"`
+	// RerankCandidates is the number of retrieved results rescored when Rerank is set
+	// (default: 20). At least TopK results are always rescored.
+	RerankCandidates int `json:"rerank_candidates,omitempty"`
+	// ChunkTopK also returns up to this many stored chunks matching the query
+	// (SearchResponse.Chunks), the raw text behind the nodes. Requires
+	// Config.ChunkEmbeddings. Default: 0 (none).
+	ChunkTopK int `json:"chunk_top_k,omitempty"`
+	// MinScore drops results whose final score (after decay, boosts and reranking) is
+	// below it. Default: 0 (keep all).
+	MinScore float64 `json:"min_score,omitempty"`
+	// MinResults keeps at least this many of the best results when MinScore would drop
+	// more, so a strict threshold never leaves the caller with nothing. Default: 0.
+	MinResults int `json:"min_results,omitempty"`
 	// IncludeMemoryIDs enables memory provenance enrichment (v1.0.0+).
 	// Default: true. Set to false to skip provenance lookup for performance.
-	IncludeMemoryIDs *bool
+	IncludeMemoryIDs *bool `json:"include_memory_ids,omitempty"`
+	// IncludeMemories attaches summaries of the memories each result came from
+	// (SearchResult.Memories), fetched in one batched query. Implies IncludeMemoryIDs.
+	// Default: false.
+	IncludeMemories bool `json:"include_memories,omitempty"`
+	// MaxMemoriesPerResult caps the summaries attached to each result when
+	// IncludeMemories is set, keeping the highest-scoring (see store.MemoryScore; default: 3).
+	MaxMemoriesPerResult int `json:"max_memories_per_result,omitempty"`
+	// IncludeEvidence attaches each result's incident edges with their source chunk
+	// and supporting text (SearchResult.SupportingEdges). Default: false.
+	IncludeEvidence bool `json:"include_evidence,omitempty"`
+	// IncludeQuarantined returns nodes held in quarantine (derived from content flagged
+	// by the extraction guardrail). Default: false.
+	IncludeQuarantined bool `json:"include_quarantined,omitempty"`
+	// IncludeArchived returns nodes referenced only by archived memories. Default: false.
+	IncludeArchived bool `json:"include_archived,omitempty"`
+	// Tags restricts results to nodes derived from memories carrying all of these tags.
+	Tags []string `json:"tags,omitempty"`
+	// Sources restricts results to nodes derived from these sources: documents added with
+	// AddOptions.Source or memories with MemoryInput.Source, matched exactly. Combine with
+	// CreatedAfter/CreatedBefore to search one period of one source.
+	Sources []string `json:"sources,omitempty"`
+	// NodeTypes restricts results to nodes of these types, e.g. ["Decision"].
+	// Graph expansion still passes through nodes of other types.
+	NodeTypes []string `json:"node_types,omitempty"`
+	// CreatedAfter restricts results to nodes created at or after this time.
+	CreatedAfter time.Time `json:"created_after,omitzero"`
+	// CreatedBefore restricts results to nodes created before this time.
+	CreatedBefore time.Time `json:"created_before,omitzero"`
+	// EmbeddingTimeout and LLMTimeout bound each embedding and LLM (Rerank) request of
+	// this search, overriding the clients' own timeouts. Default: 0 (keep them).
+	EmbeddingTimeout time.Duration `json:"embedding_timeout,omitempty"`
+	LLMTimeout       time.Duration `json:"llm_timeout,omitempty"`
 	// TraceEnabled enables detailed timing instrumentation for performance analysis.
 	// Default: false (off by default to minimize overhead).
-	TraceEnabled bool
+	TraceEnabled bool `json:"trace_enabled,omitempty"`
+}
+
+// maxGraphDepth bounds GraphDepth for options received from untrusted callers.
+const maxGraphDepth = 5
+
+// Validate reports whether the options are well-formed, for options decoded from
+// external input. Zero values are accepted and take their defaults (see ApplyDefaults).
+func (o SearchOptions) Validate() error {
+	switch o.Type {
+	case "", SearchTypeVector, SearchTypeGraph, SearchTypeHybrid, SearchTypeKeyword:
+	default:
+		return store.Invalidf("invalid search type %q: must be %q, %q, %q or %q", o.Type, SearchTypeVector, SearchTypeGraph, SearchTypeHybrid, SearchTypeKeyword)
+	}
+	if o.TopK < 0 {
+		return store.Invalidf("top_k must not be negative, got %d", o.TopK)
+	}
+	if o.GraphDepth < 0 || o.GraphDepth > maxGraphDepth {
+		return store.Invalidf("graph_depth must be between 0 and %d, got %d", maxGraphDepth, o.GraphDepth)
+	}
+	if o.MaxMemoriesPerResult < 0 {
+		return store.Invalidf("max_memories_per_result must not be negative, got %d", o.MaxMemoriesPerResult)
+	}
+	if o.MinScore < 0 {
+		return store.Invalidf("min_score must not be negative, got %g", o.MinScore)
+	}
+	if o.ChunkTopK < 0 {
+		return store.Invalidf("chunk_top_k must not be negative, got %d", o.ChunkTopK)
+	}
+	if o.MinResults < 0 {
+		return store.Invalidf("min_results must not be negative, got %d", o.MinResults)
+	}
+	if o.RerankCandidates < 0 {
+		return store.Invalidf("rerank_candidates must not be negative, got %d", o.RerankCandidates)
+	}
+	switch o.Expansion {
+	case "", ExpansionNone, ExpansionParaphrase, ExpansionHyDE:
+	default:
+		return store.Invalidf("invalid expansion %q: must be %q, %q or %q", o.Expansion, ExpansionNone, ExpansionParaphrase, ExpansionHyDE)
+	}
+	if o.DiversifyLambda < 0 || o.DiversifyLambda > 1 {
+		return store.Invalidf("diversify_lambda must be between 0 and 1, got %g", o.DiversifyLambda)
+	}
+	for _, source := range o.Sources {
+		if source == "" {
+			return store.Invalidf("sources must not contain empty strings")
+		}
+	}
+	if o.Type == SearchTypeGraph && len(o.SeedNodeIDs) == 0 {
+		return ErrNoSeeds
+	}
+	return o.NodeFilter().Validate()
+}
+
+// NodeFilter returns the node type and creation time restrictions of the options.
+func (o SearchOptions) NodeFilter() store.NodeFilter {
+	return store.NodeFilter{
+		Types:         o.NodeTypes,
+		CreatedAfter:  o.CreatedAfter,
+		CreatedBefore: o.CreatedBefore,
+	}
 }
 
 // Searcher defines the interface for knowledge graph search.
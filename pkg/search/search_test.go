@@ -0,0 +1,60 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestSearchOptions_DecodeAndValidate(t *testing.T) {
+	body := `{"type": "graph", "top_k": 5, "graph_depth": 2, "seed_node_ids": ["n1"], "include_memory_ids": false, "include_evidence": true}`
+
+	var opts SearchOptions
+	if err := json.Unmarshal([]byte(body), &opts); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if err := opts.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	if opts.Type != SearchTypeGraph || opts.TopK != 5 || opts.GraphDepth != 2 || len(opts.SeedNodeIDs) != 1 {
+		t.Errorf("Unexpected decoded options: %+v", opts)
+	}
+	if opts.IncludeMemoryIDs == nil || *opts.IncludeMemoryIDs {
+		t.Error("Expected include_memory_ids=false to decode as an explicit false")
+	}
+	if !opts.IncludeEvidence {
+		t.Error("Expected include_evidence to decode")
+	}
+}
+
+func TestSearchOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    SearchOptions
+		wantErr bool
+	}{
+		{"zero value", SearchOptions{}, false},
+		{"hybrid", SearchOptions{Type: SearchTypeHybrid, TopK: 10, GraphDepth: 1}, false},
+		{"unknown type", SearchOptions{Type: "fulltext"}, true},
+		{"negative top_k", SearchOptions{TopK: -1}, true},
+		{"graph depth too large", SearchOptions{GraphDepth: maxGraphDepth + 1}, true},
+		{"graph without seeds", SearchOptions{Type: SearchTypeGraph}, true},
+		{"empty source", SearchOptions{Sources: []string{"a.md", ""}}, true},
+		{"negative min_score", SearchOptions{MinScore: -0.1}, true},
+		{"negative min_results", SearchOptions{MinResults: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := (SearchOptions{Type: SearchTypeGraph}).Validate(); !errors.Is(err, ErrNoSeeds) {
+		t.Errorf("Expected ErrNoSeeds, got %v", err)
+	}
+}
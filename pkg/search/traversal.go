@@ -0,0 +1,102 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// traversalOptions returns the edge restrictions of opts for graph expansion. Summary
+// edges are skipped unless opts includes them or lists them in GraphRelations.
+func traversalOptions(opts SearchOptions) store.TraversalOptions {
+	traversal := store.TraversalOptions{
+		Relations: opts.GraphRelations,
+		Direction: opts.GraphDirection,
+		MaxFanOut: opts.GraphMaxFanOut,
+		AsOf:      opts.AsOf,
+	}
+	if !opts.IncludeSummaries && !slices.Contains(opts.GraphRelations, store.SummarizesRelation) {
+		traversal.ExcludeRelations = []string{store.SummarizesRelation}
+	}
+	return traversal
+}
+
+// expandNeighbors returns the nodes within depth hops of nodeID with their shortest
+// depth, following only the edges allowed by traversal. Stores implementing
+// store.DepthTraverser answer in one query; others are walked breadth-first, with
+// GetNeighbors when traversal is unrestricted and GetEdges otherwise.
+func expandNeighbors(ctx context.Context, graphStore store.GraphStore, nodeID string, depth int, traversal store.TraversalOptions) ([]store.NeighborNode, error) {
+	if traverser, ok := graphStore.(store.DepthTraverser); ok {
+		return traverser.TraverseNeighbors(ctx, nodeID, depth, traversal)
+	}
+	if err := traversal.Validate(); err != nil {
+		return nil, err
+	}
+
+	var reached []store.NeighborNode
+	visited := map[string]bool{nodeID: true}
+	frontier := []string{nodeID}
+	for level := 1; level <= depth && len(frontier) > 0; level++ {
+		var nextFrontier []string
+		for _, current := range frontier {
+			neighbors, err := stepNeighbors(ctx, graphStore, current, traversal)
+			if err != nil {
+				return nil, err
+			}
+			for _, neighbor := range neighbors {
+				if visited[neighbor.ID] {
+					continue
+				}
+				visited[neighbor.ID] = true
+				reached = append(reached, store.NeighborNode{Node: neighbor, Depth: level})
+				nextFrontier = append(nextFrontier, neighbor.ID)
+			}
+		}
+		frontier = nextFrontier
+	}
+	return reached, nil
+}
+
+// stepNeighbors returns the direct neighbors of nodeID reachable through the edges
+// allowed by traversal
+func stepNeighbors(ctx context.Context, graphStore store.GraphStore, nodeID string, traversal store.TraversalOptions) ([]*store.Node, error) {
+	if traversal.Unrestricted() {
+		return graphStore.GetNeighbors(ctx, nodeID, 1)
+	}
+
+	edges, err := graphStore.GetEdges(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	// Heaviest edges first, matching the store's fan-out order
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].Weight != edges[j].Weight {
+			return edges[i].Weight > edges[j].Weight
+		}
+		return edges[i].ID < edges[j].ID
+	})
+
+	var nextIDs []string
+	for _, edge := range edges {
+		if traversal.MaxFanOut > 0 && len(nextIDs) >= traversal.MaxFanOut {
+			break
+		}
+		if nextID, ok := traversal.Follow(edge, nodeID); ok {
+			nextIDs = append(nextIDs, nextID)
+		}
+	}
+	nodes, err := store.GetNodesByIDs(ctx, graphStore, nextIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var neighbors []*store.Node
+	for _, nextID := range nextIDs {
+		if node := nodes[nextID]; node != nil {
+			neighbors = append(neighbors, node)
+		}
+	}
+	return neighbors, nil
+}
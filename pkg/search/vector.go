@@ -2,6 +2,8 @@ package search
 
 import (
 	"context"
+	"log/slog"
+	"time"
 
 	"github.com/dan-solli/gognee/pkg/embeddings"
 	"github.com/dan-solli/gognee/pkg/store"
@@ -12,6 +14,8 @@ type VectorSearcher struct {
 	embeddings  embeddings.EmbeddingClient
 	vectorStore store.VectorStore
 	graphStore  store.GraphStore
+	expander    QueryExpander
+	searchLogger
 }
 
 // NewVectorSearcher creates a new vector searcher.
@@ -27,30 +31,43 @@ func NewVectorSearcher(
 	}
 }
 
+// SetQueryExpander sets the expander applied when SearchOptions.Expansion asks for it.
+// Without one, queries are embedded alone.
+func (v *VectorSearcher) SetQueryExpander(expander QueryExpander) {
+	v.expander = expander
+}
+
+// SetLogger sets the structured logger for this VectorSearcher. When nil, logging is disabled.
+func (v *VectorSearcher) SetLogger(logger *slog.Logger) {
+	v.setLogger(logger, componentVector)
+}
+
 // Search performs vector similarity search.
 // It embeds the query, searches the vector store, and enriches results with full node data.
 func (v *VectorSearcher) Search(ctx context.Context, query string, opts SearchOptions) ([]SearchResult, error) {
+	start := time.Now()
 	ApplyDefaults(&opts)
 
 	// Embed the query
-	embedding, err := v.embeddings.EmbedOne(ctx, query)
+	embedding, err := queryEmbedding(ctx, v.embeddings, v.expander, query, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	// Vector search
-	vectorResults, err := v.vectorStore.Search(ctx, embedding, opts.TopK)
+	// Vector search, restricted to nodes matching the type and creation time filters
+	vectorResults, err := searchVectors(ctx, v.vectorStore, v.graphStore, embedding, opts.TopK, opts.NodeFilter())
 	if err != nil {
 		return nil, err
 	}
 
 	// Enrich with full node data
+	nodes, err := store.GetNodesByIDs(ctx, v.graphStore, hitIDs(vectorResults))
+	if err != nil {
+		return nil, err
+	}
 	results := make([]SearchResult, 0, len(vectorResults))
 	for _, vr := range vectorResults {
-		node, err := v.graphStore.GetNode(ctx, vr.ID)
-		if err != nil {
-			return nil, err
-		}
+		node := nodes[vr.ID]
 
 		// Skip if node not found (stale vector index)
 		if node == nil {
@@ -62,9 +79,12 @@ func (v *VectorSearcher) Search(ctx context.Context, query string, opts SearchOp
 			Node:       node,
 			Score:      vr.Score,
 			Source:     "vector",
+			LegScores:  LegScores{Vector: vr.Score},
 			GraphDepth: 0,
 		})
 	}
+	SortResults(results)
 
+	v.logSearch(ctx, query, start, len(results), slog.Int("vector_hits", len(vectorResults)))
 	return results, nil
 }
@@ -0,0 +1,153 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Defaults of the access buffer (see SetAccessFlush)
+const (
+	DefaultAccessFlushInterval = 5 * time.Second
+	DefaultAccessFlushSize     = 256
+)
+
+// accessBuffer collects the nodes read by GetNode and writes their access times in
+// batches, so reads do not write. It is safe for concurrent use.
+type accessBuffer struct {
+	mu       sync.Mutex
+	pending  map[string]time.Time // Node ID -> latest read not yet written
+	interval time.Duration        // Delay before pending reads are written (0 = default, <0 = on Flush only)
+	size     int                  // Pending nodes that trigger a write (0 = default)
+	timer    *time.Timer          // Scheduled write, if any
+	inline   bool                 // Write in the reading goroutine, without a timer (in-memory databases)
+	closed   bool
+}
+
+// SetAccessFlush sets how the access times of nodes read by GetNode are written: once
+// interval has passed since the first unwritten read, or once size nodes are waiting,
+// whichever comes first (defaults: DefaultAccessFlushInterval, DefaultAccessFlushSize).
+// A negative interval writes them only on FlushAccessTimes, a full buffer or Close.
+//
+// Each connection to an in-memory database sees a separate database, so in-memory stores
+// ignore the interval and write a full buffer in the reading goroutine instead of the
+// background.
+func (s *SQLiteGraphStore) SetAccessFlush(interval time.Duration, size int) {
+	s.access.mu.Lock()
+	defer s.access.mu.Unlock()
+	s.access.interval = interval
+	s.access.size = size
+}
+
+// recordAccess buffers a read of a node. A full buffer is written in the background.
+func (s *SQLiteGraphStore) recordAccess(id string) {
+	b := &s.access
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	if b.pending == nil {
+		b.pending = make(map[string]time.Time)
+	}
+	b.pending[id] = time.Now()
+
+	size := b.size
+	if size <= 0 {
+		size = DefaultAccessFlushSize
+	}
+	interval := b.interval
+	if interval == 0 {
+		interval = DefaultAccessFlushInterval
+	}
+	full, inline := len(b.pending) >= size, b.inline
+	if !full && !inline && b.timer == nil && interval > 0 {
+		b.timer = time.AfterFunc(interval, s.flushInBackground)
+	}
+	b.mu.Unlock()
+
+	switch {
+	case full && inline:
+		s.flushInBackground()
+	case full:
+		go s.flushInBackground()
+	}
+}
+
+// flushInBackground writes buffered reads outside any caller, logging failures at WARN
+func (s *SQLiteGraphStore) flushInBackground() {
+	ctx := context.Background()
+	if err := s.FlushAccessTimes(ctx); err != nil {
+		s.log(ctx, slog.LevelWarn, "access time flush failed", slog.String("error", err.Error()))
+	}
+}
+
+// FlushAccessTimes writes the access times of the nodes read by GetNode since the last
+// write, in one transaction. Reads that failed to be written are kept for the next flush.
+func (s *SQLiteGraphStore) FlushAccessTimes(ctx context.Context) error {
+	b := &s.access
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if err := s.writeAccessTimes(ctx, pending); err != nil {
+		b.mu.Lock()
+		for id, at := range pending {
+			if later, ok := b.pending[id]; !ok || at.After(later) {
+				if b.pending == nil {
+					b.pending = make(map[string]time.Time)
+				}
+				b.pending[id] = at
+			}
+		}
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// writeAccessTimes sets last_accessed_at of the given nodes in one transaction
+func (s *SQLiteGraphStore) writeAccessTimes(ctx context.Context, accessed map[string]time.Time) error {
+	start := time.Now()
+	stmt, err := s.stmts.prepare(ctx, s.db, "UPDATE nodes SET last_accessed_at = ? WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("failed to update access time: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	txStmt := tx.StmtContext(ctx, stmt)
+	for id, at := range accessed {
+		if _, err := txStmt.ExecContext(ctx, at, id); err != nil {
+			return fmt.Errorf("failed to update access time: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.logQuery(ctx, "flush_access_times", start, slog.Int("nodes", len(accessed)))
+	return nil
+}
+
+// closeAccess stops buffering reads and writes those buffered, before Close
+func (s *SQLiteGraphStore) closeAccess() {
+	s.access.mu.Lock()
+	s.access.closed = true
+	s.access.mu.Unlock()
+	s.flushInBackground()
+}
@@ -0,0 +1,222 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MemoryACL restricts who can read a memory. A memory with an owner and no team is
+// private to its owner; with a team, it is shared with the team's members. Labels are
+// clearances every reader other than the owner must hold. A nil or empty ACL makes the
+// memory visible to everyone.
+type MemoryACL struct {
+	Owner  string   `json:"owner,omitempty"`  // Principal ID that always has access
+	Team   string   `json:"team,omitempty"`   // Team whose members have access
+	Labels []string `json:"labels,omitempty"` // Normalized labels a reader must hold, sorted
+}
+
+// IsEmpty reports whether the ACL restricts nothing.
+func (a *MemoryACL) IsEmpty() bool {
+	return a == nil || (a.Owner == "" && a.Team == "" && len(a.Labels) == 0)
+}
+
+// Allows reports whether p can read a memory with this ACL. A nil principal is trusted
+// and allowed everything.
+func (a *MemoryACL) Allows(p *Principal) bool {
+	if p == nil || p.Admin || a.IsEmpty() {
+		return true
+	}
+	if a.Owner != "" && a.Owner == p.ID {
+		return true
+	}
+	if a.Team != "" {
+		if !slices.Contains(p.Teams, a.Team) {
+			return false
+		}
+	} else if a.Owner != "" {
+		return false
+	}
+	labels, _ := NormalizeTags(p.Labels)
+	for _, label := range a.Labels {
+		if !slices.Contains(labels, label) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize trims the owner and team and normalizes the labels like tags.
+func (a *MemoryACL) normalize() (MemoryACL, error) {
+	if a == nil {
+		return MemoryACL{}, nil
+	}
+	labels, err := NormalizeTags(a.Labels)
+	if err != nil {
+		return MemoryACL{}, Invalidf("invalid ACL label: %w", err)
+	}
+	slices.Sort(labels)
+	return MemoryACL{Owner: strings.TrimSpace(a.Owner), Team: strings.TrimSpace(a.Team), Labels: labels}, nil
+}
+
+// Principal is the user or agent a request is made for. Memory reads made with a
+// principal on the context (see WithPrincipal) only see memories whose ACL allows it.
+type Principal struct {
+	ID     string   // Matched against MemoryACL.Owner
+	Teams  []string // Matched against MemoryACL.Team
+	Labels []string // Must include every MemoryACL label
+	Admin  bool     // Sees every memory
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context whose memory reads are restricted to what p can see.
+// Without a principal, reads are unrestricted: the caller is trusted, e.g. a background
+// job or a single-user deployment.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal set with WithPrincipal, or nil.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// migrateACLSchema adds the access control columns to memories.
+func (s *SQLiteGraphStore) migrateACLSchema() error {
+	return s.addColumns("memories", [][2]string{
+		{"acl_owner", "TEXT NOT NULL DEFAULT ''"},
+		{"acl_team", "TEXT NOT NULL DEFAULT ''"},
+		{"acl_labels", "TEXT NOT NULL DEFAULT '[]'"},
+	})
+}
+
+// aclClause returns an " AND ..." condition keeping the memories (columns prefixed with
+// prefix, e.g. "m.") that the context's principal can read, or "" when reads are
+// unrestricted. It mirrors MemoryACL.Allows.
+func aclClause(ctx context.Context, prefix string) (string, []interface{}) {
+	p := PrincipalFromContext(ctx)
+	if p == nil || p.Admin {
+		return "", nil
+	}
+	labels, _ := NormalizeTags(p.Labels)
+	args := []interface{}{p.ID}
+	for _, team := range p.Teams {
+		args = append(args, team)
+	}
+	for _, label := range labels {
+		args = append(args, label)
+	}
+	clause := fmt.Sprintf(` AND ((%[1]sacl_owner != '' AND %[1]sacl_owner = ?)
+		OR ((%[1]sacl_team IN (%[2]s) OR (%[1]sacl_team = '' AND %[1]sacl_owner = ''))
+			AND NOT EXISTS (SELECT 1 FROM json_each(%[1]sacl_labels) WHERE value NOT IN (%[3]s))))`,
+		prefix, sqlPlaceholders(len(p.Teams)), sqlPlaceholders(len(labels)))
+	return clause, args
+}
+
+// writeACL stores a memory's ACL; nil clears it.
+func writeACL(ctx context.Context, tx *sql.Tx, id string, acl *MemoryACL) error {
+	normalized, err := acl.normalize()
+	if err != nil {
+		return err
+	}
+	labelsJSON, err := json.Marshal(normalized.Labels)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ACL labels: %w", err)
+	}
+	if normalized.Labels == nil {
+		labelsJSON = []byte("[]")
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE memories SET acl_owner = ?, acl_team = ?, acl_labels = ? WHERE id = ?",
+		normalized.Owner, normalized.Team, string(labelsJSON), id)
+	if err != nil {
+		return fmt.Errorf("failed to set memory ACL: %w", err)
+	}
+	return nil
+}
+
+// scanACL builds the ACL read from the access control columns, nil if empty.
+func scanACL(owner, team, labelsJSON string) (*MemoryACL, error) {
+	acl := &MemoryACL{Owner: owner, Team: team}
+	if labelsJSON != "" && labelsJSON != "[]" {
+		if err := json.Unmarshal([]byte(labelsJSON), &acl.Labels); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ACL labels: %w", err)
+		}
+	}
+	if acl.IsEmpty() {
+		return nil, nil
+	}
+	return acl, nil
+}
+
+// SetMemoryACL replaces the ACL of a memory; nil makes it visible to everyone. Memories
+// the context's principal cannot read are reported as ErrMemoryNotFound.
+func (s *SQLiteMemoryStore) SetMemoryACL(ctx context.Context, id string, acl *MemoryACL) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	clause, aclArgs := aclClause(ctx, "")
+	var exists int
+	err = tx.QueryRowContext(ctx, "SELECT 1 FROM memories WHERE id = ? AND namespace = ?"+clause,
+		append([]interface{}{id, s.namespace}, aclArgs...)...).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return ErrMemoryNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get memory: %w", err)
+	}
+	if err := writeACL(ctx, tx, id, acl); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", classifyWriteError(err))
+	}
+	return nil
+}
+
+// RestrictedAmong returns those of nodeIDs derived only from memories the context's
+// principal cannot read, so search can drop them. Nodes with no memory provenance, or
+// with at least one readable memory, are not restricted. Empty when reads are
+// unrestricted.
+func (s *SQLiteMemoryStore) RestrictedAmong(ctx context.Context, nodeIDs []string) (map[string]bool, error) {
+	return s.restrictedAmong(ctx, "memory_nodes", "node_id", "restricted_among", nodeIDs)
+}
+
+// RestrictedEdgesAmong is RestrictedAmong for edges: it returns those of edgeIDs derived
+// only from memories the context's principal cannot read, so their evidence is not shown.
+func (s *SQLiteMemoryStore) RestrictedEdgesAmong(ctx context.Context, edgeIDs []string) (map[string]bool, error) {
+	return s.restrictedAmong(ctx, "memory_edges", "edge_id", "restricted_edges_among", edgeIDs)
+}
+
+// restrictedAmong returns those of ids whose provenance rows in table (keyed by column)
+// all link to memories the context's principal cannot read.
+func (s *SQLiteMemoryStore) restrictedAmong(ctx context.Context, table, column, op string, ids []string) (map[string]bool, error) {
+	start := time.Now()
+	clause, aclArgs := aclClause(ctx, "m.")
+	if clause == "" || len(ids) == 0 {
+		return map[string]bool{}, nil
+	}
+
+	restricted, err := nodeIDsAmong(ctx, s.db, ids, `
+		SELECT p.`+column+`
+		FROM `+table+` p
+		JOIN memories m ON p.memory_id = m.id
+		WHERE m.namespace = ? AND m.status != ? AND p.`+column+` IN (%s)
+		GROUP BY p.`+column+`
+		HAVING SUM(1 `+clause+`) = 0
+	`, []interface{}{s.namespace, StatusDeleted}, aclArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query restricted %s: %w", table, err)
+	}
+	s.logQuery(ctx, op, start, slog.Int("candidates", len(ids)), slog.Int("restricted", len(restricted)))
+	return restricted, nil
+}
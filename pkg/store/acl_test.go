@@ -0,0 +1,165 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestMemoryACL_Allows(t *testing.T) {
+	alice := &Principal{ID: "alice", Teams: []string{"platform"}, Labels: []string{"Internal"}}
+	bob := &Principal{ID: "bob", Teams: []string{"sales"}}
+
+	tests := []struct {
+		name  string
+		acl   *MemoryACL
+		alice bool
+		bob   bool
+	}{
+		{"no acl", nil, true, true},
+		{"private", &MemoryACL{Owner: "alice"}, true, false},
+		{"team", &MemoryACL{Owner: "carol", Team: "platform"}, true, false},
+		{"labels only", &MemoryACL{Labels: []string{"internal"}}, true, false},
+		{"team and labels", &MemoryACL{Team: "sales", Labels: []string{"internal"}}, false, false},
+		{"owner skips labels", &MemoryACL{Owner: "bob", Labels: []string{"secret"}}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.acl.Allows(alice); got != tt.alice {
+				t.Errorf("Allows(alice) = %v, want %v", got, tt.alice)
+			}
+			if got := tt.acl.Allows(bob); got != tt.bob {
+				t.Errorf("Allows(bob) = %v, want %v", got, tt.bob)
+			}
+			if !tt.acl.Allows(nil) || !tt.acl.Allows(&Principal{Admin: true}) {
+				t.Error("Expected nil and admin principals to be allowed")
+			}
+		})
+	}
+}
+
+// setupACLMemories stores a public, a private (alice), a team (platform) and a labelled
+// (internal) memory, and returns the store and the memory IDs by name
+func setupACLMemories(t *testing.T) (*SQLiteMemoryStore, map[string]string) {
+	t.Helper()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	t.Cleanup(func() { graphStore.Close() })
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+
+	memories := map[string]*MemoryRecord{
+		"public":   {Topic: "Public", Context: "Everyone", DocHash: "h1"},
+		"private":  {Topic: "Private", Context: "Alice only", DocHash: "h2", ACL: &MemoryACL{Owner: "alice"}},
+		"team":     {Topic: "Team", Context: "Platform team", DocHash: "h3", ACL: &MemoryACL{Team: "platform"}},
+		"labelled": {Topic: "Labelled", Context: "Internal", DocHash: "h4", ACL: &MemoryACL{Labels: []string{" Internal "}}},
+	}
+	ids := make(map[string]string)
+	for name, memory := range memories {
+		if err := memStore.AddMemory(context.Background(), memory); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+		ids[name] = memory.ID
+		if err := memStore.LinkProvenance(context.Background(), memory.ID, []string{name + "-node", "shared-node"}, nil); err != nil {
+			t.Fatalf("LinkProvenance failed: %v", err)
+		}
+	}
+	return memStore, ids
+}
+
+// listedTopics lists the topics of the memories visible on ctx, sorted
+func listedTopics(t *testing.T, memStore *SQLiteMemoryStore, ctx context.Context) []string {
+	t.Helper()
+	summaries, err := memStore.ListMemories(ctx, ListMemoriesOptions{})
+	if err != nil {
+		t.Fatalf("ListMemories failed: %v", err)
+	}
+	topics := make([]string, 0, len(summaries))
+	for _, summary := range summaries {
+		topics = append(topics, summary.Topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+func TestMemoryACL_Enforced(t *testing.T) {
+	memStore, ids := setupACLMemories(t)
+	background := context.Background()
+	alice := WithPrincipal(background, &Principal{ID: "alice"})
+	platform := WithPrincipal(background, &Principal{ID: "bob", Teams: []string{"platform"}, Labels: []string{"INTERNAL"}})
+
+	if got := listedTopics(t, memStore, background); len(got) != 4 {
+		t.Errorf("Expected every memory without a principal, got %v", got)
+	}
+	if got := listedTopics(t, memStore, alice); !reflect.DeepEqual(got, []string{"Private", "Public"}) {
+		t.Errorf("Unexpected memories for alice: %v", got)
+	}
+	if got := listedTopics(t, memStore, platform); !reflect.DeepEqual(got, []string{"Labelled", "Public", "Team"}) {
+		t.Errorf("Unexpected memories for the platform team: %v", got)
+	}
+
+	if _, err := memStore.GetMemory(platform, ids["private"]); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected ErrMemoryNotFound for a hidden memory, got %v", err)
+	}
+	memory, err := memStore.GetMemory(alice, ids["private"])
+	if err != nil {
+		t.Fatalf("GetMemory failed: %v", err)
+	}
+	if memory.ACL == nil || memory.ACL.Owner != "alice" {
+		t.Errorf("Expected the ACL read back, got %+v", memory.ACL)
+	}
+	labelled, _ := memStore.GetMemory(background, ids["labelled"])
+	if !reflect.DeepEqual(labelled.ACL, &MemoryACL{Labels: []string{"internal"}}) {
+		t.Errorf("Expected normalized labels, got %+v", labelled.ACL)
+	}
+
+	byNode, err := memStore.GetMemoriesByNodeIDBatched(alice, []string{"shared-node"})
+	if err != nil || len(byNode["shared-node"]) != 2 {
+		t.Errorf("Expected alice's 2 memories on the shared node, got %v (err %v)", byNode, err)
+	}
+
+	candidates := []string{"shared-node", "team-node", "labelled-node", "unlinked-node"}
+	restricted, err := memStore.RestrictedAmong(alice, candidates)
+	if err != nil {
+		t.Fatalf("RestrictedAmong failed: %v", err)
+	}
+	if !reflect.DeepEqual(restricted, map[string]bool{"team-node": true, "labelled-node": true}) {
+		t.Errorf("Expected the nodes of hidden memories restricted, got %v", restricted)
+	}
+	if restricted, _ := memStore.RestrictedAmong(alice, []string{"shared-node"}); len(restricted) != 0 {
+		t.Errorf("Expected only candidates checked, got %v", restricted)
+	}
+	if restricted, _ := memStore.RestrictedAmong(background, candidates); len(restricted) != 0 {
+		t.Errorf("Expected nothing restricted without a principal, got %v", restricted)
+	}
+}
+
+func TestMemoryACL_Update(t *testing.T) {
+	memStore, ids := setupACLMemories(t)
+	ctx := context.Background()
+	bob := WithPrincipal(ctx, &Principal{ID: "bob"})
+
+	// Sharing the private memory with everyone
+	if err := memStore.UpdateMemory(ctx, ids["private"], MemoryUpdate{ACL: &MemoryACL{}}); err != nil {
+		t.Fatalf("UpdateMemory failed: %v", err)
+	}
+	if _, err := memStore.GetMemory(bob, ids["private"]); err != nil {
+		t.Errorf("Expected the memory visible once its ACL is cleared, got %v", err)
+	}
+
+	if err := memStore.SetMemoryACL(ctx, ids["public"], &MemoryACL{Owner: "alice"}); err != nil {
+		t.Fatalf("SetMemoryACL failed: %v", err)
+	}
+	if _, err := memStore.GetMemory(bob, ids["public"]); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected the memory hidden from bob, got %v", err)
+	}
+	if err := memStore.SetMemoryACL(ctx, "missing", nil); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected ErrMemoryNotFound, got %v", err)
+	}
+	if err := memStore.SetMemoryACL(ctx, ids["public"], &MemoryACL{Labels: []string{" "}}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for an empty label, got %v", err)
+	}
+}
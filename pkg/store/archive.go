@@ -0,0 +1,134 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// StatusArchived is the status of an archived memory. Archived memories keep their
+// content and provenance but are left out of listings (unless asked for) and the nodes
+// only they reference are left out of search, until unarchived.
+const StatusArchived = "Archived"
+
+// migrateArchiveSchema adds the archive columns to the memories table.
+func (s *SQLiteGraphStore) migrateArchiveSchema() error {
+	return s.addColumns("memories", [][2]string{
+		{"archived_at", "DATETIME DEFAULT NULL"},
+		{"pre_archive_status", "TEXT DEFAULT NULL"},
+	})
+}
+
+// ArchiveMemory moves a memory to the archive: its status becomes StatusArchived and the
+// archive time is recorded. Archiving an archived memory is a no-op; soft-deleted
+// memories cannot be archived.
+func (s *SQLiteMemoryStore) ArchiveMemory(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE memories
+		SET pre_archive_status = status, status = ?, archived_at = ?
+		WHERE id = ? AND namespace = ? AND status NOT IN (?, ?)
+	`, StatusArchived, time.Now(), id, s.namespace, StatusArchived, StatusDeleted)
+	if err != nil {
+		return fmt.Errorf("failed to archive memory: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		status, err := s.memoryStatus(ctx, id)
+		if err != nil {
+			return err
+		}
+		if status == StatusDeleted {
+			return Invalidf("cannot archive memory %s: it is deleted", id)
+		}
+	}
+	return nil
+}
+
+// UnarchiveMemory takes a memory out of the archive, restoring the status it had when
+// archived.
+func (s *SQLiteMemoryStore) UnarchiveMemory(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE memories
+		SET status = COALESCE(pre_archive_status, 'complete'), pre_archive_status = NULL, archived_at = NULL
+		WHERE id = ? AND namespace = ? AND status = ?
+	`, id, s.namespace, StatusArchived)
+	if err != nil {
+		return fmt.Errorf("failed to unarchive memory: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		if err := s.requireMemory(ctx, id); err != nil {
+			return err
+		}
+		return Invalidf("memory %s is not archived", id)
+	}
+	return nil
+}
+
+// ArchivedNodeIDs returns the IDs of the nodes referenced only by archived memories
+// (soft-deleted ones aside). Nodes also derived from a memory that is not archived are
+// left out.
+func (s *SQLiteMemoryStore) ArchivedNodeIDs(ctx context.Context) (map[string]bool, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT mn.node_id
+		FROM memory_nodes mn
+		JOIN memories m ON mn.memory_id = m.id
+		WHERE m.namespace = ? AND m.status != ?
+		GROUP BY mn.node_id
+		HAVING SUM(m.status = ?) = COUNT(*)
+	`, s.namespace, StatusDeleted, StatusArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archived nodes: %w", err)
+	}
+	defer rows.Close()
+
+	nodeIDs := make(map[string]bool)
+	for rows.Next() {
+		var nodeID string
+		if err := rows.Scan(&nodeID); err != nil {
+			return nil, fmt.Errorf("failed to scan node ID: %w", err)
+		}
+		nodeIDs[nodeID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating archived nodes: %w", err)
+	}
+	return nodeIDs, nil
+}
+
+// ArchivedAmong is ArchivedNodeIDs restricted to nodeIDs, so search checks only its
+// candidates.
+func (s *SQLiteMemoryStore) ArchivedAmong(ctx context.Context, nodeIDs []string) (map[string]bool, error) {
+	ids, err := nodeIDsAmong(ctx, s.db, nodeIDs, `
+		SELECT mn.node_id
+		FROM memory_nodes mn
+		JOIN memories m ON mn.memory_id = m.id
+		WHERE m.namespace = ? AND m.status != ? AND mn.node_id IN (%s)
+		GROUP BY mn.node_id
+		HAVING SUM(m.status = ?) = COUNT(*)
+	`, []interface{}{s.namespace, StatusDeleted}, []interface{}{StatusArchived})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archived nodes: %w", err)
+	}
+	return ids, nil
+}
+
+// memoryStatus returns the status of a memory, or ErrMemoryNotFound
+func (s *SQLiteMemoryStore) memoryStatus(ctx context.Context, id string) (string, error) {
+	var status string
+	err := s.db.QueryRowContext(ctx, "SELECT status FROM memories WHERE id = ? AND namespace = ?", id, s.namespace).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", ErrMemoryNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get memory status: %w", err)
+	}
+	return status, nil
+}
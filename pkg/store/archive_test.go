@@ -0,0 +1,85 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestArchiveMemory(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+
+	old := &MemoryRecord{Topic: "Billing v1", Context: "Monthly invoices.", DocHash: "h1", Status: "Active"}
+	current := &MemoryRecord{Topic: "Billing v2", Context: "Usage-based invoices.", DocHash: "h2"}
+	for _, mem := range []*MemoryRecord{old, current} {
+		if err := memStore.AddMemory(ctx, mem); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+	}
+	if err := memStore.LinkProvenance(ctx, old.ID, []string{"invoice", "legacy"}, nil); err != nil {
+		t.Fatalf("LinkProvenance failed: %v", err)
+	}
+	if err := memStore.LinkProvenance(ctx, current.ID, []string{"invoice"}, nil); err != nil {
+		t.Fatalf("LinkProvenance failed: %v", err)
+	}
+
+	if err := memStore.ArchiveMemory(ctx, old.ID); err != nil {
+		t.Fatalf("ArchiveMemory failed: %v", err)
+	}
+	if err := memStore.ArchiveMemory(ctx, old.ID); err != nil {
+		t.Errorf("Expected archiving twice to be a no-op, got %v", err)
+	}
+
+	nodeIDs, err := memStore.ArchivedNodeIDs(ctx)
+	if err != nil || len(nodeIDs) != 1 || !nodeIDs["legacy"] {
+		t.Errorf("Expected only the node no live memory references, got %v (err %v)", nodeIDs, err)
+	}
+	among, err := memStore.ArchivedAmong(ctx, []string{"invoice", "legacy", "unlinked"})
+	if err != nil || len(among) != 1 || !among["legacy"] {
+		t.Errorf("Expected only the archived candidate, got %v (err %v)", among, err)
+	}
+	if among, _ := memStore.ArchivedAmong(ctx, []string{"invoice"}); len(among) != 0 {
+		t.Errorf("Expected no archived nodes among live candidates, got %v", among)
+	}
+
+	listed, _ := memStore.ListMemories(ctx, ListMemoriesOptions{})
+	if len(listed) != 1 || listed[0].ID != current.ID {
+		t.Errorf("Expected archived memories left out by default, got %+v", listed)
+	}
+	listed, _ = memStore.ListMemories(ctx, ListMemoriesOptions{IncludeArchived: true})
+	if len(listed) != 2 {
+		t.Errorf("Expected 2 memories with IncludeArchived, got %d", len(listed))
+	}
+	archived := StatusArchived
+	listed, _ = memStore.ListMemories(ctx, ListMemoriesOptions{Status: &archived})
+	if len(listed) != 1 || listed[0].ID != old.ID {
+		t.Errorf("Expected the archived memory listed by status, got %+v", listed)
+	}
+
+	if err := memStore.UnarchiveMemory(ctx, old.ID); err != nil {
+		t.Fatalf("UnarchiveMemory failed: %v", err)
+	}
+	if record, _ := memStore.GetMemory(ctx, old.ID); record.Status != "Active" {
+		t.Errorf("Expected status Active restored, got %s", record.Status)
+	}
+	if err := memStore.UnarchiveMemory(ctx, old.ID); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected a validation error unarchiving a live memory, got %v", err)
+	}
+	if err := memStore.ArchiveMemory(ctx, "missing"); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected ErrMemoryNotFound, got %v", err)
+	}
+
+	if err := memStore.SoftDeleteMemory(ctx, current.ID); err != nil {
+		t.Fatalf("SoftDeleteMemory failed: %v", err)
+	}
+	if err := memStore.ArchiveMemory(ctx, current.ID); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected a validation error archiving a deleted memory, got %v", err)
+	}
+}
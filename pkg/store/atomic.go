@@ -0,0 +1,161 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// DocumentWrite holds every write Cognify makes for one document.
+type DocumentWrite struct {
+	Nodes []*Node // Upserted like AddNode
+	Edges []*Edge // Upserted like AddEdge
+
+	// IndexNodes also stores each node's Embedding in the SQLiteVectorStore index.
+	// Leave it unset when node vectors live in another VectorStore.
+	IndexNodes bool
+
+	// EdgeEmbeddings are stored in the SQLiteEdgeVectorStore index, keyed by edge ID
+	EdgeEmbeddings map[string][]float32
+
+	// Mentions are mention count increments by node ID (see MentionCounter)
+	Mentions map[string]int
+
+	// Hash, Source and ChunkCount mark the document processed (see DocumentTracker),
+	// and the document is linked to Nodes, Edges and ChunkIDs (see DocumentStore).
+	// Nothing is recorded when Hash is empty.
+	Hash       string
+	Source     string
+	AddedAt    time.Time
+	ChunkCount int
+	ChunkIDs   []string
+}
+
+// AtomicWriter applies all of a document's writes in one transaction, for
+// CognifyOptions.Atomic.
+type AtomicWriter interface {
+	// WriteDocument writes w. Either all of it is written or, on error, none of it.
+	WriteDocument(ctx context.Context, w *DocumentWrite) error
+}
+
+// Compile-time interface check
+var _ AtomicWriter = (*SQLiteGraphStore)(nil)
+
+// WriteDocument writes nodes, edges, their vectors, mention counts, the processed
+// document record and document provenance in a single transaction.
+func (s *SQLiteGraphStore) WriteDocument(ctx context.Context, w *DocumentWrite) error {
+	start := time.Now()
+	nodeRows := make([][]interface{}, len(w.Nodes))
+	for i, node := range w.Nodes {
+		// writeNodeVector stores the embedding column of indexed nodes
+		args, err := s.nodeRow(node, !w.IndexNodes)
+		if err != nil {
+			return err
+		}
+		nodeRows[i] = args
+	}
+	edgeRows := make([][]interface{}, len(w.Edges))
+	for i, edge := range w.Edges {
+		args, err := s.edgeRow(edge)
+		if err != nil {
+			return err
+		}
+		edgeRows[i] = args
+	}
+
+	// Vector tables may be resized to fit a fresh database's first embedding, which
+	// runs its own transaction, so dimensions are settled before ours begins
+	if w.IndexNodes {
+		for _, node := range w.Nodes {
+			if len(node.Embedding) > 0 {
+				if err := checkVecDimensions(ctx, s.db, "vec_nodes", "vec_node_ids", len(node.Embedding), true); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	for _, edge := range w.Edges {
+		if embedding := w.EdgeEmbeddings[edge.ID]; len(embedding) > 0 {
+			if err := checkVecDimensions(ctx, s.db, "vec_edges", "vec_edge_ids", len(embedding), true); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if len(nodeRows) > 0 {
+		if err := execRows(ctx, tx, nodeInsertPrefix, nodeRowValues, nodeUpsertSuffix, nodeRows); err != nil {
+			return fmt.Errorf("failed to add nodes: %w", err)
+		}
+	}
+	if len(edgeRows) > 0 {
+		if err := execRows(ctx, tx, edgeInsertPrefix, edgeRowValues, edgeUpsertSuffix, edgeRows); err != nil {
+			return fmt.Errorf("failed to add edges: %w", err)
+		}
+	}
+
+	if w.IndexNodes {
+		for _, node := range w.Nodes {
+			if len(node.Embedding) == 0 {
+				continue
+			}
+			if err := writeNodeVector(ctx, tx, node.ID, node.Embedding); err != nil {
+				return fmt.Errorf("failed to index node %s: %w", node.ID, err)
+			}
+		}
+	}
+	for _, edge := range w.Edges {
+		embedding := w.EdgeEmbeddings[edge.ID]
+		if len(embedding) == 0 {
+			continue
+		}
+		if err := writeEdgeVector(ctx, tx, edge.ID, embedding); err != nil {
+			return fmt.Errorf("failed to index edge %s: %w", edge.ID, err)
+		}
+	}
+
+	for id, n := range w.Mentions {
+		_, err := tx.ExecContext(ctx, "UPDATE nodes SET mention_count = mention_count + ? WHERE namespace = ? AND id = ?",
+			n, s.namespace, id)
+		if err != nil {
+			return fmt.Errorf("failed to increment mention counts: %w", err)
+		}
+	}
+
+	if w.Hash != "" {
+		_, err := tx.ExecContext(ctx,
+			`INSERT OR REPLACE INTO processed_documents (hash, source, processed_at, chunk_count, namespace)
+			 VALUES (?, ?, CURRENT_TIMESTAMP, ?, ?)`,
+			s.documentKey(w.Hash), w.Source, w.ChunkCount, s.namespace)
+		if err != nil {
+			return fmt.Errorf("failed to mark document as processed: %w", err)
+		}
+
+		nodeIDs := make([]string, len(w.Nodes))
+		for i, node := range w.Nodes {
+			nodeIDs[i] = node.ID
+		}
+		edgeIDs := make([]string, len(w.Edges))
+		for i, edge := range w.Edges {
+			edgeIDs[i] = edge.ID
+		}
+		doc := Document{Hash: w.Hash, Source: w.Source, AddedAt: w.AddedAt}
+		if err := s.linkDocument(ctx, tx, doc, nodeIDs, edgeIDs, w.ChunkIDs); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", classifyWriteError(err))
+	}
+	s.logQuery(ctx, "write_document", start, slog.Int("nodes", len(w.Nodes)), slog.Int("edges", len(w.Edges)))
+	return nil
+}
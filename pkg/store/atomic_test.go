@@ -0,0 +1,77 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWriteDocument(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+	ctx := context.Background()
+	now := time.Now()
+
+	write := &DocumentWrite{
+		Nodes: []*Node{
+			{ID: "a", Name: "Alice", Type: "Person", Embedding: []float32{1, 0, 0}, CreatedAt: now},
+			{ID: "b", Name: "Go", Type: "Technology", Embedding: []float32{0, 1, 0}, CreatedAt: now},
+		},
+		Edges:          []*Edge{{ID: "a-uses-b", SourceID: "a", Relation: "USES", TargetID: "b", Weight: 1, CreatedAt: now}},
+		IndexNodes:     true,
+		EdgeEmbeddings: map[string][]float32{"a-uses-b": {1, 1, 0}},
+		Mentions:       map[string]int{"a": 2, "b": 1},
+		Hash:           "doc-hash",
+		Source:         "doc.md",
+		ChunkCount:     2,
+	}
+	if err := s.WriteDocument(ctx, write); err != nil {
+		t.Fatalf("WriteDocument failed: %v", err)
+	}
+
+	node, err := s.GetNode(ctx, "a")
+	if err != nil || node.MentionCount != 2 {
+		t.Fatalf("expected node a with 2 mentions, got %+v, %v", node, err)
+	}
+	if edges, _ := s.GetEdges(ctx, "a"); len(edges) != 1 {
+		t.Errorf("expected the edge written, got %v", edges)
+	}
+	if results, _ := NewSQLiteVectorStore(s.DB()).Search(ctx, []float32{1, 0, 0}, 1); len(results) != 1 || results[0].ID != "a" {
+		t.Errorf("expected node a indexed, got %v", results)
+	}
+	if results, _ := NewSQLiteEdgeVectorStore(s.DB()).Search(ctx, []float32{1, 1, 0}, 1); len(results) != 1 {
+		t.Errorf("expected the edge indexed, got %v", results)
+	}
+	if processed, _ := s.IsDocumentProcessed(ctx, "doc-hash"); !processed {
+		t.Error("expected the document marked processed")
+	}
+}
+
+func TestWriteDocument_RollsBackOnError(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+	ctx := context.Background()
+	now := time.Now()
+
+	// The edge references a missing node, failing after the nodes were inserted
+	write := &DocumentWrite{
+		Nodes:      []*Node{{ID: "a", Name: "Alice", Type: "Person", Embedding: []float32{1, 0, 0}, CreatedAt: now}},
+		Edges:      []*Edge{{ID: "a-knows-x", SourceID: "a", Relation: "KNOWS", TargetID: "missing", Weight: 1, CreatedAt: now}},
+		IndexNodes: true,
+		Mentions:   map[string]int{"a": 1},
+		Hash:       "doc-hash",
+	}
+	if err := s.WriteDocument(ctx, write); err == nil {
+		t.Fatal("expected the dangling edge to fail the write")
+	}
+
+	if node, _ := s.GetNode(ctx, "a"); node != nil {
+		t.Errorf("expected the node rolled back, got %+v", node)
+	}
+	if results, _ := NewSQLiteVectorStore(s.DB()).Search(ctx, []float32{1, 0, 0}, 1); len(results) != 0 {
+		t.Errorf("expected no vectors, got %v", results)
+	}
+	if processed, _ := s.IsDocumentProcessed(ctx, "doc-hash"); processed {
+		t.Error("expected the document not marked processed")
+	}
+}
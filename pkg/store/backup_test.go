@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	tmpDir := t.TempDir()
+	ctx := context.Background()
+
+	s, err := NewSQLiteGraphStore(filepath.Join(tmpDir, "live.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.AddNode(ctx, &Node{ID: "kept", Name: "Kept", Embedding: []float32{1, 0}}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	memStore := NewSQLiteMemoryStore(s.DB())
+	if err := memStore.AddMemory(ctx, &MemoryRecord{ID: "mem-1", Topic: "t", Context: "c", DocHash: "h"}); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if err := memStore.LinkProvenance(ctx, "mem-1", []string{"kept"}, nil); err != nil {
+		t.Fatalf("LinkProvenance failed: %v", err)
+	}
+
+	backupPath := filepath.Join(tmpDir, "snapshot.db")
+	if err := s.Backup(ctx, backupPath); err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	if _, err := os.Stat(backupPath); err != nil {
+		t.Fatalf("Backup file missing: %v", err)
+	}
+
+	// Mutate the live store after the snapshot
+	if err := s.AddNode(ctx, &Node{ID: "after", Name: "After"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if err := s.DeleteNode(ctx, "kept"); err != nil {
+		t.Fatalf("DeleteNode failed: %v", err)
+	}
+
+	if err := s.Restore(ctx, backupPath); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	kept, err := s.GetNode(ctx, "kept")
+	if err != nil || kept == nil {
+		t.Fatalf("Expected restored node, got %v (err %v)", kept, err)
+	}
+	if len(kept.Embedding) != 2 {
+		t.Errorf("Expected restored embedding, got %v", kept.Embedding)
+	}
+	after, err := s.GetNode(ctx, "after")
+	if err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	if after != nil {
+		t.Error("Node added after the snapshot should be gone after restore")
+	}
+
+	nodeIDs, _, err := memStore.GetProvenanceByMemory(ctx, "mem-1")
+	if err != nil {
+		t.Fatalf("GetProvenanceByMemory failed: %v", err)
+	}
+	if len(nodeIDs) != 1 || nodeIDs[0] != "kept" {
+		t.Errorf("Expected provenance to be restored, got %v", nodeIDs)
+	}
+}
+
+func TestRestore_MissingFile(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+
+	ctx := context.Background()
+	if err := s.AddNode(ctx, &Node{ID: "n1", Name: "N1"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+
+	if err := s.Restore(ctx, filepath.Join(t.TempDir(), "missing.db")); err == nil {
+		t.Fatal("Expected error restoring from a missing file")
+	}
+
+	// Store must be untouched
+	count, err := s.NodeCount(ctx)
+	if err != nil {
+		t.Fatalf("NodeCount failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("NodeCount after failed restore: got %d, want 1", count)
+	}
+}
+
+func TestRestore_RejectsNonGogneeDatabase(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+
+	path := filepath.Join(t.TempDir(), "other.db")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	if err := s.Restore(context.Background(), path); err == nil {
+		t.Fatal("Expected error restoring from a non-gognee database")
+	}
+}
@@ -0,0 +1,231 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// bulkInsertBatchSize is the number of rows per multi-row INSERT, keeping statements
+// well below SQLite's bound-parameter limit.
+const bulkInsertBatchSize = 100
+
+// AddNodes upserts nodes in a single transaction using multi-row statements, with the
+// same semantics as AddNode. Either all nodes are written or none are.
+func (s *SQLiteGraphStore) AddNodes(ctx context.Context, nodes []*Node) error {
+	start := time.Now()
+	rows := make([][]interface{}, len(nodes))
+	for i, node := range nodes {
+		args, err := s.nodeRow(node, true)
+		if err != nil {
+			return err
+		}
+		rows[i] = args
+	}
+	if err := s.insertRows(ctx, nodeInsertPrefix, nodeRowValues, nodeUpsertSuffix, rows); err != nil {
+		return fmt.Errorf("failed to add nodes: %w", err)
+	}
+	s.logQuery(ctx, "add_nodes", start, slog.Int("nodes", len(nodes)))
+	return nil
+}
+
+// AddEdges upserts edges in a single transaction using multi-row statements, with the
+// same semantics as AddEdge. Either all edges are written or none are.
+func (s *SQLiteGraphStore) AddEdges(ctx context.Context, edges []*Edge) error {
+	start := time.Now()
+	rows := make([][]interface{}, len(edges))
+	for i, edge := range edges {
+		args, err := s.edgeRow(edge)
+		if err != nil {
+			return err
+		}
+		rows[i] = args
+	}
+	if err := s.insertRows(ctx, edgeInsertPrefix, edgeRowValues, edgeUpsertSuffix, rows); err != nil {
+		return fmt.Errorf("failed to add edges: %w", err)
+	}
+	s.logQuery(ctx, "add_edges", start, slog.Int("edges", len(edges)))
+	return nil
+}
+
+// insertRows executes prefix followed by up to bulkInsertBatchSize comma-separated
+// rowValues and then suffix per statement, all within one transaction.
+func (s *SQLiteGraphStore) insertRows(ctx context.Context, prefix, rowValues, suffix string, rows [][]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := execRows(ctx, tx, prefix, rowValues, suffix, rows); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", classifyWriteError(err))
+	}
+	return nil
+}
+
+// execRows is insertRows within an open transaction.
+func execRows(ctx context.Context, tx *sql.Tx, prefix, rowValues, suffix string, rows [][]interface{}) error {
+	for start := 0; start < len(rows); start += bulkInsertBatchSize {
+		end := min(start+bulkInsertBatchSize, len(rows))
+		batch := rows[start:end]
+
+		query := prefix + strings.TrimSuffix(strings.Repeat(rowValues+",", len(batch)), ",") + suffix
+		args := make([]interface{}, 0, len(batch)*len(batch[0]))
+		for _, row := range batch {
+			args = append(args, row...)
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return classifyWriteError(err)
+		}
+	}
+	return nil
+}
+
+// nodeLookupBatchSize is the number of IDs per GetNodesByIDs query, keeping the IN list
+// well below SQLite's bound-parameter limit.
+const nodeLookupBatchSize = 500
+
+// GetNodesByIDs returns the namespace's nodes with the given IDs, by ID, with one IN
+// query per nodeLookupBatchSize IDs. Unknown IDs are left out. Like GetNode, each node
+// returned counts as an access.
+func (s *SQLiteGraphStore) GetNodesByIDs(ctx context.Context, ids []string) (map[string]*Node, error) {
+	start := time.Now()
+	nodes := make(map[string]*Node, len(ids))
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > nodeLookupBatchSize {
+			batch = batch[:nodeLookupBatchSize]
+		}
+		ids = ids[len(batch):]
+
+		args := make([]interface{}, 0, len(batch)+1)
+		args = append(args, s.namespace)
+		for _, id := range batch {
+			args = append(args, id)
+		}
+		if err := s.queryNodes(ctx, nodes,
+			`SELECT `+nodeColumns+` FROM nodes WHERE namespace = ? AND id IN (`+sqlPlaceholders(len(batch))+`)`, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	for id := range nodes {
+		s.recordAccess(id)
+	}
+	s.logQuery(ctx, "get_nodes_by_ids", start, slog.Int("nodes", len(nodes)))
+	return nodes, nil
+}
+
+// nodeIDsAmong runs query once per nodeLookupBatchSize of ids and returns the node IDs it
+// selects. query is a format string whose %s is replaced by the batch's placeholders;
+// before and after are the arguments bound ahead of and behind the batch.
+func nodeIDsAmong(ctx context.Context, db *sql.DB, ids []string, query string, before, after []interface{}) (map[string]bool, error) {
+	nodeIDs := make(map[string]bool)
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > nodeLookupBatchSize {
+			batch = batch[:nodeLookupBatchSize]
+		}
+		ids = ids[len(batch):]
+
+		args := make([]interface{}, 0, len(before)+len(batch)+len(after))
+		args = append(args, before...)
+		for _, id := range batch {
+			args = append(args, id)
+		}
+		args = append(args, after...)
+		rows, err := db.QueryContext(ctx, fmt.Sprintf(query, sqlPlaceholders(len(batch))), args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var nodeID string
+			if err := rows.Scan(&nodeID); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan node ID: %w", err)
+			}
+			nodeIDs[nodeID] = true
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nodeIDs, nil
+}
+
+// queryNodes adds the nodes selected by query, a SELECT of nodeColumns, to nodes
+func (s *SQLiteGraphStore) queryNodes(ctx context.Context, nodes map[string]*Node, query string, args ...interface{}) error {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query nodes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		node, err := s.scanNode(rows)
+		if err != nil {
+			return err
+		}
+		nodes[node.ID] = node
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating nodes: %w", err)
+	}
+	return nil
+}
+
+// GetNodesByIDs returns the nodes of graphStore with the given IDs, by ID, leaving out
+// unknown IDs. Stores implementing NodeBatchReader fetch them in one query; others are
+// read one node at a time.
+func GetNodesByIDs(ctx context.Context, graphStore GraphStore, ids []string) (map[string]*Node, error) {
+	if batch, ok := graphStore.(NodeBatchReader); ok {
+		return batch.GetNodesByIDs(ctx, ids)
+	}
+	nodes := make(map[string]*Node, len(ids))
+	for _, id := range ids {
+		if _, done := nodes[id]; done {
+			continue
+		}
+		node, err := graphStore.GetNode(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			nodes[id] = node
+		}
+	}
+	return nodes, nil
+}
+
+// deleteReturningIDs runs a DELETE ... RETURNING id statement within tx and returns the
+// IDs of the deleted rows.
+func deleteReturningIDs(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestSQLiteGraphStore_AddNodesAndEdges(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	// More than one batch of rows
+	nodes := make([]*Node, 250)
+	for i := range nodes {
+		nodes[i] = &Node{
+			ID:        fmt.Sprintf("n%d", i),
+			Name:      fmt.Sprintf("Node %d", i),
+			Type:      "Concept",
+			Embedding: []float32{float32(i), 1},
+			Metadata:  map[string]interface{}{"index": i},
+		}
+	}
+	if err := graphStore.AddNodes(ctx, nodes); err != nil {
+		t.Fatalf("AddNodes failed: %v", err)
+	}
+	if count, _ := graphStore.NodeCount(ctx); count != 250 {
+		t.Errorf("Expected 250 nodes, got %d", count)
+	}
+
+	// Upserts keep mention counts, like AddNode
+	if err := graphStore.IncrementMentionCounts(ctx, []string{"n7"}); err != nil {
+		t.Fatalf("IncrementMentionCounts failed: %v", err)
+	}
+	if err := graphStore.AddNodes(ctx, []*Node{{ID: "n7", Name: "Node 7", Type: "Concept", Description: "updated"}}); err != nil {
+		t.Fatalf("AddNodes upsert failed: %v", err)
+	}
+	node, err := graphStore.GetNode(ctx, "n7")
+	if err != nil || node.Description != "updated" || node.MentionCount != 1 {
+		t.Errorf("Expected updated node with mention count 1, got %+v (err %v)", node, err)
+	}
+	node, _ = graphStore.GetNode(ctx, "n200")
+	if len(node.Embedding) != 2 || node.Embedding[0] != 200 {
+		t.Errorf("Expected embedding to round-trip, got %v", node.Embedding)
+	}
+
+	edges := []*Edge{
+		{SourceID: "n0", Relation: "RELATES_TO", TargetID: "n1"},
+		{SourceID: "n1", Relation: "RELATES_TO", TargetID: "n2", Evidence: "n1 relates to n2"},
+	}
+	if err := graphStore.AddEdges(ctx, edges); err != nil {
+		t.Fatalf("AddEdges failed: %v", err)
+	}
+	if edges[0].ID == "" || edges[0].Weight != 1.0 {
+		t.Errorf("Expected edge defaults to be applied, got %+v", edges[0])
+	}
+	if count, _ := graphStore.EdgeCount(ctx); count != 2 {
+		t.Errorf("Expected 2 edges, got %d", count)
+	}
+
+	// A failing row rolls back the whole batch
+	err = graphStore.AddEdges(ctx, []*Edge{
+		{ID: "ok", SourceID: "n2", Relation: "RELATES_TO", TargetID: "n3"},
+		{ID: "dangling", SourceID: "n2", Relation: "RELATES_TO", TargetID: "missing"},
+	})
+	if err == nil {
+		t.Fatal("Expected AddEdges to fail for an edge to a missing node")
+	}
+	if count, _ := graphStore.EdgeCount(ctx); count != 2 {
+		t.Errorf("Expected the failed batch to be rolled back, got %d edges", count)
+	}
+}
+
+func TestSQLiteGraphStore_GetNodesByIDs(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	// More IDs than one lookup query takes
+	nodes := make([]*Node, nodeLookupBatchSize+20)
+	ids := make([]string, 0, len(nodes)+1)
+	for i := range nodes {
+		nodes[i] = &Node{
+			ID:        fmt.Sprintf("n%d", i),
+			Name:      fmt.Sprintf("Node %d", i),
+			Type:      "Concept",
+			Embedding: []float32{float32(i), 1},
+			Metadata:  map[string]interface{}{"index": i},
+		}
+		ids = append(ids, nodes[i].ID)
+	}
+	if err := graphStore.AddNodes(ctx, nodes); err != nil {
+		t.Fatalf("AddNodes failed: %v", err)
+	}
+	ids = append(ids, "missing")
+
+	got, err := graphStore.GetNodesByIDs(ctx, ids)
+	if err != nil {
+		t.Fatalf("GetNodesByIDs failed: %v", err)
+	}
+	if len(got) != len(nodes) {
+		t.Fatalf("expected %d nodes, got %d", len(nodes), len(got))
+	}
+	if _, ok := got["missing"]; ok {
+		t.Error("expected unknown ID to be left out")
+	}
+	last := got[fmt.Sprintf("n%d", len(nodes)-1)]
+	if last == nil || last.Name != fmt.Sprintf("Node %d", len(nodes)-1) || len(last.Embedding) != 2 || last.Metadata["index"] != float64(len(nodes)-1) {
+		t.Errorf("unexpected node: %+v", last)
+	}
+
+	// Reads count as accesses, like GetNode
+	if err := graphStore.FlushAccessTimes(ctx); err != nil {
+		t.Fatalf("FlushAccessTimes failed: %v", err)
+	}
+	node, err := graphStore.GetNode(ctx, "n0")
+	if err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	if node.LastAccessedAt == nil {
+		t.Error("expected GetNodesByIDs to record an access")
+	}
+}
+
+func TestGetNodesByIDs_NamespaceScoped(t *testing.T) {
+	ctx := context.Background()
+	a, b := openNamespacedStores(t)
+	if err := a.AddNode(ctx, &Node{ID: "shared", Name: "A", Type: "Concept"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+
+	got, err := b.GetNodesByIDs(ctx, []string{"shared"})
+	if err != nil {
+		t.Fatalf("GetNodesByIDs failed: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no nodes from another namespace, got %d", len(got))
+	}
+}
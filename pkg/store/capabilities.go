@@ -0,0 +1,394 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// Optional capability interfaces.
+//
+// GraphStore is the core every backend must implement. The interfaces below cover
+// operations only some backends support; callers check for them with a type assertion
+// (e.g. g.graphStore.(store.BulkReader)) and degrade gracefully when they are missing,
+// so a partial backend still works for cognify and search.
+
+// AccessTracker records when nodes are read, feeding access-based decay.
+type AccessTracker interface {
+	// UpdateAccessTime sets last_accessed_at to now for the given nodes.
+	UpdateAccessTime(ctx context.Context, nodeIDs []string) error
+}
+
+// Reinforcer records explicit confirmations of nodes, feeding access-based decay.
+type Reinforcer interface {
+	// ReinforceNodes credits the given nodes with strength accesses and resets their last
+	// access to now, returning the number of nodes reinforced.
+	ReinforceNodes(ctx context.Context, nodeIDs []string, strength int) (int, error)
+}
+
+// MentionCounter counts how often entities are mentioned, feeding the mention boost.
+type MentionCounter interface {
+	// IncrementMentionCounts adds one mention to each of the given nodes.
+	IncrementMentionCounts(ctx context.Context, nodeIDs []string) error
+}
+
+// BulkReader enumerates the whole graph, for prune, export and resolution passes.
+type BulkReader interface {
+	// GetAllNodes returns every node, ordered by created_at then id.
+	GetAllNodes(ctx context.Context) ([]*Node, error)
+
+	// IterateNodes streams every node to fn, ordered by created_at then id.
+	// Iteration stops at the first error returned by fn.
+	IterateNodes(ctx context.Context, fn func(*Node) error) error
+
+	// IterateEdges streams every unexpired edge to fn, ordered by created_at then id.
+	// Iteration stops at the first error returned by fn.
+	IterateEdges(ctx context.Context, fn func(*Edge) error) error
+}
+
+// NodeScanner streams the graph's nodes in batches, for maintenance passes such as prune
+// over graphs too large to load at once.
+type NodeScanner interface {
+	// ScanNodes streams every node to fn, ordered by id, reading opts.BatchSize nodes per
+	// query; fn may write to the store.
+	ScanNodes(ctx context.Context, opts NodeScanOptions, fn func(*Node) error) error
+}
+
+// NodePruner selects and deletes nodes by age inside the store, for pruning large graphs
+// without reading every node.
+type NodePruner interface {
+	// AgedNodeIDs returns the IDs of the unpinned nodes selected by filter, ordered by id.
+	AgedNodeIDs(ctx context.Context, filter NodeAgeFilter) ([]string, error)
+
+	// DeleteNodes deletes nodes and their edges in one transaction, returning the
+	// number of nodes deleted and the IDs of the edges deleted.
+	DeleteNodes(ctx context.Context, ids []string) (nodesDeleted int, edgeIDs []string, err error)
+}
+
+// NodeBatchReader fetches many nodes at once, for hydrating search results.
+type NodeBatchReader interface {
+	// GetNodesByIDs returns the nodes with the given IDs, by ID; unknown IDs are left out.
+	GetNodesByIDs(ctx context.Context, ids []string) (map[string]*Node, error)
+}
+
+// Deleter removes individual nodes and edges.
+type Deleter interface {
+	// DeleteNode removes a node from the graph.
+	DeleteNode(ctx context.Context, nodeID string) error
+
+	// DeleteEdge removes an edge from the graph.
+	DeleteEdge(ctx context.Context, edgeID string) error
+}
+
+// BulkWriter upserts many nodes or edges at once, for cognifying large documents.
+type BulkWriter interface {
+	// AddNodes upserts nodes like AddNode, in a single transaction.
+	AddNodes(ctx context.Context, nodes []*Node) error
+
+	// AddEdges upserts edges like AddEdge, in a single transaction.
+	AddEdges(ctx context.Context, edges []*Edge) error
+}
+
+// DepthTraverser expands the graph around a node in one query, for graph and hybrid search.
+type DepthTraverser interface {
+	// GetNeighborsWithDepth returns every node within depth hops of nodeID with its
+	// shortest distance from it, ordered by depth then id.
+	GetNeighborsWithDepth(ctx context.Context, nodeID string, depth int) ([]NeighborNode, error)
+
+	// TraverseNeighbors is GetNeighborsWithDepth following only the edges allowed by opts.
+	TraverseNeighbors(ctx context.Context, nodeID string, depth int, opts TraversalOptions) ([]NeighborNode, error)
+}
+
+// PathFinder finds how two nodes are connected, for explaining relationships.
+type PathFinder interface {
+	// FindPaths returns up to opts.MaxPaths loop-free paths from fromID to toID, cheapest first.
+	FindPaths(ctx context.Context, fromID, toID string, opts PathOptions) ([]Path, error)
+}
+
+// CommunityStore persists community detection results, for topic clustering.
+type CommunityStore interface {
+	// SetCommunities replaces all community assignments with assignments (node ID to
+	// community ID); nodes not listed get no community.
+	SetCommunities(ctx context.Context, assignments map[string]string) error
+
+	// ListCommunities returns the communities, largest first, each with up to
+	// representatives of its best-connected members.
+	ListCommunities(ctx context.Context, representatives int) ([]Community, error)
+
+	// CommunityNodeIDs returns the IDs of the members of a community, sorted.
+	CommunityNodeIDs(ctx context.Context, communityID string) ([]string, error)
+}
+
+// KeywordSearcher ranks nodes by the query's words in their name and description, for the
+// lexical leg of hybrid search.
+type KeywordSearcher interface {
+	// SearchKeyword returns up to topK nodes matching filter that contain any word of
+	// query, best first. Scores are relative: higher is better, with no fixed range.
+	SearchKeyword(ctx context.Context, query string, topK int, filter NodeFilter) ([]SearchResult, error)
+}
+
+// FilteredVectorSearcher restricts vector search by node type and creation time inside
+// the store, so filtered searches still fill topK.
+type FilteredVectorSearcher interface {
+	// SearchFiltered is VectorStore.Search returning only nodes matching filter.
+	SearchFiltered(ctx context.Context, query []float32, topK int, filter NodeFilter) ([]SearchResult, error)
+}
+
+// GraphQuerier runs pattern queries over the graph (see GraphQuery).
+type GraphQuerier interface {
+	// QueryGraph returns the distinct matches of q.
+	QueryGraph(ctx context.Context, q *GraphQuery) (*QueryResult, error)
+}
+
+// SupersessionResolver follows supersession chains inside the store, for resolving a
+// memory to the one that currently replaces it without a query per link.
+type SupersessionResolver interface {
+	// GetSupersessionHead returns the ID of the current memory of the supersession chain
+	// memoryID is part of, or memoryID itself if it is not superseded.
+	GetSupersessionHead(ctx context.Context, memoryID string) (string, error)
+}
+
+// EdgeReader fetches individual edges, for edge search and memory details.
+type EdgeReader interface {
+	// GetEdge returns the edge with the given ID, or nil if it does not exist or expired.
+	GetEdge(ctx context.Context, id string) (*Edge, error)
+}
+
+// EdgeExpirer reclaims the storage of expired edges, for maintenance passes.
+type EdgeExpirer interface {
+	// DeleteExpiredEdges removes every edge whose ExpiresAt is at or before now, with its
+	// memory provenance, and returns the IDs of the edges deleted.
+	DeleteExpiredEdges(ctx context.Context, now time.Time) ([]string, error)
+}
+
+// AccessFlusher buffers node reads and writes them in batches (see AccessTracker).
+type AccessFlusher interface {
+	// FlushAccessTimes writes the access times buffered since the last write.
+	FlushAccessTimes(ctx context.Context) error
+}
+
+// NodePinner exempts nodes from decay and prune.
+type NodePinner interface {
+	// SetNodePinned pins or unpins a node, recording the reason when pinning.
+	SetNodePinned(ctx context.Context, nodeID string, pinned bool, reason string) error
+}
+
+// NodeEmbeddingWriter keeps node embeddings in the graph store alongside a vector store
+// outside it, so a graph store snapshot holds them too.
+type NodeEmbeddingWriter interface {
+	// UpdateNodeEmbedding stores a node's embedding without touching its other fields.
+	UpdateNodeEmbedding(ctx context.Context, id string, embedding []float32) error
+}
+
+// EmbeddingRegistry records which embedding model made the stored embeddings, for
+// detecting and carrying out model switches.
+type EmbeddingRegistry interface {
+	// EmbeddingConfig returns the recorded embedding model and dimensions.
+	EmbeddingConfig(ctx context.Context) (EmbeddingConfig, error)
+
+	// SetEmbeddingModel records the embedding model name.
+	SetEmbeddingModel(ctx context.Context, model string) error
+
+	// ResetEmbeddings drops every stored embedding and prepares the vector indexes for
+	// vectors of the given dimensions.
+	ResetEmbeddings(ctx context.Context, dimensions int) error
+}
+
+// Snapshotter copies the whole store to and from a file, for backups.
+type Snapshotter interface {
+	// Backup writes a consistent snapshot of the store to path, overwriting it.
+	Backup(ctx context.Context, path string) error
+
+	// Restore replaces the contents of the store with the snapshot at path.
+	Restore(ctx context.Context, path string) error
+}
+
+// GraphDiffer reports how the graph changed, for syncing consumers and audits.
+type GraphDiffer interface {
+	// DiffSince lists the nodes and edges created and the memories superseded since the
+	// given time.
+	DiffSince(ctx context.Context, since time.Time) (*GraphDiff, error)
+
+	// DiffSnapshots compares two snapshots created by Snapshotter.Backup; an empty
+	// headPath compares basePath with the live store.
+	DiffSnapshots(ctx context.Context, basePath, headPath string) (*GraphDiff, error)
+}
+
+// Outbox records every mutation for consumers to read and acknowledge, giving
+// at-least-once change delivery.
+type Outbox interface {
+	// EnableOutbox starts recording mutations.
+	EnableOutbox(ctx context.Context) error
+
+	// DisableOutbox stops recording mutations; recorded entries stay until acknowledged.
+	DisableOutbox(ctx context.Context) error
+
+	// ReadOutbox returns up to limit entries with a sequence number above afterSeq,
+	// oldest first; limit <= 0 returns all of them.
+	ReadOutbox(ctx context.Context, afterSeq int64, limit int) ([]OutboxEntry, error)
+
+	// AckOutbox removes the entries up to and including seq, returning how many.
+	AckOutbox(ctx context.Context, seq int64) (int64, error)
+}
+
+// RelationMerger relabels relations and keeps an audit log of the merges, for
+// CollapseRelations.
+type RelationMerger interface {
+	// RelationCounts returns every distinct relation label with its edge count, most
+	// used first.
+	RelationCounts(ctx context.Context) ([]RelationCount, error)
+
+	// RenameRelation relabels every edge using from to to, returning the number of edges
+	// relabelled or dropped as duplicates and which edges were dropped and written.
+	RenameRelation(ctx context.Context, from, to string) (int64, EdgeChanges, error)
+
+	// RecordRelationMerge appends a merge to the audit log.
+	RecordRelationMerge(ctx context.Context, merge *RelationMerge) error
+
+	// ListRelationMerges returns the audit log, oldest first.
+	ListRelationMerges(ctx context.Context) ([]RelationMerge, error)
+}
+
+// EntityMerger merges duplicate nodes and keeps an audit log of the merges, for
+// ResolveEntities.
+type EntityMerger interface {
+	// MergeNodes merges the node fromID into toID and deletes fromID, returning the number
+	// of edges repointed or dropped and which edges were dropped and written.
+	MergeNodes(ctx context.Context, fromID, toID string) (int64, EdgeChanges, error)
+
+	// RecordEntityMerge appends a merge to the audit log.
+	RecordEntityMerge(ctx context.Context, merge *EntityMerge) error
+
+	// ListEntityMerges returns the audit log, oldest first.
+	ListEntityMerges(ctx context.Context) ([]EntityMerge, error)
+}
+
+// DescriptionHistory keeps the descriptions merged into each node, for description
+// merging and its audit trail.
+type DescriptionHistory interface {
+	// CurrentDescriptions returns the stored descriptions of the nodes with the given IDs,
+	// by node ID, without counting as an access. Unknown nodes are left out.
+	CurrentDescriptions(ctx context.Context, ids []string) (map[string]string, error)
+
+	// RecordDescriptionMerges adds the descriptions of merges to their nodes' history.
+	RecordDescriptionMerges(ctx context.Context, merges []DescriptionMerge) error
+
+	// NodeDescriptions returns a node's description history, oldest first.
+	NodeDescriptions(ctx context.Context, nodeID string) ([]NodeDescription, error)
+}
+
+// ChunkStore keeps the text of document chunks with their embeddings, for chunk search
+// and citing the text behind edges.
+type ChunkStore interface {
+	// AddChunk stores a chunk of a recorded document with its embedding, replacing an
+	// earlier version.
+	AddChunk(ctx context.Context, chunk *Chunk, embedding []float32) error
+
+	// SearchChunks returns the chunks most similar to the query embedding, best first,
+	// limited to documents with one of sources when given.
+	SearchChunks(ctx context.Context, query []float32, topK int, sources []string) ([]ChunkMatch, error)
+
+	// ChunksByID returns the chunks with the given IDs; unknown IDs are left out.
+	ChunksByID(ctx context.Context, ids []string) ([]*Chunk, error)
+
+	// IterateChunks calls fn with each stored chunk, stopping at the first error.
+	IterateChunks(ctx context.Context, fn func(*Chunk) error) error
+}
+
+// SourceIndex resolves the nodes derived from each source, for source filters.
+type SourceIndex interface {
+	// SourceNodeIDs returns the nodes derived from documents or memories with one of the
+	// given sources.
+	SourceNodeIDs(ctx context.Context, sources []string) (map[string]bool, error)
+}
+
+// QuarantineStore holds nodes derived from flagged content out of search until reviewed.
+type QuarantineStore interface {
+	// AddQuarantine records a quarantine over q.NodeIDs, setting ID and CreatedAt if empty.
+	AddQuarantine(ctx context.Context, q *Quarantine) error
+
+	// ListQuarantines returns the open quarantines, oldest first.
+	ListQuarantines(ctx context.Context) ([]Quarantine, error)
+
+	// ReleaseQuarantine closes a quarantine.
+	ReleaseQuarantine(ctx context.Context, id string) error
+
+	// QuarantinedAmong returns those of nodeIDs held by any open quarantine.
+	QuarantinedAmong(ctx context.Context, nodeIDs []string) (map[string]bool, error)
+}
+
+// ImportTracker remembers which files were imported as which documents, for incremental
+// directory imports.
+type ImportTracker interface {
+	// ImportedFiles returns the files imported from the directory root, as the hash of
+	// the document each was imported as keyed by path.
+	ImportedFiles(ctx context.Context, root string) (map[string]string, error)
+
+	// RecordImportedFile records that the file at path under root was imported as the
+	// document with the given hash.
+	RecordImportedFile(ctx context.Context, root, path, hash string) error
+
+	// ForgetImportedFile removes the record of an imported file.
+	ForgetImportedFile(ctx context.Context, root, path string) error
+}
+
+// Housekeeper records when housekeeping passes ran, for Stats.
+type Housekeeper interface {
+	// RecordHousekeeping records that a housekeeping pass ran at the given time.
+	RecordHousekeeping(ctx context.Context, pass string, at time.Time) error
+
+	// LastHousekeeping returns when each pass last ran; passes that never ran are left out.
+	LastHousekeeping(ctx context.Context) (map[string]time.Time, error)
+}
+
+// GraphStatistics summarizes the stored graph, for Stats.
+type GraphStatistics interface {
+	// NodeCountsByType returns the number of nodes of each type.
+	NodeCountsByType(ctx context.Context) (map[string]int64, error)
+
+	// EdgeCountsByRelation returns the number of edges with each relation.
+	EdgeCountsByRelation(ctx context.Context) (map[string]int64, error)
+
+	// DatabaseSize returns the size of the underlying storage in bytes.
+	DatabaseSize(ctx context.Context) (int64, error)
+}
+
+// Compile-time interface checks
+var (
+	_ GraphStore      = (*SQLiteGraphStore)(nil)
+	_ AccessTracker   = (*SQLiteGraphStore)(nil)
+	_ MentionCounter  = (*SQLiteGraphStore)(nil)
+	_ BulkReader      = (*SQLiteGraphStore)(nil)
+	_ NodeBatchReader = (*SQLiteGraphStore)(nil)
+	_ NodeScanner     = (*SQLiteGraphStore)(nil)
+	_ NodePruner      = (*SQLiteGraphStore)(nil)
+	_ Deleter         = (*SQLiteGraphStore)(nil)
+	_ BulkWriter      = (*SQLiteGraphStore)(nil)
+	_ DepthTraverser  = (*SQLiteGraphStore)(nil)
+	_ PathFinder      = (*SQLiteGraphStore)(nil)
+	_ CommunityStore  = (*SQLiteGraphStore)(nil)
+	_ KeywordSearcher = (*SQLiteGraphStore)(nil)
+	_ GraphQuerier    = (*SQLiteGraphStore)(nil)
+
+	_ EdgeReader          = (*SQLiteGraphStore)(nil)
+	_ EdgeExpirer         = (*SQLiteGraphStore)(nil)
+	_ AccessFlusher       = (*SQLiteGraphStore)(nil)
+	_ NodePinner          = (*SQLiteGraphStore)(nil)
+	_ NodeEmbeddingWriter = (*SQLiteGraphStore)(nil)
+	_ EmbeddingRegistry   = (*SQLiteGraphStore)(nil)
+	_ Snapshotter         = (*SQLiteGraphStore)(nil)
+	_ GraphDiffer         = (*SQLiteGraphStore)(nil)
+	_ Outbox              = (*SQLiteGraphStore)(nil)
+	_ RelationMerger      = (*SQLiteGraphStore)(nil)
+	_ EntityMerger        = (*SQLiteGraphStore)(nil)
+	_ DescriptionHistory  = (*SQLiteGraphStore)(nil)
+	_ ChunkStore          = (*SQLiteGraphStore)(nil)
+	_ SourceIndex         = (*SQLiteGraphStore)(nil)
+	_ QuarantineStore     = (*SQLiteGraphStore)(nil)
+	_ ImportTracker       = (*SQLiteGraphStore)(nil)
+	_ Housekeeper         = (*SQLiteGraphStore)(nil)
+	_ GraphStatistics     = (*SQLiteGraphStore)(nil)
+
+	_ FilteredVectorSearcher = (*SQLiteVectorStore)(nil)
+
+	_ SupersessionResolver = (*SQLiteMemoryStore)(nil)
+)
@@ -0,0 +1,309 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// Chunk is a span of document text kept with its embedding, so search can return the
+// text behind the graph alongside the nodes extracted from it.
+type Chunk struct {
+	ID           string    `json:"id"`            // Chunk ID, unique within its document
+	DocumentHash string    `json:"document_hash"` // SHA-256 of the document text
+	Source       string    `json:"source,omitempty"`
+	Index        int       `json:"index"` // Position of the chunk in its document
+	Text         string    `json:"text"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// StartOffset and EndOffset are the rune offsets of the chunk in its document text
+	// (end exclusive), for citing and highlighting the original passage.
+	StartOffset int `json:"start_offset"`
+	EndOffset   int `json:"end_offset"`
+}
+
+// ChunkMatch is a chunk found by SearchChunks.
+type ChunkMatch struct {
+	Chunk *Chunk  `json:"chunk"`
+	Score float64 `json:"score"` // Similarity of the query to the chunk text
+}
+
+// migrateChunkSchema adds the chunks table and its vec0 index, sized like the node index.
+// A chunk's row ID is its rowid in vec_chunks; chunks go with their document.
+func (s *SQLiteGraphStore) migrateChunkSchema() error {
+	dimensions, err := vecTableDimensions(context.Background(), s.db, "vec_nodes")
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS chunks (
+			id INTEGER PRIMARY KEY,
+			document_id TEXT NOT NULL,
+			chunk_id TEXT NOT NULL,
+			namespace TEXT NOT NULL DEFAULT '',
+			chunk_index INTEGER NOT NULL DEFAULT 0,
+			text TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			UNIQUE (document_id, chunk_id),
+			FOREIGN KEY (document_id) REFERENCES documents(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_chunks_namespace ON chunks(namespace);
+	`); err != nil {
+		return fmt.Errorf("failed to create chunks table: %w", err)
+	}
+	if _, err := s.db.Exec(fmt.Sprintf(`CREATE VIRTUAL TABLE IF NOT EXISTS vec_chunks USING vec0(embedding float[%d])`, dimensions)); err != nil {
+		return fmt.Errorf("failed to create vec_chunks table: %w", err)
+	}
+	return nil
+}
+
+// migrateChunkOffsets adds the rune offsets of each chunk in its document. Chunks stored
+// before have offsets of 0.
+func (s *SQLiteGraphStore) migrateChunkOffsets() error {
+	return s.addColumns("chunks", [][2]string{
+		{"start_offset", "INTEGER NOT NULL DEFAULT 0"},
+		{"end_offset", "INTEGER NOT NULL DEFAULT 0"},
+	})
+}
+
+// AddChunk stores a chunk of a recorded document with its embedding, replacing the text
+// and embedding stored for the same chunk before. The text is encrypted with the store's
+// cipher (see WithEncryption). Returns ErrDocumentNotFound when the document has not been
+// recorded (see LinkDocument).
+func (s *SQLiteGraphStore) AddChunk(ctx context.Context, chunk *Chunk, embedding []float32) error {
+	if len(embedding) == 0 {
+		return fmt.Errorf("embedding cannot be empty")
+	}
+	if err := checkVecDimensions(ctx, s.db, "vec_chunks", "chunks", len(embedding), true); err != nil {
+		return err
+	}
+	if chunk.CreatedAt.IsZero() {
+		chunk.CreatedAt = time.Now()
+	}
+	text, err := s.cipher.Encrypt(chunk.Text)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt chunk text: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	documentID := s.documentKey(chunk.DocumentHash)
+	var exists int
+	err = tx.QueryRowContext(ctx, `SELECT 1 FROM documents WHERE id = ?`, documentID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return ErrDocumentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check document: %w", err)
+	}
+
+	var rowid int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO chunks (document_id, chunk_id, namespace, chunk_index, text, start_offset, end_offset, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (document_id, chunk_id) DO UPDATE SET chunk_index = excluded.chunk_index, text = excluded.text,
+			start_offset = excluded.start_offset, end_offset = excluded.end_offset
+		RETURNING id
+	`, documentID, chunk.ID, s.namespace, chunk.Index, text, chunk.StartOffset, chunk.EndOffset, chunk.CreatedAt.UTC()).Scan(&rowid)
+	if err != nil {
+		return fmt.Errorf("failed to store chunk: %w", classifyWriteError(err))
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM vec_chunks WHERE rowid = ?`, rowid); err != nil {
+		return fmt.Errorf("failed to delete old vec_chunks entry: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO vec_chunks (rowid, embedding) VALUES (?, ?)`, rowid, serializeEmbedding(embedding)); err != nil {
+		return fmt.Errorf("failed to insert into vec_chunks: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", classifyWriteError(err))
+	}
+	return nil
+}
+
+// SearchChunks returns the namespace's chunks most similar to the query embedding, best
+// match first. With sources, only chunks of documents with one of them are returned.
+// Chunks of quarantined documents are skipped until the quarantine is released.
+func (s *SQLiteGraphStore) SearchChunks(ctx context.Context, query []float32, topK int, sources []string) ([]ChunkMatch, error) {
+	start := time.Now()
+	if len(query) == 0 || topK <= 0 {
+		return []ChunkMatch{}, nil
+	}
+	if err := checkVecDimensions(ctx, s.db, "vec_chunks", "chunks", len(query), false); err != nil {
+		return nil, err
+	}
+
+	queryBlob := serializeEmbedding(query)
+
+	// Filters apply after the KNN step, so widen k until enough results are found
+	k := topK
+	for {
+		matches, candidates, err := s.searchChunksK(ctx, queryBlob, k, sources)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) >= topK || candidates < k {
+			if len(matches) > topK {
+				matches = matches[:topK]
+			}
+			s.logQuery(ctx, "search_chunks", start, slog.Int("k", k), slog.Int("matches", len(matches)))
+			return matches, nil
+		}
+		k *= 4
+	}
+}
+
+// searchChunksK runs one vec0 KNN query and returns the matching chunks in the store's
+// namespace, along with the number of KNN candidates before filtering.
+func (s *SQLiteGraphStore) searchChunksK(ctx context.Context, queryBlob []byte, k int, sources []string) ([]ChunkMatch, int, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT c.chunk_id, c.namespace, c.chunk_index, c.text, c.start_offset, c.end_offset, c.created_at, d.hash, d.source, v.distance,
+			EXISTS (SELECT 1 FROM quarantines q
+				WHERE q.kind = 'document' AND q.reference = d.hash AND q.namespace = c.namespace)
+		FROM vec_chunks v
+		LEFT JOIN chunks c ON c.id = v.rowid
+		LEFT JOIN documents d ON d.id = c.document_id
+		WHERE v.embedding MATCH ? AND k = ?
+		ORDER BY v.distance
+	`, queryBlob, k)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to execute vec0 chunk search: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []ChunkMatch
+	candidates := 0
+	for rows.Next() {
+		var chunkID, namespace, text, hash, source sql.NullString
+		var index, startOffset, endOffset sql.NullInt64
+		var createdAt sql.NullTime
+		var distance float64
+		var quarantined bool
+		if err := rows.Scan(&chunkID, &namespace, &index, &text, &startOffset, &endOffset, &createdAt, &hash, &source, &distance, &quarantined); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan chunk search result: %w", err)
+		}
+		candidates++
+
+		// Skip vectors whose chunk was deleted or belongs elsewhere
+		if !chunkID.Valid || !hash.Valid || namespace.String != s.namespace || quarantined {
+			continue
+		}
+		if len(sources) > 0 && !slices.Contains(sources, source.String) {
+			continue
+		}
+		plaintext, err := s.cipher.Decrypt(text.String)
+		if err != nil {
+			return nil, 0, err
+		}
+		matches = append(matches, ChunkMatch{
+			Chunk: &Chunk{
+				ID:           chunkID.String,
+				DocumentHash: hash.String,
+				Source:       source.String,
+				Index:        int(index.Int64),
+				Text:         plaintext,
+				StartOffset:  int(startOffset.Int64),
+				EndOffset:    int(endOffset.Int64),
+				CreatedAt:    createdAt.Time,
+			},
+			Score: 1.0 - distance,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating chunk search results: %w", err)
+	}
+	return matches, candidates, nil
+}
+
+// IterateChunks calls fn with each of the namespace's stored chunks, ordered by document
+// and position. Returning an error from fn stops the iteration with that error.
+func (s *SQLiteGraphStore) IterateChunks(ctx context.Context, fn func(*Chunk) error) error {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+chunkColumns+`
+		FROM chunks c
+		JOIN documents d ON d.id = c.document_id
+		WHERE c.namespace = ?
+		ORDER BY d.added_at, d.hash, c.chunk_index
+	`, s.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to query chunks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		chunk, err := s.scanChunk(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(chunk); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating chunks: %w", err)
+	}
+	return nil
+}
+
+// chunkColumns are the columns scanChunk reads, from chunks c joined with documents d
+const chunkColumns = `c.chunk_id, c.chunk_index, c.text, c.start_offset, c.end_offset, c.created_at, d.hash, d.source`
+
+// scanChunk scans a row of chunkColumns and decrypts the chunk text
+func (s *SQLiteGraphStore) scanChunk(rows *sql.Rows) (*Chunk, error) {
+	chunk := &Chunk{}
+	if err := rows.Scan(&chunk.ID, &chunk.Index, &chunk.Text, &chunk.StartOffset, &chunk.EndOffset, &chunk.CreatedAt,
+		&chunk.DocumentHash, &chunk.Source); err != nil {
+		return nil, fmt.Errorf("failed to scan chunk: %w", err)
+	}
+	text, err := s.cipher.Decrypt(chunk.Text)
+	if err != nil {
+		return nil, err
+	}
+	chunk.Text = text
+	return chunk, nil
+}
+
+// ChunksByID returns the namespace's stored chunks with the given IDs, ordered by
+// document and position. Unknown IDs and chunks of quarantined documents are left out.
+func (s *SQLiteGraphStore) ChunksByID(ctx context.Context, ids []string) ([]*Chunk, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	args := []interface{}{s.namespace}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+chunkColumns+`
+		FROM chunks c
+		JOIN documents d ON d.id = c.document_id
+		WHERE c.namespace = ? AND c.chunk_id IN (`+sqlPlaceholders(len(ids))+`)
+			AND NOT EXISTS (SELECT 1 FROM quarantines q
+				WHERE q.kind = 'document' AND q.reference = d.hash AND q.namespace = c.namespace)
+		ORDER BY d.added_at, d.hash, c.chunk_index
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query chunks: %w", err)
+	}
+	defer rows.Close()
+
+	var chunks []*Chunk
+	for rows.Next() {
+		chunk, err := s.scanChunk(rows)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating chunks: %w", err)
+	}
+	return chunks, nil
+}
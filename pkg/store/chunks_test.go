@@ -0,0 +1,131 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestChunks_AddSearchDelete(t *testing.T) {
+	s, other := openNamespacedStores(t)
+	ctx := context.Background()
+
+	for _, doc := range []Document{{Hash: "h1", Source: "one.md"}, {Hash: "h2", Source: "two.md"}} {
+		if err := s.LinkDocument(ctx, doc, nil, nil, nil); err != nil {
+			t.Fatalf("LinkDocument failed: %v", err)
+		}
+	}
+	chunks := []struct {
+		chunk     Chunk
+		embedding []float32
+	}{
+		{Chunk{ID: "c1", DocumentHash: "h1", Index: 0, Text: "Alice writes Go."}, []float32{1, 0, 0, 0}},
+		{Chunk{ID: "c2", DocumentHash: "h1", Index: 1, Text: "Bob manages Alice.", StartOffset: 17, EndOffset: 35}, []float32{0.8, 0.6, 0, 0}},
+		{Chunk{ID: "c1", DocumentHash: "h2", Index: 0, Text: "Carol designs."}, []float32{0, 0, 1, 0}},
+	}
+	for _, c := range chunks {
+		if err := s.AddChunk(ctx, &c.chunk, c.embedding); err != nil {
+			t.Fatalf("AddChunk failed: %v", err)
+		}
+	}
+	if err := s.AddChunk(ctx, &Chunk{ID: "c9", DocumentHash: "missing", Text: "x"}, []float32{1, 0, 0, 0}); !errors.Is(err, ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound for an unrecorded document, got %v", err)
+	}
+
+	matches, err := s.SearchChunks(ctx, []float32{1, 0, 0, 0}, 2, nil)
+	if err != nil {
+		t.Fatalf("SearchChunks failed: %v", err)
+	}
+	if len(matches) != 2 || matches[0].Chunk.Text != "Alice writes Go." || matches[1].Chunk.ID != "c2" {
+		t.Fatalf("unexpected matches %+v", matches)
+	}
+	if matches[0].Chunk.Source != "one.md" || matches[0].Chunk.DocumentHash != "h1" || matches[0].Score < matches[1].Score {
+		t.Errorf("unexpected best match %+v (score %g)", matches[0].Chunk, matches[0].Score)
+	}
+	if matches[1].Chunk.StartOffset != 17 || matches[1].Chunk.EndOffset != 35 {
+		t.Errorf("expected c2's offsets [17, 35), got [%d, %d)", matches[1].Chunk.StartOffset, matches[1].Chunk.EndOffset)
+	}
+	if found, err := s.ChunksByID(ctx, []string{"c2", "missing"}); err != nil || len(found) != 1 || found[0].EndOffset != 35 || found[0].Text != "Bob manages Alice." {
+		t.Errorf("expected ChunksByID to return c2, got %+v (err %v)", found, err)
+	}
+	if matches, _ := s.SearchChunks(ctx, []float32{1, 0, 0, 0}, 5, []string{"two.md"}); len(matches) != 1 || matches[0].Chunk.Text != "Carol designs." {
+		t.Errorf("expected only two.md's chunk, got %+v", matches)
+	}
+	if matches, _ := other.SearchChunks(ctx, []float32{1, 0, 0, 0}, 5, nil); len(matches) != 0 {
+		t.Errorf("expected another namespace to find no chunks, got %+v", matches)
+	}
+
+	// Chunks of a quarantined document are hidden until it is released
+	quarantine := &Quarantine{Kind: "document", Reference: "h2", Source: "two.md"}
+	if err := s.AddQuarantine(ctx, quarantine); err != nil {
+		t.Fatalf("AddQuarantine failed: %v", err)
+	}
+	if matches, _ := s.SearchChunks(ctx, []float32{0, 0, 1, 0}, 5, nil); len(matches) != 2 || matches[0].Chunk.DocumentHash != "h1" {
+		t.Errorf("expected only h1's chunks while h2 is quarantined, got %+v", matches)
+	}
+	if err := s.ReleaseQuarantine(ctx, quarantine.ID); err != nil {
+		t.Fatalf("ReleaseQuarantine failed: %v", err)
+	}
+	if matches, _ := s.SearchChunks(ctx, []float32{0, 0, 1, 0}, 1, nil); len(matches) != 1 || matches[0].Chunk.DocumentHash != "h2" {
+		t.Errorf("expected h2's chunk after the release, got %+v", matches)
+	}
+
+	// Storing a chunk again replaces its text
+	if err := s.AddChunk(ctx, &Chunk{ID: "c1", DocumentHash: "h1", Text: "Alice writes Rust."}, []float32{1, 0, 0, 0}); err != nil {
+		t.Fatalf("AddChunk failed: %v", err)
+	}
+	var texts []string
+	if err := s.IterateChunks(ctx, func(chunk *Chunk) error {
+		texts = append(texts, chunk.Text)
+		return nil
+	}); err != nil {
+		t.Fatalf("IterateChunks failed: %v", err)
+	}
+	if len(texts) != 3 || texts[0] != "Alice writes Rust." {
+		t.Errorf("unexpected chunks %v", texts)
+	}
+
+	// Chunks and their vectors go with their document
+	if _, _, err := s.DeleteDocument(ctx, "h1", false); err != nil {
+		t.Fatalf("DeleteDocument failed: %v", err)
+	}
+	if matches, _ := s.SearchChunks(ctx, []float32{1, 0, 0, 0}, 5, nil); len(matches) != 1 || matches[0].Chunk.DocumentHash != "h2" {
+		t.Errorf("expected only h2's chunk left, got %+v", matches)
+	}
+	var vectors int
+	if err := s.DB().QueryRow(`SELECT COUNT(*) FROM vec_chunks`).Scan(&vectors); err != nil || vectors != 1 {
+		t.Errorf("expected 1 chunk vector left, got %d (err %v)", vectors, err)
+	}
+}
+
+func TestChunks_Encrypted(t *testing.T) {
+	s, _ := openNamespacedStores(t)
+	ctx := context.Background()
+	c, _ := NewFieldCipher(bytes.Repeat([]byte{7}, 32))
+	s.WithEncryption(c)
+
+	if err := s.LinkDocument(ctx, Document{Hash: "h1", Source: "one.md"}, nil, nil, nil); err != nil {
+		t.Fatalf("LinkDocument failed: %v", err)
+	}
+	if err := s.AddChunk(ctx, &Chunk{ID: "c1", DocumentHash: "h1", Text: "secret plans"}, []float32{1, 0, 0, 0}); err != nil {
+		t.Fatalf("AddChunk failed: %v", err)
+	}
+
+	var stored string
+	if err := s.DB().QueryRow(`SELECT text FROM chunks`).Scan(&stored); err != nil || strings.Contains(stored, "secret") {
+		t.Errorf("expected ciphertext in the chunks table, got %q (err %v)", stored, err)
+	}
+	if matches, err := s.SearchChunks(ctx, []float32{1, 0, 0, 0}, 1, nil); err != nil || len(matches) != 1 || matches[0].Chunk.Text != "secret plans" {
+		t.Errorf("SearchChunks = %+v, %v", matches, err)
+	}
+	if err := s.IterateChunks(ctx, func(chunk *Chunk) error {
+		if chunk.Text != "secret plans" {
+			t.Errorf("IterateChunks returned %q", chunk.Text)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("IterateChunks failed: %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Community is a cluster of densely connected nodes found by community detection.
+type Community struct {
+	ID   string `json:"id"`   // Stable for unchanged membership: the smallest member node ID
+	Size int    `json:"size"` // Number of member nodes
+	// Representatives are the best-connected members, most edges first.
+	Representatives []*Node `json:"representatives"`
+}
+
+// migrateCommunitySchema adds the nodes.community_id column.
+func (s *SQLiteGraphStore) migrateCommunitySchema() error {
+	if err := s.addColumn("nodes", "community_id", "TEXT DEFAULT NULL"); err != nil {
+		return err
+	}
+	if _, err := s.db.Exec("CREATE INDEX IF NOT EXISTS idx_nodes_community ON nodes(namespace, community_id)"); err != nil {
+		return fmt.Errorf("failed to create community index: %w", err)
+	}
+	return nil
+}
+
+// SetCommunities replaces the community assignments of the namespace: nodes in
+// assignments (node ID to community ID) get that community, all others none.
+func (s *SQLiteGraphStore) SetCommunities(ctx context.Context, assignments map[string]string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "UPDATE nodes SET community_id = NULL WHERE namespace = ? AND community_id IS NOT NULL", s.namespace); err != nil {
+		return fmt.Errorf("failed to clear communities: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, "UPDATE nodes SET community_id = ? WHERE id = ? AND namespace = ?")
+	if err != nil {
+		return fmt.Errorf("failed to prepare community update: %w", err)
+	}
+	defer stmt.Close()
+	for nodeID, communityID := range assignments {
+		if _, err := stmt.ExecContext(ctx, communityID, nodeID, s.namespace); err != nil {
+			return fmt.Errorf("failed to assign community: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit communities: %w", err)
+	}
+	return nil
+}
+
+// ListCommunities returns the communities of the namespace, largest first, each with up
+// to representatives of its best-connected members (default 3).
+func (s *SQLiteGraphStore) ListCommunities(ctx context.Context, representatives int) ([]Community, error) {
+	if representatives <= 0 {
+		representatives = 3
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT community_id, id, size FROM (
+			SELECT community_id, id,
+				COUNT(*) OVER (PARTITION BY community_id) AS size,
+				ROW_NUMBER() OVER (
+					PARTITION BY community_id
+					ORDER BY degree DESC, mention_count DESC, id
+				) AS rank
+			FROM (
+				SELECT n.community_id, n.id, n.mention_count,
+					(SELECT COUNT(*) FROM edges e
+						WHERE (e.source_id = n.id OR e.target_id = n.id)
+						AND e.namespace = n.namespace
+						AND (e.expires_at IS NULL OR e.expires_at > ?)) AS degree
+				FROM nodes n
+				WHERE n.namespace = ? AND n.community_id IS NOT NULL
+			)
+		)
+		WHERE rank <= ?
+		ORDER BY size DESC, community_id, rank
+	`, time.Now().UTC(), s.namespace, representatives)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list communities: %w", err)
+	}
+
+	var communities []Community
+	var memberIDs [][]string
+	for rows.Next() {
+		var communityID, nodeID string
+		var size int
+		if err := rows.Scan(&communityID, &nodeID, &size); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan community: %w", err)
+		}
+		if len(communities) == 0 || communities[len(communities)-1].ID != communityID {
+			communities = append(communities, Community{ID: communityID, Size: size})
+			memberIDs = append(memberIDs, nil)
+		}
+		memberIDs[len(memberIDs)-1] = append(memberIDs[len(memberIDs)-1], nodeID)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("error iterating communities: %w", err)
+	}
+	rows.Close()
+
+	// Load representatives once rows are closed, so GetNode does not need a second connection
+	for i, ids := range memberIDs {
+		for _, id := range ids {
+			node, err := s.GetNode(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if node != nil {
+				communities[i].Representatives = append(communities[i].Representatives, node)
+			}
+		}
+	}
+	return communities, nil
+}
+
+// CommunityNodeIDs returns the IDs of the members of a community, sorted.
+func (s *SQLiteGraphStore) CommunityNodeIDs(ctx context.Context, communityID string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT id FROM nodes WHERE namespace = ? AND community_id = ? ORDER BY id",
+		s.namespace, communityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list community members: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan community member: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating community members: %w", err)
+	}
+	return ids, nil
+}
+
+// NodeCommunity returns the community of a node, or "" if it has none.
+func (s *SQLiteGraphStore) NodeCommunity(ctx context.Context, nodeID string) (string, error) {
+	var communityID sql.NullString
+	err := s.db.QueryRowContext(ctx,
+		"SELECT community_id FROM nodes WHERE id = ? AND namespace = ?", nodeID, s.namespace).Scan(&communityID)
+	if err == sql.ErrNoRows {
+		return "", ErrNodeNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get node community: %w", err)
+	}
+	return communityID.String, nil
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSetCommunities_ListAndMembers(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	for _, id := range []string{"a", "b", "c", "x", "y", "lonely"} {
+		if err := store.AddNode(ctx, &Node{ID: id, Name: id, Type: "Concept"}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	for _, edge := range []*Edge{
+		{ID: "ab", SourceID: "a", Relation: "R", TargetID: "b"},
+		{ID: "bc", SourceID: "b", Relation: "R", TargetID: "c"},
+		{ID: "xy", SourceID: "x", Relation: "R", TargetID: "y"},
+	} {
+		if err := store.AddEdge(ctx, edge); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+
+	if err := store.SetCommunities(ctx, map[string]string{"a": "a", "b": "a", "c": "a", "x": "x", "y": "x"}); err != nil {
+		t.Fatalf("SetCommunities failed: %v", err)
+	}
+
+	communities, err := store.ListCommunities(ctx, 1)
+	if err != nil {
+		t.Fatalf("ListCommunities failed: %v", err)
+	}
+	if len(communities) != 2 {
+		t.Fatalf("Expected 2 communities, got %d", len(communities))
+	}
+	if communities[0].ID != "a" || communities[0].Size != 3 {
+		t.Errorf("Expected largest community a of size 3, got %s of size %d", communities[0].ID, communities[0].Size)
+	}
+	if len(communities[0].Representatives) != 1 || communities[0].Representatives[0].ID != "b" {
+		t.Errorf("Expected b (most edges) to represent community a, got %+v", communities[0].Representatives)
+	}
+
+	members, err := store.CommunityNodeIDs(ctx, "x")
+	if err != nil {
+		t.Fatalf("CommunityNodeIDs failed: %v", err)
+	}
+	if len(members) != 2 || members[0] != "x" || members[1] != "y" {
+		t.Errorf("Expected members [x y], got %v", members)
+	}
+
+	// Re-adding a node keeps its community
+	if err := store.AddNode(ctx, &Node{ID: "a", Name: "a", Type: "Concept", Description: "updated"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if community, err := store.NodeCommunity(ctx, "a"); err != nil || community != "a" {
+		t.Errorf("Expected community a after upsert, got %q (err %v)", community, err)
+	}
+
+	// Replacing the assignments clears nodes left out
+	if err := store.SetCommunities(ctx, map[string]string{"x": "x", "y": "x"}); err != nil {
+		t.Fatalf("SetCommunities failed: %v", err)
+	}
+	if community, _ := store.NodeCommunity(ctx, "a"); community != "" {
+		t.Errorf("Expected no community for a, got %q", community)
+	}
+}
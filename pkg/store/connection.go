@@ -0,0 +1,99 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// defaultBusyTimeoutMs is how long a connection waits for a lock held by another
+// connection before failing with "database is locked".
+const defaultBusyTimeoutMs = "5000"
+
+// withConnectionPragmas adds the driver options every pooled connection is opened with,
+// unless the DSN already sets them:
+//   - _foreign_keys=1, required for the ON DELETE CASCADE links
+//   - _busy_timeout=5000, so concurrent writers wait instead of failing
+//   - _journal_mode=WAL for file databases opened read-write, so readers (Search) do not
+//     block on a writer (Cognify)
+func withConnectionPragmas(dsn string) string {
+	_, query, _ := strings.Cut(dsn, "?")
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return dsn // ResolveDBPath has already rejected malformed options
+	}
+
+	var extra []string
+	if !hasAnyParam(params, "_foreign_keys", "_fk") {
+		extra = append(extra, "_foreign_keys=1")
+	}
+	if !hasAnyParam(params, "_busy_timeout", "_timeout") {
+		extra = append(extra, "_busy_timeout="+defaultBusyTimeoutMs)
+	}
+	if !IsInMemoryDSN(dsn) && params.Get("mode") != "ro" && !hasAnyParam(params, "_journal_mode", "_journal") {
+		extra = append(extra, "_journal_mode=WAL")
+	}
+	if len(extra) == 0 {
+		return dsn
+	}
+
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	return dsn + separator + strings.Join(extra, "&")
+}
+
+// hasAnyParam reports whether params sets any of keys
+func hasAnyParam(params url.Values, keys ...string) bool {
+	for _, key := range keys {
+		if _, ok := params[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// stmtCache holds prepared statements for hot-path queries, so they are compiled once per
+// connection instead of on every call. It is safe for concurrent use.
+type stmtCache struct {
+	mu    sync.Mutex
+	stmts map[string]*sql.Stmt
+}
+
+// prepare returns the cached statement for query, preparing it on first use.
+func (c *stmtCache) prepare(ctx context.Context, db *sql.DB, query string) (*sql.Stmt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if stmt, ok := c.stmts[query]; ok {
+		return stmt, nil
+	}
+	stmt, err := db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	if c.stmts == nil {
+		c.stmts = make(map[string]*sql.Stmt)
+	}
+	c.stmts[query] = stmt
+	return stmt, nil
+}
+
+// close releases every cached statement.
+func (c *stmtCache) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var firstErr error
+	for query, stmt := range c.stmts {
+		if err := stmt.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(c.stmts, query)
+	}
+	return firstErr
+}
@@ -0,0 +1,102 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestWithConnectionPragmas(t *testing.T) {
+	tests := []struct {
+		dsn  string
+		want string
+	}{
+		{":memory:", ":memory:?_foreign_keys=1&_busy_timeout=5000"},
+		{"/data/g.db", "/data/g.db?_foreign_keys=1&_busy_timeout=5000&_journal_mode=WAL"},
+		{"/data/g.db?_busy_timeout=100", "/data/g.db?_busy_timeout=100&_foreign_keys=1&_journal_mode=WAL"},
+		{"file:/data/g.db?mode=ro", "file:/data/g.db?mode=ro&_foreign_keys=1&_busy_timeout=5000"},
+		{"/data/g.db?_journal_mode=DELETE&_fk=0&_timeout=1", "/data/g.db?_journal_mode=DELETE&_fk=0&_timeout=1"},
+	}
+	for _, tt := range tests {
+		if got := withConnectionPragmas(tt.dsn); got != tt.want {
+			t.Errorf("withConnectionPragmas(%q) = %q, want %q", tt.dsn, got, tt.want)
+		}
+	}
+}
+
+func TestNewSQLiteGraphStore_ConnectionPragmas(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(filepath.Join(t.TempDir(), "gognee.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	// Hold several connections at once so the pragmas are checked on distinct connections
+	for i := 0; i < 3; i++ {
+		conn, err := graphStore.DB().Conn(ctx)
+		if err != nil {
+			t.Fatalf("Conn failed: %v", err)
+		}
+		defer conn.Close()
+
+		var journalMode string
+		var foreignKeys int
+		if err := conn.QueryRowContext(ctx, "PRAGMA journal_mode").Scan(&journalMode); err != nil {
+			t.Fatalf("PRAGMA journal_mode failed: %v", err)
+		}
+		if err := conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+			t.Fatalf("PRAGMA foreign_keys failed: %v", err)
+		}
+		if journalMode != "wal" || foreignKeys != 1 {
+			t.Errorf("Connection %d: journal_mode=%s foreign_keys=%d, want wal and 1", i, journalMode, foreignKeys)
+		}
+	}
+}
+
+func TestSQLiteGraphStore_ConcurrentWritesAndReads(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(filepath.Join(t.TempDir(), "gognee.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 25; i++ {
+				id := fmt.Sprintf("n-%d-%d", w, i)
+				if err := graphStore.AddNode(ctx, &Node{ID: id, Name: id, Type: "Concept"}); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := graphStore.GetNode(ctx, id); err != nil {
+					errs <- err
+					return
+				}
+				if err := graphStore.UpdateAccessTime(ctx, []string{id}); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Concurrent access failed: %v", err)
+	}
+
+	graphStore.stmts.mu.Lock()
+	cached := len(graphStore.stmts.stmts)
+	graphStore.stmts.mu.Unlock()
+	if cached != 3 {
+		t.Errorf("Expected 3 cached statements (AddNode, GetNode, UpdateAccessTime), got %d", cached)
+	}
+}
@@ -0,0 +1,166 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// ErrReadOnlyDatabase indicates that the database file or its directory cannot be written.
+// Open the database with the "mode=ro" option for read-only access.
+var ErrReadOnlyDatabase = errors.New("database location is read-only")
+
+// IsInMemoryDSN reports whether dbPath names an in-memory database: "", ":memory:", or a
+// file: URI for ":memory:" or with mode=memory.
+func IsInMemoryDSN(dbPath string) bool {
+	if dbPath == "" || dbPath == ":memory:" {
+		return true
+	}
+	if !strings.HasPrefix(dbPath, "file:") {
+		return false
+	}
+	path, query, _ := strings.Cut(strings.TrimPrefix(dbPath, "file:"), "?")
+	if path == ":memory:" {
+		return true
+	}
+	params, err := url.ParseQuery(query)
+	return err == nil && params.Get("mode") == "memory"
+}
+
+// ResolveDBPath turns a database path into the DSN passed to the SQLite driver, so the
+// same DBPath works on every OS:
+//   - a leading "~" is expanded to the user's home directory
+//   - options after "?" are kept; SQLite URI options such as cache=shared or mode=ro turn
+//     the DSN into a file: URI, and driver options (_busy_timeout, _journal_mode, ...)
+//     pass through unchanged
+//   - the parent directory is created, unless the database is opened with mode=ro
+//   - a database that cannot be written fails early with ErrReadOnlyDatabase
+//
+// In-memory databases (see IsInMemoryDSN) are returned unchanged, with "" becoming ":memory:".
+func ResolveDBPath(dbPath string) (string, error) {
+	if dbPath == "" {
+		return ":memory:", nil
+	}
+	if IsInMemoryDSN(dbPath) {
+		return dbPath, nil
+	}
+
+	isURI := strings.HasPrefix(dbPath, "file:")
+	path, query, _ := strings.Cut(strings.TrimPrefix(dbPath, "file:"), "?")
+	if isURI {
+		path = uriFilePath(path)
+	}
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return "", fmt.Errorf("invalid database options %q: %w", query, err)
+	}
+
+	path, err = expandHome(path)
+	if err != nil {
+		return "", err
+	}
+	path = filepath.Clean(path)
+
+	if params.Get("mode") == "ro" {
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("failed to open read-only database: %w", err)
+		}
+	} else if err := ensureWritable(path); err != nil {
+		return "", err
+	}
+
+	if !isURI && !hasSQLiteURIOptions(params) {
+		if query == "" {
+			return path, nil
+		}
+		return path + "?" + query, nil
+	}
+	dsn := "file:" + escapeURIPath(path)
+	if query != "" {
+		dsn += "?" + query
+	}
+	return dsn, nil
+}
+
+// ensureWritable creates the database's parent directory and file if needed, and reports
+// ErrReadOnlyDatabase when either cannot be written.
+func ensureWritable(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		if isReadOnlyError(err) {
+			return fmt.Errorf("%w: cannot create directory for %s", ErrReadOnlyDatabase, path)
+		}
+		return fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		if isReadOnlyError(err) {
+			return fmt.Errorf("%w: %s (open it with \"?mode=ro\" for read-only access)", ErrReadOnlyDatabase, path)
+		}
+		return fmt.Errorf("failed to open database file: %w", err)
+	}
+	return f.Close()
+}
+
+// isReadOnlyError reports whether err is a permission or read-only filesystem error
+func isReadOnlyError(err error) bool {
+	return errors.Is(err, fs.ErrPermission) || errors.Is(err, syscall.EROFS)
+}
+
+// expandHome replaces a leading "~" with the user's home directory
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to expand ~ in database path: %w", err)
+	}
+	return filepath.Join(home, path[1:]), nil
+}
+
+// hasSQLiteURIOptions reports whether params contain options interpreted by SQLite itself
+// rather than the driver (whose options start with "_")
+func hasSQLiteURIOptions(params url.Values) bool {
+	for key := range params {
+		if !strings.HasPrefix(key, "_") {
+			return true
+		}
+	}
+	return false
+}
+
+// uriFilePath returns the file path of a file: URI (without the "file:" prefix and query),
+// dropping an empty or "localhost" authority and the slash before a Windows drive letter.
+func uriFilePath(path string) string {
+	if strings.HasPrefix(path, "//") {
+		rest := path[2:]
+		if strings.HasPrefix(rest, "localhost/") {
+			rest = rest[len("localhost"):]
+		}
+		path = rest
+	}
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+	return filepath.FromSlash(path)
+}
+
+// escapeURIPath formats a file path for a file: URI, using forward slashes and an empty
+// authority before Windows drive letters.
+func escapeURIPath(path string) string {
+	p := filepath.ToSlash(path)
+	p = strings.NewReplacer("%", "%25", "?", "%3f", "#", "%23").Replace(p)
+	if filepath.VolumeName(path) != "" {
+		p = "///" + p
+	}
+	return p
+}
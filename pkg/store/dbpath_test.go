@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveDBPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	tests := []struct {
+		name   string
+		dbPath string
+		want   string
+	}{
+		{"empty", "", ":memory:"},
+		{"memory", ":memory:", ":memory:"},
+		{"memory URI", "file::memory:?cache=shared", "file::memory:?cache=shared"},
+		{"home", "~/data/gognee.db", filepath.Join(home, "data", "gognee.db")},
+		{"driver options", "~/gognee.db?_busy_timeout=10000", filepath.Join(home, "gognee.db") + "?_busy_timeout=10000"},
+		{"SQLite options", "~/shared.db?cache=shared", "file:" + filepath.ToSlash(filepath.Join(home, "shared.db")) + "?cache=shared"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveDBPath(tt.dbPath)
+			if err != nil {
+				t.Fatalf("ResolveDBPath failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveDBPath(%q) = %q, want %q", tt.dbPath, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := os.Stat(filepath.Join(home, "data")); err != nil {
+		t.Errorf("Expected parent directory to be created: %v", err)
+	}
+	if !IsInMemoryDSN("file:test?mode=memory") || IsInMemoryDSN("gognee.db") {
+		t.Error("IsInMemoryDSN misclassified a DSN")
+	}
+}
+
+func TestNewSQLiteGraphStore_CreatesDirectoriesAndOpensReadOnly(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "nested", "dir", "gognee.db")
+
+	graphStore, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create store in a missing directory: %v", err)
+	}
+	if err := graphStore.AddNode(ctx, &Node{ID: "n1", Name: "Go", Type: "Technology"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	graphStore.Close()
+
+	readOnly, err := NewSQLiteGraphStore(dbPath + "?mode=ro")
+	if err != nil {
+		t.Fatalf("Failed to open store read-only: %v", err)
+	}
+	defer readOnly.Close()
+	if node, err := readOnly.GetNode(ctx, "n1"); err != nil || node.Name != "Go" {
+		t.Errorf("Expected to read node from read-only store, got %v (err %v)", node, err)
+	}
+	if err := readOnly.AddNode(ctx, &Node{ID: "n2", Name: "Rust", Type: "Technology"}); err == nil {
+		t.Error("Expected write to a read-only store to fail")
+	}
+
+	if _, err := NewSQLiteGraphStore(filepath.Join(t.TempDir(), "missing.db?mode=ro")); err == nil {
+		t.Error("Expected error opening a missing database read-only")
+	}
+}
+
+func TestResolveDBPath_ReadOnlyLocation(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("permission checks do not apply to root")
+	}
+	dir := t.TempDir()
+	if err := os.Chmod(dir, 0o555); err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+	defer os.Chmod(dir, 0o755)
+
+	_, err := ResolveDBPath(filepath.Join(dir, "gognee.db"))
+	if !errors.Is(err, ErrReadOnlyDatabase) || !strings.Contains(err.Error(), "mode=ro") {
+		t.Errorf("Expected ErrReadOnlyDatabase with a mode=ro hint, got %v", err)
+	}
+}
@@ -0,0 +1,259 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// RetentionPolicyDef defines the parameters of a memory retention policy.
+type RetentionPolicyDef struct {
+	HalfLifeDays int  // Decay half-life in days (0 = no decay for permanent)
+	Prunable     bool // Whether memories with this policy can be pruned
+}
+
+// RetentionPolicies defines the available memory retention policies. Memories with a
+// policy not listed here decay like "standard" ones.
+var RetentionPolicies = map[string]RetentionPolicyDef{
+	"permanent": {HalfLifeDays: 0, Prunable: false},  // Never decays, never pruned
+	"decision":  {HalfLifeDays: 365, Prunable: true}, // 1 year half-life, prunable only when superseded
+	"standard":  {HalfLifeDays: 90, Prunable: true},  // 3 months half-life (default)
+	"ephemeral": {HalfLifeDays: 7, Prunable: true},   // 1 week half-life
+	"session":   {HalfLifeDays: 1, Prunable: true},   // 1 day half-life
+}
+
+// MemoryReferenceAccessCount is the access count at which a memory is fully protected
+// from the access-frequency part of its decay.
+const MemoryReferenceAccessCount = 10
+
+// Decay curves for DecayPolicy.Curve. Each curve gives a score multiplier of 1 at age
+// zero and 0.5 after one half-life.
+const (
+	// DecayExponential halves the score every half-life: 0.5^(t/h).
+	DecayExponential = "exponential"
+	// DecayPowerLaw follows an Ebbinghaus-style forgetting curve, (1 + t/h)^-1: scores
+	// drop faster than exponential at first and keep a long tail.
+	DecayPowerLaw = "power_law"
+	// DecayStep keeps the full score until a half-life has passed, then halves it once per
+	// full half-life: 0.5^floor(t/h).
+	DecayStep = "step"
+)
+
+// DecayPolicy configures how node and memory scores decay with age. A nil policy uses
+// the exponential curve and the built-in retention policy half-lives (RetentionPolicies).
+type DecayPolicy struct {
+	// Curve is the decay function: DecayExponential (default), DecayPowerLaw or DecayStep.
+	Curve string
+	// NodeTypeHalfLifeDays overrides the default node half-life for nodes of the listed
+	// types, e.g. {"Person": 365, "Event": 14}.
+	NodeTypeHalfLifeDays map[string]int
+	// RetentionHalfLifeDays overrides the half-life of the listed retention policies
+	// (see RetentionPolicies). 0 means memories under the policy never decay.
+	RetentionHalfLifeDays map[string]int
+}
+
+// Validate reports an unknown curve or a negative half-life.
+func (p *DecayPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	switch p.Curve {
+	case "", DecayExponential, DecayPowerLaw, DecayStep:
+	default:
+		return Invalidf("unknown decay curve %q: must be %q, %q or %q", p.Curve, DecayExponential, DecayPowerLaw, DecayStep)
+	}
+	for nodeType, days := range p.NodeTypeHalfLifeDays {
+		if days <= 0 {
+			return Invalidf("half-life of node type %q must be positive, got %d", nodeType, days)
+		}
+	}
+	for policy, days := range p.RetentionHalfLifeDays {
+		if days < 0 {
+			return Invalidf("half-life of retention policy %q cannot be negative, got %d", policy, days)
+		}
+	}
+	return nil
+}
+
+// Multiplier returns the score multiplier, between 0 and 1, of something age old that
+// has the given half-life. Negative ages and non-positive half-lives do not decay.
+func (p *DecayPolicy) Multiplier(age time.Duration, halfLifeDays int) float64 {
+	if age < 0 || halfLifeDays <= 0 {
+		return 1.0
+	}
+	halfLives := age.Hours() / 24.0 / float64(halfLifeDays)
+	curve := DecayExponential
+	if p != nil && p.Curve != "" {
+		curve = p.Curve
+	}
+	switch curve {
+	case DecayPowerLaw:
+		return 1.0 / (1.0 + halfLives)
+	case DecayStep:
+		return math.Pow(0.5, math.Floor(halfLives))
+	default:
+		return math.Pow(0.5, halfLives)
+	}
+}
+
+// maxDecayAgeDays bounds the ages DecayAge returns, well within time.Duration's range
+const maxDecayAgeDays = 100000
+
+// DecayAge returns the age from which Multiplier drops below minScore for the given
+// half-life (on the exponential and power-law curves, the age at which it equals
+// minScore). ok is false when it never does within maxDecayAgeDays, e.g. for
+// non-positive half-lives, which do not decay.
+func (p *DecayPolicy) DecayAge(minScore float64, halfLifeDays int) (age time.Duration, ok bool) {
+	if minScore > 1 {
+		return 0, true
+	}
+	if minScore <= 0 || halfLifeDays <= 0 {
+		return 0, false
+	}
+	curve := DecayExponential
+	if p != nil && p.Curve != "" {
+		curve = p.Curve
+	}
+	var halfLives float64
+	switch curve {
+	case DecayPowerLaw:
+		halfLives = 1/minScore - 1
+	case DecayStep:
+		halfLives = math.Floor(math.Log2(1/minScore)) + 1
+	default:
+		halfLives = math.Log2(1 / minScore)
+	}
+	days := halfLives * float64(halfLifeDays)
+	if days > maxDecayAgeDays {
+		return 0, false
+	}
+	return time.Duration(days * 24 * float64(time.Hour)), true
+}
+
+// NodeHalfLife returns the half-life of nodes of nodeType: its override, or
+// defaultDays.
+func (p *DecayPolicy) NodeHalfLife(nodeType string, defaultDays int) int {
+	if p != nil {
+		if days, ok := p.NodeTypeHalfLifeDays[nodeType]; ok {
+			return days
+		}
+	}
+	return defaultDays
+}
+
+// RetentionHalfLife returns the half-life of memories under a retention policy: its
+// override, or its RetentionPolicies entry. ok is false for unknown policies.
+func (p *DecayPolicy) RetentionHalfLife(retentionPolicy string) (days int, ok bool) {
+	if p != nil {
+		if days, ok := p.RetentionHalfLifeDays[retentionPolicy]; ok {
+			return days, true
+		}
+	}
+	def, ok := RetentionPolicies[retentionPolicy]
+	return def.HalfLifeDays, ok
+}
+
+// WithDecayPolicy scores the memories read from this store (CurrentScore) with policy
+// instead of the default one.
+func (s *SQLiteMemoryStore) WithDecayPolicy(policy *DecayPolicy) *SQLiteMemoryStore {
+	s.decayPolicy = policy
+	return s
+}
+
+// MemoryScore computes the decay score of a memory at now with the default policy; see
+// DecayPolicy.MemoryScore.
+func MemoryScore(retentionPolicy string, pinned bool, createdAt time.Time, lastAccessedAt *time.Time, accessCount int, now time.Time) float64 {
+	return (*DecayPolicy)(nil).MemoryScore(retentionPolicy, pinned, createdAt, lastAccessedAt, accessCount, now)
+}
+
+// MemoryScore computes the decay score of a memory at now, between 0 and 1, with the
+// half-life model DecayingSearcher applies to nodes:
+//
+//	score = decay(age, half_life) × (0.5 + 0.5 × heat)
+//	heat  = min(1, log(access_count + 1) / log(MemoryReferenceAccessCount + 1))
+//
+// decay is the policy's curve (exponential by default) and half_life that of the
+// memory's retention policy; unknown policies decay like "standard". The age is counted
+// from the last access, or from creation if the memory was never accessed. Pinned
+// memories, and those whose policy has no half-life (permanent), always score 1.
+func (p *DecayPolicy) MemoryScore(retentionPolicy string, pinned bool, createdAt time.Time, lastAccessedAt *time.Time, accessCount int, now time.Time) float64 {
+	if pinned {
+		return 1.0
+	}
+	halfLifeDays, ok := p.RetentionHalfLife(retentionPolicy)
+	if !ok {
+		halfLifeDays, _ = p.RetentionHalfLife("standard")
+	}
+	since := createdAt
+	if lastAccessedAt != nil {
+		since = *lastAccessedAt
+	}
+	return p.unpinnedScore(halfLifeDays, now.Sub(since), accessCount)
+}
+
+// unpinnedScore is MemoryScore of an unpinned memory with the given half-life, age since
+// its last access (or creation) and access count.
+func (p *DecayPolicy) unpinnedScore(halfLifeDays int, age time.Duration, accessCount int) float64 {
+	if halfLifeDays <= 0 {
+		return 1.0
+	}
+	decay := p.Multiplier(age, halfLifeDays)
+
+	heat := 0.0
+	if accessCount > 0 {
+		heat = math.Min(1.0, math.Log(float64(accessCount)+1.0)/math.Log(MemoryReferenceAccessCount+1.0))
+	}
+	return decay * (0.5 + 0.5*heat)
+}
+
+// sqlMemoryScore implements memory_score(curve, half_life_days, age_days, access_count),
+// the score of an unpinned memory, so listings can order memories by score in SQL.
+func sqlMemoryScore(curve string, halfLifeDays int64, ageDays float64, accessCount int64) float64 {
+	policy := &DecayPolicy{Curve: curve}
+	return policy.unpinnedScore(int(halfLifeDays), time.Duration(ageDays*24*float64(time.Hour)), int(accessCount))
+}
+
+// memoryScoreSQL returns an SQL expression computing MemoryScore at now for a row of the
+// memories table, with its arguments.
+func (p *DecayPolicy) memoryScoreSQL(now time.Time) (string, []interface{}) {
+	curve := DecayExponential
+	if p != nil && p.Curve != "" {
+		curve = p.Curve
+	}
+	policies := make([]string, 0, len(RetentionPolicies))
+	for policy := range RetentionPolicies {
+		policies = append(policies, policy)
+	}
+	if p != nil {
+		for policy := range p.RetentionHalfLifeDays {
+			if _, builtIn := RetentionPolicies[policy]; !builtIn {
+				policies = append(policies, policy)
+			}
+		}
+	}
+	sort.Strings(policies)
+
+	args := []interface{}{curve}
+	halfLife := "CASE retention_policy"
+	for _, policy := range policies {
+		days, _ := p.RetentionHalfLife(policy)
+		halfLife += " WHEN ? THEN ?"
+		args = append(args, policy, days)
+	}
+	standard, _ := p.RetentionHalfLife("standard")
+	halfLife += " ELSE ? END"
+	args = append(args, standard, now.UTC())
+
+	return fmt.Sprintf(`(CASE WHEN pinned THEN 1.0 ELSE memory_score(?, %s,
+		COALESCE(julianday(?) - julianday(COALESCE(last_accessed_at, created_at)), 0),
+		COALESCE(access_count, 0)) END)`, halfLife), args
+}
+
+// SortByScore orders memory summaries by CurrentScore, highest first. Ties keep their
+// order.
+func SortByScore(summaries []MemorySummary) {
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].CurrentScore > summaries[j].CurrentScore
+	})
+}
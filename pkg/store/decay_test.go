@@ -0,0 +1,225 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMemoryScore(t *testing.T) {
+	now := time.Now()
+	weekAgo := now.Add(-7 * 24 * time.Hour)
+
+	tests := []struct {
+		name           string
+		policy         string
+		pinned         bool
+		lastAccessedAt *time.Time
+		accessCount    int
+		want           float64
+	}{
+		{"new and never accessed", "standard", false, &now, 0, 0.5},
+		{"ephemeral after one half-life", "ephemeral", false, nil, 0, 0.25},
+		{"accessed a week ago", "ephemeral", false, &weekAgo, MemoryReferenceAccessCount, 0.5},
+		{"unknown policy decays like standard", "unknown", false, nil, 0, 0.5 * math.Pow(0.5, 7.0/90)},
+		{"permanent", "permanent", false, nil, 0, 1},
+		{"pinned", "session", true, nil, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MemoryScore(tt.policy, tt.pinned, weekAgo, tt.lastAccessedAt, tt.accessCount, now)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("MemoryScore = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecayPolicy_Multiplier(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		curve string
+		age   time.Duration
+		want  float64
+	}{
+		{DecayExponential, 10 * day, 0.5},
+		{DecayExponential, 20 * day, 0.25},
+		{DecayPowerLaw, 10 * day, 0.5},
+		{DecayPowerLaw, 30 * day, 0.25},
+		{DecayStep, 9 * day, 1},
+		{DecayStep, 25 * day, 0.25},
+		{DecayStep, -day, 1},
+	}
+	for _, tt := range tests {
+		policy := &DecayPolicy{Curve: tt.curve}
+		if got := policy.Multiplier(tt.age, 10); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s curve at %v: got %v, want %v", tt.curve, tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestDecayPolicy_DecayAge(t *testing.T) {
+	hour := time.Hour
+	for _, curve := range []string{DecayExponential, DecayPowerLaw, DecayStep} {
+		policy := &DecayPolicy{Curve: curve}
+		for _, minScore := range []float64{0.9, 0.5, 0.3, 0.01} {
+			age, ok := policy.DecayAge(minScore, 10)
+			if !ok {
+				t.Fatalf("%s curve, min score %v: expected an age", curve, minScore)
+			}
+			if got := policy.Multiplier(age+hour, 10); got >= minScore {
+				t.Errorf("%s curve, min score %v: expected a score below it after %v, got %v", curve, minScore, age, got)
+			}
+			if got := policy.Multiplier(age-hour, 10); got < minScore {
+				t.Errorf("%s curve, min score %v: expected a score of at least it before %v, got %v", curve, minScore, age, got)
+			}
+		}
+	}
+
+	if _, ok := (*DecayPolicy)(nil).DecayAge(0.5, 0); ok {
+		t.Error("Expected no decay age without a half-life")
+	}
+	if age, ok := (*DecayPolicy)(nil).DecayAge(1.5, 10); !ok || age != 0 {
+		t.Errorf("Expected every age to score below 1.5, got %v, %v", age, ok)
+	}
+}
+
+func TestDecayPolicy_Overrides(t *testing.T) {
+	policy := &DecayPolicy{
+		NodeTypeHalfLifeDays:  map[string]int{"Person": 365},
+		RetentionHalfLifeDays: map[string]int{"ephemeral": 0, "standard": 30},
+	}
+	if got := policy.NodeHalfLife("Person", 30); got != 365 {
+		t.Errorf("Expected the Person override, got %d", got)
+	}
+	if got := policy.NodeHalfLife("Event", 30); got != 30 {
+		t.Errorf("Expected the default half-life, got %d", got)
+	}
+
+	now := time.Now()
+	monthAgo := now.Add(-30 * 24 * time.Hour)
+	if got := policy.MemoryScore("ephemeral", false, monthAgo, nil, 0, now); got != 1 {
+		t.Errorf("Expected an ephemeral memory not to decay with a zero half-life, got %v", got)
+	}
+	if got := policy.MemoryScore("unknown", false, monthAgo, nil, 0, now); math.Abs(got-0.25) > 1e-9 {
+		t.Errorf("Expected an unknown policy to use the standard override, got %v", got)
+	}
+	if got := policy.MemoryScore("decision", false, monthAgo, nil, 0, now); got != MemoryScore("decision", false, monthAgo, nil, 0, now) {
+		t.Errorf("Expected the built-in decision half-life, got %v", got)
+	}
+
+	for _, invalid := range []*DecayPolicy{
+		{Curve: "linear"},
+		{NodeTypeHalfLifeDays: map[string]int{"Person": 0}},
+		{RetentionHalfLifeDays: map[string]int{"standard": -1}},
+	} {
+		if err := invalid.Validate(); !errors.Is(err, ErrValidation) {
+			t.Errorf("Expected a validation error for %+v, got %v", invalid, err)
+		}
+	}
+	if err := (*DecayPolicy)(nil).Validate(); err != nil {
+		t.Errorf("Expected a nil policy to be valid, got %v", err)
+	}
+}
+
+func TestListMemories_OrderByScore(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+
+	stale := &MemoryRecord{Topic: "Stale", Context: "Old news.", DocHash: "h1", RetentionPolicy: "ephemeral"}
+	fresh := &MemoryRecord{Topic: "Fresh", Context: "Today.", DocHash: "h2"}
+	hot := &MemoryRecord{Topic: "Hot", Context: "Read often.", DocHash: "h3"}
+	for _, mem := range []*MemoryRecord{stale, fresh, hot} {
+		if err := memStore.AddMemory(ctx, mem); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+	}
+	monthAgo := time.Now().Add(-30 * 24 * time.Hour)
+	if _, err := graphStore.DB().Exec("UPDATE memories SET created_at = ?, updated_at = ? WHERE id = ?", monthAgo, time.Now(), stale.ID); err != nil {
+		t.Fatalf("Failed to age memory: %v", err)
+	}
+	if _, err := graphStore.DB().Exec("UPDATE memories SET access_count = 10, last_accessed_at = ? WHERE id = ?", time.Now(), hot.ID); err != nil {
+		t.Fatalf("Failed to record accesses: %v", err)
+	}
+
+	summaries, err := memStore.ListMemories(ctx, ListMemoriesOptions{OrderBy: "score", OrderDesc: true})
+	if err != nil {
+		t.Fatalf("ListMemories failed: %v", err)
+	}
+	if len(summaries) != 3 || summaries[0].ID != hot.ID || summaries[1].ID != fresh.ID || summaries[2].ID != stale.ID {
+		t.Fatalf("Expected hot, fresh, stale, got %+v", summaries)
+	}
+	if summaries[0].CurrentScore <= summaries[1].CurrentScore || summaries[2].CurrentScore > 0.1 {
+		t.Errorf("Unexpected scores: %v, %v, %v", summaries[0].CurrentScore, summaries[1].CurrentScore, summaries[2].CurrentScore)
+	}
+
+	page, err := memStore.ListMemories(ctx, ListMemoriesOptions{OrderBy: "score", Offset: 1, Limit: 1})
+	if err != nil {
+		t.Fatalf("ListMemories failed: %v", err)
+	}
+	if len(page) != 1 || page[0].ID != fresh.ID {
+		t.Errorf("Expected the middle memory on the second ascending page, got %+v", page)
+	}
+
+	record, err := memStore.GetMemory(ctx, stale.ID)
+	if err != nil {
+		t.Fatalf("GetMemory failed: %v", err)
+	}
+	if math.Abs(record.CurrentScore-summaries[2].CurrentScore) > 1e-6 {
+		t.Errorf("Expected GetMemory to report score %v, got %v", summaries[2].CurrentScore, record.CurrentScore)
+	}
+}
+
+func TestListMemories_OrderByScoreFollowsPolicy(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	// Ephemeral memories never decay under this policy, and curves apply in the query too
+	policy := &DecayPolicy{Curve: DecayStep, RetentionHalfLifeDays: map[string]int{"ephemeral": 0}}
+	memStore := NewSQLiteMemoryStore(graphStore.DB()).WithDecayPolicy(policy)
+
+	policies := []string{"standard", "ephemeral", "decision", "session", "standard", "custom"}
+	for i, retention := range policies {
+		mem := &MemoryRecord{Topic: fmt.Sprintf("M%d", i), Context: "Fact.", DocHash: fmt.Sprintf("h%d", i), RetentionPolicy: retention}
+		if err := memStore.AddMemory(ctx, mem); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+		age := time.Now().Add(-time.Duration(20*(i+1)) * 24 * time.Hour)
+		if _, err := graphStore.DB().Exec("UPDATE memories SET created_at = ?, access_count = ? WHERE id = ?", age, i%3, mem.ID); err != nil {
+			t.Fatalf("Failed to age memory: %v", err)
+		}
+	}
+
+	var listed []MemorySummary
+	for offset := 0; offset < len(policies); offset += 2 {
+		page, err := memStore.ListMemories(ctx, ListMemoriesOptions{OrderBy: "score", OrderDesc: true, Offset: offset, Limit: 2})
+		if err != nil {
+			t.Fatalf("ListMemories failed: %v", err)
+		}
+		listed = append(listed, page...)
+	}
+	if len(listed) != len(policies) {
+		t.Fatalf("Expected %d memories over the pages, got %d", len(policies), len(listed))
+	}
+	if listed[0].RetentionPolicy != "ephemeral" || listed[0].CurrentScore != 1 {
+		t.Errorf("Expected the non-decaying ephemeral memory first, got %+v", listed[0])
+	}
+	for i := 1; i < len(listed); i++ {
+		if listed[i].CurrentScore > listed[i-1].CurrentScore+1e-9 {
+			t.Errorf("Expected scores in descending order, got %v after %v", listed[i].CurrentScore, listed[i-1].CurrentScore)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// NodeDescription is a description an entity was given, kept in the node's description
+// history when a later mention's description was merged into it.
+type NodeDescription struct {
+	NodeID      string    `json:"node_id"`
+	Description string    `json:"description"`
+	RecordedAt  time.Time `json:"recorded_at"`
+}
+
+// DescriptionMerge records that a node's Previous description was merged with the
+// Incoming description of a new mention.
+type DescriptionMerge struct {
+	NodeID   string
+	Previous string
+	Incoming string
+}
+
+// migrateDescriptionSchema adds the node_descriptions history table. Its rows are removed
+// with their node by the trigger migrateDescriptionCleanup adds.
+func (s *SQLiteGraphStore) migrateDescriptionSchema() error {
+	if _, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS node_descriptions (
+			id INTEGER PRIMARY KEY,
+			node_id TEXT NOT NULL,
+			namespace TEXT NOT NULL DEFAULT '',
+			description TEXT NOT NULL,
+			recorded_at DATETIME NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_node_descriptions_node_id ON node_descriptions(node_id, namespace, id);
+	`); err != nil {
+		return fmt.Errorf("failed to create node_descriptions table: %w", err)
+	}
+	return nil
+}
+
+// migrateDescriptionCleanup deletes a node's description history along with the node, on
+// every delete path (DeleteNode, Prune, garbage collection and document cascades), and
+// drops the history left behind by nodes deleted before. MergeNodes moves the history to
+// the canonical node first.
+func (s *SQLiteGraphStore) migrateDescriptionCleanup() error {
+	if _, err := s.db.Exec(`
+		CREATE TRIGGER IF NOT EXISTS node_descriptions_delete AFTER DELETE ON nodes BEGIN
+			DELETE FROM node_descriptions WHERE node_id = old.id AND namespace = old.namespace;
+		END;
+
+		DELETE FROM node_descriptions WHERE NOT EXISTS (
+			SELECT 1 FROM nodes n WHERE n.id = node_descriptions.node_id AND n.namespace = node_descriptions.namespace
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create node description cleanup trigger: %w", err)
+	}
+	return nil
+}
+
+// CurrentDescriptions returns the stored descriptions of the namespace's nodes with the
+// given IDs, by node ID. Unknown nodes are left out. Unlike GetNode it does not count as
+// an access.
+func (s *SQLiteGraphStore) CurrentDescriptions(ctx context.Context, ids []string) (map[string]string, error) {
+	descriptions := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return descriptions, nil
+	}
+	args := []interface{}{s.namespace}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT id, description FROM nodes WHERE namespace = ? AND id IN (`+sqlPlaceholders(len(ids))+`)`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query node descriptions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, description string
+		if err := rows.Scan(&id, &description); err != nil {
+			return nil, fmt.Errorf("failed to scan node description: %w", err)
+		}
+		if descriptions[id], err = s.cipher.Decrypt(description); err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating node descriptions: %w", err)
+	}
+	return descriptions, nil
+}
+
+// RecordDescriptionMerges adds the descriptions of merges to their nodes' history: the
+// previous description when the node has no history yet, then the incoming one.
+func (s *SQLiteGraphStore) RecordDescriptionMerges(ctx context.Context, merges []DescriptionMerge) error {
+	if len(merges) == 0 {
+		return nil
+	}
+	start := time.Now()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	for _, merge := range merges {
+		var recorded bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM node_descriptions WHERE node_id = ? AND namespace = ?)`,
+			merge.NodeID, s.namespace).Scan(&recorded); err != nil {
+			return fmt.Errorf("failed to check description history: %w", err)
+		}
+		descriptions := []string{merge.Incoming}
+		if !recorded && merge.Previous != "" {
+			descriptions = []string{merge.Previous, merge.Incoming}
+		}
+		for _, description := range descriptions {
+			encrypted, err := s.cipher.Encrypt(description)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt description: %w", err)
+			}
+			if _, err := tx.ExecContext(ctx, `INSERT INTO node_descriptions (node_id, namespace, description, recorded_at) VALUES (?, ?, ?, ?)`,
+				merge.NodeID, s.namespace, encrypted, now); err != nil {
+				return fmt.Errorf("failed to record description: %w", classifyWriteError(err))
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", classifyWriteError(err))
+	}
+	s.logQuery(ctx, "record_description_merges", start, slog.Int("merges", len(merges)))
+	return nil
+}
+
+// NodeDescriptions returns a node's description history, oldest first. Nodes whose
+// description was never merged have none.
+func (s *SQLiteGraphStore) NodeDescriptions(ctx context.Context, nodeID string) ([]NodeDescription, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT description, recorded_at FROM node_descriptions
+		WHERE node_id = ? AND namespace = ?
+		ORDER BY id
+	`, nodeID, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query description history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []NodeDescription
+	for rows.Next() {
+		entry := NodeDescription{NodeID: nodeID}
+		if err := rows.Scan(&entry.Description, &entry.RecordedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan description: %w", err)
+		}
+		if entry.Description, err = s.cipher.Decrypt(entry.Description); err != nil {
+			return nil, err
+		}
+		history = append(history, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating description history: %w", err)
+	}
+	return history, nil
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNodeDescriptions_History(t *testing.T) {
+	s, other := openNamespacedStores(t)
+	ctx := context.Background()
+
+	if err := s.AddNode(ctx, &Node{ID: "go", Name: "Go", Type: "Technology", Description: "A language."}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	current, err := s.CurrentDescriptions(ctx, []string{"go", "missing"})
+	if err != nil {
+		t.Fatalf("CurrentDescriptions failed: %v", err)
+	}
+	if len(current) != 1 || current["go"] != "A language." {
+		t.Fatalf("unexpected current descriptions %v", current)
+	}
+
+	merges := []DescriptionMerge{
+		{NodeID: "go", Previous: "A language.", Incoming: "Compiled."},
+		{NodeID: "go", Previous: "A language. Compiled.", Incoming: "Created at Google."},
+	}
+	if err := s.RecordDescriptionMerges(ctx, merges); err != nil {
+		t.Fatalf("RecordDescriptionMerges failed: %v", err)
+	}
+	history, err := s.NodeDescriptions(ctx, "go")
+	if err != nil {
+		t.Fatalf("NodeDescriptions failed: %v", err)
+	}
+	var got []string
+	for _, entry := range history {
+		got = append(got, entry.Description)
+	}
+	if len(got) != 3 || got[0] != "A language." || got[1] != "Compiled." || got[2] != "Created at Google." {
+		t.Errorf("unexpected history %q", got)
+	}
+	if history, _ := other.NodeDescriptions(ctx, "go"); len(history) != 0 {
+		t.Errorf("expected no history in another namespace, got %d entries", len(history))
+	}
+
+	if err := s.DeleteNode(ctx, "go"); err != nil {
+		t.Fatalf("DeleteNode failed: %v", err)
+	}
+	if history, _ := s.NodeDescriptions(ctx, "go"); len(history) != 0 {
+		t.Errorf("expected the history deleted with the node, got %d entries", len(history))
+	}
+}
+
+func TestNodeDescriptions_FollowNodeLifecycle(t *testing.T) {
+	s, _ := openNamespacedStores(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"golang", "go", "orphan", "doc-only"} {
+		if err := s.AddNode(ctx, &Node{ID: id, Name: id, Type: "Technology", Description: "Old."}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+		if err := s.RecordDescriptionMerges(ctx, []DescriptionMerge{{NodeID: id, Previous: "Old.", Incoming: "New."}}); err != nil {
+			t.Fatalf("RecordDescriptionMerges failed: %v", err)
+		}
+	}
+	count := func(id string) int {
+		history, err := s.NodeDescriptions(ctx, id)
+		if err != nil {
+			t.Fatalf("NodeDescriptions failed: %v", err)
+		}
+		return len(history)
+	}
+
+	// Merging moves the history to the canonical node
+	if _, _, err := s.MergeNodes(ctx, "golang", "go"); err != nil {
+		t.Fatalf("MergeNodes failed: %v", err)
+	}
+	if count("golang") != 0 || count("go") != 4 {
+		t.Errorf("expected golang's history moved to go, got %d and %d entries", count("golang"), count("go"))
+	}
+
+	// Garbage collection and cascading document deletes remove it
+	if _, _, err := NewSQLiteMemoryStore(s.DB()).GarbageCollectCandidates(ctx, []string{"orphan"}, nil); err != nil {
+		t.Fatalf("GarbageCollectCandidates failed: %v", err)
+	}
+	if count("orphan") != 0 {
+		t.Errorf("expected the history garbage collected, got %d entries", count("orphan"))
+	}
+	if err := s.LinkDocument(ctx, Document{Hash: "h1", Source: "one.md"}, []string{"doc-only"}, nil, nil); err != nil {
+		t.Fatalf("LinkDocument failed: %v", err)
+	}
+	if _, _, err := s.DeleteDocument(ctx, "h1", true); err != nil {
+		t.Fatalf("DeleteDocument failed: %v", err)
+	}
+	if count("doc-only") != 0 {
+		t.Errorf("expected the history deleted with the document's nodes, got %d entries", count("doc-only"))
+	}
+}
@@ -0,0 +1,203 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+)
+
+// GraphDiff lists what changed in a namespace between a baseline and a later state.
+type GraphDiff struct {
+	NodesAdded    []DiffNode           // Nodes only in the later state
+	NodesRemoved  []DiffNode           // Nodes only in the baseline; only known when diffing snapshots
+	EdgesAdded    []DiffEdge           // Edges only in the later state
+	EdgesRemoved  []DiffEdge           // Edges only in the baseline; only known when diffing snapshots
+	Supersessions []SupersessionRecord // Memories superseded after the baseline
+}
+
+// DiffNode identifies a node in a GraphDiff.
+type DiffNode struct {
+	ID        string
+	Name      string
+	Type      string
+	CreatedAt time.Time
+}
+
+// DiffEdge identifies an edge in a GraphDiff.
+type DiffEdge struct {
+	ID        string
+	SourceID  string
+	Relation  string
+	TargetID  string
+	CreatedAt time.Time
+}
+
+// DiffSince lists the nodes and edges created and the memories superseded since the given
+// time. Deletions leave no trace in the store, so NodesRemoved and EdgesRemoved stay
+// empty; diff against a backup with DiffSnapshots to see them.
+func (s *SQLiteGraphStore) DiffSince(ctx context.Context, since time.Time) (*GraphDiff, error) {
+	diff := &GraphDiff{}
+	var err error
+	if diff.NodesAdded, err = queryDiffNodes(ctx, s.db, `
+		SELECT id, name, type, created_at FROM nodes
+		WHERE namespace = ? AND created_at >= ? ORDER BY created_at, id
+	`, s.namespace, since); err != nil {
+		return nil, err
+	}
+	if diff.EdgesAdded, err = queryDiffEdges(ctx, s.db, `
+		SELECT id, source_id, relation, target_id, created_at FROM edges
+		WHERE namespace = ? AND created_at >= ? ORDER BY created_at, id
+	`, s.namespace, since); err != nil {
+		return nil, err
+	}
+	if diff.Supersessions, err = querySupersessions(ctx, s.db, `
+		SELECT ms.id, ms.superseding_id, ms.superseded_id, ms.reason, ms.created_at
+		FROM memory_supersession ms JOIN memories m ON m.id = ms.superseded_id
+		WHERE m.namespace = ? AND ms.created_at >= ? ORDER BY ms.created_at, ms.id
+	`, s.namespace, since); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// DiffSnapshots compares two snapshots of the database (created by Backup) in this
+// store's namespace: basePath is the baseline and headPath the later state, or "" for
+// the live database.
+func (s *SQLiteGraphStore) DiffSnapshots(ctx context.Context, basePath, headPath string) (*GraphDiff, error) {
+	// ATTACH is per connection, so every query runs on one pinned connection
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if err := attachSnapshot(ctx, conn, basePath, "diff_base"); err != nil {
+		return nil, err
+	}
+	defer conn.ExecContext(context.Background(), "DETACH DATABASE diff_base")
+	head := "main"
+	if headPath != "" {
+		if err := attachSnapshot(ctx, conn, headPath, "diff_head"); err != nil {
+			return nil, err
+		}
+		defer conn.ExecContext(context.Background(), "DETACH DATABASE diff_head")
+		head = "diff_head"
+	}
+
+	diff := &GraphDiff{}
+	nodesIn := `
+		SELECT id, name, type, created_at FROM %[1]s.nodes
+		WHERE namespace = ? AND id NOT IN (SELECT id FROM %[2]s.nodes WHERE namespace = ?)
+		ORDER BY created_at, id`
+	edgesIn := `
+		SELECT id, source_id, relation, target_id, created_at FROM %[1]s.edges
+		WHERE namespace = ? AND id NOT IN (SELECT id FROM %[2]s.edges WHERE namespace = ?)
+		ORDER BY created_at, id`
+	if diff.NodesAdded, err = queryDiffNodes(ctx, conn, fmt.Sprintf(nodesIn, head, "diff_base"), s.namespace, s.namespace); err != nil {
+		return nil, err
+	}
+	if diff.NodesRemoved, err = queryDiffNodes(ctx, conn, fmt.Sprintf(nodesIn, "diff_base", head), s.namespace, s.namespace); err != nil {
+		return nil, err
+	}
+	if diff.EdgesAdded, err = queryDiffEdges(ctx, conn, fmt.Sprintf(edgesIn, head, "diff_base"), s.namespace, s.namespace); err != nil {
+		return nil, err
+	}
+	if diff.EdgesRemoved, err = queryDiffEdges(ctx, conn, fmt.Sprintf(edgesIn, "diff_base", head), s.namespace, s.namespace); err != nil {
+		return nil, err
+	}
+	if diff.Supersessions, err = querySupersessions(ctx, conn, fmt.Sprintf(`
+		SELECT ms.id, ms.superseding_id, ms.superseded_id, ms.reason, ms.created_at
+		FROM %[1]s.memory_supersession ms JOIN %[1]s.memories m ON m.id = ms.superseded_id
+		WHERE m.namespace = ? AND ms.id NOT IN (SELECT id FROM diff_base.memory_supersession)
+		ORDER BY ms.created_at, ms.id`, head), s.namespace); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// attachSnapshot attaches the snapshot at path read-only under the given schema name
+func attachSnapshot(ctx context.Context, conn *sql.Conn, path, schema string) error {
+	if path == "" {
+		return fmt.Errorf("snapshot path cannot be empty")
+	}
+	// Attaching a missing file would create an empty database
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("snapshot file not accessible: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS "+schema, "file:"+path+"?mode=ro"); err != nil {
+		return fmt.Errorf("failed to attach snapshot %s: %w", path, err)
+	}
+	return nil
+}
+
+// queryDiffNodes reads (id, name, type, created_at) rows
+func queryDiffNodes(ctx context.Context, db queryer, query string, args ...interface{}) ([]DiffNode, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff nodes: %w", err)
+	}
+	defer rows.Close()
+
+	var nodes []DiffNode
+	for rows.Next() {
+		var node DiffNode
+		var nodeType sql.NullString
+		if err := rows.Scan(&node.ID, &node.Name, &nodeType, &node.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan node: %w", err)
+		}
+		node.Type = nodeType.String
+		nodes = append(nodes, node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating nodes: %w", err)
+	}
+	return nodes, nil
+}
+
+// queryDiffEdges reads (id, source_id, relation, target_id, created_at) rows
+func queryDiffEdges(ctx context.Context, db queryer, query string, args ...interface{}) ([]DiffEdge, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff edges: %w", err)
+	}
+	defer rows.Close()
+
+	var edges []DiffEdge
+	for rows.Next() {
+		var edge DiffEdge
+		if err := rows.Scan(&edge.ID, &edge.SourceID, &edge.Relation, &edge.TargetID, &edge.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan edge: %w", err)
+		}
+		edges = append(edges, edge)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating edges: %w", err)
+	}
+	return edges, nil
+}
+
+// querySupersessions reads (id, superseding_id, superseded_id, reason, created_at) rows
+func querySupersessions(ctx context.Context, db queryer, query string, args ...interface{}) ([]SupersessionRecord, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff supersessions: %w", err)
+	}
+	defer rows.Close()
+
+	var records []SupersessionRecord
+	for rows.Next() {
+		var record SupersessionRecord
+		var reason sql.NullString
+		if err := rows.Scan(&record.ID, &record.SupersedingID, &record.SupersededID, &reason, &record.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan supersession: %w", err)
+		}
+		record.Reason = reason.String
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating supersessions: %w", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestDiffSince_IgnoresRewrittenEdges verifies that re-extracting an existing edge keeps
+// its creation time, so DiffSince reports only edges that are new.
+func TestDiffSince_IgnoresRewrittenEdges(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	old := time.Now().Add(-48 * time.Hour)
+	for _, node := range []*Node{
+		{ID: "alice", Name: "Alice", Type: "Person", CreatedAt: old},
+		{ID: "go", Name: "Go", Type: "Technology", CreatedAt: old},
+	} {
+		if err := s.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	if err := s.AddEdge(ctx, &Edge{ID: "uses", SourceID: "alice", Relation: "USES", TargetID: "go", CreatedAt: old}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	since := time.Now().Add(-time.Hour)
+
+	// Cognify writes the edge again, stamped with the current time
+	if err := s.AddEdge(ctx, &Edge{ID: "uses", SourceID: "alice", Relation: "USES", TargetID: "go", Weight: 2}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	if err := s.AddEdge(ctx, &Edge{ID: "knows", SourceID: "alice", Relation: "KNOWS", TargetID: "go"}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+
+	diff, err := s.DiffSince(ctx, since)
+	if err != nil {
+		t.Fatalf("DiffSince failed: %v", err)
+	}
+	if len(diff.EdgesAdded) != 1 || diff.EdgesAdded[0].ID != "knows" {
+		t.Errorf("expected only the new edge, got %+v", diff.EdgesAdded)
+	}
+
+	edges, err := s.GetEdges(ctx, "alice")
+	if err != nil {
+		t.Fatalf("GetEdges failed: %v", err)
+	}
+	for _, edge := range edges {
+		if edge.ID == "uses" && edge.Weight != 2 {
+			t.Errorf("expected the rewritten edge to be updated, got weight %v", edge.Weight)
+		}
+	}
+}
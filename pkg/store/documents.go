@@ -0,0 +1,362 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// ErrDocumentNotFound indicates that no document was found for the given hash.
+var ErrDocumentNotFound = fmt.Errorf("document not found")
+
+// Document is an Add() document whose Cognify output is linked back to it.
+type Document struct {
+	Hash    string // SHA-256 of the document text, its identity
+	Source  string
+	AddedAt time.Time
+}
+
+// DocumentStore records which document produced each node, edge and chunk, so
+// Cognify output can be listed or deleted per document. Separate from GraphStore,
+// like DocumentTracker.
+type DocumentStore interface {
+	// LinkDocument records doc (if new) and links the given nodes, edges and chunks to it.
+	LinkDocument(ctx context.Context, doc Document, nodeIDs, edgeIDs, chunkIDs []string) error
+
+	// ListDocuments returns the recorded documents, oldest first.
+	ListDocuments(ctx context.Context) ([]Document, error)
+
+	// GetNodesByDocument returns the nodes linked to a document, ordered by id.
+	GetNodesByDocument(ctx context.Context, hash string) ([]*Node, error)
+
+	// DocumentsByNode returns the documents linked to a node, oldest first.
+	DocumentsByNode(ctx context.Context, nodeID string) ([]Document, error)
+
+	// DocumentChunkIDs returns the IDs of the chunks Cognify wrote from a document, sorted.
+	DocumentChunkIDs(ctx context.Context, hash string) ([]string, error)
+
+	// DeleteDocument removes a document, its links and its processed record. With cascade,
+	// nodes and edges no other document or memory links to are deleted too; pinned nodes
+	// and nodes still used by other edges are kept. Returns the number of nodes and the
+	// IDs of the edges deleted, or ErrDocumentNotFound if the document is unknown.
+	DeleteDocument(ctx context.Context, hash string, cascade bool) (nodesDeleted int, edgesDeleted []string, err error)
+}
+
+// Compile-time interface check
+var _ DocumentStore = (*SQLiteGraphStore)(nil)
+
+// migrateDocumentSchema adds the documents table and its node, edge and chunk links.
+func (s *SQLiteGraphStore) migrateDocumentSchema() error {
+	if _, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS documents (
+			id TEXT PRIMARY KEY,
+			hash TEXT NOT NULL,
+			namespace TEXT NOT NULL DEFAULT '',
+			source TEXT NOT NULL DEFAULT '',
+			added_at DATETIME NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_documents_namespace ON documents(namespace, added_at);
+
+		CREATE TABLE IF NOT EXISTS document_nodes (
+			document_id TEXT NOT NULL,
+			node_id TEXT NOT NULL,
+			PRIMARY KEY (document_id, node_id),
+			FOREIGN KEY (document_id) REFERENCES documents(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_document_nodes_node_id ON document_nodes(node_id);
+
+		CREATE TABLE IF NOT EXISTS document_edges (
+			document_id TEXT NOT NULL,
+			edge_id TEXT NOT NULL,
+			PRIMARY KEY (document_id, edge_id),
+			FOREIGN KEY (document_id) REFERENCES documents(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_document_edges_edge_id ON document_edges(edge_id);
+
+		CREATE TABLE IF NOT EXISTS document_chunks (
+			document_id TEXT NOT NULL,
+			chunk_id TEXT NOT NULL,
+			PRIMARY KEY (document_id, chunk_id),
+			FOREIGN KEY (document_id) REFERENCES documents(id) ON DELETE CASCADE
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create document tables: %w", err)
+	}
+	return nil
+}
+
+// LinkDocument records doc in the instance's namespace and links nodes, edges and
+// chunks to it. Existing links are kept; the document's AddedAt is set on first record.
+func (s *SQLiteGraphStore) LinkDocument(ctx context.Context, doc Document, nodeIDs, edgeIDs, chunkIDs []string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.linkDocument(ctx, tx, doc, nodeIDs, edgeIDs, chunkIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// linkDocument is LinkDocument within an open transaction.
+func (s *SQLiteGraphStore) linkDocument(ctx context.Context, tx *sql.Tx, doc Document, nodeIDs, edgeIDs, chunkIDs []string) error {
+	id := s.documentKey(doc.Hash)
+	if doc.AddedAt.IsZero() {
+		doc.AddedAt = time.Now()
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT OR IGNORE INTO documents (id, hash, namespace, source, added_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, id, doc.Hash, s.namespace, doc.Source, doc.AddedAt.UTC()); err != nil {
+		return fmt.Errorf("failed to record document: %w", err)
+	}
+
+	links := []struct {
+		table, column string
+		ids           []string
+	}{
+		{"document_nodes", "node_id", nodeIDs},
+		{"document_edges", "edge_id", edgeIDs},
+		{"document_chunks", "chunk_id", chunkIDs},
+	}
+	for _, link := range links {
+		for _, linked := range link.ids {
+			if _, err := tx.ExecContext(ctx,
+				"INSERT OR IGNORE INTO "+link.table+" (document_id, "+link.column+") VALUES (?, ?)",
+				id, linked); err != nil {
+				return fmt.Errorf("failed to link document: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// ListDocuments returns the namespace's documents, oldest first.
+func (s *SQLiteGraphStore) ListDocuments(ctx context.Context) ([]Document, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT hash, source, added_at FROM documents
+		WHERE namespace = ?
+		ORDER BY added_at, rowid
+	`, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query documents: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []Document
+	for rows.Next() {
+		var doc Document
+		if err := rows.Scan(&doc.Hash, &doc.Source, &doc.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan document: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating documents: %w", err)
+	}
+	return docs, nil
+}
+
+// GetNodesByDocument returns the nodes linked to a document, ordered by id.
+func (s *SQLiteGraphStore) GetNodesByDocument(ctx context.Context, hash string) ([]*Node, error) {
+	nodeIDs, err := s.documentLinks(ctx, "document_nodes", "node_id", hash)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*Node, 0, len(nodeIDs))
+	for _, id := range nodeIDs {
+		node, err := s.GetNode(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
+
+// DocumentsByNode returns the namespace's documents linked to a node, oldest first.
+func (s *SQLiteGraphStore) DocumentsByNode(ctx context.Context, nodeID string) ([]Document, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT d.hash, d.source, d.added_at FROM documents d
+		JOIN document_nodes dn ON dn.document_id = d.id
+		WHERE dn.node_id = ? AND d.namespace = ?
+		ORDER BY d.added_at, d.rowid
+	`, nodeID, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query node documents: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []Document
+	for rows.Next() {
+		var doc Document
+		if err := rows.Scan(&doc.Hash, &doc.Source, &doc.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan document: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating documents: %w", err)
+	}
+	return docs, nil
+}
+
+// SourceNodeIDs returns the namespace's nodes derived from the given sources: linked to a
+// Cognify document added with one of them (AddOptions.Source), or to a memory whose
+// Source is one of them. Sources match exactly.
+func (s *SQLiteGraphStore) SourceNodeIDs(ctx context.Context, sources []string) (map[string]bool, error) {
+	start := time.Now()
+	nodeIDs := make(map[string]bool)
+	if len(sources) == 0 {
+		return nodeIDs, nil
+	}
+
+	args := []interface{}{s.namespace}
+	for _, source := range sources {
+		args = append(args, source)
+	}
+	args = append(args, s.namespace, StatusDeleted)
+	for _, source := range sources {
+		args = append(args, source)
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT dn.node_id FROM document_nodes dn
+		JOIN documents d ON dn.document_id = d.id
+		WHERE d.namespace = ? AND d.source IN (`+sqlPlaceholders(len(sources))+`)
+		UNION
+		SELECT mn.node_id FROM memory_nodes mn
+		JOIN memories m ON mn.memory_id = m.id
+		WHERE m.namespace = ? AND m.status != ? AND m.source IN (`+sqlPlaceholders(len(sources))+`)
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query source nodes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var nodeID string
+		if err := rows.Scan(&nodeID); err != nil {
+			return nil, fmt.Errorf("failed to scan node ID: %w", err)
+		}
+		nodeIDs[nodeID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating source nodes: %w", err)
+	}
+	s.logQuery(ctx, "source_node_ids", start, slog.Int("sources", len(sources)), slog.Int("nodes", len(nodeIDs)))
+	return nodeIDs, nil
+}
+
+// DocumentChunkIDs returns the IDs of the chunks written from a document, sorted.
+func (s *SQLiteGraphStore) DocumentChunkIDs(ctx context.Context, hash string) ([]string, error) {
+	return s.documentLinks(ctx, "document_chunks", "chunk_id", hash)
+}
+
+// documentLinks returns the IDs a document links to in table, sorted.
+func (s *SQLiteGraphStore) documentLinks(ctx context.Context, table, column, hash string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+column+" FROM "+table+" WHERE document_id = ? ORDER BY "+column,
+		s.documentKey(hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query document links: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan document link: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating document links: %w", err)
+	}
+	return ids, nil
+}
+
+// DeleteDocument removes a document and, with cascade, the nodes and edges only it links to.
+func (s *SQLiteGraphStore) DeleteDocument(ctx context.Context, hash string, cascade bool) (nodesDeleted int, edgesDeleted []string, err error) {
+	start := time.Now()
+	id := s.documentKey(hash)
+	nodeIDs, err := s.documentLinks(ctx, "document_nodes", "node_id", hash)
+	if err != nil {
+		return 0, nil, err
+	}
+	edgeIDs, err := s.documentLinks(ctx, "document_edges", "edge_id", hash)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Links and chunks are removed with the document (ON DELETE CASCADE); chunk vectors
+	// are not
+	if _, err := tx.ExecContext(ctx, "DELETE FROM vec_chunks WHERE rowid IN (SELECT id FROM chunks WHERE document_id = ?)", id); err != nil {
+		return 0, nil, fmt.Errorf("failed to delete document chunk vectors: %w", err)
+	}
+	res, err := tx.ExecContext(ctx, "DELETE FROM documents WHERE id = ?", id)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to delete document: %w", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return 0, nil, ErrDocumentNotFound
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM processed_documents WHERE hash = ?", id); err != nil {
+		return 0, nil, fmt.Errorf("failed to delete processed document record: %w", err)
+	}
+
+	if cascade {
+		// Edges first, so the nodes they connected can be checked for remaining edges
+		for _, edgeID := range edgeIDs {
+			res, err := tx.ExecContext(ctx, `
+				DELETE FROM edges WHERE id = ? AND namespace = ?
+				AND NOT EXISTS (SELECT 1 FROM document_edges WHERE edge_id = ?)
+				AND NOT EXISTS (SELECT 1 FROM memory_edges WHERE edge_id = ?)
+			`, edgeID, s.namespace, edgeID, edgeID)
+			if err != nil {
+				return 0, nil, fmt.Errorf("failed to delete document edge: %w", err)
+			}
+			if affected, _ := res.RowsAffected(); affected > 0 {
+				edgesDeleted = append(edgesDeleted, edgeID)
+			}
+		}
+
+		for _, nodeID := range nodeIDs {
+			res, err := tx.ExecContext(ctx, `
+				DELETE FROM nodes WHERE id = ? AND namespace = ? AND NOT pinned
+				AND NOT EXISTS (SELECT 1 FROM document_nodes WHERE node_id = ?)
+				AND NOT EXISTS (SELECT 1 FROM memory_nodes WHERE node_id = ?)
+				AND NOT EXISTS (SELECT 1 FROM edges WHERE source_id = ? OR target_id = ?)
+			`, nodeID, s.namespace, nodeID, nodeID, nodeID, nodeID)
+			if err != nil {
+				return 0, nil, fmt.Errorf("failed to delete document node: %w", err)
+			}
+			if affected, _ := res.RowsAffected(); affected > 0 {
+				nodesDeleted++
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.logQuery(ctx, "delete_document", start, slog.Bool("cascade", cascade), slog.Int("nodes_deleted", nodesDeleted), slog.Int("edges_deleted", len(edgesDeleted)))
+	return nodesDeleted, edgesDeleted, nil
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDocumentProvenance(t *testing.T) {
+	s, other := openNamespacedStores(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, node := range []*Node{
+		{ID: "alice", Name: "Alice", Type: "Person", CreatedAt: now},
+		{ID: "go", Name: "Go", Type: "Technology", CreatedAt: now},
+		{ID: "bob", Name: "Bob", Type: "Person", CreatedAt: now},
+	} {
+		if err := s.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	if err := s.AddEdge(ctx, &Edge{ID: "alice-uses-go", SourceID: "alice", Relation: "USES", TargetID: "go", Weight: 1, CreatedAt: now}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	if err := s.MarkDocumentProcessed(ctx, "h1", "one.md", 1); err != nil {
+		t.Fatalf("MarkDocumentProcessed failed: %v", err)
+	}
+
+	// Both documents mention Go
+	doc1 := Document{Hash: "h1", Source: "one.md", AddedAt: now}
+	if err := s.LinkDocument(ctx, doc1, []string{"alice", "go"}, []string{"alice-uses-go"}, []string{"c1"}); err != nil {
+		t.Fatalf("LinkDocument failed: %v", err)
+	}
+	doc2 := Document{Hash: "h2", Source: "two.md", AddedAt: now.Add(time.Second)}
+	if err := s.LinkDocument(ctx, doc2, []string{"go", "bob"}, nil, []string{"c2"}); err != nil {
+		t.Fatalf("LinkDocument failed: %v", err)
+	}
+
+	sourced, err := s.SourceNodeIDs(ctx, []string{"two.md"})
+	if err != nil || len(sourced) != 2 || !sourced["go"] || !sourced["bob"] {
+		t.Errorf("expected go and bob for two.md, got %v, %v", sourced, err)
+	}
+	if sourced, _ := other.SourceNodeIDs(ctx, []string{"two.md"}); len(sourced) != 0 {
+		t.Errorf("expected another namespace to have no source nodes, got %v", sourced)
+	}
+
+	docs, err := s.ListDocuments(ctx)
+	if err != nil || len(docs) != 2 || docs[0].Hash != "h1" || docs[0].Source != "one.md" {
+		t.Fatalf("unexpected documents %+v, %v", docs, err)
+	}
+	if docs, _ := other.ListDocuments(ctx); len(docs) != 0 {
+		t.Errorf("expected another namespace to have no documents, got %+v", docs)
+	}
+
+	nodes, err := s.GetNodesByDocument(ctx, "h1")
+	if err != nil || len(nodes) != 2 || nodes[0].ID != "alice" || nodes[1].ID != "go" {
+		t.Fatalf("unexpected nodes for h1: %+v, %v", nodes, err)
+	}
+	if chunks, _ := s.DocumentChunkIDs(ctx, "h2"); len(chunks) != 1 || chunks[0] != "c2" {
+		t.Errorf("expected chunk c2 for h2, got %v", chunks)
+	}
+
+	// Cascading delete keeps Go, which the other document still links to
+	nodesDeleted, edgesDeleted, err := s.DeleteDocument(ctx, "h1", true)
+	if err != nil {
+		t.Fatalf("DeleteDocument failed: %v", err)
+	}
+	if nodesDeleted != 1 || len(edgesDeleted) != 1 || edgesDeleted[0] != "alice-uses-go" {
+		t.Errorf("expected 1 node and 1 edge deleted, got %d and %v", nodesDeleted, edgesDeleted)
+	}
+	if node, _ := s.GetNode(ctx, "alice"); node != nil {
+		t.Error("expected alice deleted")
+	}
+	if node, _ := s.GetNode(ctx, "go"); node == nil {
+		t.Error("expected go kept")
+	}
+	if processed, _ := s.IsDocumentProcessed(ctx, "h1"); processed {
+		t.Error("expected the processed record removed")
+	}
+
+	// Without cascade only the document goes
+	if _, _, err := s.DeleteDocument(ctx, "h2", false); err != nil {
+		t.Fatalf("DeleteDocument failed: %v", err)
+	}
+	if node, _ := s.GetNode(ctx, "bob"); node == nil {
+		t.Error("expected bob kept without cascade")
+	}
+	if _, _, err := s.DeleteDocument(ctx, "h2", false); !errors.Is(err, ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,284 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// ErrDimensionMismatch is matched (via errors.Is) by every DimensionMismatchError.
+var ErrDimensionMismatch = errors.New("embedding dimension mismatch")
+
+// DimensionMismatchError reports a vector whose length differs from the embeddings the
+// store holds, typically after switching embedding models without re-embedding.
+type DimensionMismatchError struct {
+	Expected int // Dimensions of the stored embeddings
+	Actual   int // Dimensions of the rejected vector
+}
+
+func (e *DimensionMismatchError) Error() string {
+	return fmt.Sprintf("embedding has %d dimensions, the store holds %d-dimensional embeddings", e.Actual, e.Expected)
+}
+
+// Is makes errors.Is(err, ErrDimensionMismatch) match.
+func (e *DimensionMismatchError) Is(target error) bool {
+	return target == ErrDimensionMismatch
+}
+
+// ErrEmbeddingModelMismatch is matched (via errors.Is) by every ModelMismatchError.
+var ErrEmbeddingModelMismatch = errors.New("embedding model mismatch")
+
+// ModelMismatchError reports a configured embedding model that differs from the one the
+// registry records for the stored embeddings, whose vectors the new model cannot match.
+type ModelMismatchError struct {
+	Stored     string // Model recorded in the registry
+	Configured string // Model the caller is configured with
+}
+
+func (e *ModelMismatchError) Error() string {
+	return fmt.Sprintf("embeddings were made with %q, not the configured %q; re-embed to switch models", e.Stored, e.Configured)
+}
+
+// Is makes errors.Is(err, ErrEmbeddingModelMismatch) match.
+func (e *ModelMismatchError) Is(target error) bool {
+	return target == ErrEmbeddingModelMismatch
+}
+
+// EmbeddingConfig records which embedding model produced the stored embeddings.
+type EmbeddingConfig struct {
+	Model      string // Embedding model name; empty if never recorded
+	Dimensions int    // Vector length of the node and edge indexes
+}
+
+// Keys of the embedding registry in store_metadata
+const (
+	metadataEmbeddingModel      = "embedding_model"
+	metadataEmbeddingDimensions = "embedding_dimensions"
+)
+
+// vecDimensionsPattern extracts N from a vec0 declaration "embedding float[N]"
+var vecDimensionsPattern = regexp.MustCompile(`float\[(\d+)\]`)
+
+// migrateEmbeddingRegistry adds the store_metadata key-value table and records the
+// dimensions of the existing vector indexes in it.
+func (s *SQLiteGraphStore) migrateEmbeddingRegistry() error {
+	if _, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS store_metadata (
+			key TEXT PRIMARY KEY,
+			value TEXT NOT NULL
+		)
+	`); err != nil {
+		return fmt.Errorf("failed to create store_metadata table: %w", err)
+	}
+
+	var recorded bool
+	if err := s.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM store_metadata WHERE key = ?)`,
+		metadataEmbeddingDimensions).Scan(&recorded); err != nil {
+		return fmt.Errorf("failed to check embedding registry: %w", err)
+	}
+	if recorded {
+		return nil
+	}
+	dimensions, err := vecTableDimensions(context.Background(), s.db, "vec_nodes")
+	if err != nil {
+		return err
+	}
+	return recordEmbeddingDimensions(context.Background(), s.db, dimensions)
+}
+
+// EmbeddingConfig returns the embedding model and dimensions recorded for the database.
+// The registry is shared by all namespaces, since they share the vector indexes.
+func (s *SQLiteGraphStore) EmbeddingConfig(ctx context.Context) (EmbeddingConfig, error) {
+	var cfg EmbeddingConfig
+	rows, err := s.db.QueryContext(ctx, `SELECT key, value FROM store_metadata WHERE key IN (?, ?)`,
+		metadataEmbeddingModel, metadataEmbeddingDimensions)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read embedding registry: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return cfg, fmt.Errorf("failed to scan embedding registry: %w", err)
+		}
+		switch key {
+		case metadataEmbeddingModel:
+			cfg.Model = value
+		case metadataEmbeddingDimensions:
+			cfg.Dimensions, _ = strconv.Atoi(value)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return cfg, fmt.Errorf("error iterating embedding registry: %w", err)
+	}
+	return cfg, nil
+}
+
+// SetEmbeddingModel records the embedding model name in the registry. The dimensions
+// follow the vector indexes and change only through ResetEmbeddings or an empty index
+// adopting a new size.
+func (s *SQLiteGraphStore) SetEmbeddingModel(ctx context.Context, model string) error {
+	if _, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO store_metadata (key, value) VALUES (?, ?)`,
+		metadataEmbeddingModel, model); err != nil {
+		return fmt.Errorf("failed to record embedding model: %w", err)
+	}
+	return nil
+}
+
+// ResetEmbeddings drops every node, edge and chunk embedding and recreates the vector indexes
+// for vectors of the given dimensions, ready to be refilled with a new embedding model.
+// Chunk text is kept for Reembed. It refuses when other namespaces hold embeddings, since
+// the indexes are shared.
+func (s *SQLiteGraphStore) ResetEmbeddings(ctx context.Context, dimensions int) error {
+	if dimensions <= 0 {
+		return fmt.Errorf("dimensions must be positive, got %d", dimensions)
+	}
+
+	var others int
+	if err := s.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM vec_node_ids v JOIN nodes n ON n.id = v.node_id WHERE n.namespace != ?) +
+			(SELECT COUNT(*) FROM vec_edge_ids v JOIN edges e ON e.id = v.edge_id WHERE e.namespace != ?) +
+			(SELECT COUNT(*) FROM chunks WHERE namespace != ?)
+	`, s.namespace, s.namespace, s.namespace).Scan(&others); err != nil {
+		return fmt.Errorf("failed to check other namespaces: %w", err)
+	}
+	if others > 0 {
+		return fmt.Errorf("cannot reset embeddings: %d embeddings belong to other namespaces", others)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"vec_nodes", "vec_edges", "vec_chunks"} {
+		if err := recreateVecTable(ctx, tx, table, dimensions); err != nil {
+			return err
+		}
+	}
+	statements := []string{
+		`DELETE FROM vec_node_ids`,
+		`DELETE FROM vec_edge_ids`,
+		`UPDATE nodes SET embedding = NULL WHERE embedding IS NOT NULL`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to clear embeddings: %w", err)
+		}
+	}
+	if err := recordEmbeddingDimensions(ctx, tx, dimensions); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UpdateNodeEmbedding stores an embedding in the node's embedding column without touching
+// the node's other fields or the vector index, for vector stores kept outside the database.
+func (s *SQLiteGraphStore) UpdateNodeEmbedding(ctx context.Context, id string, embedding []float32) error {
+	if _, err := s.db.ExecContext(ctx, `UPDATE nodes SET embedding = ? WHERE id = ? AND namespace = ?`,
+		serializeEmbedding(embedding), id, s.namespace); err != nil {
+		return fmt.Errorf("failed to update node embedding: %w", err)
+	}
+	return nil
+}
+
+// queryer is the query subset of *sql.DB, *sql.Tx and *sql.Conn.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// vecTableDimensions returns the vector length declared by a vec0 table.
+func vecTableDimensions(ctx context.Context, q queryer, table string) (int, error) {
+	var declaration string
+	err := q.QueryRowContext(ctx, `SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&declaration)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s declaration: %w", table, err)
+	}
+	match := vecDimensionsPattern.FindStringSubmatch(declaration)
+	if match == nil {
+		return 0, fmt.Errorf("failed to parse %s dimensions from %q", table, declaration)
+	}
+	return strconv.Atoi(match[1])
+}
+
+// checkVecDimensions validates a vector's length against a vec0 table. When adopt is
+// set and the table's ID mapping is empty, a table of another size is recreated to fit,
+// so a fresh database accepts any embedding model.
+func checkVecDimensions(ctx context.Context, db *sql.DB, table, mappingTable string, dimensions int, adopt bool) error {
+	expected, err := vecTableDimensions(ctx, db, table)
+	if err != nil {
+		return err
+	}
+	if dimensions == expected {
+		return nil
+	}
+	if adopt {
+		var used bool
+		if err := db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM `+mappingTable+`)`).Scan(&used); err != nil {
+			return fmt.Errorf("failed to check %s: %w", mappingTable, err)
+		}
+		if !used {
+			return adoptVecDimensions(ctx, db, table, mappingTable, expected, dimensions)
+		}
+	}
+	return &DimensionMismatchError{Expected: expected, Actual: dimensions}
+}
+
+// adoptVecDimensions recreates an unused vec0 table for vectors of the given size.
+func adoptVecDimensions(ctx context.Context, db *sql.DB, table, mappingTable string, expected, dimensions int) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Another connection may have started using the table since the check
+	var used bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM `+mappingTable+`)`).Scan(&used); err != nil {
+		return fmt.Errorf("failed to check %s: %w", mappingTable, err)
+	}
+	if used {
+		return &DimensionMismatchError{Expected: expected, Actual: dimensions}
+	}
+
+	if err := recreateVecTable(ctx, tx, table, dimensions); err != nil {
+		return err
+	}
+	if err := recordEmbeddingDimensions(ctx, tx, dimensions); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func recreateVecTable(ctx context.Context, q queryer, table string, dimensions int) error {
+	if _, err := q.ExecContext(ctx, `DROP TABLE IF EXISTS `+table); err != nil {
+		return fmt.Errorf("failed to drop %s: %w", table, err)
+	}
+	if _, err := q.ExecContext(ctx, fmt.Sprintf(`CREATE VIRTUAL TABLE %s USING vec0(embedding float[%d])`, table, dimensions)); err != nil {
+		return fmt.Errorf("failed to create %s: %w", table, err)
+	}
+	return nil
+}
+
+func recordEmbeddingDimensions(ctx context.Context, q queryer, dimensions int) error {
+	if _, err := q.ExecContext(ctx, `INSERT OR REPLACE INTO store_metadata (key, value) VALUES (?, ?)`,
+		metadataEmbeddingDimensions, strconv.Itoa(dimensions)); err != nil {
+		return fmt.Errorf("failed to record embedding dimensions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmbeddingRegistry_AdoptsAndValidates(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	cfg, err := s.EmbeddingConfig(ctx)
+	if err != nil {
+		t.Fatalf("EmbeddingConfig failed: %v", err)
+	}
+	if cfg.Dimensions != 1536 || cfg.Model != "" {
+		t.Errorf("expected the default index size and no model, got %+v", cfg)
+	}
+	if err := s.SetEmbeddingModel(ctx, "nomic-embed-text"); err != nil {
+		t.Fatalf("SetEmbeddingModel failed: %v", err)
+	}
+
+	// An unused index adopts the first embedding's size
+	vectors := NewSQLiteVectorStore(s.DB())
+	for _, id := range []string{"a", "b"} {
+		if err := s.AddNode(ctx, &Node{ID: id, Name: id, Type: "Concept"}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	if err := vectors.Add(ctx, "a", []float32{1, 0, 0, 0}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	cfg, _ = s.EmbeddingConfig(ctx)
+	if cfg.Dimensions != 4 || cfg.Model != "nomic-embed-text" {
+		t.Errorf("expected 4 dimensions recorded, got %+v", cfg)
+	}
+
+	// Once used, other sizes are rejected on Add and Search
+	err = vectors.Add(ctx, "b", []float32{1, 0, 0})
+	var mismatch *DimensionMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Expected != 4 || mismatch.Actual != 3 {
+		t.Errorf("expected a dimension mismatch on Add, got %v", err)
+	}
+	if _, err := vectors.Search(ctx, make([]float32, 1536), 5); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("expected a dimension mismatch on Search, got %v", err)
+	}
+	results, err := vectors.Search(ctx, []float32{1, 0, 0, 0}, 5)
+	if err != nil || len(results) != 1 || results[0].ID != "a" {
+		t.Errorf("expected a match at the adopted size, got %v, %v", results, err)
+	}
+}
+
+func TestEmbeddingRegistry_ResetEmbeddings(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reset.db")
+	a, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	defer a.Close()
+	a.WithNamespace("a")
+	ctx := context.Background()
+
+	if err := a.AddNode(ctx, &Node{ID: "n1", Name: "Go", Type: "Technology", Embedding: []float32{1, 0}}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	vectors := NewSQLiteVectorStore(a.DB()).WithNamespace("a")
+	if err := vectors.Add(ctx, "n1", []float32{1, 0}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	if err := a.ResetEmbeddings(ctx, 3); err != nil {
+		t.Fatalf("ResetEmbeddings failed: %v", err)
+	}
+	if cfg, _ := a.EmbeddingConfig(ctx); cfg.Dimensions != 3 {
+		t.Errorf("expected 3 dimensions recorded, got %+v", cfg)
+	}
+	node, _ := a.GetNode(ctx, "n1")
+	if len(node.Embedding) != 0 {
+		t.Errorf("expected the node's embedding to be cleared, got %v", node.Embedding)
+	}
+	if err := vectors.Add(ctx, "n1", []float32{0, 1, 0}); err != nil {
+		t.Fatalf("Add at the new size failed: %v", err)
+	}
+
+	// The new size survives reopening
+	b, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	defer b.Close()
+	b.WithNamespace("b")
+	if n, _ := vecTableDimensions(ctx, b.DB(), "vec_nodes"); n != 3 {
+		t.Errorf("expected vec_nodes to keep 3 dimensions, got %d", n)
+	}
+
+	// Another namespace's embeddings block a reset
+	if err := b.ResetEmbeddings(ctx, 5); err == nil {
+		t.Error("expected ResetEmbeddings to refuse while namespace a holds embeddings")
+	}
+}
+
+func TestMemoryVectorStore_DimensionMismatch(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryVectorStore()
+	if err := s.Add(ctx, "a", []float32{1, 0}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := s.Add(ctx, "b", []float32{1, 0, 0}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("expected a dimension mismatch on Add, got %v", err)
+	}
+	if _, err := s.Search(ctx, []float32{1, 0, 0}, 1); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("expected a dimension mismatch on Search, got %v", err)
+	}
+
+	// A cleared store accepts a new size
+	s.Clear()
+	if err := s.Add(ctx, "b", []float32{1, 0, 0}); err != nil {
+		t.Errorf("expected a cleared store to accept a new size, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package store
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDecryption is returned when an encrypted field cannot be decrypted: the key is
+// wrong or missing, or the stored value is corrupted.
+var ErrDecryption = errors.New("failed to decrypt field")
+
+// encryptedFieldPrefix marks encrypted values, so plaintext written before encryption was
+// enabled stays readable.
+const encryptedFieldPrefix = "gognee:aes-gcm:v1:"
+
+// FieldCipher encrypts sensitive text fields with AES-GCM before they are written:
+// memory context and decisions (including their revisions) and node descriptions.
+// Names, types, topics and metadata stay in plaintext for lookups.
+//
+// Encrypted fields can't be matched inside SQL: keyword search skips node descriptions,
+// and graph queries can't compare them. A nil *FieldCipher leaves fields in plaintext.
+type FieldCipher struct {
+	aead cipher.AEAD
+}
+
+// NewFieldCipher creates a cipher from an AES key of 16, 24 or 32 bytes (AES-128, -192
+// or -256). Keep the key outside the database; data encrypted with a lost key is gone.
+func NewFieldCipher(key []byte) (*FieldCipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, Invalidf("invalid encryption key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES-GCM: %w", err)
+	}
+	return &FieldCipher{aead: aead}, nil
+}
+
+// Encrypt returns plaintext sealed with a random nonce, base64-encoded behind a marker
+// prefix. Empty values stay empty. A nil cipher returns plaintext unchanged.
+func (c *FieldCipher) Encrypt(plaintext string) (string, error) {
+	if c == nil || plaintext == "" {
+		return plaintext, nil
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedFieldPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt reverses Encrypt. Values without the marker prefix are plaintext and returned
+// unchanged. Encrypted values fail with ErrDecryption under a nil cipher or the wrong key.
+func (c *FieldCipher) Decrypt(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, encryptedFieldPrefix)
+	if !ok {
+		return value, nil
+	}
+	if c == nil {
+		return "", fmt.Errorf("%w: field is encrypted but no key is configured", ErrDecryption)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", fmt.Errorf("%w: malformed value", ErrDecryption)
+	}
+	nonceSize := c.aead.NonceSize()
+	plaintext, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: wrong key or corrupted value", ErrDecryption)
+	}
+	return string(plaintext), nil
+}
+
+// encryptBytes is Encrypt for JSON columns scanned as bytes.
+func (c *FieldCipher) encryptBytes(plaintext []byte) ([]byte, error) {
+	encrypted, err := c.Encrypt(string(plaintext))
+	return []byte(encrypted), err
+}
+
+// decryptBytes is Decrypt for JSON columns scanned as bytes.
+func (c *FieldCipher) decryptBytes(value []byte) ([]byte, error) {
+	if !strings.HasPrefix(string(value), encryptedFieldPrefix) {
+		return value, nil
+	}
+	plaintext, err := c.Decrypt(string(value))
+	return []byte(plaintext), err
+}
+
+// WithEncryption encrypts node descriptions and edge evidence written by this store with
+// c and decrypts them on read, along with the encrypted fields of outbox payloads (use
+// the memory store's cipher too). A nil cipher (the default) stores them in plaintext.
+func (s *SQLiteGraphStore) WithEncryption(c *FieldCipher) *SQLiteGraphStore {
+	s.cipher = c
+	return s
+}
+
+// WithEncryption encrypts memory context and decisions written by this store with c and
+// decrypts them on read. A nil cipher (the default) stores them in plaintext.
+func (s *SQLiteMemoryStore) WithEncryption(c *FieldCipher) *SQLiteMemoryStore {
+	s.cipher = c
+	return s
+}
+
+// sealContent encrypts a memory's context and serialized decisions for storage.
+func (s *SQLiteMemoryStore) sealContent(memoryContext string, decisionsJSON []byte) (string, []byte, error) {
+	memoryContext, err := s.cipher.Encrypt(memoryContext)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encrypt context: %w", err)
+	}
+	decisionsJSON, err = s.cipher.encryptBytes(decisionsJSON)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encrypt decisions: %w", err)
+	}
+	return memoryContext, decisionsJSON, nil
+}
+
+// openContent decrypts a memory's context and serialized decisions in place.
+func (s *SQLiteMemoryStore) openContent(memoryContext *string, decisionsJSON *[]byte) error {
+	var err error
+	if *memoryContext, err = s.cipher.Decrypt(*memoryContext); err != nil {
+		return err
+	}
+	*decisionsJSON, err = s.cipher.decryptBytes(*decisionsJSON)
+	return err
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFieldCipher(t *testing.T) {
+	c, err := NewFieldCipher(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatalf("NewFieldCipher failed: %v", err)
+	}
+
+	sealed, err := c.Encrypt("we chose SQLite")
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if !strings.HasPrefix(sealed, encryptedFieldPrefix) || strings.Contains(sealed, "SQLite") {
+		t.Errorf("expected a marked ciphertext, got %q", sealed)
+	}
+	if again, _ := c.Encrypt("we chose SQLite"); again == sealed {
+		t.Error("expected a fresh nonce per encryption")
+	}
+	if opened, err := c.Decrypt(sealed); err != nil || opened != "we chose SQLite" {
+		t.Errorf("Decrypt = %q, %v", opened, err)
+	}
+
+	// Plaintext from before encryption was enabled passes through
+	if opened, err := c.Decrypt("legacy"); err != nil || opened != "legacy" {
+		t.Errorf("Decrypt(plaintext) = %q, %v", opened, err)
+	}
+	if empty, _ := c.Encrypt(""); empty != "" {
+		t.Errorf("expected empty values to stay empty, got %q", empty)
+	}
+
+	other, _ := NewFieldCipher(bytes.Repeat([]byte{2}, 16))
+	if _, err := other.Decrypt(sealed); !errors.Is(err, ErrDecryption) {
+		t.Errorf("expected ErrDecryption for the wrong key, got %v", err)
+	}
+	var none *FieldCipher
+	if _, err := none.Decrypt(sealed); !errors.Is(err, ErrDecryption) {
+		t.Errorf("expected ErrDecryption without a key, got %v", err)
+	}
+	if _, err := NewFieldCipher([]byte("short")); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ErrValidation for a short key, got %v", err)
+	}
+}
+
+func TestEncryption_StoresCiphertext(t *testing.T) {
+	ctx := context.Background()
+	graphStore := setupTestStore(t)
+	c, _ := NewFieldCipher(bytes.Repeat([]byte{7}, 32))
+	graphStore.WithEncryption(c)
+	memStore := NewSQLiteMemoryStore(graphStore.DB()).WithEncryption(c)
+
+	if err := graphStore.AddNode(ctx, &Node{ID: "n1", Name: "SQLite", Type: "Technology", Description: "secret engine"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if err := graphStore.AddNode(ctx, &Node{ID: "n2", Name: "C", Type: "Technology"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if err := graphStore.AddEdge(ctx, &Edge{ID: "e1", SourceID: "n1", Relation: "WRITTEN_IN", TargetID: "n2", Evidence: "secret sentence"}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	cache := NewSQLiteLLMCache(graphStore.DB()).WithEncryption(c)
+	if err := cache.PutResponse(ctx, "k", "model", "secret response"); err != nil {
+		t.Fatalf("PutResponse failed: %v", err)
+	}
+	record := &MemoryRecord{ID: "m1", Topic: "Database", Context: "secret context", Decisions: []string{"secret decision"}, DocHash: "h"}
+	if err := memStore.AddMemory(ctx, record); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	newContext := "new secret context"
+	if err := memStore.UpdateMemory(ctx, "m1", MemoryUpdate{Context: &newContext}); err != nil {
+		t.Fatalf("UpdateMemory failed: %v", err)
+	}
+
+	// Nothing sensitive reaches the database in plaintext
+	for _, query := range []string{
+		"SELECT description FROM nodes WHERE id = 'n1'",
+		"SELECT evidence FROM edges",
+		"SELECT response FROM llm_cache",
+		"SELECT context || decisions_json FROM memories",
+		"SELECT context || decisions_json FROM memory_revisions",
+	} {
+		var stored string
+		if err := graphStore.DB().QueryRow(query).Scan(&stored); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		if strings.Contains(stored, "secret") {
+			t.Errorf("%s returned plaintext %q", query, stored)
+		}
+	}
+
+	if node, err := graphStore.GetNode(ctx, "n1"); err != nil || node.Description != "secret engine" {
+		t.Errorf("GetNode = %+v, %v", node, err)
+	}
+	if edge, err := graphStore.GetEdge(ctx, "e1"); err != nil || edge.Evidence != "secret sentence" {
+		t.Errorf("GetEdge = %+v, %v", edge, err)
+	}
+	if response, ok, err := cache.GetResponse(ctx, "k"); err != nil || !ok || response != "secret response" {
+		t.Errorf("GetResponse = %q, %v, %v", response, ok, err)
+	}
+	memory, err := memStore.GetMemory(ctx, "m1")
+	if err != nil || memory.Context != newContext || len(memory.Decisions) != 1 || memory.Decisions[0] != "secret decision" {
+		t.Errorf("GetMemory = %+v, %v", memory, err)
+	}
+	history, err := memStore.GetMemoryHistory(ctx, "m1")
+	if err != nil || len(history) != 2 || history[0].Context != "secret context" {
+		t.Errorf("GetMemoryHistory = %+v, %v", history, err)
+	}
+	summaries, err := memStore.ListMemories(ctx, ListMemoriesOptions{})
+	if err != nil || len(summaries) != 1 || summaries[0].Preview != newContext || summaries[0].DecisionCount != 1 {
+		t.Errorf("ListMemories = %+v, %v", summaries, err)
+	}
+
+	// Without the key, reads fail instead of returning ciphertext
+	unkeyed := NewSQLiteMemoryStore(graphStore.DB())
+	if _, err := unkeyed.GetMemory(ctx, "m1"); !errors.Is(err, ErrDecryption) {
+		t.Errorf("expected ErrDecryption without the key, got %v", err)
+	}
+}
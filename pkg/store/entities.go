@@ -0,0 +1,378 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AliasesMetadataKey is the node metadata key listing the names of entities merged into the node.
+const AliasesMetadataKey = "aliases"
+
+// EntityMerge is an audit record of one entity node being merged into a canonical node.
+type EntityMerge struct {
+	ID           string    `json:"id"`
+	FromNodeID   string    `json:"from_node_id"` // Alias node that was removed
+	FromName     string    `json:"from_name"`    // Alias name, recorded in the canonical node's metadata
+	ToNodeID     string    `json:"to_node_id"`   // Canonical node it was merged into
+	ToName       string    `json:"to_name"`
+	Type         string    `json:"type"`          // Entity type shared by both nodes
+	Method       string    `json:"method"`        // "exact", "embedding" or "llm"
+	Similarity   float64   `json:"similarity"`    // Cosine similarity of the node embeddings (1.0 for exact matches)
+	EdgesUpdated int64     `json:"edges_updated"` // Edges repointed to the canonical node or dropped as duplicates
+	MergedAt     time.Time `json:"merged_at"`
+}
+
+// migrateEntityMergeSchema creates the entity merge audit table.
+func (s *SQLiteGraphStore) migrateEntityMergeSchema() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS entity_merges (
+		id TEXT PRIMARY KEY,
+		from_node_id TEXT NOT NULL,
+		from_name TEXT NOT NULL,
+		to_node_id TEXT NOT NULL,
+		to_name TEXT NOT NULL,
+		type TEXT,
+		method TEXT NOT NULL,
+		similarity REAL DEFAULT 1.0,
+		edges_updated INTEGER DEFAULT 0,
+		merged_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_entity_merges_from ON entity_merges(from_node_id);
+	`
+
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create entity_merges table: %w", err)
+	}
+	return nil
+}
+
+// MergeNodes merges the node fromID into the node toID and deletes fromID.
+// Edges are repointed to toID; edges that would become self-loops or duplicate an
+// existing edge (same endpoints and relation) are dropped, with their memory and document
+// provenance moved to the surviving edge. Provenance, mention counts and pin state are
+// carried over, fromID's description history is moved to toID, and fromID's name and
+// aliases are appended to toID's "aliases" metadata. Derived edge IDs (see DerivedEdgeID)
+// are re-keyed to the new endpoints. Returns the number of edges repointed or dropped,
+// and which edges were dropped and which written.
+func (s *SQLiteGraphStore) MergeNodes(ctx context.Context, fromID, toID string) (int64, EdgeChanges, error) {
+	if fromID == toID {
+		return 0, EdgeChanges{}, fmt.Errorf("cannot merge node %s into itself", fromID)
+	}
+
+	from, err := s.GetNode(ctx, fromID)
+	if err != nil {
+		return 0, EdgeChanges{}, err
+	}
+	to, err := s.GetNode(ctx, toID)
+	if err != nil {
+		return 0, EdgeChanges{}, err
+	}
+	if from == nil || to == nil {
+		return 0, EdgeChanges{}, fmt.Errorf("cannot merge %s into %s: %w", fromID, toID, ErrNodeNotFound)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	changes, err := mergeEdges(ctx, tx, s.namespace, fromID, toID)
+	if err != nil {
+		return 0, EdgeChanges{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT OR IGNORE INTO memory_nodes (memory_id, node_id, created_at)
+		SELECT memory_id, ?, created_at FROM memory_nodes WHERE node_id = ?
+	`, toID, fromID); err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("failed to move node provenance: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM memory_nodes WHERE node_id = ?", fromID); err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("failed to delete node provenance: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT OR IGNORE INTO document_nodes (document_id, node_id)
+		SELECT document_id, ? FROM document_nodes WHERE node_id = ?
+	`, toID, fromID); err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("failed to move node provenance: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM document_nodes WHERE node_id = ?", fromID); err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("failed to delete node provenance: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE node_descriptions SET node_id = ? WHERE node_id = ? AND namespace = ?",
+		toID, fromID, s.namespace); err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("failed to move description history: %w", err)
+	}
+
+	if to.Metadata == nil {
+		to.Metadata = make(map[string]interface{})
+	}
+	aliases := append(metadataAliases(to.Metadata), from.Name)
+	aliases = append(aliases, metadataAliases(from.Metadata)...)
+	to.Metadata[AliasesMetadataKey] = aliases
+	metadataJSON, err := json.Marshal(to.Metadata)
+	if err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	pinnedAt, pinReason := to.PinnedAt, to.PinReason
+	if !to.Pinned && from.Pinned {
+		pinnedAt, pinReason = from.PinnedAt, from.PinReason
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE nodes SET metadata = ?, mention_count = mention_count + ?, pinned = ?, pinned_at = ?, pin_reason = ?
+		WHERE id = ? AND namespace = ?
+	`, metadataJSON, from.MentionCount, to.Pinned || from.Pinned, pinnedAt, pinReason, toID, s.namespace); err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("failed to update canonical node: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM nodes WHERE id = ? AND namespace = ?", fromID, s.namespace); err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("failed to delete merged node: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return int64(len(changes.Removed) + len(changes.Written)), changes, nil
+}
+
+// mergeEdges repoints the edges incident to fromID at toID within tx, returning the
+// edges it dropped and the edges it wrote.
+func mergeEdges(ctx context.Context, tx *sql.Tx, namespace, fromID, toID string) (EdgeChanges, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, source_id, relation, target_id, valid_from, valid_to FROM edges
+		WHERE (source_id = ? OR target_id = ?) AND namespace = ?
+	`, fromID, fromID, namespace)
+	if err != nil {
+		return EdgeChanges{}, fmt.Errorf("failed to query edges: %w", err)
+	}
+
+	var edges []Edge
+	for rows.Next() {
+		var e Edge
+		var validFrom, validTo sql.NullTime
+		if err := rows.Scan(&e.ID, &e.SourceID, &e.Relation, &e.TargetID, &validFrom, &validTo); err != nil {
+			rows.Close()
+			return EdgeChanges{}, fmt.Errorf("failed to scan edge: %w", err)
+		}
+		e.ValidFrom, e.ValidTo = validityTimes(validFrom, validTo)
+		edges = append(edges, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return EdgeChanges{}, fmt.Errorf("error iterating edges: %w", err)
+	}
+
+	var changes EdgeChanges
+	for _, old := range edges {
+		e := old
+		if e.SourceID == fromID {
+			e.SourceID = toID
+		}
+		if e.TargetID == fromID {
+			e.TargetID = toID
+		}
+
+		// A relation between the two aliases collapses into a self-loop; drop it
+		if e.SourceID == e.TargetID {
+			if err := deleteMergedEdge(ctx, tx, e.ID, ""); err != nil {
+				return EdgeChanges{}, err
+			}
+			changes.Removed = append(changes.Removed, e.ID)
+			continue
+		}
+
+		if err := rewriteEdge(ctx, tx, namespace, old, e, &changes); err != nil {
+			return EdgeChanges{}, err
+		}
+	}
+
+	return changes, nil
+}
+
+// DerivedEdgeID returns the ID Cognify gives an edge: its endpoint IDs around the
+// relation, upper-cased with underscores for spaces, then its validity period (see
+// Edge.ValidFrom) if bounded, e.g. "a-WORKS_AT-b@2019-01-01..2023-01-01". The same
+// relation holding over different periods thus yields distinct edges.
+func DerivedEdgeID(sourceID, relation, targetID string, validFrom, validTo *time.Time) string {
+	id := sourceID + "-" + strings.ToUpper(strings.ReplaceAll(relation, " ", "_")) + "-" + targetID
+	if validFrom == nil && validTo == nil {
+		return id
+	}
+	return id + "@" + validityBound(validFrom) + ".." + validityBound(validTo)
+}
+
+// validityBound formats one end of a validity period for DerivedEdgeID: a date when it
+// falls on midnight UTC, an RFC 3339 timestamp otherwise, empty when unbounded.
+func validityBound(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	utc := t.UTC()
+	if utc.Equal(utc.Truncate(24 * time.Hour)) {
+		return utc.Format(time.DateOnly)
+	}
+	return utc.Format(time.RFC3339)
+}
+
+// rewriteEdge gives the edge old the endpoints and relation of e within tx, recording
+// the change. An edge already linking them with the same relation, or holding the
+// rewritten ID, survives and takes over old's provenance. A derived ID (see
+// DerivedEdgeID) is re-keyed, so extracting the same relation again updates the edge
+// instead of adding a parallel one. Edges must carry their validity period, since
+// edges holding over different periods are not duplicates.
+func rewriteEdge(ctx context.Context, tx *sql.Tx, namespace string, old, e Edge, changes *EdgeChanges) error {
+	newID := old.ID
+	// Edges derived before IDs included the validity period are re-keyed too
+	if old.ID == DerivedEdgeID(old.SourceID, old.Relation, old.TargetID, old.ValidFrom, old.ValidTo) ||
+		old.ID == DerivedEdgeID(old.SourceID, old.Relation, old.TargetID, nil, nil) {
+		newID = DerivedEdgeID(e.SourceID, e.Relation, e.TargetID, e.ValidFrom, e.ValidTo)
+	}
+
+	var survivor string
+	err := tx.QueryRowContext(ctx, `
+		SELECT id FROM edges
+		WHERE ((source_id = ? AND relation = ? AND target_id = ? AND valid_from IS ? AND valid_to IS ?) OR id = ?)
+			AND id != ? AND namespace = ?
+		LIMIT 1
+	`, e.SourceID, e.Relation, e.TargetID, nullUTC(e.ValidFrom), nullUTC(e.ValidTo), newID, old.ID, namespace).Scan(&survivor)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to check duplicate edge: %w", err)
+	}
+	if survivor != "" {
+		if err := deleteMergedEdge(ctx, tx, old.ID, survivor); err != nil {
+			return err
+		}
+		changes.Removed = append(changes.Removed, old.ID)
+		return nil
+	}
+
+	if newID == old.ID {
+		if _, err := tx.ExecContext(ctx, "UPDATE edges SET source_id = ?, relation = ?, target_id = ? WHERE id = ?",
+			e.SourceID, e.Relation, e.TargetID, old.ID); err != nil {
+			return fmt.Errorf("failed to rewrite edge: %w", err)
+		}
+		changes.Written = append(changes.Written, old.ID)
+		return nil
+	}
+
+	// Copy the edge under its new ID, then drop the old one with its provenance moved
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO edges (id, source_id, relation, target_id, weight, created_at, expires_at,
+			source_chunk_id, evidence, namespace, valid_from, valid_to)
+		SELECT ?, ?, ?, ?, weight, created_at, expires_at, source_chunk_id, evidence, namespace, valid_from, valid_to
+		FROM edges WHERE id = ?
+	`, newID, e.SourceID, e.Relation, e.TargetID, old.ID); err != nil {
+		return fmt.Errorf("failed to re-key edge: %w", classifyWriteError(err))
+	}
+	if err := deleteMergedEdge(ctx, tx, old.ID, newID); err != nil {
+		return err
+	}
+	changes.Removed = append(changes.Removed, old.ID)
+	changes.Written = append(changes.Written, newID)
+	return nil
+}
+
+// deleteMergedEdge deletes an edge made redundant by a merge, moving its memory and
+// document provenance to survivor (if any).
+func deleteMergedEdge(ctx context.Context, tx *sql.Tx, edgeID, survivor string) error {
+	if survivor != "" {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO memory_edges (memory_id, edge_id, created_at)
+			SELECT memory_id, ?, created_at FROM memory_edges WHERE edge_id = ?
+		`, survivor, edgeID); err != nil {
+			return fmt.Errorf("failed to move edge provenance: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO document_edges (document_id, edge_id)
+			SELECT document_id, ? FROM document_edges WHERE edge_id = ?
+		`, survivor, edgeID); err != nil {
+			return fmt.Errorf("failed to move edge provenance: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM memory_edges WHERE edge_id = ?", edgeID); err != nil {
+		return fmt.Errorf("failed to delete edge provenance: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM document_edges WHERE edge_id = ?", edgeID); err != nil {
+		return fmt.Errorf("failed to delete edge provenance: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM edges WHERE id = ?", edgeID); err != nil {
+		return fmt.Errorf("failed to delete duplicate edge: %w", err)
+	}
+	return nil
+}
+
+// metadataAliases returns the alias names recorded in node metadata.
+func metadataAliases(metadata map[string]interface{}) []string {
+	var aliases []string
+	switch v := metadata[AliasesMetadataKey].(type) {
+	case []string:
+		aliases = append(aliases, v...)
+	case []interface{}:
+		for _, a := range v {
+			if name, ok := a.(string); ok {
+				aliases = append(aliases, name)
+			}
+		}
+	}
+	return aliases
+}
+
+// RecordEntityMerge appends a merge to the entity_merges audit table.
+func (s *SQLiteGraphStore) RecordEntityMerge(ctx context.Context, merge *EntityMerge) error {
+	if merge.ID == "" {
+		merge.ID = uuid.New().String()
+	}
+	if merge.MergedAt.IsZero() {
+		merge.MergedAt = time.Now()
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO entity_merges (id, from_node_id, from_name, to_node_id, to_name, type, method, similarity, edges_updated, merged_at, namespace)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, merge.ID, merge.FromNodeID, merge.FromName, merge.ToNodeID, merge.ToName, merge.Type, merge.Method,
+		merge.Similarity, merge.EdgesUpdated, merge.MergedAt, s.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to record entity merge: %w", err)
+	}
+	return nil
+}
+
+// ListEntityMerges returns the entity merge audit log, oldest first.
+func (s *SQLiteGraphStore) ListEntityMerges(ctx context.Context) ([]EntityMerge, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, from_node_id, from_name, to_node_id, to_name, COALESCE(type, ''), method, similarity, edges_updated, merged_at
+		FROM entity_merges
+		WHERE namespace = ?
+		ORDER BY merged_at, id
+	`, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query entity merges: %w", err)
+	}
+	defer rows.Close()
+
+	var merges []EntityMerge
+	for rows.Next() {
+		var m EntityMerge
+		if err := rows.Scan(&m.ID, &m.FromNodeID, &m.FromName, &m.ToNodeID, &m.ToName, &m.Type, &m.Method,
+			&m.Similarity, &m.EdgesUpdated, &m.MergedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan entity merge: %w", err)
+		}
+		merges = append(merges, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating entity merges: %w", err)
+	}
+
+	return merges, nil
+}
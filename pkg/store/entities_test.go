@@ -0,0 +1,212 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMergeNodes(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+
+	for _, n := range []*Node{
+		{ID: "pg", Name: "PostgreSQL", Type: "Technology", Metadata: map[string]interface{}{"aliases": []string{"PG"}}},
+		{ID: "postgres", Name: "Postgres", Type: "Technology"},
+		{ID: "billing", Name: "Billing", Type: "System"},
+	} {
+		if err := s.AddNode(ctx, n); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	if err := s.IncrementMentionCounts(ctx, []string{"postgres", "pg"}); err != nil {
+		t.Fatalf("IncrementMentionCounts failed: %v", err)
+	}
+	if err := s.SetNodePinned(ctx, "postgres", true, "core dependency"); err != nil {
+		t.Fatalf("SetNodePinned failed: %v", err)
+	}
+	for _, e := range []*Edge{
+		{ID: "e1", SourceID: "billing", Relation: "USES", TargetID: "pg"},
+		{ID: "e2", SourceID: "billing", Relation: "USES", TargetID: "postgres"},
+		{ID: "e3", SourceID: "postgres", Relation: "STORES", TargetID: "billing"},
+	} {
+		if err := s.AddEdge(ctx, e); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+
+	mem := NewSQLiteMemoryStore(s.DB())
+	record := &MemoryRecord{Topic: "Billing", Context: "Billing uses Postgres", DocHash: ComputeDocHash("Billing", "Billing uses Postgres", nil, nil), Status: "complete"}
+	if err := mem.AddMemory(ctx, record); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if err := mem.LinkProvenance(ctx, record.ID, []string{"postgres", "billing"}, []string{"e2", "e3"}); err != nil {
+		t.Fatalf("LinkProvenance failed: %v", err)
+	}
+
+	updated, changes, err := s.MergeNodes(ctx, "postgres", "pg")
+	if err != nil {
+		t.Fatalf("MergeNodes failed: %v", err)
+	}
+	if updated != 2 {
+		t.Errorf("Expected 2 edges updated, got %d", updated)
+	}
+	if len(changes.Removed) != 1 || changes.Removed[0] != "e2" || len(changes.Written) != 1 || changes.Written[0] != "e3" {
+		t.Errorf("Expected e2 removed and e3 written, got %+v", changes)
+	}
+
+	if n, _ := s.GetNode(ctx, "postgres"); n != nil {
+		t.Error("Merged node should be deleted")
+	}
+	pg, err := s.GetNode(ctx, "pg")
+	if err != nil || pg == nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	if pg.MentionCount != 2 {
+		t.Errorf("Expected mention counts summed to 2, got %d", pg.MentionCount)
+	}
+	if !pg.Pinned || pg.PinReason != "core dependency" {
+		t.Errorf("Expected pin carried over, got pinned=%v reason=%q", pg.Pinned, pg.PinReason)
+	}
+	if aliases := metadataAliases(pg.Metadata); len(aliases) != 2 || aliases[0] != "PG" || aliases[1] != "Postgres" {
+		t.Errorf("Unexpected aliases: %v", aliases)
+	}
+
+	// e2 duplicated e1 and was dropped; its provenance moved to e1
+	nodeIDs, edgeIDs, err := mem.GetProvenanceByMemory(ctx, record.ID)
+	if err != nil {
+		t.Fatalf("GetProvenanceByMemory failed: %v", err)
+	}
+	nodes, edges := map[string]bool{}, map[string]bool{}
+	for _, id := range nodeIDs {
+		nodes[id] = true
+	}
+	for _, id := range edgeIDs {
+		edges[id] = true
+	}
+	if len(nodes) != 2 || !nodes["pg"] || !nodes["billing"] {
+		t.Errorf("Unexpected node provenance: %v", nodeIDs)
+	}
+	if len(edges) != 2 || !edges["e1"] || !edges["e3"] {
+		t.Errorf("Unexpected edge provenance: %v", edgeIDs)
+	}
+
+	e3, err := s.GetEdge(ctx, "e3")
+	if err != nil || e3 == nil || e3.SourceID != "pg" {
+		t.Errorf("Expected e3 repointed to pg, got %+v (err %v)", e3, err)
+	}
+}
+
+func TestMergeNodes_RekeysDerivedEdgeIDs(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+
+	for _, n := range []*Node{
+		{ID: "pg", Name: "PostgreSQL", Type: "Technology"},
+		{ID: "postgres", Name: "Postgres", Type: "Technology"},
+		{ID: "billing", Name: "Billing", Type: "System"},
+	} {
+		if err := s.AddNode(ctx, n); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	stores := &Edge{ID: DerivedEdgeID("postgres", "stores", "billing", nil, nil), SourceID: "postgres", Relation: "stores", TargetID: "billing", Evidence: "Postgres stores billing data"}
+	if err := s.AddEdge(ctx, stores); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	mem := NewSQLiteMemoryStore(s.DB())
+	record := &MemoryRecord{Topic: "Billing", Context: "Postgres stores billing data", DocHash: "h", Status: "complete"}
+	if err := mem.AddMemory(ctx, record); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if err := mem.LinkProvenance(ctx, record.ID, nil, []string{stores.ID}); err != nil {
+		t.Fatalf("LinkProvenance failed: %v", err)
+	}
+
+	_, changes, err := s.MergeNodes(ctx, "postgres", "pg")
+	if err != nil {
+		t.Fatalf("MergeNodes failed: %v", err)
+	}
+	rekeyed := DerivedEdgeID("pg", "stores", "billing", nil, nil)
+	if len(changes.Removed) != 1 || changes.Removed[0] != stores.ID || len(changes.Written) != 1 || changes.Written[0] != rekeyed {
+		t.Errorf("Expected %s re-keyed to %s, got %+v", stores.ID, rekeyed, changes)
+	}
+	if old, _ := s.GetEdge(ctx, stores.ID); old != nil {
+		t.Errorf("Expected the alias-keyed edge to be gone, got %+v", old)
+	}
+	edge, err := s.GetEdge(ctx, rekeyed)
+	if err != nil || edge == nil || edge.SourceID != "pg" || edge.Evidence != stores.Evidence {
+		t.Fatalf("Expected the edge under %s, got %+v (err %v)", rekeyed, edge, err)
+	}
+	if _, edgeIDs, _ := mem.GetProvenanceByMemory(ctx, record.ID); len(edgeIDs) != 1 || edgeIDs[0] != rekeyed {
+		t.Errorf("Expected provenance moved to %s, got %v", rekeyed, edgeIDs)
+	}
+
+	// Extracting the relation again under the canonical name updates the same edge
+	if err := s.AddEdge(ctx, &Edge{ID: rekeyed, SourceID: "pg", Relation: "stores", TargetID: "billing"}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	if edges, _ := s.GetEdges(ctx, "billing"); len(edges) != 1 {
+		t.Errorf("Expected one edge between pg and billing, got %d", len(edges))
+	}
+}
+
+func TestMergeNodes_KeepsEdgesOfDistinctPeriods(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+
+	for _, n := range []*Node{
+		{ID: "alice", Name: "Alice", Type: "Person"},
+		{ID: "ally", Name: "Ally", Type: "Person"},
+		{ID: "acme", Name: "Acme", Type: "Organization"},
+	} {
+		if err := s.AddNode(ctx, n); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	y2019 := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	y2021 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	y2023 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := &Edge{ID: DerivedEdgeID("alice", "WORKS_AT", "acme", &y2019, &y2021), SourceID: "alice", Relation: "WORKS_AT", TargetID: "acme", ValidFrom: &y2019, ValidTo: &y2021}
+	// Derived before IDs carried the validity period
+	second := &Edge{ID: DerivedEdgeID("ally", "WORKS_AT", "acme", nil, nil), SourceID: "ally", Relation: "WORKS_AT", TargetID: "acme", ValidFrom: &y2023}
+	for _, e := range []*Edge{first, second} {
+		if err := s.AddEdge(ctx, e); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+	if first.ID != "alice-WORKS_AT-acme@2019-01-01..2021-01-01" {
+		t.Errorf("unexpected derived ID %q", first.ID)
+	}
+
+	if _, _, err := s.MergeNodes(ctx, "ally", "alice"); err != nil {
+		t.Fatalf("MergeNodes failed: %v", err)
+	}
+	edges, err := s.GetEdges(ctx, "acme")
+	if err != nil {
+		t.Fatalf("GetEdges failed: %v", err)
+	}
+	ids := make(map[string]bool)
+	for _, e := range edges {
+		ids[e.ID] = true
+	}
+	rekeyed := DerivedEdgeID("alice", "WORKS_AT", "acme", &y2023, nil)
+	if len(edges) != 2 || !ids[first.ID] || !ids[rekeyed] {
+		t.Errorf("expected both periods kept as %s and %s, got %v", first.ID, rekeyed, ids)
+	}
+}
+
+func TestMergeNodes_Errors(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+
+	if err := s.AddNode(ctx, &Node{ID: "a", Name: "A"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if _, _, err := s.MergeNodes(ctx, "a", "a"); err == nil {
+		t.Error("Expected error merging a node into itself")
+	}
+	if _, _, err := s.MergeNodes(ctx, "missing", "a"); err == nil {
+		t.Error("Expected error merging a missing node")
+	}
+}
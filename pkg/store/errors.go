@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// ErrValidation is matched (via errors.Is) by every ValidationError.
+var ErrValidation = errors.New("invalid argument")
+
+// ValidationError reports arguments or options that are invalid before any work is
+// done, such as a negative fan-out or an empty time range.
+type ValidationError struct {
+	Err error
+}
+
+// Invalidf returns a ValidationError with a formatted message; %w wraps as in fmt.Errorf.
+func Invalidf(format string, args ...interface{}) error {
+	return &ValidationError{Err: fmt.Errorf(format, args...)}
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is(err, ErrValidation) match.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// ErrConflict is matched by write errors caused by the stored state rather than the
+// request's form: a violated uniqueness or foreign key constraint, or a database busy
+// or locked by another writer. Busy and locked writes may succeed when retried.
+var ErrConflict = errors.New("store conflict")
+
+// conflictError marks a SQLite error as an ErrConflict
+type conflictError struct {
+	err error
+}
+
+func (e *conflictError) Error() string {
+	return e.err.Error()
+}
+
+func (e *conflictError) Unwrap() error {
+	return e.err
+}
+
+// Is makes errors.Is(err, ErrConflict) match.
+func (e *conflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// ErrCancelled is matched by errors from operations stopped because their context was
+// canceled or its deadline passed. Such errors also match the context's own error.
+var ErrCancelled = errors.New("operation cancelled")
+
+// cancelledError wraps ctx.Err() so it matches both ErrCancelled and the context error.
+func cancelledError(ctx context.Context) error {
+	return fmt.Errorf("%w: %w", ErrCancelled, ctx.Err())
+}
+
+// classifyWriteError marks constraint, busy and locked SQLite errors as conflicts and
+// returns other errors unchanged.
+func classifyWriteError(err error) error {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+	switch sqliteErr.Code {
+	case sqlite3.ErrConstraint, sqlite3.ErrBusy, sqlite3.ErrLocked:
+		return &conflictError{err: err}
+	}
+	return err
+}
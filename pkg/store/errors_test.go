@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestErrorTaxonomy(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// An edge to a missing node violates the foreign key
+	err := s.AddEdge(ctx, &Edge{ID: "dangling", SourceID: "a", Relation: "KNOWS", TargetID: "missing"})
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict for a dangling edge, got %v", err)
+	}
+	if err := s.AddEdges(ctx, []*Edge{{ID: "dangling", SourceID: "a", Relation: "KNOWS", TargetID: "missing"}}); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict from AddEdges, got %v", err)
+	}
+
+	if _, err := s.TraverseNeighbors(ctx, "a", 0, TraversalOptions{}); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ErrValidation for depth 0, got %v", err)
+	}
+	if err := (TraversalOptions{MaxFanOut: -1}).Validate(); !errors.Is(err, ErrValidation) || errors.Is(err, ErrConflict) {
+		t.Errorf("expected only ErrValidation for a negative fan-out, got %v", err)
+	}
+}
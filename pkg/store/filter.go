@@ -0,0 +1,76 @@
+package store
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// NodeFilter restricts search results by node type and creation time. The zero value
+// matches every node.
+type NodeFilter struct {
+	// Types keeps only nodes of one of these types (exact match), e.g. ["Decision"].
+	Types []string `json:"types,omitempty"`
+	// CreatedAfter keeps only nodes created at or after this time.
+	CreatedAfter time.Time `json:"created_after,omitzero"`
+	// CreatedBefore keeps only nodes created before this time.
+	CreatedBefore time.Time `json:"created_before,omitzero"`
+}
+
+// IsZero reports whether the filter matches every node.
+func (f NodeFilter) IsZero() bool {
+	return len(f.Types) == 0 && f.CreatedAfter.IsZero() && f.CreatedBefore.IsZero()
+}
+
+// Validate reports whether the filter can match anything.
+func (f NodeFilter) Validate() error {
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && !f.CreatedAfter.Before(f.CreatedBefore) {
+		return Invalidf("created_after (%s) must be before created_before (%s)",
+			f.CreatedAfter.Format(time.RFC3339), f.CreatedBefore.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Matches reports whether a node passes the filter.
+func (f NodeFilter) Matches(node *Node) bool {
+	if node == nil {
+		return false
+	}
+	return f.matches(node.Type, node.CreatedAt)
+}
+
+func (f NodeFilter) matches(nodeType string, createdAt time.Time) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, nodeType) {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && createdAt.Before(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !createdAt.Before(f.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// sqlConditions returns the filter as SQL conditions on the nodes table under alias,
+// each prefixed with AND, and their arguments. Creation times are compared with
+// julianday, since stored timestamps may carry any UTC offset.
+func (f NodeFilter) sqlConditions(alias string) (string, []interface{}) {
+	var conditions strings.Builder
+	var args []interface{}
+	if len(f.Types) > 0 {
+		conditions.WriteString(" AND " + alias + ".type IN (?" + strings.Repeat(", ?", len(f.Types)-1) + ")")
+		for _, nodeType := range f.Types {
+			args = append(args, nodeType)
+		}
+	}
+	if !f.CreatedAfter.IsZero() {
+		conditions.WriteString(" AND julianday(" + alias + ".created_at) >= julianday(?)")
+		args = append(args, f.CreatedAfter)
+	}
+	if !f.CreatedBefore.IsZero() {
+		conditions.WriteString(" AND julianday(" + alias + ".created_at) < julianday(?)")
+		args = append(args, f.CreatedBefore)
+	}
+	return conditions.String(), args
+}
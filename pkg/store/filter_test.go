@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNodeFilter_Matches(t *testing.T) {
+	now := time.Now()
+	node := &Node{ID: "n1", Type: "Decision", CreatedAt: now.Add(-48 * time.Hour)}
+
+	tests := []struct {
+		name   string
+		filter NodeFilter
+		want   bool
+	}{
+		{"zero filter", NodeFilter{}, true},
+		{"matching type", NodeFilter{Types: []string{"Person", "Decision"}}, true},
+		{"other type", NodeFilter{Types: []string{"Person"}}, false},
+		{"created after", NodeFilter{CreatedAfter: now.Add(-72 * time.Hour)}, true},
+		{"created too early", NodeFilter{CreatedAfter: now.Add(-24 * time.Hour)}, false},
+		{"created before", NodeFilter{CreatedBefore: now.Add(-24 * time.Hour)}, true},
+		{"created too late", NodeFilter{CreatedBefore: now.Add(-72 * time.Hour)}, false},
+		{"after bound is inclusive", NodeFilter{CreatedAfter: node.CreatedAt}, true},
+		{"before bound is exclusive", NodeFilter{CreatedBefore: node.CreatedAt}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(node); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if (NodeFilter{}).Matches(nil) {
+		t.Error("nil node should not match")
+	}
+}
+
+func TestNodeFilter_Validate(t *testing.T) {
+	now := time.Now()
+	if err := (NodeFilter{CreatedAfter: now.Add(-time.Hour), CreatedBefore: now}).Validate(); err != nil {
+		t.Errorf("valid range rejected: %v", err)
+	}
+	if err := (NodeFilter{CreatedAfter: now, CreatedBefore: now.Add(-time.Hour)}).Validate(); err == nil {
+		t.Error("expected error for an empty time range")
+	}
+}
+
+func TestSQLiteVectorStore_SearchFiltered(t *testing.T) {
+	ctx := context.Background()
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	vs := NewSQLiteVectorStore(db)
+
+	// Stored in a non-UTC zone to check times are compared as instants
+	zone := time.FixedZone("UTC+5", 5*60*60)
+	now := time.Now().In(zone)
+	nodes := []struct {
+		id        string
+		nodeType  string
+		createdAt time.Time
+		embedding []float32
+	}{
+		{"close-concept", "Concept", now, []float32{1, 0, 0}},
+		{"old-decision", "Decision", now.AddDate(0, -3, 0), []float32{0.9, 0.1, 0}},
+		{"new-decision", "Decision", now.AddDate(0, 0, -3), []float32{0.5, 0.5, 0}},
+	}
+	for _, n := range nodes {
+		if _, err := db.Exec(`INSERT INTO nodes (id, name, type, created_at) VALUES (?, ?, ?, ?)`,
+			n.id, n.id, n.nodeType, n.createdAt); err != nil {
+			t.Fatalf("Failed to create node %s: %v", n.id, err)
+		}
+		if err := vs.Add(ctx, n.id, n.embedding); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	query := []float32{1, 0, 0}
+	results, err := vs.SearchFiltered(ctx, query, 1, NodeFilter{Types: []string{"Decision"}})
+	if err != nil {
+		t.Fatalf("SearchFiltered failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "old-decision" {
+		t.Errorf("expected the closest Decision old-decision, got %+v", results)
+	}
+
+	lastMonth := NodeFilter{Types: []string{"Decision"}, CreatedAfter: time.Now().UTC().AddDate(0, -1, 0)}
+	results, err = vs.SearchFiltered(ctx, query, 5, lastMonth)
+	if err != nil {
+		t.Fatalf("SearchFiltered failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "new-decision" {
+		t.Errorf("expected only new-decision, got %+v", results)
+	}
+
+	results, err = vs.SearchFiltered(ctx, query, 5, NodeFilter{Types: []string{"Person"}})
+	if err != nil {
+		t.Fatalf("SearchFiltered failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no results, got %+v", results)
+	}
+}
@@ -16,22 +16,53 @@ type Node struct {
 	Embedding      []float32              // Vector embedding for semantic search
 	CreatedAt      time.Time              // Timestamp of creation
 	LastAccessedAt *time.Time             // Timestamp of last access (for decay tracking)
+	MentionCount   int64                  // Number of cognified chunks mentioning this entity
+	Pinned         bool                   // Pinned nodes are never pruned and do not decay
+	PinnedAt       *time.Time             // When the node was pinned (nil if not pinned)
+	PinReason      string                 // Why the node was pinned
 	Metadata       map[string]interface{} // Additional metadata as JSON
 }
 
 // Edge represents a relationship between two nodes in the knowledge graph.
 type Edge struct {
-	ID        string    // Unique identifier (UUID)
-	SourceID  string    // Source node ID
-	Relation  string    // Relationship type (USES, DEPENDS_ON, etc.)
-	TargetID  string    // Target node ID
-	Weight    float64   // Relationship weight (default 1.0, reserved for future ranking)
-	CreatedAt time.Time // Timestamp of creation
+	ID        string     // Unique identifier (UUID)
+	SourceID  string     // Source node ID
+	Relation  string     // Relationship type (USES, DEPENDS_ON, etc.)
+	TargetID  string     // Target node ID
+	Weight    float64    // Relationship weight (default 1.0, reserved for future ranking)
+	CreatedAt time.Time  // Timestamp of creation
+	ExpiresAt *time.Time // Optional expiration; expired edges are hidden from reads until swept
+	// SourceChunkID is the ID of the chunk the edge was extracted from (empty if unknown).
+	SourceChunkID string
+	// Evidence is the source text supporting the edge, typically the sentence naming both entities.
+	Evidence string
+	// ValidFrom and ValidTo bound when the fact held in the world ("since 2023", "until
+	// Q3"); nil means unbounded. ValidTo is exclusive. See ValidAt.
+	ValidFrom *time.Time
+	ValidTo   *time.Time
+}
+
+// ValidAt reports whether the edge's fact held at t: on or after ValidFrom and before ValidTo.
+func (e *Edge) ValidAt(t time.Time) bool {
+	if e.ValidFrom != nil && e.ValidFrom.After(t) {
+		return false
+	}
+	return e.ValidTo == nil || e.ValidTo.After(t)
+}
+
+// EdgeChanges lists the edges a graph rewrite (a node merge, a relation rename, a
+// deletion) removed and the edges whose text it changed, so callers can keep edge
+// vectors in step.
+type EdgeChanges struct {
+	Removed []string // IDs of deleted edges
+	Written []string // IDs of edges whose endpoints or relation changed
 }
 
 // GraphStore defines the interface for graph storage operations.
 // Implementations must provide persistent storage for nodes and edges,
 // supporting both direct access and graph traversal operations.
+// Optional operations live in capability interfaces (AccessTracker, MentionCounter,
+// BulkReader, Deleter) that backends may implement in addition.
 type GraphStore interface {
 	// AddNode adds or updates a node in the graph.
 	// Uses upsert semantics (INSERT OR REPLACE by ID).
@@ -52,12 +83,13 @@ type GraphStore interface {
 	FindNodeByName(ctx context.Context, name string) (*Node, error)
 
 	// AddEdge adds or updates an edge in the graph.
-	// Uses upsert semantics (INSERT OR REPLACE by ID).
+	// Uses upsert semantics: an edge with the same ID is updated in place.
 	// If Edge.ID is empty, a new UUID will be generated.
 	AddEdge(ctx context.Context, edge *Edge) error
 
 	// GetEdges retrieves all edges incident to a node (both incoming and outgoing).
 	// This is direction-agnostic: returns edges where the node is either source or target.
+	// Expired edges (ExpiresAt in the past) are excluded.
 	// Returns an empty slice if no edges are found.
 	// Cognee-aligned: treats adjacency as undirected for discovery.
 	GetEdges(ctx context.Context, nodeID string) ([]*Edge, error)
@@ -66,6 +98,7 @@ type GraphStore interface {
 	// Depth=1 returns direct neighbors only (Cognee-aligned default).
 	// Depth>1 recursively traverses the graph (gognee extension).
 	// Traversal is direction-agnostic (treats edges as undirected).
+	// Expired edges are not traversed.
 	// Returns unique nodes only (no duplicates).
 	GetNeighbors(ctx context.Context, nodeID string, depth int) ([]*Node, error)
 
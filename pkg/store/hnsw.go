@@ -0,0 +1,401 @@
+package store
+
+import (
+	"container/heap"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+)
+
+// HNSWConfig tunes the HNSW index of a MemoryVectorStore. Zero values use the defaults.
+type HNSWConfig struct {
+	// M is the number of neighbors each vector links to per layer (layer 0 keeps 2*M).
+	// Higher values improve recall at the cost of memory and insert time (default: 16).
+	M int
+
+	// EfConstruction is the candidate list size while inserting. Higher values build a
+	// better graph, more slowly (default: 100).
+	EfConstruction int
+
+	// EfSearch is the candidate list size while searching, raised to topK if smaller.
+	// Higher values improve recall at the cost of latency (default: 64).
+	EfSearch int
+}
+
+// DefaultHNSWConfig returns the default HNSW parameters.
+func DefaultHNSWConfig() HNSWConfig {
+	return HNSWConfig{M: 16, EfConstruction: 100, EfSearch: 64}
+}
+
+func (c HNSWConfig) withDefaults() HNSWConfig {
+	defaults := DefaultHNSWConfig()
+	if c.M <= 0 {
+		c.M = defaults.M
+	}
+	if c.EfConstruction <= 0 {
+		c.EfConstruction = defaults.EfConstruction
+	}
+	if c.EfSearch <= 0 {
+		c.EfSearch = defaults.EfSearch
+	}
+	return c
+}
+
+// hnswIndex is a hierarchical navigable small world graph over cosine similarity
+// (Malkov & Yashunin, 2016). It is not safe for concurrent use; MemoryVectorStore
+// serializes access.
+//
+// Deleted and replaced vectors are tombstoned rather than unlinked, so the graph stays
+// navigable; the index is rebuilt once tombstones outnumber live vectors.
+type hnswIndex struct {
+	cfg        HNSWConfig
+	levelScale float64 // 1/ln(M), the level generation factor
+	rng        *rand.Rand
+
+	nodes      []*hnswNode
+	byID       map[string]int32
+	entry      int32 // Entry point on the top layer, -1 when empty
+	maxLevel   int
+	tombstones int
+
+	visitedPool sync.Pool // *visitedSet, reused across searches
+}
+
+type hnswNode struct {
+	id        string
+	vector    []float32
+	norm      float64
+	neighbors [][]int32 // Per layer, 0..level
+	deleted   bool
+}
+
+func newHNSWIndex(cfg HNSWConfig) *hnswIndex {
+	cfg = cfg.withDefaults()
+	return &hnswIndex{
+		visitedPool: sync.Pool{New: func() any { return &visitedSet{} }},
+		cfg:         cfg,
+		levelScale:  1 / math.Log(float64(max(cfg.M, 2))),
+		rng:         rand.New(rand.NewPCG(1, 2)),
+		byID:        make(map[string]int32),
+		entry:       -1,
+	}
+}
+
+// similarity is the cosine similarity of the query with node n, matching CosineSimilarity.
+func (h *hnswIndex) similarity(query []float32, queryNorm float64, n int32) float64 {
+	node := h.nodes[n]
+	if len(query) != len(node.vector) || queryNorm == 0 || node.norm == 0 {
+		return 0
+	}
+	return float64(dot(query, node.vector)) / (queryNorm * node.norm)
+}
+
+// dot is the dot product of two vectors of equal length. Distance computations dominate
+// index time, so it is unrolled into independent float32 accumulators.
+func dot(a, b []float32) float32 {
+	b = b[:len(a)]
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		s0 += a[i] * b[i]
+		s1 += a[i+1] * b[i+1]
+		s2 += a[i+2] * b[i+2]
+		s3 += a[i+3] * b[i+3]
+	}
+	for ; i < len(a); i++ {
+		s0 += a[i] * b[i]
+	}
+	return s0 + s1 + s2 + s3
+}
+
+func vectorNorm(v []float32) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	return math.Sqrt(sum)
+}
+
+// add inserts or replaces the vector for id. The index keeps a reference to vector,
+// which must not be modified afterwards.
+func (h *hnswIndex) add(id string, vector []float32) {
+	h.remove(id)
+
+	level := int(-math.Log(1-h.rng.Float64()) * h.levelScale)
+	n := int32(len(h.nodes))
+	node := &hnswNode{id: id, vector: vector, norm: vectorNorm(vector), neighbors: make([][]int32, level+1)}
+	h.nodes = append(h.nodes, node)
+	h.byID[id] = n
+
+	if h.entry < 0 {
+		h.entry, h.maxLevel = n, level
+		return
+	}
+
+	// Descend greedily to the node's top layer, then link it on each layer below
+	entry := h.entry
+	for l := h.maxLevel; l > level; l-- {
+		entry = h.greedyClosest(vector, node.norm, entry, l)
+	}
+	entries := []int32{entry}
+	for l := min(level, h.maxLevel); l >= 0; l-- {
+		candidates := h.searchLayer(vector, node.norm, entries, h.cfg.EfConstruction, l)
+		node.neighbors[l] = h.selectNeighbors(candidates, h.cfg.M)
+		for _, neighbor := range node.neighbors[l] {
+			h.link(neighbor, n, l)
+		}
+		entries = make([]int32, len(candidates))
+		for i, c := range candidates {
+			entries[i] = c.node
+		}
+	}
+
+	if level > h.maxLevel {
+		h.entry, h.maxLevel = n, level
+	}
+}
+
+// link adds n to the neighbors of node on layer l. When the layer's limit is exceeded
+// the farthest neighbor is dropped, which is much cheaper than re-running the selection
+// heuristic on every insert.
+func (h *hnswIndex) link(node, n int32, l int) {
+	limit := h.cfg.M
+	if l == 0 {
+		limit = 2 * h.cfg.M
+	}
+	base := h.nodes[node]
+	neighbors := append(base.neighbors[l], n)
+	if len(neighbors) > limit {
+		farthest, farthestSimilarity := 0, math.Inf(1)
+		for i, neighbor := range neighbors {
+			if s := h.similarity(base.vector, base.norm, neighbor); s < farthestSimilarity {
+				farthest, farthestSimilarity = i, s
+			}
+		}
+		neighbors = slices.Delete(neighbors, farthest, farthest+1)
+	}
+	base.neighbors[l] = neighbors
+}
+
+// selectNeighbors picks up to m neighbors from candidates sorted by similarity, skipping
+// candidates closer to an already selected neighbor than to the base vector so links
+// spread in all directions; remaining slots are filled with the closest skipped ones.
+func (h *hnswIndex) selectNeighbors(candidates []hnswCandidate, m int) []int32 {
+	selected := make([]int32, 0, m)
+	var skipped []int32
+	for _, c := range candidates {
+		if len(selected) >= m {
+			break
+		}
+		diverse := true
+		candidate := h.nodes[c.node]
+		for _, s := range selected {
+			if h.similarity(candidate.vector, candidate.norm, s) > c.similarity {
+				diverse = false
+				break
+			}
+		}
+		if diverse {
+			selected = append(selected, c.node)
+		} else {
+			skipped = append(skipped, c.node)
+		}
+	}
+	for _, s := range skipped {
+		if len(selected) >= m {
+			break
+		}
+		selected = append(selected, s)
+	}
+	return selected
+}
+
+// remove tombstones the vector for id, rebuilding the index once tombstones outnumber
+// live vectors.
+func (h *hnswIndex) remove(id string) {
+	n, ok := h.byID[id]
+	if !ok {
+		return
+	}
+	h.nodes[n].deleted = true
+	delete(h.byID, id)
+	h.tombstones++
+
+	if h.tombstones > len(h.byID) {
+		h.rebuild()
+	}
+}
+
+// rebuild re-inserts the live vectors into a fresh graph.
+func (h *hnswIndex) rebuild() {
+	nodes := h.nodes
+	h.nodes, h.byID, h.entry, h.maxLevel, h.tombstones = nil, make(map[string]int32), -1, 0, 0
+	for _, node := range nodes {
+		if !node.deleted {
+			h.add(node.id, node.vector)
+		}
+	}
+}
+
+// search returns up to topK live vectors most similar to query, most similar first.
+func (h *hnswIndex) search(query []float32, topK int) []SearchResult {
+	if h.entry < 0 || topK <= 0 {
+		return []SearchResult{}
+	}
+	queryNorm := vectorNorm(query)
+
+	entry := h.entry
+	for l := h.maxLevel; l > 0; l-- {
+		entry = h.greedyClosest(query, queryNorm, entry, l)
+	}
+
+	// Tombstones take up candidate slots, so widen the search until topK live vectors
+	// are found or the whole graph has been visited
+	ef := max(h.cfg.EfSearch, topK)
+	for {
+		candidates := h.searchLayer(query, queryNorm, []int32{entry}, ef, 0)
+		results := make([]SearchResult, 0, topK)
+		for _, c := range candidates {
+			if len(results) == topK {
+				break
+			}
+			if node := h.nodes[c.node]; !node.deleted {
+				results = append(results, SearchResult{ID: node.id, Score: c.similarity})
+			}
+		}
+		if len(results) == topK || len(candidates) < ef {
+			return results
+		}
+		ef *= 2
+	}
+}
+
+// greedyClosest walks layer l from entry towards the query and returns the closest node found.
+func (h *hnswIndex) greedyClosest(query []float32, queryNorm float64, entry int32, l int) int32 {
+	best := h.similarity(query, queryNorm, entry)
+	for changed := true; changed; {
+		changed = false
+		for _, neighbor := range h.nodes[entry].neighbors[l] {
+			if s := h.similarity(query, queryNorm, neighbor); s > best {
+				best, entry, changed = s, neighbor, true
+			}
+		}
+	}
+	return entry
+}
+
+// searchLayer runs a best-first search of layer l from the entry nodes and returns up to
+// ef of the closest nodes, most similar first.
+func (h *hnswIndex) searchLayer(query []float32, queryNorm float64, entries []int32, ef int, l int) []hnswCandidate {
+	visited := h.visitedPool.Get().(*visitedSet)
+	defer h.visitedPool.Put(visited)
+	visited.reset(len(h.nodes))
+	frontier := &candidateHeap{}                 // Closest first
+	found := &candidateHeap{farthestFirst: true} // Farthest first, capped at ef
+	for _, e := range entries {
+		if !visited.visit(e) {
+			continue
+		}
+		c := hnswCandidate{node: e, similarity: h.similarity(query, queryNorm, e)}
+		heap.Push(frontier, c)
+		heap.Push(found, c)
+	}
+	for found.Len() > ef {
+		heap.Pop(found)
+	}
+
+	for frontier.Len() > 0 {
+		current := heap.Pop(frontier).(hnswCandidate)
+		if found.Len() >= ef && current.similarity < found.items[0].similarity {
+			break
+		}
+		node := h.nodes[current.node]
+		if l >= len(node.neighbors) {
+			continue
+		}
+		for _, neighbor := range node.neighbors[l] {
+			if !visited.visit(neighbor) {
+				continue
+			}
+			c := hnswCandidate{node: neighbor, similarity: h.similarity(query, queryNorm, neighbor)}
+			if found.Len() < ef || c.similarity > found.items[0].similarity {
+				heap.Push(frontier, c)
+				heap.Push(found, c)
+				if found.Len() > ef {
+					heap.Pop(found)
+				}
+			}
+		}
+	}
+
+	results := found.items
+	sortCandidates(results)
+	return results
+}
+
+// visitedSet marks the nodes seen by one layer search. Marks are generation numbers,
+// so resetting it between searches is O(1).
+type visitedSet struct {
+	marks      []uint32
+	generation uint32
+}
+
+func (v *visitedSet) reset(n int) {
+	if len(v.marks) < n {
+		v.marks = append(v.marks, make([]uint32, n-len(v.marks))...)
+	}
+	v.generation++
+	if v.generation == 0 {
+		clear(v.marks)
+		v.generation = 1
+	}
+}
+
+// visit marks node n and reports whether it was not yet visited.
+func (v *visitedSet) visit(n int32) bool {
+	if v.marks[n] == v.generation {
+		return false
+	}
+	v.marks[n] = v.generation
+	return true
+}
+
+type hnswCandidate struct {
+	node       int32
+	similarity float64
+}
+
+// sortCandidates orders candidates by similarity, most similar first.
+func sortCandidates(candidates []hnswCandidate) {
+	slices.SortFunc(candidates, func(a, b hnswCandidate) int {
+		switch {
+		case a.similarity > b.similarity:
+			return -1
+		case a.similarity < b.similarity:
+			return 1
+		}
+		return int(a.node - b.node)
+	})
+}
+
+// candidateHeap is a heap of candidates ordered closest first, or farthest first.
+type candidateHeap struct {
+	items         []hnswCandidate
+	farthestFirst bool
+}
+
+func (c *candidateHeap) Len() int { return len(c.items) }
+func (c *candidateHeap) Less(i, j int) bool {
+	if c.farthestFirst {
+		return c.items[i].similarity < c.items[j].similarity
+	}
+	return c.items[i].similarity > c.items[j].similarity
+}
+func (c *candidateHeap) Swap(i, j int) { c.items[i], c.items[j] = c.items[j], c.items[i] }
+func (c *candidateHeap) Push(x any)    { c.items = append(c.items, x.(hnswCandidate)) }
+func (c *candidateHeap) Pop() any {
+	last := c.items[len(c.items)-1]
+	c.items = c.items[:len(c.items)-1]
+	return last
+}
@@ -0,0 +1,174 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func randomVectors(rng *rand.Rand, n, dim int) [][]float32 {
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		vectors[i] = make([]float32, dim)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.Float32()*2 - 1
+		}
+	}
+	return vectors
+}
+
+// clusteredVectors draws n vectors around a fixed set of centers, closer to the
+// structure of real embeddings than uniform noise
+func clusteredVectors(rng *rand.Rand, n, dim int) [][]float32 {
+	centers := randomVectors(rand.New(rand.NewSource(99)), 200, dim)
+	vectors := randomVectors(rng, n, dim)
+	for _, v := range vectors {
+		center := centers[rng.Intn(len(centers))]
+		for j := range v {
+			v[j] = center[j] + 0.4*v[j]
+		}
+	}
+	return vectors
+}
+
+// exactTopK returns the IDs of the topK vectors most similar to query by linear scan
+func exactTopK(vectors map[string][]float32, query []float32, topK int) []string {
+	results := make([]SearchResult, 0, len(vectors))
+	for id, v := range vectors {
+		results = append(results, SearchResult{ID: id, Score: CosineSimilarity(query, v)})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	ids := make([]string, topK)
+	for i := range ids {
+		ids[i] = results[i].ID
+	}
+	return ids
+}
+
+func TestMemoryVectorStore_HNSWRecall(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(42))
+	s := NewMemoryVectorStore()
+
+	vectors := make(map[string][]float32)
+	for i, v := range randomVectors(rng, 3000, 32) {
+		id := fmt.Sprintf("n%d", i)
+		vectors[id] = v
+		if err := s.Add(ctx, id, v); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	const topK = 10
+	hits, total := 0, 0
+	for _, query := range randomVectors(rng, 50, 32) {
+		results, err := s.Search(ctx, query, topK)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != topK {
+			t.Fatalf("expected %d results, got %d", topK, len(results))
+		}
+		for i := 1; i < len(results); i++ {
+			if results[i].Score > results[i-1].Score {
+				t.Fatalf("results not sorted: %v", results)
+			}
+		}
+
+		found := make(map[string]bool)
+		for _, r := range results {
+			found[r.ID] = true
+		}
+		for _, id := range exactTopK(vectors, query, topK) {
+			if found[id] {
+				hits++
+			}
+			total++
+		}
+	}
+	if recall := float64(hits) / float64(total); recall < 0.95 {
+		t.Errorf("recall@%d = %.3f, want >= 0.95", topK, recall)
+	}
+}
+
+func TestMemoryVectorStore_HNSWDeleteAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(7))
+	s := NewMemoryVectorStoreWithConfig(HNSWConfig{M: 8, EfConstruction: 64, EfSearch: 32})
+
+	vectors := randomVectors(rng, 2000, 16)
+	for i, v := range vectors {
+		if err := s.Add(ctx, fmt.Sprintf("n%d", i), v); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	// Deleted vectors are never returned, even when they are the closest
+	if err := s.Delete(ctx, "n0"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	results, _ := s.Search(ctx, vectors[0], 5)
+	for _, r := range results {
+		if r.ID == "n0" {
+			t.Fatalf("deleted vector returned: %v", results)
+		}
+	}
+
+	// An updated vector is found at its new position
+	moved := make([]float32, 16)
+	moved[3] = 1
+	if err := s.Add(ctx, "n1", moved); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	results, _ = s.Search(ctx, moved, 1)
+	if len(results) != 1 || results[0].ID != "n1" || results[0].Score < 0.999 {
+		t.Errorf("expected the updated vector first, got %v", results)
+	}
+
+	// Deleting most vectors compacts the index and keeps the rest searchable
+	for i := 2; i < 1900; i++ {
+		if err := s.Delete(ctx, fmt.Sprintf("n%d", i)); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+	}
+	for i := 1900; i < 2000; i++ {
+		results, _ := s.Search(ctx, vectors[i], 1)
+		if len(results) != 1 || results[0].ID != fmt.Sprintf("n%d", i) {
+			t.Fatalf("expected n%d after deletes, got %v", i, results)
+		}
+	}
+}
+
+func TestHNSWIndex_SearchesWholeSmallGraph(t *testing.T) {
+	index := newHNSWIndex(HNSWConfig{})
+	index.add("a", []float32{1, 0})
+	index.add("b", []float32{0, 1})
+	index.add("c", []float32{-1, 0})
+	index.remove("b")
+
+	results := index.search([]float32{1, 0}, 5)
+	if len(results) != 2 || results[0].ID != "a" || results[1].ID != "c" {
+		t.Errorf("expected [a c], got %v", results)
+	}
+}
+
+// BenchmarkMemoryVectorStore_Search100k measures HNSW search over 100k vectors.
+// Building the index takes a while; run with -run '^$' -bench Search100k.
+func BenchmarkMemoryVectorStore_Search100k(b *testing.B) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(1))
+	s := NewMemoryVectorStore()
+	for i, v := range clusteredVectors(rng, 100000, 128) {
+		s.Add(ctx, fmt.Sprintf("n%d", i), v)
+	}
+	queries := clusteredVectors(rng, 100, 128)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Search(ctx, queries[i%len(queries)], 10); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// migrateImportSchema adds the imported_files table, which tracks the files imported
+// from a directory so re-imports only process changed files.
+func (s *SQLiteGraphStore) migrateImportSchema() error {
+	if _, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS imported_files (
+			namespace TEXT NOT NULL DEFAULT '',
+			root TEXT NOT NULL,
+			path TEXT NOT NULL,
+			hash TEXT NOT NULL,
+			imported_at DATETIME NOT NULL,
+			PRIMARY KEY (namespace, root, path)
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create imported_files table: %w", err)
+	}
+	return nil
+}
+
+// ImportedFiles returns the files imported from the directory root, as the hash of the
+// document each was imported as keyed by path.
+func (s *SQLiteGraphStore) ImportedFiles(ctx context.Context, root string) (map[string]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT path, hash FROM imported_files WHERE namespace = ? AND root = ?
+	`, s.namespace, root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query imported files: %w", err)
+	}
+	defer rows.Close()
+
+	files := make(map[string]string)
+	for rows.Next() {
+		var path, hash string
+		if err := rows.Scan(&path, &hash); err != nil {
+			return nil, fmt.Errorf("failed to scan imported file: %w", err)
+		}
+		files[path] = hash
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating imported files: %w", err)
+	}
+	return files, nil
+}
+
+// RecordImportedFile records that the file at path under root was imported as the
+// document with the given hash, replacing an earlier record.
+func (s *SQLiteGraphStore) RecordImportedFile(ctx context.Context, root, path, hash string) error {
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO imported_files (namespace, root, path, hash, imported_at) VALUES (?, ?, ?, ?, ?)
+	`, s.namespace, root, path, hash, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to record imported file %s: %w", path, err)
+	}
+	return nil
+}
+
+// ForgetImportedFile removes the record of an imported file.
+func (s *SQLiteGraphStore) ForgetImportedFile(ctx context.Context, root, path string) error {
+	if _, err := s.db.ExecContext(ctx, `
+		DELETE FROM imported_files WHERE namespace = ? AND root = ? AND path = ?
+	`, s.namespace, root, path); err != nil {
+		return fmt.Errorf("failed to forget imported file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PendingDocument is a document added for Cognify but not yet fully processed.
+type PendingDocument struct {
+	ID      string
+	Text    string
+	Source  string
+	AddedAt time.Time
+}
+
+// IngestionQueue persists the documents buffered for Cognify and the chunks already
+// written from each, so a crash mid-Cognify loses neither and processing can resume
+// where it stopped. Separate from GraphStore, like DocumentTracker.
+type IngestionQueue interface {
+	// EnqueueDocument stores a buffered document.
+	EnqueueDocument(ctx context.Context, doc PendingDocument) error
+
+	// PendingDocuments returns the queued documents in the order they were added.
+	PendingDocuments(ctx context.Context) ([]PendingDocument, error)
+
+	// MarkChunkDone records that a chunk of a queued document has been written to the graph.
+	MarkChunkDone(ctx context.Context, documentID, chunkID string) error
+
+	// CompletedChunks returns the IDs of the chunks of a queued document already written.
+	CompletedChunks(ctx context.Context, documentID string) (map[string]bool, error)
+
+	// RemovePendingDocument drops a processed document and its chunk records from the queue.
+	RemovePendingDocument(ctx context.Context, documentID string) error
+}
+
+// Compile-time interface check
+var _ IngestionQueue = (*SQLiteGraphStore)(nil)
+
+// migrateIngestionQueueSchema adds the pending_documents and pending_chunks tables.
+func (s *SQLiteGraphStore) migrateIngestionQueueSchema() error {
+	if _, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS pending_documents (
+			id TEXT PRIMARY KEY,
+			namespace TEXT NOT NULL DEFAULT '',
+			text TEXT NOT NULL,
+			source TEXT NOT NULL DEFAULT '',
+			added_at DATETIME NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_pending_documents_namespace ON pending_documents(namespace, added_at);
+
+		CREATE TABLE IF NOT EXISTS pending_chunks (
+			document_id TEXT NOT NULL,
+			chunk_id TEXT NOT NULL,
+			completed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (document_id, chunk_id)
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create ingestion queue tables: %w", err)
+	}
+	return nil
+}
+
+// EnqueueDocument stores a buffered document in the instance's namespace.
+func (s *SQLiteGraphStore) EnqueueDocument(ctx context.Context, doc PendingDocument) error {
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO pending_documents (id, namespace, text, source, added_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, doc.ID, s.namespace, doc.Text, doc.Source, doc.AddedAt); err != nil {
+		return fmt.Errorf("failed to enqueue document: %w", err)
+	}
+	return nil
+}
+
+// PendingDocuments returns the namespace's queued documents, oldest first.
+func (s *SQLiteGraphStore) PendingDocuments(ctx context.Context) ([]PendingDocument, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, text, source, added_at FROM pending_documents
+		WHERE namespace = ?
+		ORDER BY added_at, rowid
+	`, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending documents: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []PendingDocument
+	for rows.Next() {
+		var doc PendingDocument
+		if err := rows.Scan(&doc.ID, &doc.Text, &doc.Source, &doc.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pending document: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pending documents: %w", err)
+	}
+	return docs, nil
+}
+
+// MarkChunkDone records that a chunk of a queued document has been written.
+func (s *SQLiteGraphStore) MarkChunkDone(ctx context.Context, documentID, chunkID string) error {
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO pending_chunks (document_id, chunk_id) VALUES (?, ?)`,
+		documentID, chunkID); err != nil {
+		return fmt.Errorf("failed to mark chunk done: %w", err)
+	}
+	return nil
+}
+
+// CompletedChunks returns the IDs of the chunks of a queued document already written.
+func (s *SQLiteGraphStore) CompletedChunks(ctx context.Context, documentID string) (map[string]bool, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT chunk_id FROM pending_chunks WHERE document_id = ?`, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query completed chunks: %w", err)
+	}
+	defer rows.Close()
+
+	done := make(map[string]bool)
+	for rows.Next() {
+		var chunkID string
+		if err := rows.Scan(&chunkID); err != nil {
+			return nil, fmt.Errorf("failed to scan completed chunk: %w", err)
+		}
+		done[chunkID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating completed chunks: %w", err)
+	}
+	return done, nil
+}
+
+// RemovePendingDocument drops a document and its chunk records from the queue.
+func (s *SQLiteGraphStore) RemovePendingDocument(ctx context.Context, documentID string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM pending_chunks WHERE document_id = ?`, documentID); err != nil {
+		return fmt.Errorf("failed to delete pending chunks: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM pending_documents WHERE id = ?`, documentID); err != nil {
+		return fmt.Errorf("failed to delete pending document: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
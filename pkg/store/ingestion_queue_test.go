@@ -0,0 +1,57 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestIngestionQueue(t *testing.T) {
+	s, other := openNamespacedStores(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	docs := []PendingDocument{
+		{ID: "d1", Text: "first", Source: "a.md", AddedAt: now},
+		{ID: "d2", Text: "second", AddedAt: now.Add(time.Second)},
+	}
+	for _, doc := range docs {
+		if err := s.EnqueueDocument(ctx, doc); err != nil {
+			t.Fatalf("EnqueueDocument failed: %v", err)
+		}
+	}
+
+	// Other namespaces have their own queue
+	if pending, _ := other.PendingDocuments(ctx); len(pending) != 0 {
+		t.Errorf("expected another namespace's queue to be empty, got %v", pending)
+	}
+
+	pending, err := s.PendingDocuments(ctx)
+	if err != nil {
+		t.Fatalf("PendingDocuments failed: %v", err)
+	}
+	if len(pending) != 2 || pending[0].ID != "d1" || pending[0].Source != "a.md" || !pending[0].AddedAt.Equal(now) {
+		t.Fatalf("unexpected pending documents %+v", pending)
+	}
+
+	for _, chunk := range []string{"c1", "c2", "c1"} {
+		if err := s.MarkChunkDone(ctx, "d1", chunk); err != nil {
+			t.Fatalf("MarkChunkDone failed: %v", err)
+		}
+	}
+	done, err := s.CompletedChunks(ctx, "d1")
+	if err != nil || len(done) != 2 || !done["c1"] || !done["c2"] {
+		t.Errorf("expected chunks c1 and c2 done, got %v, %v", done, err)
+	}
+
+	if err := s.RemovePendingDocument(ctx, "d1"); err != nil {
+		t.Fatalf("RemovePendingDocument failed: %v", err)
+	}
+	pending, _ = s.PendingDocuments(ctx)
+	if len(pending) != 1 || pending[0].ID != "d2" {
+		t.Errorf("expected only d2 pending, got %+v", pending)
+	}
+	if done, _ := s.CompletedChunks(ctx, "d1"); len(done) != 0 {
+		t.Errorf("expected d1's chunk records removed, got %v", done)
+	}
+}
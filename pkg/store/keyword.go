@@ -0,0 +1,299 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// BM25 parameters: term frequency saturation and document length normalization
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// keywordColumns are the columns of the full-text index, in index order.
+var keywordColumns = []string{"name", "description"}
+
+// keywordColumnWeights weighs matches in the indexed columns (name, description):
+// a term in the name says more about the node than one in its description.
+var keywordColumnWeights = []float64{2, 1}
+
+// migrateKeywordSchema adds the full-text index over node names and descriptions.
+//
+// The index is an FTS4 table rather than FTS5, which mattn/go-sqlite3 only compiles in
+// with the sqlite_fts5 build tag; FTS4 is always available, and BM25 is computed from
+// its matchinfo hit counts. The unicode61 tokenizer keeps underscores inside tokens so
+// identifiers like ERR_CONN_RESET match whole. Triggers keep the index in sync with
+// every write to nodes, with keyword_node_ids mapping FTS docids to node IDs (like
+// vec_node_ids for embeddings), since a node's rowid is not stable (VACUUM may renumber it).
+func (s *SQLiteGraphStore) migrateKeywordSchema() error {
+	schema := `
+	CREATE VIRTUAL TABLE IF NOT EXISTS nodes_fts USING fts4(name, description, tokenize=unicode61 "tokenchars=_");
+
+	CREATE TABLE IF NOT EXISTS keyword_node_ids (
+		rowid INTEGER PRIMARY KEY,
+		node_id TEXT NOT NULL UNIQUE
+	);
+
+	CREATE TRIGGER IF NOT EXISTS nodes_fts_insert AFTER INSERT ON nodes BEGIN
+		INSERT OR IGNORE INTO keyword_node_ids (node_id) VALUES (new.id);
+		DELETE FROM nodes_fts WHERE docid = (SELECT rowid FROM keyword_node_ids WHERE node_id = new.id);
+		INSERT INTO nodes_fts (docid, name, description)
+			VALUES ((SELECT rowid FROM keyword_node_ids WHERE node_id = new.id), new.name, COALESCE(new.description, ''));
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS nodes_fts_update AFTER UPDATE OF name, description ON nodes BEGIN
+		DELETE FROM nodes_fts WHERE docid = (SELECT rowid FROM keyword_node_ids WHERE node_id = new.id);
+		INSERT INTO nodes_fts (docid, name, description)
+			VALUES ((SELECT rowid FROM keyword_node_ids WHERE node_id = new.id), new.name, COALESCE(new.description, ''));
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS nodes_fts_delete AFTER DELETE ON nodes BEGIN
+		DELETE FROM nodes_fts WHERE docid = (SELECT rowid FROM keyword_node_ids WHERE node_id = old.id);
+		DELETE FROM keyword_node_ids WHERE node_id = old.id;
+	END;
+	`
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create keyword index: %w", err)
+	}
+
+	// Index nodes written before the index existed
+	var missing int
+	if err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM nodes WHERE id NOT IN (SELECT node_id FROM keyword_node_ids)
+	`).Scan(&missing); err != nil {
+		return fmt.Errorf("failed to check keyword index: %w", err)
+	}
+	if missing == 0 {
+		return nil
+	}
+	backfill := `
+	INSERT OR IGNORE INTO keyword_node_ids (node_id) SELECT id FROM nodes;
+	INSERT INTO nodes_fts (docid, name, description)
+		SELECT k.rowid, n.name, COALESCE(n.description, '')
+		FROM nodes n JOIN keyword_node_ids k ON k.node_id = n.id
+		WHERE k.rowid NOT IN (SELECT docid FROM nodes_fts);
+	`
+	if _, err := s.db.Exec(backfill); err != nil {
+		return fmt.Errorf("failed to backfill keyword index: %w", err)
+	}
+	return nil
+}
+
+// SearchKeyword returns up to topK nodes whose name or description contains any of the
+// words of query, ranked by BM25 (names weigh double). Scores are raw BM25 values,
+// higher is better. Only nodes matching filter are returned.
+//
+// The index is shared by all namespaces, so the BM25 corpus statistics come from
+// keywordCorpusStats rather than matchinfo; scoring, filtering and the limit run in SQL.
+func (s *SQLiteGraphStore) SearchKeyword(ctx context.Context, query string, topK int, filter NodeFilter) ([]SearchResult, error) {
+	start := time.Now()
+	terms := keywordTerms(query)
+	if len(terms) == 0 || topK <= 0 {
+		return []SearchResult{}, nil
+	}
+	stats, err := s.keywordCorpusStats(ctx, terms)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, filterArgs := filter.sqlConditions("n")
+	args := append([]interface{}{encodeFloats(stats), keywordMatchExpression(terms), s.namespace}, filterArgs...)
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT n.id, keyword_bm25(matchinfo(nodes_fts, 'pclx'), ?) AS score
+		FROM nodes_fts
+		JOIN keyword_node_ids k ON k.rowid = nodes_fts.docid
+		JOIN nodes n ON n.id = k.node_id
+		WHERE nodes_fts MATCH ? AND n.namespace = ?`+conditions+`
+		ORDER BY score DESC, n.id
+		LIMIT ?
+	`, append(args, topK)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute keyword search: %w", err)
+	}
+	defer rows.Close()
+
+	results := []SearchResult{}
+	for rows.Next() {
+		var result SearchResult
+		if err := rows.Scan(&result.ID, &result.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan keyword result: %w", err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating keyword results: %w", err)
+	}
+	s.logQuery(ctx, "search_keyword", start, slog.Int("results", len(results)))
+	return results, nil
+}
+
+// keywordCorpusStats returns the BM25 corpus statistics of the store's namespace for
+// the terms of a keyword query, laid out as keyword_bm25 expects: the row count, the
+// average token count per column, then per term and column the rows containing the
+// term. Token counts come from the FTS docsize table, document frequencies from one
+// column-restricted MATCH per term and column (FTS4 takes no quoted phrase there).
+func (s *SQLiteGraphStore) keywordCorpusStats(ctx context.Context, terms []string) ([]float64, error) {
+	var rowCount float64
+	averages := make([]sql.NullFloat64, len(keywordColumns))
+	dest := []interface{}{&rowCount}
+	selects := []string{"COUNT(*)"}
+	for c := range keywordColumns {
+		dest = append(dest, &averages[c])
+		selects = append(selects, fmt.Sprintf("AVG(fts_length(d.size, %d))", c))
+	}
+	if err := s.db.QueryRowContext(ctx, `
+		SELECT `+strings.Join(selects, ", ")+`
+		FROM nodes_fts_docsize d
+		JOIN keyword_node_ids k ON k.rowid = d.docid
+		JOIN nodes n ON n.id = k.node_id
+		WHERE n.namespace = ?
+	`, s.namespace).Scan(dest...); err != nil {
+		return nil, fmt.Errorf("failed to read keyword index statistics: %w", err)
+	}
+	stats := []float64{rowCount}
+	for _, average := range averages {
+		stats = append(stats, average.Float64)
+	}
+
+	frequencies := make([]float64, len(terms)*len(keywordColumns))
+	dest = dest[:0]
+	selects = selects[:0]
+	var args []interface{}
+	for p, term := range terms {
+		for c, column := range keywordColumns {
+			dest = append(dest, &frequencies[p*len(keywordColumns)+c])
+			selects = append(selects, `(
+				SELECT COUNT(*) FROM nodes_fts
+				JOIN keyword_node_ids k ON k.rowid = nodes_fts.docid
+				JOIN nodes n ON n.id = k.node_id
+				WHERE nodes_fts MATCH ? AND n.namespace = ?)`)
+			args = append(args, column+":"+term, s.namespace)
+		}
+	}
+	if err := s.db.QueryRowContext(ctx, "SELECT "+strings.Join(selects, ", "), args...).Scan(dest...); err != nil {
+		return nil, fmt.Errorf("failed to count keyword document frequencies: %w", err)
+	}
+	return append(stats, frequencies...), nil
+}
+
+// keywordTerms splits free text into the distinct lowercase words of a keyword query.
+// Words hold only letters, digits and underscores, and FTS operators are uppercase, so
+// a term is never interpreted as query syntax.
+func keywordTerms(query string) []string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	seen := make(map[string]bool, len(words))
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.ToLower(word)
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// keywordMatchExpression is the FTS query matching any of terms, each quoted as a phrase.
+func keywordMatchExpression(terms []string) string {
+	phrases := make([]string, len(terms))
+	for i, term := range terms {
+		phrases[i] = `"` + term + `"`
+	}
+	return strings.Join(phrases, " OR ")
+}
+
+// sqlKeywordBM25 implements keyword_bm25(matchinfo, stats): the BM25 score of one
+// matched row from its FTS4 matchinfo 'pclx' blob and the corpus statistics from
+// keywordCorpusStats, encoded with encodeFloats.
+func sqlKeywordBM25(info, stats []byte) float64 {
+	return bm25(info, decodeFloats(stats))
+}
+
+// bm25 scores one matched row. The matchinfo 'pclx' blob holds the phrase and column
+// counts, the token count per column of this row, and per phrase and column the hits in
+// this row, in all rows and the rows with a hit; only the hits in this row are used,
+// since the other counts span every namespace.
+func bm25(info []byte, stats []float64) float64 {
+	values := make([]uint32, len(info)/4)
+	for i := range values {
+		values[i] = binary.NativeEndian.Uint32(info[i*4:])
+	}
+	if len(values) < 2 {
+		return 0
+	}
+	phrases, columns := int(values[0]), int(values[1])
+	if len(values) < 2+columns+3*phrases*columns || len(stats) < 1+columns+phrases*columns {
+		return 0
+	}
+	lengths := values[2 : 2+columns]
+	hits := values[2+columns:]
+	rowCount := stats[0]
+	averages := stats[1 : 1+columns]
+	documentFrequencies := stats[1+columns:]
+
+	score := 0.0
+	for p := 0; p < phrases; p++ {
+		for c := 0; c < columns; c++ {
+			termFrequency := float64(hits[3*(p*columns+c)])
+			if termFrequency == 0 {
+				continue
+			}
+			documentFrequency := documentFrequencies[p*columns+c]
+			idf := math.Log((rowCount-documentFrequency+0.5)/(documentFrequency+0.5) + 1)
+			lengthRatio := 1.0
+			if averages[c] > 0 {
+				lengthRatio = float64(lengths[c]) / averages[c]
+			}
+			weight := 1.0
+			if c < len(keywordColumnWeights) {
+				weight = keywordColumnWeights[c]
+			}
+			score += weight * idf * termFrequency * (bm25K1 + 1) / (termFrequency + bm25K1*(1-bm25B+bm25B*lengthRatio))
+		}
+	}
+	return score
+}
+
+// sqlFTSLength implements fts_length(size, column): the token count of one column in a
+// row of an FTS4 docsize table, whose size blob holds one varint per column.
+func sqlFTSLength(size []byte, column int64) int64 {
+	for c := int64(0); len(size) > 0; c++ {
+		length, n := binary.Uvarint(size)
+		if n <= 0 {
+			break
+		}
+		if c == column {
+			return int64(length)
+		}
+		size = size[n:]
+	}
+	return 0
+}
+
+// encodeFloats packs values into a blob for passing to an SQL function.
+func encodeFloats(values []float64) []byte {
+	blob := make([]byte, 8*len(values))
+	for i, v := range values {
+		binary.NativeEndian.PutUint64(blob[i*8:], math.Float64bits(v))
+	}
+	return blob
+}
+
+// decodeFloats is the inverse of encodeFloats.
+func decodeFloats(blob []byte) []float64 {
+	values := make([]float64, len(blob)/8)
+	for i := range values {
+		values[i] = math.Float64frombits(binary.NativeEndian.Uint64(blob[i*8:]))
+	}
+	return values
+}
@@ -0,0 +1,260 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func keywordIDs(t *testing.T, s *SQLiteGraphStore, query string, filter NodeFilter) []string {
+	t.Helper()
+	results, err := s.SearchKeyword(context.Background(), query, 10, filter)
+	if err != nil {
+		t.Fatalf("SearchKeyword failed: %v", err)
+	}
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func TestSearchKeyword_ExactIdentifiers(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	nodes := []*Node{
+		{ID: "err", Name: "ERR_CONN_RESET", Type: "Error", Description: "Connection reset by the load balancer"},
+		{ID: "fn", Name: "parseConfig", Type: "Function", Description: "Reads config.yaml at startup"},
+		{ID: "conn", Name: "Connection pool", Type: "Concept", Description: "Reuses database connections"},
+	}
+	for _, node := range nodes {
+		if err := s.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+
+	if got := keywordIDs(t, s, "why do we see ERR_CONN_RESET?", NodeFilter{}); len(got) != 1 || got[0] != "err" {
+		t.Errorf("expected the error code node, got %v", got)
+	}
+	if got := keywordIDs(t, s, "who calls parseconfig", NodeFilter{}); len(got) != 1 || got[0] != "fn" {
+		t.Errorf("expected a case-insensitive match on the function, got %v", got)
+	}
+	// FTS syntax in the query is treated as text
+	if got := keywordIDs(t, s, `"connection" NEAR* OR (`, NodeFilter{}); len(got) != 2 {
+		t.Errorf("expected both connection nodes, got %v", got)
+	}
+	if got := keywordIDs(t, s, "?!", NodeFilter{}); len(got) != 0 {
+		t.Errorf("expected no results for a query without words, got %v", got)
+	}
+}
+
+func TestSearchKeyword_RanksNameMatchesFirst(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	nodes := []*Node{
+		{ID: "mention", Name: "Deployment guide", Type: "Document", Description: "Explains how Kubernetes rolls out releases"},
+		{ID: "name", Name: "Kubernetes", Type: "Technology", Description: "Container orchestrator"},
+		{ID: "other", Name: "Grafana", Type: "Technology", Description: "Dashboards"},
+	}
+	for _, node := range nodes {
+		if err := s.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+
+	got := keywordIDs(t, s, "kubernetes", NodeFilter{})
+	if len(got) != 2 || got[0] != "name" || got[1] != "mention" {
+		t.Errorf("expected [name mention], got %v", got)
+	}
+	if got := keywordIDs(t, s, "kubernetes", NodeFilter{Types: []string{"Document"}}); len(got) != 1 || got[0] != "mention" {
+		t.Errorf("expected the type filter to apply, got %v", got)
+	}
+	if got := keywordIDs(t, s, "kubernetes", NodeFilter{CreatedBefore: time.Now().Add(-time.Hour)}); len(got) != 0 {
+		t.Errorf("expected the creation time filter to apply, got %v", got)
+	}
+}
+
+func TestSearchKeyword_FollowsNodeWrites(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	if err := s.AddNode(ctx, &Node{ID: "n1", Name: "Postgres", Type: "Technology"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	// Upserting replaces the indexed text
+	if err := s.AddNode(ctx, &Node{ID: "n1", Name: "PostgreSQL", Type: "Technology", Description: "Relational database"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if got := keywordIDs(t, s, "postgres", NodeFilter{}); len(got) != 0 {
+		t.Errorf("expected the old name to be gone, got %v", got)
+	}
+	if got := keywordIDs(t, s, "relational", NodeFilter{}); len(got) != 1 {
+		t.Errorf("expected the new description to match, got %v", got)
+	}
+
+	if _, err := s.DB().Exec(`UPDATE nodes SET description = 'Object store' WHERE id = 'n1'`); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if got := keywordIDs(t, s, "object", NodeFilter{}); len(got) != 1 {
+		t.Errorf("expected updates to be indexed, got %v", got)
+	}
+
+	if err := s.DeleteNode(ctx, "n1"); err != nil {
+		t.Fatalf("DeleteNode failed: %v", err)
+	}
+	if got := keywordIDs(t, s, "postgresql", NodeFilter{}); len(got) != 0 {
+		t.Errorf("expected deleted nodes to be gone, got %v", got)
+	}
+}
+
+func TestSearchKeyword_Namespaces(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "keyword.db")
+	a, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	defer a.Close()
+	a.WithNamespace("a")
+	b, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	defer b.Close()
+	b.WithNamespace("b")
+
+	ctx := context.Background()
+	if err := a.AddNode(ctx, &Node{ID: "a1", Name: "Kafka", Type: "Technology"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if got := keywordIDs(t, b, "kafka", NodeFilter{}); len(got) != 0 {
+		t.Errorf("expected no results from another namespace, got %v", got)
+	}
+	if got := keywordIDs(t, a, "kafka", NodeFilter{}); len(got) != 1 {
+		t.Errorf("expected the namespace's own node, got %v", got)
+	}
+}
+
+func TestSearchKeyword_NamespaceStatistics(t *testing.T) {
+	ctx := context.Background()
+	nodes := []*Node{
+		{ID: "kafka", Name: "Kafka", Type: "Technology", Description: "Event streaming platform"},
+		{ID: "consumer", Name: "Consumer group", Type: "Concept", Description: "Reads Kafka partitions"},
+		{ID: "redis", Name: "Redis", Type: "Technology", Description: "In-memory cache"},
+	}
+	scores := func(s *SQLiteGraphStore) map[string]float64 {
+		t.Helper()
+		results, err := s.SearchKeyword(ctx, "kafka streaming", 10, NodeFilter{})
+		if err != nil {
+			t.Fatalf("SearchKeyword failed: %v", err)
+		}
+		byID := make(map[string]float64, len(results))
+		for _, r := range results {
+			byID[r.ID] = r.Score
+		}
+		return byID
+	}
+
+	alone := setupTestStore(t)
+	defer alone.Close()
+	for _, node := range nodes {
+		if err := alone.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	want := scores(alone)
+	if len(want) != 2 || want["kafka"] <= want["consumer"] {
+		t.Fatalf("expected the name match to score higher, got %v", want)
+	}
+
+	dbPath := filepath.Join(t.TempDir(), "keyword.db")
+	a, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	defer a.Close()
+	a.WithNamespace("a")
+	b, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	defer b.Close()
+	b.WithNamespace("b")
+	for _, node := range nodes {
+		if err := a.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	// Another namespace full of long documents about Kafka must not change a's scores
+	for i := 0; i < 20; i++ {
+		node := &Node{ID: fmt.Sprintf("b%d", i), Name: "Kafka topic", Type: "Concept",
+			Description: "Kafka streaming notes with a much longer description than any node in namespace a"}
+		if err := b.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	got := scores(a)
+	for id, score := range want {
+		if math.Abs(got[id]-score) > 1e-9 {
+			t.Errorf("score of %s = %v, want %v as in a store of its own", id, got[id], score)
+		}
+	}
+}
+
+func TestSearchKeyword_Limit(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		node := &Node{ID: fmt.Sprintf("n%d", i), Name: fmt.Sprintf("Service %d", i), Type: "Service"}
+		if err := s.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	results, err := s.SearchKeyword(ctx, "service", 3, NodeFilter{})
+	if err != nil {
+		t.Fatalf("SearchKeyword failed: %v", err)
+	}
+	if len(results) != 3 || results[0].ID != "n0" || results[2].ID != "n2" {
+		t.Errorf("expected the first 3 equally scored nodes by ID, got %v", results)
+	}
+	if got := keywordIDs(t, s, "service", NodeFilter{Types: []string{"Service"}, CreatedAfter: time.Now().Add(-time.Hour)}); len(got) != 5 {
+		t.Errorf("expected the filter to keep every node, got %v", got)
+	}
+}
+
+func TestMigrateKeywordSchema_Backfill(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "backfill.db")
+	s, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	if err := s.AddNode(context.Background(), &Node{ID: "n1", Name: "Redis", Type: "Technology"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	// Simulate a database from before the keyword index (and schema_migrations)
+	if _, err := s.DB().Exec(`
+		DROP TRIGGER nodes_fts_insert; DROP TRIGGER nodes_fts_update; DROP TRIGGER nodes_fts_delete;
+		DROP TABLE nodes_fts; DROP TABLE keyword_node_ids; DROP TABLE schema_migrations;
+	`); err != nil {
+		t.Fatalf("failed to drop keyword index: %v", err)
+	}
+	s.Close()
+
+	s, err = NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	defer s.Close()
+	if got := keywordIDs(t, s, "redis", NodeFilter{}); len(got) != 1 {
+		t.Errorf("expected existing nodes to be indexed on open, got %v", got)
+	}
+}
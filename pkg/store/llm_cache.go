@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// SQLiteLLMCache persists LLM responses in the llm_cache table, so re-running Cognify
+// on unchanged documents replays extraction results instead of calling the LLM again.
+// It implements llm.ResponseCache. Entries are shared by all namespaces: identical
+// prompts to the same model yield the same response.
+type SQLiteLLMCache struct {
+	db     *sql.DB
+	cipher *FieldCipher // Encrypts responses (see WithEncryption)
+}
+
+// NewSQLiteLLMCache creates an LLM cache on a database initialized by NewSQLiteGraphStore.
+func NewSQLiteLLMCache(db *sql.DB) *SQLiteLLMCache {
+	return &SQLiteLLMCache{db: db}
+}
+
+// WithEncryption encrypts the responses written by this cache with c and decrypts them
+// on read. Responses echo the documents they were extracted from, so use the graph
+// store's cipher. A nil cipher (the default) stores them in plaintext.
+func (c *SQLiteLLMCache) WithEncryption(fc *FieldCipher) *SQLiteLLMCache {
+	c.cipher = fc
+	return c
+}
+
+// migrateLLMCacheSchema adds the llm_cache table.
+func (s *SQLiteGraphStore) migrateLLMCacheSchema() error {
+	if _, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS llm_cache (
+			key TEXT PRIMARY KEY,
+			model TEXT NOT NULL,
+			response TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`); err != nil {
+		return fmt.Errorf("failed to create llm_cache table: %w", err)
+	}
+	return nil
+}
+
+// GetResponse returns the cached response for key, if any.
+func (c *SQLiteLLMCache) GetResponse(ctx context.Context, key string) (string, bool, error) {
+	var response string
+	err := c.db.QueryRowContext(ctx, `SELECT response FROM llm_cache WHERE key = ?`, key).Scan(&response)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read llm cache: %w", err)
+	}
+	if response, err = c.cipher.Decrypt(response); err != nil {
+		return "", false, err
+	}
+	return response, true, nil
+}
+
+// PutResponse stores the response for key, replacing any previous entry.
+func (c *SQLiteLLMCache) PutResponse(ctx context.Context, key, model, response string) error {
+	response, err := c.cipher.Encrypt(response)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt llm cache entry: %w", err)
+	}
+	if _, err := c.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO llm_cache (key, model, response, created_at)
+		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+	`, key, model, response); err != nil {
+		return fmt.Errorf("failed to write llm cache: %w", err)
+	}
+	return nil
+}
+
+// Clear deletes the cached responses of model, or of every model if model is empty,
+// and returns the number of entries removed.
+func (c *SQLiteLLMCache) Clear(ctx context.Context, model string) (int64, error) {
+	query, args := `DELETE FROM llm_cache`, []any{}
+	if model != "" {
+		query, args = query+` WHERE model = ?`, append(args, model)
+	}
+	result, err := c.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear llm cache: %w", err)
+	}
+	return result.RowsAffected()
+}
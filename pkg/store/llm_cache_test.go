@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSQLiteLLMCache(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+	ctx := context.Background()
+	cache := NewSQLiteLLMCache(s.DB())
+
+	if _, ok, err := cache.GetResponse(ctx, "k1"); err != nil || ok {
+		t.Fatalf("expected a miss, got %v, %v", ok, err)
+	}
+	if err := cache.PutResponse(ctx, "k1", "model-a", `["first"]`); err != nil {
+		t.Fatalf("PutResponse failed: %v", err)
+	}
+	if err := cache.PutResponse(ctx, "k1", "model-a", `["second"]`); err != nil {
+		t.Fatalf("PutResponse failed: %v", err)
+	}
+	if err := cache.PutResponse(ctx, "k2", "model-b", `["other"]`); err != nil {
+		t.Fatalf("PutResponse failed: %v", err)
+	}
+
+	response, ok, err := cache.GetResponse(ctx, "k1")
+	if err != nil || !ok || response != `["second"]` {
+		t.Errorf("expected the replaced response, got %q, %v, %v", response, ok, err)
+	}
+
+	if n, err := cache.Clear(ctx, "model-a"); err != nil || n != 1 {
+		t.Errorf("expected one entry cleared for model-a, got %d, %v", n, err)
+	}
+	if _, ok, _ := cache.GetResponse(ctx, "k2"); !ok {
+		t.Error("expected model-b's entry to survive")
+	}
+	if n, err := cache.Clear(ctx, ""); err != nil || n != 1 {
+		t.Errorf("expected the remaining entry cleared, got %d, %v", n, err)
+	}
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// DefaultSlowQueryThreshold is the duration from which the SQLite stores log a query
+// at WARN as slow; faster queries are logged at DEBUG.
+const DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+// Values of the "component" attribute on records logged by the stores
+const (
+	componentGraphStore  = "store.graph"
+	componentMemoryStore = "store.memory"
+)
+
+// queryLogger is the optional structured logger of a store. Records identify nodes,
+// edges and memories by ID only: names, descriptions, memory content and query text
+// are never logged.
+type queryLogger struct {
+	logger    *slog.Logger
+	slowQuery time.Duration
+}
+
+// setLogger sets the logger, tagging its records with component; nil disables logging
+func (l *queryLogger) setLogger(logger *slog.Logger, component string) {
+	if logger != nil {
+		logger = logger.With(slog.String("component", component))
+	}
+	l.logger = logger
+}
+
+// SetSlowQueryThreshold sets the duration from which queries are logged at WARN
+// (default: DefaultSlowQueryThreshold).
+func (l *queryLogger) SetSlowQueryThreshold(threshold time.Duration) {
+	l.slowQuery = threshold
+}
+
+// logQuery logs a completed operation op with its duration, at WARN when slow
+func (l *queryLogger) logQuery(ctx context.Context, op string, start time.Time, attrs ...slog.Attr) {
+	if l.logger == nil {
+		return
+	}
+	elapsed := time.Since(start)
+	threshold := l.slowQuery
+	if threshold <= 0 {
+		threshold = DefaultSlowQueryThreshold
+	}
+
+	level, msg := slog.LevelDebug, "query completed"
+	if elapsed >= threshold {
+		level, msg = slog.LevelWarn, "slow query"
+	}
+	attrs = append([]slog.Attr{
+		slog.String("op", op),
+		slog.Int64("duration_ms", elapsed.Milliseconds()),
+	}, attrs...)
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// log logs msg at level, if a logger is set
+func (l *queryLogger) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if l.logger != nil {
+		l.logger.LogAttrs(ctx, level, msg, attrs...)
+	}
+}
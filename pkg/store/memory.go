@@ -5,7 +5,9 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -35,6 +37,9 @@ type MemoryRecord struct {
 	Pinned          bool                   `json:"pinned"`           // M9: Plan 021 - Whether this memory is pinned
 	PinnedAt        *time.Time             `json:"pinned_at"`        // M9: Plan 021 - When this memory was pinned
 	PinnedReason    *string                `json:"pinned_reason"`    // M9: Plan 021 - Why this memory was pinned (nullable)
+	Tags            []string               `json:"tags,omitempty"`   // Normalized tags, sorted (see AddTags)
+	CurrentScore    float64                `json:"current_score"`    // Decay score when read, before the read counts as an access (see MemoryScore)
+	ACL             *MemoryACL             `json:"acl,omitempty"`    // Who can read the memory; nil = everyone (see WithPrincipal)
 }
 
 // MemorySummary provides a lightweight view of a memory for list operations.
@@ -50,17 +55,47 @@ type MemorySummary struct {
 	Pinned          bool      `json:"pinned"`           // M10: Plan 021
 	AccessCount     int       `json:"access_count"`     // M10: Plan 021
 	SupersededBy    *string   `json:"superseded_by"`    // M10: Plan 021
+	CurrentScore    float64   `json:"current_score"`    // Decay score when listed (see MemoryScore)
+}
+
+// Summary returns the list view of the memory, with the context truncated to 200 characters.
+func (r *MemoryRecord) Summary() MemorySummary {
+	preview := r.Context
+	if len(preview) > 200 {
+		preview = preview[:200] + "..."
+	}
+	return MemorySummary{
+		ID:              r.ID,
+		Topic:           r.Topic,
+		Preview:         preview,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+		DecisionCount:   len(r.Decisions),
+		Status:          r.Status,
+		RetentionPolicy: r.RetentionPolicy,
+		Pinned:          r.Pinned,
+		AccessCount:     r.AccessCount,
+		SupersededBy:    r.SupersededBy,
+		CurrentScore:    r.CurrentScore,
+	}
 }
 
 // ListMemoriesOptions provides pagination and filtering for memory listing (M10: Plan 021).
 type ListMemoriesOptions struct {
 	Offset          int
-	Limit           int     // Default 50, max 100
-	Status          *string // Filter by status (Active, Superseded, Pinned, etc.) (M10)
-	RetentionPolicy *string // Filter by retention_policy (M10)
-	Pinned          *bool   // Filter pinned only (M10)
-	OrderBy         string  // "created_at", "updated_at", "access_count", "last_accessed_at" (M10)
-	OrderDesc       bool    // Default true (newest/highest first) (M10)
+	Limit           int      // Default 50, max 100
+	Status          *string  // Filter by status (Active, Superseded, Pinned, etc.) (M10)
+	RetentionPolicy *string  // Filter by retention_policy (M10)
+	Pinned          *bool    // Filter pinned only (M10)
+	OrderBy         string   // "created_at", "updated_at", "access_count", "last_accessed_at" (M10), "score"
+	OrderDesc       bool     // Default true (newest/highest first) (M10)
+	Tags            []string // Filter to memories carrying all of these tags
+	// MetadataFilters keeps memories whose metadata has each key set to the given string
+	// value. Keys may address nested objects with dots ("jira.ticket"). See IndexedMetadataKeys.
+	MetadataFilters map[string]string
+	// IncludeArchived lists archived memories along with the others. Archived memories
+	// are otherwise only listed when Status is StatusArchived.
+	IncludeArchived bool
 }
 
 // MemoryUpdate represents partial updates to a memory.
@@ -72,6 +107,7 @@ type MemoryUpdate struct {
 	Rationale *[]string
 	Metadata  *map[string]interface{}
 	Status    *string
+	ACL       *MemoryACL // Replaces the ACL; an empty ACL makes the memory visible to everyone
 }
 
 // SupersessionRecord represents a memory supersession relationship (M3: Plan 021).
@@ -100,10 +136,11 @@ type MemoryStore interface {
 	// DeleteMemory removes a memory and its provenance links.
 	DeleteMemory(ctx context.Context, id string) error
 
-	// GetMemoriesByNodeID returns all memory IDs that reference a given node.
+	// GetMemoriesByNodeID returns the IDs of the memories (excluding soft-deleted ones)
+	// that reference a given node.
 	GetMemoriesByNodeID(ctx context.Context, nodeID string) ([]string, error)
 
-	// CountMemories returns the total number of memories in the store.
+	// CountMemories returns the number of memories in the store, excluding soft-deleted ones.
 	CountMemories(ctx context.Context) (int64, error)
 
 	// UpdateMemoryAccess increments access tracking for a single memory.
@@ -123,11 +160,30 @@ type MemoryStore interface {
 
 	// GetSupersededMemories returns the IDs of memories this one supersedes (M3: Plan 021).
 	GetSupersededMemories(ctx context.Context, memoryID string) ([]string, error)
+
+	// PinMemory pins a memory with a reason, exempting it from decay, retention and pruning.
+	PinMemory(ctx context.Context, id, reason string) error
+
+	// UnpinMemory unpins a memory.
+	UnpinMemory(ctx context.Context, id string) error
+
+	// ListPinned returns the pinned memories, most recently pinned first.
+	ListPinned(ctx context.Context) ([]PinnedMemory, error)
+
+	// ArchiveMemory moves a memory to the archive (StatusArchived).
+	ArchiveMemory(ctx context.Context, id string) error
+
+	// UnarchiveMemory restores an archived memory to the status it had when archived.
+	UnarchiveMemory(ctx context.Context, id string) error
 }
 
 // SQLiteMemoryStore implements MemoryStore using SQLite.
 type SQLiteMemoryStore struct {
-	db *sql.DB
+	db          *sql.DB
+	namespace   string       // Scopes all reads and writes (see WithNamespace)
+	decayPolicy *DecayPolicy // Scores memories (see WithDecayPolicy)
+	cipher      *FieldCipher // Encrypts context and decisions (see WithEncryption)
+	queryLogger
 }
 
 // NewSQLiteMemoryStore creates a new SQLite-backed memory store.
@@ -136,6 +192,12 @@ func NewSQLiteMemoryStore(db *sql.DB) *SQLiteMemoryStore {
 	return &SQLiteMemoryStore{db: db}
 }
 
+// SetLogger sets the structured logger for query timings and garbage collection
+// decisions; nil (the default) disables logging.
+func (s *SQLiteMemoryStore) SetLogger(logger *slog.Logger) {
+	s.setLogger(logger, componentMemoryStore)
+}
+
 // DB returns the underlying database connection for advanced operations.
 func (s *SQLiteMemoryStore) DB() *sql.DB {
 	return s.db
@@ -168,8 +230,58 @@ func ComputeDocHash(topic, context string, decisions, rationale []string) string
 	return fmt.Sprintf("%x", hash)
 }
 
+// FindDuplicateMemory returns the ID of a memory that is not deleted and has the given
+// content hash, or "" if there is none. Session memories only match within their session:
+// an empty sessionID matches memories added outside any session.
+func (s *SQLiteMemoryStore) FindDuplicateMemory(ctx context.Context, docHash, sessionID string) (string, error) {
+	sessionExpr, err := metadataExpr(SessionMetadataKey)
+	if err != nil {
+		return "", err
+	}
+	query := `SELECT id FROM memories WHERE doc_hash = ? AND namespace = ? AND status != ?`
+	args := []interface{}{docHash, s.namespace, StatusDeleted}
+	if sessionID == "" {
+		query += " AND " + sessionExpr + " IS NULL"
+	} else {
+		query += " AND " + sessionExpr + " = ?"
+		args = append(args, sessionID)
+	}
+
+	var id string
+	err = s.db.QueryRowContext(ctx, query+" LIMIT 1", args...).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check for duplicate memory: %w", err)
+	}
+	return id, nil
+}
+
 // AddMemory creates a new memory record.
 func (s *SQLiteMemoryStore) AddMemory(ctx context.Context, record *MemoryRecord) error {
+	// Begin transaction
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	if err := s.insertMemory(ctx, tx, record); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", classifyWriteError(err))
+	}
+
+	return nil
+}
+
+// insertMemory fills in the defaults of a new memory record and inserts it with its
+// tags and ACL.
+func (s *SQLiteMemoryStore) insertMemory(ctx context.Context, tx *sql.Tx, record *MemoryRecord) error {
 	// Generate ID if not provided
 	if record.ID == "" {
 		record.ID = uuid.New().String()
@@ -212,23 +324,26 @@ func (s *SQLiteMemoryStore) AddMemory(ctx context.Context, record *MemoryRecord)
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
-	// Begin transaction
-	tx, err := s.db.BeginTx(ctx, nil)
+	tags, err := NormalizeTags(record.Tags)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return err
+	}
+
+	storedContext, decisionsJSON, err := s.sealContent(record.Context, decisionsJSON)
+	if err != nil {
+		return err
 	}
-	defer tx.Rollback() // Rollback if not committed
 
 	query := `
 		INSERT INTO memories (id, topic, context, decisions_json, rationale_json, metadata_json,
-			created_at, updated_at, version, doc_hash, source, status, retention_policy, pinned)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			created_at, updated_at, version, doc_hash, source, status, retention_policy, pinned, namespace)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err = tx.ExecContext(ctx, query,
 		record.ID,
 		record.Topic,
-		record.Context,
+		storedContext,
 		decisionsJSON,
 		rationaleJSON,
 		metadataJSON,
@@ -240,36 +355,43 @@ func (s *SQLiteMemoryStore) AddMemory(ctx context.Context, record *MemoryRecord)
 		record.Status,
 		record.RetentionPolicy,
 		record.Pinned,
+		s.namespace,
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to insert memory: %w", err)
+		return fmt.Errorf("failed to insert memory: %w", classifyWriteError(err))
 	}
 
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	for _, tag := range tags {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO memory_tags (memory_id, tag) VALUES (?, ?)", record.ID, tag); err != nil {
+			return fmt.Errorf("failed to add tag: %w", err)
+		}
 	}
 
+	if !record.ACL.IsEmpty() {
+		if err := writeACL(ctx, tx, record.ID, record.ACL); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// GetMemory retrieves a memory by ID.
-func (s *SQLiteMemoryStore) GetMemory(ctx context.Context, id string) (*MemoryRecord, error) {
-	query := `
-		SELECT id, topic, context, decisions_json, rationale_json, metadata_json,
+// memoryColumns are the columns of a MemoryRecord, in the order scanMemoryRecord reads them.
+const memoryColumns = `id, topic, context, decisions_json, rationale_json, metadata_json,
 			created_at, updated_at, version, doc_hash, source, status,
 			access_count, last_accessed_at, access_velocity, superseded_by,
-			retention_policy, retention_until, pinned, pinned_at, pinned_reason
-		FROM memories
-		WHERE id = ?
-	`
+			retention_policy, retention_until, pinned, pinned_at, pinned_reason,
+			acl_owner, acl_team, acl_labels`
 
+// scanMemoryRecord scans a row of memoryColumns, scoring the memory with the store's
+// decay policy. Tags are not read.
+func (s *SQLiteMemoryStore) scanMemoryRecord(row interface{ Scan(...any) error }) (*MemoryRecord, error) {
 	var record MemoryRecord
 	var decisionsJSON, rationaleJSON, metadataJSON []byte
 	var pinnedReason sql.NullString
+	var aclOwner, aclTeam, aclLabels string
 
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
+	err := row.Scan(
 		&record.ID,
 		&record.Topic,
 		&record.Context,
@@ -291,19 +413,26 @@ func (s *SQLiteMemoryStore) GetMemory(ctx context.Context, id string) (*MemoryRe
 		&record.Pinned,
 		&record.PinnedAt,
 		&pinnedReason,
+		&aclOwner,
+		&aclTeam,
+		&aclLabels,
 	)
-
-	if err == sql.ErrNoRows {
-		return nil, ErrMemoryNotFound
-	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get memory: %w", err)
+		return nil, err
+	}
+	if record.ACL, err = scanACL(aclOwner, aclTeam, aclLabels); err != nil {
+		return nil, err
+	}
+	if err := s.openContent(&record.Context, &decisionsJSON); err != nil {
+		return nil, err
 	}
 
 	// Handle nullable pinned_reason
 	if pinnedReason.Valid {
 		record.PinnedReason = &pinnedReason.String
 	}
+	record.CurrentScore = s.decayPolicy.MemoryScore(record.RetentionPolicy, record.Pinned, record.CreatedAt,
+		record.LastAccessedAt, record.AccessCount, time.Now())
 
 	// Deserialize JSON fields
 	if len(decisionsJSON) > 0 {
@@ -323,55 +452,63 @@ func (s *SQLiteMemoryStore) GetMemory(ctx context.Context, id string) (*MemoryRe
 			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
 		}
 	}
+	return &record, nil
+}
+
+// GetMemory retrieves a memory by ID. Memories the context's principal cannot read
+// (see WithPrincipal) are reported as ErrMemoryNotFound.
+func (s *SQLiteMemoryStore) GetMemory(ctx context.Context, id string) (*MemoryRecord, error) {
+	clause, aclArgs := aclClause(ctx, "")
+	query := "SELECT " + memoryColumns + " FROM memories WHERE id = ? AND namespace = ?" + clause
+
+	args := append([]interface{}{id, s.namespace}, aclArgs...)
+	record, err := s.scanMemoryRecord(s.db.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrMemoryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory: %w", err)
+	}
+
+	tags, err := s.ListTags(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) > 0 {
+		record.Tags = tags
+	}
 
 	// Update access tracking (Milestone 1: Memory Access Tracking)
-	// Don't fail the read if access tracking fails
+	// Don't fail the read if access tracking fails, e.g. on a read-only database
 	if err := s.UpdateMemoryAccess(ctx, id); err != nil {
-		// Log error but don't fail the read
-		// In production, this could use a proper logger
-		_ = err
+		s.log(ctx, slog.LevelWarn, "memory access tracking failed",
+			slog.String("memory_id", id), slog.String("error", err.Error()))
 	}
 
-	return &record, nil
+	return record, nil
 }
 
-// ListMemories returns paginated memory summaries.
+// ListMemories returns paginated memory summaries. Soft-deleted memories are only
+// listed when opts.Status is StatusDeleted, archived ones when opts.Status is
+// StatusArchived or opts.IncludeArchived is set. Only memories the context's principal
+// can read are listed (see WithPrincipal).
 func (s *SQLiteMemoryStore) ListMemories(ctx context.Context, opts ListMemoriesOptions) ([]MemorySummary, error) {
 	// Apply defaults and limits
-	if opts.Limit == 0 {
-		opts.Limit = 50
-	}
-	if opts.Limit > 100 {
-		opts.Limit = 100
-	}
+	opts.Limit = clampListLimit(opts.Limit)
 	if opts.Offset < 0 {
 		opts.Offset = 0
 	}
 
 	// M10: Build dynamic query with filters
-	query := `
-		SELECT id, topic, context, decisions_json, created_at, updated_at, status,
-			retention_policy, pinned, access_count, superseded_by
-		FROM memories
-		WHERE 1=1
-	`
-
-	args := make([]interface{}, 0)
-
-	// M10: Apply filters
-	if opts.Status != nil {
-		query += " AND status = ?"
-		args = append(args, *opts.Status)
-	}
-
-	if opts.RetentionPolicy != nil {
-		query += " AND retention_policy = ?"
-		args = append(args, *opts.RetentionPolicy)
+	where, args, err := s.memoryFilters(ctx, opts)
+	if err != nil {
+		return nil, err
 	}
+	query := memorySummaryColumns + " WHERE " + where
 
-	if opts.Pinned != nil {
-		query += " AND pinned = ?"
-		args = append(args, *opts.Pinned)
+	// Decay scores are computed by memory_score, registered with the SQLite driver
+	if opts.OrderBy == "score" {
+		return s.listMemoriesByScore(ctx, query, args, opts)
 	}
 
 	// M10: Apply ordering
@@ -400,7 +537,106 @@ func (s *SQLiteMemoryStore) ListMemories(ctx context.Context, opts ListMemoriesO
 	}
 	defer rows.Close()
 
+	return scanMemorySummaries(rows, s.decayPolicy, s.cipher)
+}
+
+// listMemoriesByScore lists the memories selected by query ordered by CurrentScore
+// (highest first, or lowest first when opts.OrderDesc is unset). The query computes the
+// scores, so only the requested page is read.
+func (s *SQLiteMemoryStore) listMemoriesByScore(ctx context.Context, query string, args []interface{}, opts ListMemoriesOptions) ([]MemorySummary, error) {
+	score, scoreArgs := s.decayPolicy.memoryScoreSQL(time.Now())
+	orderDir := "ASC"
+	if opts.OrderDesc {
+		orderDir = "DESC"
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, created_at %s LIMIT ? OFFSET ?", score, orderDir, orderDir)
+	args = append(append(args, scoreArgs...), opts.Limit, opts.Offset)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memories: %w", err)
+	}
+	defer rows.Close()
+
+	return scanMemorySummaries(rows, s.decayPolicy, s.cipher)
+}
+
+// memorySummaryColumns selects the columns read by scanMemorySummaries
+const memorySummaryColumns = `
+		SELECT id, topic, context, decisions_json, created_at, updated_at, status,
+			retention_policy, pinned, access_count, superseded_by, last_accessed_at
+		FROM memories`
+
+// clampListLimit applies the default (50) and maximum (100) list page size
+func clampListLimit(limit int) int {
+	if limit <= 0 {
+		return 50
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
+
+// memoryFilters builds the WHERE condition (without the keyword) and arguments for the
+// filters of opts and the context's principal.
+func (s *SQLiteMemoryStore) memoryFilters(ctx context.Context, opts ListMemoriesOptions) (string, []interface{}, error) {
+	where := "namespace = ?"
+	args := []interface{}{s.namespace}
+
+	// M10: Apply filters (soft-deleted and archived memories are only listed when asked for)
+	if opts.Status != nil {
+		where += " AND status = ?"
+		args = append(args, *opts.Status)
+	} else if opts.IncludeArchived {
+		where += " AND status != ?"
+		args = append(args, StatusDeleted)
+	} else {
+		where += " AND status NOT IN (?, ?)"
+		args = append(args, StatusDeleted, StatusArchived)
+	}
+
+	if opts.RetentionPolicy != nil {
+		where += " AND retention_policy = ?"
+		args = append(args, *opts.RetentionPolicy)
+	}
+
+	if opts.Pinned != nil {
+		where += " AND pinned = ?"
+		args = append(args, *opts.Pinned)
+	}
+
+	if len(opts.Tags) > 0 {
+		tags, err := NormalizeTags(opts.Tags)
+		if err != nil {
+			return "", nil, err
+		}
+		clause, tagArgs := tagFilterClause("id", tags)
+		where += clause
+		args = append(args, tagArgs...)
+	}
+
+	if len(opts.MetadataFilters) > 0 {
+		clause, filterArgs, err := metadataFilterClause(opts.MetadataFilters)
+		if err != nil {
+			return "", nil, err
+		}
+		where += clause
+		args = append(args, filterArgs...)
+	}
+
+	clause, aclArgs := aclClause(ctx, "")
+	where += clause
+	args = append(args, aclArgs...)
+
+	return where, args, nil
+}
+
+// scanMemorySummaries reads rows selected with memorySummaryColumns, scoring them with policy
+// and decrypting them with c
+func scanMemorySummaries(rows *sql.Rows, policy *DecayPolicy, c *FieldCipher) ([]MemorySummary, error) {
 	var summaries []MemorySummary
+	now := time.Now()
 	for rows.Next() {
 		var id, topic, context, status, retentionPolicy string
 		var decisionsJSON []byte
@@ -408,12 +644,19 @@ func (s *SQLiteMemoryStore) ListMemories(ctx context.Context, opts ListMemoriesO
 		var pinned bool
 		var accessCount int
 		var supersededBy *string
+		var lastAccessedAt *time.Time
 
 		err := rows.Scan(&id, &topic, &context, &decisionsJSON, &createdAt, &updatedAt, &status,
-			&retentionPolicy, &pinned, &accessCount, &supersededBy)
+			&retentionPolicy, &pinned, &accessCount, &supersededBy, &lastAccessedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan memory: %w", err)
 		}
+		if context, err = c.Decrypt(context); err != nil {
+			return nil, err
+		}
+		if decisionsJSON, err = c.decryptBytes(decisionsJSON); err != nil {
+			return nil, err
+		}
 
 		// Truncate context for preview (max 200 chars)
 		preview := context
@@ -439,6 +682,7 @@ func (s *SQLiteMemoryStore) ListMemories(ctx context.Context, opts ListMemoriesO
 			Pinned:          pinned,
 			AccessCount:     accessCount,
 			SupersededBy:    supersededBy,
+			CurrentScore:    policy.MemoryScore(retentionPolicy, pinned, createdAt, lastAccessedAt, accessCount, now),
 		})
 	}
 
@@ -463,13 +707,13 @@ func (s *SQLiteMemoryStore) UpdateMemory(ctx context.Context, id string, updates
 		SELECT id, topic, context, decisions_json, rationale_json, metadata_json,
 			created_at, updated_at, version, doc_hash, source, status
 		FROM memories
-		WHERE id = ?
+		WHERE id = ? AND namespace = ?
 	`
 
 	var existing MemoryRecord
 	var decisionsJSON, rationaleJSON, metadataJSON []byte
 
-	err = tx.QueryRowContext(ctx, query, id).Scan(
+	err = tx.QueryRowContext(ctx, query, id, s.namespace).Scan(
 		&existing.ID,
 		&existing.Topic,
 		&existing.Context,
@@ -490,6 +734,9 @@ func (s *SQLiteMemoryStore) UpdateMemory(ctx context.Context, id string, updates
 	if err != nil {
 		return fmt.Errorf("failed to get memory: %w", err)
 	}
+	if err := s.openContent(&existing.Context, &decisionsJSON); err != nil {
+		return err
+	}
 
 	// Deserialize JSON fields
 	if len(decisionsJSON) > 0 {
@@ -510,6 +757,12 @@ func (s *SQLiteMemoryStore) UpdateMemory(ctx context.Context, id string, updates
 		}
 	}
 
+	// Keep the version being replaced in the edit history
+	revisedAt := time.Now()
+	if err := s.recordRevision(ctx, tx, &existing, revisedAt); err != nil {
+		return err
+	}
+
 	// Apply updates
 	if updates.Topic != nil {
 		existing.Topic = *updates.Topic
@@ -529,9 +782,15 @@ func (s *SQLiteMemoryStore) UpdateMemory(ctx context.Context, id string, updates
 	if updates.Status != nil {
 		existing.Status = *updates.Status
 	}
+	if updates.ACL != nil {
+		if err := writeACL(ctx, tx, id, updates.ACL); err != nil {
+			return err
+		}
+	}
 
-	// Update timestamp and version
-	existing.UpdatedAt = time.Now()
+	// Update content hash, timestamp and version
+	existing.DocHash = ComputeDocHash(existing.Topic, existing.Context, existing.Decisions, existing.Rationale)
+	existing.UpdatedAt = revisedAt
 	existing.Version++
 
 	// Serialize JSON fields
@@ -550,19 +809,25 @@ func (s *SQLiteMemoryStore) UpdateMemory(ctx context.Context, id string, updates
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
+	storedContext, decisionsJSON, err := s.sealContent(existing.Context, decisionsJSON)
+	if err != nil {
+		return err
+	}
+
 	updateQuery := `
 		UPDATE memories
 		SET topic = ?, context = ?, decisions_json = ?, rationale_json = ?, metadata_json = ?,
-			updated_at = ?, version = ?, status = ?
+			doc_hash = ?, updated_at = ?, version = ?, status = ?
 		WHERE id = ?
 	`
 
 	_, err = tx.ExecContext(ctx, updateQuery,
 		existing.Topic,
-		existing.Context,
+		storedContext,
 		decisionsJSON,
 		rationaleJSON,
 		metadataJSON,
+		existing.DocHash,
 		existing.UpdatedAt,
 		existing.Version,
 		existing.Status,
@@ -591,7 +856,7 @@ func (s *SQLiteMemoryStore) DeleteMemory(ctx context.Context, id string) error {
 	defer tx.Rollback()
 
 	// Delete memory (CASCADE will handle provenance tables)
-	result, err := tx.ExecContext(ctx, "DELETE FROM memories WHERE id = ?", id)
+	result, err := tx.ExecContext(ctx, "DELETE FROM memories WHERE id = ? AND namespace = ?", id, s.namespace)
 	if err != nil {
 		return fmt.Errorf("failed to delete memory: %w", err)
 	}
@@ -613,18 +878,20 @@ func (s *SQLiteMemoryStore) DeleteMemory(ctx context.Context, id string) error {
 	return nil
 }
 
-// GetMemoriesByNodeID returns all memory IDs that reference a given node.
+// GetMemoriesByNodeID returns the IDs of the memories (excluding soft-deleted ones and
+// those the context's principal cannot read) that reference a given node.
 // Returns memory IDs sorted by updated_at DESC (most recent first).
 func (s *SQLiteMemoryStore) GetMemoriesByNodeID(ctx context.Context, nodeID string) ([]string, error) {
+	clause, aclArgs := aclClause(ctx, "m.")
 	query := `
 		SELECT DISTINCT m.id
 		FROM memories m
 		JOIN memory_nodes mn ON m.id = mn.memory_id
-		WHERE mn.node_id = ?
+		WHERE mn.node_id = ? AND m.status != ?` + clause + `
 		ORDER BY m.updated_at DESC
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, nodeID)
+	rows, err := s.db.QueryContext(ctx, query, append([]interface{}{nodeID, StatusDeleted}, aclArgs...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query memories by node: %w", err)
 	}
@@ -646,21 +913,23 @@ func (s *SQLiteMemoryStore) GetMemoriesByNodeID(ctx context.Context, nodeID stri
 	return memoryIDs, nil
 }
 
-// CountMemories returns the total number of memories in the store.
+// CountMemories returns the number of memories in the store, excluding soft-deleted ones.
 // Uses an indexed query for O(1) performance.
 func (s *SQLiteMemoryStore) CountMemories(ctx context.Context) (int64, error) {
 	var count int64
-	query := "SELECT COUNT(*) FROM memories"
-	err := s.db.QueryRowContext(ctx, query).Scan(&count)
+	query := "SELECT COUNT(*) FROM memories WHERE namespace = ? AND status != ?"
+	err := s.db.QueryRowContext(ctx, query, s.namespace, StatusDeleted).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count memories: %w", err)
 	}
 	return count, nil
 }
 
-// GetMemoriesByNodeIDBatched returns memory IDs for multiple nodes in a single query.
+// GetMemoriesByNodeIDBatched returns memory IDs for multiple nodes in a single query,
+// excluding soft-deleted memories and those the context's principal cannot read.
 // Returns a map of nodeID -> []memoryID (sorted by updated_at DESC per node).
 func (s *SQLiteMemoryStore) GetMemoriesByNodeIDBatched(ctx context.Context, nodeIDs []string) (map[string][]string, error) {
+	start := time.Now()
 	if len(nodeIDs) == 0 {
 		return make(map[string][]string), nil
 	}
@@ -673,13 +942,16 @@ func (s *SQLiteMemoryStore) GetMemoriesByNodeIDBatched(ctx context.Context, node
 		args[i] = nodeID
 	}
 
+	clause, aclArgs := aclClause(ctx, "m.")
 	query := fmt.Sprintf(`
 		SELECT mn.node_id, m.id, m.updated_at
 		FROM memory_nodes mn
 		JOIN memories m ON mn.memory_id = m.id
-		WHERE mn.node_id IN (%s)
+		WHERE mn.node_id IN (%s) AND m.status != ?%s
 		ORDER BY mn.node_id, m.updated_at DESC
-	`, strings.Join(placeholders, ","))
+	`, strings.Join(placeholders, ","), clause)
+	args = append(args, StatusDeleted)
+	args = append(args, aclArgs...)
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -709,6 +981,42 @@ func (s *SQLiteMemoryStore) GetMemoriesByNodeIDBatched(ctx context.Context, node
 		}
 	}
 
+	s.logQuery(ctx, "get_memories_by_node_ids", start, slog.Int("node_ids", len(nodeIDs)))
+	return result, nil
+}
+
+// GetMemorySummariesBatched returns the summaries of the given memories in one query,
+// keyed by memory ID. Memories that do not exist or that the context's principal cannot
+// read are left out.
+func (s *SQLiteMemoryStore) GetMemorySummariesBatched(ctx context.Context, ids []string) (map[string]MemorySummary, error) {
+	result := make(map[string]MemorySummary, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := []interface{}{s.namespace}
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+	clause, aclArgs := aclClause(ctx, "")
+	query := memorySummaryColumns + fmt.Sprintf(" WHERE namespace = ? AND id IN (%s)", strings.Join(placeholders, ",")) + clause
+	args = append(args, aclArgs...)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch query memory summaries: %w", err)
+	}
+	defer rows.Close()
+
+	summaries, err := scanMemorySummaries(rows, s.decayPolicy, s.cipher)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range summaries {
+		result[summary.ID] = summary
+	}
 	return result, nil
 }
 
@@ -721,6 +1029,20 @@ func (s *SQLiteMemoryStore) LinkProvenance(ctx context.Context, memoryID string,
 	}
 	defer tx.Rollback()
 
+	if err := linkProvenance(ctx, tx, memoryID, nodeIDs, edgeIDs); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// linkProvenance links derived nodes/edges to a memory within tx.
+func linkProvenance(ctx context.Context, tx *sql.Tx, memoryID string, nodeIDs, edgeIDs []string) error {
 	// Insert node provenance
 	nodeStmt, err := tx.PrepareContext(ctx, "INSERT OR IGNORE INTO memory_nodes (memory_id, node_id) VALUES (?, ?)")
 	if err != nil {
@@ -746,12 +1068,6 @@ func (s *SQLiteMemoryStore) LinkProvenance(ctx context.Context, memoryID string,
 			return fmt.Errorf("failed to link edge provenance: %w", err)
 		}
 	}
-
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
 	return nil
 }
 
@@ -972,60 +1288,111 @@ func (s *SQLiteMemoryStore) GarbageCollect(ctx context.Context) (nodesDeleted, e
 }
 
 // GarbageCollectCandidates removes candidate nodes/edges if they have zero provenance references.
-// This is the actual GC implementation called after unlinking provenance.
-func (s *SQLiteMemoryStore) GarbageCollectCandidates(ctx context.Context, nodeIDs, edgeIDs []string) (nodesDeleted, edgesDeleted int, err error) {
+// This is the actual GC implementation called after unlinking provenance. Nodes and edges
+// a Cognify document links to (see DocumentStore) are kept. GC runs in one transaction, so
+// a canceled ctx rolls it back entirely and returns an error matching ErrCancelled.
+// Returns the number of nodes and the IDs of the edges deleted.
+func (s *SQLiteMemoryStore) GarbageCollectCandidates(ctx context.Context, nodeIDs, edgeIDs []string) (nodesDeleted int, edgesDeleted []string, err error) {
+	start := time.Now()
+	if ctx.Err() != nil {
+		return 0, nil, cancelledError(ctx)
+	}
+
 	// Begin transaction
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+		return 0, nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
 	// Delete edges with zero provenance references
 	for _, edgeID := range edgeIDs {
+		if ctx.Err() != nil {
+			return 0, nil, cancelledError(ctx)
+		}
 		var count int
-		err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM memory_edges WHERE edge_id = ?", edgeID).Scan(&count)
+		err := tx.QueryRowContext(ctx, `
+			SELECT (SELECT COUNT(*) FROM memory_edges WHERE edge_id = ?) + (SELECT COUNT(*) FROM document_edges WHERE edge_id = ?)
+		`, edgeID, edgeID).Scan(&count)
 		if err != nil {
-			return 0, 0, fmt.Errorf("failed to count edge references: %w", err)
+			return 0, nil, fmt.Errorf("failed to count edge references: %w", err)
 		}
 
 		if count == 0 {
 			_, err := tx.ExecContext(ctx, "DELETE FROM edges WHERE id = ?", edgeID)
 			if err != nil {
-				return 0, 0, fmt.Errorf("failed to delete orphaned edge: %w", err)
+				return 0, nil, fmt.Errorf("failed to delete orphaned edge: %w", err)
 			}
-			edgesDeleted++
+			edgesDeleted = append(edgesDeleted, edgeID)
 		}
+		s.logGCDecision(ctx, slog.String("edge_id", edgeID), count, count == 0, false)
 	}
 
 	// Delete nodes with zero provenance references
 	for _, nodeID := range nodeIDs {
+		if ctx.Err() != nil {
+			return 0, nil, cancelledError(ctx)
+		}
 		var count int
-		err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM memory_nodes WHERE node_id = ?", nodeID).Scan(&count)
+		err := tx.QueryRowContext(ctx, `
+			SELECT (SELECT COUNT(*) FROM memory_nodes WHERE node_id = ?) + (SELECT COUNT(*) FROM document_nodes WHERE node_id = ?)
+		`, nodeID, nodeID).Scan(&count)
 		if err != nil {
-			return 0, 0, fmt.Errorf("failed to count node references: %w", err)
+			return 0, nil, fmt.Errorf("failed to count node references: %w", err)
 		}
 
 		if count == 0 {
-			_, err := tx.ExecContext(ctx, "DELETE FROM nodes WHERE id = ?", nodeID)
+			// Pinned nodes outlive the memories they came from
+			res, err := tx.ExecContext(ctx, "DELETE FROM nodes WHERE id = ? AND NOT pinned", nodeID)
 			if err != nil {
-				return 0, 0, fmt.Errorf("failed to delete orphaned node: %w", err)
+				return 0, nil, fmt.Errorf("failed to delete orphaned node: %w", err)
+			}
+			affected, _ := res.RowsAffected()
+			if affected > 0 {
+				nodesDeleted++
 			}
-			nodesDeleted++
+			s.logGCDecision(ctx, slog.String("node_id", nodeID), count, affected > 0, affected == 0)
+			continue
 		}
+		s.logGCDecision(ctx, slog.String("node_id", nodeID), count, false, false)
 	}
 
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
-		return 0, 0, fmt.Errorf("failed to commit transaction: %w", err)
+		return 0, nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	s.log(ctx, slog.LevelInfo, "garbage collection completed",
+		slog.Int("candidates", len(nodeIDs)+len(edgeIDs)),
+		slog.Int("nodes_deleted", nodesDeleted),
+		slog.Int("edges_deleted", len(edgesDeleted)),
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()))
 	return nodesDeleted, edgesDeleted, nil
 }
 
+// logGCDecision logs at DEBUG whether a garbage collection candidate, identified by id,
+// was deleted or kept because it is still referenced or pinned.
+func (s *SQLiteMemoryStore) logGCDecision(ctx context.Context, id slog.Attr, references int, deleted, pinned bool) {
+	decision := "keep_referenced"
+	switch {
+	case deleted:
+		decision = "delete"
+	case pinned:
+		decision = "keep_pinned"
+	}
+	s.log(ctx, slog.LevelDebug, "gc decision", id, slog.Int("references", references), slog.String("decision", decision))
+}
+
 // ErrMemoryNotFound indicates that no memory was found for the given ID.
 var ErrMemoryNotFound = fmt.Errorf("memory not found")
 
+// ErrSupersessionCycle indicates a supersession that would make a memory supersede
+// itself, directly or through other memories.
+var ErrSupersessionCycle = errors.New("supersession cycle")
+
+// maxSupersessionChainLength bounds the supersession links followed from one memory.
+const maxSupersessionChainLength = 1000
+
 // UpdateMemoryAccess increments access tracking for a single memory.
 // Updates access_count, last_accessed_at, and recomputes access_velocity in real-time.
 func (s *SQLiteMemoryStore) UpdateMemoryAccess(ctx context.Context, id string) error {
@@ -1154,9 +1521,31 @@ func (s *SQLiteMemoryStore) BatchUpdateMemoryAccess(ctx context.Context, ids []s
 
 // RecordSupersession records that one memory supersedes another (M3: Plan 021).
 func (s *SQLiteMemoryStore) RecordSupersession(ctx context.Context, supersedingID, supersededID, reason string) error {
+	// Begin transaction
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.recordSupersession(ctx, tx, supersedingID, supersededID, reason); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit supersession: %w", err)
+	}
+
+	return nil
+}
+
+// recordSupersession records within tx that one memory supersedes another and marks
+// the superseded memory Superseded.
+func (s *SQLiteMemoryStore) recordSupersession(ctx context.Context, tx *sql.Tx, supersedingID, supersededID, reason string) error {
 	// Validate that both memories exist
 	var countSuperseding, countSuperseded int
-	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM memories WHERE id = ?", supersedingID).Scan(&countSuperseding)
+	err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM memories WHERE id = ? AND namespace = ?", supersedingID, s.namespace).Scan(&countSuperseding)
 	if err != nil {
 		return fmt.Errorf("failed to check superseding memory: %w", err)
 	}
@@ -1164,7 +1553,7 @@ func (s *SQLiteMemoryStore) RecordSupersession(ctx context.Context, supersedingI
 		return fmt.Errorf("superseding memory %s not found", supersedingID)
 	}
 
-	err = s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM memories WHERE id = ?", supersededID).Scan(&countSuperseded)
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM memories WHERE id = ? AND namespace = ?", supersededID, s.namespace).Scan(&countSuperseded)
 	if err != nil {
 		return fmt.Errorf("failed to check superseded memory: %w", err)
 	}
@@ -1172,12 +1561,10 @@ func (s *SQLiteMemoryStore) RecordSupersession(ctx context.Context, supersedingI
 		return fmt.Errorf("superseded memory %s not found", supersededID)
 	}
 
-	// Begin transaction
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+	// Refuse supersessions that would make a memory (transitively) supersede itself
+	if err := checkSupersessionCycle(ctx, tx, supersedingID, supersededID); err != nil {
+		return err
 	}
-	defer tx.Rollback()
 
 	// Insert supersession record
 	supersessionID := uuid.New().String()
@@ -1187,7 +1574,7 @@ func (s *SQLiteMemoryStore) RecordSupersession(ctx context.Context, supersedingI
 	`
 	_, err = tx.ExecContext(ctx, insertQuery, supersessionID, supersedingID, supersededID, reason, time.Now())
 	if err != nil {
-		return fmt.Errorf("failed to insert supersession record: %w", err)
+		return fmt.Errorf("failed to insert supersession record: %w", classifyWriteError(err))
 	}
 
 	// Update superseded memory: set status to 'Superseded' and superseded_by field
@@ -1202,21 +1589,85 @@ func (s *SQLiteMemoryStore) RecordSupersession(ctx context.Context, supersedingI
 	if err != nil {
 		return fmt.Errorf("failed to update superseded memory: %w", err)
 	}
+	return nil
+}
+
+// ConsolidateMemory adds record, links it to the given nodes and edges and records that
+// it supersedes each of supersededIDs, all in one transaction. Every superseded memory
+// must still be current ('Active' or 'complete'); if one is not, nothing is written.
+func (s *SQLiteMemoryStore) ConsolidateMemory(ctx context.Context, record *MemoryRecord, nodeIDs, edgeIDs, supersededIDs []string, reason string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Checked inside the transaction, so a concurrent supersession cannot slip in between
+	for _, id := range supersededIDs {
+		var status string
+		err := tx.QueryRowContext(ctx, "SELECT status FROM memories WHERE id = ? AND namespace = ?", id, s.namespace).Scan(&status)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("cannot consolidate memory %s: %w", id, ErrMemoryNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check memory %s: %w", id, err)
+		}
+		if status != "Active" && status != "complete" {
+			return Invalidf("cannot consolidate memory %s: status is '%s', must be 'Active' or 'complete'", id, status)
+		}
+	}
+
+	if err := s.insertMemory(ctx, tx, record); err != nil {
+		return err
+	}
+	if err := linkProvenance(ctx, tx, record.ID, nodeIDs, edgeIDs); err != nil {
+		return err
+	}
+	for _, id := range supersededIDs {
+		if err := s.recordSupersession(ctx, tx, record.ID, id, reason); err != nil {
+			return err
+		}
+	}
 
-	// Commit transaction
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit supersession: %w", err)
+		return fmt.Errorf("failed to commit consolidation: %w", classifyWriteError(err))
 	}
+	return nil
+}
 
+// checkSupersessionCycle returns ErrSupersessionCycle if recording that supersedingID
+// supersedes supersededID would make a memory (transitively) supersede itself.
+func checkSupersessionCycle(ctx context.Context, tx *sql.Tx, supersedingID, supersededID string) error {
+	if supersedingID == supersededID {
+		return fmt.Errorf("memory %s cannot supersede itself: %w", supersedingID, ErrSupersessionCycle)
+	}
+	var cycle bool
+	err := tx.QueryRowContext(ctx, `
+		WITH RECURSIVE older(id) AS (
+			SELECT superseded_id FROM memory_supersession WHERE superseding_id = ?
+			UNION
+			SELECT ms.superseded_id FROM memory_supersession ms JOIN older ON ms.superseding_id = older.id
+		)
+		SELECT EXISTS (SELECT 1 FROM older WHERE id = ?)
+	`, supersededID, supersedingID).Scan(&cycle)
+	if err != nil {
+		return fmt.Errorf("failed to check supersession cycle: %w", err)
+	}
+	if cycle {
+		return fmt.Errorf("memory %s already supersedes memory %s: %w", supersededID, supersedingID, ErrSupersessionCycle)
+	}
 	return nil
 }
 
 // GetSupersessionChain retrieves the full chain of supersessions for a memory (M3: Plan 021).
 // Returns the chain from oldest to newest, including the given memoryID.
 func (s *SQLiteMemoryStore) GetSupersessionChain(ctx context.Context, memoryID string) ([]SupersessionRecord, error) {
-	// Trace backward to find the root (oldest) memory
+	// Trace backward to find the root (oldest) memory. Links are followed at most
+	// maxSupersessionChainLength times and never twice, so cycles recorded before
+	// RecordSupersession refused them cannot loop forever.
 	rootID := memoryID
-	for {
+	visited := map[string]bool{rootID: true}
+	for len(visited) <= maxSupersessionChainLength {
 		var supersededID sql.NullString
 		err := s.db.QueryRowContext(ctx,
 			"SELECT superseded_id FROM memory_supersession WHERE superseding_id = ?",
@@ -1229,17 +1680,19 @@ func (s *SQLiteMemoryStore) GetSupersessionChain(ctx context.Context, memoryID s
 		if err != nil {
 			return nil, fmt.Errorf("failed to trace supersession chain backward: %w", err)
 		}
-		if !supersededID.Valid {
+		if !supersededID.Valid || visited[supersededID.String] {
 			break
 		}
 		rootID = supersededID.String
+		visited[rootID] = true
 	}
 
 	// Now trace forward from root to build the full chain
 	chain := []SupersessionRecord{}
 	currentID := rootID
+	visited = map[string]bool{rootID: true}
 
-	for {
+	for len(chain) < maxSupersessionChainLength {
 		query := `
 			SELECT id, superseding_id, superseded_id, reason, created_at
 			FROM memory_supersession
@@ -1262,7 +1715,8 @@ func (s *SQLiteMemoryStore) GetSupersessionChain(ctx context.Context, memoryID s
 			}
 			chain = append(chain, record)
 			currentID = record.SupersedingID
-			foundNext = true
+			foundNext = !visited[currentID]
+			visited[currentID] = true
 			break // Only take the first (oldest) superseding record
 		}
 
@@ -1282,12 +1736,75 @@ func (s *SQLiteMemoryStore) GetSupersessionChain(ctx context.Context, memoryID s
 	return chain, nil
 }
 
+// GetSupersessionHead returns the ID of the current memory of the supersession chain
+// memoryID is part of: the memory reached by following superseded_by links, or memoryID
+// itself if it is not superseded. The links are followed in one recursive query. Returns
+// ErrMemoryNotFound for an unknown memoryID and ErrSupersessionCycle when the links loop
+// or exceed maxSupersessionChainLength.
+func (s *SQLiteMemoryStore) GetSupersessionHead(ctx context.Context, memoryID string) (string, error) {
+	var headID string
+	var next sql.NullString
+	var depth int
+	err := s.db.QueryRowContext(ctx, `
+		WITH RECURSIVE chain(id, next, depth) AS (
+			SELECT id, superseded_by, 0 FROM memories WHERE id = ? AND namespace = ?
+			UNION ALL
+			SELECT m.id, m.superseded_by, chain.depth + 1
+			FROM chain JOIN memories m ON m.id = chain.next AND m.namespace = ?
+			WHERE chain.depth < ?
+		)
+		SELECT id, next, depth FROM chain ORDER BY depth DESC LIMIT 1
+	`, memoryID, s.namespace, s.namespace, maxSupersessionChainLength).Scan(&headID, &next, &depth)
+	if err == sql.ErrNoRows {
+		return "", ErrMemoryNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get supersession head: %w", err)
+	}
+	switch {
+	case !next.Valid:
+		return headID, nil
+	case depth == maxSupersessionChainLength:
+		return "", fmt.Errorf("failed to find the head of the supersession chain of memory %s: %w", memoryID, ErrSupersessionCycle)
+	default:
+		// The superseding memory is gone
+		return "", ErrMemoryNotFound
+	}
+}
+
+// SupersessionHead returns the ID of the current memory of the supersession chain
+// memoryID is part of, or memoryID itself if it is not superseded. Stores implementing
+// SupersessionResolver resolve it themselves; others are followed one
+// GetSupersedingMemory call at a time.
+func SupersessionHead(ctx context.Context, memoryStore MemoryStore, memoryID string) (string, error) {
+	if resolver, ok := memoryStore.(SupersessionResolver); ok {
+		return resolver.GetSupersessionHead(ctx, memoryID)
+	}
+	headID := memoryID
+	visited := map[string]bool{}
+	for len(visited) <= maxSupersessionChainLength {
+		visited[headID] = true
+		next, err := memoryStore.GetSupersedingMemory(ctx, headID)
+		if err != nil {
+			return "", err
+		}
+		if next == nil {
+			return headID, nil
+		}
+		if visited[*next] {
+			break
+		}
+		headID = *next
+	}
+	return "", fmt.Errorf("failed to find the head of the supersession chain of memory %s: %w", memoryID, ErrSupersessionCycle)
+}
+
 // GetSupersedingMemory returns the ID of the memory that supersedes this one, if any (M3: Plan 021).
 func (s *SQLiteMemoryStore) GetSupersedingMemory(ctx context.Context, memoryID string) (*string, error) {
 	var supersedingID sql.NullString
-	query := "SELECT superseded_by FROM memories WHERE id = ?"
+	query := "SELECT superseded_by FROM memories WHERE id = ? AND namespace = ?"
 
-	err := s.db.QueryRowContext(ctx, query, memoryID).Scan(&supersedingID)
+	err := s.db.QueryRowContext(ctx, query, memoryID, s.namespace).Scan(&supersedingID)
 	if err == sql.ErrNoRows {
 		return nil, ErrMemoryNotFound
 	}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -245,6 +246,16 @@ func TestMemoryStore_Provenance(t *testing.T) {
 	if len(batchMap["node1"]) != 1 {
 		t.Errorf("Expected 1 memory for node1, got %d", len(batchMap["node1"]))
 	}
+
+	// Test batched summaries
+	summaries, err := memStore.GetMemorySummariesBatched(ctx, []string{memory.ID, "missing"})
+	if err != nil {
+		t.Fatalf("GetMemorySummariesBatched failed: %v", err)
+	}
+
+	if len(summaries) != 1 || summaries[memory.ID].Topic != "Test" {
+		t.Errorf("Expected only the summary of %s, got %+v", memory.ID, summaries)
+	}
 }
 
 func TestMemoryStore_UnlinkProvenanceAndCounts(t *testing.T) {
@@ -310,6 +321,13 @@ func TestMemoryStore_UnlinkProvenanceAndCounts(t *testing.T) {
 		t.Fatalf("expected 0 references after unlink, got %d", refCount)
 	}
 
+	// A canceled context stops GC before anything is deleted.
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, _, err := memStore.GarbageCollectCandidates(canceled, []string{"node1"}, []string{"edge1"}); !errors.Is(err, ErrCancelled) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected ErrCancelled wrapping context.Canceled, got %v", err)
+	}
+
 	// Candidate-based GC should delete the unreferenced artifacts.
 	nodesDeleted, edgesDeleted, err := memStore.GarbageCollectCandidates(ctx, []string{"node1"}, []string{"edge1"})
 	if err != nil {
@@ -318,8 +336,8 @@ func TestMemoryStore_UnlinkProvenanceAndCounts(t *testing.T) {
 	if nodesDeleted != 1 {
 		t.Fatalf("expected 1 node deleted, got %d", nodesDeleted)
 	}
-	if edgesDeleted != 1 {
-		t.Fatalf("expected 1 edge deleted, got %d", edgesDeleted)
+	if len(edgesDeleted) != 1 || edgesDeleted[0] != "edge1" {
+		t.Fatalf("expected edge1 deleted, got %v", edgesDeleted)
 	}
 
 	// Placeholder GC currently returns (0,0,nil); exercise it to lock behavior.
@@ -6,36 +6,60 @@ import (
 	"sync"
 )
 
+// exactSearchLimit is the store size up to which Search scans every vector: small
+// stores are searched exactly, at no cost in latency.
+const exactSearchLimit = 1000
+
 // MemoryVectorStore is an in-memory implementation of VectorStore.
-// It uses a map to store vectors and provides thread-safe access via RWMutex.
+// Vectors are kept in a map and indexed by an HNSW graph, so search stays fast at
+// hundreds of thousands of vectors; access is thread-safe via RWMutex.
 // Note: This implementation does not persist vectors across restarts.
 type MemoryVectorStore struct {
-	vectors map[string][]float32
-	mu      sync.RWMutex
+	vectors    map[string][]float32
+	index      *hnswIndex
+	dimensions int // Set by the first Add; later vectors must match
+	mu         sync.RWMutex
 }
 
-// NewMemoryVectorStore creates a new in-memory vector store.
+// NewMemoryVectorStore creates a new in-memory vector store with the default HNSW parameters.
 func NewMemoryVectorStore() *MemoryVectorStore {
+	return NewMemoryVectorStoreWithConfig(DefaultHNSWConfig())
+}
+
+// NewMemoryVectorStoreWithConfig creates a new in-memory vector store with the given
+// HNSW parameters.
+func NewMemoryVectorStoreWithConfig(cfg HNSWConfig) *MemoryVectorStore {
 	return &MemoryVectorStore{
 		vectors: make(map[string][]float32),
+		index:   newHNSWIndex(cfg),
 	}
 }
 
 // Add adds or updates a vector for the given ID.
+// Returns a *DimensionMismatchError if its length differs from the vectors already stored.
 func (m *MemoryVectorStore) Add(ctx context.Context, id string, embedding []float32) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if len(m.vectors) == 0 {
+		m.dimensions = len(embedding)
+	} else if len(embedding) != m.dimensions {
+		return &DimensionMismatchError{Expected: m.dimensions, Actual: len(embedding)}
+	}
+
 	// Make a copy to avoid external mutations
 	embeddingCopy := make([]float32, len(embedding))
 	copy(embeddingCopy, embedding)
 
 	m.vectors[id] = embeddingCopy
+	m.index.add(id, embeddingCopy)
 	return nil
 }
 
 // Search finds the most similar vectors to the query.
 // Returns up to topK results sorted by similarity score (descending).
+// Stores larger than exactSearchLimit are searched approximately through the HNSW
+// index; HNSWConfig.EfSearch trades latency for recall.
 func (m *MemoryVectorStore) Search(ctx context.Context, query []float32, topK int) ([]SearchResult, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -44,6 +68,13 @@ func (m *MemoryVectorStore) Search(ctx context.Context, query []float32, topK in
 	if len(m.vectors) == 0 {
 		return []SearchResult{}, nil
 	}
+	if len(query) != m.dimensions {
+		return nil, &DimensionMismatchError{Expected: m.dimensions, Actual: len(query)}
+	}
+
+	if len(m.vectors) > exactSearchLimit {
+		return m.index.search(query, topK), nil
+	}
 
 	// Compute similarity for all vectors
 	var results []SearchResult
@@ -74,5 +105,15 @@ func (m *MemoryVectorStore) Delete(ctx context.Context, id string) error {
 	defer m.mu.Unlock()
 
 	delete(m.vectors, id)
+	m.index.remove(id)
 	return nil
 }
+
+// Clear removes all vectors from the store.
+func (m *MemoryVectorStore) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.vectors = make(map[string][]float32)
+	m.index = newHNSWIndex(m.index.cfg)
+}
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// IndexedMetadataKeys are the metadata keys indexed by default for ListMemoriesOptions.MetadataFilters.
+// Other keys can be indexed with EnsureMetadataIndex.
+var IndexedMetadataKeys = []string{"project", "author", "ticket_id"}
+
+// metadataKeyPattern restricts metadata filter keys to identifiers, optionally nested with
+// dots ("jira.ticket"), since they are embedded in the JSON path literal.
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+// metadataExpr returns the SQL expression extracting a metadata key. The JSON path is a
+// literal rather than a parameter so queries can use the expression indexes.
+func metadataExpr(key string) (string, error) {
+	if !metadataKeyPattern.MatchString(key) {
+		return "", fmt.Errorf("invalid metadata key %q: must be letters, digits and underscores, optionally separated by dots", key)
+	}
+	return fmt.Sprintf("json_extract(metadata_json, '$.%s')", key), nil
+}
+
+// metadataFilterClause returns an " AND ..." clause restricting memories to those whose
+// metadata has every key of filters set to the given string value, with its arguments.
+func metadataFilterClause(filters map[string]string) (string, []interface{}, error) {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys) // Stable query text
+
+	var clause strings.Builder
+	args := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		expr, err := metadataExpr(key)
+		if err != nil {
+			return "", nil, err
+		}
+		clause.WriteString(" AND " + expr + " = ?")
+		args = append(args, filters[key])
+	}
+	return clause.String(), args, nil
+}
+
+// EnsureMetadataIndex creates an index on a metadata key, speeding up MetadataFilters on it.
+func (s *SQLiteMemoryStore) EnsureMetadataIndex(ctx context.Context, key string) error {
+	return createMetadataIndex(ctx, s.db, key)
+}
+
+// metadataIndexName returns the name of the index on a metadata key.
+func metadataIndexName(key string) string {
+	return "idx_memories_meta_" + strings.ReplaceAll(key, ".", "_")
+}
+
+// migrateMetadataIndexes indexes the IndexedMetadataKeys.
+func (s *SQLiteGraphStore) migrateMetadataIndexes() error {
+	for _, key := range IndexedMetadataKeys {
+		if err := createMetadataIndex(context.Background(), s.db, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createMetadataIndex creates the expression index used by metadata filters on key
+func createMetadataIndex(ctx context.Context, db *sql.DB, key string) error {
+	expr, err := metadataExpr(key)
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON memories(namespace, %s)", metadataIndexName(key), expr)
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create metadata index on %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestListMemories_MetadataFilters(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+
+	memories := []*MemoryRecord{
+		{Topic: "A", Context: "a", DocHash: "h1", Metadata: map[string]interface{}{"project": "apollo", "author": "kim"}},
+		{Topic: "B", Context: "b", DocHash: "h2", Metadata: map[string]interface{}{"project": "apollo", "author": "lee"}},
+		{Topic: "C", Context: "c", DocHash: "h3", Metadata: map[string]interface{}{"project": "gemini", "jira": map[string]interface{}{"ticket": "GEM-7"}}},
+		{Topic: "D", Context: "d", DocHash: "h4"},
+	}
+	for _, mem := range memories {
+		if err := memStore.AddMemory(ctx, mem); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		filters map[string]string
+		want    []string
+	}{
+		{map[string]string{"project": "apollo"}, []string{"A", "B"}},
+		{map[string]string{"project": "apollo", "author": "lee"}, []string{"B"}},
+		{map[string]string{"jira.ticket": "GEM-7"}, []string{"C"}},
+		{map[string]string{"project": "mercury"}, nil},
+	}
+	for _, tt := range tests {
+		listed, err := memStore.ListMemories(ctx, ListMemoriesOptions{MetadataFilters: tt.filters, OrderBy: "created_at"})
+		if err != nil {
+			t.Fatalf("ListMemories(%v) failed: %v", tt.filters, err)
+		}
+		var topics []string
+		for _, summary := range listed {
+			topics = append(topics, summary.Topic)
+		}
+		if strings.Join(topics, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("ListMemories(%v) = %v, want %v", tt.filters, topics, tt.want)
+		}
+	}
+
+	if _, err := memStore.ListMemories(ctx, ListMemoriesOptions{MetadataFilters: map[string]string{"x') OR 1=1 --": "y"}}); err == nil {
+		t.Error("Expected error for invalid metadata key")
+	}
+}
+
+func TestMetadataIndexes(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+	if err := memStore.EnsureMetadataIndex(ctx, "customer"); err != nil {
+		t.Fatalf("EnsureMetadataIndex failed: %v", err)
+	}
+
+	for _, key := range []string{"project", "customer"} {
+		expr, _ := metadataExpr(key)
+		rows, err := graphStore.DB().QueryContext(ctx,
+			"EXPLAIN QUERY PLAN SELECT id FROM memories WHERE namespace = ? AND "+expr+" = ?", "", "x")
+		if err != nil {
+			t.Fatalf("EXPLAIN failed: %v", err)
+		}
+		var plan strings.Builder
+		for rows.Next() {
+			var id, parent, notUsed int
+			var detail string
+			rows.Scan(&id, &parent, &notUsed, &detail)
+			plan.WriteString(detail)
+		}
+		rows.Close()
+		if !strings.Contains(plan.String(), "idx_memories_meta_"+key) {
+			t.Errorf("Expected filter on %s to use its index, got plan %q", key, plan.String())
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrSchemaTooNew is matched (via errors.Is) by every SchemaVersionError.
+var ErrSchemaTooNew = errors.New("database schema is newer than supported")
+
+// SchemaVersionError reports a database migrated by a newer version of gognee than the
+// running one. Such databases are not opened, since older code may corrupt them.
+type SchemaVersionError struct {
+	Version   int // Schema version of the database
+	Supported int // Latest schema version this code knows (LatestSchemaVersion)
+}
+
+func (e *SchemaVersionError) Error() string {
+	return fmt.Sprintf("database schema version %d is newer than the supported version %d", e.Version, e.Supported)
+}
+
+// Is makes errors.Is(err, ErrSchemaTooNew) match.
+func (e *SchemaVersionError) Is(target error) bool {
+	return target == ErrSchemaTooNew
+}
+
+// schemaMigration is one versioned step of the SQLite schema. Up is idempotent, so
+// databases created before schema_migrations existed replay every step to adopt it. Down
+// reverts Up; steps without Down cannot be reverted.
+type schemaMigration struct {
+	version int
+	name    string
+	up      func(s *SQLiteGraphStore) error
+	down    func(s *SQLiteGraphStore) error
+}
+
+// schemaMigrations are the schema steps in the order they are applied. Append new steps
+// with the next version; never change or reorder released ones.
+var schemaMigrations = []schemaMigration{
+	{1, "base", (*SQLiteGraphStore).createBaseSchema, nil},
+	{2, "node_columns", (*SQLiteGraphStore).migrateNodeColumns, nil},
+	{3, "edge_columns", (*SQLiteGraphStore).migrateEdgeColumns, nil},
+	{4, "memories", (*SQLiteGraphStore).migrateMemoryTables, dropTables("memory_supersession", "memory_edges", "memory_nodes", "memories")},
+	{5, "relation_merges", (*SQLiteGraphStore).migrateRelationMergeSchema, dropTables("relation_merges")},
+	{6, "entity_merges", (*SQLiteGraphStore).migrateEntityMergeSchema, dropTables("entity_merges")},
+	{7, "memory_revisions", (*SQLiteGraphStore).migrateMemoryRevisionSchema, dropTables("memory_revisions")},
+	{8, "memory_soft_delete", (*SQLiteGraphStore).migrateSoftDeleteSchema, func(s *SQLiteGraphStore) error {
+		return s.dropColumns("memories", []string{"idx_memories_deleted_at"}, "deleted_at", "pre_delete_status")
+	}},
+	{9, "quarantine", (*SQLiteGraphStore).migrateQuarantineSchema, dropTables("quarantine_nodes", "quarantines")},
+	{10, "memory_tags", (*SQLiteGraphStore).migrateTagSchema, dropTables("memory_tags")},
+	{11, "namespaces", (*SQLiteGraphStore).migrateNamespaceSchema, (*SQLiteGraphStore).revertNamespaceSchema},
+	{12, "metadata_indexes", (*SQLiteGraphStore).migrateMetadataIndexes, func(s *SQLiteGraphStore) error {
+		for _, key := range IndexedMetadataKeys {
+			if _, err := s.db.Exec("DROP INDEX IF EXISTS " + metadataIndexName(key)); err != nil {
+				return fmt.Errorf("failed to drop metadata index on %s: %w", key, err)
+			}
+		}
+		return nil
+	}},
+	{13, "communities", (*SQLiteGraphStore).migrateCommunitySchema, func(s *SQLiteGraphStore) error {
+		return s.dropColumns("nodes", []string{"idx_nodes_community"}, "community_id")
+	}},
+	{14, "keyword_index", (*SQLiteGraphStore).migrateKeywordSchema, func(s *SQLiteGraphStore) error {
+		for _, trigger := range []string{"nodes_fts_insert", "nodes_fts_update", "nodes_fts_delete"} {
+			if _, err := s.db.Exec("DROP TRIGGER IF EXISTS " + trigger); err != nil {
+				return fmt.Errorf("failed to drop trigger %s: %w", trigger, err)
+			}
+		}
+		return dropTables("nodes_fts", "keyword_node_ids")(s)
+	}},
+	{15, "embedding_registry", (*SQLiteGraphStore).migrateEmbeddingRegistry, dropTables("store_metadata")},
+	{16, "llm_cache", (*SQLiteGraphStore).migrateLLMCacheSchema, dropTables("llm_cache")},
+	{17, "ingestion_queue", (*SQLiteGraphStore).migrateIngestionQueueSchema, dropTables("pending_chunks", "pending_documents")},
+	{18, "documents", (*SQLiteGraphStore).migrateDocumentSchema, dropTables("document_chunks", "document_edges", "document_nodes", "documents")},
+	{19, "memory_archive", (*SQLiteGraphStore).migrateArchiveSchema, func(s *SQLiteGraphStore) error {
+		return s.dropColumns("memories", nil, "archived_at", "pre_archive_status")
+	}},
+	{20, "memory_sessions", (*SQLiteGraphStore).migrateSessionSchema, (*SQLiteGraphStore).revertSessionSchema},
+	{21, "outbox", (*SQLiteGraphStore).migrateOutboxSchema, (*SQLiteGraphStore).revertOutboxSchema},
+	{22, "imported_files", (*SQLiteGraphStore).migrateImportSchema, dropTables("imported_files")},
+	{23, "memory_acl", (*SQLiteGraphStore).migrateACLSchema, func(s *SQLiteGraphStore) error {
+		return s.dropColumns("memories", nil, "acl_owner", "acl_team", "acl_labels")
+	}},
+	{24, "chunks", (*SQLiteGraphStore).migrateChunkSchema, dropTables("vec_chunks", "chunks")},
+	{25, "node_descriptions", (*SQLiteGraphStore).migrateDescriptionSchema, dropTables("node_descriptions")},
+	{26, "node_age_index", (*SQLiteGraphStore).migrateNodeAgeIndex, func(s *SQLiteGraphStore) error {
+		return s.dropColumns("nodes", []string{"idx_nodes_namespace_accessed"})
+	}},
+	{27, "vector_cleanup", (*SQLiteGraphStore).migrateVectorCleanup, func(s *SQLiteGraphStore) error {
+		for _, trigger := range []string{"vec_node_ids_delete", "vec_edge_ids_delete"} {
+			if _, err := s.db.Exec("DROP TRIGGER IF EXISTS " + trigger); err != nil {
+				return fmt.Errorf("failed to drop trigger %s: %w", trigger, err)
+			}
+		}
+		return nil
+	}},
+	{28, "node_description_cleanup", (*SQLiteGraphStore).migrateDescriptionCleanup, func(s *SQLiteGraphStore) error {
+		if _, err := s.db.Exec("DROP TRIGGER IF EXISTS node_descriptions_delete"); err != nil {
+			return fmt.Errorf("failed to drop trigger node_descriptions_delete: %w", err)
+		}
+		return nil
+	}},
+	{29, "chunk_offsets", (*SQLiteGraphStore).migrateChunkOffsets, func(s *SQLiteGraphStore) error {
+		return s.dropColumns("chunks", nil, "start_offset", "end_offset")
+	}},
+}
+
+// LatestSchemaVersion returns the schema version this code migrates databases to.
+func LatestSchemaVersion() int {
+	return schemaMigrations[len(schemaMigrations)-1].version
+}
+
+// initSchema records applied migrations in schema_migrations, refuses databases with a
+// newer schema than this code knows and applies the pending migrations.
+func (s *SQLiteGraphStore) initSchema() error {
+	ctx := context.Background()
+	if _, err := s.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	current, err := s.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if current > LatestSchemaVersion() {
+		return &SchemaVersionError{Version: current, Supported: LatestSchemaVersion()}
+	}
+	return s.MigrateTo(ctx, LatestSchemaVersion())
+}
+
+// SchemaVersion returns the version of the last schema migration applied to the database.
+func (s *SQLiteGraphStore) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	if err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+	return version, nil
+}
+
+// MigrateTo migrates the database schema up or down to version, applying or reverting
+// one migration at a time. Opening a store already migrates it to LatestSchemaVersion;
+// migrating down prepares a database for an older version of gognee, and the store
+// must not be used for anything else afterwards. Returns a ValidationError, before
+// changing anything, for an unknown version or when a migration to revert has no down
+// step.
+func (s *SQLiteGraphStore) MigrateTo(ctx context.Context, version int) error {
+	if version < 0 || version > LatestSchemaVersion() {
+		return Invalidf("unknown schema version %d: must be between 0 and %d", version, LatestSchemaVersion())
+	}
+	current, err := s.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range schemaMigrations {
+		if m.version > current && m.version <= version {
+			if err := m.up(s); err != nil {
+				return fmt.Errorf("failed to apply schema migration %d (%s): %w", m.version, m.name, err)
+			}
+			if _, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO schema_migrations (version, name) VALUES (?, ?)`, m.version, m.name); err != nil {
+				return fmt.Errorf("failed to record schema migration %d: %w", m.version, err)
+			}
+		}
+	}
+
+	if version >= current {
+		return nil
+	}
+	for _, m := range schemaMigrations {
+		if m.version > version && m.version <= current && m.down == nil {
+			return Invalidf("schema migration %d (%s) cannot be reverted", m.version, m.name)
+		}
+	}
+	for i := len(schemaMigrations) - 1; i >= 0; i-- {
+		m := schemaMigrations[i]
+		if m.version <= version || m.version > current {
+			continue
+		}
+		if err := m.down(s); err != nil {
+			return fmt.Errorf("failed to revert schema migration %d (%s): %w", m.version, m.name, err)
+		}
+		if _, err := s.db.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?`, m.version); err != nil {
+			return fmt.Errorf("failed to record reverted schema migration %d: %w", m.version, err)
+		}
+	}
+	return nil
+}
+
+// dropTables returns a down step dropping tables, in order, along with their indexes.
+func dropTables(tables ...string) func(s *SQLiteGraphStore) error {
+	return func(s *SQLiteGraphStore) error {
+		for _, table := range tables {
+			if _, err := s.db.Exec("DROP TABLE IF EXISTS " + table); err != nil {
+				return fmt.Errorf("failed to drop %s table: %w", table, err)
+			}
+		}
+		return nil
+	}
+}
+
+// addColumn adds column with definition to table. Columns that already exist, in
+// databases created before schema_migrations, are skipped.
+func (s *SQLiteGraphStore) addColumn(table, column, definition string) error {
+	if s.columnExists(table, column) {
+		return nil
+	}
+	if _, err := s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add %s column: %w", column, err)
+	}
+	return nil
+}
+
+// addColumns adds columns, given as name and definition pairs, to table (see addColumn).
+func (s *SQLiteGraphStore) addColumns(table string, columns [][2]string) error {
+	for _, column := range columns {
+		if err := s.addColumn(table, column[0], column[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropColumns drops indexes and then columns from table, skipping those already gone.
+func (s *SQLiteGraphStore) dropColumns(table string, indexes []string, columns ...string) error {
+	for _, index := range indexes {
+		if _, err := s.db.Exec("DROP INDEX IF EXISTS " + index); err != nil {
+			return fmt.Errorf("failed to drop index %s: %w", index, err)
+		}
+	}
+	for _, column := range columns {
+		if !s.columnExists(table, column) {
+			continue
+		}
+		if _, err := s.db.Exec(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", table, column)); err != nil {
+			return fmt.Errorf("failed to drop %s column: %w", column, err)
+		}
+	}
+	return nil
+}
+
+// columnExists checks if a column exists in a table.
+func (s *SQLiteGraphStore) columnExists(tableName, columnName string) bool {
+	query := fmt.Sprintf("PRAGMA table_info(%s)", tableName)
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return false
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid int
+		var name string
+		var ctype string
+		var notnull int
+		var dfltValue sql.NullString
+		var pk int
+
+		err := rows.Scan(&cid, &name, &ctype, &notnull, &dfltValue, &pk)
+		if err != nil {
+			return false
+		}
+
+		if name == columnName {
+			return true
+		}
+	}
+
+	return false
+}
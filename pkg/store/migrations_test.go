@@ -0,0 +1,139 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func tableExists(t *testing.T, s *SQLiteGraphStore, table string) bool {
+	t.Helper()
+	var count int
+	if err := s.DB().QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = ?`, table).Scan(&count); err != nil {
+		t.Fatalf("failed to check for table %s: %v", table, err)
+	}
+	return count > 0
+}
+
+func TestSchemaVersion_NewStoreIsLatest(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+
+	version, err := s.SchemaVersion(context.Background())
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if version != LatestSchemaVersion() {
+		t.Errorf("expected schema version %d, got %d", LatestSchemaVersion(), version)
+	}
+
+	var applied int
+	if err := s.DB().QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&applied); err != nil {
+		t.Fatalf("failed to count migrations: %v", err)
+	}
+	if applied != len(schemaMigrations) {
+		t.Errorf("expected %d recorded migrations, got %d", len(schemaMigrations), applied)
+	}
+}
+
+func TestMigrateTo_DownAndUp(t *testing.T) {
+	ctx := context.Background()
+	s := setupTestStore(t)
+	defer s.Close()
+
+	// Revert back to the edge columns, the last migration that cannot be reverted
+	if err := s.MigrateTo(ctx, 3); err != nil {
+		t.Fatalf("MigrateTo(3) failed: %v", err)
+	}
+	if version, _ := s.SchemaVersion(ctx); version != 3 {
+		t.Errorf("expected schema version 3, got %d", version)
+	}
+	if tableExists(t, s, "documents") || tableExists(t, s, "memories") || tableExists(t, s, "nodes_fts") ||
+		s.columnExists("nodes", "community_id") || s.columnExists("nodes", "namespace") {
+		t.Error("expected the reverted migrations' tables and columns dropped")
+	}
+	if !s.columnExists("edges", "valid_from") {
+		t.Error("expected migration 3 kept")
+	}
+
+	if err := s.MigrateTo(ctx, LatestSchemaVersion()); err != nil {
+		t.Fatalf("MigrateTo(latest) failed: %v", err)
+	}
+	if !tableExists(t, s, "documents") || !tableExists(t, s, "memories") || !s.columnExists("nodes", "namespace") {
+		t.Error("expected the migrations reapplied")
+	}
+	if err := s.AddNode(ctx, &Node{ID: "n1", Name: "Go", Type: "Language"}); err != nil {
+		t.Fatalf("AddNode after migrating up failed: %v", err)
+	}
+	if ids := keywordIDs(t, s, "go", NodeFilter{}); len(ids) != 1 {
+		t.Errorf("expected the keyword index restored, got %v", ids)
+	}
+}
+
+func TestMigrateTo_Invalid(t *testing.T) {
+	ctx := context.Background()
+	s := setupTestStore(t)
+	defer s.Close()
+
+	for _, version := range []int{-1, LatestSchemaVersion() + 1, 2} {
+		if err := s.MigrateTo(ctx, version); !errors.Is(err, ErrValidation) {
+			t.Errorf("MigrateTo(%d): expected ErrValidation, got %v", version, err)
+		}
+	}
+	if version, _ := s.SchemaVersion(ctx); version != LatestSchemaVersion() {
+		t.Errorf("expected a rejected migration to change nothing, got version %d", version)
+	}
+	if !tableExists(t, s, "documents") {
+		t.Error("expected a rejected migration to keep the tables")
+	}
+}
+
+func TestNewSQLiteGraphStore_RefusesNewerSchema(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "newer.db")
+	s, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	if _, err := s.DB().Exec(`INSERT INTO schema_migrations (version, name) VALUES (?, 'from_the_future')`, LatestSchemaVersion()+1); err != nil {
+		t.Fatalf("failed to record migration: %v", err)
+	}
+	s.Close()
+
+	_, err = NewSQLiteGraphStore(dbPath)
+	var versionErr *SchemaVersionError
+	if !errors.Is(err, ErrSchemaTooNew) || !errors.As(err, &versionErr) {
+		t.Fatalf("expected ErrSchemaTooNew, got %v", err)
+	}
+	if versionErr.Version != LatestSchemaVersion()+1 || versionErr.Supported != LatestSchemaVersion() {
+		t.Errorf("unexpected versions in %+v", versionErr)
+	}
+}
+
+func TestNewSQLiteGraphStore_AdoptsUnversionedDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "legacy.db")
+	s, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	if err := s.AddNode(context.Background(), &Node{ID: "n1", Name: "Go", Type: "Language"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	// Simulate a database from before schema_migrations
+	if _, err := s.DB().Exec(`DROP TABLE schema_migrations`); err != nil {
+		t.Fatalf("failed to drop schema_migrations: %v", err)
+	}
+	s.Close()
+
+	s, err = NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("expected the unversioned database adopted, got %v", err)
+	}
+	defer s.Close()
+	if version, _ := s.SchemaVersion(context.Background()); version != LatestSchemaVersion() {
+		t.Errorf("expected schema version %d, got %d", LatestSchemaVersion(), version)
+	}
+	if node, err := s.GetNode(context.Background(), "n1"); err != nil || node == nil {
+		t.Errorf("expected existing data kept, got %v, %v", node, err)
+	}
+}
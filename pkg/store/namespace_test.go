@@ -0,0 +1,182 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+// openNamespacedStores opens two stores in different namespaces on the same database file.
+func openNamespacedStores(t *testing.T) (*SQLiteGraphStore, *SQLiteGraphStore) {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "namespaces.db")
+
+	a, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	t.Cleanup(func() { a.Close() })
+
+	b, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	t.Cleanup(func() { b.Close() })
+
+	return a.WithNamespace("project-a"), b.WithNamespace("project-b")
+}
+
+func TestNamespace_GraphIsolation(t *testing.T) {
+	a, b := openNamespacedStores(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"a1", "a2"} {
+		if err := a.AddNode(ctx, &Node{ID: id, Name: "Shared Name"}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	if err := a.AddEdge(ctx, &Edge{ID: "a-edge", SourceID: "a1", Relation: "USES", TargetID: "a2"}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	if err := b.AddNode(ctx, &Node{ID: "b1", Name: "Shared Name"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+
+	if n, _ := a.NodeCount(ctx); n != 2 {
+		t.Errorf("Expected 2 nodes in project-a, got %d", n)
+	}
+	if n, _ := b.NodeCount(ctx); n != 1 {
+		t.Errorf("Expected 1 node in project-b, got %d", n)
+	}
+	if n, _ := b.EdgeCount(ctx); n != 0 {
+		t.Errorf("Expected 0 edges in project-b, got %d", n)
+	}
+
+	node, err := b.GetNode(ctx, "a1")
+	if err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	if node != nil {
+		t.Errorf("Expected node from project-a to be invisible in project-b")
+	}
+
+	found, err := b.FindNodeByName(ctx, "shared name")
+	if err != nil {
+		t.Fatalf("FindNodeByName failed: %v", err)
+	}
+	if found.ID != "b1" {
+		t.Errorf("Expected b1, got %s", found.ID)
+	}
+
+	edges, err := b.GetEdges(ctx, "a1")
+	if err != nil {
+		t.Fatalf("GetEdges failed: %v", err)
+	}
+	if len(edges) != 0 {
+		t.Errorf("Expected no edges visible from project-b, got %d", len(edges))
+	}
+}
+
+func TestNamespace_ProcessedDocuments(t *testing.T) {
+	a, b := openNamespacedStores(t)
+	ctx := context.Background()
+
+	if err := a.MarkDocumentProcessed(ctx, "hash1", "doc", 1); err != nil {
+		t.Fatalf("MarkDocumentProcessed failed: %v", err)
+	}
+
+	if processed, _ := a.IsDocumentProcessed(ctx, "hash1"); !processed {
+		t.Error("Expected document to be processed in project-a")
+	}
+	if processed, _ := b.IsDocumentProcessed(ctx, "hash1"); processed {
+		t.Error("Expected document to be unprocessed in project-b")
+	}
+	if n, _ := b.GetProcessedDocumentCount(ctx); n != 0 {
+		t.Errorf("Expected 0 processed documents in project-b, got %d", n)
+	}
+}
+
+func TestNamespace_MemoryIsolation(t *testing.T) {
+	a, b := openNamespacedStores(t)
+	ctx := context.Background()
+
+	memA := NewSQLiteMemoryStore(a.DB()).WithNamespace(a.Namespace())
+	memB := NewSQLiteMemoryStore(b.DB()).WithNamespace(b.Namespace())
+
+	record := &MemoryRecord{Topic: "Decision", Context: "Use SQLite"}
+	if err := memA.AddMemory(ctx, record); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	if n, _ := memB.CountMemories(ctx); n != 0 {
+		t.Errorf("Expected 0 memories in project-b, got %d", n)
+	}
+	list, err := memB.ListMemories(ctx, ListMemoriesOptions{})
+	if err != nil {
+		t.Fatalf("ListMemories failed: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("Expected empty list in project-b, got %d", len(list))
+	}
+	if _, err := memB.GetMemory(ctx, record.ID); err != ErrMemoryNotFound {
+		t.Errorf("Expected ErrMemoryNotFound from project-b, got %v", err)
+	}
+	if err := memB.DeleteMemory(ctx, record.ID); err != ErrMemoryNotFound {
+		t.Errorf("Expected ErrMemoryNotFound deleting from project-b, got %v", err)
+	}
+
+	if n, _ := memA.CountMemories(ctx); n != 1 {
+		t.Errorf("Expected 1 memory in project-a, got %d", n)
+	}
+}
+
+func TestNamespace_VectorSearch(t *testing.T) {
+	a, b := openNamespacedStores(t)
+	ctx := context.Background()
+
+	vecA := NewSQLiteVectorStore(a.DB()).WithNamespace(a.Namespace())
+	vecB := NewSQLiteVectorStore(b.DB()).WithNamespace(b.Namespace())
+
+	// Several close project-a vectors crowd the nearest neighbours of the query,
+	// so project-b's single result is only found by widening k.
+	embedding := func(x float32) []float32 {
+		v := make([]float32, 1536)
+		v[0], v[1] = 1, x
+		return v
+	}
+	for i, id := range []string{"a1", "a2", "a3", "a4", "a5"} {
+		if err := a.AddNode(ctx, &Node{ID: id, Name: id}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+		if err := vecA.Add(ctx, id, embedding(float32(i)*0.01)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if err := b.AddNode(ctx, &Node{ID: "b1", Name: "b1"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if err := vecB.Add(ctx, "b1", embedding(0.5)); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	results, err := vecB.Search(ctx, embedding(0), 1)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "b1" {
+		t.Errorf("Expected only b1 from project-b, got %+v", results)
+	}
+
+	results, err = vecA.Search(ctx, embedding(0), 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 5 {
+		t.Errorf("Expected 5 results from project-a, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.ID == "b1" {
+			t.Errorf("project-b node leaked into project-a search")
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Outbox entities and operations recorded in OutboxEntry.
+const (
+	OutboxEntityNode   = "node"
+	OutboxEntityEdge   = "edge"
+	OutboxEntityMemory = "memory"
+	// Links of a memory to the nodes and edges derived from it, its tags, and
+	// supersessions between memories
+	OutboxEntityMemoryNode   = "memory_node"
+	OutboxEntityMemoryEdge   = "memory_edge"
+	OutboxEntityMemoryTag    = "memory_tag"
+	OutboxEntitySupersession = "supersession"
+
+	// OutboxUpsert records a created or changed row; Payload holds its current fields.
+	OutboxUpsert = "upsert"
+	// OutboxDelete records a removed row; Payload holds only its ID, or for memory links
+	// and tags the linked IDs.
+	OutboxDelete = "delete"
+)
+
+// OutboxEntry is one recorded mutation of a node, edge, memory, memory link, tag or
+// supersession.
+type OutboxEntry struct {
+	Seq       int64           // Monotonic sequence number, the cursor for ReadOutbox
+	Entity    string          // One of the OutboxEntity constants
+	EntityID  string          // ID of the mutated row; for memory links and tags, the memory's ID
+	Op        string          // OutboxUpsert or OutboxDelete
+	Payload   json.RawMessage // JSON object with the row's fields at the time of the mutation, decrypted
+	CreatedAt time.Time
+}
+
+// outboxEncryptedFields are the payload fields FieldCipher encrypts, per entity. The
+// triggers copy them as stored, so ReadOutbox decrypts them.
+var outboxEncryptedFields = map[string][]string{
+	OutboxEntityNode:   {"description"},
+	OutboxEntityMemory: {"context", "decisions_json"},
+}
+
+// outboxTriggers are the trigger names created by EnableOutbox
+var outboxTriggers = []string{
+	"outbox_nodes_insert", "outbox_nodes_update", "outbox_nodes_delete",
+	"outbox_edges_insert", "outbox_edges_update", "outbox_edges_delete",
+	"outbox_memories_insert", "outbox_memories_update", "outbox_memories_delete",
+	"outbox_memory_nodes_insert", "outbox_memory_nodes_delete",
+	"outbox_memory_edges_insert", "outbox_memory_edges_delete",
+	"outbox_memory_tags_insert", "outbox_memory_tags_delete",
+	"outbox_memory_supersession_insert", "outbox_memory_supersession_delete",
+}
+
+// migrateOutboxSchema creates the outbox table. Recording is off until EnableOutbox.
+func (s *SQLiteGraphStore) migrateOutboxSchema() error {
+	if _, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS outbox (
+			seq INTEGER PRIMARY KEY AUTOINCREMENT,
+			namespace TEXT NOT NULL DEFAULT '',
+			entity TEXT NOT NULL,
+			entity_id TEXT NOT NULL,
+			op TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+		);
+		CREATE INDEX IF NOT EXISTS idx_outbox_namespace_seq ON outbox(namespace, seq);
+	`); err != nil {
+		return fmt.Errorf("failed to create outbox table: %w", err)
+	}
+	return nil
+}
+
+// revertOutboxSchema drops the outbox triggers and table.
+func (s *SQLiteGraphStore) revertOutboxSchema() error {
+	if err := s.DisableOutbox(context.Background()); err != nil {
+		return err
+	}
+	return dropTables("outbox")(s)
+}
+
+// EnableOutbox starts recording every mutation of nodes, edges and memories (including
+// their ACLs), of the links between memories and the nodes and edges derived from them,
+// of memory tags and of supersessions, in all namespaces, in the outbox. Recording is
+// done by triggers in the same transaction as the mutation, so it stays on across
+// restarts until DisableOutbox and no committed change is missed. Access bookkeeping
+// (access counts, last access times) is not recorded, nor are links and tags removed
+// along with their memory, which the memory's delete entry covers. Enabling an enabled
+// outbox recreates its triggers, picking up ones added by newer versions.
+func (s *SQLiteGraphStore) EnableOutbox(ctx context.Context) error {
+	nodePayload := `json_object('id', %[1]s.id, 'name', %[1]s.name, 'type', %[1]s.type, 'description', %[1]s.description,
+		'metadata', CAST(%[1]s.metadata AS TEXT), 'pinned', %[1]s.pinned, 'created_at', %[1]s.created_at)`
+	edgePayload := `json_object('id', %[1]s.id, 'source_id', %[1]s.source_id, 'relation', %[1]s.relation,
+		'target_id', %[1]s.target_id, 'weight', %[1]s.weight, 'expires_at', %[1]s.expires_at,
+		'valid_from', %[1]s.valid_from, 'valid_to', %[1]s.valid_to, 'created_at', %[1]s.created_at)`
+	memoryPayload := `json_object('id', %[1]s.id, 'topic', %[1]s.topic, 'context', CAST(%[1]s.context AS TEXT),
+		'decisions_json', CAST(%[1]s.decisions_json AS TEXT), 'rationale_json', CAST(%[1]s.rationale_json AS TEXT),
+		'metadata_json', CAST(%[1]s.metadata_json AS TEXT),
+		'status', %[1]s.status, 'version', %[1]s.version, 'retention_policy', %[1]s.retention_policy,
+		'pinned', %[1]s.pinned, 'acl_owner', %[1]s.acl_owner, 'acl_team', %[1]s.acl_team,
+		'acl_labels', json(%[1]s.acl_labels), 'created_at', %[1]s.created_at, 'updated_at', %[1]s.updated_at)`
+
+	tables := []struct {
+		table, entity, columns, payload string
+	}{
+		{"nodes", OutboxEntityNode, "name, type, description, metadata, pinned", nodePayload},
+		{"edges", OutboxEntityEdge, "source_id, relation, target_id, weight, expires_at, valid_from, valid_to", edgePayload},
+		{"memories", OutboxEntityMemory, "topic, context, decisions_json, rationale_json, metadata_json, status, version, retention_policy, pinned, acl_owner, acl_team, acl_labels", memoryPayload},
+	}
+
+	// Links are only inserted and deleted. They carry no namespace of their own, so it is
+	// their memory's; a link whose memory is gone was removed along with it.
+	links := []struct {
+		table, entity, entityID, memoryID, payload string
+	}{
+		{"memory_nodes", OutboxEntityMemoryNode, "%[1]s.memory_id", "%[1]s.memory_id",
+			`json_object('memory_id', %[1]s.memory_id, 'node_id', %[1]s.node_id)`},
+		{"memory_edges", OutboxEntityMemoryEdge, "%[1]s.memory_id", "%[1]s.memory_id",
+			`json_object('memory_id', %[1]s.memory_id, 'edge_id', %[1]s.edge_id)`},
+		{"memory_tags", OutboxEntityMemoryTag, "%[1]s.memory_id", "%[1]s.memory_id",
+			`json_object('memory_id', %[1]s.memory_id, 'tag', %[1]s.tag)`},
+		{"memory_supersession", OutboxEntitySupersession, "%[1]s.id", "%[1]s.superseded_id",
+			`json_object('id', %[1]s.id, 'superseding_id', %[1]s.superseding_id, 'superseded_id', %[1]s.superseded_id,
+				'reason', %[1]s.reason, 'created_at', %[1]s.created_at)`},
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, trigger := range outboxTriggers {
+		if _, err := tx.ExecContext(ctx, "DROP TRIGGER IF EXISTS "+trigger); err != nil {
+			return fmt.Errorf("failed to drop trigger %s: %w", trigger, err)
+		}
+	}
+
+	for _, t := range tables {
+		upsert := fmt.Sprintf(`INSERT INTO outbox (namespace, entity, entity_id, op, payload)
+			VALUES (new.namespace, '%s', new.id, '%s', %s);`, t.entity, OutboxUpsert, fmt.Sprintf(t.payload, "new"))
+		statements := []string{
+			fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS outbox_%s_insert AFTER INSERT ON %s BEGIN %s END",
+				t.table, t.table, upsert),
+			fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS outbox_%s_update AFTER UPDATE OF %s ON %s BEGIN %s END",
+				t.table, t.columns, t.table, upsert),
+			fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS outbox_%s_delete AFTER DELETE ON %s BEGIN
+				INSERT INTO outbox (namespace, entity, entity_id, op, payload)
+				VALUES (old.namespace, '%s', old.id, '%s', json_object('id', old.id));
+			END`, t.table, t.table, t.entity, OutboxDelete),
+		}
+		for _, statement := range statements {
+			if _, err := tx.ExecContext(ctx, statement); err != nil {
+				return fmt.Errorf("failed to create outbox trigger on %s: %w", t.table, err)
+			}
+		}
+	}
+
+	for _, l := range links {
+		for _, trigger := range []struct{ event, row, op string }{
+			{"INSERT", "new", OutboxUpsert},
+			{"DELETE", "old", OutboxDelete},
+		} {
+			memoryID := fmt.Sprintf(l.memoryID, trigger.row)
+			statement := fmt.Sprintf(`CREATE TRIGGER outbox_%s_%s AFTER %s ON %s
+				WHEN EXISTS (SELECT 1 FROM memories WHERE id = %s)
+			BEGIN
+				INSERT INTO outbox (namespace, entity, entity_id, op, payload)
+				VALUES ((SELECT namespace FROM memories WHERE id = %s), '%s', %s, '%s', %s);
+			END`, l.table, strings.ToLower(trigger.event), trigger.event, l.table, memoryID,
+				memoryID, l.entity, fmt.Sprintf(l.entityID, trigger.row), trigger.op, fmt.Sprintf(l.payload, trigger.row))
+			if _, err := tx.ExecContext(ctx, statement); err != nil {
+				return fmt.Errorf("failed to create outbox trigger on %s: %w", l.table, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit outbox triggers: %w", err)
+	}
+	return nil
+}
+
+// DisableOutbox stops recording mutations in the outbox. Entries already recorded are
+// kept until acknowledged.
+func (s *SQLiteGraphStore) DisableOutbox(ctx context.Context) error {
+	for _, trigger := range outboxTriggers {
+		if _, err := s.db.ExecContext(ctx, "DROP TRIGGER IF EXISTS "+trigger); err != nil {
+			return fmt.Errorf("failed to drop trigger %s: %w", trigger, err)
+		}
+	}
+	return nil
+}
+
+// ReadOutbox returns up to limit entries of this store's namespace with a sequence number
+// above afterSeq, oldest first. limit <= 0 returns all of them. Entries stay in the
+// outbox until acknowledged with AckOutbox, so a consumer that restarts before
+// acknowledging reads them again. Encrypted fields are decrypted with this store's
+// cipher (see WithEncryption).
+func (s *SQLiteGraphStore) ReadOutbox(ctx context.Context, afterSeq int64, limit int) ([]OutboxEntry, error) {
+	query := `
+		SELECT seq, entity, entity_id, op, payload, created_at FROM outbox
+		WHERE namespace = ? AND seq > ? ORDER BY seq`
+	args := []interface{}{s.namespace, afterSeq}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []OutboxEntry
+	for rows.Next() {
+		var entry OutboxEntry
+		var payload string
+		var createdAt sql.NullTime
+		if err := rows.Scan(&entry.Seq, &entry.Entity, &entry.EntityID, &entry.Op, &payload, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox entry: %w", err)
+		}
+		entry.Payload, err = s.decryptOutboxPayload(entry.Entity, payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt outbox entry %d: %w", entry.Seq, err)
+		}
+		entry.CreatedAt = createdAt.Time
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outbox: %w", err)
+	}
+	return entries, nil
+}
+
+// decryptOutboxPayload decrypts the encrypted fields of an entity's payload. Payloads
+// without encrypted values are returned unchanged.
+func (s *SQLiteGraphStore) decryptOutboxPayload(entity, payload string) (json.RawMessage, error) {
+	fields := outboxEncryptedFields[entity]
+	if len(fields) == 0 || !strings.Contains(payload, encryptedFieldPrefix) {
+		return json.RawMessage(payload), nil
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(payload), &values); err != nil {
+		return nil, fmt.Errorf("failed to parse payload: %w", err)
+	}
+	for _, field := range fields {
+		value, ok := values[field].(string)
+		if !ok {
+			continue
+		}
+		plaintext, err := s.cipher.Decrypt(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s: %w", field, err)
+		}
+		values[field] = plaintext
+	}
+	decrypted, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	return decrypted, nil
+}
+
+// AckOutbox acknowledges every entry of this store's namespace up to and including seq,
+// removing them from the outbox. It returns the number of entries removed.
+func (s *SQLiteGraphStore) AckOutbox(ctx context.Context, seq int64) (int64, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM outbox WHERE namespace = ? AND seq <= ?", s.namespace, seq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to acknowledge outbox entries: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,282 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOutbox(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "outbox.db")
+	team, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer team.Close()
+	team.WithNamespace("team")
+	other, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer other.Close()
+	other.WithNamespace("other")
+
+	// Mutations before enabling are not recorded
+	if err := team.AddNode(ctx, &Node{ID: "early", Name: "Early", Type: "Concept"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if err := team.EnableOutbox(ctx); err != nil {
+		t.Fatalf("EnableOutbox failed: %v", err)
+	}
+	if err := team.EnableOutbox(ctx); err != nil {
+		t.Fatalf("Enabling twice should be a no-op: %v", err)
+	}
+
+	for _, node := range []*Node{{ID: "a", Name: "Alice", Type: "Person"}, {ID: "b", Name: "Go", Type: "Technology"}} {
+		if err := team.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	if err := team.AddEdge(ctx, &Edge{ID: "e", SourceID: "a", Relation: "USES", TargetID: "b", Weight: 1}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	if err := other.AddNode(ctx, &Node{ID: "x", Name: "Elsewhere", Type: "Concept"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	// Access bookkeeping is not a mutation
+	if _, err := team.ReinforceNodes(ctx, []string{"a"}, 1); err != nil {
+		t.Fatalf("ReinforceNodes failed: %v", err)
+	}
+	if err := team.DeleteNode(ctx, "early"); err != nil {
+		t.Fatalf("DeleteNode failed: %v", err)
+	}
+
+	entries, err := team.ReadOutbox(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("ReadOutbox failed: %v", err)
+	}
+	want := []struct{ entity, id, op string }{
+		{OutboxEntityNode, "a", OutboxUpsert},
+		{OutboxEntityNode, "b", OutboxUpsert},
+		{OutboxEntityEdge, "e", OutboxUpsert},
+		{OutboxEntityNode, "early", OutboxDelete},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %+v", len(want), entries)
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Entity != w.entity || e.EntityID != w.id || e.Op != w.op {
+			t.Errorf("Entry %d: expected %s %s %s, got %s %s %s", i, w.op, w.entity, w.id, e.Op, e.Entity, e.EntityID)
+		}
+		if i > 0 && e.Seq <= entries[i-1].Seq {
+			t.Errorf("Expected increasing sequence numbers, got %d after %d", e.Seq, entries[i-1].Seq)
+		}
+		if e.CreatedAt.IsZero() {
+			t.Errorf("Entry %d has no creation time", i)
+		}
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(entries[0].Payload, &payload); err != nil || payload["name"] != "Alice" {
+		t.Errorf("Expected a node payload, got %s (%v)", entries[0].Payload, err)
+	}
+
+	// Reading resumes after a sequence number and honours the limit
+	page, err := team.ReadOutbox(ctx, entries[0].Seq, 2)
+	if err != nil || len(page) != 2 || page[0].EntityID != "b" {
+		t.Errorf("Expected the two entries after the first, got %+v (%v)", page, err)
+	}
+
+	// Acknowledged entries are removed; the rest are read again
+	removed, err := team.AckOutbox(ctx, entries[1].Seq)
+	if err != nil || removed != 2 {
+		t.Fatalf("Expected 2 entries acknowledged, got %d (%v)", removed, err)
+	}
+	remaining, err := team.ReadOutbox(ctx, 0, 0)
+	if err != nil || len(remaining) != 2 || remaining[0].EntityID != "e" {
+		t.Errorf("Expected the unacknowledged entries, got %+v (%v)", remaining, err)
+	}
+	if otherEntries, err := other.ReadOutbox(ctx, 0, 0); err != nil || len(otherEntries) != 1 || otherEntries[0].EntityID != "x" {
+		t.Errorf("Expected only the other namespace's entry, got %+v (%v)", otherEntries, err)
+	}
+
+	if err := team.DisableOutbox(ctx); err != nil {
+		t.Fatalf("DisableOutbox failed: %v", err)
+	}
+	if err := team.AddNode(ctx, &Node{ID: "late", Name: "Late", Type: "Concept"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if after, _ := team.ReadOutbox(ctx, 0, 0); len(after) != 2 {
+		t.Errorf("Expected no entries recorded after DisableOutbox, got %+v", after)
+	}
+}
+
+func TestOutbox_Memories(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+	memoryStore := NewSQLiteMemoryStore(graphStore.DB())
+	if err := graphStore.EnableOutbox(ctx); err != nil {
+		t.Fatalf("EnableOutbox failed: %v", err)
+	}
+
+	record := &MemoryRecord{Topic: "Outbox", Context: "Mirror changes", Decisions: []string{"Use triggers"}}
+	if err := memoryStore.AddMemory(ctx, record); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+	if _, err := memoryStore.GetMemory(ctx, record.ID); err != nil {
+		t.Fatalf("GetMemory failed: %v", err)
+	}
+
+	entries, err := graphStore.ReadOutbox(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("ReadOutbox failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Entity != OutboxEntityMemory || entries[0].EntityID != record.ID || entries[0].Op != OutboxUpsert {
+		t.Fatalf("Expected one memory upsert (reads are not recorded), got %+v", entries)
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(entries[0].Payload, &payload); err != nil || payload["topic"] != "Outbox" {
+		t.Errorf("Expected a memory payload, got %s (%v)", entries[0].Payload, err)
+	}
+}
+
+func TestOutbox_MemoryLinks(t *testing.T) {
+	ctx := context.Background()
+	graphStore := setupTestStore(t)
+	defer graphStore.Close()
+	graphStore.WithNamespace("team")
+	memoryStore := NewSQLiteMemoryStore(graphStore.DB()).WithNamespace("team")
+
+	for _, node := range []*Node{{ID: "a", Name: "Alice", Type: "Person"}, {ID: "b", Name: "Go", Type: "Technology"}} {
+		if err := graphStore.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	if err := graphStore.AddEdge(ctx, &Edge{ID: "e", SourceID: "a", Relation: "USES", TargetID: "b"}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	old := &MemoryRecord{Topic: "Language", Context: "We use Go"}
+	current := &MemoryRecord{Topic: "Language", Context: "We use Go 1.25"}
+	for _, record := range []*MemoryRecord{old, current} {
+		if err := memoryStore.AddMemory(ctx, record); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+	}
+	if err := graphStore.EnableOutbox(ctx); err != nil {
+		t.Fatalf("EnableOutbox failed: %v", err)
+	}
+
+	if err := memoryStore.LinkProvenance(ctx, old.ID, []string{"a"}, []string{"e"}); err != nil {
+		t.Fatalf("LinkProvenance failed: %v", err)
+	}
+	if err := memoryStore.AddTags(ctx, old.ID, "backend"); err != nil {
+		t.Fatalf("AddTags failed: %v", err)
+	}
+	if err := memoryStore.RecordSupersession(ctx, current.ID, old.ID, "Upgrade"); err != nil {
+		t.Fatalf("RecordSupersession failed: %v", err)
+	}
+	if err := memoryStore.SetMemoryACL(ctx, current.ID, &MemoryACL{Team: "platform"}); err != nil {
+		t.Fatalf("SetMemoryACL failed: %v", err)
+	}
+	if err := memoryStore.RemoveTags(ctx, old.ID, "backend"); err != nil {
+		t.Fatalf("RemoveTags failed: %v", err)
+	}
+	// The memory's delete entry covers the links removed with it
+	if err := memoryStore.DeleteMemory(ctx, old.ID); err != nil {
+		t.Fatalf("DeleteMemory failed: %v", err)
+	}
+
+	entries, err := graphStore.ReadOutbox(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("ReadOutbox failed: %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		if e.Entity != OutboxEntityMemory || e.Op == OutboxDelete {
+			got = append(got, e.Op+" "+e.Entity)
+		}
+	}
+	want := []string{
+		"upsert memory_node", "upsert memory_edge", "upsert memory_tag", "upsert supersession",
+		"delete memory_tag", "delete memory",
+	}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	var acl map[string]interface{}
+	for _, e := range entries {
+		if e.Entity == OutboxEntityMemory && e.EntityID == current.ID {
+			if err := json.Unmarshal(e.Payload, &acl); err != nil {
+				t.Fatalf("Failed to parse memory payload: %v", err)
+			}
+		}
+		if e.Entity == OutboxEntityMemoryTag && !strings.Contains(string(e.Payload), `"tag":"backend"`) {
+			t.Errorf("Expected the tag in the payload, got %s", e.Payload)
+		}
+	}
+	if acl["acl_team"] != "platform" {
+		t.Errorf("Expected the ACL change recorded, got %v", acl)
+	}
+}
+
+func TestOutbox_DecryptsEncryptedFields(t *testing.T) {
+	ctx := context.Background()
+	graphStore := setupTestStore(t)
+	defer graphStore.Close()
+	c, _ := NewFieldCipher(bytes.Repeat([]byte{7}, 32))
+	graphStore.WithEncryption(c)
+	memoryStore := NewSQLiteMemoryStore(graphStore.DB()).WithEncryption(c)
+	if err := graphStore.EnableOutbox(ctx); err != nil {
+		t.Fatalf("EnableOutbox failed: %v", err)
+	}
+
+	if err := graphStore.AddNode(ctx, &Node{ID: "n1", Name: "SQLite", Type: "Technology", Description: "secret engine"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	record := &MemoryRecord{Topic: "Database", Context: "secret context", Decisions: []string{"secret decision"}}
+	if err := memoryStore.AddMemory(ctx, record); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	var stored string
+	if err := graphStore.DB().QueryRow("SELECT group_concat(payload) FROM outbox").Scan(&stored); err != nil {
+		t.Fatalf("Failed to read stored payloads: %v", err)
+	}
+	if strings.Contains(stored, "secret") {
+		t.Errorf("Expected ciphertext in stored payloads, got %s", stored)
+	}
+
+	entries, err := graphStore.ReadOutbox(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("ReadOutbox failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected a node and a memory entry, got %+v", entries)
+	}
+	var node, memory map[string]interface{}
+	if err := json.Unmarshal(entries[0].Payload, &node); err != nil || node["description"] != "secret engine" {
+		t.Errorf("Expected a decrypted description, got %s (%v)", entries[0].Payload, err)
+	}
+	if err := json.Unmarshal(entries[1].Payload, &memory); err != nil {
+		t.Fatalf("Failed to parse memory payload: %v", err)
+	}
+	if memory["context"] != "secret context" || memory["decisions_json"] != `["secret decision"]` {
+		t.Errorf("Expected decrypted context and decisions, got %s", entries[1].Payload)
+	}
+
+	// Without the key the payload can't be read
+	graphStore.WithEncryption(nil)
+	if _, err := graphStore.ReadOutbox(ctx, 0, 0); !errors.Is(err, ErrDecryption) {
+		t.Errorf("Expected ErrDecryption without a key, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidCursor indicates a malformed MemoryPage cursor.
+var ErrInvalidCursor = errors.New("invalid memory page cursor")
+
+// MemoryPage is one page of memories from ListMemoriesPage.
+type MemoryPage struct {
+	Items      []MemorySummary `json:"items"`
+	Total      int64           `json:"total"`                 // Memories matching the filters, across all pages
+	NextCursor string          `json:"next_cursor,omitempty"` // Empty on the last page
+}
+
+// ListMemoriesPage returns a page of memories matching the filters of opts, most recently
+// updated first, with the total number of matches. Pages are keyed on (updated_at, id)
+// rather than offsets, so deep pages stay fast: pass the NextCursor of a page as cursor
+// to fetch the next one ("" for the first page). opts.Offset, OrderBy and OrderDesc are
+// ignored. Memories updated while paging may move to an earlier page.
+func (s *SQLiteMemoryStore) ListMemoriesPage(ctx context.Context, opts ListMemoriesOptions, cursor string) (*MemoryPage, error) {
+	limit := clampListLimit(opts.Limit)
+
+	where, args, err := s.memoryFilters(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &MemoryPage{}
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM memories WHERE "+where, args...).Scan(&page.Total); err != nil {
+		return nil, fmt.Errorf("failed to count memories: %w", err)
+	}
+
+	query := memorySummaryColumns + " WHERE " + where
+	if cursor != "" {
+		updatedAt, id, err := decodeMemoryCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		query += " AND (updated_at, id) < (?, ?)"
+		args = append(args, updatedAt, id)
+	}
+	// Fetch one extra row to learn whether there is a next page
+	query += " ORDER BY updated_at DESC, id DESC LIMIT ?"
+	args = append(args, limit+1)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memories: %w", err)
+	}
+	defer rows.Close()
+
+	items, err := scanMemorySummaries(rows, s.decayPolicy, s.cipher)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > limit {
+		items = items[:limit]
+		last := items[limit-1]
+		// Key on the stored text of updated_at, so the comparison matches the column exactly
+		var updatedAt string
+		err := s.db.QueryRowContext(ctx, "SELECT CAST(updated_at AS TEXT) FROM memories WHERE id = ?", last.ID).Scan(&updatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build page cursor: %w", err)
+		}
+		page.NextCursor = encodeMemoryCursor(updatedAt, last.ID)
+	}
+	page.Items = items
+	return page, nil
+}
+
+// encodeMemoryCursor encodes the position after a memory as an opaque cursor
+func encodeMemoryCursor(updatedAt, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(updatedAt + "|" + id))
+}
+
+// decodeMemoryCursor reverses encodeMemoryCursor
+func decodeMemoryCursor(cursor string) (string, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", ErrInvalidCursor
+	}
+	updatedAt, id, found := strings.Cut(string(raw), "|")
+	if !found || updatedAt == "" || id == "" {
+		return "", "", ErrInvalidCursor
+	}
+	return updatedAt, id, nil
+}
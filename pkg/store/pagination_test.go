@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestListMemoriesPage(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+
+	// Pairs of memories share an updated_at, so ties are broken by ID
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 7; i++ {
+		mem := &MemoryRecord{
+			ID:        fmt.Sprintf("mem-%d", i),
+			Topic:     fmt.Sprintf("Topic %d", i),
+			Context:   "context",
+			DocHash:   fmt.Sprintf("hash-%d", i),
+			UpdatedAt: base.Add(time.Duration(i/2) * time.Minute),
+		}
+		if err := memStore.AddMemory(ctx, mem); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+	}
+
+	var ids []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("Pagination did not terminate")
+		}
+		page, err := memStore.ListMemoriesPage(ctx, ListMemoriesOptions{Limit: 3}, cursor)
+		if err != nil {
+			t.Fatalf("ListMemoriesPage failed: %v", err)
+		}
+		if page.Total != 7 {
+			t.Errorf("Expected total 7, got %d", page.Total)
+		}
+		for _, item := range page.Items {
+			ids = append(ids, item.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	want := []string{"mem-6", "mem-5", "mem-4", "mem-3", "mem-2", "mem-1", "mem-0"}
+	if fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Errorf("Paged IDs = %v, want %v", ids, want)
+	}
+
+	pinned := true
+	page, err := memStore.ListMemoriesPage(ctx, ListMemoriesOptions{Pinned: &pinned}, "")
+	if err != nil || page.Total != 0 || len(page.Items) != 0 || page.NextCursor != "" {
+		t.Errorf("Expected an empty filtered page, got %+v (err %v)", page, err)
+	}
+
+	if _, err := memStore.ListMemoriesPage(ctx, ListMemoriesOptions{}, "not a cursor!"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+}
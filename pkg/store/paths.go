@@ -0,0 +1,355 @@
+package store
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultMaxPathHops bounds path length when PathOptions.MaxHops is unset.
+const defaultMaxPathHops = 6
+
+// PathOptions configures FindPaths.
+type PathOptions struct {
+	// TraversalOptions restricts the edges paths may use (relations, direction, fan-out).
+	TraversalOptions
+	// MaxPaths is the number of paths to return, shortest first. Default: 1.
+	MaxPaths int `json:"max_paths,omitempty"`
+	// MaxHops is the longest path considered, in edges. Default: 6.
+	MaxHops int `json:"max_hops,omitempty"`
+}
+
+// Path is a chain of nodes connected by edges.
+type Path struct {
+	Nodes []*Node `json:"nodes"` // From the start node to the end node
+	Edges []*Edge `json:"edges"` // Edges[i] connects Nodes[i] and Nodes[i+1]
+	Cost  float64 `json:"cost"`  // Sum of 1/weight over Edges
+}
+
+// FindPaths returns up to opts.MaxPaths loop-free paths from fromID to toID, cheapest
+// first. An edge costs 1/weight, so paths through strong relationships are preferred;
+// ties go to the path with fewer hops. Edges are followed in both directions unless
+// opts restricts them. It returns no paths (and no error) when the nodes are not
+// connected within opts.MaxHops, and ErrNodeNotFound when either node does not exist.
+func (s *SQLiteGraphStore) FindPaths(ctx context.Context, fromID, toID string, opts PathOptions) ([]Path, error) {
+	start := time.Now()
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.MaxPaths <= 0 {
+		opts.MaxPaths = 1
+	}
+	if opts.MaxHops <= 0 {
+		opts.MaxHops = defaultMaxPathHops
+	}
+
+	finder := &pathFinder{
+		store:     s,
+		opts:      opts.TraversalOptions,
+		adjacency: make(map[string][]pathStep),
+		nodes:     make(map[string]*Node),
+	}
+	for _, id := range []string{fromID, toID} {
+		node, err := finder.node(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			return nil, fmt.Errorf("failed to find paths: %s: %w", id, ErrNodeNotFound)
+		}
+	}
+
+	found, err := finder.kShortest(ctx, fromID, toID, opts.MaxPaths, opts.MaxHops)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]Path, 0, len(found))
+	for _, candidate := range found {
+		path := Path{Edges: candidate.edges, Cost: candidate.cost}
+		for _, id := range candidate.nodeIDs {
+			node, err := finder.node(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if node == nil {
+				// Edge to a node deleted since; skip the path rather than return a gap
+				path.Nodes = nil
+				break
+			}
+			path.Nodes = append(path.Nodes, node)
+		}
+		if path.Nodes != nil {
+			paths = append(paths, path)
+		}
+	}
+	s.logQuery(ctx, "find_paths", start, slog.Int("paths", len(paths)))
+	return paths, nil
+}
+
+// pathStep is an edge that can be followed from a node, and where it leads
+type pathStep struct {
+	edge *Edge
+	next string
+}
+
+// candidatePath is a path found by the search, before its nodes are loaded
+type candidatePath struct {
+	nodeIDs []string
+	edges   []*Edge
+	cost    float64
+}
+
+// key identifies a path by its edges, which distinguishes parallel relations
+func (p *candidatePath) key() string {
+	ids := make([]string, len(p.edges))
+	for i, edge := range p.edges {
+		ids[i] = edge.ID
+	}
+	return strings.Join(ids, "\x00")
+}
+
+// pathFinder searches paths for one FindPaths call, caching adjacency and nodes
+type pathFinder struct {
+	store     *SQLiteGraphStore
+	opts      TraversalOptions
+	adjacency map[string][]pathStep
+	nodes     map[string]*Node
+}
+
+// node returns a node by ID, or nil if it does not exist
+func (f *pathFinder) node(ctx context.Context, id string) (*Node, error) {
+	if node, ok := f.nodes[id]; ok {
+		return node, nil
+	}
+	node, err := f.store.GetNode(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	f.nodes[id] = node
+	return node, nil
+}
+
+// steps returns the edges that may be followed from nodeID, heaviest first
+func (f *pathFinder) steps(ctx context.Context, nodeID string) ([]pathStep, error) {
+	if steps, ok := f.adjacency[nodeID]; ok {
+		return steps, nil
+	}
+	edges, err := f.store.GetEdges(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].Weight != edges[j].Weight {
+			return edges[i].Weight > edges[j].Weight
+		}
+		return edges[i].ID < edges[j].ID
+	})
+
+	var steps []pathStep
+	for _, edge := range edges {
+		if f.opts.MaxFanOut > 0 && len(steps) >= f.opts.MaxFanOut {
+			break
+		}
+		next, ok := f.opts.Follow(edge, nodeID)
+		if !ok || next == nodeID {
+			continue
+		}
+		steps = append(steps, pathStep{edge: edge, next: next})
+	}
+	f.adjacency[nodeID] = steps
+	return steps, nil
+}
+
+// edgeCost is the length of an edge for path finding: strong edges are short
+func edgeCost(edge *Edge) float64 {
+	if edge.Weight <= 0 {
+		return 1
+	}
+	return 1 / edge.Weight
+}
+
+// kShortest finds up to k loop-free paths with Yen's algorithm: each next path branches
+// off a previous one at some node, avoiding the edges already taken from there.
+func (f *pathFinder) kShortest(ctx context.Context, fromID, toID string, k, maxHops int) ([]*candidatePath, error) {
+	first, err := f.shortest(ctx, fromID, toID, maxHops, nil, nil)
+	if err != nil || first == nil {
+		return nil, err
+	}
+
+	paths := []*candidatePath{first}
+	seen := map[string]bool{first.key(): true}
+	var candidates []*candidatePath
+	for len(paths) < k {
+		last := paths[len(paths)-1]
+		for i := range last.edges {
+			rootEdges := last.edges[:i]
+			bannedEdges := make(map[string]bool)
+			for _, path := range paths {
+				if len(path.edges) > i && sameEdges(path.edges[:i], rootEdges) {
+					bannedEdges[path.edges[i].ID] = true
+				}
+			}
+			bannedNodes := make(map[string]bool, i)
+			for _, id := range last.nodeIDs[:i] {
+				bannedNodes[id] = true
+			}
+
+			spur, err := f.shortest(ctx, last.nodeIDs[i], toID, maxHops-i, bannedNodes, bannedEdges)
+			if err != nil {
+				return nil, err
+			}
+			if spur == nil {
+				continue
+			}
+
+			candidate := &candidatePath{
+				nodeIDs: append(append([]string{}, last.nodeIDs[:i]...), spur.nodeIDs...),
+				edges:   append(append([]*Edge{}, rootEdges...), spur.edges...),
+				cost:    spur.cost,
+			}
+			for _, edge := range rootEdges {
+				candidate.cost += edgeCost(edge)
+			}
+			if key := candidate.key(); !seen[key] {
+				seen[key] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return lessPath(candidates[i], candidates[j])
+		})
+		paths = append(paths, candidates[0])
+		candidates = candidates[1:]
+	}
+	return paths, nil
+}
+
+// shortest runs Dijkstra from fromID to toID over paths of at most maxHops edges that
+// avoid the banned nodes and edges. It returns nil if there is no such path.
+func (f *pathFinder) shortest(ctx context.Context, fromID, toID string, maxHops int, bannedNodes, bannedEdges map[string]bool) (*candidatePath, error) {
+	if fromID == toID {
+		return &candidatePath{nodeIDs: []string{fromID}}, nil
+	}
+
+	// States are (node, hops): a node first reached by a cheap path that used up the
+	// hop limit may still be on a costlier path that fits within it
+	type state struct {
+		nodeID string
+		hops   int
+	}
+	type link struct {
+		prev state
+		edge *Edge
+	}
+	dist := map[state]float64{{fromID, 0}: 0}
+	prev := make(map[state]link)
+	queue := &pathQueue{{nodeID: fromID}}
+
+	for queue.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		item := heap.Pop(queue).(pathQueueItem)
+		current := state{item.nodeID, item.hops}
+		if item.cost > dist[current] {
+			continue // Stale entry
+		}
+
+		if current.nodeID == toID {
+			path := &candidatePath{cost: item.cost}
+			for at := current; at.hops > 0; at = prev[at].prev {
+				path.nodeIDs = append(path.nodeIDs, at.nodeID)
+				path.edges = append(path.edges, prev[at].edge)
+			}
+			path.nodeIDs = append(path.nodeIDs, fromID)
+			slices.Reverse(path.nodeIDs)
+			slices.Reverse(path.edges)
+			return path, nil
+		}
+		if current.hops >= maxHops {
+			continue
+		}
+
+		steps, err := f.steps(ctx, current.nodeID)
+		if err != nil {
+			return nil, err
+		}
+		for _, step := range steps {
+			if bannedNodes[step.next] || bannedEdges[step.edge.ID] || step.next == fromID {
+				continue
+			}
+			next := state{step.next, current.hops + 1}
+			cost := item.cost + edgeCost(step.edge)
+			if known, ok := dist[next]; ok && known <= cost {
+				continue
+			}
+			dist[next] = cost
+			prev[next] = link{prev: current, edge: step.edge}
+			heap.Push(queue, pathQueueItem{nodeID: next.nodeID, hops: next.hops, cost: cost})
+		}
+	}
+	return nil, nil
+}
+
+// lessPath orders paths by cost, then hops, then edge IDs, so results are stable
+func lessPath(a, b *candidatePath) bool {
+	if a.cost != b.cost {
+		return a.cost < b.cost
+	}
+	if len(a.edges) != len(b.edges) {
+		return len(a.edges) < len(b.edges)
+	}
+	return a.key() < b.key()
+}
+
+// sameEdges reports whether two edge sequences have the same IDs
+func sameEdges(a, b []*Edge) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID {
+			return false
+		}
+	}
+	return true
+}
+
+// pathQueueItem is a Dijkstra frontier entry
+type pathQueueItem struct {
+	nodeID string
+	hops   int
+	cost   float64
+}
+
+// pathQueue is a min-heap of frontier entries by cost, then hops
+type pathQueue []pathQueueItem
+
+func (q pathQueue) Len() int { return len(q) }
+func (q pathQueue) Less(i, j int) bool {
+	if q[i].cost != q[j].cost {
+		return q[i].cost < q[j].cost
+	}
+	if q[i].hops != q[j].hops {
+		return q[i].hops < q[j].hops
+	}
+	return q[i].nodeID < q[j].nodeID
+}
+func (q pathQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x interface{}) { *q = append(*q, x.(pathQueueItem)) }
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
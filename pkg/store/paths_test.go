@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+// setupPathGraph builds two routes from decision to component:
+//
+//	decision -CHOSE(1.0)-> go -USED_BY(1.0)-> component          cost 2
+//	decision -MENTIONS(0.25)-> component                          cost 4
+//	decision -AFFECTS(0.5)-> service -CALLS(0.5)-> component       cost 4
+func setupPathGraph(t *testing.T) *SQLiteGraphStore {
+	t.Helper()
+	store := setupTestStore(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"decision", "go", "service", "component", "island"} {
+		if err := store.AddNode(ctx, &Node{ID: id, Name: id, Type: "Concept"}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	edges := []*Edge{
+		{ID: "e1", SourceID: "decision", Relation: "CHOSE", TargetID: "go", Weight: 1.0},
+		{ID: "e2", SourceID: "go", Relation: "USED_BY", TargetID: "component", Weight: 1.0},
+		{ID: "e3", SourceID: "decision", Relation: "MENTIONS", TargetID: "component", Weight: 0.25},
+		{ID: "e4", SourceID: "decision", Relation: "AFFECTS", TargetID: "service", Weight: 0.5},
+		{ID: "e5", SourceID: "service", Relation: "CALLS", TargetID: "component", Weight: 0.5},
+	}
+	for _, edge := range edges {
+		if err := store.AddEdge(ctx, edge); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+	return store
+}
+
+func pathEdgeIDs(path Path) []string {
+	ids := make([]string, len(path.Edges))
+	for i, edge := range path.Edges {
+		ids[i] = edge.ID
+	}
+	return ids
+}
+
+func TestFindPaths_Shortest(t *testing.T) {
+	store := setupPathGraph(t)
+	defer store.Close()
+
+	paths, err := store.FindPaths(context.Background(), "decision", "component", PathOptions{})
+	if err != nil {
+		t.Fatalf("FindPaths failed: %v", err)
+	}
+	if len(paths) != 1 {
+		t.Fatalf("Expected 1 path, got %d", len(paths))
+	}
+
+	path := paths[0]
+	if got := pathEdgeIDs(path); len(got) != 2 || got[0] != "e1" || got[1] != "e2" {
+		t.Errorf("Expected edges [e1 e2], got %v", got)
+	}
+	if len(path.Nodes) != 3 || path.Nodes[0].ID != "decision" || path.Nodes[1].ID != "go" || path.Nodes[2].ID != "component" {
+		t.Errorf("Expected nodes decision, go, component, got %+v", path.Nodes)
+	}
+	if math.Abs(path.Cost-2) > 1e-9 {
+		t.Errorf("Expected cost 2, got %f", path.Cost)
+	}
+}
+
+func TestFindPaths_KShortest(t *testing.T) {
+	store := setupPathGraph(t)
+	defer store.Close()
+
+	paths, err := store.FindPaths(context.Background(), "decision", "component", PathOptions{MaxPaths: 5})
+	if err != nil {
+		t.Fatalf("FindPaths failed: %v", err)
+	}
+
+	// Ties in cost go to fewer hops
+	want := [][]string{{"e1", "e2"}, {"e3"}, {"e4", "e5"}}
+	if len(paths) != len(want) {
+		t.Fatalf("Expected %d paths, got %d", len(want), len(paths))
+	}
+	for i, w := range want {
+		got := pathEdgeIDs(paths[i])
+		if len(got) != len(w) {
+			t.Errorf("path %d: expected %v, got %v", i, w, got)
+			continue
+		}
+		for j := range w {
+			if got[j] != w[j] {
+				t.Errorf("path %d: expected %v, got %v", i, w, got)
+				break
+			}
+		}
+	}
+}
+
+func TestFindPaths_Options(t *testing.T) {
+	store := setupPathGraph(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	// One hop only leaves the direct, weak edge
+	paths, err := store.FindPaths(ctx, "decision", "component", PathOptions{MaxHops: 1})
+	if err != nil {
+		t.Fatalf("FindPaths failed: %v", err)
+	}
+	if len(paths) != 1 || pathEdgeIDs(paths[0])[0] != "e3" {
+		t.Errorf("Expected only e3 within one hop, got %+v", paths)
+	}
+
+	// Edges point away from decision, so nothing is reachable going incoming
+	paths, err = store.FindPaths(ctx, "decision", "component", PathOptions{
+		TraversalOptions: TraversalOptions{Direction: DirectionIncoming},
+	})
+	if err != nil {
+		t.Fatalf("FindPaths failed: %v", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("Expected no incoming paths, got %d", len(paths))
+	}
+
+	// Relation filter
+	paths, err = store.FindPaths(ctx, "decision", "component", PathOptions{
+		TraversalOptions: TraversalOptions{Relations: []string{"AFFECTS", "CALLS"}},
+	})
+	if err != nil {
+		t.Fatalf("FindPaths failed: %v", err)
+	}
+	if len(paths) != 1 || len(paths[0].Edges) != 2 || paths[0].Edges[0].ID != "e4" {
+		t.Errorf("Expected the AFFECTS/CALLS path, got %+v", paths)
+	}
+}
+
+func TestFindPaths_Unconnected(t *testing.T) {
+	store := setupPathGraph(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	paths, err := store.FindPaths(ctx, "decision", "island", PathOptions{})
+	if err != nil {
+		t.Fatalf("FindPaths failed: %v", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("Expected no paths, got %d", len(paths))
+	}
+
+	if _, err := store.FindPaths(ctx, "decision", "missing", PathOptions{}); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("Expected ErrNodeNotFound, got %v", err)
+	}
+}
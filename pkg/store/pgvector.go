@@ -0,0 +1,245 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Index types for PgVectorConfig.Index
+const (
+	// PgVectorIndexHNSW builds an HNSW graph index: better recall and query speed,
+	// slower to build. The default.
+	PgVectorIndexHNSW = "hnsw"
+	// PgVectorIndexIVFFlat builds an IVFFlat index: faster to build, but it should be
+	// created once the table holds representative data.
+	PgVectorIndexIVFFlat = "ivfflat"
+	// PgVectorIndexNone skips the index; searches scan the table exactly.
+	PgVectorIndexNone = "none"
+)
+
+// DefaultPgVectorTable is the table used when PgVectorConfig.Table is empty.
+const DefaultPgVectorTable = "gognee_node_vectors"
+
+// pgIdentifier limits table names to plain unquoted Postgres identifiers, since they
+// are interpolated into the schema statements.
+var pgIdentifier = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// PgVectorConfig configures a PgVectorStore.
+type PgVectorConfig struct {
+	// Table holds the embeddings (default: DefaultPgVectorTable). It is created if missing.
+	Table string
+
+	// Dimensions is the embedding size, e.g. 1536 for text-embedding-3-small (required).
+	// Opening an existing table with a different size fails.
+	Dimensions int
+
+	// Index is the approximate nearest neighbor index on the embeddings:
+	// PgVectorIndexHNSW (default), PgVectorIndexIVFFlat or PgVectorIndexNone.
+	Index string
+
+	// M and EfConstruction tune the HNSW index (defaults: 16 and 64, pgvector's own).
+	M              int
+	EfConstruction int
+
+	// Lists is the number of IVFFlat lists (default: 100).
+	Lists int
+}
+
+// PgVectorStore implements VectorStore on PostgreSQL with the pgvector extension.
+//
+// Implementation notes:
+//   - The store works on a *sql.DB opened by the caller with any Postgres driver (e.g. pgx's
+//     stdlib or lib/pq), so the connection pool can be shared with other stores; the store
+//     never closes it
+//   - Embeddings live in one table keyed by (namespace, node_id), independent of where the
+//     graph is stored; search skips vectors whose node no longer exists
+//   - Similarity is cosine: the index uses vector_cosine_ops and scores are 1 - cosine distance
+type PgVectorStore struct {
+	db        *sql.DB
+	cfg       PgVectorConfig
+	namespace string // Restricts search results to vectors in this namespace (see WithNamespace)
+}
+
+// NewPgVectorStore creates a pgvector-backed vector store on db, creating the vector
+// extension, the table and its index if they don't exist.
+func NewPgVectorStore(db *sql.DB, cfg PgVectorConfig) (*PgVectorStore, error) {
+	if cfg.Table == "" {
+		cfg.Table = DefaultPgVectorTable
+	}
+	if cfg.Index == "" {
+		cfg.Index = PgVectorIndexHNSW
+	}
+	if cfg.M == 0 {
+		cfg.M = 16
+	}
+	if cfg.EfConstruction == 0 {
+		cfg.EfConstruction = 64
+	}
+	if cfg.Lists == 0 {
+		cfg.Lists = 100
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	s := &PgVectorStore{db: db, cfg: cfg}
+	if err := s.initSchema(); err != nil {
+		return nil, fmt.Errorf("failed to initialize pgvector schema: %w", err)
+	}
+	return s, nil
+}
+
+// WithNamespace scopes the store to one namespace, so several namespaces can share the
+// table. It returns the store for chaining.
+func (s *PgVectorStore) WithNamespace(namespace string) *PgVectorStore {
+	s.namespace = namespace
+	return s
+}
+
+func (c PgVectorConfig) validate() error {
+	if !pgIdentifier.MatchString(c.Table) {
+		return fmt.Errorf("invalid pgvector table name %q", c.Table)
+	}
+	// pgvector indexes support up to 2000 dimensions
+	if c.Dimensions <= 0 || c.Dimensions > 2000 {
+		return fmt.Errorf("pgvector Dimensions must be between 1 and 2000, got %d", c.Dimensions)
+	}
+	switch c.Index {
+	case PgVectorIndexHNSW, PgVectorIndexIVFFlat, PgVectorIndexNone:
+	default:
+		return fmt.Errorf("pgvector Index must be %q, %q or %q, got %q",
+			PgVectorIndexHNSW, PgVectorIndexIVFFlat, PgVectorIndexNone, c.Index)
+	}
+	if c.M < 2 || c.EfConstruction < 2*c.M {
+		return fmt.Errorf("pgvector HNSW needs M >= 2 and EfConstruction >= 2*M, got %d and %d", c.M, c.EfConstruction)
+	}
+	if c.Lists < 1 {
+		return fmt.Errorf("pgvector Lists must be positive, got %d", c.Lists)
+	}
+	return nil
+}
+
+// schemaStatements returns the statements creating the extension, table and index.
+func (c PgVectorConfig) schemaStatements() []string {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS vector`,
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			namespace TEXT NOT NULL DEFAULT '',
+			node_id TEXT NOT NULL,
+			embedding vector(%d) NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+			PRIMARY KEY (namespace, node_id)
+		)`, c.Table, c.Dimensions),
+	}
+	switch c.Index {
+	case PgVectorIndexHNSW:
+		statements = append(statements, fmt.Sprintf(
+			`CREATE INDEX IF NOT EXISTS %s_embedding_idx ON %s USING hnsw (embedding vector_cosine_ops) WITH (m = %d, ef_construction = %d)`,
+			c.Table, c.Table, c.M, c.EfConstruction))
+	case PgVectorIndexIVFFlat:
+		statements = append(statements, fmt.Sprintf(
+			`CREATE INDEX IF NOT EXISTS %s_embedding_idx ON %s USING ivfflat (embedding vector_cosine_ops) WITH (lists = %d)`,
+			c.Table, c.Table, c.Lists))
+	}
+	return statements
+}
+
+func (s *PgVectorStore) initSchema() error {
+	for _, statement := range s.cfg.schemaStatements() {
+		if _, err := s.db.Exec(statement); err != nil {
+			return err
+		}
+	}
+
+	// An existing table keeps its size; refuse to mix embedding models
+	var dimensions int
+	err := s.db.QueryRow(`
+		SELECT atttypmod FROM pg_attribute
+		WHERE attrelid = $1::regclass AND attname = 'embedding'
+	`, s.cfg.Table).Scan(&dimensions)
+	if err != nil {
+		return fmt.Errorf("failed to read embedding dimensions: %w", err)
+	}
+	if dimensions != s.cfg.Dimensions {
+		return fmt.Errorf("table %s holds %d-dimensional embeddings, configured for %d", s.cfg.Table, dimensions, s.cfg.Dimensions)
+	}
+	return nil
+}
+
+// Add adds or updates the embedding for the given node ID.
+func (s *PgVectorStore) Add(ctx context.Context, id string, embedding []float32) error {
+	if len(embedding) != s.cfg.Dimensions {
+		return fmt.Errorf("embedding has %d dimensions, expected %d", len(embedding), s.cfg.Dimensions)
+	}
+
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO %s (namespace, node_id, embedding) VALUES ($1, $2, $3::vector)
+		ON CONFLICT (namespace, node_id) DO UPDATE SET embedding = EXCLUDED.embedding, updated_at = now()
+	`, s.cfg.Table), s.namespace, id, pgVectorLiteral(embedding))
+	if err != nil {
+		return fmt.Errorf("failed to upsert vector: %w", err)
+	}
+	return nil
+}
+
+// Search finds the topK vectors in the store's namespace most similar to the query.
+func (s *PgVectorStore) Search(ctx context.Context, query []float32, topK int) ([]SearchResult, error) {
+	if len(query) != s.cfg.Dimensions {
+		return nil, fmt.Errorf("query has %d dimensions, expected %d", len(query), s.cfg.Dimensions)
+	}
+	if topK <= 0 {
+		return []SearchResult{}, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT node_id, 1 - (embedding <=> $1::vector) AS score
+		FROM %s
+		WHERE namespace = $2
+		ORDER BY embedding <=> $1::vector
+		LIMIT $3
+	`, s.cfg.Table), pgVectorLiteral(query), s.namespace, topK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute vector search: %w", err)
+	}
+	defer rows.Close()
+
+	results := []SearchResult{}
+	for rows.Next() {
+		var result SearchResult
+		if err := rows.Scan(&result.ID, &result.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search results: %w", err)
+	}
+	return results, nil
+}
+
+// Delete removes the embedding for the given node ID. Deleting a missing vector is not an error.
+func (s *PgVectorStore) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE namespace = $1 AND node_id = $2`, s.cfg.Table), s.namespace, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete vector: %w", err)
+	}
+	return nil
+}
+
+// pgVectorLiteral formats an embedding in pgvector's text format, e.g. "[0.1,0.2]".
+func pgVectorLiteral(embedding []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, v := range embedding {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+// PgVectorStore needs a PostgreSQL server with pgvector, so these tests cover the
+// configuration and SQL generation only.
+
+func TestPgVectorLiteral(t *testing.T) {
+	if got := pgVectorLiteral([]float32{0.1, -2, 3.5e-7}); got != "[0.1,-2,3.5e-07]" {
+		t.Errorf("unexpected literal %s", got)
+	}
+	if got := pgVectorLiteral(nil); got != "[]" {
+		t.Errorf("unexpected literal %s", got)
+	}
+}
+
+func TestNewPgVectorStore_ValidatesConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  PgVectorConfig
+	}{
+		{"missing dimensions", PgVectorConfig{}},
+		{"too many dimensions", PgVectorConfig{Dimensions: 3072}},
+		{"quoted table", PgVectorConfig{Dimensions: 3, Table: `vectors"; DROP TABLE nodes; --`}},
+		{"unknown index", PgVectorConfig{Dimensions: 3, Index: "flat"}},
+		{"small ef_construction", PgVectorConfig{Dimensions: 3, M: 32, EfConstruction: 40}},
+		{"negative lists", PgVectorConfig{Dimensions: 3, Index: PgVectorIndexIVFFlat, Lists: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Validation fails before the database is touched
+			if _, err := NewPgVectorStore(nil, tt.cfg); err == nil {
+				t.Error("expected a config error")
+			}
+		})
+	}
+}
+
+func TestPgVectorConfig_SchemaStatements(t *testing.T) {
+	hnsw := PgVectorConfig{Table: "vectors", Dimensions: 768, Index: PgVectorIndexHNSW, M: 24, EfConstruction: 100}
+	statements := strings.Join(hnsw.schemaStatements(), "\n")
+	for _, want := range []string{"CREATE EXTENSION IF NOT EXISTS vector", "embedding vector(768)", "USING hnsw (embedding vector_cosine_ops) WITH (m = 24, ef_construction = 100)"} {
+		if !strings.Contains(statements, want) {
+			t.Errorf("expected %q in:\n%s", want, statements)
+		}
+	}
+
+	ivf := PgVectorConfig{Table: "vectors", Dimensions: 768, Index: PgVectorIndexIVFFlat, Lists: 50}
+	if statements := strings.Join(ivf.schemaStatements(), "\n"); !strings.Contains(statements, "USING ivfflat (embedding vector_cosine_ops) WITH (lists = 50)") {
+		t.Errorf("expected an IVFFlat index in:\n%s", statements)
+	}
+
+	none := PgVectorConfig{Table: "vectors", Dimensions: 768, Index: PgVectorIndexNone}
+	if statements := none.schemaStatements(); len(statements) != 2 {
+		t.Errorf("expected no index statement, got %v", statements)
+	}
+}
@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// PinnedMemory is a pinned memory with the time and reason it was pinned.
+type PinnedMemory struct {
+	MemorySummary
+	PinnedAt     time.Time `json:"pinned_at"`
+	PinnedReason string    `json:"pinned_reason,omitempty"`
+}
+
+// PinMemory pins a memory: pinned memories do not decay and are never pruned, whatever
+// their retention policy. Pinning a pinned memory replaces its reason and keeps the time
+// it was first pinned. Soft-deleted memories cannot be pinned.
+func (s *SQLiteMemoryStore) PinMemory(ctx context.Context, id, reason string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE memories
+		SET pinned = TRUE, pinned_at = CASE WHEN pinned THEN pinned_at ELSE ? END, pinned_reason = ?
+		WHERE id = ? AND namespace = ? AND status != ?
+	`, time.Now(), reason, id, s.namespace, StatusDeleted)
+	if err != nil {
+		return fmt.Errorf("failed to pin memory: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		if err := s.requireMemory(ctx, id); err != nil {
+			return err
+		}
+		return Invalidf("cannot pin memory %s: it is deleted", id)
+	}
+	return nil
+}
+
+// UnpinMemory unpins a memory, clearing its pin time and reason. Unpinning a memory
+// that is not pinned is a no-op.
+func (s *SQLiteMemoryStore) UnpinMemory(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE memories
+		SET pinned = FALSE, pinned_at = NULL, pinned_reason = NULL
+		WHERE id = ? AND namespace = ? AND pinned
+	`, id, s.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to unpin memory: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	} else if rows == 0 {
+		return s.requireMemory(ctx, id)
+	}
+	return nil
+}
+
+// ListPinned returns the pinned memories (excluding soft-deleted ones) the context's
+// principal can read, most recently pinned first. Listing does not count as an access.
+func (s *SQLiteMemoryStore) ListPinned(ctx context.Context) ([]PinnedMemory, error) {
+	clause, aclArgs := aclClause(ctx, "")
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, pinned_at, pinned_reason FROM memories
+		WHERE namespace = ? AND pinned AND status != ?`+clause+`
+		ORDER BY pinned_at DESC, id
+	`, append([]interface{}{s.namespace, StatusDeleted}, aclArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pinned memories: %w", err)
+	}
+	defer rows.Close()
+
+	var pinned []PinnedMemory
+	var ids []string
+	for rows.Next() {
+		var memory PinnedMemory
+		var pinnedAt sql.NullTime
+		var reason sql.NullString
+		if err := rows.Scan(&memory.ID, &pinnedAt, &reason); err != nil {
+			return nil, fmt.Errorf("failed to scan pinned memory: %w", err)
+		}
+		memory.PinnedAt = pinnedAt.Time
+		memory.PinnedReason = reason.String
+		pinned = append(pinned, memory)
+		ids = append(ids, memory.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pinned memories: %w", err)
+	}
+	if len(pinned) == 0 {
+		return pinned, nil
+	}
+
+	summaries, err := s.GetMemorySummariesBatched(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pinned {
+		pinned[i].MemorySummary = summaries[pinned[i].ID]
+	}
+	return pinned, nil
+}
+
+// PinnedNodeIDs returns the IDs of the nodes derived from pinned memories (excluding
+// soft-deleted ones).
+func (s *SQLiteMemoryStore) PinnedNodeIDs(ctx context.Context) (map[string]bool, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT mn.node_id
+		FROM memory_nodes mn
+		JOIN memories m ON mn.memory_id = m.id
+		WHERE m.namespace = ? AND m.pinned AND m.status != ?
+	`, s.namespace, StatusDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pinned nodes: %w", err)
+	}
+	defer rows.Close()
+
+	nodeIDs := make(map[string]bool)
+	for rows.Next() {
+		var nodeID string
+		if err := rows.Scan(&nodeID); err != nil {
+			return nil, fmt.Errorf("failed to scan node ID: %w", err)
+		}
+		nodeIDs[nodeID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pinned nodes: %w", err)
+	}
+	return nodeIDs, nil
+}
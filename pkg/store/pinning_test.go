@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPinMemory(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+
+	kept := &MemoryRecord{Topic: "Mission", Context: "What we build.", DocHash: "h1"}
+	other := &MemoryRecord{Topic: "Lunch", Context: "Pizza on Fridays.", DocHash: "h2"}
+	for _, mem := range []*MemoryRecord{kept, other} {
+		if err := memStore.AddMemory(ctx, mem); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+	}
+	if err := memStore.LinkProvenance(ctx, kept.ID, []string{"n1"}, nil); err != nil {
+		t.Fatalf("LinkProvenance failed: %v", err)
+	}
+
+	if err := memStore.PinMemory(ctx, kept.ID, "core knowledge"); err != nil {
+		t.Fatalf("PinMemory failed: %v", err)
+	}
+	first, _ := memStore.GetMemory(ctx, kept.ID)
+	if !first.Pinned || first.PinnedAt == nil || first.PinnedReason == nil || *first.PinnedReason != "core knowledge" {
+		t.Fatalf("Expected memory pinned with its reason, got %+v", first)
+	}
+
+	// Pinning again replaces the reason and keeps the pin time
+	if err := memStore.PinMemory(ctx, kept.ID, "mission statement"); err != nil {
+		t.Fatalf("PinMemory failed: %v", err)
+	}
+	pinned, err := memStore.ListPinned(ctx)
+	if err != nil {
+		t.Fatalf("ListPinned failed: %v", err)
+	}
+	if len(pinned) != 1 || pinned[0].ID != kept.ID || pinned[0].Topic != "Mission" || pinned[0].PinnedReason != "mission statement" {
+		t.Fatalf("Expected the pinned memory listed with its new reason, got %+v", pinned)
+	}
+	if !pinned[0].PinnedAt.Equal(*first.PinnedAt) {
+		t.Errorf("Expected pin time %v kept, got %v", *first.PinnedAt, pinned[0].PinnedAt)
+	}
+
+	nodeIDs, err := memStore.PinnedNodeIDs(ctx)
+	if err != nil || len(nodeIDs) != 1 || !nodeIDs["n1"] {
+		t.Errorf("Expected node n1 of the pinned memory, got %v (err %v)", nodeIDs, err)
+	}
+
+	if err := memStore.UnpinMemory(ctx, kept.ID); err != nil {
+		t.Fatalf("UnpinMemory failed: %v", err)
+	}
+	if err := memStore.UnpinMemory(ctx, kept.ID); err != nil {
+		t.Errorf("Expected unpinning twice to be a no-op, got %v", err)
+	}
+	unpinned, _ := memStore.GetMemory(ctx, kept.ID)
+	if unpinned.Pinned || unpinned.PinnedAt != nil || unpinned.PinnedReason != nil {
+		t.Errorf("Expected pin fields cleared, got %+v", unpinned)
+	}
+	if pinned, _ := memStore.ListPinned(ctx); len(pinned) != 0 {
+		t.Errorf("Expected no pinned memories, got %+v", pinned)
+	}
+
+	if err := memStore.PinMemory(ctx, "missing", ""); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected ErrMemoryNotFound pinning a missing memory, got %v", err)
+	}
+	if err := memStore.SoftDeleteMemory(ctx, other.ID); err != nil {
+		t.Fatalf("SoftDeleteMemory failed: %v", err)
+	}
+	if err := memStore.PinMemory(ctx, other.ID, ""); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected a validation error pinning a deleted memory, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NodeAgeFilter selects nodes by the time their age is counted from: their creation or,
+// with FromLastAccess, their last access (creation for nodes never accessed). Only
+// unpinned nodes are selected.
+type NodeAgeFilter struct {
+	FromLastAccess bool
+
+	// Before selects nodes whose age is counted from at or before it. Zero selects none.
+	Before time.Time
+
+	// TypeBefore replaces Before for nodes of the listed types; a zero time selects none
+	// of them.
+	TypeBefore map[string]time.Time
+}
+
+// migrateNodeAgeIndex indexes the time access-based decay counts a node's age from, so
+// AgedNodeIDs finds old nodes without a scan; created_at is already indexed by namespace.
+func (s *SQLiteGraphStore) migrateNodeAgeIndex() error {
+	if _, err := s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_nodes_namespace_accessed
+		ON nodes(namespace, COALESCE(last_accessed_at, created_at))`); err != nil {
+		return fmt.Errorf("failed to create node access index: %w", err)
+	}
+	return nil
+}
+
+// where returns the predicate selecting the nodes of f, or "" when it selects none
+func (f NodeAgeFilter) where() (string, []interface{}) {
+	anchor := "created_at"
+	if f.FromLastAccess {
+		anchor = "COALESCE(last_accessed_at, created_at)"
+	}
+
+	types := make([]string, 0, len(f.TypeBefore))
+	for nodeType := range f.TypeBefore {
+		types = append(types, nodeType)
+	}
+	sort.Strings(types)
+
+	var clauses []string
+	var args []interface{}
+	for _, nodeType := range types {
+		if before := f.TypeBefore[nodeType]; !before.IsZero() {
+			clauses = append(clauses, "(COALESCE(type, '') = ? AND "+anchor+" <= ?)")
+			args = append(args, nodeType, before)
+		}
+	}
+	if !f.Before.IsZero() {
+		if len(types) == 0 {
+			clauses = append(clauses, anchor+" <= ?")
+		} else {
+			clauses = append(clauses, "(COALESCE(type, '') NOT IN ("+sqlPlaceholders(len(types))+") AND "+anchor+" <= ?)")
+			for _, nodeType := range types {
+				args = append(args, nodeType)
+			}
+		}
+		args = append(args, f.Before)
+	}
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// AgedNodeIDs returns the IDs of the namespace's unpinned nodes selected by filter,
+// ordered by id. The age comparison runs in SQL on indexed columns, so no node is read.
+func (s *SQLiteGraphStore) AgedNodeIDs(ctx context.Context, filter NodeAgeFilter) ([]string, error) {
+	start := time.Now()
+	where, args := filter.where()
+	if where == "" {
+		return nil, nil
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT id FROM nodes WHERE namespace = ? AND NOT pinned AND `+where+` ORDER BY id`,
+		append([]interface{}{s.namespace}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query aged nodes: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan node id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating aged nodes: %w", err)
+	}
+	s.logQuery(ctx, "aged_node_ids", start, slog.Int("nodes", len(ids)))
+	return ids, nil
+}
+
+// DeleteNodes deletes the namespace's nodes with the given IDs, with their edges and
+// description history, in one transaction. Unknown IDs are ignored. Returns the number
+// of nodes deleted and the IDs of the edges deleted with them. Like DeleteNode, it
+// leaves the nodes' and edges' vectors in other stores to the caller.
+func (s *SQLiteGraphStore) DeleteNodes(ctx context.Context, ids []string) (nodesDeleted int, edgeIDs []string, err error) {
+	start := time.Now()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > nodeLookupBatchSize {
+			batch = batch[:nodeLookupBatchSize]
+		}
+		ids = ids[len(batch):]
+
+		args := make([]interface{}, 0, len(batch)*2+1)
+		args = append(args, s.namespace)
+		for _, id := range batch {
+			args = append(args, id)
+		}
+		in := "(" + sqlPlaceholders(len(batch)) + ")"
+
+		deleted, err := deleteReturningIDs(ctx, tx, "DELETE FROM edges WHERE namespace = ? AND (source_id IN "+in+" OR target_id IN "+in+") RETURNING id",
+			append(args, args[1:]...)...)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to delete nodes: %w", err)
+		}
+		edgeIDs = append(edgeIDs, deleted...)
+
+		res, err := tx.ExecContext(ctx, "DELETE FROM nodes WHERE namespace = ? AND id IN "+in, args...)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to delete nodes: %w", err)
+		}
+		affected, _ := res.RowsAffected()
+		nodesDeleted += int(affected)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.logQuery(ctx, "delete_nodes", start, slog.Int("nodes_deleted", nodesDeleted), slog.Int("edges_deleted", len(edgeIDs)))
+	return nodesDeleted, edgeIDs, nil
+}
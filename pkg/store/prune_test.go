@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestAgedNodeIDs(t *testing.T) {
+	ctx := context.Background()
+	graphStore := setupTestStore(t)
+	defer graphStore.Close()
+
+	now := time.Now()
+	day := 24 * time.Hour
+	for _, node := range []*Node{
+		{ID: "old-concept", Name: "Old concept", Type: "Concept", CreatedAt: now.Add(-40 * day)},
+		{ID: "new-concept", Name: "New concept", Type: "Concept", CreatedAt: now.Add(-5 * day)},
+		{ID: "old-person", Name: "Old person", Type: "Person", CreatedAt: now.Add(-40 * day)},
+		{ID: "ancient-person", Name: "Ancient person", Type: "Person", CreatedAt: now.Add(-400 * day)},
+		{ID: "pinned", Name: "Pinned", Type: "Concept", CreatedAt: now.Add(-40 * day)},
+		{ID: "read", Name: "Read", Type: "Concept", CreatedAt: now.Add(-40 * day)},
+	} {
+		if err := graphStore.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	if err := graphStore.SetNodePinned(ctx, "pinned", true, "keep"); err != nil {
+		t.Fatalf("SetNodePinned failed: %v", err)
+	}
+	if err := graphStore.UpdateAccessTime(ctx, []string{"read"}); err != nil {
+		t.Fatalf("UpdateAccessTime failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter NodeAgeFilter
+		want   []string
+	}{
+		{"none", NodeAgeFilter{}, nil},
+		{"created", NodeAgeFilter{Before: now.Add(-30 * day)}, []string{"ancient-person", "old-concept", "old-person", "read"}},
+		{"accessed", NodeAgeFilter{FromLastAccess: true, Before: now.Add(-30 * day)}, []string{"ancient-person", "old-concept", "old-person"}},
+		{"type override", NodeAgeFilter{
+			Before:     now.Add(-30 * day),
+			TypeBefore: map[string]time.Time{"Person": now.Add(-365 * day)},
+		}, []string{"ancient-person", "old-concept", "read"}},
+		{"type excluded", NodeAgeFilter{
+			Before:     now.Add(-30 * day),
+			TypeBefore: map[string]time.Time{"Person": {}},
+		}, []string{"old-concept", "read"}},
+	}
+	for _, tt := range tests {
+		got, err := graphStore.AgedNodeIDs(ctx, tt.filter)
+		if err != nil {
+			t.Fatalf("%s: AgedNodeIDs failed: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDeleteNodes(t *testing.T) {
+	ctx := context.Background()
+	graphStore := setupTestStore(t)
+	defer graphStore.Close()
+
+	for _, id := range []string{"a", "b", "c"} {
+		if err := graphStore.AddNode(ctx, &Node{ID: id, Name: id}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	for _, edge := range []*Edge{
+		{ID: "ab", SourceID: "a", Relation: "USES", TargetID: "b"},
+		{ID: "ca", SourceID: "c", Relation: "USES", TargetID: "a"},
+		{ID: "bc", SourceID: "b", Relation: "USES", TargetID: "c"},
+	} {
+		if err := graphStore.AddEdge(ctx, edge); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+	if err := graphStore.RecordDescriptionMerges(ctx, []DescriptionMerge{{NodeID: "a", Previous: "old", Incoming: "new"}}); err != nil {
+		t.Fatalf("RecordDescriptionMerges failed: %v", err)
+	}
+
+	nodesDeleted, edgeIDs, err := graphStore.DeleteNodes(ctx, []string{"a", "missing"})
+	if err != nil {
+		t.Fatalf("DeleteNodes failed: %v", err)
+	}
+	sort.Strings(edgeIDs)
+	if nodesDeleted != 1 || len(edgeIDs) != 2 || edgeIDs[0] != "ab" || edgeIDs[1] != "ca" {
+		t.Errorf("expected 1 node and edges ab, ca deleted, got %d and %v", nodesDeleted, edgeIDs)
+	}
+	if count, _ := graphStore.NodeCount(ctx); count != 2 {
+		t.Errorf("expected 2 nodes left, got %d", count)
+	}
+	if count, _ := graphStore.EdgeCount(ctx); count != 1 {
+		t.Errorf("expected the edge between the kept nodes left, got %d edges", count)
+	}
+	if history, _ := graphStore.NodeDescriptions(ctx, "a"); len(history) != 0 {
+		t.Errorf("expected the description history deleted, got %v", history)
+	}
+}
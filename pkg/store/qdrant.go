@@ -0,0 +1,278 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultQdrantCollection is the collection used when none is configured.
+const DefaultQdrantCollection = "gognee_nodes"
+
+// qdrantPointNamespace seeds the point IDs derived from node IDs. Qdrant only accepts
+// unsigned integers and UUIDs as point IDs, so each node ID maps to a name-based UUID.
+var qdrantPointNamespace = uuid.MustParse("6f1c2d52-8b1e-4c55-9a43-2e7b0d9c3a61")
+
+// QdrantVectorStore implements VectorStore on a Qdrant server through its REST API,
+// for deployments that offload similarity search while keeping the graph in SQLite.
+//
+// Implementation notes:
+//   - Each node is one point whose ID is derived from the namespace and node ID; the node ID
+//     and namespace are kept in the payload
+//   - The collection is created with cosine distance on the first Add, sized to that embedding
+//   - Points are not removed when nodes are deleted through the graph store; search skips
+//     vectors whose node no longer exists, and Delete removes them explicitly
+type QdrantVectorStore struct {
+	baseURL    string
+	collection string
+	apiKey     string
+	namespace  string // Restricts search results to points in this namespace (see WithNamespace)
+	client     *http.Client
+
+	mu    sync.Mutex
+	ready bool // The collection is known to exist
+}
+
+// NewQdrantVectorStore creates a vector store for the given Qdrant server, e.g.
+// "http://localhost:6333". An empty collection uses DefaultQdrantCollection.
+func NewQdrantVectorStore(baseURL, collection string) *QdrantVectorStore {
+	if collection == "" {
+		collection = DefaultQdrantCollection
+	}
+	return &QdrantVectorStore{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		collection: collection,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// WithAPIKey sets the API key sent with every request, for Qdrant Cloud or servers
+// started with an api-key.
+func (s *QdrantVectorStore) WithAPIKey(apiKey string) *QdrantVectorStore {
+	s.apiKey = apiKey
+	return s
+}
+
+// WithNamespace scopes the store to one namespace, so several namespaces can share a
+// collection. It returns the store for chaining.
+func (s *QdrantVectorStore) WithNamespace(namespace string) *QdrantVectorStore {
+	s.namespace = namespace
+	return s
+}
+
+type qdrantPoint struct {
+	ID      string        `json:"id"`
+	Vector  []float32     `json:"vector"`
+	Payload qdrantPayload `json:"payload"`
+}
+
+type qdrantPayload struct {
+	NodeID    string `json:"node_id"`
+	Namespace string `json:"namespace"`
+}
+
+type qdrantFilter struct {
+	Must []qdrantCondition `json:"must"`
+}
+
+type qdrantCondition struct {
+	Key   string `json:"key"`
+	Match struct {
+		Value string `json:"value"`
+	} `json:"match"`
+}
+
+type qdrantSearchRequest struct {
+	Vector      []float32    `json:"vector"`
+	Limit       int          `json:"limit"`
+	WithPayload bool         `json:"with_payload"`
+	Filter      qdrantFilter `json:"filter"`
+}
+
+type qdrantSearchResponse struct {
+	Result []struct {
+		Score   float64       `json:"score"`
+		Payload qdrantPayload `json:"payload"`
+	} `json:"result"`
+}
+
+// Add adds or updates the embedding for the given node ID, creating the collection
+// if it does not exist yet.
+func (s *QdrantVectorStore) Add(ctx context.Context, id string, embedding []float32) error {
+	if len(embedding) == 0 {
+		return fmt.Errorf("embedding cannot be empty")
+	}
+	if err := s.ensureCollection(ctx, len(embedding)); err != nil {
+		return err
+	}
+
+	body := map[string][]qdrantPoint{
+		"points": {{
+			ID:      s.pointID(id),
+			Vector:  embedding,
+			Payload: qdrantPayload{NodeID: id, Namespace: s.namespace},
+		}},
+	}
+	if _, err := s.do(ctx, http.MethodPut, s.collectionPath()+"/points?wait=true", body); err != nil {
+		return fmt.Errorf("failed to upsert vector: %w", err)
+	}
+	return nil
+}
+
+// Search finds the topK points in the store's namespace most similar to the query.
+// Returns an empty slice if the collection does not exist yet.
+func (s *QdrantVectorStore) Search(ctx context.Context, query []float32, topK int) ([]SearchResult, error) {
+	if len(query) == 0 {
+		return nil, fmt.Errorf("query embedding cannot be empty")
+	}
+	if topK <= 0 {
+		return []SearchResult{}, nil
+	}
+
+	req := qdrantSearchRequest{
+		Vector:      query,
+		Limit:       topK,
+		WithPayload: true,
+		Filter:      s.namespaceFilter(),
+	}
+	data, err := s.do(ctx, http.MethodPost, s.collectionPath()+"/points/search", req)
+	if err != nil {
+		if isQdrantNotFound(err) {
+			return []SearchResult{}, nil
+		}
+		return nil, fmt.Errorf("failed to search vectors: %w", err)
+	}
+
+	var resp qdrantSearchResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode search response: %w", err)
+	}
+	results := make([]SearchResult, 0, len(resp.Result))
+	for _, point := range resp.Result {
+		results = append(results, SearchResult{ID: point.Payload.NodeID, Score: point.Score})
+	}
+	return results, nil
+}
+
+// Delete removes the embedding for the given node ID. Deleting a missing vector is not an error.
+func (s *QdrantVectorStore) Delete(ctx context.Context, id string) error {
+	body := map[string][]string{"points": {s.pointID(id)}}
+	if _, err := s.do(ctx, http.MethodPost, s.collectionPath()+"/points/delete?wait=true", body); err != nil {
+		if isQdrantNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete vector: %w", err)
+	}
+	return nil
+}
+
+// ensureCollection creates the collection with cosine distance and the given vector
+// size unless it already exists.
+func (s *QdrantVectorStore) ensureCollection(ctx context.Context, dimensions int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ready {
+		return nil
+	}
+
+	_, err := s.do(ctx, http.MethodGet, s.collectionPath(), nil)
+	if isQdrantNotFound(err) {
+		body := map[string]any{
+			"vectors": map[string]any{"size": dimensions, "distance": "Cosine"},
+		}
+		_, err = s.do(ctx, http.MethodPut, s.collectionPath(), body)
+		if err != nil {
+			return fmt.Errorf("failed to create collection %s: %w", s.collection, err)
+		}
+		// Namespace filters run on every search
+		index := map[string]string{"field_name": "namespace", "field_schema": "keyword"}
+		if _, err := s.do(ctx, http.MethodPut, s.collectionPath()+"/index?wait=true", index); err != nil {
+			return fmt.Errorf("failed to index namespace payload: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to check collection %s: %w", s.collection, err)
+	}
+
+	s.ready = true
+	return nil
+}
+
+func (s *QdrantVectorStore) pointID(id string) string {
+	return uuid.NewSHA1(qdrantPointNamespace, []byte(s.namespace+"\x00"+id)).String()
+}
+
+func (s *QdrantVectorStore) namespaceFilter() qdrantFilter {
+	var condition qdrantCondition
+	condition.Key = "namespace"
+	condition.Match.Value = s.namespace
+	return qdrantFilter{Must: []qdrantCondition{condition}}
+}
+
+func (s *QdrantVectorStore) collectionPath() string {
+	return "/collections/" + url.PathEscape(s.collection)
+}
+
+// qdrantStatusError is returned for non-2xx responses.
+type qdrantStatusError struct {
+	status int
+	body   string
+}
+
+func (e *qdrantStatusError) Error() string {
+	return fmt.Sprintf("qdrant returned %d: %s", e.status, e.body)
+}
+
+func isQdrantNotFound(err error) bool {
+	var statusErr *qdrantStatusError
+	return errors.As(err, &statusErr) && statusErr.status == http.StatusNotFound
+}
+
+// do sends a JSON request to the Qdrant API and returns the response body.
+func (s *QdrantVectorStore) do(ctx context.Context, method, path string, body any) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("marshal request: %w", err)
+		}
+		reader = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.apiKey != "" {
+		req.Header.Set("api-key", s.apiKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("qdrant request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &qdrantStatusError{status: resp.StatusCode, body: string(data)}
+	}
+	return data, nil
+}
@@ -0,0 +1,169 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeQdrant serves the subset of the Qdrant REST API used by QdrantVectorStore
+type fakeQdrant struct {
+	mu          sync.Mutex
+	collections map[string]int // name -> vector size
+	points      map[string]qdrantPoint
+	apiKeys     []string
+}
+
+func newFakeQdrant(t *testing.T) (*fakeQdrant, *httptest.Server) {
+	f := &fakeQdrant{collections: map[string]int{}, points: map[string]qdrantPoint{}}
+	server := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeQdrant) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.apiKeys = append(f.apiKeys, r.Header.Get("api-key"))
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/collections/"), "/")
+	name, action := parts[0], strings.Join(parts[1:], "/")
+	if _, ok := f.collections[name]; !ok && !(action == "" && r.Method == http.MethodPut) {
+		http.Error(w, `{"status":{"error":"Not found"}}`, http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodPut:
+		var req struct {
+			Vectors struct {
+				Size     int    `json:"size"`
+				Distance string `json:"distance"`
+			} `json:"vectors"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		f.collections[name] = req.Vectors.Size
+	case action == "" || action == "index":
+	case action == "points" && r.Method == http.MethodPut:
+		var req struct {
+			Points []qdrantPoint `json:"points"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, p := range req.Points {
+			if len(p.Vector) != f.collections[name] {
+				http.Error(w, `{"status":{"error":"wrong vector size"}}`, http.StatusBadRequest)
+				return
+			}
+			f.points[p.ID] = p
+		}
+	case action == "points/delete":
+		var req struct {
+			Points []string `json:"points"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, id := range req.Points {
+			delete(f.points, id)
+		}
+	case action == "points/search":
+		var req qdrantSearchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		type hit struct {
+			Score   float64       `json:"score"`
+			Payload qdrantPayload `json:"payload"`
+		}
+		var hits []hit
+		for _, p := range f.points {
+			if p.Payload.Namespace == req.Filter.Must[0].Match.Value {
+				hits = append(hits, hit{Score: CosineSimilarity(req.Vector, p.Vector), Payload: p.Payload})
+			}
+		}
+		sort.Slice(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+		if len(hits) > req.Limit {
+			hits = hits[:req.Limit]
+		}
+		json.NewEncoder(w).Encode(map[string]any{"result": hits})
+		return
+	}
+	w.Write([]byte(`{"result": true, "status": "ok"}`))
+}
+
+func TestQdrantVectorStore(t *testing.T) {
+	ctx := context.Background()
+	fake, server := newFakeQdrant(t)
+	s := NewQdrantVectorStore(server.URL+"/", "").WithAPIKey("secret")
+
+	// Searching before the collection exists finds nothing
+	results, err := s.Search(ctx, []float32{1, 0, 0}, 5)
+	if err != nil || len(results) != 0 {
+		t.Fatalf("expected no results before the first Add, got %v, %v", results, err)
+	}
+
+	for id, vec := range map[string][]float32{"a": {1, 0, 0}, "b": {0.9, 0.1, 0}, "c": {0, 0, 1}} {
+		if err := s.Add(ctx, id, vec); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if fake.collections[DefaultQdrantCollection] != 3 {
+		t.Errorf("expected the default collection sized to the embeddings, got %v", fake.collections)
+	}
+	// Re-adding replaces the point
+	if err := s.Add(ctx, "c", []float32{0, 1, 0}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if len(fake.points) != 3 {
+		t.Errorf("expected 3 points after an update, got %d", len(fake.points))
+	}
+
+	results, err = s.Search(ctx, []float32{1, 0, 0}, 2)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 2 || results[0].ID != "a" || results[1].ID != "b" {
+		t.Errorf("expected [a b], got %v", results)
+	}
+
+	if err := s.Delete(ctx, "a"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	results, _ = s.Search(ctx, []float32{1, 0, 0}, 1)
+	if len(results) != 1 || results[0].ID != "b" {
+		t.Errorf("expected b after deleting a, got %v", results)
+	}
+
+	for _, key := range fake.apiKeys {
+		if key != "secret" {
+			t.Fatalf("expected every request to carry the API key, got %q", key)
+		}
+	}
+
+	if err := s.Add(ctx, "d", []float32{1, 0}); err == nil {
+		t.Error("expected an error for a vector of the wrong size")
+	}
+}
+
+func TestQdrantVectorStore_Namespaces(t *testing.T) {
+	ctx := context.Background()
+	_, server := newFakeQdrant(t)
+	a := NewQdrantVectorStore(server.URL, "shared").WithNamespace("a")
+	b := NewQdrantVectorStore(server.URL, "shared").WithNamespace("b")
+
+	if err := a.Add(ctx, "n1", []float32{1, 0}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := b.Add(ctx, "n1", []float32{0, 1}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	results, err := b.Search(ctx, []float32{1, 0}, 5)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "n1" || results[0].Score > 0.01 {
+		t.Errorf("expected only namespace b's vector, got %v", results)
+	}
+}
@@ -0,0 +1,203 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrQuarantineNotFound indicates that no quarantine exists with the given ID.
+var ErrQuarantineNotFound = errors.New("quarantine not found")
+
+// Quarantine holds the nodes derived from a suspicious document or memory out of search
+// until a reviewer releases them.
+type Quarantine struct {
+	ID         string    `json:"id"`
+	Kind       string    `json:"kind"`      // "document" or "memory"
+	Reference  string    `json:"reference"` // Document hash or memory ID
+	Source     string    `json:"source"`    // Document source, if known
+	Violations []string  `json:"violations"`
+	NodeIDs    []string  `json:"node_ids"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// migrateQuarantineSchema creates the quarantine tables.
+func (s *SQLiteGraphStore) migrateQuarantineSchema() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS quarantines (
+		id TEXT PRIMARY KEY,
+		kind TEXT NOT NULL,
+		reference TEXT NOT NULL,
+		source TEXT,
+		violations_json TEXT,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS quarantine_nodes (
+		quarantine_id TEXT NOT NULL,
+		node_id TEXT NOT NULL,
+		PRIMARY KEY (quarantine_id, node_id),
+		FOREIGN KEY (quarantine_id) REFERENCES quarantines(id) ON DELETE CASCADE
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_quarantine_nodes_node ON quarantine_nodes(node_id);
+	`
+
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create quarantine tables: %w", err)
+	}
+	return nil
+}
+
+// AddQuarantine records a quarantine over q.NodeIDs. ID and CreatedAt are set if empty.
+func (s *SQLiteGraphStore) AddQuarantine(ctx context.Context, q *Quarantine) error {
+	if q.ID == "" {
+		q.ID = uuid.New().String()
+	}
+	if q.CreatedAt.IsZero() {
+		q.CreatedAt = time.Now()
+	}
+	violationsJSON, err := json.Marshal(q.Violations)
+	if err != nil {
+		return fmt.Errorf("failed to marshal violations: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO quarantines (id, kind, reference, source, violations_json, created_at, namespace)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, q.ID, q.Kind, q.Reference, q.Source, violationsJSON, q.CreatedAt, s.namespace); err != nil {
+		return fmt.Errorf("failed to record quarantine: %w", err)
+	}
+	for _, nodeID := range q.NodeIDs {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT OR IGNORE INTO quarantine_nodes (quarantine_id, node_id) VALUES (?, ?)", q.ID, nodeID); err != nil {
+			return fmt.Errorf("failed to quarantine node: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListQuarantines returns the open quarantines, oldest first.
+func (s *SQLiteGraphStore) ListQuarantines(ctx context.Context) ([]Quarantine, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT q.id, q.kind, q.reference, COALESCE(q.source, ''), q.violations_json, q.created_at,
+			COALESCE(GROUP_CONCAT(qn.node_id, char(31)), '')
+		FROM quarantines q
+		LEFT JOIN quarantine_nodes qn ON qn.quarantine_id = q.id
+		WHERE q.namespace = ?
+		GROUP BY q.id
+		ORDER BY q.created_at, q.id
+	`, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quarantines: %w", err)
+	}
+	defer rows.Close()
+
+	var quarantines []Quarantine
+	for rows.Next() {
+		var q Quarantine
+		var violationsJSON []byte
+		var nodeIDs string
+		if err := rows.Scan(&q.ID, &q.Kind, &q.Reference, &q.Source, &violationsJSON, &q.CreatedAt, &nodeIDs); err != nil {
+			return nil, fmt.Errorf("failed to scan quarantine: %w", err)
+		}
+		if len(violationsJSON) > 0 {
+			if err := json.Unmarshal(violationsJSON, &q.Violations); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal violations: %w", err)
+			}
+		}
+		if nodeIDs != "" {
+			q.NodeIDs = strings.Split(nodeIDs, "\x1f")
+		}
+		quarantines = append(quarantines, q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating quarantines: %w", err)
+	}
+
+	return quarantines, nil
+}
+
+// ReleaseQuarantine closes a quarantine. Its nodes become searchable again unless
+// another open quarantine still holds them.
+func (s *SQLiteGraphStore) ReleaseQuarantine(ctx context.Context, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM quarantine_nodes WHERE quarantine_id = ?", id); err != nil {
+		return fmt.Errorf("failed to release quarantined nodes: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, "DELETE FROM quarantines WHERE id = ? AND namespace = ?", id, s.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to release quarantine: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	} else if n == 0 {
+		return ErrQuarantineNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// QuarantinedNodeIDs returns the IDs of nodes held by any open quarantine.
+func (s *SQLiteGraphStore) QuarantinedNodeIDs(ctx context.Context) (map[string]bool, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT qn.node_id
+		FROM quarantine_nodes qn
+		JOIN quarantines q ON q.id = qn.quarantine_id
+		WHERE q.namespace = ?
+	`, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quarantined nodes: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan quarantined node: %w", err)
+		}
+		ids[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating quarantined nodes: %w", err)
+	}
+	return ids, nil
+}
+
+// QuarantinedAmong returns those of nodeIDs held by any open quarantine.
+func (s *SQLiteGraphStore) QuarantinedAmong(ctx context.Context, nodeIDs []string) (map[string]bool, error) {
+	ids, err := nodeIDsAmong(ctx, s.db, nodeIDs, `
+		SELECT DISTINCT qn.node_id
+		FROM quarantine_nodes qn
+		JOIN quarantines q ON q.id = qn.quarantine_id
+		WHERE q.namespace = ? AND qn.node_id IN (%s)
+	`, []interface{}{s.namespace}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quarantined nodes: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,392 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// DefaultQueryLimit is the number of rows a GraphQuery returns when Limit is 0;
+// MaxQueryLimit caps Limit.
+const (
+	DefaultQueryLimit = 100
+	MaxQueryLimit     = 10000
+)
+
+// GraphQuery matches a path pattern against the graph, like a Cypher MATCH clause.
+// ParseGraphQuery builds one from text; it can also be built directly:
+//
+//	q := &GraphQuery{
+//		Nodes:  []NodePattern{{Var: "a", Type: "Technology"}, {Var: "b"}},
+//		Edges:  []EdgePattern{{Relations: []string{"DEPENDS_ON"}, Direction: DirectionOutgoing}},
+//		Return: []string{"a", "b"},
+//	}
+type GraphQuery struct {
+	// Nodes are the nodes of the path, in order. A variable used twice matches the same node.
+	Nodes []NodePattern
+	// Edges connect the nodes: Edges[i] joins Nodes[i] and Nodes[i+1].
+	Edges []EdgePattern
+	// Where conditions must all hold.
+	Where []QueryCondition
+	// Return lists the variables to return; empty returns every variable.
+	Return []string
+	// Limit caps the rows returned. Default DefaultQueryLimit, max MaxQueryLimit.
+	Limit int
+}
+
+// NodePattern matches a node.
+type NodePattern struct {
+	Var  string // Variable naming the node in conditions and results; optional
+	Type string // Matches the node type, case-insensitively; empty matches any
+	// Properties must equal the node's (see QueryCondition for property names)
+	Properties map[string]interface{}
+}
+
+// EdgePattern matches an edge between two nodes of the path.
+type EdgePattern struct {
+	Var string // Variable naming the edge in conditions and results; optional
+	// Relations matches edges with one of these relations, compared like sanitized labels
+	// ("depends on" matches DEPENDS_ON); empty matches any
+	Relations []string
+	// Direction relative to the path: DirectionOutgoing runs from the earlier node to
+	// the later one. Default DirectionBoth.
+	Direction TraversalDirection
+}
+
+// QueryCondition compares a property of a node or edge variable with a value.
+// Node properties are id, name, type, description and mention_count; edge properties
+// are id, relation, weight and evidence. Text comparisons ignore case.
+type QueryCondition struct {
+	Var      string
+	Property string
+	Op       string      // =, <>, <, <=, >, >=, CONTAINS, STARTS WITH or ENDS WITH
+	Value    interface{} // string or number
+}
+
+// QueryResult holds the rows matched by a GraphQuery. Rows are distinct.
+type QueryResult struct {
+	Columns []string   // The returned variables, in order
+	Rows    []QueryRow // Matches, ordered by the IDs of the returned nodes and edges
+}
+
+// QueryRow is one match of a GraphQuery, keyed by variable.
+type QueryRow struct {
+	Nodes map[string]*Node
+	Edges map[string]*Edge
+}
+
+// queryProperty is a property conditions can compare
+type queryProperty struct {
+	column  string
+	numeric bool
+}
+
+var (
+	nodeQueryProperties = map[string]queryProperty{
+		"id": {"id", false}, "name": {"name", false}, "type": {"type", false},
+		"description": {"description", false}, "mention_count": {"mention_count", true},
+	}
+	edgeQueryProperties = map[string]queryProperty{
+		"id": {"id", false}, "relation": {"relation", false}, "weight": {"weight", true},
+		"evidence": {"evidence", false},
+	}
+)
+
+// queryVar is a variable bound to a table alias of the compiled query
+type queryVar struct {
+	alias  string
+	isEdge bool
+}
+
+// compiledQuery is a GraphQuery compiled to SQL selecting the IDs of the returned variables
+type compiledQuery struct {
+	sql     string
+	args    []interface{}
+	columns []string
+	vars    map[string]queryVar
+}
+
+// compile validates the query and compiles it to SQL scoped to namespace, hiding edges
+// expired at now and the nodes search hides from the context's principal (see
+// visibleNodeCondition).
+func (q *GraphQuery) compile(ctx context.Context, namespace string, now time.Time) (*compiledQuery, error) {
+	if len(q.Nodes) == 0 {
+		return nil, Invalidf("query must match at least one node")
+	}
+	if len(q.Edges) != len(q.Nodes)-1 {
+		return nil, Invalidf("query has %d nodes and %d edges; a path needs one edge fewer than nodes", len(q.Nodes), len(q.Edges))
+	}
+	limit := q.Limit
+	if limit == 0 {
+		limit = DefaultQueryLimit
+	}
+	if limit < 0 || limit > MaxQueryLimit {
+		return nil, Invalidf("query limit must be between 1 and %d", MaxQueryLimit)
+	}
+
+	c := &compiledQuery{vars: make(map[string]queryVar)}
+	var from, where []string
+	var order []string
+	cond := func(clause string, args ...interface{}) {
+		where = append(where, clause)
+		c.args = append(c.args, args...)
+	}
+
+	// Nodes: one alias per distinct variable
+	nodeAliases := make([]string, len(q.Nodes))
+	for i, node := range q.Nodes {
+		alias := fmt.Sprintf("n%d", i)
+		if bound, ok := c.vars[node.Var]; ok && node.Var != "" {
+			if bound.isEdge {
+				return nil, Invalidf("variable %q names both an edge and a node", node.Var)
+			}
+			alias = bound.alias
+		} else {
+			from = append(from, "nodes "+alias)
+			cond(alias+".namespace = ?", namespace)
+			visible, visibleArgs := visibleNodeCondition(ctx, alias, namespace)
+			cond(visible, visibleArgs...)
+			if node.Var != "" {
+				c.vars[node.Var] = queryVar{alias: alias}
+			}
+		}
+		nodeAliases[i] = alias
+		if node.Type != "" {
+			cond(alias+".type = ? COLLATE NOCASE", node.Type)
+		}
+		for property, value := range node.Properties {
+			clause, args, err := compileCondition(alias, nodeQueryProperties, QueryCondition{Var: node.Var, Property: property, Op: "=", Value: value})
+			if err != nil {
+				return nil, err
+			}
+			cond(clause, args...)
+		}
+	}
+
+	// Edges: joined to the nodes on either side; an edge is matched at most once per path
+	for i, edge := range q.Edges {
+		alias := fmt.Sprintf("e%d", i)
+		if edge.Var != "" {
+			if _, ok := c.vars[edge.Var]; ok {
+				return nil, Invalidf("variable %q is bound twice; edge variables must be unique", edge.Var)
+			}
+			c.vars[edge.Var] = queryVar{alias: alias, isEdge: true}
+		}
+		from = append(from, "edges "+alias)
+		cond(alias+".namespace = ? AND ("+alias+".expires_at IS NULL OR "+alias+".expires_at > ?)", namespace, now)
+
+		left, right := nodeAliases[i], nodeAliases[i+1]
+		forward := fmt.Sprintf("(%[1]s.source_id = %[2]s.id AND %[1]s.target_id = %[3]s.id)", alias, left, right)
+		backward := fmt.Sprintf("(%[1]s.source_id = %[3]s.id AND %[1]s.target_id = %[2]s.id)", alias, left, right)
+		switch edge.Direction {
+		case DirectionOutgoing:
+			cond(forward)
+		case DirectionIncoming:
+			cond(backward)
+		case "", DirectionBoth:
+			cond("(" + forward + " OR " + backward + ")")
+		default:
+			return nil, Invalidf("invalid edge direction %q", edge.Direction)
+		}
+		if len(edge.Relations) > 0 {
+			labels := make([]interface{}, len(edge.Relations))
+			for j, relation := range edge.Relations {
+				labels[j] = queryRelationLabel(relation)
+			}
+			cond("UPPER(REPLACE("+alias+".relation, ' ', '_')) IN ("+sqlPlaceholders(len(labels))+")", labels...)
+		}
+		for j := 0; j < i; j++ {
+			cond(fmt.Sprintf("%s.id <> e%d.id", alias, j))
+		}
+	}
+
+	for _, condition := range q.Where {
+		bound, ok := c.vars[condition.Var]
+		if !ok || condition.Var == "" {
+			return nil, Invalidf("unknown variable %q in condition", condition.Var)
+		}
+		properties := nodeQueryProperties
+		if bound.isEdge {
+			properties = edgeQueryProperties
+		}
+		clause, args, err := compileCondition(bound.alias, properties, condition)
+		if err != nil {
+			return nil, err
+		}
+		cond(clause, args...)
+	}
+
+	c.columns = q.Return
+	if len(c.columns) == 0 {
+		c.columns = queryVariables(q)
+	}
+	if len(c.columns) == 0 {
+		return nil, Invalidf("query returns nothing: name a node or edge with a variable")
+	}
+	selected := make([]string, len(c.columns))
+	for i, name := range c.columns {
+		bound, ok := c.vars[name]
+		if !ok || name == "" {
+			return nil, Invalidf("unknown variable %q in return", name)
+		}
+		selected[i] = bound.alias + ".id"
+		order = append(order, bound.alias+".id")
+	}
+
+	c.sql = "SELECT DISTINCT " + strings.Join(selected, ", ") +
+		" FROM " + strings.Join(from, ", ") +
+		" WHERE " + strings.Join(where, " AND ") +
+		" ORDER BY " + strings.Join(order, ", ") +
+		fmt.Sprintf(" LIMIT %d", limit)
+	return c, nil
+}
+
+// visibleNodeCondition returns the condition keeping the nodes of alias that search
+// shows: nodes not quarantined and, with a principal on ctx, not derived only from
+// memories it cannot read (see SQLiteMemoryStore.RestrictedAmong).
+func visibleNodeCondition(ctx context.Context, alias, namespace string) (string, []interface{}) {
+	condition := fmt.Sprintf(`NOT EXISTS (
+		SELECT 1 FROM quarantine_nodes qn JOIN quarantines q ON q.id = qn.quarantine_id
+		WHERE q.namespace = ? AND qn.node_id = %s.id)`, alias)
+	args := []interface{}{namespace}
+
+	clause, aclArgs := aclClause(ctx, "m.")
+	if clause == "" {
+		return condition, args
+	}
+	provenance := fmt.Sprintf(`SELECT 1 FROM memory_nodes mn JOIN memories m ON m.id = mn.memory_id
+		WHERE mn.node_id = %s.id AND m.namespace = ? AND m.status != ?`, alias)
+	condition += " AND (NOT EXISTS (" + provenance + ") OR EXISTS (" + provenance + clause + "))"
+	args = append(args, namespace, StatusDeleted, namespace, StatusDeleted)
+	return condition, append(args, aclArgs...)
+}
+
+// queryVariables returns the variables of a query in order of first appearance
+func queryVariables(q *GraphQuery) []string {
+	var names []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for i, node := range q.Nodes {
+		add(node.Var)
+		if i < len(q.Edges) {
+			add(q.Edges[i].Var)
+		}
+	}
+	return names
+}
+
+// queryRelationLabel normalizes a relation like sanitized edge labels: upper case with
+// underscores for spaces
+func queryRelationLabel(relation string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(relation), " ", "_"))
+}
+
+// compileCondition compiles a condition on the table alias to a SQL clause
+func compileCondition(alias string, properties map[string]queryProperty, c QueryCondition) (string, []interface{}, error) {
+	property, ok := properties[strings.ToLower(c.Property)]
+	if !ok {
+		return "", nil, Invalidf("unknown property %q of variable %q", c.Property, c.Var)
+	}
+	column := alias + "." + property.column
+
+	op := strings.ToUpper(strings.Join(strings.Fields(c.Op), " "))
+	if op == "!=" {
+		op = "<>"
+	}
+	switch value := c.Value.(type) {
+	case string:
+		if property.numeric {
+			return "", nil, Invalidf("property %s.%s is a number, got %q", c.Var, c.Property, value)
+		}
+		switch op {
+		case "=", "<>", "<", "<=", ">", ">=":
+			return column + " " + op + " ? COLLATE NOCASE", []interface{}{value}, nil
+		case "CONTAINS":
+			return "INSTR(LOWER(" + column + "), LOWER(?)) > 0", []interface{}{value}, nil
+		case "STARTS WITH":
+			return "SUBSTR(LOWER(" + column + "), 1, LENGTH(?)) = LOWER(?)", []interface{}{value, value}, nil
+		case "ENDS WITH":
+			return "SUBSTR(LOWER(" + column + "), -LENGTH(?)) = LOWER(?)", []interface{}{value, value}, nil
+		}
+	case int, int64, float64:
+		if !property.numeric {
+			return "", nil, Invalidf("property %s.%s is text, got %v", c.Var, c.Property, value)
+		}
+		switch op {
+		case "=", "<>", "<", "<=", ">", ">=":
+			return column + " " + op + " ?", []interface{}{value}, nil
+		}
+	default:
+		return "", nil, Invalidf("invalid value %v for %s.%s: must be a string or number", c.Value, c.Var, c.Property)
+	}
+	return "", nil, Invalidf("invalid operator %q for %s.%s", c.Op, c.Var, c.Property)
+}
+
+// QueryGraph runs a GraphQuery over this namespace's nodes and unexpired edges. Like
+// search, it skips quarantined nodes and nodes derived only from memories the context's
+// principal cannot read.
+func (s *SQLiteGraphStore) QueryGraph(ctx context.Context, q *GraphQuery) (*QueryResult, error) {
+	compiled, err := q.compile(ctx, s.namespace, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	rows, err := s.db.QueryContext(ctx, compiled.sql, compiled.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run graph query: %w", err)
+	}
+	var matches [][]string
+	for rows.Next() {
+		ids := make([]string, len(compiled.columns))
+		targets := make([]interface{}, len(ids))
+		for i := range ids {
+			targets[i] = &ids[i]
+		}
+		if err := rows.Scan(targets...); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan graph query row: %w", err)
+		}
+		matches = append(matches, ids)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating graph query rows: %w", err)
+	}
+	s.logQuery(ctx, "query_graph", start, slog.Int("rows", len(matches)))
+
+	// Load each matched node and edge once
+	nodes := make(map[string]*Node)
+	edges := make(map[string]*Edge)
+	result := &QueryResult{Columns: compiled.columns, Rows: make([]QueryRow, 0, len(matches))}
+	for _, ids := range matches {
+		row := QueryRow{Nodes: make(map[string]*Node), Edges: make(map[string]*Edge)}
+		for i, name := range compiled.columns {
+			id := ids[i]
+			if compiled.vars[name].isEdge {
+				if _, ok := edges[id]; !ok {
+					if edges[id], err = s.GetEdge(ctx, id); err != nil {
+						return nil, err
+					}
+				}
+				row.Edges[name] = edges[id]
+			} else {
+				if _, ok := nodes[id]; !ok {
+					if nodes[id], err = s.GetNode(ctx, id); err != nil {
+						return nil, err
+					}
+				}
+				row.Nodes[name] = nodes[id]
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, nil
+}
@@ -0,0 +1,399 @@
+package store
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseGraphQuery parses a query in a small subset of Cypher:
+//
+//	MATCH (a:Technology {name: "Go"})-[r:DEPENDS_ON|USES]->(b)<-[:OWNS]-(c)
+//	WHERE b.name CONTAINS "sql" AND r.weight >= 0.5
+//	RETURN a, b
+//	LIMIT 20
+//
+// MATCH takes a single path: nodes in parentheses with an optional variable, type label
+// and property map, joined by edges in brackets with an optional variable and relations
+// separated by |. Arrows give the direction; -[...]- matches either. WHERE conditions
+// are joined by AND (see QueryCondition). RETURN lists variables, or * for all. Keywords
+// are case-insensitive; labels with spaces are quoted in backticks.
+func ParseGraphQuery(text string) (*GraphQuery, error) {
+	tokens, err := lexGraphQuery(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{tokens: tokens}
+	return p.parse()
+}
+
+type queryTokenKind int
+
+const (
+	tokenEOF queryTokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int // Byte offset in the query
+}
+
+// lexGraphQuery splits a query into tokens. Symbols are single characters; the parser
+// combines arrows and comparison operators.
+func lexGraphQuery(text string) ([]queryToken, error) {
+	var tokens []queryToken
+	for i := 0; i < len(text); {
+		ch := rune(text[i])
+		switch {
+		case unicode.IsSpace(ch):
+			i++
+		case ch == '"' || ch == '\'':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(text) && rune(text[j]) != ch; j++ {
+				if text[j] == '\\' && j+1 < len(text) {
+					j++
+				}
+				b.WriteByte(text[j])
+			}
+			if j >= len(text) {
+				return nil, Invalidf("invalid query at offset %d: unterminated string", i)
+			}
+			tokens = append(tokens, queryToken{tokenString, b.String(), i})
+			i = j + 1
+		case ch == '`':
+			end := strings.IndexByte(text[i+1:], '`')
+			if end < 0 {
+				return nil, Invalidf("invalid query at offset %d: unterminated quoted name", i)
+			}
+			tokens = append(tokens, queryToken{tokenIdent, text[i+1 : i+1+end], i})
+			i += end + 2
+		case unicode.IsDigit(ch):
+			j := i
+			for j < len(text) && (unicode.IsDigit(rune(text[j])) || text[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, queryToken{tokenNumber, text[i:j], i})
+			i = j
+		case ch == '_' || unicode.IsLetter(ch):
+			j := i
+			for j < len(text) && (text[j] == '_' || unicode.IsLetter(rune(text[j])) || unicode.IsDigit(rune(text[j]))) {
+				j++
+			}
+			tokens = append(tokens, queryToken{tokenIdent, text[i:j], i})
+			i = j
+		case strings.ContainsRune("()[]{}:,.|-<>=!*", ch):
+			tokens = append(tokens, queryToken{tokenSymbol, string(ch), i})
+			i++
+		default:
+			return nil, Invalidf("invalid query at offset %d: unexpected character %q", i, ch)
+		}
+	}
+	return append(tokens, queryToken{tokenEOF, "", len(text)}), nil
+}
+
+// queryParser parses tokens by recursive descent
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() queryToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEOF {
+		p.pos++
+	}
+	return token
+}
+
+// errorf reports a syntax error at the current token
+func (p *queryParser) errorf(format string, args ...interface{}) error {
+	token := p.peek()
+	found := "end of query"
+	if token.kind != tokenEOF {
+		found = fmt.Sprintf("%q", token.text)
+	}
+	return Invalidf("invalid query at offset %d: %s, found %s", token.pos, fmt.Sprintf(format, args...), found)
+}
+
+// isSymbol reports whether the current token is the symbol s
+func (p *queryParser) isSymbol(s string) bool {
+	token := p.peek()
+	return token.kind == tokenSymbol && token.text == s
+}
+
+// acceptSymbol consumes the symbol s if it is next
+func (p *queryParser) acceptSymbol(s string) bool {
+	if p.isSymbol(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) expectSymbol(s string) error {
+	if !p.acceptSymbol(s) {
+		return p.errorf("expected %q", s)
+	}
+	return nil
+}
+
+// acceptKeyword consumes the keyword if it is next
+func (p *queryParser) acceptKeyword(keyword string) bool {
+	token := p.peek()
+	if token.kind == tokenIdent && strings.EqualFold(token.text, keyword) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) expectIdent(what string) (string, error) {
+	if p.peek().kind != tokenIdent {
+		return "", p.errorf("expected %s", what)
+	}
+	return p.next().text, nil
+}
+
+func (p *queryParser) parse() (*GraphQuery, error) {
+	if !p.acceptKeyword("MATCH") {
+		return nil, p.errorf("expected MATCH")
+	}
+	q := &GraphQuery{}
+	node, err := p.parseNode()
+	if err != nil {
+		return nil, err
+	}
+	q.Nodes = append(q.Nodes, node)
+	for p.isSymbol("-") || p.isSymbol("<") {
+		edge, err := p.parseEdge()
+		if err != nil {
+			return nil, err
+		}
+		node, err := p.parseNode()
+		if err != nil {
+			return nil, err
+		}
+		q.Edges = append(q.Edges, edge)
+		q.Nodes = append(q.Nodes, node)
+	}
+
+	if p.acceptKeyword("WHERE") {
+		for {
+			condition, err := p.parseCondition()
+			if err != nil {
+				return nil, err
+			}
+			q.Where = append(q.Where, condition)
+			if !p.acceptKeyword("AND") {
+				break
+			}
+		}
+	}
+
+	if !p.acceptKeyword("RETURN") {
+		return nil, p.errorf("expected RETURN")
+	}
+	if !p.acceptSymbol("*") {
+		for {
+			name, err := p.expectIdent("a variable")
+			if err != nil {
+				return nil, err
+			}
+			q.Return = append(q.Return, name)
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+	}
+
+	if p.acceptKeyword("LIMIT") {
+		token := p.next()
+		limit, err := strconv.Atoi(token.text)
+		if token.kind != tokenNumber || err != nil {
+			p.pos--
+			return nil, p.errorf("expected a whole number")
+		}
+		q.Limit = limit
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, p.errorf("expected end of query")
+	}
+	return q, nil
+}
+
+// parseNode parses (var:Type {key: value, ...}), every part optional
+func (p *queryParser) parseNode() (NodePattern, error) {
+	var node NodePattern
+	if err := p.expectSymbol("("); err != nil {
+		return node, err
+	}
+	if p.peek().kind == tokenIdent {
+		node.Var = p.next().text
+	}
+	if p.acceptSymbol(":") {
+		label, err := p.expectIdent("a node type")
+		if err != nil {
+			return node, err
+		}
+		node.Type = label
+	}
+	if p.acceptSymbol("{") {
+		node.Properties = make(map[string]interface{})
+		for !p.acceptSymbol("}") {
+			if len(node.Properties) > 0 {
+				if err := p.expectSymbol(","); err != nil {
+					return node, err
+				}
+			}
+			key, err := p.expectIdent("a property name")
+			if err != nil {
+				return node, err
+			}
+			if err := p.expectSymbol(":"); err != nil {
+				return node, err
+			}
+			value, err := p.parseValue()
+			if err != nil {
+				return node, err
+			}
+			node.Properties[key] = value
+		}
+	}
+	return node, p.expectSymbol(")")
+}
+
+// parseEdge parses -[var:REL|REL]->, <-[...]- or -[...]-; the brackets are optional
+func (p *queryParser) parseEdge() (EdgePattern, error) {
+	var edge EdgePattern
+	incoming := p.acceptSymbol("<")
+	if err := p.expectSymbol("-"); err != nil {
+		return edge, err
+	}
+	if p.acceptSymbol("[") {
+		if p.peek().kind == tokenIdent {
+			edge.Var = p.next().text
+		}
+		if p.acceptSymbol(":") {
+			for {
+				relation, err := p.expectIdent("a relation")
+				if err != nil {
+					return edge, err
+				}
+				edge.Relations = append(edge.Relations, relation)
+				if !p.acceptSymbol("|") {
+					break
+				}
+			}
+		}
+		if err := p.expectSymbol("]"); err != nil {
+			return edge, err
+		}
+	}
+	if err := p.expectSymbol("-"); err != nil {
+		return edge, err
+	}
+	outgoing := p.acceptSymbol(">")
+
+	switch {
+	case incoming && outgoing:
+		return edge, p.errorf("an edge cannot point both ways")
+	case outgoing:
+		edge.Direction = DirectionOutgoing
+	case incoming:
+		edge.Direction = DirectionIncoming
+	default:
+		edge.Direction = DirectionBoth
+	}
+	return edge, nil
+}
+
+// parseCondition parses var.property OP value
+func (p *queryParser) parseCondition() (QueryCondition, error) {
+	var condition QueryCondition
+	var err error
+	if condition.Var, err = p.expectIdent("a variable"); err != nil {
+		return condition, err
+	}
+	if err := p.expectSymbol("."); err != nil {
+		return condition, err
+	}
+	if condition.Property, err = p.expectIdent("a property name"); err != nil {
+		return condition, err
+	}
+
+	switch {
+	case p.acceptSymbol("="):
+		condition.Op = "="
+	case p.acceptSymbol("!"):
+		if err := p.expectSymbol("="); err != nil {
+			return condition, err
+		}
+		condition.Op = "<>"
+	case p.acceptSymbol("<"):
+		condition.Op = "<"
+		if p.acceptSymbol("=") {
+			condition.Op = "<="
+		} else if p.acceptSymbol(">") {
+			condition.Op = "<>"
+		}
+	case p.acceptSymbol(">"):
+		condition.Op = ">"
+		if p.acceptSymbol("=") {
+			condition.Op = ">="
+		}
+	case p.acceptKeyword("CONTAINS"):
+		condition.Op = "CONTAINS"
+	case p.acceptKeyword("STARTS"):
+		if !p.acceptKeyword("WITH") {
+			return condition, p.errorf("expected WITH")
+		}
+		condition.Op = "STARTS WITH"
+	case p.acceptKeyword("ENDS"):
+		if !p.acceptKeyword("WITH") {
+			return condition, p.errorf("expected WITH")
+		}
+		condition.Op = "ENDS WITH"
+	default:
+		return condition, p.errorf("expected a comparison operator")
+	}
+
+	condition.Value, err = p.parseValue()
+	return condition, err
+}
+
+// parseValue parses a string or a number, optionally negative
+func (p *queryParser) parseValue() (interface{}, error) {
+	negative := p.acceptSymbol("-")
+	token := p.peek()
+	switch {
+	case token.kind == tokenString && !negative:
+		p.pos++
+		return token.text, nil
+	case token.kind == tokenNumber:
+		p.pos++
+		text := token.text
+		if negative {
+			text = "-" + text
+		}
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return n, nil
+		}
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f, nil
+		}
+		p.pos--
+		return nil, p.errorf("invalid number")
+	}
+	return nil, p.errorf("expected a string or number")
+}
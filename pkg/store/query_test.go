@@ -0,0 +1,214 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// setupQueryGraph builds a small dependency graph:
+//
+//	gognee -DEPENDS_ON-> sqlite, gognee -DEPENDS_ON-> go, sqlite -WRITTEN_IN-> c
+//	alice -MAINTAINS-> gognee
+func setupQueryGraph(t *testing.T) *SQLiteGraphStore {
+	t.Helper()
+	store := setupTestStore(t)
+	ctx := context.Background()
+
+	nodes := []*Node{
+		{ID: "gognee", Name: "gognee", Type: "Project"},
+		{ID: "sqlite", Name: "SQLite", Type: "Technology"},
+		{ID: "go", Name: "Go", Type: "Technology"},
+		{ID: "c", Name: "C", Type: "Technology"},
+		{ID: "alice", Name: "Alice", Type: "Person"},
+	}
+	for _, node := range nodes {
+		if err := store.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	edges := []*Edge{
+		{ID: "e1", SourceID: "gognee", Relation: "DEPENDS_ON", TargetID: "sqlite", Weight: 0.9},
+		{ID: "e2", SourceID: "gognee", Relation: "DEPENDS_ON", TargetID: "go", Weight: 0.4},
+		{ID: "e3", SourceID: "sqlite", Relation: "WRITTEN_IN", TargetID: "c", Weight: 1.0},
+		{ID: "e4", SourceID: "alice", Relation: "maintains", TargetID: "gognee", Weight: 1.0},
+	}
+	for _, edge := range edges {
+		if err := store.AddEdge(ctx, edge); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+	return store
+}
+
+// runQuery parses and runs a query
+func runQuery(store *SQLiteGraphStore, query string) (*QueryResult, error) {
+	q, err := ParseGraphQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return store.QueryGraph(context.Background(), q)
+}
+
+// queryColumn returns the IDs bound to a variable, row by row
+func queryColumn(result *QueryResult, name string) []string {
+	var ids []string
+	for _, row := range result.Rows {
+		if node, ok := row.Nodes[name]; ok {
+			ids = append(ids, node.ID)
+		} else if edge, ok := row.Edges[name]; ok {
+			ids = append(ids, edge.ID)
+		}
+	}
+	return ids
+}
+
+func TestParseGraphQuery(t *testing.T) {
+	q, err := ParseGraphQuery(`match (a:Technology {name: "SQLite", mention_count: 1})<-[r:DEPENDS_ON|uses]-(b)-[]-(:` + "`Open Source`" + `)
+		WHERE r.weight >= -0.5 AND b.name STARTS WITH 'go' AND a.name != "x"
+		RETURN a, r LIMIT 5`)
+	if err != nil {
+		t.Fatalf("ParseGraphQuery failed: %v", err)
+	}
+	want := &GraphQuery{
+		Nodes: []NodePattern{
+			{Var: "a", Type: "Technology", Properties: map[string]interface{}{"name": "SQLite", "mention_count": int64(1)}},
+			{Var: "b"},
+			{Type: "Open Source"},
+		},
+		Edges: []EdgePattern{
+			{Var: "r", Relations: []string{"DEPENDS_ON", "uses"}, Direction: DirectionIncoming},
+			{Direction: DirectionBoth},
+		},
+		Where: []QueryCondition{
+			{Var: "r", Property: "weight", Op: ">=", Value: -0.5},
+			{Var: "b", Property: "name", Op: "STARTS WITH", Value: "go"},
+			{Var: "a", Property: "name", Op: "<>", Value: "x"},
+		},
+		Return: []string{"a", "r"},
+		Limit:  5,
+	}
+	if !reflect.DeepEqual(q, want) {
+		t.Errorf("ParseGraphQuery =\n%+v\nwant\n%+v", q, want)
+	}
+}
+
+func TestParseGraphQuery_Errors(t *testing.T) {
+	queries := []string{
+		``,
+		`RETURN a`,
+		`MATCH (a RETURN a`,
+		`MATCH (a)<-[]->(b) RETURN a`,
+		`MATCH (a) WHERE a.name LIKE "x" RETURN a`,
+		`MATCH (a) WHERE a.name = "x RETURN a`,
+		`MATCH (a) RETURN a LIMIT many`,
+		`MATCH (a) RETURN a; DROP TABLE nodes`,
+	}
+	for _, query := range queries {
+		if _, err := ParseGraphQuery(query); !errors.Is(err, ErrValidation) {
+			t.Errorf("ParseGraphQuery(%q): expected ErrValidation, got %v", query, err)
+		}
+	}
+}
+
+func TestQueryGraph(t *testing.T) {
+	store := setupQueryGraph(t)
+
+	tests := []struct {
+		name  string
+		query string
+		col   string
+		want  []string
+	}{
+		{"outgoing", `MATCH (a:project)-[:DEPENDS_ON]->(b) RETURN b`, "b", []string{"go", "sqlite"}},
+		{"incoming", `MATCH (b:Technology)<-[:DEPENDS_ON]-(a) RETURN b`, "b", []string{"go", "sqlite"}},
+		{"either direction", `MATCH (a {name: "sqlite"})-[r]-(b) RETURN r`, "r", []string{"e1", "e3"}},
+		{"relation like a label", `MATCH (p)-[:MAINTAINS]->(x) RETURN p`, "p", []string{"alice"}},
+		{"multi hop", `MATCH (p:Person)-->(x)-[:DEPENDS_ON]->(t)-[:WRITTEN_IN]->(l) RETURN l`, "l", []string{"c"}},
+		{"numeric condition", `MATCH (a)-[r:DEPENDS_ON]->(b) WHERE r.weight > 0.5 RETURN b`, "b", []string{"sqlite"}},
+		{"text condition", `MATCH (a:Technology) WHERE a.name CONTAINS "l" RETURN a`, "a", []string{"sqlite"}},
+		{"alternative relations", `MATCH (a)-[:WRITTEN_IN|MAINTAINS]->(b) RETURN a`, "a", []string{"alice", "sqlite"}},
+		{"limit", `MATCH (a:Technology) RETURN a LIMIT 1`, "a", []string{"c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := runQuery(store, tt.query)
+			if err != nil {
+				t.Fatalf("query failed: %v", err)
+			}
+			if got := queryColumn(result, tt.col); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryGraph_Builder(t *testing.T) {
+	store := setupQueryGraph(t)
+	ctx := context.Background()
+
+	result, err := store.QueryGraph(ctx, &GraphQuery{
+		Nodes: []NodePattern{{Var: "a"}, {Var: "b", Type: "Technology"}},
+		Edges: []EdgePattern{{Var: "r", Relations: []string{"depends on"}, Direction: DirectionOutgoing}},
+		Where: []QueryCondition{{Var: "b", Property: "name", Op: "=", Value: "go"}},
+	})
+	if err != nil {
+		t.Fatalf("QueryGraph failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.Columns, []string{"a", "r", "b"}) {
+		t.Errorf("expected every variable returned, got %v", result.Columns)
+	}
+	if len(result.Rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(result.Rows))
+	}
+	row := result.Rows[0]
+	if row.Nodes["a"].Name != "gognee" || row.Edges["r"].ID != "e2" || row.Nodes["b"].Name != "Go" {
+		t.Errorf("unexpected row %+v", row)
+	}
+
+	invalid := []*GraphQuery{
+		{},
+		{Nodes: []NodePattern{{Var: "a"}, {Var: "b"}}},
+		{Nodes: []NodePattern{{Var: "a"}}, Return: []string{"b"}},
+		{Nodes: []NodePattern{{Var: "a"}}, Where: []QueryCondition{{Var: "a", Property: "embedding", Op: "=", Value: "x"}}},
+		{Nodes: []NodePattern{{Var: "a"}}, Where: []QueryCondition{{Var: "a", Property: "mention_count", Op: "=", Value: "x"}}},
+		{Nodes: []NodePattern{{Var: "a"}}, Where: []QueryCondition{{Var: "a", Property: "name", Op: "LIKE", Value: "x"}}},
+		{Nodes: []NodePattern{{Var: "a"}}, Limit: MaxQueryLimit + 1},
+		{Nodes: []NodePattern{{}}},
+	}
+	for _, q := range invalid {
+		if _, err := store.QueryGraph(ctx, q); !errors.Is(err, ErrValidation) {
+			t.Errorf("QueryGraph(%+v): expected ErrValidation, got %v", q, err)
+		}
+	}
+}
+
+func TestQueryGraph_Namespace(t *testing.T) {
+	store := setupQueryGraph(t)
+
+	result, err := runQuery(store.WithNamespace("other"), `MATCH (a) RETURN a`)
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if len(result.Rows) != 0 {
+		t.Errorf("expected no rows in another namespace, got %d", len(result.Rows))
+	}
+}
+
+func TestQueryGraph_SkipsQuarantinedNodes(t *testing.T) {
+	store := setupQueryGraph(t)
+	ctx := context.Background()
+
+	if err := store.AddQuarantine(ctx, &Quarantine{Kind: "document", Reference: "doc", NodeIDs: []string{"sqlite"}}); err != nil {
+		t.Fatalf("AddQuarantine failed: %v", err)
+	}
+
+	result, err := runQuery(store, `MATCH (a:Project)-[:DEPENDS_ON]->(b) RETURN b`)
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if got := queryColumn(result, "b"); len(got) != 1 || got[0] != "go" {
+		t.Errorf("expected only the unquarantined dependency, got %v", got)
+	}
+}
@@ -0,0 +1,301 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults for RedisVectorConfig
+const (
+	DefaultRedisVectorIndex  = "gognee_nodes"
+	DefaultRedisVectorPrefix = "gognee:vec:"
+)
+
+// redisIndexName limits index names to characters that need no quoting in commands.
+var redisIndexName = regexp.MustCompile(`^[A-Za-z0-9_:.-]{1,128}$`)
+
+// RedisDoer sends one command to Redis and returns its RESP2 reply: strings or []byte
+// for bulk strings, int64 for integers and []interface{} for arrays. Error replies are
+// returned as errors. With go-redis (Options.Protocol = 2):
+//
+//	store.RedisDoFunc(func(ctx context.Context, args ...interface{}) (interface{}, error) {
+//		return rdb.Do(ctx, args...).Result()
+//	})
+type RedisDoer interface {
+	Do(ctx context.Context, args ...interface{}) (interface{}, error)
+}
+
+// RedisDoFunc adapts a function to RedisDoer.
+type RedisDoFunc func(ctx context.Context, args ...interface{}) (interface{}, error)
+
+// Do calls f.
+func (f RedisDoFunc) Do(ctx context.Context, args ...interface{}) (interface{}, error) {
+	return f(ctx, args...)
+}
+
+// RedisVectorConfig configures a RedisVectorStore.
+type RedisVectorConfig struct {
+	// Index is the RediSearch index (default: DefaultRedisVectorIndex). It is created if missing.
+	Index string
+
+	// Prefix starts the key of every vector hash (default: DefaultRedisVectorPrefix).
+	// Indexes sharing a Redis database need distinct prefixes.
+	Prefix string
+
+	// Dimensions is the embedding size, e.g. 1536 for text-embedding-3-small (required).
+	Dimensions int
+
+	// M and EfConstruction tune the HNSW index (defaults: 16 and 200, RediSearch's own).
+	M              int
+	EfConstruction int
+
+	// TTL expires vectors this long after they were last added (default: 0 = never).
+	// Gognee itself never expires vectors: an expired vector drops its node from vector
+	// search while the node stays in the graph, so set a TTL only for a store whose
+	// vectors the caller re-adds or whose nodes are disposable.
+	TTL time.Duration
+}
+
+// RedisVectorStore implements VectorStore on Redis with a RediSearch HNSW vector index,
+// as a low-latency alternative to the SQLite vector index.
+//
+// Implementation notes:
+//   - The store works on a RedisDoer wrapping the caller's client, so no Redis client is
+//     added as a dependency and the connection pool can be shared; the store never closes it
+//   - Each node is one hash holding the node ID, a namespace tag and the embedding as
+//     little-endian float32s; its key is derived from the prefix, namespace and node ID
+//   - Expiry is Redis's own: expired vectors disappear from search without a sweep
+//   - Similarity is cosine: scores are 1 - cosine distance
+type RedisVectorStore struct {
+	client    RedisDoer
+	cfg       RedisVectorConfig
+	namespace string // Restricts search results to vectors in this namespace (see WithNamespace)
+}
+
+// NewRedisVectorStore creates a RediSearch-backed vector store on client, creating the
+// index if it doesn't exist.
+func NewRedisVectorStore(ctx context.Context, client RedisDoer, cfg RedisVectorConfig) (*RedisVectorStore, error) {
+	if cfg.Index == "" {
+		cfg.Index = DefaultRedisVectorIndex
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = DefaultRedisVectorPrefix
+	}
+	if cfg.M == 0 {
+		cfg.M = 16
+	}
+	if cfg.EfConstruction == 0 {
+		cfg.EfConstruction = 200
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	s := &RedisVectorStore{client: client, cfg: cfg}
+	if _, err := client.Do(ctx, cfg.createIndexArgs()...); err != nil && !strings.Contains(err.Error(), "Index already exists") {
+		return nil, fmt.Errorf("failed to create redis index %s: %w", cfg.Index, err)
+	}
+	return s, nil
+}
+
+// WithNamespace scopes the store to one namespace, so several namespaces can share the
+// index. It returns the store for chaining.
+func (s *RedisVectorStore) WithNamespace(namespace string) *RedisVectorStore {
+	s.namespace = namespace
+	return s
+}
+
+func (c RedisVectorConfig) validate() error {
+	if !redisIndexName.MatchString(c.Index) {
+		return fmt.Errorf("invalid redis index name %q", c.Index)
+	}
+	if c.Dimensions <= 0 || c.Dimensions > 32768 {
+		return fmt.Errorf("redis Dimensions must be between 1 and 32768, got %d", c.Dimensions)
+	}
+	if c.M < 2 || c.EfConstruction < c.M {
+		return fmt.Errorf("redis HNSW needs M >= 2 and EfConstruction >= M, got %d and %d", c.M, c.EfConstruction)
+	}
+	if c.TTL < 0 {
+		return fmt.Errorf("redis TTL cannot be negative, got %v", c.TTL)
+	}
+	return nil
+}
+
+// createIndexArgs returns the FT.CREATE command for the index.
+func (c RedisVectorConfig) createIndexArgs() []interface{} {
+	return []interface{}{
+		"FT.CREATE", c.Index, "ON", "HASH", "PREFIX", "1", c.Prefix,
+		"SCHEMA",
+		"namespace", "TAG",
+		"embedding", "VECTOR", "HNSW", "10",
+		"TYPE", "FLOAT32",
+		"DIM", strconv.Itoa(c.Dimensions),
+		"DISTANCE_METRIC", "COSINE",
+		"M", strconv.Itoa(c.M),
+		"EF_CONSTRUCTION", strconv.Itoa(c.EfConstruction),
+	}
+}
+
+// Add adds or updates the embedding for the given node ID, expiring it after the
+// configured TTL.
+func (s *RedisVectorStore) Add(ctx context.Context, id string, embedding []float32) error {
+	return s.AddWithTTL(ctx, id, embedding, s.cfg.TTL)
+}
+
+// AddWithTTL adds or updates the embedding for the given node ID, expiring it after ttl
+// in place of the configured TTL; 0 keeps it until deleted.
+func (s *RedisVectorStore) AddWithTTL(ctx context.Context, id string, embedding []float32, ttl time.Duration) error {
+	if len(embedding) != s.cfg.Dimensions {
+		return fmt.Errorf("embedding has %d dimensions, expected %d", len(embedding), s.cfg.Dimensions)
+	}
+	if ttl < 0 {
+		return fmt.Errorf("ttl cannot be negative, got %v", ttl)
+	}
+
+	key := s.key(id)
+	_, err := s.client.Do(ctx, "HSET", key,
+		"node_id", id,
+		"namespace", s.namespaceTag(),
+		"embedding", redisVectorBytes(embedding))
+	if err != nil {
+		return fmt.Errorf("failed to upsert vector: %w", err)
+	}
+	if err := s.expire(ctx, key, ttl); err != nil {
+		return fmt.Errorf("failed to set vector ttl: %w", err)
+	}
+	return nil
+}
+
+// Expire changes the TTL of the embedding for the given node ID; 0 keeps it until
+// deleted. Expiring a missing vector is not an error.
+func (s *RedisVectorStore) Expire(ctx context.Context, id string, ttl time.Duration) error {
+	if ttl < 0 {
+		return fmt.Errorf("ttl cannot be negative, got %v", ttl)
+	}
+	if err := s.expire(ctx, s.key(id), ttl); err != nil {
+		return fmt.Errorf("failed to set vector ttl: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisVectorStore) expire(ctx context.Context, key string, ttl time.Duration) error {
+	var err error
+	if ttl > 0 {
+		_, err = s.client.Do(ctx, "PEXPIRE", key, strconv.FormatInt(ttl.Milliseconds(), 10))
+	} else {
+		_, err = s.client.Do(ctx, "PERSIST", key)
+	}
+	return err
+}
+
+// Search finds the topK vectors in the store's namespace most similar to the query.
+func (s *RedisVectorStore) Search(ctx context.Context, query []float32, topK int) ([]SearchResult, error) {
+	if len(query) != s.cfg.Dimensions {
+		return nil, fmt.Errorf("query has %d dimensions, expected %d", len(query), s.cfg.Dimensions)
+	}
+	if topK <= 0 {
+		return []SearchResult{}, nil
+	}
+
+	k := strconv.Itoa(topK)
+	reply, err := s.client.Do(ctx, "FT.SEARCH", s.cfg.Index,
+		fmt.Sprintf("(@namespace:{%s})=>[KNN %s @embedding $vec AS score]", s.namespaceTag(), k),
+		"PARAMS", "2", "vec", redisVectorBytes(query),
+		"SORTBY", "score",
+		"RETURN", "2", "node_id", "score",
+		"LIMIT", "0", k,
+		"DIALECT", "2")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute vector search: %w", err)
+	}
+	results, err := parseRedisSearchReply(reply)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode search reply: %w", err)
+	}
+	return results, nil
+}
+
+// Delete removes the embedding for the given node ID. Deleting a missing vector is not an error.
+func (s *RedisVectorStore) Delete(ctx context.Context, id string) error {
+	if _, err := s.client.Do(ctx, "DEL", s.key(id)); err != nil {
+		return fmt.Errorf("failed to delete vector: %w", err)
+	}
+	return nil
+}
+
+// key returns the hash key of a node's vector. Both parts are escaped so neither can
+// contain the ':' separating them.
+func (s *RedisVectorStore) key(id string) string {
+	return s.cfg.Prefix + url.QueryEscape(s.namespace) + ":" + url.QueryEscape(id)
+}
+
+// namespaceTag encodes the namespace as a TAG value. Hex needs no escaping in queries,
+// and the leading "n" keeps the unnamed namespace from being an empty tag, which
+// RediSearch cannot match.
+func (s *RedisVectorStore) namespaceTag() string {
+	return "n" + hex.EncodeToString([]byte(s.namespace))
+}
+
+// redisVectorBytes encodes an embedding as RediSearch FLOAT32 vector bytes.
+func redisVectorBytes(embedding []float32) []byte {
+	buf := make([]byte, 4*len(embedding))
+	for i, v := range embedding {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(v))
+	}
+	return buf
+}
+
+// parseRedisSearchReply decodes an FT.SEARCH reply: the total, then a key and a
+// field/value array per document.
+func parseRedisSearchReply(reply interface{}) ([]SearchResult, error) {
+	items, ok := reply.([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("unexpected reply %T", reply)
+	}
+	results := make([]SearchResult, 0, len(items)/2)
+	for i := 2; i < len(items); i += 2 {
+		fields, ok := items[i].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected document fields %T", items[i])
+		}
+		var result SearchResult
+		for j := 0; j+1 < len(fields); j += 2 {
+			name, value := redisString(fields[j]), redisString(fields[j+1])
+			switch name {
+			case "node_id":
+				result.ID = value
+			case "score":
+				distance, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid score %q", value)
+				}
+				result.Score = 1 - distance
+			}
+		}
+		if result.ID == "" {
+			return nil, fmt.Errorf("document %v has no node_id", items[i-1])
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// redisString returns a bulk string reply as a string.
+func redisString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
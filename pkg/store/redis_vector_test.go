@@ -0,0 +1,241 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeRediSearch answers the subset of Redis and RediSearch commands used by
+// RedisVectorStore, with RESP2-shaped replies
+type fakeRediSearch struct {
+	indexes map[string][]interface{} // name -> FT.CREATE arguments
+	hashes  map[string]map[string]interface{}
+	ttls    map[string]time.Duration
+}
+
+func newFakeRediSearch() *fakeRediSearch {
+	return &fakeRediSearch{
+		indexes: map[string][]interface{}{},
+		hashes:  map[string]map[string]interface{}{},
+		ttls:    map[string]time.Duration{},
+	}
+}
+
+var fakeKNNQuery = regexp.MustCompile(`^\(@namespace:\{(\w+)\}\)=>\[KNN (\d+) @embedding \$vec AS score\]$`)
+
+func (f *fakeRediSearch) Do(ctx context.Context, args ...interface{}) (interface{}, error) {
+	switch args[0] {
+	case "FT.CREATE":
+		name := args[1].(string)
+		if _, ok := f.indexes[name]; ok {
+			return nil, errors.New("Index already exists")
+		}
+		f.indexes[name] = args
+		return "OK", nil
+	case "HSET":
+		key := args[1].(string)
+		hash := f.hashes[key]
+		if hash == nil {
+			hash = map[string]interface{}{}
+			f.hashes[key] = hash
+		}
+		for i := 2; i+1 < len(args); i += 2 {
+			hash[args[i].(string)] = args[i+1]
+		}
+		return int64(1), nil
+	case "PEXPIRE":
+		ms, _ := strconv.ParseInt(args[2].(string), 10, 64)
+		f.ttls[args[1].(string)] = time.Duration(ms) * time.Millisecond
+		return int64(1), nil
+	case "PERSIST":
+		delete(f.ttls, args[1].(string))
+		return int64(1), nil
+	case "DEL":
+		delete(f.hashes, args[1].(string))
+		delete(f.ttls, args[1].(string))
+		return int64(1), nil
+	case "FT.SEARCH":
+		m := fakeKNNQuery.FindStringSubmatch(args[2].(string))
+		if m == nil {
+			return nil, fmt.Errorf("Syntax error in query %q", args[2])
+		}
+		k, _ := strconv.Atoi(m[2])
+		query := fakeDecodeVector(args[6].([]byte))
+		type hit struct {
+			key      string
+			distance float64
+		}
+		var hits []hit
+		for key, hash := range f.hashes {
+			if hash["namespace"] == m[1] {
+				hits = append(hits, hit{key, 1 - CosineSimilarity(query, fakeDecodeVector(hash["embedding"].([]byte)))})
+			}
+		}
+		sort.Slice(hits, func(i, j int) bool { return hits[i].distance < hits[j].distance })
+		if len(hits) > k {
+			hits = hits[:k]
+		}
+		reply := []interface{}{int64(len(hits))}
+		for _, h := range hits {
+			reply = append(reply, h.key, []interface{}{
+				"node_id", f.hashes[h.key]["node_id"],
+				"score", strconv.FormatFloat(h.distance, 'g', -1, 64),
+			})
+		}
+		return reply, nil
+	}
+	return nil, fmt.Errorf("ERR unknown command %v", args[0])
+}
+
+func fakeDecodeVector(b []byte) []float32 {
+	v := make([]float32, len(b)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return v
+}
+
+func TestRedisVectorStore(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeRediSearch()
+	s, err := NewRedisVectorStore(ctx, fake, RedisVectorConfig{Dimensions: 3, TTL: time.Hour})
+	if err != nil {
+		t.Fatalf("NewRedisVectorStore failed: %v", err)
+	}
+	if _, ok := fake.indexes[DefaultRedisVectorIndex]; !ok {
+		t.Fatalf("expected the default index to be created, got %v", fake.indexes)
+	}
+	// Opening an existing index is not an error
+	if _, err := NewRedisVectorStore(ctx, fake, RedisVectorConfig{Dimensions: 3}); err != nil {
+		t.Fatalf("NewRedisVectorStore on an existing index failed: %v", err)
+	}
+
+	for id, vec := range map[string][]float32{"a": {1, 0, 0}, "b": {0.9, 0.1, 0}, "c": {0, 0, 1}} {
+		if err := s.Add(ctx, id, vec); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if err := s.Add(ctx, "c", []float32{0, 1, 0}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if len(fake.hashes) != 3 {
+		t.Errorf("expected 3 hashes after an update, got %d", len(fake.hashes))
+	}
+
+	results, err := s.Search(ctx, []float32{1, 0, 0}, 2)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 2 || results[0].ID != "a" || results[1].ID != "b" || math.Abs(results[0].Score-1) > 1e-6 {
+		t.Errorf("expected [a b] with a scoring 1, got %v", results)
+	}
+
+	if err := s.Delete(ctx, "a"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	results, _ = s.Search(ctx, []float32{1, 0, 0}, 1)
+	if len(results) != 1 || results[0].ID != "b" {
+		t.Errorf("expected b after deleting a, got %v", results)
+	}
+
+	if err := s.Add(ctx, "d", []float32{1, 0}); err == nil {
+		t.Error("expected an error for a vector of the wrong size")
+	}
+}
+
+func TestRedisVectorStore_TTL(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeRediSearch()
+	s, err := NewRedisVectorStore(ctx, fake, RedisVectorConfig{Dimensions: 2, TTL: 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("NewRedisVectorStore failed: %v", err)
+	}
+
+	if err := s.Add(ctx, "n1", []float32{1, 0}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := s.AddWithTTL(ctx, "n2", []float32{0, 1}, 0); err != nil {
+		t.Fatalf("AddWithTTL failed: %v", err)
+	}
+	if got := fake.ttls[s.key("n1")]; got != 24*time.Hour {
+		t.Errorf("expected the configured TTL of 24h, got %v", got)
+	}
+	if _, ok := fake.ttls[s.key("n2")]; ok {
+		t.Error("expected no TTL for a vector added with ttl 0")
+	}
+
+	// Expire extends the TTL; 0 removes the expiry
+	if err := s.Expire(ctx, "n1", 90*24*time.Hour); err != nil {
+		t.Fatalf("Expire failed: %v", err)
+	}
+	if got := fake.ttls[s.key("n1")]; got != 90*24*time.Hour {
+		t.Errorf("expected 90 days, got %v", got)
+	}
+	if err := s.Expire(ctx, "n1", 0); err != nil {
+		t.Fatalf("Expire failed: %v", err)
+	}
+	if _, ok := fake.ttls[s.key("n1")]; ok {
+		t.Error("expected the vector to have no TTL")
+	}
+}
+
+func TestRedisVectorStore_Namespaces(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeRediSearch()
+	a, err := NewRedisVectorStore(ctx, fake, RedisVectorConfig{Index: "shared", Dimensions: 2})
+	if err != nil {
+		t.Fatalf("NewRedisVectorStore failed: %v", err)
+	}
+	b, err := NewRedisVectorStore(ctx, fake, RedisVectorConfig{Index: "shared", Dimensions: 2})
+	if err != nil {
+		t.Fatalf("NewRedisVectorStore failed: %v", err)
+	}
+	b.WithNamespace("b:1")
+
+	if err := a.Add(ctx, "n1", []float32{1, 0}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := b.Add(ctx, "n1", []float32{0, 1}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	results, err := b.Search(ctx, []float32{1, 0}, 5)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "n1" || results[0].Score > 0.01 {
+		t.Errorf("expected only namespace b's vector, got %v", results)
+	}
+	results, _ = a.Search(ctx, []float32{1, 0}, 5)
+	if len(results) != 1 || results[0].Score < 0.99 {
+		t.Errorf("expected only the unnamed namespace's vector, got %v", results)
+	}
+}
+
+func TestNewRedisVectorStore_ValidatesConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  RedisVectorConfig
+	}{
+		{"missing dimensions", RedisVectorConfig{}},
+		{"index with spaces", RedisVectorConfig{Dimensions: 3, Index: "my index"}},
+		{"small ef_construction", RedisVectorConfig{Dimensions: 3, M: 32, EfConstruction: 16}},
+		{"negative ttl", RedisVectorConfig{Dimensions: 3, TTL: -time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Validation fails before Redis is touched
+			if _, err := NewRedisVectorStore(context.Background(), nil, tt.cfg); err == nil {
+				t.Error("expected a config error")
+			}
+		})
+	}
+}
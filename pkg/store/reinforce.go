@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ReinforceNodes credits each of the given nodes with strength accesses and resets its
+// last access to now, restarting its access-based decay. Unlike UpdateAccessTime this is
+// an explicit signal that the nodes were confirmed, not a read. Returns the number of
+// nodes reinforced; unknown IDs and nodes of other namespaces are skipped.
+func (s *SQLiteGraphStore) ReinforceNodes(ctx context.Context, nodeIDs []string, strength int) (int, error) {
+	if strength <= 0 {
+		return 0, Invalidf("reinforcement strength must be positive, got %d", strength)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	reinforced := 0
+	seen := make(map[string]bool, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if seen[nodeID] {
+			continue
+		}
+		seen[nodeID] = true
+		result, err := tx.ExecContext(ctx,
+			"UPDATE nodes SET access_count = COALESCE(access_count, 0) + ?, last_accessed_at = ? WHERE id = ? AND namespace = ?",
+			strength, now, nodeID, s.namespace)
+		if err != nil {
+			return 0, fmt.Errorf("failed to reinforce node %s: %w", nodeID, err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		reinforced += int(rows)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return reinforced, nil
+}
+
+// ReinforceMemories credits each of the given memories with strength accesses, resets
+// its last access to now and recomputes its access velocity, raising its decay score
+// (see MemoryScore) like strength reads would, without counting as reads. Returns the
+// number of memories reinforced; unknown and soft-deleted memories are skipped.
+func (s *SQLiteMemoryStore) ReinforceMemories(ctx context.Context, ids []string, strength int) (int, error) {
+	if strength <= 0 {
+		return 0, Invalidf("reinforcement strength must be positive, got %d", strength)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	reinforced := 0
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		var createdAt time.Time
+		err := tx.QueryRowContext(ctx, "SELECT created_at FROM memories WHERE id = ? AND namespace = ? AND status != ?",
+			id, s.namespace, StatusDeleted).Scan(&createdAt)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to get memory created_at for %s: %w", id, err)
+		}
+
+		// access_velocity = access_count / max(1, days_since_creation), as for reads
+		daysSinceCreation := max(now.Sub(createdAt).Hours()/24.0, 1)
+		_, err = tx.ExecContext(ctx, `
+			UPDATE memories
+			SET access_count = access_count + ?,
+			    last_accessed_at = ?,
+			    access_velocity = (access_count + ?) / ?
+			WHERE id = ? AND namespace = ?
+		`, strength, now, strength, daysSinceCreation, id, s.namespace)
+		if err != nil {
+			return 0, fmt.Errorf("failed to reinforce memory %s: %w", id, err)
+		}
+		reinforced++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return reinforced, nil
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReinforceMemories(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+
+	confirmed := &MemoryRecord{Topic: "Confirmed", Context: "Verified fact.", DocHash: "h1"}
+	deleted := &MemoryRecord{Topic: "Deleted", Context: "Gone.", DocHash: "h2"}
+	for _, mem := range []*MemoryRecord{confirmed, deleted} {
+		if err := memStore.AddMemory(ctx, mem); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+	}
+	if err := memStore.SoftDeleteMemory(ctx, deleted.ID); err != nil {
+		t.Fatalf("SoftDeleteMemory failed: %v", err)
+	}
+	weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+	if _, err := graphStore.DB().Exec("UPDATE memories SET created_at = ?, last_accessed_at = ? WHERE id = ?", weekAgo, weekAgo, confirmed.ID); err != nil {
+		t.Fatalf("Failed to age memory: %v", err)
+	}
+	before, err := memStore.ListMemories(ctx, ListMemoriesOptions{})
+	if err != nil || len(before) != 1 {
+		t.Fatalf("ListMemories failed: %v (%d memories)", err, len(before))
+	}
+
+	n, err := memStore.ReinforceMemories(ctx, []string{confirmed.ID, confirmed.ID, deleted.ID, "missing"}, 5)
+	if err != nil {
+		t.Fatalf("ReinforceMemories failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 memory reinforced, got %d", n)
+	}
+
+	after, err := memStore.ListMemories(ctx, ListMemoriesOptions{})
+	if err != nil {
+		t.Fatalf("ListMemories failed: %v", err)
+	}
+	if after[0].AccessCount != 5 {
+		t.Errorf("Expected 5 accesses credited, got %d", after[0].AccessCount)
+	}
+	if after[0].CurrentScore <= before[0].CurrentScore {
+		t.Errorf("Expected the score to rise from %v, got %v", before[0].CurrentScore, after[0].CurrentScore)
+	}
+
+	if _, err := memStore.ReinforceMemories(ctx, []string{confirmed.ID}, 0); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected a validation error for zero strength, got %v", err)
+	}
+}
+
+func TestReinforceNodes(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	if err := graphStore.AddNode(ctx, &Node{ID: "n1", Name: "Go", Type: "Technology"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+
+	n, err := graphStore.ReinforceNodes(ctx, []string{"n1", "missing"}, 3)
+	if err != nil {
+		t.Fatalf("ReinforceNodes failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 node reinforced, got %d", n)
+	}
+
+	var accessCount int
+	var lastAccessedAt *time.Time
+	if err := graphStore.DB().QueryRow("SELECT access_count, last_accessed_at FROM nodes WHERE id = 'n1'").Scan(&accessCount, &lastAccessedAt); err != nil {
+		t.Fatalf("Failed to read node: %v", err)
+	}
+	if accessCount != 3 || lastAccessedAt == nil {
+		t.Errorf("Expected 3 accesses and a last access time, got %d and %v", accessCount, lastAccessedAt)
+	}
+}
+
+func TestReinforceNodes_Namespaces(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reinforce.db")
+	a, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	defer a.Close()
+	a.WithNamespace("a")
+	b, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	defer b.Close()
+	b.WithNamespace("b")
+
+	ctx := context.Background()
+	if err := a.AddNode(ctx, &Node{ID: "a1", Name: "Kafka", Type: "Technology"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if n, err := b.ReinforceNodes(ctx, []string{"a1"}, 3); err != nil || n != 0 {
+		t.Errorf("Expected no node of another namespace reinforced, got %d (err %v)", n, err)
+	}
+	var accessCount int
+	if err := a.DB().QueryRow("SELECT COALESCE(access_count, 0) FROM nodes WHERE id = 'a1'").Scan(&accessCount); err != nil {
+		t.Fatalf("Failed to read node: %v", err)
+	}
+	if accessCount != 0 {
+		t.Errorf("Expected the node left untouched, got %d accesses", accessCount)
+	}
+	if n, err := a.ReinforceNodes(ctx, []string{"a1"}, 3); err != nil || n != 1 {
+		t.Errorf("Expected the namespace's own node reinforced, got %d (err %v)", n, err)
+	}
+}
@@ -0,0 +1,179 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RelationCount reports how many edges use a given relation label.
+type RelationCount struct {
+	Relation string
+	Count    int64
+}
+
+// RelationMerge is an audit record of one relation label being collapsed into another.
+type RelationMerge struct {
+	ID           string    `json:"id"`
+	FromRelation string    `json:"from_relation"` // Label that was replaced
+	ToRelation   string    `json:"to_relation"`   // Canonical label it was merged into
+	Method       string    `json:"method"`        // "synonym" or "embedding"
+	Similarity   float64   `json:"similarity"`    // Cosine similarity for embedding merges (1.0 for synonyms)
+	EdgesUpdated int64     `json:"edges_updated"` // Number of edges relabelled
+	MergedAt     time.Time `json:"merged_at"`
+}
+
+// migrateRelationMergeSchema creates the relation merge audit table.
+func (s *SQLiteGraphStore) migrateRelationMergeSchema() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS relation_merges (
+		id TEXT PRIMARY KEY,
+		from_relation TEXT NOT NULL,
+		to_relation TEXT NOT NULL,
+		method TEXT NOT NULL,
+		similarity REAL DEFAULT 1.0,
+		edges_updated INTEGER DEFAULT 0,
+		merged_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_relation_merges_from ON relation_merges(from_relation);
+	`
+
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create relation_merges table: %w", err)
+	}
+	return nil
+}
+
+// RelationCounts returns every distinct relation label with its edge count,
+// ordered by count descending, then label.
+func (s *SQLiteGraphStore) RelationCounts(ctx context.Context) ([]RelationCount, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT relation, COUNT(*) AS cnt
+		FROM edges
+		WHERE namespace = ?
+		GROUP BY relation
+		ORDER BY cnt DESC, relation
+	`, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count relations: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []RelationCount
+	for rows.Next() {
+		var rc RelationCount
+		if err := rows.Scan(&rc.Relation, &rc.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan relation count: %w", err)
+		}
+		counts = append(counts, rc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating relation counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// RenameRelation relabels every edge using the from relation to the to relation.
+// Derived edge IDs (see DerivedEdgeID) are re-keyed to the new relation, and an edge
+// that duplicates one already using the to relation between the same nodes is dropped,
+// with its memory and document provenance moved to the surviving edge. Returns the
+// number of edges relabelled or dropped, and which edges were dropped and which written.
+func (s *SQLiteGraphStore) RenameRelation(ctx context.Context, from, to string) (int64, EdgeChanges, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, source_id, relation, target_id, valid_from, valid_to FROM edges
+		WHERE relation = ? AND namespace = ?
+		ORDER BY id
+	`, from, s.namespace)
+	if err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("failed to query edges: %w", err)
+	}
+	var edges []Edge
+	for rows.Next() {
+		var e Edge
+		var validFrom, validTo sql.NullTime
+		if err := rows.Scan(&e.ID, &e.SourceID, &e.Relation, &e.TargetID, &validFrom, &validTo); err != nil {
+			rows.Close()
+			return 0, EdgeChanges{}, fmt.Errorf("failed to scan edge: %w", err)
+		}
+		e.ValidFrom, e.ValidTo = validityTimes(validFrom, validTo)
+		edges = append(edges, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("error iterating edges: %w", err)
+	}
+
+	var changes EdgeChanges
+	for _, old := range edges {
+		e := old
+		e.Relation = to
+		if err := rewriteEdge(ctx, tx, s.namespace, old, e, &changes); err != nil {
+			return 0, EdgeChanges{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, EdgeChanges{}, fmt.Errorf("failed to commit transaction: %w", classifyWriteError(err))
+	}
+	return int64(len(edges)), changes, nil
+}
+
+// RecordRelationMerge appends a merge to the relation_merges audit table.
+func (s *SQLiteGraphStore) RecordRelationMerge(ctx context.Context, merge *RelationMerge) error {
+	if merge.ID == "" {
+		merge.ID = uuid.New().String()
+	}
+	if merge.MergedAt.IsZero() {
+		merge.MergedAt = time.Now()
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO relation_merges (id, from_relation, to_relation, method, similarity, edges_updated, merged_at, namespace)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, merge.ID, merge.FromRelation, merge.ToRelation, merge.Method, merge.Similarity, merge.EdgesUpdated, merge.MergedAt, s.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to record relation merge: %w", err)
+	}
+	return nil
+}
+
+// ListRelationMerges returns the relation merge audit log, oldest first.
+func (s *SQLiteGraphStore) ListRelationMerges(ctx context.Context) ([]RelationMerge, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, from_relation, to_relation, method, similarity, edges_updated, merged_at
+		FROM relation_merges
+		WHERE namespace = ?
+		ORDER BY merged_at, id
+	`, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query relation merges: %w", err)
+	}
+	defer rows.Close()
+
+	var merges []RelationMerge
+	for rows.Next() {
+		var m RelationMerge
+		if err := rows.Scan(&m.ID, &m.FromRelation, &m.ToRelation, &m.Method, &m.Similarity, &m.EdgesUpdated, &m.MergedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan relation merge: %w", err)
+		}
+		merges = append(merges, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating relation merges: %w", err)
+	}
+
+	return merges, nil
+}
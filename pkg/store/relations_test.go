@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRelationCountsAndRename(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b", "c"} {
+		if err := store.AddNode(ctx, &Node{ID: id, Name: id}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	edges := []*Edge{
+		{ID: "e1", SourceID: "a", Relation: "USES", TargetID: "b"},
+		{ID: "e2", SourceID: "b", Relation: "USES", TargetID: "c"},
+		{ID: "e3", SourceID: "a", Relation: "UTILIZES", TargetID: "c"},
+	}
+	for _, e := range edges {
+		if err := store.AddEdge(ctx, e); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+
+	counts, err := store.RelationCounts(ctx)
+	if err != nil {
+		t.Fatalf("RelationCounts failed: %v", err)
+	}
+	if len(counts) != 2 || counts[0].Relation != "USES" || counts[0].Count != 2 || counts[1].Relation != "UTILIZES" {
+		t.Fatalf("Unexpected relation counts: %+v", counts)
+	}
+
+	updated, changes, err := store.RenameRelation(ctx, "UTILIZES", "USES")
+	if err != nil {
+		t.Fatalf("RenameRelation failed: %v", err)
+	}
+	if updated != 1 || len(changes.Written) != 1 || changes.Written[0] != "e3" {
+		t.Errorf("Expected edge e3 updated, got %d (%+v)", updated, changes)
+	}
+
+	edgesOfA, err := store.GetEdges(ctx, "a")
+	if err != nil {
+		t.Fatalf("GetEdges failed: %v", err)
+	}
+	for _, e := range edgesOfA {
+		if e.Relation != "USES" {
+			t.Errorf("Edge %s still has relation %s", e.ID, e.Relation)
+		}
+	}
+}
+
+func TestRenameRelation_RekeysDerivedEdgeIDs(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b", "c"} {
+		if err := store.AddNode(ctx, &Node{ID: id, Name: id}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	for _, e := range []*Edge{
+		{ID: DerivedEdgeID("a", "USES", "c", nil, nil), SourceID: "a", Relation: "USES", TargetID: "c"},
+		{ID: DerivedEdgeID("a", "UTILIZES", "c", nil, nil), SourceID: "a", Relation: "UTILIZES", TargetID: "c"},
+		{ID: DerivedEdgeID("b", "UTILIZES", "c", nil, nil), SourceID: "b", Relation: "UTILIZES", TargetID: "c"},
+	} {
+		if err := store.AddEdge(ctx, e); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+
+	updated, changes, err := store.RenameRelation(ctx, "UTILIZES", "USES")
+	if err != nil {
+		t.Fatalf("RenameRelation failed: %v", err)
+	}
+	if updated != 2 || len(changes.Removed) != 2 || len(changes.Written) != 1 || changes.Written[0] != "b-USES-c" {
+		t.Errorf("expected both edges replaced and b-USES-c written, got %d (%+v)", updated, changes)
+	}
+
+	// The duplicate a-UTILIZES-c collapses into a-USES-c instead of running parallel to it
+	for node, want := range map[string]string{"a": "a-USES-c", "b": "b-USES-c"} {
+		edges, err := store.GetEdges(ctx, node)
+		if err != nil {
+			t.Fatalf("GetEdges failed: %v", err)
+		}
+		if len(edges) != 1 || edges[0].ID != want {
+			t.Errorf("expected %s to have only %s, got %+v", node, want, edges)
+		}
+	}
+}
+
+func TestRelationMergeAuditRoundTrip(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	merge := &RelationMerge{FromRelation: "USING", ToRelation: "USES", Method: "embedding", Similarity: 0.93, EdgesUpdated: 4}
+	if err := store.RecordRelationMerge(ctx, merge); err != nil {
+		t.Fatalf("RecordRelationMerge failed: %v", err)
+	}
+	if merge.ID == "" {
+		t.Error("Expected merge ID to be generated")
+	}
+
+	merges, err := store.ListRelationMerges(ctx)
+	if err != nil {
+		t.Fatalf("ListRelationMerges failed: %v", err)
+	}
+	if len(merges) != 1 {
+		t.Fatalf("Expected 1 merge, got %d", len(merges))
+	}
+	got := merges[0]
+	if got.FromRelation != "USING" || got.ToRelation != "USES" || got.Method != "embedding" || got.EdgesUpdated != 4 {
+		t.Errorf("Unexpected merge record: %+v", got)
+	}
+}
@@ -0,0 +1,183 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrRevisionNotFound indicates that a memory has no revision with the requested version.
+var ErrRevisionNotFound = errors.New("memory revision not found")
+
+// MemoryRevision is the content of a memory at one version.
+type MemoryRevision struct {
+	MemoryID  string    `json:"memory_id"`
+	Version   int       `json:"version"`
+	Topic     string    `json:"topic"`
+	Context   string    `json:"context"`
+	Decisions []string  `json:"decisions,omitempty"`
+	Rationale []string  `json:"rationale,omitempty"`
+	Status    string    `json:"status"`
+	DocHash   string    `json:"doc_hash"`
+	CreatedAt time.Time `json:"created_at"`           // When this version was written
+	RevisedAt time.Time `json:"revised_at,omitempty"` // When it was replaced (zero for the current version)
+}
+
+// migrateMemoryRevisionSchema creates the memory edit history table.
+func (s *SQLiteGraphStore) migrateMemoryRevisionSchema() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS memory_revisions (
+		memory_id TEXT NOT NULL,
+		version INTEGER NOT NULL,
+		topic TEXT NOT NULL,
+		context TEXT NOT NULL,
+		decisions_json TEXT,
+		rationale_json TEXT,
+		status TEXT,
+		doc_hash TEXT,
+		created_at DATETIME,
+		revised_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (memory_id, version),
+		FOREIGN KEY (memory_id) REFERENCES memories(id) ON DELETE CASCADE
+	);
+	`
+
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create memory_revisions table: %w", err)
+	}
+	return nil
+}
+
+// recordRevision saves the version of a memory about to be replaced within tx.
+func (s *SQLiteMemoryStore) recordRevision(ctx context.Context, tx *sql.Tx, previous *MemoryRecord, revisedAt time.Time) error {
+	decisionsJSON, err := json.Marshal(previous.Decisions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal decisions: %w", err)
+	}
+	storedContext, decisionsJSON, err := s.sealContent(previous.Context, decisionsJSON)
+	if err != nil {
+		return err
+	}
+	rationaleJSON, err := json.Marshal(previous.Rationale)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rationale: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT OR REPLACE INTO memory_revisions
+			(memory_id, version, topic, context, decisions_json, rationale_json, status, doc_hash, created_at, revised_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, previous.ID, previous.Version, previous.Topic, storedContext, decisionsJSON, rationaleJSON,
+		previous.Status, previous.DocHash, previous.UpdatedAt, revisedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record memory revision: %w", err)
+	}
+	return nil
+}
+
+// GetMemoryHistory returns every version of a memory, oldest first. The last entry is the
+// current version. Versions written before revision tracking existed are missing.
+func (s *SQLiteMemoryStore) GetMemoryHistory(ctx context.Context, id string) ([]MemoryRevision, error) {
+	current, err := s.GetMemory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT version, topic, context, decisions_json, rationale_json, COALESCE(status, ''), COALESCE(doc_hash, ''), created_at, revised_at
+		FROM memory_revisions
+		WHERE memory_id = ?
+		ORDER BY version
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query memory revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var history []MemoryRevision
+	for rows.Next() {
+		revision, err := s.scanRevision(rows, id)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, *revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating memory revisions: %w", err)
+	}
+
+	return append(history, currentRevision(current)), nil
+}
+
+// GetMemoryAtVersion returns the content of a memory at the given version.
+// Returns ErrMemoryNotFound if the memory does not exist and ErrRevisionNotFound if
+// the version was never recorded.
+func (s *SQLiteMemoryStore) GetMemoryAtVersion(ctx context.Context, id string, version int) (*MemoryRevision, error) {
+	current, err := s.GetMemory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version == current.Version {
+		revision := currentRevision(current)
+		return &revision, nil
+	}
+
+	row := s.db.QueryRowContext(ctx, `
+		SELECT version, topic, context, decisions_json, rationale_json, COALESCE(status, ''), COALESCE(doc_hash, ''), created_at, revised_at
+		FROM memory_revisions
+		WHERE memory_id = ? AND version = ?
+	`, id, version)
+	revision, err := s.scanRevision(row, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("memory %s version %d: %w", id, version, ErrRevisionNotFound)
+	}
+	return revision, err
+}
+
+// scanRevision reads one memory_revisions row
+func (s *SQLiteMemoryStore) scanRevision(row interface{ Scan(...any) error }, memoryID string) (*MemoryRevision, error) {
+	revision := &MemoryRevision{MemoryID: memoryID}
+	var decisionsJSON, rationaleJSON []byte
+	var createdAt sql.NullTime
+	if err := row.Scan(&revision.Version, &revision.Topic, &revision.Context, &decisionsJSON, &rationaleJSON,
+		&revision.Status, &revision.DocHash, &createdAt, &revision.RevisedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan memory revision: %w", err)
+	}
+	revision.CreatedAt = createdAt.Time
+	if err := s.openContent(&revision.Context, &decisionsJSON); err != nil {
+		return nil, err
+	}
+
+	if len(decisionsJSON) > 0 {
+		if err := json.Unmarshal(decisionsJSON, &revision.Decisions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal decisions: %w", err)
+		}
+	}
+	if len(rationaleJSON) > 0 {
+		if err := json.Unmarshal(rationaleJSON, &revision.Rationale); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rationale: %w", err)
+		}
+	}
+	return revision, nil
+}
+
+// currentRevision describes the current version of a memory as a revision
+func currentRevision(memory *MemoryRecord) MemoryRevision {
+	return MemoryRevision{
+		MemoryID:  memory.ID,
+		Version:   memory.Version,
+		Topic:     memory.Topic,
+		Context:   memory.Context,
+		Decisions: memory.Decisions,
+		Rationale: memory.Rationale,
+		Status:    memory.Status,
+		DocHash:   memory.DocHash,
+		CreatedAt: memory.UpdatedAt,
+	}
+}
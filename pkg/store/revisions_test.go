@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMemoryRevisions_History(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+
+	mem := &MemoryRecord{
+		Topic:     "Database choice",
+		Context:   "We use SQLite.",
+		Decisions: []string{"Use SQLite"},
+		DocHash:   "hash-v1",
+	}
+	if err := memStore.AddMemory(ctx, mem); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	context2 := "We moved to Postgres."
+	decisions2 := []string{"Use Postgres"}
+	if err := memStore.UpdateMemory(ctx, mem.ID, MemoryUpdate{Context: &context2, Decisions: &decisions2}); err != nil {
+		t.Fatalf("UpdateMemory failed: %v", err)
+	}
+	status := "Active"
+	if err := memStore.UpdateMemory(ctx, mem.ID, MemoryUpdate{Status: &status}); err != nil {
+		t.Fatalf("UpdateMemory failed: %v", err)
+	}
+
+	history, err := memStore.GetMemoryHistory(ctx, mem.ID)
+	if err != nil {
+		t.Fatalf("GetMemoryHistory failed: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("Expected 3 versions, got %d: %+v", len(history), history)
+	}
+	for i, revision := range history {
+		if revision.Version != i+1 {
+			t.Errorf("history[%d].Version = %d, want %d", i, revision.Version, i+1)
+		}
+	}
+	if history[0].Context != "We use SQLite." || len(history[0].Decisions) != 1 || history[0].Decisions[0] != "Use SQLite" {
+		t.Errorf("Unexpected first version: %+v", history[0])
+	}
+	if history[0].RevisedAt.IsZero() {
+		t.Error("Expected replaced version to record when it was revised")
+	}
+	if history[2].Status != "Active" || history[2].Context != context2 || !history[2].RevisedAt.IsZero() {
+		t.Errorf("Unexpected current version: %+v", history[2])
+	}
+
+	v1, err := memStore.GetMemoryAtVersion(ctx, mem.ID, 1)
+	if err != nil {
+		t.Fatalf("GetMemoryAtVersion failed: %v", err)
+	}
+	if v1.Context != "We use SQLite." || v1.DocHash != "hash-v1" {
+		t.Errorf("Unexpected version 1: %+v", v1)
+	}
+	current, err := memStore.GetMemoryAtVersion(ctx, mem.ID, 3)
+	if err != nil {
+		t.Fatalf("GetMemoryAtVersion failed: %v", err)
+	}
+	if current.Status != "Active" {
+		t.Errorf("Expected current version to be Active, got %+v", current)
+	}
+
+	if _, err := memStore.GetMemoryAtVersion(ctx, mem.ID, 7); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("Expected ErrRevisionNotFound, got %v", err)
+	}
+	if _, err := memStore.GetMemoryHistory(ctx, "missing"); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected ErrMemoryNotFound, got %v", err)
+	}
+
+	// Revisions are removed with their memory
+	if err := memStore.DeleteMemory(ctx, mem.ID); err != nil {
+		t.Fatalf("DeleteMemory failed: %v", err)
+	}
+	var count int
+	if err := graphStore.DB().QueryRow("SELECT COUNT(*) FROM memory_revisions WHERE memory_id = ?", mem.ID).Scan(&count); err != nil {
+		t.Fatalf("count query failed: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected revisions to be deleted with the memory, got %d", count)
+	}
+}
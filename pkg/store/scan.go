@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// DefaultNodeScanBatchSize is the number of nodes ScanNodes reads per query by default
+const DefaultNodeScanBatchSize = 1000
+
+// NodeScanOptions tunes ScanNodes.
+type NodeScanOptions struct {
+	// BatchSize is the number of nodes read per query (default: DefaultNodeScanBatchSize).
+	BatchSize int
+
+	// SkipEmbeddings leaves Node.Embedding nil, for passes that only need the node's
+	// fields; embeddings are most of a node's size.
+	SkipEmbeddings bool
+}
+
+// ScanNodes streams every node of the namespace to fn, ordered by id. Unlike IterateNodes,
+// nodes are read in batches keyed on the last id seen, and no query is open while fn runs,
+// so fn may write to the store, including deleting the node it was given. Nodes added
+// during the scan are seen only if their id sorts after the current batch. Scanning stops
+// at the first error returned by fn, or when ctx is done.
+func (s *SQLiteGraphStore) ScanNodes(ctx context.Context, opts NodeScanOptions, fn func(*Node) error) error {
+	start := time.Now()
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultNodeScanBatchSize
+	}
+	columns := nodeColumns
+	if opts.SkipEmbeddings {
+		columns = nodeColumnsWithoutEmbedding
+	}
+	query := `SELECT ` + columns + ` FROM nodes WHERE namespace = ? AND id > ? ORDER BY id LIMIT ?`
+
+	scanned := 0
+	after := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch, err := s.nodeBatch(ctx, query, after, batchSize)
+		if err != nil {
+			return err
+		}
+		for _, node := range batch {
+			if err := fn(node); err != nil {
+				return err
+			}
+		}
+		scanned += len(batch)
+		if len(batch) < batchSize {
+			break
+		}
+		after = batch[len(batch)-1].ID
+	}
+
+	s.logQuery(ctx, "scan_nodes", start, slog.Int("nodes", scanned))
+	return nil
+}
+
+// nodeBatch reads the nodes selected by query, a keyset page of nodes after the given id
+func (s *SQLiteGraphStore) nodeBatch(ctx context.Context, query, after string, limit int) ([]*Node, error) {
+	rows, err := s.db.QueryContext(ctx, query, s.namespace, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nodes: %w", err)
+	}
+	defer rows.Close()
+
+	batch := make([]*Node, 0, limit)
+	for rows.Next() {
+		node, err := s.scanNode(rows)
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating nodes: %w", err)
+	}
+	return batch, nil
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestScanNodes_BatchesAndSkipsEmbeddings(t *testing.T) {
+	ctx := context.Background()
+	graphStore := setupTestStore(t)
+	defer graphStore.Close()
+
+	nodes := make([]*Node, 25)
+	for i := range nodes {
+		nodes[i] = &Node{ID: fmt.Sprintf("n%02d", i), Name: fmt.Sprintf("Node %d", i), Type: "Concept", Embedding: []float32{float32(i), 1}}
+	}
+	if err := graphStore.AddNodes(ctx, nodes); err != nil {
+		t.Fatalf("AddNodes failed: %v", err)
+	}
+
+	var ids []string
+	err := graphStore.ScanNodes(ctx, NodeScanOptions{BatchSize: 10, SkipEmbeddings: true}, func(node *Node) error {
+		if node.Embedding != nil {
+			t.Errorf("expected no embedding for %s", node.ID)
+		}
+		ids = append(ids, node.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanNodes failed: %v", err)
+	}
+	if len(ids) != len(nodes) || ids[0] != "n00" || ids[len(ids)-1] != "n24" {
+		t.Errorf("expected all nodes ordered by id, got %v", ids)
+	}
+
+	var embedded int
+	err = graphStore.ScanNodes(ctx, NodeScanOptions{}, func(node *Node) error {
+		if len(node.Embedding) == 2 {
+			embedded++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanNodes failed: %v", err)
+	}
+	if embedded != len(nodes) {
+		t.Errorf("expected %d nodes with embeddings, got %d", len(nodes), embedded)
+	}
+}
+
+func TestScanNodes_CallbackMayDelete(t *testing.T) {
+	ctx := context.Background()
+	graphStore := setupTestStore(t)
+	defer graphStore.Close()
+	for i := 0; i < 7; i++ {
+		if err := graphStore.AddNode(ctx, &Node{ID: fmt.Sprintf("n%d", i), Name: fmt.Sprintf("Node %d", i)}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+
+	scanned := 0
+	err := graphStore.ScanNodes(ctx, NodeScanOptions{BatchSize: 3}, func(node *Node) error {
+		scanned++
+		return graphStore.DeleteNode(ctx, node.ID)
+	})
+	if err != nil {
+		t.Fatalf("ScanNodes failed: %v", err)
+	}
+	if scanned != 7 {
+		t.Errorf("expected 7 nodes scanned, got %d", scanned)
+	}
+	if count, _ := graphStore.NodeCount(ctx); count != 0 {
+		t.Errorf("expected every node deleted, got %d left", count)
+	}
+
+	stop := errors.New("stop")
+	if err := graphStore.AddNode(ctx, &Node{ID: "x", Name: "X"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if err := graphStore.ScanNodes(ctx, NodeScanOptions{}, func(*Node) error { return stop }); !errors.Is(err, stop) {
+		t.Errorf("expected the callback's error, got %v", err)
+	}
+}
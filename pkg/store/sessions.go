@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSessionClosed indicates that a memory was added to a session that is not open.
+var ErrSessionClosed = errors.New("session is closed")
+
+// SessionMetadataKey is the memory metadata key holding the ID of the session a memory
+// was added in.
+const SessionMetadataKey = "session_id"
+
+// MemorySession is an open session: a workspace whose memories are purged when it is
+// closed or expires.
+type MemorySession struct {
+	ID        string     `json:"id"`
+	OpenedAt  time.Time  `json:"opened_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Nil when the session never expires
+}
+
+// migrateSessionSchema adds the memory_sessions table.
+func (s *SQLiteGraphStore) migrateSessionSchema() error {
+	if _, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS memory_sessions (
+			id TEXT NOT NULL,
+			namespace TEXT NOT NULL DEFAULT '',
+			opened_at DATETIME NOT NULL,
+			expires_at DATETIME,
+			PRIMARY KEY (namespace, id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_memory_sessions_expires_at ON memory_sessions(expires_at);
+	`); err != nil {
+		return fmt.Errorf("failed to create memory_sessions table: %w", err)
+	}
+	return createMetadataIndex(context.Background(), s.db, SessionMetadataKey)
+}
+
+// revertSessionSchema drops the memory_sessions table and the session metadata index.
+func (s *SQLiteGraphStore) revertSessionSchema() error {
+	if _, err := s.db.Exec("DROP INDEX IF EXISTS " + metadataIndexName(SessionMetadataKey)); err != nil {
+		return fmt.Errorf("failed to drop session metadata index: %w", err)
+	}
+	return dropTables("memory_sessions")(s)
+}
+
+// OpenSession opens a session, or reopens it with a new expiry. A nil expiresAt keeps
+// the session open until closed.
+func (s *SQLiteMemoryStore) OpenSession(ctx context.Context, id string, expiresAt *time.Time) (*MemorySession, error) {
+	if id == "" {
+		return nil, Invalidf("session ID cannot be empty")
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO memory_sessions (id, namespace, opened_at, expires_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (namespace, id) DO UPDATE SET expires_at = excluded.expires_at
+	`, id, s.namespace, time.Now(), expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session: %w", classifyWriteError(err))
+	}
+	return s.GetSession(ctx, id)
+}
+
+// GetSession returns an open session, or nil if no session with id is open.
+func (s *SQLiteMemoryStore) GetSession(ctx context.Context, id string) (*MemorySession, error) {
+	var session MemorySession
+	var expiresAt sql.NullTime
+	err := s.db.QueryRowContext(ctx,
+		"SELECT id, opened_at, expires_at FROM memory_sessions WHERE id = ? AND namespace = ?",
+		id, s.namespace).Scan(&session.ID, &session.OpenedAt, &expiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if expiresAt.Valid {
+		session.ExpiresAt = &expiresAt.Time
+	}
+	return &session, nil
+}
+
+// CloseSession forgets a session. Its memories are left to the caller to purge (see
+// SessionMemoryIDs). Closing a session that is not open is a no-op.
+func (s *SQLiteMemoryStore) CloseSession(ctx context.Context, id string) error {
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM memory_sessions WHERE id = ? AND namespace = ?", id, s.namespace); err != nil {
+		return fmt.Errorf("failed to close session: %w", err)
+	}
+	return nil
+}
+
+// SessionMemoryIDs returns the IDs of the memories added in a session, including
+// soft-deleted and archived ones.
+func (s *SQLiteMemoryStore) SessionMemoryIDs(ctx context.Context, id string) ([]string, error) {
+	clause, args, err := metadataFilterClause(map[string]string{SessionMetadataKey: id})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx, "SELECT id FROM memories WHERE namespace = ?"+clause+" ORDER BY created_at",
+		append([]interface{}{s.namespace}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query session memories: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var memoryID string
+		if err := rows.Scan(&memoryID); err != nil {
+			return nil, fmt.Errorf("failed to scan memory ID: %w", err)
+		}
+		ids = append(ids, memoryID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating session memories: %w", err)
+	}
+	return ids, nil
+}
+
+// ExpiredSessions returns the IDs of the sessions that expired before now.
+func (s *SQLiteMemoryStore) ExpiredSessions(ctx context.Context, now time.Time) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id FROM memory_sessions
+		WHERE namespace = ? AND expires_at IS NOT NULL AND expires_at < ?
+		ORDER BY expires_at
+	`, s.namespace, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan session ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating expired sessions: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemorySessions(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+
+	past := time.Now().Add(-time.Minute)
+	if _, err := memStore.OpenSession(ctx, "expired", &past); err != nil {
+		t.Fatalf("OpenSession failed: %v", err)
+	}
+	session, err := memStore.OpenSession(ctx, "open", nil)
+	if err != nil {
+		t.Fatalf("OpenSession failed: %v", err)
+	}
+	if session.ID != "open" || session.ExpiresAt != nil || session.OpenedAt.IsZero() {
+		t.Errorf("Unexpected session %+v", session)
+	}
+
+	inSession := &MemoryRecord{Topic: "Scratch", Context: "Draft.", DocHash: "h1", Metadata: map[string]interface{}{SessionMetadataKey: "open"}}
+	other := &MemoryRecord{Topic: "Kept", Context: "Long-term.", DocHash: "h2"}
+	for _, mem := range []*MemoryRecord{inSession, other} {
+		if err := memStore.AddMemory(ctx, mem); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+	}
+
+	ids, err := memStore.SessionMemoryIDs(ctx, "open")
+	if err != nil || len(ids) != 1 || ids[0] != inSession.ID {
+		t.Errorf("Expected the session's memory, got %v (err %v)", ids, err)
+	}
+
+	expired, err := memStore.ExpiredSessions(ctx, time.Now())
+	if err != nil || len(expired) != 1 || expired[0] != "expired" {
+		t.Errorf("Expected only the expired session, got %v (err %v)", expired, err)
+	}
+
+	if err := memStore.CloseSession(ctx, "open"); err != nil {
+		t.Fatalf("CloseSession failed: %v", err)
+	}
+	if closed, err := memStore.GetSession(ctx, "open"); err != nil || closed != nil {
+		t.Errorf("Expected the session closed, got %+v (err %v)", closed, err)
+	}
+	if _, err := memStore.OpenSession(ctx, "", nil); err == nil {
+		t.Error("Expected an error for an empty session ID")
+	}
+}
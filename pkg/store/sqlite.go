@@ -6,39 +6,70 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"math"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	_ "github.com/mattn/go-sqlite3" // SQLite driver
+	sqlite3 "github.com/mattn/go-sqlite3"
 )
 
 // SQLiteGraphStore implements GraphStore using SQLite as the backend.
 type SQLiteGraphStore struct {
-	db *sql.DB
+	db        *sql.DB
+	namespace string       // Scopes all reads and writes (see WithNamespace)
+	stmts     stmtCache    // Prepared hot-path statements
+	cipher    *FieldCipher // Encrypts node descriptions (see WithEncryption)
+	access    accessBuffer // Reads by GetNode, written in batches (see SetAccessFlush)
+	queryLogger
+}
+
+// sqliteDriverName is the database/sql driver SQLite stores open databases with:
+// go-sqlite3 with the functions in sqlFunctions registered on every connection.
+const sqliteDriverName = "sqlite3_gognee"
+
+// sqlFunctions are the Go functions queries can call, by SQL name.
+var sqlFunctions = map[string]interface{}{
+	"memory_score": sqlMemoryScore, // see DecayPolicy.memoryScoreSQL
+	"keyword_bm25": sqlKeywordBM25, // see SearchKeyword
+	"fts_length":   sqlFTSLength,   // see keywordCorpusStats
+}
+
+func init() {
+	sql.Register(sqliteDriverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			for name, fn := range sqlFunctions {
+				if err := conn.RegisterFunc(name, fn, true); err != nil {
+					return fmt.Errorf("failed to register SQL function %s: %w", name, err)
+				}
+			}
+			return nil
+		},
+	})
 }
 
 // NewSQLiteGraphStore creates a new SQLite-backed graph store.
-// The dbPath can be a file path or ":memory:" for an in-memory database.
+// The dbPath can be a file path, a file: URI or ":memory:" for an in-memory database;
+// see ResolveDBPath for how it is interpreted. Connections are opened with foreign keys,
+// a busy timeout and (for file databases) WAL journaling, unless dbPath sets those options.
 // Creates tables and indexes if they don't exist.
 func NewSQLiteGraphStore(dbPath string) (*SQLiteGraphStore, error) {
 	// Initialize sqlite-vec for all future connections
 	EnableSQLiteVec()
 
-	db, err := sql.Open("sqlite3", dbPath)
+	dsn, err := ResolveDBPath(dbPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+		return nil, err
 	}
 
-	// Enable foreign key constraints (required for CASCADE)
-	_, err = db.Exec("PRAGMA foreign_keys = ON")
+	db, err := sql.Open(sqliteDriverName, withConnectionPragmas(dsn))
 	if err != nil {
-		db.Close()
-		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
 	store := &SQLiteGraphStore{db: db}
+	store.access.inline = IsInMemoryDSN(dsn)
 	if err := store.initSchema(); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("failed to initialize schema: %w", err)
@@ -47,9 +78,9 @@ func NewSQLiteGraphStore(dbPath string) (*SQLiteGraphStore, error) {
 	return store, nil
 }
 
-// initSchema creates the database schema if it doesn't exist.
-// Also performs schema migrations for new columns.
-func (s *SQLiteGraphStore) initSchema() error {
+// createBaseSchema creates the graph, processed document and vector tables (schema
+// migration 1).
+func (s *SQLiteGraphStore) createBaseSchema() error {
 	schema := `
 	CREATE TABLE IF NOT EXISTS nodes (
 		id TEXT PRIMARY KEY,
@@ -99,40 +130,54 @@ func (s *SQLiteGraphStore) initSchema() error {
 	);
 
 	CREATE INDEX IF NOT EXISTS idx_vec_node_ids_node_id ON vec_node_ids(node_id);
-	`
 
-	_, err := s.db.Exec(schema)
-	if err != nil {
-		return err
-	}
+	-- vec0 virtual table for edge embeddings (relationship text such as "Alice USES Go")
+	CREATE VIRTUAL TABLE IF NOT EXISTS vec_edges USING vec0(
+		embedding float[1536]
+	);
 
-	// Run schema migrations for new columns
-	return s.migrateSchema()
-}
+	-- ID mapping table: correlates vec_edges.rowid with edges.id
+	CREATE TABLE IF NOT EXISTS vec_edge_ids (
+		rowid INTEGER PRIMARY KEY,
+		edge_id TEXT NOT NULL UNIQUE,
+		FOREIGN KEY (edge_id) REFERENCES edges(id) ON DELETE CASCADE
+	);
+	`
 
-// migrateSchema adds new columns to existing tables if they don't exist.
-func (s *SQLiteGraphStore) migrateSchema() error {
-	// Check and add last_accessed_at column
-	if !s.columnExists("nodes", "last_accessed_at") {
-		_, err := s.db.Exec("ALTER TABLE nodes ADD COLUMN last_accessed_at DATETIME DEFAULT NULL")
-		if err != nil {
-			return fmt.Errorf("failed to add last_accessed_at column: %w", err)
-		}
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create base schema: %w", err)
 	}
+	return nil
+}
 
-	// Check and add access_count column
-	if !s.columnExists("nodes", "access_count") {
-		_, err := s.db.Exec("ALTER TABLE nodes ADD COLUMN access_count INTEGER DEFAULT 0")
-		if err != nil {
-			return fmt.Errorf("failed to add access_count column: %w", err)
-		}
-	}
+// migrateNodeColumns adds the access tracking, mention count and pinning columns of
+// nodes (schema migration 2).
+func (s *SQLiteGraphStore) migrateNodeColumns() error {
+	return s.addColumns("nodes", [][2]string{
+		{"last_accessed_at", "DATETIME DEFAULT NULL"},
+		{"access_count", "INTEGER DEFAULT 0"},
+		{"mention_count", "INTEGER NOT NULL DEFAULT 0"},
+		{"pinned", "BOOLEAN NOT NULL DEFAULT FALSE"},
+		{"pinned_at", "DATETIME DEFAULT NULL"},
+		{"pin_reason", "TEXT DEFAULT NULL"},
+	})
+}
 
-	// Phase 2: Add memory CRUD tables (v1.0.0)
-	if err := s.migrateMemoryTables(); err != nil {
+// migrateEdgeColumns adds the expiry, provenance (originating chunk and supporting text)
+// and temporal validity columns of edges (schema migration 3).
+func (s *SQLiteGraphStore) migrateEdgeColumns() error {
+	if err := s.addColumns("edges", [][2]string{
+		{"expires_at", "DATETIME DEFAULT NULL"},
+		{"source_chunk_id", "TEXT DEFAULT NULL"},
+		{"evidence", "TEXT DEFAULT NULL"},
+		{"valid_from", "DATETIME DEFAULT NULL"},
+		{"valid_to", "DATETIME DEFAULT NULL"},
+	}); err != nil {
 		return err
 	}
-
+	if _, err := s.db.Exec("CREATE INDEX IF NOT EXISTS idx_edges_expires_at ON edges(expires_at)"); err != nil {
+		return fmt.Errorf("failed to create expires_at index: %w", err)
+	}
 	return nil
 }
 
@@ -215,28 +260,12 @@ func (s *SQLiteGraphStore) migrateMemoryTables() error {
 
 // migrateMemoryAccessTracking adds access tracking columns for v1.1.0.
 func (s *SQLiteGraphStore) migrateMemoryAccessTracking() error {
-	// Check if access_count column exists
-	if !s.columnExists("memories", "access_count") {
-		_, err := s.db.Exec("ALTER TABLE memories ADD COLUMN access_count INTEGER DEFAULT 0")
-		if err != nil {
-			return fmt.Errorf("failed to add access_count column: %w", err)
-		}
-	}
-
-	// Check if last_accessed_at column exists
-	if !s.columnExists("memories", "last_accessed_at") {
-		_, err := s.db.Exec("ALTER TABLE memories ADD COLUMN last_accessed_at DATETIME")
-		if err != nil {
-			return fmt.Errorf("failed to add last_accessed_at column: %w", err)
-		}
-	}
-
-	// Check if access_velocity column exists
-	if !s.columnExists("memories", "access_velocity") {
-		_, err := s.db.Exec("ALTER TABLE memories ADD COLUMN access_velocity REAL DEFAULT 0.0")
-		if err != nil {
-			return fmt.Errorf("failed to add access_velocity column: %w", err)
-		}
+	if err := s.addColumns("memories", [][2]string{
+		{"access_count", "INTEGER DEFAULT 0"},
+		{"last_accessed_at", "DATETIME"},
+		{"access_velocity", "REAL DEFAULT 0.0"},
+	}); err != nil {
+		return err
 	}
 
 	// Create index on last_accessed_at
@@ -280,12 +309,9 @@ func (s *SQLiteGraphStore) migrateSupersessionSchema() error {
 		}
 	}
 
-	// Add superseded_by column to memories table if it doesn't exist
-	if !s.columnExists("memories", "superseded_by") {
-		_, err := s.db.Exec("ALTER TABLE memories ADD COLUMN superseded_by TEXT")
-		if err != nil {
-			return fmt.Errorf("failed to add superseded_by column: %w", err)
-		}
+	// Add superseded_by column to memories table
+	if err := s.addColumn("memories", "superseded_by", "TEXT"); err != nil {
+		return err
 	}
 
 	// Create index on superseded_by
@@ -299,44 +325,15 @@ func (s *SQLiteGraphStore) migrateSupersessionSchema() error {
 
 // migrateRetentionPolicySchema adds retention policy columns for v1.1.0 (M6: Plan 021).
 func (s *SQLiteGraphStore) migrateRetentionPolicySchema() error {
-	// Add retention_policy column if it doesn't exist
-	if !s.columnExists("memories", "retention_policy") {
-		_, err := s.db.Exec("ALTER TABLE memories ADD COLUMN retention_policy TEXT DEFAULT 'standard'")
-		if err != nil {
-			return fmt.Errorf("failed to add retention_policy column: %w", err)
-		}
-	}
-
-	// Add retention_until column if it doesn't exist
-	if !s.columnExists("memories", "retention_until") {
-		_, err := s.db.Exec("ALTER TABLE memories ADD COLUMN retention_until DATETIME")
-		if err != nil {
-			return fmt.Errorf("failed to add retention_until column: %w", err)
-		}
-	}
-
-	// Add pinned column if it doesn't exist (M9: Plan 021)
-	if !s.columnExists("memories", "pinned") {
-		_, err := s.db.Exec("ALTER TABLE memories ADD COLUMN pinned BOOLEAN DEFAULT FALSE")
-		if err != nil {
-			return fmt.Errorf("failed to add pinned column: %w", err)
-		}
-	}
-
-	// Add pinned_at column if it doesn't exist (M9: Plan 021)
-	if !s.columnExists("memories", "pinned_at") {
-		_, err := s.db.Exec("ALTER TABLE memories ADD COLUMN pinned_at DATETIME")
-		if err != nil {
-			return fmt.Errorf("failed to add pinned_at column: %w", err)
-		}
-	}
-
-	// Add pinned_reason column if it doesn't exist (M9: Plan 021)
-	if !s.columnExists("memories", "pinned_reason") {
-		_, err := s.db.Exec("ALTER TABLE memories ADD COLUMN pinned_reason TEXT")
-		if err != nil {
-			return fmt.Errorf("failed to add pinned_reason column: %w", err)
-		}
+	if err := s.addColumns("memories", [][2]string{
+		{"retention_policy", "TEXT DEFAULT 'standard'"},
+		{"retention_until", "DATETIME"},
+		// Pinning (M9: Plan 021)
+		{"pinned", "BOOLEAN DEFAULT FALSE"},
+		{"pinned_at", "DATETIME"},
+		{"pinned_reason", "TEXT"},
+	}); err != nil {
+		return err
 	}
 
 	// Create index on retention_policy
@@ -354,38 +351,72 @@ func (s *SQLiteGraphStore) migrateRetentionPolicySchema() error {
 	return nil
 }
 
-// columnExists checks if a column exists in a table.
-func (s *SQLiteGraphStore) columnExists(tableName, columnName string) bool {
-	query := fmt.Sprintf("PRAGMA table_info(%s)", tableName)
-	rows, err := s.db.Query(query)
+// nullString maps an empty string to SQL NULL for optional text columns.
+func nullString(v string) sql.NullString {
+	return sql.NullString{String: v, Valid: v != ""}
+}
+
+// nullUTC returns t in UTC, so it compares correctly as text, or nil when t is unset.
+func nullUTC(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
+
+// validityTimes converts scanned valid_from/valid_to columns to an edge's validity period.
+func validityTimes(from, to sql.NullTime) (*time.Time, *time.Time) {
+	var validFrom, validTo *time.Time
+	if from.Valid {
+		validFrom = &from.Time
+	}
+	if to.Valid {
+		validTo = &to.Time
+	}
+	return validFrom, validTo
+}
+
+// AddNode adds or updates a node in the graph. Updating a node replaces its name, type,
+// description and metadata, and its embedding when one is given; its creation time,
+// access stats, mention count, pin state and community are kept.
+func (s *SQLiteGraphStore) AddNode(ctx context.Context, node *Node) error {
+	args, err := s.nodeRow(node, true)
 	if err != nil {
-		return false
+		return err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var cid int
-		var name string
-		var ctype string
-		var notnull int
-		var dfltValue sql.NullString
-		var pk int
-
-		err := rows.Scan(&cid, &name, &ctype, &notnull, &dfltValue, &pk)
-		if err != nil {
-			return false
-		}
+	stmt, err := s.stmts.prepare(ctx, s.db, nodeInsertPrefix+nodeRowValues+nodeUpsertSuffix)
+	if err != nil {
+		return fmt.Errorf("failed to add node: %w", err)
+	}
 
-		if name == columnName {
-			return true
-		}
+	if _, err := stmt.ExecContext(ctx, args...); err != nil {
+		return fmt.Errorf("failed to add node: %w", classifyWriteError(err))
 	}
 
-	return false
+	return nil
 }
 
-// AddNode adds or updates a node in the graph.
-func (s *SQLiteGraphStore) AddNode(ctx context.Context, node *Node) error {
+const (
+	// nodeInsertPrefix, nodeRowValues and nodeUpsertSuffix form the node upsert; bulk
+	// inserts repeat the row values. Only the extracted fields of an existing row are
+	// updated, and its embedding only when the row carries one.
+	nodeInsertPrefix = `
+		INSERT INTO nodes (id, name, type, description, embedding, created_at, metadata, namespace)
+		VALUES `
+	nodeRowValues    = `(?, ?, ?, ?, ?, ?, ?, ?)`
+	nodeUpsertSuffix = `
+		ON CONFLICT (id) DO UPDATE SET
+			name = excluded.name,
+			type = excluded.type,
+			description = excluded.description,
+			embedding = COALESCE(excluded.embedding, nodes.embedding),
+			metadata = excluded.metadata`
+)
+
+// nodeRow applies node defaults (ID, CreatedAt) and returns the arguments of its
+// nodeRowValues. Without withEmbedding the row leaves the stored embedding as it is.
+func (s *SQLiteGraphStore) nodeRow(node *Node, withEmbedding bool) ([]interface{}, error) {
 	// Generate ID if not provided
 	if node.ID == "" {
 		node.ID = uuid.New().String()
@@ -396,9 +427,9 @@ func (s *SQLiteGraphStore) AddNode(ctx context.Context, node *Node) error {
 		node.CreatedAt = time.Now()
 	}
 
-	// Serialize embedding to bytes
+	// Serialize embedding to bytes (NULL keeps the stored embedding)
 	var embeddingBytes []byte
-	if len(node.Embedding) > 0 {
+	if withEmbedding && len(node.Embedding) > 0 {
 		embeddingBytes = make([]byte, len(node.Embedding)*4)
 		for i, v := range node.Embedding {
 			binary.LittleEndian.PutUint32(embeddingBytes[i*4:], math.Float32bits(v))
@@ -407,43 +438,40 @@ func (s *SQLiteGraphStore) AddNode(ctx context.Context, node *Node) error {
 
 	// Serialize metadata to JSON
 	var metadataJSON []byte
-	var err error
 	if node.Metadata != nil {
+		var err error
 		metadataJSON, err = json.Marshal(node.Metadata)
 		if err != nil {
-			return fmt.Errorf("failed to marshal metadata: %w", err)
+			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
 		}
 	}
 
-	query := `
-		INSERT OR REPLACE INTO nodes (id, name, type, description, embedding, created_at, metadata)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
-	`
+	description, err := s.cipher.Encrypt(node.Description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt description: %w", err)
+	}
 
-	_, err = s.db.ExecContext(ctx, query,
+	return []interface{}{
 		node.ID,
 		node.Name,
 		node.Type,
-		node.Description,
+		description,
 		embeddingBytes,
 		node.CreatedAt,
 		metadataJSON,
-	)
-
-	if err != nil {
-		return fmt.Errorf("failed to add node: %w", err)
-	}
-
-	return nil
+		s.namespace,
+	}, nil
 }
 
 // GetNode retrieves a node by its ID.
-// Also updates last_accessed_at timestamp to track access for decay.
+// The read counts as an access for decay: it is buffered and written to last_accessed_at
+// in a later batch (see SetAccessFlush), so GetNode itself does not write.
 func (s *SQLiteGraphStore) GetNode(ctx context.Context, id string) (*Node, error) {
 	query := `
-		SELECT id, name, type, description, embedding, created_at, metadata, last_accessed_at
+		SELECT id, name, type, description, embedding, created_at, metadata, last_accessed_at, mention_count,
+			pinned, pinned_at, COALESCE(pin_reason, '')
 		FROM nodes
-		WHERE id = ?
+		WHERE id = ? AND namespace = ?
 	`
 
 	var node Node
@@ -451,7 +479,12 @@ func (s *SQLiteGraphStore) GetNode(ctx context.Context, id string) (*Node, error
 	var metadataJSON []byte
 	var lastAccessed sql.NullTime
 
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
+	stmt, err := s.stmts.prepare(ctx, s.db, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node: %w", err)
+	}
+
+	err = stmt.QueryRowContext(ctx, id, s.namespace).Scan(
 		&node.ID,
 		&node.Name,
 		&node.Type,
@@ -460,6 +493,10 @@ func (s *SQLiteGraphStore) GetNode(ctx context.Context, id string) (*Node, error
 		&node.CreatedAt,
 		&metadataJSON,
 		&lastAccessed,
+		&node.MentionCount,
+		&node.Pinned,
+		&node.PinnedAt,
+		&node.PinReason,
 	)
 
 	if err == sql.ErrNoRows {
@@ -468,6 +505,9 @@ func (s *SQLiteGraphStore) GetNode(ctx context.Context, id string) (*Node, error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get node: %w", err)
 	}
+	if node.Description, err = s.cipher.Decrypt(node.Description); err != nil {
+		return nil, err
+	}
 
 	// Deserialize embedding
 	if len(embeddingBytes) > 0 {
@@ -489,12 +529,7 @@ func (s *SQLiteGraphStore) GetNode(ctx context.Context, id string) (*Node, error
 		node.LastAccessedAt = &lastAccessed.Time
 	}
 
-	// Update last_accessed_at timestamp
-	_, err = s.db.ExecContext(ctx, "UPDATE nodes SET last_accessed_at = ? WHERE id = ?", time.Now(), id)
-	if err != nil {
-		// Log but don't fail - access tracking is not critical
-		// In production, could use a logger here
-	}
+	s.recordAccess(node.ID)
 
 	return &node, nil
 }
@@ -502,13 +537,14 @@ func (s *SQLiteGraphStore) GetNode(ctx context.Context, id string) (*Node, error
 // FindNodesByName searches for nodes by name using case-insensitive matching.
 func (s *SQLiteGraphStore) FindNodesByName(ctx context.Context, name string) ([]*Node, error) {
 	query := `
-		SELECT id, name, type, description, embedding, created_at, metadata, last_accessed_at
+		SELECT id, name, type, description, embedding, created_at, metadata, last_accessed_at, mention_count,
+			pinned, pinned_at, COALESCE(pin_reason, '')
 		FROM nodes
-		WHERE LOWER(name) = LOWER(?)
+		WHERE LOWER(name) = LOWER(?) AND namespace = ?
 		ORDER BY created_at, id
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, name)
+	rows, err := s.db.QueryContext(ctx, query, name, s.namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find nodes by name: %w", err)
 	}
@@ -530,10 +566,17 @@ func (s *SQLiteGraphStore) FindNodesByName(ctx context.Context, name string) ([]
 			&node.CreatedAt,
 			&metadataJSON,
 			&lastAccessed,
+			&node.MentionCount,
+			&node.Pinned,
+			&node.PinnedAt,
+			&node.PinReason,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan node: %w", err)
 		}
+		if node.Description, err = s.cipher.Decrypt(node.Description); err != nil {
+			return nil, err
+		}
 
 		// Deserialize embedding
 		if len(embeddingBytes) > 0 {
@@ -585,6 +628,48 @@ func (s *SQLiteGraphStore) FindNodeByName(ctx context.Context, name string) (*No
 
 // AddEdge adds or updates an edge in the graph.
 func (s *SQLiteGraphStore) AddEdge(ctx context.Context, edge *Edge) error {
+	stmt, err := s.stmts.prepare(ctx, s.db, edgeInsertPrefix+edgeRowValues+edgeUpsertSuffix)
+	if err != nil {
+		return fmt.Errorf("failed to add edge: %w", err)
+	}
+
+	args, err := s.edgeRow(edge)
+	if err != nil {
+		return err
+	}
+	if _, err := stmt.ExecContext(ctx, args...); err != nil {
+		return fmt.Errorf("failed to add edge: %w", classifyWriteError(err))
+	}
+
+	return nil
+}
+
+const (
+	// edgeInsertPrefix, edgeRowValues and edgeUpsertSuffix form the edge upsert; bulk
+	// inserts repeat the row values. An existing row is updated in place rather than
+	// replaced, so rows referencing the edge (its vector mapping, provenance) survive,
+	// and keeps its created_at, so DiffSince does not report re-extracted edges as added.
+	edgeInsertPrefix = `
+		INSERT INTO edges (id, source_id, relation, target_id, weight, created_at, expires_at,
+			source_chunk_id, evidence, namespace, valid_from, valid_to)
+		VALUES `
+	edgeRowValues    = `(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	edgeUpsertSuffix = `
+		ON CONFLICT (id) DO UPDATE SET
+			source_id = excluded.source_id,
+			relation = excluded.relation,
+			target_id = excluded.target_id,
+			weight = excluded.weight,
+			expires_at = excluded.expires_at,
+			source_chunk_id = excluded.source_chunk_id,
+			evidence = excluded.evidence,
+			valid_from = excluded.valid_from,
+			valid_to = excluded.valid_to`
+)
+
+// edgeRow applies edge defaults (ID, CreatedAt, Weight) and returns the arguments of its
+// edgeRowValues, with the evidence encrypted.
+func (s *SQLiteGraphStore) edgeRow(edge *Edge) ([]interface{}, error) {
 	// Generate ID if not provided
 	if edge.ID == "" {
 		edge.ID = uuid.New().String()
@@ -600,37 +685,47 @@ func (s *SQLiteGraphStore) AddEdge(ctx context.Context, edge *Edge) error {
 		edge.Weight = 1.0
 	}
 
-	query := `
-		INSERT OR REPLACE INTO edges (id, source_id, relation, target_id, weight, created_at)
-		VALUES (?, ?, ?, ?, ?, ?)
-	`
+	// Expiry is stored in UTC so it compares correctly as text
+	var expiresAt interface{}
+	if edge.ExpiresAt != nil {
+		expiresAt = edge.ExpiresAt.UTC()
+	}
 
-	_, err := s.db.ExecContext(ctx, query,
+	evidence, err := s.cipher.Encrypt(edge.Evidence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt evidence: %w", err)
+	}
+
+	return []interface{}{
 		edge.ID,
 		edge.SourceID,
 		edge.Relation,
 		edge.TargetID,
 		edge.Weight,
 		edge.CreatedAt,
-	)
-
-	if err != nil {
-		return fmt.Errorf("failed to add edge: %w", err)
-	}
-
-	return nil
+		expiresAt,
+		nullString(edge.SourceChunkID),
+		nullString(evidence),
+		s.namespace,
+		nullUTC(edge.ValidFrom),
+		nullUTC(edge.ValidTo),
+	}, nil
 }
 
 // GetEdges retrieves all edges incident to a node (both incoming and outgoing).
+// Expired edges are excluded.
 func (s *SQLiteGraphStore) GetEdges(ctx context.Context, nodeID string) ([]*Edge, error) {
 	query := `
-		SELECT id, source_id, relation, target_id, weight, created_at
+		SELECT id, source_id, relation, target_id, weight, created_at, expires_at, source_chunk_id, evidence,
+			valid_from, valid_to
 		FROM edges
-		WHERE source_id = ? OR target_id = ?
+		WHERE (source_id = ? OR target_id = ?)
+		AND namespace = ?
+		AND (expires_at IS NULL OR expires_at > ?)
 		ORDER BY created_at
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, nodeID, nodeID)
+	rows, err := s.db.QueryContext(ctx, query, nodeID, nodeID, s.namespace, time.Now().UTC())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get edges: %w", err)
 	}
@@ -639,6 +734,8 @@ func (s *SQLiteGraphStore) GetEdges(ctx context.Context, nodeID string) ([]*Edge
 	var edges []*Edge
 	for rows.Next() {
 		var edge Edge
+		var expiresAt, validFrom, validTo sql.NullTime
+		var sourceChunkID, evidence sql.NullString
 		err := rows.Scan(
 			&edge.ID,
 			&edge.SourceID,
@@ -646,10 +743,23 @@ func (s *SQLiteGraphStore) GetEdges(ctx context.Context, nodeID string) ([]*Edge
 			&edge.TargetID,
 			&edge.Weight,
 			&edge.CreatedAt,
+			&expiresAt,
+			&sourceChunkID,
+			&evidence,
+			&validFrom,
+			&validTo,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan edge: %w", err)
 		}
+		if expiresAt.Valid {
+			edge.ExpiresAt = &expiresAt.Time
+		}
+		edge.SourceChunkID = sourceChunkID.String
+		if edge.Evidence, err = s.cipher.Decrypt(evidence.String); err != nil {
+			return nil, err
+		}
+		edge.ValidFrom, edge.ValidTo = validityTimes(validFrom, validTo)
 		edges = append(edges, &edge)
 	}
 
@@ -660,98 +770,69 @@ func (s *SQLiteGraphStore) GetEdges(ctx context.Context, nodeID string) ([]*Edge
 	return edges, nil
 }
 
-// GetNeighbors retrieves all nodes adjacent to a given node, up to the specified depth.
-// Uses a recursive CTE for efficient single-query graph expansion (v1.4.0 optimization).
-func (s *SQLiteGraphStore) GetNeighbors(ctx context.Context, nodeID string, depth int) ([]*Node, error) {
-	if depth < 1 {
-		return nil, fmt.Errorf("depth must be at least 1")
-	}
-
-	// Recursive CTE to traverse graph bidirectionally up to depth
+// GetEdge retrieves an edge by its ID. Returns nil if the edge does not exist or has expired.
+func (s *SQLiteGraphStore) GetEdge(ctx context.Context, id string) (*Edge, error) {
 	query := `
-	WITH RECURSIVE
-	graph_traversal(node_id, depth_level) AS (
-		-- Base case: starting node at depth 0
-		SELECT ? AS node_id, 0 AS depth_level
-		
-		UNION
-		
-		-- Recursive case: expand to neighbors
-		SELECT 
-			CASE 
-				WHEN edges.source_id = graph_traversal.node_id THEN edges.target_id
-				ELSE edges.source_id
-			END AS node_id,
-			graph_traversal.depth_level + 1 AS depth_level
-		FROM graph_traversal
-		JOIN edges ON (
-			edges.source_id = graph_traversal.node_id OR 
-			edges.target_id = graph_traversal.node_id
-		)
-		WHERE graph_traversal.depth_level < ?
-	)
-	SELECT DISTINCT 
-		n.id, n.name, n.type, n.description, n.embedding, 
-		n.created_at, n.last_accessed_at, n.metadata
-	FROM graph_traversal gt
-	JOIN nodes n ON gt.node_id = n.id
-	WHERE gt.node_id != ? -- Exclude starting node
+		SELECT id, source_id, relation, target_id, weight, created_at, expires_at, source_chunk_id, evidence,
+			valid_from, valid_to
+		FROM edges
+		WHERE id = ? AND namespace = ?
+		AND (expires_at IS NULL OR expires_at > ?)
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, nodeID, depth, nodeID)
+	var edge Edge
+	var expiresAt, validFrom, validTo sql.NullTime
+	var sourceChunkID, evidence sql.NullString
+	err := s.db.QueryRowContext(ctx, query, id, s.namespace, time.Now().UTC()).Scan(
+		&edge.ID,
+		&edge.SourceID,
+		&edge.Relation,
+		&edge.TargetID,
+		&edge.Weight,
+		&edge.CreatedAt,
+		&expiresAt,
+		&sourceChunkID,
+		&evidence,
+		&validFrom,
+		&validTo,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil // Not found, no error
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to query neighbors with CTE: %w", err)
+		return nil, fmt.Errorf("failed to get edge: %w", err)
 	}
-	defer rows.Close()
-
-	var neighbors []*Node
-	for rows.Next() {
-		node := &Node{}
-		var embeddingData []byte
-		var metadataJSON []byte
-		var lastAccessed sql.NullTime
-
-		err := rows.Scan(
-			&node.ID, &node.Name, &node.Type, &node.Description,
-			&embeddingData, &node.CreatedAt, &lastAccessed, &metadataJSON,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan neighbor node: %w", err)
-		}
-
-		// Deserialize embedding
-		if len(embeddingData) > 0 {
-			node.Embedding = deserializeEmbedding(embeddingData)
-		}
-
-		// Deserialize metadata
-		if len(metadataJSON) > 0 {
-			if err := json.Unmarshal(metadataJSON, &node.Metadata); err != nil {
-				node.Metadata = make(map[string]interface{})
-			}
-		} else {
-			node.Metadata = make(map[string]interface{})
-		}
+	if expiresAt.Valid {
+		edge.ExpiresAt = &expiresAt.Time
+	}
+	edge.SourceChunkID = sourceChunkID.String
+	if edge.Evidence, err = s.cipher.Decrypt(evidence.String); err != nil {
+		return nil, err
+	}
+	edge.ValidFrom, edge.ValidTo = validityTimes(validFrom, validTo)
 
-		// Handle nullable last_accessed_at
-		if lastAccessed.Valid {
-			node.LastAccessedAt = &lastAccessed.Time
-		}
+	return &edge, nil
+}
 
-		neighbors = append(neighbors, node)
+// GetNeighbors retrieves all nodes adjacent to a given node, up to the specified depth.
+// Uses a recursive CTE for efficient single-query graph expansion (v1.4.0 optimization);
+// see GetNeighborsWithDepth for the BFS depth of each node.
+func (s *SQLiteGraphStore) GetNeighbors(ctx context.Context, nodeID string, depth int) ([]*Node, error) {
+	reached, err := s.GetNeighborsWithDepth(ctx, nodeID, depth)
+	if err != nil {
+		return nil, err
 	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating neighbor rows: %w", err)
+	neighbors := make([]*Node, len(reached))
+	for i, neighbor := range reached {
+		neighbors[i] = neighbor.Node
 	}
-
 	return neighbors, nil
 }
 
 // NodeCount returns the total number of nodes in the graph.
 func (s *SQLiteGraphStore) NodeCount(ctx context.Context) (int64, error) {
 	var count int64
-	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM nodes").Scan(&count)
+	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM nodes WHERE namespace = ?", s.namespace).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count nodes: %w", err)
 	}
@@ -761,50 +842,104 @@ func (s *SQLiteGraphStore) NodeCount(ctx context.Context) (int64, error) {
 // EdgeCount returns the total number of edges in the graph.
 func (s *SQLiteGraphStore) EdgeCount(ctx context.Context) (int64, error) {
 	var count int64
-	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM edges").Scan(&count)
+	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM edges WHERE namespace = ?", s.namespace).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count edges: %w", err)
 	}
 	return count, nil
 }
 
-// UpdateAccessTime updates the last_accessed_at timestamp for a batch of nodes.
-// This is used for access reinforcement in memory decay.
-func (s *SQLiteGraphStore) UpdateAccessTime(ctx context.Context, nodeIDs []string) error {
+// IncrementMentionCounts adds one mention to each of the given nodes, typically the
+// distinct entities extracted from one chunk. Unknown IDs are ignored.
+func (s *SQLiteGraphStore) IncrementMentionCounts(ctx context.Context, nodeIDs []string) error {
 	if len(nodeIDs) == 0 {
 		return nil
 	}
 
-	// Build IN clause with placeholders
 	placeholders := make([]string, len(nodeIDs))
-	args := make([]interface{}, len(nodeIDs)+1)
-	args[0] = time.Now()
-
-	for i, nodeID := range nodeIDs {
+	args := make([]interface{}, 0, len(nodeIDs)+1)
+	args = append(args, s.namespace)
+	for i, id := range nodeIDs {
 		placeholders[i] = "?"
-		args[i+1] = nodeID
+		args = append(args, id)
 	}
 
-	query := fmt.Sprintf("UPDATE nodes SET last_accessed_at = ? WHERE id IN (%s)",
+	query := fmt.Sprintf("UPDATE nodes SET mention_count = mention_count + 1 WHERE namespace = ? AND id IN (%s)",
 		strings.Join(placeholders, ","))
+	if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to increment mention counts: %w", err)
+	}
+	return nil
+}
+
+// SetNodePinned pins or unpins a node. Pinning records the time and reason;
+// unpinning clears them. Returns an error if the node does not exist.
+func (s *SQLiteGraphStore) SetNodePinned(ctx context.Context, nodeID string, pinned bool, reason string) error {
+	var res sql.Result
+	var err error
+	if pinned {
+		res, err = s.db.ExecContext(ctx,
+			"UPDATE nodes SET pinned = TRUE, pinned_at = ?, pin_reason = ? WHERE id = ? AND namespace = ?",
+			time.Now(), nullString(reason), nodeID, s.namespace)
+	} else {
+		res, err = s.db.ExecContext(ctx,
+			"UPDATE nodes SET pinned = FALSE, pinned_at = NULL, pin_reason = NULL WHERE id = ? AND namespace = ?",
+			nodeID, s.namespace)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update node pin: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update node pin: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("node not found: %s", nodeID)
+	}
+	return nil
+}
 
-	_, err := s.db.ExecContext(ctx, query, args...)
+// UpdateAccessTime updates the last_accessed_at timestamp for a batch of nodes.
+// This is used for access reinforcement in memory decay.
+func (s *SQLiteGraphStore) UpdateAccessTime(ctx context.Context, nodeIDs []string) error {
+	if len(nodeIDs) == 0 {
+		return nil
+	}
+
+	// One cached statement executed per node in a single transaction, rather than an
+	// IN clause whose placeholder count (and so compiled statement) varies per call
+	stmt, err := s.stmts.prepare(ctx, s.db, "UPDATE nodes SET last_accessed_at = ? WHERE id = ?")
 	if err != nil {
 		return fmt.Errorf("failed to update access time: %w", err)
 	}
 
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	txStmt := tx.StmtContext(ctx, stmt)
+	now := time.Now()
+	for _, nodeID := range nodeIDs {
+		if _, err := txStmt.ExecContext(ctx, now, nodeID); err != nil {
+			return fmt.Errorf("failed to update access time: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	return nil
 }
 
 // GetAllNodes returns all nodes in the graph (for pruning operations).
 func (s *SQLiteGraphStore) GetAllNodes(ctx context.Context) ([]*Node, error) {
-	query := `
-		SELECT id, name, type, description, embedding, created_at, metadata, last_accessed_at
-		FROM nodes
-		ORDER BY created_at, id
-	`
+	start := time.Now()
+	query := `SELECT ` + nodeColumns + ` FROM nodes WHERE namespace = ? ORDER BY created_at, id`
 
-	rows, err := s.db.QueryContext(ctx, query)
+	rows, err := s.db.QueryContext(ctx, query, s.namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query all nodes: %w", err)
 	}
@@ -812,58 +947,160 @@ func (s *SQLiteGraphStore) GetAllNodes(ctx context.Context) ([]*Node, error) {
 
 	var nodes []*Node
 	for rows.Next() {
-		var node Node
-		var embeddingBytes []byte
-		var metadataJSON []byte
-		var lastAccessed sql.NullTime
-
-		err := rows.Scan(
-			&node.ID,
-			&node.Name,
-			&node.Type,
-			&node.Description,
-			&embeddingBytes,
-			&node.CreatedAt,
-			&metadataJSON,
-			&lastAccessed,
-		)
+		node, err := s.scanNode(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan node: %w", err)
+			return nil, err
 		}
+		nodes = append(nodes, node)
+	}
 
-		// Deserialize embedding
-		if len(embeddingBytes) > 0 {
-			node.Embedding = make([]float32, len(embeddingBytes)/4)
-			for i := range node.Embedding {
-				node.Embedding[i] = math.Float32frombits(binary.LittleEndian.Uint32(embeddingBytes[i*4:]))
-			}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating nodes: %w", err)
+	}
+
+	s.logQuery(ctx, "get_all_nodes", start, slog.Int("nodes", len(nodes)))
+	return nodes, nil
+}
+
+// IterateNodes streams every node in the graph to fn, ordered by created_at then id.
+// Rows are scanned one at a time so callers can process large graphs without
+// materializing them. Iteration stops at the first error returned by fn.
+func (s *SQLiteGraphStore) IterateNodes(ctx context.Context, fn func(*Node) error) error {
+	query := `SELECT ` + nodeColumns + ` FROM nodes WHERE namespace = ? ORDER BY created_at, id`
+
+	rows, err := s.db.QueryContext(ctx, query, s.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to query nodes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		node, err := s.scanNode(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(node); err != nil {
+			return err
 		}
+	}
 
-		// Deserialize metadata
-		if len(metadataJSON) > 0 {
-			if err := json.Unmarshal(metadataJSON, &node.Metadata); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
-			}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating nodes: %w", err)
+	}
+
+	return nil
+}
+
+// Columns scanNode reads, in order; nodeColumnsWithoutEmbedding reads NULL embeddings
+const (
+	nodeColumns                 = `id, name, type, description, embedding, ` + nodeStateColumns
+	nodeColumnsWithoutEmbedding = `id, name, type, description, NULL, ` + nodeStateColumns
+	nodeStateColumns            = `created_at, metadata, last_accessed_at, mention_count, pinned, pinned_at, COALESCE(pin_reason, '')`
+)
+
+// scanNode reads a row of nodeColumns into a node, decrypting its description
+func (s *SQLiteGraphStore) scanNode(rows *sql.Rows) (*Node, error) {
+	var node Node
+	var embeddingBytes []byte
+	var metadataJSON []byte
+	var lastAccessed sql.NullTime
+
+	err := rows.Scan(
+		&node.ID,
+		&node.Name,
+		&node.Type,
+		&node.Description,
+		&embeddingBytes,
+		&node.CreatedAt,
+		&metadataJSON,
+		&lastAccessed,
+		&node.MentionCount,
+		&node.Pinned,
+		&node.PinnedAt,
+		&node.PinReason,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan node: %w", err)
+	}
+	if node.Description, err = s.cipher.Decrypt(node.Description); err != nil {
+		return nil, err
+	}
+
+	node.Embedding = deserializeEmbedding(embeddingBytes)
+
+	if len(metadataJSON) > 0 {
+		if err := json.Unmarshal(metadataJSON, &node.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
 		}
+	}
 
-		// Hydrate last_accessed_at if it's not NULL
-		if lastAccessed.Valid {
-			node.LastAccessedAt = &lastAccessed.Time
+	if lastAccessed.Valid {
+		node.LastAccessedAt = &lastAccessed.Time
+	}
+	return &node, nil
+}
+
+// IterateEdges streams every unexpired edge in the graph to fn, ordered by created_at then id.
+// Iteration stops at the first error returned by fn.
+func (s *SQLiteGraphStore) IterateEdges(ctx context.Context, fn func(*Edge) error) error {
+	query := `
+		SELECT id, source_id, relation, target_id, weight, created_at, expires_at, source_chunk_id, evidence,
+			valid_from, valid_to
+		FROM edges
+		WHERE namespace = ? AND (expires_at IS NULL OR expires_at > ?)
+		ORDER BY created_at, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, s.namespace, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to query edges: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var edge Edge
+		var expiresAt, validFrom, validTo sql.NullTime
+		var sourceChunkID, evidence sql.NullString
+		err := rows.Scan(
+			&edge.ID,
+			&edge.SourceID,
+			&edge.Relation,
+			&edge.TargetID,
+			&edge.Weight,
+			&edge.CreatedAt,
+			&expiresAt,
+			&sourceChunkID,
+			&evidence,
+			&validFrom,
+			&validTo,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan edge: %w", err)
+		}
+		if expiresAt.Valid {
+			edge.ExpiresAt = &expiresAt.Time
+		}
+		edge.SourceChunkID = sourceChunkID.String
+		if edge.Evidence, err = s.cipher.Decrypt(evidence.String); err != nil {
+			return err
 		}
+		edge.ValidFrom, edge.ValidTo = validityTimes(validFrom, validTo)
 
-		nodes = append(nodes, &node)
+		if err := fn(&edge); err != nil {
+			return err
+		}
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating nodes: %w", err)
+		return fmt.Errorf("error iterating edges: %w", err)
 	}
 
-	return nodes, nil
+	return nil
 }
 
-// DeleteNode removes a node from the graph.
+// DeleteNode removes a node from the graph, along with its description history.
 func (s *SQLiteGraphStore) DeleteNode(ctx context.Context, nodeID string) error {
-	_, err := s.db.ExecContext(ctx, "DELETE FROM nodes WHERE id = ?", nodeID)
+	_, err := s.db.ExecContext(ctx, "DELETE FROM nodes WHERE id = ? AND namespace = ?", nodeID, s.namespace)
 	if err != nil {
 		return fmt.Errorf("failed to delete node: %w", err)
 	}
@@ -872,15 +1109,49 @@ func (s *SQLiteGraphStore) DeleteNode(ctx context.Context, nodeID string) error
 
 // DeleteEdge removes an edge from the graph.
 func (s *SQLiteGraphStore) DeleteEdge(ctx context.Context, edgeID string) error {
-	_, err := s.db.ExecContext(ctx, "DELETE FROM edges WHERE id = ?", edgeID)
+	_, err := s.db.ExecContext(ctx, "DELETE FROM edges WHERE id = ? AND namespace = ?", edgeID, s.namespace)
 	if err != nil {
 		return fmt.Errorf("failed to delete edge: %w", err)
 	}
 	return nil
 }
 
+// DeleteExpiredEdges removes every edge whose ExpiresAt is at or before now,
+// along with any memory provenance links pointing at those edges.
+// Returns the IDs of the edges deleted.
+func (s *SQLiteGraphStore) DeleteExpiredEdges(ctx context.Context, now time.Time) ([]string, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	cutoff := now.UTC()
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM memory_edges
+		WHERE edge_id IN (
+			SELECT id FROM edges WHERE namespace = ? AND expires_at IS NOT NULL AND expires_at <= ?
+		)
+	`, s.namespace, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete provenance for expired edges: %w", err)
+	}
+
+	ids, err := deleteReturningIDs(ctx, tx, "DELETE FROM edges WHERE namespace = ? AND expires_at IS NOT NULL AND expires_at <= ? RETURNING id", s.namespace, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete expired edges: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return ids, nil
+}
+
 // Close releases database resources.
 func (s *SQLiteGraphStore) Close() error {
+	s.closeAccess()
+	s.stmts.close()
 	return s.db.Close()
 }
 
@@ -890,3 +1161,9 @@ func (s *SQLiteGraphStore) Close() error {
 func (s *SQLiteGraphStore) DB() *sql.DB {
 	return s.db
 }
+
+// SetLogger sets the structured logger for query timings; nil (the default) disables
+// logging. Queries slower than the slow query threshold are logged at WARN.
+func (s *SQLiteGraphStore) SetLogger(logger *slog.Logger) {
+	s.setLogger(logger, componentGraphStore)
+}
@@ -0,0 +1,239 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SQLiteEdgeVectorStore implements VectorStore for edge embeddings, kept apart from node
+// embeddings so relationship search never mixes with entity search.
+//
+// It mirrors SQLiteVectorStore: vectors live in the vec_edges vec0 virtual table and
+// vec_edge_ids maps vec0 rowids to edge IDs. Mappings are removed with their edge
+// (ON DELETE CASCADE), and a trigger removes the vector with its mapping.
+// The database connection is shared with SQLiteGraphStore and must not be closed by this store.
+type SQLiteEdgeVectorStore struct {
+	db        *sql.DB
+	namespace string // Restricts search results to edges in this namespace (see WithNamespace)
+}
+
+// NewSQLiteEdgeVectorStore creates a SQLite-backed vector store for edge embeddings.
+// The database connection is shared and owned by the caller (typically SQLiteGraphStore).
+func NewSQLiteEdgeVectorStore(db *sql.DB) *SQLiteEdgeVectorStore {
+	return &SQLiteEdgeVectorStore{db: db}
+}
+
+// WithNamespace scopes searches of this store to edges in the given namespace.
+func (s *SQLiteEdgeVectorStore) WithNamespace(namespace string) *SQLiteEdgeVectorStore {
+	s.namespace = namespace
+	return s
+}
+
+// Namespace returns the namespace this store is scoped to.
+func (s *SQLiteEdgeVectorStore) Namespace() string {
+	return s.namespace
+}
+
+// Add adds or updates the embedding for the given edge ID.
+// Returns an error if the edge doesn't exist.
+func (s *SQLiteEdgeVectorStore) Add(ctx context.Context, id string, embedding []float32) error {
+	if len(embedding) == 0 {
+		return fmt.Errorf("embedding cannot be empty")
+	}
+	if err := checkVecDimensions(ctx, s.db, "vec_edges", "vec_edge_ids", len(embedding), true); err != nil {
+		return err
+	}
+
+	var exists int
+	err := s.db.QueryRowContext(ctx, `SELECT 1 FROM edges WHERE id = ?`, id).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("edge %s not found", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check edge existence: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := writeEdgeVector(ctx, tx, id, embedding); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// writeEdgeVector stores an edge's embedding in vec_edges within tx, replacing any
+// previous vector.
+func writeEdgeVector(ctx context.Context, tx *sql.Tx, id string, embedding []float32) error {
+	var rowid int64
+	err := tx.QueryRowContext(ctx, `SELECT rowid FROM vec_edge_ids WHERE edge_id = ?`, id).Scan(&rowid)
+	if err == sql.ErrNoRows {
+		result, err := tx.ExecContext(ctx, `INSERT INTO vec_edge_ids (edge_id) VALUES (?)`, id)
+		if err != nil {
+			return fmt.Errorf("failed to create vec_edge_ids mapping: %w", err)
+		}
+		rowid, err = result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert rowid: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to query vec_edge_ids: %w", err)
+	} else {
+		// Replace the existing vector
+		if _, err := tx.ExecContext(ctx, `DELETE FROM vec_edges WHERE rowid = ?`, rowid); err != nil {
+			return fmt.Errorf("failed to delete old vec_edges entry: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO vec_edges (rowid, embedding) VALUES (?, ?)`, rowid, serializeEmbedding(embedding)); err != nil {
+		return fmt.Errorf("failed to insert into vec_edges: %w", err)
+	}
+	return nil
+}
+
+// Search finds the edges whose embeddings are most similar to the query.
+// Only unexpired edges in the store's namespace are returned, best match first.
+func (s *SQLiteEdgeVectorStore) Search(ctx context.Context, query []float32, topK int) ([]SearchResult, error) {
+	if len(query) == 0 || topK <= 0 {
+		return []SearchResult{}, nil
+	}
+	if err := checkVecDimensions(ctx, s.db, "vec_edges", "vec_edge_ids", len(query), false); err != nil {
+		return nil, err
+	}
+
+	queryBlob := serializeEmbedding(query)
+
+	// Filters apply after the KNN step, so widen k until enough results are found
+	k := topK
+	for {
+		results, candidates, err := s.searchK(ctx, queryBlob, k)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) >= topK || candidates < k {
+			if len(results) > topK {
+				results = results[:topK]
+			}
+			return results, nil
+		}
+		k *= 4
+	}
+}
+
+// searchK runs one vec0 KNN query and returns the matching live edges in the store's
+// namespace, along with the number of KNN candidates before filtering.
+func (s *SQLiteEdgeVectorStore) searchK(ctx context.Context, queryBlob []byte, k int) ([]SearchResult, int, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT vec_edge_ids.edge_id, distance, edges.namespace, edges.expires_at
+		FROM vec_edges
+		LEFT JOIN vec_edge_ids ON vec_edges.rowid = vec_edge_ids.rowid
+		LEFT JOIN edges ON edges.id = vec_edge_ids.edge_id
+		WHERE vec_edges.embedding MATCH ? AND k = ?
+		ORDER BY distance
+	`, queryBlob, k)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to execute vec0 edge search: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	var results []SearchResult
+	candidates := 0
+	for rows.Next() {
+		var edgeID, namespace sql.NullString
+		var distance float64
+		var expiresAt sql.NullTime
+
+		if err := rows.Scan(&edgeID, &distance, &namespace, &expiresAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan edge search result: %w", err)
+		}
+		candidates++
+
+		// Skip vectors whose edge was deleted, belongs elsewhere, or has expired
+		if !edgeID.Valid || !namespace.Valid || namespace.String != s.namespace {
+			continue
+		}
+		if expiresAt.Valid && !expiresAt.Time.After(now) {
+			continue
+		}
+
+		results = append(results, SearchResult{
+			ID:    edgeID.String,
+			Score: 1.0 - distance,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating edge search results: %w", err)
+	}
+
+	return results, candidates, nil
+}
+
+// Delete removes the embedding for the given edge ID. The edge itself is kept.
+func (s *SQLiteEdgeVectorStore) Delete(ctx context.Context, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var rowid int64
+	err = tx.QueryRowContext(ctx, `SELECT rowid FROM vec_edge_ids WHERE edge_id = ?`, id).Scan(&rowid)
+	if err == sql.ErrNoRows {
+		return nil // No embedding stored
+	}
+	if err != nil {
+		return fmt.Errorf("failed to query vec_edge_ids: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM vec_edges WHERE rowid = ?`, rowid); err != nil {
+		return fmt.Errorf("failed to delete from vec_edges: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM vec_edge_ids WHERE rowid = ?`, rowid); err != nil {
+		return fmt.Errorf("failed to delete from vec_edge_ids: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Close is a no-op because the database connection is owned by SQLiteGraphStore.
+func (s *SQLiteEdgeVectorStore) Close() error {
+	return nil
+}
+
+// migrateVectorCleanup removes a node's or edge's vector together with its mapping row,
+// which is deleted by ON DELETE CASCADE when the node or edge goes away, and drops the
+// vectors such cascades orphaned earlier (schema migration 27). An orphaned vector would
+// otherwise collide with the next mapping that reuses its rowid.
+func (s *SQLiteGraphStore) migrateVectorCleanup() error {
+	if _, err := s.db.Exec(`
+		CREATE TRIGGER IF NOT EXISTS vec_node_ids_delete AFTER DELETE ON vec_node_ids BEGIN
+			DELETE FROM vec_nodes WHERE rowid = old.rowid;
+		END;
+
+		CREATE TRIGGER IF NOT EXISTS vec_edge_ids_delete AFTER DELETE ON vec_edge_ids BEGIN
+			DELETE FROM vec_edges WHERE rowid = old.rowid;
+		END;
+
+		DELETE FROM vec_nodes WHERE rowid NOT IN (SELECT rowid FROM vec_node_ids);
+		DELETE FROM vec_edges WHERE rowid NOT IN (SELECT rowid FROM vec_edge_ids);
+	`); err != nil {
+		return fmt.Errorf("failed to create vector cleanup triggers: %w", err)
+	}
+	return nil
+}
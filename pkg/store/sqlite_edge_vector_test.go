@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openEdgeVectorStore(t *testing.T) (*SQLiteGraphStore, *SQLiteEdgeVectorStore) {
+	t.Helper()
+	gs, err := NewSQLiteGraphStore(filepath.Join(t.TempDir(), "edges.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteGraphStore failed: %v", err)
+	}
+	t.Cleanup(func() { gs.Close() })
+
+	ctx := context.Background()
+	for _, id := range []string{"alice", "go", "bob"} {
+		if err := gs.AddNode(ctx, &Node{ID: id, Name: id}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	return gs, NewSQLiteEdgeVectorStore(gs.DB())
+}
+
+func edgeEmbedding(x float32) []float32 {
+	v := make([]float32, 1536)
+	v[0], v[1] = 1, x
+	return v
+}
+
+func TestSQLiteEdgeVectorStore_AddAndSearch(t *testing.T) {
+	gs, vs := openEdgeVectorStore(t)
+	ctx := context.Background()
+
+	if err := gs.AddEdge(ctx, &Edge{ID: "e1", SourceID: "alice", Relation: "USES", TargetID: "go", Weight: 1}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	if err := gs.AddEdge(ctx, &Edge{ID: "e2", SourceID: "bob", Relation: "KNOWS", TargetID: "alice", Weight: 1}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	if err := vs.Add(ctx, "e1", edgeEmbedding(0)); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := vs.Add(ctx, "e2", edgeEmbedding(1)); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	results, err := vs.Search(ctx, edgeEmbedding(0), 2)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 2 || results[0].ID != "e1" || results[1].ID != "e2" {
+		t.Fatalf("Expected [e1 e2], got %+v", results)
+	}
+
+	if err := vs.Add(ctx, "missing", edgeEmbedding(0)); err == nil {
+		t.Error("Expected error adding embedding for nonexistent edge")
+	}
+}
+
+func TestSQLiteEdgeVectorStore_SkipsDeletedAndExpiredEdges(t *testing.T) {
+	gs, vs := openEdgeVectorStore(t)
+	ctx := context.Background()
+
+	past := time.Now().Add(-time.Hour)
+	edges := []*Edge{
+		{ID: "live", SourceID: "alice", Relation: "USES", TargetID: "go", Weight: 1},
+		{ID: "expired", SourceID: "bob", Relation: "USES", TargetID: "go", Weight: 1, ExpiresAt: &past},
+		{ID: "deleted", SourceID: "bob", Relation: "KNOWS", TargetID: "alice", Weight: 1},
+	}
+	for i, e := range edges {
+		if err := gs.AddEdge(ctx, e); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+		if err := vs.Add(ctx, e.ID, edgeEmbedding(float32(i)*0.1)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if err := gs.DeleteEdge(ctx, "deleted"); err != nil {
+		t.Fatalf("DeleteEdge failed: %v", err)
+	}
+
+	results, err := vs.Search(ctx, edgeEmbedding(0.1), 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "live" {
+		t.Errorf("Expected only the live edge, got %+v", results)
+	}
+
+	if err := vs.Delete(ctx, "live"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	results, err = vs.Search(ctx, edgeEmbedding(0), 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no results after Delete, got %+v", results)
+	}
+}
+
+func TestSQLiteEdgeVectorStore_ReaddedAndDeletedEdges(t *testing.T) {
+	gs, vs := openEdgeVectorStore(t)
+	ctx := context.Background()
+
+	edge := &Edge{ID: "e1", SourceID: "alice", Relation: "USES", TargetID: "go", Weight: 1}
+	if err := gs.AddEdge(ctx, edge); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	if err := vs.Add(ctx, "e1", edgeEmbedding(0)); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	// Re-extracting the edge updates it in place and keeps its vector
+	edge.Evidence = "Alice writes Go every day"
+	if err := gs.AddEdge(ctx, edge); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	results, err := vs.Search(ctx, edgeEmbedding(0), 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "e1" {
+		t.Fatalf("Expected the re-added edge to keep its vector, got %+v", results)
+	}
+	if err := vs.Add(ctx, "e1", edgeEmbedding(0.5)); err != nil {
+		t.Fatalf("Add after re-adding the edge failed: %v", err)
+	}
+
+	// Deleting the edge removes its vector, so the next mapping can reuse the rowid
+	if err := gs.DeleteEdge(ctx, "e1"); err != nil {
+		t.Fatalf("DeleteEdge failed: %v", err)
+	}
+	var vectors int
+	if err := gs.DB().QueryRowContext(ctx, `SELECT COUNT(*) FROM vec_edges`).Scan(&vectors); err != nil {
+		t.Fatalf("count vec_edges failed: %v", err)
+	}
+	if vectors != 0 {
+		t.Errorf("Expected the deleted edge's vector removed, got %d vectors", vectors)
+	}
+	if err := gs.AddEdge(ctx, &Edge{ID: "e2", SourceID: "bob", Relation: "KNOWS", TargetID: "alice", Weight: 1}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	if err := vs.Add(ctx, "e2", edgeEmbedding(1)); err != nil {
+		t.Fatalf("Add after deleting an edge failed: %v", err)
+	}
+}
@@ -3,9 +3,11 @@ package store
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -135,6 +137,62 @@ func TestAddNode_Upsert(t *testing.T) {
 	}
 }
 
+// TestAddNode_UpsertPreservesStats tests that upserts keep a node's creation time, access
+// stats and, when no new one is given, its embedding.
+func TestAddNode_UpsertPreservesStats(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	accessed := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	node := &Node{ID: "n1", Name: "Go", Type: "Technology", CreatedAt: created, Embedding: []float32{1, 2, 3}}
+	if err := store.AddNode(ctx, node); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if _, err := store.db.Exec("UPDATE nodes SET access_count = 3, last_accessed_at = ? WHERE id = ?", accessed, "n1"); err != nil {
+		t.Fatalf("failed to set access stats: %v", err)
+	}
+
+	// AddNodes upserts last, so its description is the one read back
+	upserts := []struct {
+		name   string
+		upsert func(*Node) error
+	}{
+		{"AddNode", func(n *Node) error { return store.AddNode(ctx, n) }},
+		{"AddNodes", func(n *Node) error { return store.AddNodes(ctx, []*Node{n}) }},
+	}
+	for _, tc := range upserts {
+		name, upsert := tc.name, tc.upsert
+		if err := upsert(&Node{ID: "n1", Name: "Golang", Type: "Technology", Description: name}); err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+
+		var createdAt, lastAccessed time.Time
+		var accessCount int
+		var embedding []byte
+		err := store.db.QueryRow("SELECT created_at, access_count, last_accessed_at, embedding FROM nodes WHERE id = ?", "n1").
+			Scan(&createdAt, &accessCount, &lastAccessed, &embedding)
+		if err != nil {
+			t.Fatalf("failed to read node: %v", err)
+		}
+		if !createdAt.Equal(created) || accessCount != 3 || !lastAccessed.Equal(accessed) {
+			t.Errorf("%s: expected created_at and access stats kept, got %v, %d, %v", name, createdAt, accessCount, lastAccessed)
+		}
+		if len(deserializeEmbedding(embedding)) != 3 {
+			t.Errorf("%s: expected the embedding kept, got %d bytes", name, len(embedding))
+		}
+	}
+
+	retrieved, err := store.GetNode(ctx, "n1")
+	if err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	if retrieved.Name != "Golang" || retrieved.Description != "AddNodes" {
+		t.Errorf("expected the extracted fields updated, got %+v", retrieved)
+	}
+}
+
 // TestFindNodesByName_CaseInsensitive tests case-insensitive name matching.
 func TestFindNodesByName_CaseInsensitive(t *testing.T) {
 	store := setupTestStore(t)
@@ -970,7 +1028,8 @@ func TestGetNode_UpdatesLastAccessed(t *testing.T) {
 		t.Fatalf("AddNode failed: %v", err)
 	}
 
-	// Get the node (should update last_accessed_at)
+	// Get the node (should buffer an update of last_accessed_at)
+	store.SetAccessFlush(-1, 0)
 	time.Sleep(10 * time.Millisecond) // Ensure time difference
 	retrieved, err := store.GetNode(ctx, "test-node-1")
 	if err != nil {
@@ -981,12 +1040,24 @@ func TestGetNode_UpdatesLastAccessed(t *testing.T) {
 		t.Fatal("Expected node, got nil")
 	}
 
-	// Verify last_accessed_at was set by querying directly
+	// The read itself does not write
 	var lastAccessed sql.NullTime
 	err = store.db.QueryRow("SELECT last_accessed_at FROM nodes WHERE id = ?", "test-node-1").Scan(&lastAccessed)
 	if err != nil {
 		t.Fatalf("Failed to query last_accessed_at: %v", err)
 	}
+	if lastAccessed.Valid {
+		t.Errorf("Expected last_accessed_at unset before the flush, got %v", lastAccessed.Time)
+	}
+
+	// Verify last_accessed_at was set by the flush by querying directly
+	if err := store.FlushAccessTimes(ctx); err != nil {
+		t.Fatalf("FlushAccessTimes failed: %v", err)
+	}
+	err = store.db.QueryRow("SELECT last_accessed_at FROM nodes WHERE id = ?", "test-node-1").Scan(&lastAccessed)
+	if err != nil {
+		t.Fatalf("Failed to query last_accessed_at: %v", err)
+	}
 
 	if !lastAccessed.Valid {
 		t.Error("Expected last_accessed_at to be set after GetNode, got NULL")
@@ -997,6 +1068,86 @@ func TestGetNode_UpdatesLastAccessed(t *testing.T) {
 	}
 }
 
+// TestGetNode_FlushesAccessInBackground tests that buffered reads are written once the
+// flush interval passes or the buffer fills up.
+func TestGetNode_FlushesAccessInBackground(t *testing.T) {
+	// Background writes need a file database: each connection to an in-memory one is separate
+	store, err := NewSQLiteGraphStore(filepath.Join(t.TempDir(), "access.db"))
+	if err != nil {
+		t.Fatalf("Failed to create test store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	for _, id := range []string{"a", "b", "c"} {
+		if err := store.AddNode(ctx, &Node{ID: id, Name: id, Type: "Concept"}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+
+	accessed := func(id string) bool {
+		var lastAccessed sql.NullTime
+		if err := store.db.QueryRow("SELECT last_accessed_at FROM nodes WHERE id = ?", id).Scan(&lastAccessed); err != nil {
+			t.Fatalf("Failed to query last_accessed_at: %v", err)
+		}
+		return lastAccessed.Valid
+	}
+	waitAccessed := func(id string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !accessed(id) {
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected the read of %s flushed", id)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	// By interval
+	store.SetAccessFlush(10*time.Millisecond, 0)
+	if _, err := store.GetNode(ctx, "a"); err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	waitAccessed("a")
+
+	// By size
+	store.SetAccessFlush(-1, 2)
+	if _, err := store.GetNode(ctx, "b"); err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	if accessed("b") {
+		t.Error("Expected a single read to stay buffered")
+	}
+	if _, err := store.GetNode(ctx, "c"); err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	waitAccessed("b")
+	waitAccessed("c")
+}
+
+// TestGetNode_InMemoryFlushesInline tests that in-memory stores write a full buffer in
+// the reading goroutine.
+func TestGetNode_InMemoryFlushesInline(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	if err := store.AddNode(ctx, &Node{ID: "a", Name: "a", Type: "Concept"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	store.SetAccessFlush(0, 1)
+	if _, err := store.GetNode(ctx, "a"); err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	var lastAccessed sql.NullTime
+	if err := store.db.QueryRow("SELECT last_accessed_at FROM nodes WHERE id = ?", "a").Scan(&lastAccessed); err != nil {
+		t.Fatalf("Failed to query last_accessed_at: %v", err)
+	}
+	if !lastAccessed.Valid {
+		t.Error("Expected the full buffer written by GetNode")
+	}
+}
+
 // TestUpdateAccessTime_BatchUpdate tests batch updating of last_accessed_at timestamps
 func TestUpdateAccessTime_BatchUpdate(t *testing.T) {
 	store := setupTestStore(t)
@@ -1379,3 +1530,227 @@ func TestFindNodesByName_HydratesLastAccessedAt(t *testing.T) {
 		t.Errorf("node-2 LastAccessedAt mismatch: got %v, want %v", nodes[1].LastAccessedAt, accessTime)
 	}
 }
+
+func TestIterateNodesAndEdges(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"node-a", "node-b", "node-c"} {
+		node := &Node{ID: id, Name: id, Type: "Concept", CreatedAt: base.Add(time.Duration(i) * time.Hour)}
+		if err := store.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode(%s) failed: %v", id, err)
+		}
+	}
+	if err := store.AddEdge(ctx, &Edge{ID: "e1", SourceID: "node-a", Relation: "USES", TargetID: "node-b", CreatedAt: base}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+	if err := store.AddEdge(ctx, &Edge{ID: "e2", SourceID: "node-b", Relation: "USES", TargetID: "node-c", CreatedAt: base.Add(time.Hour)}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+
+	var nodeIDs []string
+	if err := store.IterateNodes(ctx, func(n *Node) error {
+		nodeIDs = append(nodeIDs, n.ID)
+		return nil
+	}); err != nil {
+		t.Fatalf("IterateNodes failed: %v", err)
+	}
+	if strings.Join(nodeIDs, ",") != "node-a,node-b,node-c" {
+		t.Errorf("IterateNodes order: got %v", nodeIDs)
+	}
+
+	var edgeIDs []string
+	if err := store.IterateEdges(ctx, func(e *Edge) error {
+		edgeIDs = append(edgeIDs, e.ID)
+		return nil
+	}); err != nil {
+		t.Fatalf("IterateEdges failed: %v", err)
+	}
+	if strings.Join(edgeIDs, ",") != "e1,e2" {
+		t.Errorf("IterateEdges order: got %v", edgeIDs)
+	}
+
+	// Callback errors stop iteration and are returned unchanged
+	stop := errors.New("stop")
+	visited := 0
+	err := store.IterateNodes(ctx, func(n *Node) error {
+		visited++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("Expected callback error, got %v", err)
+	}
+	if visited != 1 {
+		t.Errorf("Expected iteration to stop after 1 node, visited %d", visited)
+	}
+}
+
+func TestEdgeExpiry(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b", "c"} {
+		if err := store.AddNode(ctx, &Node{ID: id, Name: id}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	edges := []*Edge{
+		{ID: "live", SourceID: "a", Relation: "KNOWS", TargetID: "b"},
+		{ID: "expired", SourceID: "a", Relation: "ON_CALL", TargetID: "c", ExpiresAt: &past},
+		{ID: "pending", SourceID: "b", Relation: "ON_CALL", TargetID: "c", ExpiresAt: &future},
+	}
+	for _, e := range edges {
+		if err := store.AddEdge(ctx, e); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+
+	got, err := store.GetEdges(ctx, "c")
+	if err != nil {
+		t.Fatalf("GetEdges failed: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "pending" {
+		t.Fatalf("Expected only the unexpired edge, got %+v", got)
+	}
+	if got[0].ExpiresAt == nil || !got[0].ExpiresAt.Equal(future) {
+		t.Errorf("Expected ExpiresAt %v, got %v", future, got[0].ExpiresAt)
+	}
+
+	// Expired edges are not traversed
+	neighbors, err := store.GetNeighbors(ctx, "a", 1)
+	if err != nil {
+		t.Fatalf("GetNeighbors failed: %v", err)
+	}
+	if len(neighbors) != 1 || neighbors[0].ID != "b" {
+		t.Errorf("Expected only b as neighbor of a, got %+v", neighbors)
+	}
+
+	deleted, err := store.DeleteExpiredEdges(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("DeleteExpiredEdges failed: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "expired" {
+		t.Errorf("Expected the expired edge deleted, got %v", deleted)
+	}
+	count, err := store.EdgeCount(ctx)
+	if err != nil {
+		t.Fatalf("EdgeCount failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 edges remaining, got %d", count)
+	}
+}
+
+func TestEdgeProvenanceRoundTrip(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b"} {
+		if err := store.AddNode(ctx, &Node{ID: id, Name: id}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+
+	edges := []*Edge{
+		{ID: "cited", SourceID: "a", Relation: "USES", TargetID: "b", SourceChunkID: "chunk-1", Evidence: "A uses B."},
+		{ID: "legacy", SourceID: "b", Relation: "KNOWS", TargetID: "a"},
+	}
+	for _, e := range edges {
+		if err := store.AddEdge(ctx, e); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+
+	got, err := store.GetEdges(ctx, "a")
+	if err != nil {
+		t.Fatalf("GetEdges failed: %v", err)
+	}
+	byID := make(map[string]*Edge)
+	for _, e := range got {
+		byID[e.ID] = e
+	}
+	if e := byID["cited"]; e == nil || e.SourceChunkID != "chunk-1" || e.Evidence != "A uses B." {
+		t.Errorf("Expected provenance on cited edge, got %+v", e)
+	}
+	if e := byID["legacy"]; e == nil || e.SourceChunkID != "" || e.Evidence != "" {
+		t.Errorf("Expected empty provenance on legacy edge, got %+v", e)
+	}
+}
+
+func TestIncrementMentionCounts(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	if err := store.AddNode(ctx, &Node{ID: "react", Name: "React"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := store.IncrementMentionCounts(ctx, []string{"react", "unknown"}); err != nil {
+			t.Fatalf("IncrementMentionCounts failed: %v", err)
+		}
+	}
+
+	// Upserting the node must not reset its count
+	if err := store.AddNode(ctx, &Node{ID: "react", Name: "React", Description: "updated"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+
+	node, err := store.GetNode(ctx, "react")
+	if err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	if node.MentionCount != 3 {
+		t.Errorf("Expected MentionCount 3, got %d", node.MentionCount)
+	}
+}
+
+func TestSetNodePinned(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	if err := store.AddNode(ctx, &Node{ID: "product", Name: "Gognee"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if err := store.SetNodePinned(ctx, "product", true, "core product"); err != nil {
+		t.Fatalf("SetNodePinned failed: %v", err)
+	}
+
+	// Upserting the node must not unpin it
+	if err := store.AddNode(ctx, &Node{ID: "product", Name: "Gognee", Description: "updated"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+
+	node, err := store.GetNode(ctx, "product")
+	if err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	if !node.Pinned || node.PinnedAt == nil || node.PinReason != "core product" {
+		t.Errorf("Expected pinned node with time and reason, got pinned=%v at=%v reason=%q", node.Pinned, node.PinnedAt, node.PinReason)
+	}
+
+	if err := store.SetNodePinned(ctx, "product", false, ""); err != nil {
+		t.Fatalf("SetNodePinned failed: %v", err)
+	}
+	nodes, err := store.GetAllNodes(ctx)
+	if err != nil {
+		t.Fatalf("GetAllNodes failed: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Pinned || nodes[0].PinnedAt != nil || nodes[0].PinReason != "" {
+		t.Errorf("Expected unpinned node, got %+v", nodes[0])
+	}
+
+	if err := store.SetNodePinned(ctx, "missing", true, ""); err == nil {
+		t.Error("Expected error pinning a missing node")
+	}
+}
@@ -18,7 +18,8 @@ import (
 // - Legacy nodes.embedding column is maintained for backwards compatibility
 // - The database connection is shared with SQLiteGraphStore and must not be closed by this store
 type SQLiteVectorStore struct {
-	db *sql.DB
+	db        *sql.DB
+	namespace string // Restricts search results to nodes in this namespace (see WithNamespace)
 }
 
 // NewSQLiteVectorStore creates a new SQLite-backed vector store.
@@ -41,6 +42,9 @@ func (s *SQLiteVectorStore) Add(ctx context.Context, id string, embedding []floa
 	if len(embedding) == 0 {
 		return fmt.Errorf("embedding cannot be empty")
 	}
+	if err := checkVecDimensions(ctx, s.db, "vec_nodes", "vec_node_ids", len(embedding), true); err != nil {
+		return err
+	}
 
 	// Verify node exists
 	var exists int
@@ -60,9 +64,23 @@ func (s *SQLiteVectorStore) Add(ctx context.Context, id string, embedding []floa
 	}
 	defer tx.Rollback()
 
+	if err := writeNodeVector(ctx, tx, id, embedding); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// writeNodeVector stores a node's embedding in vec_nodes and the legacy embedding
+// column within tx, replacing any previous vector.
+func writeNodeVector(ctx context.Context, tx *sql.Tx, id string, embedding []float32) error {
 	// Get or create rowid mapping
 	var rowid int64
-	err = tx.QueryRowContext(ctx, `SELECT rowid FROM vec_node_ids WHERE node_id = ?`, id).Scan(&rowid)
+	err := tx.QueryRowContext(ctx, `SELECT rowid FROM vec_node_ids WHERE node_id = ?`, id).Scan(&rowid)
 	if err == sql.ErrNoRows {
 		// Insert new mapping (rowid will be auto-generated)
 		result, err := tx.ExecContext(ctx, `INSERT INTO vec_node_ids (node_id) VALUES (?)`, id)
@@ -97,11 +115,6 @@ func (s *SQLiteVectorStore) Add(ctx context.Context, id string, embedding []floa
 	if err != nil {
 		return fmt.Errorf("failed to update nodes embedding column: %w", err)
 	}
-
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
 	return nil
 }
 
@@ -113,15 +126,44 @@ func (s *SQLiteVectorStore) Add(ctx context.Context, id string, embedding []floa
 // - Returns distance metric from vec0, converted to similarity score (1 - distance)
 // - Maps rowid back to node string ID via vec_node_ids table
 // - Results are sorted by similarity score in descending order (best matches first)
+// - Only nodes in the store's namespace are returned
 // - Returns up to topK results
 func (s *SQLiteVectorStore) Search(ctx context.Context, query []float32, topK int) ([]SearchResult, error) {
+	return s.SearchFiltered(ctx, query, topK, NodeFilter{})
+}
+
+// SearchFiltered is Search restricted to nodes matching filter.
+func (s *SQLiteVectorStore) SearchFiltered(ctx context.Context, query []float32, topK int, filter NodeFilter) ([]SearchResult, error) {
 	if len(query) == 0 {
 		return []SearchResult{}, nil
 	}
-
-	// Serialize query embedding for vec0 MATCH
+	if err := checkVecDimensions(ctx, s.db, "vec_nodes", "vec_node_ids", len(query), false); err != nil {
+		return nil, err
+	}
 	queryBlob := serializeEmbedding(query)
 
+	// The namespace and node filters are applied after the KNN step, so widen k until
+	// enough matching results are found or every vector has been considered.
+	k := topK
+	for {
+		results, candidates, err := s.searchK(ctx, queryBlob, k, filter)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) >= topK || candidates < k {
+			if len(results) > topK {
+				results = results[:topK]
+			}
+			return results, nil
+		}
+		k *= 4
+	}
+}
+
+// searchK runs one vec0 KNN query for the k nearest vectors and returns those in the
+// store's namespace that match filter, along with the number of KNN candidates before
+// filtering.
+func (s *SQLiteVectorStore) searchK(ctx context.Context, queryBlob []byte, k int, filter NodeFilter) ([]SearchResult, int, error) {
 	// vec0 MATCH query with distance metric
 	// The MATCH operator returns results ordered by distance (ascending)
 	// We'll convert distance to similarity score (1 - distance for cosine-like behavior)
@@ -129,24 +171,39 @@ func (s *SQLiteVectorStore) Search(ctx context.Context, query []float32, topK in
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT 
 			vec_node_ids.node_id,
-			distance
+			distance,
+			nodes.namespace,
+			nodes.type,
+			nodes.created_at
 		FROM vec_nodes
 		INNER JOIN vec_node_ids ON vec_nodes.rowid = vec_node_ids.rowid
-		WHERE embedding MATCH ? AND k = ?
+		LEFT JOIN nodes ON nodes.id = vec_node_ids.node_id
+		WHERE vec_nodes.embedding MATCH ? AND k = ?
 		ORDER BY distance
-	`, queryBlob, topK)
+	`, queryBlob, k)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute vec0 search: %w", err)
+		return nil, 0, fmt.Errorf("failed to execute vec0 search: %w", err)
 	}
 	defer rows.Close()
 
 	var results []SearchResult
+	candidates := 0
 	for rows.Next() {
 		var nodeID string
 		var distance float64
+		var namespace, nodeType sql.NullString
+		var createdAt sql.NullTime
 
-		if err := rows.Scan(&nodeID, &distance); err != nil {
-			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		if err := rows.Scan(&nodeID, &distance, &namespace, &nodeType, &createdAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		candidates++
+
+		if namespace.String != s.namespace {
+			continue
+		}
+		if !filter.IsZero() && !filter.matches(nodeType.String, createdAt.Time) {
+			continue
 		}
 
 		// Convert distance to similarity score
@@ -161,10 +218,10 @@ func (s *SQLiteVectorStore) Search(ctx context.Context, query []float32, topK in
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating search results: %w", err)
+		return nil, 0, fmt.Errorf("error iterating search results: %w", err)
 	}
 
-	return results, nil
+	return results, candidates, nil
 }
 
 // Delete removes the embedding for the given node ID.
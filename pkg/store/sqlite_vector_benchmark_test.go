@@ -31,7 +31,8 @@ func setupBenchmarkDB(b *testing.B) (*sql.DB, func()) {
 			description TEXT,
 			embedding BLOB,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			metadata TEXT
+			metadata TEXT,
+			namespace TEXT NOT NULL DEFAULT ''
 		);
 
 		CREATE VIRTUAL TABLE vec_nodes USING vec0(
@@ -357,7 +357,8 @@ func TestSQLiteVectorStore_Persistence(t *testing.T) {
 			description TEXT,
 			embedding BLOB,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			metadata TEXT
+			metadata TEXT,
+			namespace TEXT NOT NULL DEFAULT ''
 		);
 
 		CREATE VIRTUAL TABLE vec_nodes USING vec0(
@@ -511,7 +512,8 @@ func setupTestDB(t *testing.T) (*sql.DB, func()) {
 			description TEXT,
 			embedding BLOB,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			metadata TEXT
+			metadata TEXT,
+			namespace TEXT NOT NULL DEFAULT ''
 		);
 
 		CREATE VIRTUAL TABLE vec_nodes USING vec0(
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -282,3 +283,180 @@ func TestSupersession_NoChain(t *testing.T) {
 		t.Errorf("Expected no superseded memories, got %d", len(supersededIDs))
 	}
 }
+
+// TestSupersession_RejectsCycles tests that supersessions closing a cycle are refused
+func TestSupersession_RejectsCycles(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	memStore := NewSQLiteMemoryStore(store.DB())
+	mems := make([]*MemoryRecord, 3)
+	for i := range mems {
+		mems[i] = &MemoryRecord{Topic: "Decision", Context: "Version " + string(rune('1'+i)), Status: "Active"}
+		if err := memStore.AddMemory(ctx, mems[i]); err != nil {
+			t.Fatalf("Failed to add memory %d: %v", i+1, err)
+		}
+	}
+
+	// v2 supersedes v1, v3 supersedes v2
+	if err := memStore.RecordSupersession(ctx, mems[1].ID, mems[0].ID, "Update 1"); err != nil {
+		t.Fatalf("RecordSupersession 1 failed: %v", err)
+	}
+	if err := memStore.RecordSupersession(ctx, mems[2].ID, mems[1].ID, "Update 2"); err != nil {
+		t.Fatalf("RecordSupersession 2 failed: %v", err)
+	}
+
+	for _, pair := range [][2]*MemoryRecord{{mems[0], mems[1]}, {mems[0], mems[2]}, {mems[1], mems[1]}} {
+		if err := memStore.RecordSupersession(ctx, pair[0].ID, pair[1].ID, "Cycle"); !errors.Is(err, ErrSupersessionCycle) {
+			t.Errorf("Expected ErrSupersessionCycle, got %v", err)
+		}
+	}
+
+	head, err := memStore.GetSupersessionHead(ctx, mems[0].ID)
+	if err != nil {
+		t.Fatalf("GetSupersessionHead failed: %v", err)
+	}
+	if head != mems[2].ID {
+		t.Errorf("Expected head %s, got %s", mems[2].ID, head)
+	}
+	if head, err := memStore.GetSupersessionHead(ctx, mems[2].ID); err != nil || head != mems[2].ID {
+		t.Errorf("Expected the unsuperseded memory to be its own head, got %s, %v", head, err)
+	}
+	if _, err := memStore.GetSupersessionHead(ctx, "missing"); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected ErrMemoryNotFound, got %v", err)
+	}
+}
+
+// TestSupersession_ExistingCycleTerminates tests chain traversal over a cycle recorded
+// before cycles were refused
+func TestSupersession_ExistingCycleTerminates(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	memStore := NewSQLiteMemoryStore(store.DB())
+	mem1 := &MemoryRecord{Topic: "Decision v1", Context: "Original", Status: "Active"}
+	mem2 := &MemoryRecord{Topic: "Decision v2", Context: "Update", Status: "Active"}
+	for _, mem := range []*MemoryRecord{mem1, mem2} {
+		if err := memStore.AddMemory(ctx, mem); err != nil {
+			t.Fatalf("Failed to add memory: %v", err)
+		}
+	}
+	if _, err := store.DB().Exec(`
+		INSERT INTO memory_supersession (id, superseding_id, superseded_id, reason) VALUES ('s1', ?, ?, ''), ('s2', ?, ?, '');
+		UPDATE memories SET superseded_by = ? WHERE id = ?;
+		UPDATE memories SET superseded_by = ? WHERE id = ?;
+	`, mem2.ID, mem1.ID, mem1.ID, mem2.ID, mem2.ID, mem1.ID, mem1.ID, mem2.ID); err != nil {
+		t.Fatalf("Failed to record cycle: %v", err)
+	}
+
+	chain, err := memStore.GetSupersessionChain(ctx, mem1.ID)
+	if err != nil {
+		t.Fatalf("GetSupersessionChain failed: %v", err)
+	}
+	if len(chain) != 2 {
+		t.Errorf("Expected the cycle's 2 links once each, got %d", len(chain))
+	}
+	if _, err := memStore.GetSupersessionHead(ctx, mem1.ID); !errors.Is(err, ErrSupersessionCycle) {
+		t.Errorf("Expected ErrSupersessionCycle, got %v", err)
+	}
+}
+
+// plainMemoryStore hides the optional capabilities of the store it wraps
+type plainMemoryStore struct {
+	MemoryStore
+}
+
+// TestSupersessionHead tests resolving the head with and without SupersessionResolver,
+// and that other namespaces' memories are not found
+func TestSupersessionHead(t *testing.T) {
+	ctx := context.Background()
+	a, b := openNamespacedStores(t)
+	memStore := NewSQLiteMemoryStore(a.DB()).WithNamespace("project-a")
+	otherStore := NewSQLiteMemoryStore(b.DB()).WithNamespace("project-b")
+
+	mems := make([]*MemoryRecord, 3)
+	for i := range mems {
+		mems[i] = &MemoryRecord{Topic: "Decision", Context: "Version " + string(rune('1'+i)), Status: "Active"}
+		if err := memStore.AddMemory(ctx, mems[i]); err != nil {
+			t.Fatalf("Failed to add memory %d: %v", i+1, err)
+		}
+	}
+	for i := 1; i < len(mems); i++ {
+		if err := memStore.RecordSupersession(ctx, mems[i].ID, mems[i-1].ID, "Update"); err != nil {
+			t.Fatalf("RecordSupersession failed: %v", err)
+		}
+	}
+
+	for _, memories := range []MemoryStore{memStore, plainMemoryStore{memStore}} {
+		if head, err := SupersessionHead(ctx, memories, mems[0].ID); err != nil || head != mems[2].ID {
+			t.Errorf("Expected head %s, got %s (%v)", mems[2].ID, head, err)
+		}
+		if _, err := SupersessionHead(ctx, memories, "missing"); !errors.Is(err, ErrMemoryNotFound) {
+			t.Errorf("Expected ErrMemoryNotFound, got %v", err)
+		}
+	}
+
+	if _, err := otherStore.GetSupersedingMemory(ctx, mems[0].ID); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected another namespace's memory not found, got %v", err)
+	}
+	if _, err := otherStore.GetSupersessionHead(ctx, mems[0].ID); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected another namespace's chain not found, got %v", err)
+	}
+}
+
+func TestConsolidateMemory_Atomic(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+	memStore := NewSQLiteMemoryStore(store.DB())
+
+	current := &MemoryRecord{Topic: "Note 1", Context: "First note", Status: "complete"}
+	old := &MemoryRecord{Topic: "Note 2", Context: "Second note", Status: "complete"}
+	newer := &MemoryRecord{Topic: "Note 3", Context: "Third note", Status: "complete"}
+	for _, mem := range []*MemoryRecord{current, old, newer} {
+		if err := memStore.AddMemory(ctx, mem); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+	}
+	if err := memStore.RecordSupersession(ctx, newer.ID, old.ID, "replaced"); err != nil {
+		t.Fatalf("RecordSupersession failed: %v", err)
+	}
+
+	// A source superseded in the meantime fails the whole consolidation
+	merged := &MemoryRecord{Topic: "Merged", Context: "All notes", Status: "complete"}
+	err = memStore.ConsolidateMemory(ctx, merged, nil, nil, []string{current.ID, old.ID}, "consolidated")
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("Expected a validation error for a superseded source, got %v", err)
+	}
+	if _, err := memStore.GetMemory(ctx, merged.ID); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected no consolidated memory after the failure, got %v", err)
+	}
+	if mem, err := memStore.GetMemory(ctx, current.ID); err != nil || mem.Status != "complete" {
+		t.Errorf("Expected the current source untouched, got %+v (%v)", mem, err)
+	}
+
+	merged = &MemoryRecord{Topic: "Merged", Context: "All notes", Status: "complete"}
+	if err := memStore.ConsolidateMemory(ctx, merged, nil, nil, []string{current.ID, newer.ID}, "consolidated"); err != nil {
+		t.Fatalf("ConsolidateMemory failed: %v", err)
+	}
+	for _, id := range []string{current.ID, newer.ID} {
+		mem, err := memStore.GetMemory(ctx, id)
+		if err != nil {
+			t.Fatalf("GetMemory failed: %v", err)
+		}
+		if mem.Status != "Superseded" || mem.SupersededBy == nil || *mem.SupersededBy != merged.ID {
+			t.Errorf("Expected %s superseded by the consolidated memory, got %+v", id, mem)
+		}
+	}
+}
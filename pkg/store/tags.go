@@ -0,0 +1,182 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// migrateTagSchema creates the memory tag table.
+func (s *SQLiteGraphStore) migrateTagSchema() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS memory_tags (
+		memory_id TEXT NOT NULL,
+		tag TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (memory_id, tag),
+		FOREIGN KEY (memory_id) REFERENCES memories(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_memory_tags_tag ON memory_tags(tag);
+	`
+
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create memory_tags table: %w", err)
+	}
+	return nil
+}
+
+// NormalizeTags trims and lower-cases tags and drops duplicates, preserving order.
+// Empty tags are rejected.
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return nil, fmt.Errorf("tag must not be empty")
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
+
+// AddTags attaches tags to a memory. Tags already present are left unchanged.
+func (s *SQLiteMemoryStore) AddTags(ctx context.Context, id string, tags ...string) error {
+	tags, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	if err := s.requireMemory(ctx, id); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, tag := range tags {
+		if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO memory_tags (memory_id, tag) VALUES (?, ?)", id, tag); err != nil {
+			return fmt.Errorf("failed to add tag: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// RemoveTags detaches tags from a memory. Tags the memory does not carry are ignored.
+func (s *SQLiteMemoryStore) RemoveTags(ctx context.Context, id string, tags ...string) error {
+	tags, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	if err := s.requireMemory(ctx, id); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("DELETE FROM memory_tags WHERE memory_id = ? AND tag IN (%s)", sqlPlaceholders(len(tags)))
+	args := []interface{}{id}
+	for _, tag := range tags {
+		args = append(args, tag)
+	}
+	if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to remove tags: %w", err)
+	}
+	return nil
+}
+
+// ListTags returns the tags of a memory, sorted alphabetically.
+func (s *SQLiteMemoryStore) ListTags(ctx context.Context, id string) ([]string, error) {
+	if err := s.requireMemory(ctx, id); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT tag FROM memory_tags WHERE memory_id = ? ORDER BY tag", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]string, 0)
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tags: %w", err)
+	}
+	return tags, nil
+}
+
+// TaggedNodeIDs returns the IDs of the nodes derived from memories (excluding soft-deleted
+// ones) that carry all of the given tags.
+func (s *SQLiteMemoryStore) TaggedNodeIDs(ctx context.Context, tags []string) (map[string]bool, error) {
+	tags, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	clause, tagArgs := tagFilterClause("m.id", tags)
+	query := `
+		SELECT DISTINCT mn.node_id
+		FROM memory_nodes mn
+		JOIN memories m ON mn.memory_id = m.id
+		WHERE m.namespace = ? AND m.status != ?` + clause
+	args := append([]interface{}{s.namespace, StatusDeleted}, tagArgs...)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tagged nodes: %w", err)
+	}
+	defer rows.Close()
+
+	nodeIDs := make(map[string]bool)
+	for rows.Next() {
+		var nodeID string
+		if err := rows.Scan(&nodeID); err != nil {
+			return nil, fmt.Errorf("failed to scan node ID: %w", err)
+		}
+		nodeIDs[nodeID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tagged nodes: %w", err)
+	}
+	return nodeIDs, nil
+}
+
+// tagFilterClause returns an " AND ..." clause restricting idColumn to memories that
+// carry all of tags, with its arguments. tags must already be normalized.
+func tagFilterClause(idColumn string, tags []string) (string, []interface{}) {
+	if len(tags) == 0 {
+		return "", nil
+	}
+	args := make([]interface{}, 0, len(tags)+1)
+	for _, tag := range tags {
+		args = append(args, tag)
+	}
+	args = append(args, len(tags))
+
+	clause := fmt.Sprintf(` AND %s IN (
+		SELECT memory_id FROM memory_tags WHERE tag IN (%s)
+		GROUP BY memory_id HAVING COUNT(*) = ?
+	)`, idColumn, sqlPlaceholders(len(tags)))
+	return clause, args
+}
+
+// sqlPlaceholders returns n comma-separated SQL placeholders.
+func sqlPlaceholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
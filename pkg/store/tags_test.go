@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMemoryTags(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+
+	both := &MemoryRecord{Topic: "Deploys", Context: "Blue/green.", DocHash: "h1", Tags: []string{" Ops ", "infra", "ops"}}
+	opsOnly := &MemoryRecord{Topic: "Paging", Context: "On-call rota.", DocHash: "h2"}
+	for _, mem := range []*MemoryRecord{both, opsOnly} {
+		if err := memStore.AddMemory(ctx, mem); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+	}
+	if err := memStore.AddTags(ctx, opsOnly.ID, "OPS", "ops"); err != nil {
+		t.Fatalf("AddTags failed: %v", err)
+	}
+
+	tags, err := memStore.ListTags(ctx, both.ID)
+	if err != nil || !reflect.DeepEqual(tags, []string{"infra", "ops"}) {
+		t.Errorf("Expected normalized tags [infra ops], got %v (err %v)", tags, err)
+	}
+	record, _ := memStore.GetMemory(ctx, both.ID)
+	if !reflect.DeepEqual(record.Tags, []string{"infra", "ops"}) {
+		t.Errorf("Expected GetMemory to load tags, got %v", record.Tags)
+	}
+
+	listed, err := memStore.ListMemories(ctx, ListMemoriesOptions{Tags: []string{"ops"}})
+	if err != nil || len(listed) != 2 {
+		t.Errorf("Expected 2 memories tagged ops, got %d (err %v)", len(listed), err)
+	}
+	listed, err = memStore.ListMemories(ctx, ListMemoriesOptions{Tags: []string{"Ops", "infra"}})
+	if err != nil || len(listed) != 1 || listed[0].ID != both.ID {
+		t.Errorf("Expected only the memory carrying both tags, got %v (err %v)", listed, err)
+	}
+
+	if err := memStore.RemoveTags(ctx, both.ID, "infra", "unknown"); err != nil {
+		t.Fatalf("RemoveTags failed: %v", err)
+	}
+	listed, _ = memStore.ListMemories(ctx, ListMemoriesOptions{Tags: []string{"infra"}})
+	if len(listed) != 0 {
+		t.Errorf("Expected no memories tagged infra after removal, got %d", len(listed))
+	}
+
+	if err := memStore.AddTags(ctx, both.ID, " "); err == nil {
+		t.Error("Expected error for empty tag")
+	}
+	if err := memStore.AddTags(ctx, "missing", "ops"); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("Expected ErrMemoryNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Housekeeping passes whose last run is recorded with RecordHousekeeping.
+const (
+	HousekeepingGC          = "gc"
+	HousekeepingPrune       = "prune"
+	HousekeepingMaintenance = "maintenance"
+)
+
+// MemoryStats breaks the memories of a namespace down for dashboards.
+type MemoryStats struct {
+	ByStatus          map[string]int64 // Memory count per status, including soft-deleted memories
+	ByRetentionPolicy map[string]int64 // Memory count per retention policy, excluding soft-deleted memories
+	Pinned            int64            // Pinned memories, excluding soft-deleted ones
+	AverageScore      float64          // Mean decay score (see MemoryScore), excluding soft-deleted memories; 0 when there are none
+}
+
+// housekeepingKey is the store_metadata key of the last run of a pass in a namespace
+func housekeepingKey(namespace, pass string) string {
+	return "last_" + pass + ":" + namespace
+}
+
+// RecordHousekeeping records that a housekeeping pass (HousekeepingGC, HousekeepingPrune,
+// HousekeepingMaintenance) ran in this store's namespace at the given time.
+func (s *SQLiteGraphStore) RecordHousekeeping(ctx context.Context, pass string, at time.Time) error {
+	if _, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO store_metadata (key, value) VALUES (?, ?)`,
+		housekeepingKey(s.namespace, pass), at.UTC().Format(time.RFC3339Nano)); err != nil {
+		return fmt.Errorf("failed to record %s run: %w", pass, err)
+	}
+	return nil
+}
+
+// LastHousekeeping returns when each housekeeping pass last ran in this store's
+// namespace. Passes that never ran are left out.
+func (s *SQLiteGraphStore) LastHousekeeping(ctx context.Context) (map[string]time.Time, error) {
+	passes := []string{HousekeepingGC, HousekeepingPrune, HousekeepingMaintenance}
+	keys := make([]interface{}, len(passes))
+	for i, pass := range passes {
+		keys[i] = housekeepingKey(s.namespace, pass)
+	}
+	rows, err := s.db.QueryContext(ctx, "SELECT key, value FROM store_metadata WHERE key IN (?, ?, ?)", keys...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read housekeeping runs: %w", err)
+	}
+	defer rows.Close()
+
+	last := make(map[string]time.Time, len(passes))
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan housekeeping run: %w", err)
+		}
+		at, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time recorded for %s: %w", key, err)
+		}
+		pass := strings.TrimPrefix(strings.TrimSuffix(key, ":"+s.namespace), "last_")
+		last[pass] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating housekeeping runs: %w", err)
+	}
+	return last, nil
+}
+
+// NodeCountsByType returns the number of nodes of each type.
+func (s *SQLiteGraphStore) NodeCountsByType(ctx context.Context) (map[string]int64, error) {
+	return s.countBy(ctx, "SELECT type, COUNT(*) FROM nodes WHERE namespace = ? GROUP BY type", "node types")
+}
+
+// EdgeCountsByRelation returns the number of edges with each relation.
+func (s *SQLiteGraphStore) EdgeCountsByRelation(ctx context.Context) (map[string]int64, error) {
+	return s.countBy(ctx, "SELECT relation, COUNT(*) FROM edges WHERE namespace = ? GROUP BY relation", "edge relations")
+}
+
+// countBy runs a "SELECT key, COUNT(*) ... GROUP BY key" query scoped to the namespace
+func (s *SQLiteGraphStore) countBy(ctx context.Context, query, what string) (map[string]int64, error) {
+	rows, err := s.db.QueryContext(ctx, query, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count %s: %w", what, err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var key sql.NullString
+		var count int64
+		if err := rows.Scan(&key, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", what, err)
+		}
+		counts[key.String] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating %s: %w", what, err)
+	}
+	return counts, nil
+}
+
+// DatabaseSize returns the size of the database in bytes (page count × page size),
+// shared by all namespaces. In-memory databases report their allocated pages.
+func (s *SQLiteGraphStore) DatabaseSize(ctx context.Context) (int64, error) {
+	var pageCount, pageSize int64
+	if err := s.db.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pageCount); err != nil {
+		return 0, fmt.Errorf("failed to read page count: %w", err)
+	}
+	if err := s.db.QueryRowContext(ctx, "PRAGMA page_size").Scan(&pageSize); err != nil {
+		return 0, fmt.Errorf("failed to read page size: %w", err)
+	}
+	return pageCount * pageSize, nil
+}
+
+// MemoryStats counts the memories by status and retention policy and averages their
+// decay scores. Listing does not count as an access.
+func (s *SQLiteMemoryStore) MemoryStats(ctx context.Context) (MemoryStats, error) {
+	stats := MemoryStats{ByStatus: make(map[string]int64), ByRetentionPolicy: make(map[string]int64)}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT status, retention_policy, pinned, created_at, last_accessed_at, access_count
+		FROM memories WHERE namespace = ?
+	`, s.namespace)
+	if err != nil {
+		return stats, fmt.Errorf("failed to query memory stats: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	var scored int64
+	var totalScore float64
+	for rows.Next() {
+		var status string
+		var retentionPolicy sql.NullString
+		var pinned bool
+		var createdAt time.Time
+		var lastAccessedAt *time.Time
+		var accessCount int
+		if err := rows.Scan(&status, &retentionPolicy, &pinned, &createdAt, &lastAccessedAt, &accessCount); err != nil {
+			return stats, fmt.Errorf("failed to scan memory stats: %w", err)
+		}
+		stats.ByStatus[status]++
+		if status == StatusDeleted {
+			continue
+		}
+		stats.ByRetentionPolicy[retentionPolicy.String]++
+		if pinned {
+			stats.Pinned++
+		}
+		totalScore += s.decayPolicy.MemoryScore(retentionPolicy.String, pinned, createdAt, lastAccessedAt, accessCount, now)
+		scored++
+	}
+	if err := rows.Err(); err != nil {
+		return stats, fmt.Errorf("error iterating memory stats: %w", err)
+	}
+	if scored > 0 {
+		stats.AverageScore = totalScore / float64(scored)
+	}
+	return stats, nil
+}
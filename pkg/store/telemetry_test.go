@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHousekeepingAndCounts(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "telemetry.db")
+	team, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer team.Close()
+	team.WithNamespace("team")
+	other, err := NewSQLiteGraphStore(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer other.Close()
+	other.WithNamespace("other")
+
+	for _, node := range []*Node{{ID: "a", Name: "Alice", Type: "Person"}, {ID: "b", Name: "Go", Type: "Technology"}} {
+		if err := team.AddNode(ctx, node); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	if err := team.AddEdge(ctx, &Edge{ID: "e", SourceID: "a", Relation: "USES", TargetID: "b", Weight: 1}); err != nil {
+		t.Fatalf("AddEdge failed: %v", err)
+	}
+
+	byType, err := team.NodeCountsByType(ctx)
+	if err != nil || byType["Person"] != 1 || byType["Technology"] != 1 {
+		t.Errorf("Unexpected node counts %v (%v)", byType, err)
+	}
+	byRelation, err := team.EdgeCountsByRelation(ctx)
+	if err != nil || byRelation["USES"] != 1 {
+		t.Errorf("Unexpected edge counts %v (%v)", byRelation, err)
+	}
+	if counts, err := other.NodeCountsByType(ctx); err != nil || len(counts) != 0 {
+		t.Errorf("Expected no nodes in the other namespace, got %v (%v)", counts, err)
+	}
+	if size, err := team.DatabaseSize(ctx); err != nil || size <= 0 {
+		t.Errorf("Expected a database size, got %d (%v)", size, err)
+	}
+
+	pruned := time.Now().Add(-time.Hour).Round(0)
+	if err := team.RecordHousekeeping(ctx, HousekeepingPrune, pruned); err != nil {
+		t.Fatalf("RecordHousekeeping failed: %v", err)
+	}
+	last, err := team.LastHousekeeping(ctx)
+	if err != nil {
+		t.Fatalf("LastHousekeeping failed: %v", err)
+	}
+	if len(last) != 1 || !last[HousekeepingPrune].Equal(pruned) {
+		t.Errorf("Expected only the prune run at %v, got %v", pruned, last)
+	}
+	if last, err := other.LastHousekeeping(ctx); err != nil || len(last) != 0 {
+		t.Errorf("Expected no runs in the other namespace, got %v (%v)", last, err)
+	}
+}
@@ -37,7 +37,7 @@ var _ DocumentTracker = (*SQLiteGraphStore)(nil)
 func (s *SQLiteGraphStore) IsDocumentProcessed(ctx context.Context, hash string) (bool, error) {
 	var count int
 	err := s.db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM processed_documents WHERE hash = ?", hash).Scan(&count)
+		"SELECT COUNT(*) FROM processed_documents WHERE hash = ?", s.documentKey(hash)).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("failed to check document processed status: %w", err)
 	}
@@ -47,9 +47,9 @@ func (s *SQLiteGraphStore) IsDocumentProcessed(ctx context.Context, hash string)
 // MarkDocumentProcessed records that a document has been successfully processed.
 func (s *SQLiteGraphStore) MarkDocumentProcessed(ctx context.Context, hash, source string, chunkCount int) error {
 	_, err := s.db.ExecContext(ctx,
-		`INSERT OR REPLACE INTO processed_documents (hash, source, processed_at, chunk_count)
-		 VALUES (?, ?, CURRENT_TIMESTAMP, ?)`,
-		hash, source, chunkCount)
+		`INSERT OR REPLACE INTO processed_documents (hash, source, processed_at, chunk_count, namespace)
+		 VALUES (?, ?, CURRENT_TIMESTAMP, ?, ?)`,
+		s.documentKey(hash), source, chunkCount, s.namespace)
 	if err != nil {
 		return fmt.Errorf("failed to mark document as processed: %w", err)
 	}
@@ -60,7 +60,7 @@ func (s *SQLiteGraphStore) MarkDocumentProcessed(ctx context.Context, hash, sour
 func (s *SQLiteGraphStore) GetProcessedDocumentCount(ctx context.Context) (int64, error) {
 	var count int64
 	err := s.db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM processed_documents").Scan(&count)
+		"SELECT COUNT(*) FROM processed_documents WHERE namespace = ?", s.namespace).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get processed document count: %w", err)
 	}
@@ -69,9 +69,19 @@ func (s *SQLiteGraphStore) GetProcessedDocumentCount(ctx context.Context) (int64
 
 // ClearProcessedDocuments removes all document tracking records without affecting the knowledge graph.
 func (s *SQLiteGraphStore) ClearProcessedDocuments(ctx context.Context) error {
-	_, err := s.db.ExecContext(ctx, "DELETE FROM processed_documents")
+	_, err := s.db.ExecContext(ctx, "DELETE FROM processed_documents WHERE namespace = ?", s.namespace)
 	if err != nil {
 		return fmt.Errorf("failed to clear processed documents: %w", err)
 	}
 	return nil
 }
+
+// documentKey returns the processed_documents primary key for a document hash.
+// Hashes are prefixed with the namespace so the same document can be processed
+// independently in each namespace.
+func (s *SQLiteGraphStore) documentKey(hash string) string {
+	if s.namespace == DefaultNamespace {
+		return hash
+	}
+	return s.namespace + ":" + hash
+}
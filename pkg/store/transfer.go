@@ -0,0 +1,316 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// IterateMemories calls fn for every memory in this namespace the context's principal
+// can read, oldest first, with its tags. Soft-deleted memories are only included with
+// includeDeleted. Unlike GetMemory, reading does not count as an access. Records are
+// read before fn is first called, so fn may write to the store.
+func (s *SQLiteMemoryStore) IterateMemories(ctx context.Context, includeDeleted bool, fn func(*MemoryRecord) error) error {
+	clause, aclArgs := aclClause(ctx, "")
+	query := "SELECT " + memoryColumns + " FROM memories WHERE namespace = ?" + clause
+	args := append([]interface{}{s.namespace}, aclArgs...)
+	if !includeDeleted {
+		query += " AND status != ?"
+		args = append(args, StatusDeleted)
+	}
+	rows, err := s.db.QueryContext(ctx, query+" ORDER BY created_at, id", args...)
+	if err != nil {
+		return fmt.Errorf("failed to query memories: %w", err)
+	}
+	var records []*MemoryRecord
+	for rows.Next() {
+		record, err := s.scanMemoryRecord(rows)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan memory: %w", err)
+		}
+		records = append(records, record)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating memories: %w", err)
+	}
+
+	tags, err := s.namespaceTags(ctx)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		record.Tags = tags[record.ID]
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// namespaceTags returns the tags of every memory in this namespace, sorted, keyed by
+// memory ID
+func (s *SQLiteMemoryStore) namespaceTags(ctx context.Context) (map[string][]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT t.memory_id, t.tag FROM memory_tags t
+		JOIN memories m ON m.id = t.memory_id
+		WHERE m.namespace = ?
+		ORDER BY t.memory_id, t.tag
+	`, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make(map[string][]string)
+	for rows.Next() {
+		var id, tag string
+		if err := rows.Scan(&id, &tag); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags[id] = append(tags[id], tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tags: %w", err)
+	}
+	return tags, nil
+}
+
+// PeekMemory returns a memory like GetMemory, without tags, and without counting the
+// read as an access. Returns nil if no memory with id exists in this namespace.
+func (s *SQLiteMemoryStore) PeekMemory(ctx context.Context, id string) (*MemoryRecord, error) {
+	query := "SELECT " + memoryColumns + " FROM memories WHERE id = ? AND namespace = ?"
+	record, err := s.scanMemoryRecord(s.db.QueryRowContext(ctx, query, id, s.namespace))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory: %w", err)
+	}
+	return record, nil
+}
+
+// PutMemory writes a complete memory record as is, including its version, timestamps,
+// access statistics, pin state, supersession pointer, tags and ACL, replacing the memory
+// with the same ID. Used to import memories exported from another store; AddMemory and
+// UpdateMemory are the regular write paths. Provenance and supersession records are left
+// alone (see ReplaceProvenance and ImportSupersession). Returns an ErrConflict error if
+// the ID is taken by a memory of another namespace.
+func (s *SQLiteMemoryStore) PutMemory(ctx context.Context, record *MemoryRecord) error {
+	if record.ID == "" {
+		return Invalidf("memory ID cannot be empty")
+	}
+	tags, err := NormalizeTags(record.Tags)
+	if err != nil {
+		return err
+	}
+	decisionsJSON, err := json.Marshal(record.Decisions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal decisions: %w", err)
+	}
+	rationaleJSON, err := json.Marshal(record.Rationale)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rationale: %w", err)
+	}
+	metadataJSON, err := json.Marshal(record.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	storedContext, decisionsJSON, err := s.sealContent(record.Context, decisionsJSON)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Archived and deleted memories are dated by their last update, so restoring them
+	// and purging the trash keep working
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO memories (id, topic, context, decisions_json, rationale_json, metadata_json,
+			created_at, updated_at, version, doc_hash, source, status,
+			access_count, last_accessed_at, access_velocity, superseded_by,
+			retention_policy, retention_until, pinned, pinned_at, pinned_reason,
+			archived_at, deleted_at, namespace)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+			CASE WHEN ? = ? THEN ? END, CASE WHEN ? = ? THEN ? END, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			topic = excluded.topic, context = excluded.context,
+			decisions_json = excluded.decisions_json, rationale_json = excluded.rationale_json,
+			metadata_json = excluded.metadata_json, created_at = excluded.created_at,
+			updated_at = excluded.updated_at, version = excluded.version,
+			doc_hash = excluded.doc_hash, source = excluded.source, status = excluded.status,
+			access_count = excluded.access_count, last_accessed_at = excluded.last_accessed_at,
+			access_velocity = excluded.access_velocity, superseded_by = excluded.superseded_by,
+			retention_policy = excluded.retention_policy, retention_until = excluded.retention_until,
+			pinned = excluded.pinned, pinned_at = excluded.pinned_at, pinned_reason = excluded.pinned_reason,
+			archived_at = excluded.archived_at, pre_archive_status = NULL,
+			deleted_at = excluded.deleted_at, pre_delete_status = NULL
+		WHERE memories.namespace = excluded.namespace
+	`,
+		record.ID, record.Topic, storedContext, decisionsJSON, rationaleJSON, metadataJSON,
+		record.CreatedAt, record.UpdatedAt, record.Version, record.DocHash, record.Source, record.Status,
+		record.AccessCount, record.LastAccessedAt, record.AccessVelocity, record.SupersededBy,
+		record.RetentionPolicy, record.RetentionUntil, record.Pinned, record.PinnedAt, record.PinnedReason,
+		record.Status, StatusArchived, record.UpdatedAt, record.Status, StatusDeleted, record.UpdatedAt,
+		s.namespace,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to put memory: %w", classifyWriteError(err))
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	} else if rows == 0 {
+		return fmt.Errorf("memory %s belongs to another namespace: %w", record.ID, ErrConflict)
+	}
+
+	if err := writeACL(ctx, tx, record.ID, record.ACL); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM memory_tags WHERE memory_id = ?", record.ID); err != nil {
+		return fmt.Errorf("failed to clear tags: %w", err)
+	}
+	for _, tag := range tags {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO memory_tags (memory_id, tag) VALUES (?, ?)", record.ID, tag); err != nil {
+			return fmt.Errorf("failed to add tag: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", classifyWriteError(err))
+	}
+	return nil
+}
+
+// ReplaceProvenance replaces the provenance links of a memory with the given nodes and
+// edges. IDs without a node or edge in this namespace are not linked; their number is
+// returned as missing.
+func (s *SQLiteMemoryStore) ReplaceProvenance(ctx context.Context, memoryID string, nodeIDs, edgeIDs []string) (missing int, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM memory_nodes WHERE memory_id = ?", memoryID); err != nil {
+		return 0, fmt.Errorf("failed to unlink node provenance: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM memory_edges WHERE memory_id = ?", memoryID); err != nil {
+		return 0, fmt.Errorf("failed to unlink edge provenance: %w", err)
+	}
+
+	link := func(ids []string, insert string) error {
+		for _, id := range ids {
+			result, err := tx.ExecContext(ctx, insert, memoryID, id, s.namespace)
+			if err != nil {
+				return err
+			}
+			if rows, err := result.RowsAffected(); err != nil {
+				return err
+			} else if rows == 0 {
+				missing++
+			}
+		}
+		return nil
+	}
+	if err := link(nodeIDs, `
+		INSERT OR IGNORE INTO memory_nodes (memory_id, node_id)
+		SELECT ?, id FROM nodes WHERE id = ? AND namespace = ?
+	`); err != nil {
+		return 0, fmt.Errorf("failed to link node provenance: %w", err)
+	}
+	if err := link(edgeIDs, `
+		INSERT OR IGNORE INTO memory_edges (memory_id, edge_id)
+		SELECT ?, id FROM edges WHERE id = ? AND namespace = ?
+	`); err != nil {
+		return 0, fmt.Errorf("failed to link edge provenance: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return missing, nil
+}
+
+// GetSupersessions returns the supersession records of the memories supersedingID
+// supersedes, oldest first.
+func (s *SQLiteMemoryStore) GetSupersessions(ctx context.Context, supersedingID string) ([]SupersessionRecord, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, superseding_id, superseded_id, COALESCE(reason, ''), created_at
+		FROM memory_supersession
+		WHERE superseding_id = ?
+		ORDER BY created_at ASC
+	`, supersedingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query supersessions: %w", err)
+	}
+	defer rows.Close()
+
+	var records []SupersessionRecord
+	for rows.Next() {
+		var record SupersessionRecord
+		if err := rows.Scan(&record.ID, &record.SupersedingID, &record.SupersededID, &record.Reason, &record.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan supersession: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating supersessions: %w", err)
+	}
+	return records, nil
+}
+
+// ImportSupersession records a supersession exported from another store, keeping its ID,
+// reason and time, and marks the superseded memory Superseded. Returns false without
+// changes if the pair is already recorded. Both memories must exist in this namespace;
+// supersessions that would form a cycle are refused with ErrSupersessionCycle.
+func (s *SQLiteMemoryStore) ImportSupersession(ctx context.Context, record SupersessionRecord) (bool, error) {
+	for _, id := range []string{record.SupersedingID, record.SupersededID} {
+		if err := s.requireMemory(ctx, id); err != nil {
+			return false, fmt.Errorf("cannot import supersession of memory %s: %w", id, err)
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM memory_supersession WHERE superseding_id = ? AND superseded_id = ?)
+	`, record.SupersedingID, record.SupersededID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check supersession: %w", err)
+	}
+	if exists {
+		return false, nil
+	}
+	if err := checkSupersessionCycle(ctx, tx, record.SupersedingID, record.SupersededID); err != nil {
+		return false, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO memory_supersession (id, superseding_id, superseded_id, reason, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, record.ID, record.SupersedingID, record.SupersededID, record.Reason, record.CreatedAt); err != nil {
+		return false, fmt.Errorf("failed to insert supersession record: %w", classifyWriteError(err))
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE memories SET status = 'Superseded', superseded_by = ?
+		WHERE id = ? AND status NOT IN (?, ?)
+	`, record.SupersedingID, record.SupersededID, StatusArchived, StatusDeleted); err != nil {
+		return false, fmt.Errorf("failed to update superseded memory: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit supersession: %w", err)
+	}
+	return true, nil
+}
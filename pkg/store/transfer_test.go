@@ -0,0 +1,133 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPutMemory_KeepsFullRecord(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	lastAccess := created.Add(time.Hour)
+	reason := "core decision"
+	record := &MemoryRecord{
+		ID: "m1", Topic: "Database", Context: "We use SQLite.", Decisions: []string{"Use SQLite"},
+		CreatedAt: created, UpdatedAt: created, Version: 3, DocHash: "hash", Status: "Active",
+		AccessCount: 7, LastAccessedAt: &lastAccess, RetentionPolicy: "decision",
+		Pinned: true, PinnedAt: &lastAccess, PinnedReason: &reason, Tags: []string{"db", "infra"},
+	}
+	if err := memStore.PutMemory(ctx, record); err != nil {
+		t.Fatalf("PutMemory failed: %v", err)
+	}
+
+	got, err := memStore.PeekMemory(ctx, "m1")
+	if err != nil || got == nil {
+		t.Fatalf("PeekMemory failed: %v, %v", got, err)
+	}
+	if got.Version != 3 || got.AccessCount != 7 || !got.CreatedAt.Equal(created) || !got.Pinned ||
+		got.PinnedReason == nil || *got.PinnedReason != reason || got.RetentionPolicy != "decision" {
+		t.Errorf("record not kept as is: %+v", got)
+	}
+
+	// Replacing the record replaces its tags
+	record.Topic, record.Tags = "Storage", []string{"storage"}
+	if err := memStore.PutMemory(ctx, record); err != nil {
+		t.Fatalf("PutMemory failed: %v", err)
+	}
+	var count int
+	err = memStore.IterateMemories(ctx, false, func(m *MemoryRecord) error {
+		count++
+		if m.Topic != "Storage" || len(m.Tags) != 1 || m.Tags[0] != "storage" {
+			t.Errorf("unexpected replaced memory %+v", m)
+		}
+		return nil
+	})
+	if err != nil || count != 1 {
+		t.Fatalf("IterateMemories returned %d memories, %v", count, err)
+	}
+	if got, _ := memStore.PeekMemory(ctx, "m1"); got.AccessCount != 7 {
+		t.Errorf("reads counted as accesses: %d", got.AccessCount)
+	}
+
+	// The ID is taken in another namespace
+	other := NewSQLiteMemoryStore(graphStore.DB()).WithNamespace("other")
+	if err := other.PutMemory(ctx, record); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict, got %v", err)
+	}
+}
+
+func TestReplaceProvenance_SkipsMissing(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+
+	if err := graphStore.AddNode(ctx, &Node{ID: "n1", Name: "SQLite", Type: "Technology", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if err := memStore.AddMemory(ctx, &MemoryRecord{ID: "m1", Topic: "T", Context: "C", DocHash: "h"}); err != nil {
+		t.Fatalf("AddMemory failed: %v", err)
+	}
+
+	missing, err := memStore.ReplaceProvenance(ctx, "m1", []string{"n1", "n2"}, []string{"e1"})
+	if err != nil {
+		t.Fatalf("ReplaceProvenance failed: %v", err)
+	}
+	if missing != 2 {
+		t.Errorf("expected 2 missing references, got %d", missing)
+	}
+	nodeIDs, edgeIDs, _ := memStore.GetProvenanceByMemory(ctx, "m1")
+	if len(nodeIDs) != 1 || nodeIDs[0] != "n1" || len(edgeIDs) != 0 {
+		t.Errorf("unexpected provenance %v, %v", nodeIDs, edgeIDs)
+	}
+}
+
+func TestImportSupersession(t *testing.T) {
+	ctx := context.Background()
+	graphStore, err := NewSQLiteGraphStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer graphStore.Close()
+	memStore := NewSQLiteMemoryStore(graphStore.DB())
+
+	for _, id := range []string{"old", "new"} {
+		if err := memStore.AddMemory(ctx, &MemoryRecord{ID: id, Topic: id, Context: id, DocHash: id, Status: "Active"}); err != nil {
+			t.Fatalf("AddMemory failed: %v", err)
+		}
+	}
+
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	record := SupersessionRecord{ID: "s1", SupersedingID: "new", SupersededID: "old", Reason: "replaced", CreatedAt: at}
+	if recorded, err := memStore.ImportSupersession(ctx, record); err != nil || !recorded {
+		t.Fatalf("ImportSupersession = %v, %v", recorded, err)
+	}
+	if recorded, err := memStore.ImportSupersession(ctx, record); err != nil || recorded {
+		t.Errorf("expected the second import to be a no-op, got %v, %v", recorded, err)
+	}
+
+	supersessions, err := memStore.GetSupersessions(ctx, "new")
+	if err != nil || len(supersessions) != 1 || supersessions[0].ID != "s1" || supersessions[0].Reason != "replaced" || !supersessions[0].CreatedAt.Equal(at) {
+		t.Errorf("unexpected supersessions %+v, %v", supersessions, err)
+	}
+	if old, _ := memStore.PeekMemory(ctx, "old"); old.Status != "Superseded" || old.SupersededBy == nil || *old.SupersededBy != "new" {
+		t.Errorf("expected old to be superseded, got %+v", old)
+	}
+
+	reverse := SupersessionRecord{ID: "s2", SupersedingID: "old", SupersededID: "new", CreatedAt: at}
+	if _, err := memStore.ImportSupersession(ctx, reverse); !errors.Is(err, ErrSupersessionCycle) {
+		t.Errorf("expected ErrSupersessionCycle, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StatusDeleted is the status of a soft-deleted memory. Deleted memories keep their
+// content and provenance but are hidden from listings, counts and node lookups until
+// restored or purged.
+const StatusDeleted = "Deleted"
+
+// migrateSoftDeleteSchema adds the soft-delete columns to the memories table.
+func (s *SQLiteGraphStore) migrateSoftDeleteSchema() error {
+	if err := s.addColumns("memories", [][2]string{
+		{"deleted_at", "DATETIME DEFAULT NULL"},
+		{"pre_delete_status", "TEXT DEFAULT NULL"},
+	}); err != nil {
+		return err
+	}
+	if _, err := s.db.Exec("CREATE INDEX IF NOT EXISTS idx_memories_deleted_at ON memories(deleted_at)"); err != nil {
+		return fmt.Errorf("failed to create deleted_at index: %w", err)
+	}
+	return nil
+}
+
+// SoftDeleteMemory moves a memory to the trash: its status becomes StatusDeleted and the
+// deletion time is recorded. Deleting an already deleted memory is a no-op.
+func (s *SQLiteMemoryStore) SoftDeleteMemory(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE memories
+		SET pre_delete_status = status, status = ?, deleted_at = ?
+		WHERE id = ? AND namespace = ? AND status != ?
+	`, StatusDeleted, time.Now(), id, s.namespace, StatusDeleted)
+	if err != nil {
+		return fmt.Errorf("failed to soft-delete memory: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	} else if rows == 0 {
+		return s.requireMemory(ctx, id)
+	}
+	return nil
+}
+
+// RestoreMemory takes a memory out of the trash, restoring the status it had when deleted.
+func (s *SQLiteMemoryStore) RestoreMemory(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE memories
+		SET status = COALESCE(pre_delete_status, 'complete'), pre_delete_status = NULL, deleted_at = NULL
+		WHERE id = ? AND namespace = ? AND status = ?
+	`, id, s.namespace, StatusDeleted)
+	if err != nil {
+		return fmt.Errorf("failed to restore memory: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		if err := s.requireMemory(ctx, id); err != nil {
+			return err
+		}
+		return fmt.Errorf("memory %s is not deleted", id)
+	}
+	return nil
+}
+
+// DeletedMemoriesBefore returns the IDs of memories soft-deleted before cutoff.
+func (s *SQLiteMemoryStore) DeletedMemoriesBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id FROM memories
+		WHERE namespace = ? AND status = ? AND deleted_at < ?
+		ORDER BY deleted_at
+	`, s.namespace, StatusDeleted, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deleted memories: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan memory ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deleted memories: %w", err)
+	}
+	return ids, nil
+}
+
+// requireMemory returns ErrMemoryNotFound if no memory with id exists in this namespace
+func (s *SQLiteMemoryStore) requireMemory(ctx context.Context, id string) error {
+	var count int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM memories WHERE id = ? AND namespace = ?", id, s.namespace).Scan(&count); err != nil {
+		return fmt.Errorf("failed to check memory: %w", err)
+	}
+	if count == 0 {
+		return ErrMemoryNotFound
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// NeighborNode is a node reached by a graph traversal, with its distance from the start.
+type NeighborNode struct {
+	Node  *Node
+	Depth int // Hops on the shortest path from the start node, starting at 1
+}
+
+// TraversalDirection selects which edges a traversal follows from a node.
+type TraversalDirection string
+
+const (
+	// DirectionBoth follows edges either way, treating the graph as undirected (default).
+	DirectionBoth TraversalDirection = "both"
+	// DirectionOutgoing follows edges from source to target ("what does X depend on").
+	DirectionOutgoing TraversalDirection = "outgoing"
+	// DirectionIncoming follows edges from target to source ("what depends on X").
+	DirectionIncoming TraversalDirection = "incoming"
+)
+
+// SummarizesRelation links a summary node to each node it summarizes (see
+// gognee.Summarize). Search skips these edges during graph expansion by default, so a
+// summary does not connect every node it covers.
+const SummarizesRelation = "SUMMARIZES"
+
+// TraversalOptions restricts which edges a traversal follows. The zero value follows
+// every unexpired edge in both directions.
+type TraversalOptions struct {
+	// Relations limits traversal to edges with one of these relation names (exact match).
+	Relations []string `json:"relations,omitempty"`
+	// ExcludeRelations skips edges with one of these relation names (exact match).
+	ExcludeRelations []string `json:"exclude_relations,omitempty"`
+	// Direction limits traversal to outgoing or incoming edges. Default: DirectionBoth.
+	Direction TraversalDirection `json:"direction,omitempty"`
+	// MaxFanOut caps the edges followed from each node, keeping the heaviest (ties broken
+	// by edge id), so hub nodes do not flood the result. 0 means unlimited.
+	MaxFanOut int `json:"max_fan_out,omitempty"`
+	// AsOf limits traversal to edges whose validity period (Edge.ValidFrom/ValidTo)
+	// contains this time, querying the graph as it was then. Edges without a validity
+	// period always qualify. Zero means no restriction.
+	AsOf time.Time `json:"as_of,omitzero"`
+}
+
+// Validate reports whether the options are usable.
+func (o TraversalOptions) Validate() error {
+	switch o.Direction {
+	case "", DirectionBoth, DirectionOutgoing, DirectionIncoming:
+	default:
+		return Invalidf("invalid traversal direction %q: must be %q, %q or %q",
+			o.Direction, DirectionBoth, DirectionOutgoing, DirectionIncoming)
+	}
+	if o.MaxFanOut < 0 {
+		return Invalidf("max fan-out must not be negative")
+	}
+	return nil
+}
+
+// Unrestricted reports whether the options follow every edge, like GetNeighbors.
+func (o TraversalOptions) Unrestricted() bool {
+	return len(o.Relations) == 0 && len(o.ExcludeRelations) == 0 && (o.Direction == "" || o.Direction == DirectionBoth) && o.MaxFanOut <= 0 &&
+		o.AsOf.IsZero()
+}
+
+// Follow returns the node an edge leads to when reached from fromID, and whether the
+// options allow following it. MaxFanOut is not applied. Expiry is not checked.
+func (o TraversalOptions) Follow(edge *Edge, fromID string) (string, bool) {
+	if len(o.Relations) > 0 && !slices.Contains(o.Relations, edge.Relation) {
+		return "", false
+	}
+	if slices.Contains(o.ExcludeRelations, edge.Relation) {
+		return "", false
+	}
+	if !o.AsOf.IsZero() && !edge.ValidAt(o.AsOf) {
+		return "", false
+	}
+	switch {
+	case edge.SourceID == fromID && o.Direction != DirectionIncoming:
+		return edge.TargetID, true
+	case edge.TargetID == fromID && o.Direction != DirectionOutgoing:
+		return edge.SourceID, true
+	}
+	return "", false
+}
+
+// GetNeighborsWithDepth returns every node within depth hops of nodeID, ignoring edge
+// direction, with its BFS depth. The whole traversal runs as a single recursive CTE, so
+// deep expansions cost one round trip instead of one query per frontier node. Results
+// are ordered by depth, then id; the start node is not included.
+func (s *SQLiteGraphStore) GetNeighborsWithDepth(ctx context.Context, nodeID string, depth int) ([]NeighborNode, error) {
+	return s.TraverseNeighbors(ctx, nodeID, depth, TraversalOptions{})
+}
+
+// TraverseNeighbors is GetNeighborsWithDepth restricted to the edges allowed by opts,
+// e.g. only incoming DEPENDS_ON edges to find what depends on nodeID.
+func (s *SQLiteGraphStore) TraverseNeighbors(ctx context.Context, nodeID string, depth int, opts TraversalOptions) ([]NeighborNode, error) {
+	start := time.Now()
+	if depth < 1 {
+		return nil, Invalidf("depth must be at least 1")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Edges of the frontier node gt.node_id to follow, and the node each leads to
+	var match, next string
+	switch opts.Direction {
+	case DirectionOutgoing:
+		match, next = "%[1]s.source_id = gt.node_id", "edges.target_id"
+	case DirectionIncoming:
+		match, next = "%[1]s.target_id = gt.node_id", "edges.source_id"
+	default:
+		match = "(%[1]s.source_id = gt.node_id OR %[1]s.target_id = gt.node_id)"
+		next = "CASE WHEN edges.source_id = gt.node_id THEN edges.target_id ELSE edges.source_id END"
+	}
+	filter := "%[1]s.namespace = ? AND (%[1]s.expires_at IS NULL OR %[1]s.expires_at > ?)"
+	filterArgs := []interface{}{s.namespace, time.Now().UTC()}
+	if len(opts.Relations) > 0 {
+		filter += " AND %[1]s.relation IN (" + sqlPlaceholders(len(opts.Relations)) + ")"
+		for _, relation := range opts.Relations {
+			filterArgs = append(filterArgs, relation)
+		}
+	}
+	if len(opts.ExcludeRelations) > 0 {
+		filter += " AND %[1]s.relation NOT IN (" + sqlPlaceholders(len(opts.ExcludeRelations)) + ")"
+		for _, relation := range opts.ExcludeRelations {
+			filterArgs = append(filterArgs, relation)
+		}
+	}
+	if !opts.AsOf.IsZero() {
+		filter += " AND (%[1]s.valid_from IS NULL OR %[1]s.valid_from <= ?) AND (%[1]s.valid_to IS NULL OR %[1]s.valid_to > ?)"
+		asOf := opts.AsOf.UTC()
+		filterArgs = append(filterArgs, asOf, asOf)
+	}
+
+	var join string
+	var args []interface{}
+	if opts.MaxFanOut > 0 {
+		// A correlated subquery picks the heaviest edges of each frontier node
+		join = fmt.Sprintf(`JOIN edges ON edges.id IN (
+			SELECT fan.id FROM edges fan
+			WHERE `+match+` AND `+filter+`
+			ORDER BY fan.weight DESC, fan.id
+			LIMIT ?
+		)`, "fan")
+		args = append(append(args, filterArgs...), opts.MaxFanOut)
+	} else {
+		join = fmt.Sprintf("JOIN edges ON "+match+" AND "+filter, "edges")
+		args = append(args, filterArgs...)
+	}
+
+	// UNION (not UNION ALL) drops repeated (node, depth) rows, which bounds the
+	// recursion on cycles; MIN keeps the shortest depth of nodes reached several ways.
+	query := `
+	WITH RECURSIVE
+	graph_traversal(node_id, depth_level) AS (
+		SELECT ? AS node_id, 0 AS depth_level
+
+		UNION
+
+		SELECT ` + next + ` AS node_id, gt.depth_level + 1 AS depth_level
+		FROM graph_traversal gt
+		` + join + `
+		WHERE gt.depth_level < ?
+	),
+	nearest(node_id, depth_level) AS (
+		SELECT node_id, MIN(depth_level)
+		FROM graph_traversal
+		WHERE node_id != ? -- Exclude starting node
+		GROUP BY node_id
+	)
+	SELECT
+		n.id, n.name, n.type, n.description, n.embedding,
+		n.created_at, n.last_accessed_at, n.metadata, n.mention_count,
+		n.pinned, n.pinned_at, COALESCE(n.pin_reason, ''),
+		nearest.depth_level
+	FROM nearest
+	JOIN nodes n ON n.id = nearest.node_id
+	ORDER BY nearest.depth_level, n.id
+	`
+	args = append([]interface{}{nodeID}, args...)
+	args = append(args, depth, nodeID)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query neighbors with CTE: %w", err)
+	}
+	defer rows.Close()
+
+	var neighbors []NeighborNode
+	for rows.Next() {
+		node := &Node{}
+		var embeddingData []byte
+		var metadataJSON []byte
+		var lastAccessed sql.NullTime
+		var nodeDepth int
+
+		err := rows.Scan(
+			&node.ID, &node.Name, &node.Type, &node.Description,
+			&embeddingData, &node.CreatedAt, &lastAccessed, &metadataJSON, &node.MentionCount,
+			&node.Pinned, &node.PinnedAt, &node.PinReason, &nodeDepth,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan neighbor node: %w", err)
+		}
+		if node.Description, err = s.cipher.Decrypt(node.Description); err != nil {
+			return nil, err
+		}
+
+		if len(embeddingData) > 0 {
+			node.Embedding = deserializeEmbedding(embeddingData)
+		}
+		node.Metadata = make(map[string]interface{})
+		if len(metadataJSON) > 0 {
+			if err := json.Unmarshal(metadataJSON, &node.Metadata); err != nil {
+				node.Metadata = make(map[string]interface{})
+			}
+		}
+		if lastAccessed.Valid {
+			node.LastAccessedAt = &lastAccessed.Time
+		}
+
+		neighbors = append(neighbors, NeighborNode{Node: node, Depth: nodeDepth})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating neighbor rows: %w", err)
+	}
+
+	s.logQuery(ctx, "traverse_neighbors", start, slog.Int("depth", depth), slog.Int("reached", len(neighbors)))
+	return neighbors, nil
+}
@@ -0,0 +1,230 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetNeighborsWithDepth_ShortestDepth(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	// A -- B -- C -- D, with a shortcut A -- C and a cycle back D -- A through E
+	for _, id := range []string{"A", "B", "C", "D", "E", "F"} {
+		if err := store.AddNode(ctx, &Node{ID: id, Name: "Node " + id, Type: "Concept"}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	past := time.Now().Add(-time.Hour)
+	edges := []*Edge{
+		{ID: "e1", SourceID: "A", Relation: "CONNECTS", TargetID: "B"},
+		{ID: "e2", SourceID: "B", Relation: "CONNECTS", TargetID: "C"},
+		{ID: "e3", SourceID: "C", Relation: "CONNECTS", TargetID: "D"},
+		{ID: "e4", SourceID: "A", Relation: "CONNECTS", TargetID: "C"},
+		{ID: "e5", SourceID: "D", Relation: "CONNECTS", TargetID: "E"},
+		{ID: "e6", SourceID: "E", Relation: "CONNECTS", TargetID: "A"},
+		{ID: "e7", SourceID: "A", Relation: "CONNECTS", TargetID: "F", ExpiresAt: &past},
+	}
+	for _, edge := range edges {
+		if err := store.AddEdge(ctx, edge); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+
+	neighbors, err := store.GetNeighborsWithDepth(ctx, "A", 3)
+	if err != nil {
+		t.Fatalf("GetNeighborsWithDepth failed: %v", err)
+	}
+
+	// Ordered by depth, then id; A itself and F (expired edge) are excluded
+	want := []struct {
+		id    string
+		depth int
+	}{{"B", 1}, {"C", 1}, {"E", 1}, {"D", 2}}
+	if len(neighbors) != len(want) {
+		t.Fatalf("Expected %d neighbors, got %d: %+v", len(want), len(neighbors), neighbors)
+	}
+	for i, w := range want {
+		if neighbors[i].Node.ID != w.id || neighbors[i].Depth != w.depth {
+			t.Errorf("neighbor %d: expected %s at depth %d, got %s at depth %d",
+				i, w.id, w.depth, neighbors[i].Node.ID, neighbors[i].Depth)
+		}
+	}
+	if neighbors[0].Node.Name != "Node B" {
+		t.Errorf("Expected full node data, got name %q", neighbors[0].Node.Name)
+	}
+}
+
+func TestGetNeighborsWithDepth_InvalidDepth(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	if _, err := store.GetNeighborsWithDepth(context.Background(), "A", 0); err == nil {
+		t.Error("Expected error for depth 0")
+	}
+}
+
+// setupDependencyGraph builds: api -DEPENDS_ON-> db, worker -DEPENDS_ON-> db,
+// db -DEPENDS_ON-> disk, db -OWNED_BY-> team, cli -DEPENDS_ON-> api
+func setupDependencyGraph(t *testing.T) *SQLiteGraphStore {
+	t.Helper()
+	store := setupTestStore(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"api", "worker", "db", "disk", "team", "cli"} {
+		if err := store.AddNode(ctx, &Node{ID: id, Name: id, Type: "Component"}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	edges := []*Edge{
+		{ID: "e1", SourceID: "api", Relation: "DEPENDS_ON", TargetID: "db", Weight: 1.0},
+		{ID: "e2", SourceID: "worker", Relation: "DEPENDS_ON", TargetID: "db", Weight: 0.5},
+		{ID: "e3", SourceID: "db", Relation: "DEPENDS_ON", TargetID: "disk", Weight: 1.0},
+		{ID: "e4", SourceID: "db", Relation: "OWNED_BY", TargetID: "team", Weight: 1.0},
+		{ID: "e5", SourceID: "cli", Relation: "DEPENDS_ON", TargetID: "api", Weight: 1.0},
+	}
+	for _, edge := range edges {
+		if err := store.AddEdge(ctx, edge); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+	return store
+}
+
+func neighborDepths(neighbors []NeighborNode) map[string]int {
+	depths := make(map[string]int)
+	for _, n := range neighbors {
+		depths[n.Node.ID] = n.Depth
+	}
+	return depths
+}
+
+func TestTraverseNeighbors_Options(t *testing.T) {
+	store := setupDependencyGraph(t)
+	defer store.Close()
+
+	tests := []struct {
+		name  string
+		depth int
+		opts  TraversalOptions
+		want  map[string]int
+	}{
+		{
+			name:  "incoming depends_on finds dependents",
+			depth: 2,
+			opts:  TraversalOptions{Relations: []string{"DEPENDS_ON"}, Direction: DirectionIncoming},
+			want:  map[string]int{"api": 1, "worker": 1, "cli": 2},
+		},
+		{
+			name:  "outgoing finds dependencies",
+			depth: 3,
+			opts:  TraversalOptions{Direction: DirectionOutgoing},
+			want:  map[string]int{"disk": 1, "team": 1},
+		},
+		{
+			name:  "relation filter in both directions",
+			depth: 1,
+			opts:  TraversalOptions{Relations: []string{"OWNED_BY"}},
+			want:  map[string]int{"team": 1},
+		},
+		{
+			name:  "excluded relations are skipped",
+			depth: 2,
+			opts:  TraversalOptions{ExcludeRelations: []string{"DEPENDS_ON"}},
+			want:  map[string]int{"team": 1},
+		},
+		{
+			name:  "fan-out keeps heaviest edges",
+			depth: 1,
+			opts:  TraversalOptions{Direction: DirectionIncoming, MaxFanOut: 1},
+			want:  map[string]int{"api": 1},
+		},
+		{
+			name:  "fan-out applies per node",
+			depth: 2,
+			opts:  TraversalOptions{Direction: DirectionIncoming, MaxFanOut: 1},
+			want:  map[string]int{"api": 1, "cli": 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			neighbors, err := store.TraverseNeighbors(context.Background(), "db", tt.depth, tt.opts)
+			if err != nil {
+				t.Fatalf("TraverseNeighbors failed: %v", err)
+			}
+			got := neighborDepths(neighbors)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for id, depth := range tt.want {
+				if got[id] != depth {
+					t.Errorf("expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestTraverseNeighbors_InvalidOptions(t *testing.T) {
+	store := setupDependencyGraph(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	if _, err := store.TraverseNeighbors(ctx, "db", 1, TraversalOptions{Direction: "sideways"}); err == nil {
+		t.Error("Expected error for invalid direction")
+	}
+	if _, err := store.TraverseNeighbors(ctx, "db", 1, TraversalOptions{MaxFanOut: -1}); err == nil {
+		t.Error("Expected error for negative fan-out")
+	}
+}
+
+func TestTraverseNeighbors_AsOf(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	for _, id := range []string{"alice", "acme", "globex", "go"} {
+		if err := store.AddNode(ctx, &Node{ID: id, Name: id, Type: "Concept"}); err != nil {
+			t.Fatalf("AddNode failed: %v", err)
+		}
+	}
+	date := func(y int) *time.Time {
+		d := time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	edges := []*Edge{
+		{ID: "acme", SourceID: "alice", Relation: "WORKS_AT", TargetID: "acme", ValidFrom: date(2019), ValidTo: date(2023)},
+		{ID: "globex", SourceID: "alice", Relation: "WORKS_AT", TargetID: "globex", ValidFrom: date(2023)},
+		{ID: "go", SourceID: "alice", Relation: "USES", TargetID: "go"},
+	}
+	for _, edge := range edges {
+		if err := store.AddEdge(ctx, edge); err != nil {
+			t.Fatalf("AddEdge failed: %v", err)
+		}
+	}
+
+	edge, err := store.GetEdge(ctx, "acme")
+	if err != nil || edge.ValidFrom == nil || !edge.ValidFrom.Equal(*date(2019)) || !edge.ValidTo.Equal(*date(2023)) {
+		t.Fatalf("expected the validity period stored, got %+v, %v", edge, err)
+	}
+
+	for asOf, want := range map[int][]string{2020: {"acme", "go"}, 2023: {"globex", "go"}} {
+		opts := TraversalOptions{AsOf: *date(asOf)}
+		neighbors, err := store.TraverseNeighbors(ctx, "alice", 1, opts)
+		if err != nil {
+			t.Fatalf("TraverseNeighbors failed: %v", err)
+		}
+		if got := neighborDepths(neighbors); len(got) != len(want) || got[want[0]] != 1 || got[want[1]] != 1 {
+			t.Errorf("as of %d: expected %v, got %v", asOf, want, got)
+		}
+
+		// Follow applies the same window to edges read one at a time
+		edge, _ := store.GetEdge(ctx, "acme")
+		if _, ok := opts.Follow(edge, "alice"); ok != (asOf == 2020) {
+			t.Errorf("as of %d: unexpected Follow result %v for the acme edge", asOf, ok)
+		}
+	}
+}
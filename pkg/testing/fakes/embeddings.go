@@ -0,0 +1,110 @@
+// Package fakes provides deterministic, in-process stand-ins for gognee's external
+// dependencies, so applications can unit test against the real pipeline without
+// network access or API keys:
+//
+//	g, err := gognee.NewWithClients(gognee.Config{}, fakes.NewEmbeddingClient(), llm)
+//
+// where llm is a *fakes.LLMClient scripted with the entities and relations to extract.
+package fakes
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/dan-solli/gognee/pkg/embeddings"
+)
+
+// DefaultDimensions is the embedding size used when EmbeddingClient.Dimensions is zero.
+const DefaultDimensions = 64
+
+// EmbeddingClient produces deterministic bag-of-words embeddings: each lowercased word is
+// hashed into one of Dimensions buckets and the vector is L2-normalized. Texts sharing
+// words therefore have a higher cosine similarity, which keeps search results meaningful.
+// Safe for concurrent use.
+type EmbeddingClient struct {
+	Dimensions int   // Embedding size (default: DefaultDimensions)
+	Err        error // If set, returned by every call
+
+	mu    sync.Mutex
+	calls int
+}
+
+var _ embeddings.EmbeddingClient = (*EmbeddingClient)(nil)
+
+// NewEmbeddingClient creates a fake embedding client with DefaultDimensions.
+func NewEmbeddingClient() *EmbeddingClient {
+	return &EmbeddingClient{Dimensions: DefaultDimensions}
+}
+
+// Embed returns one embedding per text.
+func (c *EmbeddingClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	c.recordCall()
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	result := make([][]float32, len(texts))
+	for i, text := range texts {
+		result[i] = c.embed(text)
+	}
+	return result, nil
+}
+
+// EmbedOne returns the embedding for a single text.
+func (c *EmbeddingClient) EmbedOne(ctx context.Context, text string) ([]float32, error) {
+	c.recordCall()
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	return c.embed(text), nil
+}
+
+// CallCount returns the number of Embed and EmbedOne calls made so far.
+func (c *EmbeddingClient) CallCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func (c *EmbeddingClient) recordCall() {
+	c.mu.Lock()
+	c.calls++
+	c.mu.Unlock()
+}
+
+// embed computes the bag-of-words vector for text.
+func (c *EmbeddingClient) embed(text string) []float32 {
+	dims := c.Dimensions
+	if dims <= 0 {
+		dims = DefaultDimensions
+	}
+
+	vector := make([]float32, dims)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		h := fnv.New32a()
+		h.Write([]byte(word))
+		vector[h.Sum32()%uint32(dims)]++
+	}
+
+	var norm float64
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		// Empty text: a fixed unit vector keeps cosine similarity defined
+		vector[0] = 1
+		return vector
+	}
+	scale := float32(1 / math.Sqrt(norm))
+	for i := range vector {
+		vector[i] *= scale
+	}
+	return vector
+}
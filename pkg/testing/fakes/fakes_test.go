@@ -0,0 +1,124 @@
+package fakes_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/gognee"
+	"github.com/dan-solli/gognee/pkg/store"
+	"github.com/dan-solli/gognee/pkg/testing/fakes"
+)
+
+func TestEmbeddingClient_Deterministic(t *testing.T) {
+	ctx := context.Background()
+	client := fakes.NewEmbeddingClient()
+
+	a, _ := client.EmbedOne(ctx, "SQLite stores the graph")
+	b, _ := client.EmbedOne(ctx, "SQLite stores the graph")
+	related, _ := client.EmbedOne(ctx, "the graph lives in SQLite")
+	unrelated, _ := client.EmbedOne(ctx, "kubernetes helm charts")
+
+	if len(a) != fakes.DefaultDimensions {
+		t.Fatalf("Expected %d dimensions, got %d", fakes.DefaultDimensions, len(a))
+	}
+	if store.CosineSimilarity(a, b) < 0.9999 {
+		t.Error("Expected identical texts to produce identical embeddings")
+	}
+	if store.CosineSimilarity(a, related) <= store.CosineSimilarity(a, unrelated) {
+		t.Error("Expected texts sharing words to be more similar than unrelated texts")
+	}
+	if client.CallCount() != 4 {
+		t.Errorf("Expected 4 calls, got %d", client.CallCount())
+	}
+
+	client.Err = errors.New("boom")
+	if _, err := client.Embed(ctx, []string{"x"}); err == nil {
+		t.Error("Expected configured error")
+	}
+}
+
+func TestLLMClient_CannedResponses(t *testing.T) {
+	ctx := context.Background()
+	client := fakes.NewLLMClient().
+		WithEntities(extraction.Entity{Name: "Go", Type: "Technology", Description: "A language"}).
+		WithEntities(extraction.Entity{Name: "Rust", Type: "Technology", Description: "A language"})
+
+	names := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		var entities []extraction.Entity
+		if err := client.CompleteWithSchema(ctx, "prompt", &entities); err != nil {
+			t.Fatalf("CompleteWithSchema failed: %v", err)
+		}
+		names = append(names, entities[0].Name)
+	}
+	if names[0] != "Go" || names[1] != "Rust" || names[2] != "Rust" {
+		t.Errorf("Expected responses in order with the last repeated, got %v", names)
+	}
+
+	var triplets []extraction.Triplet
+	if err := client.CompleteWithSchema(ctx, "prompt", &triplets); err != nil {
+		t.Fatalf("CompleteWithSchema failed: %v", err)
+	}
+	if len(triplets) != 0 {
+		t.Errorf("Expected no relations without a scripted response, got %v", triplets)
+	}
+	if client.CallCount() != 4 || len(client.Prompts()) != 4 {
+		t.Errorf("Expected 4 recorded calls, got %d", client.CallCount())
+	}
+}
+
+func TestFakes_DrivePipeline(t *testing.T) {
+	ctx := context.Background()
+	llm := fakes.NewLLMClient().
+		WithEntities(
+			extraction.Entity{Name: "React", Type: "Technology", Description: "A JavaScript library"},
+			extraction.Entity{Name: "TypeScript", Type: "Technology", Description: "A typed JavaScript"},
+		).
+		WithRelations(extraction.Triplet{Subject: "React", Relation: "USES", Object: "TypeScript"})
+
+	g, err := gognee.NewWithClients(gognee.Config{}, fakes.NewEmbeddingClient(), llm)
+	if err != nil {
+		t.Fatalf("NewWithClients failed: %v", err)
+	}
+	defer g.Close()
+
+	if err := g.Add(ctx, "Our React app uses TypeScript.", gognee.AddOptions{}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	result, err := g.Cognify(ctx, gognee.CognifyOptions{})
+	if err != nil {
+		t.Fatalf("Cognify failed: %v", err)
+	}
+	if result.NodesCreated != 2 || result.EdgesCreated != 1 {
+		t.Fatalf("Expected 2 nodes and 1 edge, got %d and %d", result.NodesCreated, result.EdgesCreated)
+	}
+
+	resp, err := g.Search(ctx, "React library", gognee.SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) == 0 || resp.Results[0].Node.Name != "React" {
+		t.Errorf("Expected React as the top result, got %+v", resp.Results)
+	}
+}
+
+func TestFakes_Stores(t *testing.T) {
+	ctx := context.Background()
+	graph, err := fakes.NewGraphStore()
+	if err != nil {
+		t.Fatalf("NewGraphStore failed: %v", err)
+	}
+	defer graph.Close()
+
+	if err := graph.AddNode(ctx, &store.Node{ID: "n1", Name: "Node"}); err != nil {
+		t.Fatalf("AddNode failed: %v", err)
+	}
+	if err := fakes.NewVectorStore().Add(ctx, "n1", []float32{1, 0}); err != nil {
+		t.Fatalf("vector Add failed: %v", err)
+	}
+	if count, err := fakes.NewMemoryStore(graph).CountMemories(ctx); err != nil || count != 0 {
+		t.Errorf("Expected empty memory store, got %d (%v)", count, err)
+	}
+}
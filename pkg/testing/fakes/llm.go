@@ -0,0 +1,124 @@
+package fakes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/dan-solli/gognee/pkg/extraction"
+	"github.com/dan-solli/gognee/pkg/llm"
+)
+
+// LLMClient returns canned extraction results instead of calling a language model.
+// Entity and relation responses are consumed in order, one per extraction call;
+// the last response is repeated once the queue is exhausted, and an empty queue
+// yields no entities or relations. Safe for concurrent use.
+type LLMClient struct {
+	mu                sync.Mutex
+	entityResponses   [][]extraction.Entity
+	relationResponses [][]extraction.Triplet
+	completion        string
+	prompts           []string
+	err               error
+}
+
+var _ llm.LLMClient = (*LLMClient)(nil)
+
+// NewLLMClient creates a fake LLM client with no scripted responses.
+func NewLLMClient() *LLMClient {
+	return &LLMClient{}
+}
+
+// WithEntities queues an entity extraction response.
+func (c *LLMClient) WithEntities(entities ...extraction.Entity) *LLMClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entityResponses = append(c.entityResponses, entities)
+	return c
+}
+
+// WithRelations queues a relation extraction response.
+func (c *LLMClient) WithRelations(triplets ...extraction.Triplet) *LLMClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.relationResponses = append(c.relationResponses, triplets)
+	return c
+}
+
+// WithCompletion sets the text returned by Complete.
+func (c *LLMClient) WithCompletion(text string) *LLMClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.completion = text
+	return c
+}
+
+// WithError makes every subsequent call fail with err (nil clears it).
+func (c *LLMClient) WithError(err error) *LLMClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+	return c
+}
+
+// Complete returns the text set by WithCompletion.
+func (c *LLMClient) Complete(ctx context.Context, prompt string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prompts = append(c.prompts, prompt)
+	if c.err != nil {
+		return "", c.err
+	}
+	return c.completion, nil
+}
+
+// CompleteWithSchema fills schema with the next canned response for its type.
+// Supported schemas are *[]extraction.Entity and *[]extraction.Triplet; any other
+// schema is filled by unmarshalling the Complete text.
+func (c *LLMClient) CompleteWithSchema(ctx context.Context, prompt string, schema any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prompts = append(c.prompts, prompt)
+	if c.err != nil {
+		return c.err
+	}
+
+	switch s := schema.(type) {
+	case *[]extraction.Entity:
+		*s = append([]extraction.Entity{}, nextResponse(&c.entityResponses)...)
+	case *[]extraction.Triplet:
+		*s = append([]extraction.Triplet{}, nextResponse(&c.relationResponses)...)
+	default:
+		if err := json.Unmarshal([]byte(c.completion), schema); err != nil {
+			return fmt.Errorf("fake LLM cannot fill schema %T: %w", schema, err)
+		}
+	}
+	return nil
+}
+
+// Prompts returns every prompt received so far, in order.
+func (c *LLMClient) Prompts() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.prompts...)
+}
+
+// CallCount returns the number of Complete and CompleteWithSchema calls made so far.
+func (c *LLMClient) CallCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.prompts)
+}
+
+// nextResponse pops the head of queue, keeping the last element for repeated use.
+func nextResponse[T any](queue *[][]T) []T {
+	if len(*queue) == 0 {
+		return nil
+	}
+	head := (*queue)[0]
+	if len(*queue) > 1 {
+		*queue = (*queue)[1:]
+	}
+	return head
+}
@@ -0,0 +1,22 @@
+package fakes
+
+import (
+	"github.com/dan-solli/gognee/pkg/store"
+)
+
+// NewGraphStore creates an empty graph store backed by a private in-memory SQLite
+// database, with the full production schema. Close it when done.
+func NewGraphStore() (*store.SQLiteGraphStore, error) {
+	return store.NewSQLiteGraphStore(":memory:")
+}
+
+// NewVectorStore creates an empty in-memory vector store.
+func NewVectorStore() *store.MemoryVectorStore {
+	return store.NewMemoryVectorStore()
+}
+
+// NewMemoryStore creates a memory store sharing the graph store's database,
+// as gognee does internally.
+func NewMemoryStore(graphStore *store.SQLiteGraphStore) *store.SQLiteMemoryStore {
+	return store.NewSQLiteMemoryStore(graphStore.DB())
+}